@@ -121,9 +121,17 @@ func (ParticipantRole) EnumDescriptor() ([]byte, []int) {
 }
 
 type Participant struct {
-	state         protoimpl.MessageState `protogen:"open.v1"`
-	UserId        string                 `protobuf:"bytes,1,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
-	Role          ParticipantRole        `protobuf:"varint,2,opt,name=role,proto3,enum=realchat.conversation.v1.ParticipantRole" json:"role,omitempty"`
+	state  protoimpl.MessageState `protogen:"open.v1"`
+	UserId string                 `protobuf:"bytes,1,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+	Role   ParticipantRole        `protobuf:"varint,2,opt,name=role,proto3,enum=realchat.conversation.v1.ParticipantRole" json:"role,omitempty"`
+	// Highest sequence the participant has read. GetConversation may serve it
+	// from cache; ListConversations always reads it fresh.
+	LastReadSequence int64 `protobuf:"varint,3,opt,name=last_read_sequence,json=lastReadSequence,proto3" json:"last_read_sequence,omitempty"`
+	// Highest sequence delivered to at least one of the participant's devices.
+	// Cached like last_read_sequence.
+	LastDeliveredSequence int64 `protobuf:"varint,4,opt,name=last_delivered_sequence,json=lastDeliveredSequence,proto3" json:"last_delivered_sequence,omitempty"`
+	// When last_read_sequence last moved forward. Unset until the first read.
+	LastReadAt    *timestamppb.Timestamp `protobuf:"bytes,5,opt,name=last_read_at,json=lastReadAt,proto3" json:"last_read_at,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
@@ -172,6 +180,27 @@ func (x *Participant) GetRole() ParticipantRole {
 	return ParticipantRole_PARTICIPANT_ROLE_UNSPECIFIED
 }
 
+func (x *Participant) GetLastReadSequence() int64 {
+	if x != nil {
+		return x.LastReadSequence
+	}
+	return 0
+}
+
+func (x *Participant) GetLastDeliveredSequence() int64 {
+	if x != nil {
+		return x.LastDeliveredSequence
+	}
+	return 0
+}
+
+func (x *Participant) GetLastReadAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.LastReadAt
+	}
+	return nil
+}
+
 type Conversation struct {
 	state                 protoimpl.MessageState `protogen:"open.v1"`
 	ConversationId        string                 `protobuf:"bytes,1,opt,name=conversation_id,json=conversationId,proto3" json:"conversation_id,omitempty"`
@@ -181,8 +210,14 @@ type Conversation struct {
 	Type                  ConversationType       `protobuf:"varint,6,opt,name=type,proto3,enum=realchat.conversation.v1.ConversationType" json:"type,omitempty"`
 	ParticipantUserIds    []string               `protobuf:"bytes,7,rep,name=participant_user_ids,json=participantUserIds,proto3" json:"participant_user_ids,omitempty"`
 	ParticipantsWithRoles []*Participant         `protobuf:"bytes,8,rep,name=participants_with_roles,json=participantsWithRoles,proto3" json:"participants_with_roles,omitempty"`
-	unknownFields         protoimpl.UnknownFields
-	sizeCache             protoimpl.SizeCache
+	// Messages sent into the conversation disappear this many seconds after
+	// they are sent. Zero keeps them forever.
+	MessageTtlSeconds int64 `protobuf:"varint,9,opt,name=message_ttl_seconds,json=messageTtlSeconds,proto3" json:"message_ttl_seconds,omitempty"`
+	// Messages after the caller's last read sequence. Only set by
+	// ListConversations.
+	UnreadCount   int64 `protobuf:"varint,10,opt,name=unread_count,json=unreadCount,proto3" json:"unread_count,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
 }
 
 func (x *Conversation) Reset() {
@@ -264,14 +299,32 @@ func (x *Conversation) GetParticipantsWithRoles() []*Participant {
 	return nil
 }
 
+func (x *Conversation) GetMessageTtlSeconds() int64 {
+	if x != nil {
+		return x.MessageTtlSeconds
+	}
+	return 0
+}
+
+func (x *Conversation) GetUnreadCount() int64 {
+	if x != nil {
+		return x.UnreadCount
+	}
+	return 0
+}
+
 var File_conversation_v1_conversation_proto protoreflect.FileDescriptor
 
 const file_conversation_v1_conversation_proto_rawDesc = "" +
 	"\n" +
-	"\"conversation/v1/conversation.proto\x12\x18realchat.conversation.v1\x1a\x1fgoogle/protobuf/timestamp.proto\"e\n" +
+	"\"conversation/v1/conversation.proto\x12\x18realchat.conversation.v1\x1a\x1fgoogle/protobuf/timestamp.proto\"\x89\x02\n" +
 	"\vParticipant\x12\x17\n" +
 	"\auser_id\x18\x01 \x01(\tR\x06userId\x12=\n" +
-	"\x04role\x18\x02 \x01(\x0e2).realchat.conversation.v1.ParticipantRoleR\x04role\"\x95\x03\n" +
+	"\x04role\x18\x02 \x01(\x0e2).realchat.conversation.v1.ParticipantRoleR\x04role\x12,\n" +
+	"\x12last_read_sequence\x18\x03 \x01(\x03R\x10lastReadSequence\x126\n" +
+	"\x17last_delivered_sequence\x18\x04 \x01(\x03R\x15lastDeliveredSequence\x12<\n" +
+	"\flast_read_at\x18\x05 \x01(\v2\x1a.google.protobuf.TimestampR\n" +
+	"lastReadAt\"\xe8\x03\n" +
 	"\fConversation\x12'\n" +
 	"\x0fconversation_id\x18\x01 \x01(\tR\x0econversationId\x12!\n" +
 	"\fdisplay_name\x18\x03 \x01(\tR\vdisplayName\x12\x1d\n" +
@@ -281,7 +334,10 @@ const file_conversation_v1_conversation_proto_rawDesc = "" +
 	"created_at\x18\x05 \x01(\v2\x1a.google.protobuf.TimestampR\tcreatedAt\x12>\n" +
 	"\x04type\x18\x06 \x01(\x0e2*.realchat.conversation.v1.ConversationTypeR\x04type\x120\n" +
 	"\x14participant_user_ids\x18\a \x03(\tR\x12participantUserIds\x12]\n" +
-	"\x17participants_with_roles\x18\b \x03(\v2%.realchat.conversation.v1.ParticipantR\x15participantsWithRolesJ\x04\b\x02\x10\x03R\bis_group*L\n" +
+	"\x17participants_with_roles\x18\b \x03(\v2%.realchat.conversation.v1.ParticipantR\x15participantsWithRoles\x12.\n" +
+	"\x13message_ttl_seconds\x18\t \x01(\x03R\x11messageTtlSeconds\x12!\n" +
+	"\funread_count\x18\n" +
+	" \x01(\x03R\vunreadCountJ\x04\b\x02\x10\x03R\bis_group*L\n" +
 	"\x10ConversationType\x12!\n" +
 	"\x1dCONVERSATION_TYPE_UNSPECIFIED\x10\x00\x12\n" +
 	"\n" +
@@ -316,14 +372,15 @@ var file_conversation_v1_conversation_proto_goTypes = []any{
 }
 var file_conversation_v1_conversation_proto_depIdxs = []int32{
 	1, // 0: realchat.conversation.v1.Participant.role:type_name -> realchat.conversation.v1.ParticipantRole
-	4, // 1: realchat.conversation.v1.Conversation.created_at:type_name -> google.protobuf.Timestamp
-	0, // 2: realchat.conversation.v1.Conversation.type:type_name -> realchat.conversation.v1.ConversationType
-	2, // 3: realchat.conversation.v1.Conversation.participants_with_roles:type_name -> realchat.conversation.v1.Participant
-	4, // [4:4] is the sub-list for method output_type
-	4, // [4:4] is the sub-list for method input_type
-	4, // [4:4] is the sub-list for extension type_name
-	4, // [4:4] is the sub-list for extension extendee
-	0, // [0:4] is the sub-list for field type_name
+	4, // 1: realchat.conversation.v1.Participant.last_read_at:type_name -> google.protobuf.Timestamp
+	4, // 2: realchat.conversation.v1.Conversation.created_at:type_name -> google.protobuf.Timestamp
+	0, // 3: realchat.conversation.v1.Conversation.type:type_name -> realchat.conversation.v1.ConversationType
+	2, // 4: realchat.conversation.v1.Conversation.participants_with_roles:type_name -> realchat.conversation.v1.Participant
+	5, // [5:5] is the sub-list for method output_type
+	5, // [5:5] is the sub-list for method input_type
+	5, // [5:5] is the sub-list for extension type_name
+	5, // [5:5] is the sub-list for extension extendee
+	0, // [0:5] is the sub-list for field type_name
 }
 
 func init() { file_conversation_v1_conversation_proto_init() }
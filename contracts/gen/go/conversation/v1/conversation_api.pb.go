@@ -429,6 +429,209 @@ func (*UpdateReadReceiptResponse) Descriptor() ([]byte, []int) {
 	return file_conversation_v1_conversation_api_proto_rawDescGZIP(), []int{7}
 }
 
+type GetSeenByRequest struct {
+	state          protoimpl.MessageState `protogen:"open.v1"`
+	ConversationId string                 `protobuf:"bytes,1,opt,name=conversation_id,json=conversationId,proto3" json:"conversation_id,omitempty"`
+	Sequence       int64                  `protobuf:"varint,2,opt,name=sequence,proto3" json:"sequence,omitempty"`
+	unknownFields  protoimpl.UnknownFields
+	sizeCache      protoimpl.SizeCache
+}
+
+func (x *GetSeenByRequest) Reset() {
+	*x = GetSeenByRequest{}
+	mi := &file_conversation_v1_conversation_api_proto_msgTypes[8]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetSeenByRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetSeenByRequest) ProtoMessage() {}
+
+func (x *GetSeenByRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_conversation_v1_conversation_api_proto_msgTypes[8]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetSeenByRequest.ProtoReflect.Descriptor instead.
+func (*GetSeenByRequest) Descriptor() ([]byte, []int) {
+	return file_conversation_v1_conversation_api_proto_rawDescGZIP(), []int{8}
+}
+
+func (x *GetSeenByRequest) GetConversationId() string {
+	if x != nil {
+		return x.ConversationId
+	}
+	return ""
+}
+
+func (x *GetSeenByRequest) GetSequence() int64 {
+	if x != nil {
+		return x.Sequence
+	}
+	return 0
+}
+
+type GetSeenByResponse struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Participants whose last_read_sequence is at or past the sequence, most
+	// recent reader first. last_read_at is when each last moved their read
+	// sequence forward, which may be after they passed this sequence.
+	Readers       []*Participant `protobuf:"bytes,1,rep,name=readers,proto3" json:"readers,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetSeenByResponse) Reset() {
+	*x = GetSeenByResponse{}
+	mi := &file_conversation_v1_conversation_api_proto_msgTypes[9]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetSeenByResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetSeenByResponse) ProtoMessage() {}
+
+func (x *GetSeenByResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_conversation_v1_conversation_api_proto_msgTypes[9]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetSeenByResponse.ProtoReflect.Descriptor instead.
+func (*GetSeenByResponse) Descriptor() ([]byte, []int) {
+	return file_conversation_v1_conversation_api_proto_rawDescGZIP(), []int{9}
+}
+
+func (x *GetSeenByResponse) GetReaders() []*Participant {
+	if x != nil {
+		return x.Readers
+	}
+	return nil
+}
+
+type SetMessageTTLRequest struct {
+	state             protoimpl.MessageState `protogen:"open.v1"`
+	ConversationId    string                 `protobuf:"bytes,1,opt,name=conversation_id,json=conversationId,proto3" json:"conversation_id,omitempty"`
+	ActorUserId       string                 `protobuf:"bytes,2,opt,name=actor_user_id,json=actorUserId,proto3" json:"actor_user_id,omitempty"`
+	MessageTtlSeconds int64                  `protobuf:"varint,3,opt,name=message_ttl_seconds,json=messageTtlSeconds,proto3" json:"message_ttl_seconds,omitempty"`
+	unknownFields     protoimpl.UnknownFields
+	sizeCache         protoimpl.SizeCache
+}
+
+func (x *SetMessageTTLRequest) Reset() {
+	*x = SetMessageTTLRequest{}
+	mi := &file_conversation_v1_conversation_api_proto_msgTypes[10]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SetMessageTTLRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SetMessageTTLRequest) ProtoMessage() {}
+
+func (x *SetMessageTTLRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_conversation_v1_conversation_api_proto_msgTypes[10]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SetMessageTTLRequest.ProtoReflect.Descriptor instead.
+func (*SetMessageTTLRequest) Descriptor() ([]byte, []int) {
+	return file_conversation_v1_conversation_api_proto_rawDescGZIP(), []int{10}
+}
+
+func (x *SetMessageTTLRequest) GetConversationId() string {
+	if x != nil {
+		return x.ConversationId
+	}
+	return ""
+}
+
+func (x *SetMessageTTLRequest) GetActorUserId() string {
+	if x != nil {
+		return x.ActorUserId
+	}
+	return ""
+}
+
+func (x *SetMessageTTLRequest) GetMessageTtlSeconds() int64 {
+	if x != nil {
+		return x.MessageTtlSeconds
+	}
+	return 0
+}
+
+type SetMessageTTLResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Conversation  *Conversation          `protobuf:"bytes,1,opt,name=conversation,proto3" json:"conversation,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *SetMessageTTLResponse) Reset() {
+	*x = SetMessageTTLResponse{}
+	mi := &file_conversation_v1_conversation_api_proto_msgTypes[11]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SetMessageTTLResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SetMessageTTLResponse) ProtoMessage() {}
+
+func (x *SetMessageTTLResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_conversation_v1_conversation_api_proto_msgTypes[11]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SetMessageTTLResponse.ProtoReflect.Descriptor instead.
+func (*SetMessageTTLResponse) Descriptor() ([]byte, []int) {
+	return file_conversation_v1_conversation_api_proto_rawDescGZIP(), []int{11}
+}
+
+func (x *SetMessageTTLResponse) GetConversation() *Conversation {
+	if x != nil {
+		return x.Conversation
+	}
+	return nil
+}
+
 type ListConversationsRequest struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
 	UserId        string                 `protobuf:"bytes,1,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
@@ -438,7 +641,7 @@ type ListConversationsRequest struct {
 
 func (x *ListConversationsRequest) Reset() {
 	*x = ListConversationsRequest{}
-	mi := &file_conversation_v1_conversation_api_proto_msgTypes[8]
+	mi := &file_conversation_v1_conversation_api_proto_msgTypes[12]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -450,7 +653,7 @@ func (x *ListConversationsRequest) String() string {
 func (*ListConversationsRequest) ProtoMessage() {}
 
 func (x *ListConversationsRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_conversation_v1_conversation_api_proto_msgTypes[8]
+	mi := &file_conversation_v1_conversation_api_proto_msgTypes[12]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -463,7 +666,7 @@ func (x *ListConversationsRequest) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use ListConversationsRequest.ProtoReflect.Descriptor instead.
 func (*ListConversationsRequest) Descriptor() ([]byte, []int) {
-	return file_conversation_v1_conversation_api_proto_rawDescGZIP(), []int{8}
+	return file_conversation_v1_conversation_api_proto_rawDescGZIP(), []int{12}
 }
 
 func (x *ListConversationsRequest) GetUserId() string {
@@ -482,7 +685,7 @@ type ListConversationsResponse struct {
 
 func (x *ListConversationsResponse) Reset() {
 	*x = ListConversationsResponse{}
-	mi := &file_conversation_v1_conversation_api_proto_msgTypes[9]
+	mi := &file_conversation_v1_conversation_api_proto_msgTypes[13]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -494,7 +697,7 @@ func (x *ListConversationsResponse) String() string {
 func (*ListConversationsResponse) ProtoMessage() {}
 
 func (x *ListConversationsResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_conversation_v1_conversation_api_proto_msgTypes[9]
+	mi := &file_conversation_v1_conversation_api_proto_msgTypes[13]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -507,7 +710,7 @@ func (x *ListConversationsResponse) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use ListConversationsResponse.ProtoReflect.Descriptor instead.
 func (*ListConversationsResponse) Descriptor() ([]byte, []int) {
-	return file_conversation_v1_conversation_api_proto_rawDescGZIP(), []int{9}
+	return file_conversation_v1_conversation_api_proto_rawDescGZIP(), []int{13}
 }
 
 func (x *ListConversationsResponse) GetConversations() []*Conversation {
@@ -526,7 +729,7 @@ type GetConversationRequest struct {
 
 func (x *GetConversationRequest) Reset() {
 	*x = GetConversationRequest{}
-	mi := &file_conversation_v1_conversation_api_proto_msgTypes[10]
+	mi := &file_conversation_v1_conversation_api_proto_msgTypes[14]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -538,7 +741,7 @@ func (x *GetConversationRequest) String() string {
 func (*GetConversationRequest) ProtoMessage() {}
 
 func (x *GetConversationRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_conversation_v1_conversation_api_proto_msgTypes[10]
+	mi := &file_conversation_v1_conversation_api_proto_msgTypes[14]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -551,7 +754,7 @@ func (x *GetConversationRequest) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use GetConversationRequest.ProtoReflect.Descriptor instead.
 func (*GetConversationRequest) Descriptor() ([]byte, []int) {
-	return file_conversation_v1_conversation_api_proto_rawDescGZIP(), []int{10}
+	return file_conversation_v1_conversation_api_proto_rawDescGZIP(), []int{14}
 }
 
 func (x *GetConversationRequest) GetConversationId() string {
@@ -571,7 +774,7 @@ type GetConversationResponse struct {
 
 func (x *GetConversationResponse) Reset() {
 	*x = GetConversationResponse{}
-	mi := &file_conversation_v1_conversation_api_proto_msgTypes[11]
+	mi := &file_conversation_v1_conversation_api_proto_msgTypes[15]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -583,7 +786,7 @@ func (x *GetConversationResponse) String() string {
 func (*GetConversationResponse) ProtoMessage() {}
 
 func (x *GetConversationResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_conversation_v1_conversation_api_proto_msgTypes[11]
+	mi := &file_conversation_v1_conversation_api_proto_msgTypes[15]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -596,7 +799,7 @@ func (x *GetConversationResponse) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use GetConversationResponse.ProtoReflect.Descriptor instead.
 func (*GetConversationResponse) Descriptor() ([]byte, []int) {
-	return file_conversation_v1_conversation_api_proto_rawDescGZIP(), []int{11}
+	return file_conversation_v1_conversation_api_proto_rawDescGZIP(), []int{15}
 }
 
 func (x *GetConversationResponse) GetConversation() *Conversation {
@@ -613,28 +816,34 @@ func (x *GetConversationResponse) GetParticipantUserIds() []string {
 	return nil
 }
 
-type NextSequenceRequest struct {
+type ReserveSequencesRequest struct {
 	state          protoimpl.MessageState `protogen:"open.v1"`
 	ConversationId string                 `protobuf:"bytes,1,opt,name=conversation_id,json=conversationId,proto3" json:"conversation_id,omitempty"`
-	unknownFields  protoimpl.UnknownFields
-	sizeCache      protoimpl.SizeCache
+	// Last sequence of the caller's previous block, or 0 when it holds none.
+	// Retrying with the same value returns the same block, so a block lost to
+	// a rolled-back transaction is handed out again. A request with 0 always
+	// gets a fresh block past everything reserved so far.
+	AfterSequence int64 `protobuf:"varint,2,opt,name=after_sequence,json=afterSequence,proto3" json:"after_sequence,omitempty"`
+	Count         int64 `protobuf:"varint,3,opt,name=count,proto3" json:"count,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
 }
 
-func (x *NextSequenceRequest) Reset() {
-	*x = NextSequenceRequest{}
-	mi := &file_conversation_v1_conversation_api_proto_msgTypes[12]
+func (x *ReserveSequencesRequest) Reset() {
+	*x = ReserveSequencesRequest{}
+	mi := &file_conversation_v1_conversation_api_proto_msgTypes[16]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *NextSequenceRequest) String() string {
+func (x *ReserveSequencesRequest) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*NextSequenceRequest) ProtoMessage() {}
+func (*ReserveSequencesRequest) ProtoMessage() {}
 
-func (x *NextSequenceRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_conversation_v1_conversation_api_proto_msgTypes[12]
+func (x *ReserveSequencesRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_conversation_v1_conversation_api_proto_msgTypes[16]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -645,40 +854,175 @@ func (x *NextSequenceRequest) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use NextSequenceRequest.ProtoReflect.Descriptor instead.
-func (*NextSequenceRequest) Descriptor() ([]byte, []int) {
-	return file_conversation_v1_conversation_api_proto_rawDescGZIP(), []int{12}
+// Deprecated: Use ReserveSequencesRequest.ProtoReflect.Descriptor instead.
+func (*ReserveSequencesRequest) Descriptor() ([]byte, []int) {
+	return file_conversation_v1_conversation_api_proto_rawDescGZIP(), []int{16}
+}
+
+func (x *ReserveSequencesRequest) GetConversationId() string {
+	if x != nil {
+		return x.ConversationId
+	}
+	return ""
+}
+
+func (x *ReserveSequencesRequest) GetAfterSequence() int64 {
+	if x != nil {
+		return x.AfterSequence
+	}
+	return 0
 }
 
-func (x *NextSequenceRequest) GetConversationId() string {
+func (x *ReserveSequencesRequest) GetCount() int64 {
+	if x != nil {
+		return x.Count
+	}
+	return 0
+}
+
+type ReserveSequencesResponse struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// The reserved block is first_sequence..last_sequence inclusive.
+	FirstSequence int64 `protobuf:"varint,1,opt,name=first_sequence,json=firstSequence,proto3" json:"first_sequence,omitempty"`
+	LastSequence  int64 `protobuf:"varint,2,opt,name=last_sequence,json=lastSequence,proto3" json:"last_sequence,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ReserveSequencesResponse) Reset() {
+	*x = ReserveSequencesResponse{}
+	mi := &file_conversation_v1_conversation_api_proto_msgTypes[17]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ReserveSequencesResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ReserveSequencesResponse) ProtoMessage() {}
+
+func (x *ReserveSequencesResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_conversation_v1_conversation_api_proto_msgTypes[17]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ReserveSequencesResponse.ProtoReflect.Descriptor instead.
+func (*ReserveSequencesResponse) Descriptor() ([]byte, []int) {
+	return file_conversation_v1_conversation_api_proto_rawDescGZIP(), []int{17}
+}
+
+func (x *ReserveSequencesResponse) GetFirstSequence() int64 {
+	if x != nil {
+		return x.FirstSequence
+	}
+	return 0
+}
+
+func (x *ReserveSequencesResponse) GetLastSequence() int64 {
+	if x != nil {
+		return x.LastSequence
+	}
+	return 0
+}
+
+type DeliveryReceipt struct {
+	state             protoimpl.MessageState `protogen:"open.v1"`
+	ConversationId    string                 `protobuf:"bytes,1,opt,name=conversation_id,json=conversationId,proto3" json:"conversation_id,omitempty"`
+	UserId            string                 `protobuf:"bytes,2,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+	DeviceId          string                 `protobuf:"bytes,3,opt,name=device_id,json=deviceId,proto3" json:"device_id,omitempty"`
+	DeliveredSequence int64                  `protobuf:"varint,4,opt,name=delivered_sequence,json=deliveredSequence,proto3" json:"delivered_sequence,omitempty"`
+	unknownFields     protoimpl.UnknownFields
+	sizeCache         protoimpl.SizeCache
+}
+
+func (x *DeliveryReceipt) Reset() {
+	*x = DeliveryReceipt{}
+	mi := &file_conversation_v1_conversation_api_proto_msgTypes[18]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *DeliveryReceipt) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DeliveryReceipt) ProtoMessage() {}
+
+func (x *DeliveryReceipt) ProtoReflect() protoreflect.Message {
+	mi := &file_conversation_v1_conversation_api_proto_msgTypes[18]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DeliveryReceipt.ProtoReflect.Descriptor instead.
+func (*DeliveryReceipt) Descriptor() ([]byte, []int) {
+	return file_conversation_v1_conversation_api_proto_rawDescGZIP(), []int{18}
+}
+
+func (x *DeliveryReceipt) GetConversationId() string {
 	if x != nil {
 		return x.ConversationId
 	}
 	return ""
 }
 
-type NextSequenceResponse struct {
+func (x *DeliveryReceipt) GetUserId() string {
+	if x != nil {
+		return x.UserId
+	}
+	return ""
+}
+
+func (x *DeliveryReceipt) GetDeviceId() string {
+	if x != nil {
+		return x.DeviceId
+	}
+	return ""
+}
+
+func (x *DeliveryReceipt) GetDeliveredSequence() int64 {
+	if x != nil {
+		return x.DeliveredSequence
+	}
+	return 0
+}
+
+type RecordDeliveryReceiptsRequest struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
-	Sequence      int64                  `protobuf:"varint,1,opt,name=sequence,proto3" json:"sequence,omitempty"`
+	Receipts      []*DeliveryReceipt     `protobuf:"bytes,1,rep,name=receipts,proto3" json:"receipts,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *NextSequenceResponse) Reset() {
-	*x = NextSequenceResponse{}
-	mi := &file_conversation_v1_conversation_api_proto_msgTypes[13]
+func (x *RecordDeliveryReceiptsRequest) Reset() {
+	*x = RecordDeliveryReceiptsRequest{}
+	mi := &file_conversation_v1_conversation_api_proto_msgTypes[19]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *NextSequenceResponse) String() string {
+func (x *RecordDeliveryReceiptsRequest) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*NextSequenceResponse) ProtoMessage() {}
+func (*RecordDeliveryReceiptsRequest) ProtoMessage() {}
 
-func (x *NextSequenceResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_conversation_v1_conversation_api_proto_msgTypes[13]
+func (x *RecordDeliveryReceiptsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_conversation_v1_conversation_api_proto_msgTypes[19]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -689,18 +1033,143 @@ func (x *NextSequenceResponse) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use NextSequenceResponse.ProtoReflect.Descriptor instead.
-func (*NextSequenceResponse) Descriptor() ([]byte, []int) {
-	return file_conversation_v1_conversation_api_proto_rawDescGZIP(), []int{13}
+// Deprecated: Use RecordDeliveryReceiptsRequest.ProtoReflect.Descriptor instead.
+func (*RecordDeliveryReceiptsRequest) Descriptor() ([]byte, []int) {
+	return file_conversation_v1_conversation_api_proto_rawDescGZIP(), []int{19}
+}
+
+func (x *RecordDeliveryReceiptsRequest) GetReceipts() []*DeliveryReceipt {
+	if x != nil {
+		return x.Receipts
+	}
+	return nil
+}
+
+type RecordDeliveryReceiptsResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
 }
 
-func (x *NextSequenceResponse) GetSequence() int64 {
+func (x *RecordDeliveryReceiptsResponse) Reset() {
+	*x = RecordDeliveryReceiptsResponse{}
+	mi := &file_conversation_v1_conversation_api_proto_msgTypes[20]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *RecordDeliveryReceiptsResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RecordDeliveryReceiptsResponse) ProtoMessage() {}
+
+func (x *RecordDeliveryReceiptsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_conversation_v1_conversation_api_proto_msgTypes[20]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RecordDeliveryReceiptsResponse.ProtoReflect.Descriptor instead.
+func (*RecordDeliveryReceiptsResponse) Descriptor() ([]byte, []int) {
+	return file_conversation_v1_conversation_api_proto_rawDescGZIP(), []int{20}
+}
+
+type RecordMessageSequenceRequest struct {
+	state          protoimpl.MessageState `protogen:"open.v1"`
+	ConversationId string                 `protobuf:"bytes,1,opt,name=conversation_id,json=conversationId,proto3" json:"conversation_id,omitempty"`
+	// Sequence of the latest message written.
+	Sequence      int64 `protobuf:"varint,2,opt,name=sequence,proto3" json:"sequence,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *RecordMessageSequenceRequest) Reset() {
+	*x = RecordMessageSequenceRequest{}
+	mi := &file_conversation_v1_conversation_api_proto_msgTypes[21]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *RecordMessageSequenceRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RecordMessageSequenceRequest) ProtoMessage() {}
+
+func (x *RecordMessageSequenceRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_conversation_v1_conversation_api_proto_msgTypes[21]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RecordMessageSequenceRequest.ProtoReflect.Descriptor instead.
+func (*RecordMessageSequenceRequest) Descriptor() ([]byte, []int) {
+	return file_conversation_v1_conversation_api_proto_rawDescGZIP(), []int{21}
+}
+
+func (x *RecordMessageSequenceRequest) GetConversationId() string {
+	if x != nil {
+		return x.ConversationId
+	}
+	return ""
+}
+
+func (x *RecordMessageSequenceRequest) GetSequence() int64 {
 	if x != nil {
 		return x.Sequence
 	}
 	return 0
 }
 
+type RecordMessageSequenceResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *RecordMessageSequenceResponse) Reset() {
+	*x = RecordMessageSequenceResponse{}
+	mi := &file_conversation_v1_conversation_api_proto_msgTypes[22]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *RecordMessageSequenceResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RecordMessageSequenceResponse) ProtoMessage() {}
+
+func (x *RecordMessageSequenceResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_conversation_v1_conversation_api_proto_msgTypes[22]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RecordMessageSequenceResponse.ProtoReflect.Descriptor instead.
+func (*RecordMessageSequenceResponse) Descriptor() ([]byte, []int) {
+	return file_conversation_v1_conversation_api_proto_rawDescGZIP(), []int{22}
+}
+
 var File_conversation_v1_conversation_api_proto protoreflect.FileDescriptor
 
 const file_conversation_v1_conversation_api_proto_rawDesc = "" +
@@ -729,7 +1198,18 @@ const file_conversation_v1_conversation_api_proto_rawDesc = "" +
 	"\x0fconversation_id\x18\x01 \x01(\tR\x0econversationId\x12\x17\n" +
 	"\auser_id\x18\x02 \x01(\tR\x06userId\x12#\n" +
 	"\rread_sequence\x18\x03 \x01(\x03R\freadSequence\"\x1b\n" +
-	"\x19UpdateReadReceiptResponse\"3\n" +
+	"\x19UpdateReadReceiptResponse\"W\n" +
+	"\x10GetSeenByRequest\x12'\n" +
+	"\x0fconversation_id\x18\x01 \x01(\tR\x0econversationId\x12\x1a\n" +
+	"\bsequence\x18\x02 \x01(\x03R\bsequence\"T\n" +
+	"\x11GetSeenByResponse\x12?\n" +
+	"\areaders\x18\x01 \x03(\v2%.realchat.conversation.v1.ParticipantR\areaders\"\x93\x01\n" +
+	"\x14SetMessageTTLRequest\x12'\n" +
+	"\x0fconversation_id\x18\x01 \x01(\tR\x0econversationId\x12\"\n" +
+	"\ractor_user_id\x18\x02 \x01(\tR\vactorUserId\x12.\n" +
+	"\x13message_ttl_seconds\x18\x03 \x01(\x03R\x11messageTtlSeconds\"c\n" +
+	"\x15SetMessageTTLResponse\x12J\n" +
+	"\fconversation\x18\x01 \x01(\v2&.realchat.conversation.v1.ConversationR\fconversation\"3\n" +
 	"\x18ListConversationsRequest\x12\x17\n" +
 	"\auser_id\x18\x01 \x01(\tR\x06userId\"i\n" +
 	"\x19ListConversationsResponse\x12L\n" +
@@ -738,19 +1218,39 @@ const file_conversation_v1_conversation_api_proto_rawDesc = "" +
 	"\x0fconversation_id\x18\x01 \x01(\tR\x0econversationId\"\x97\x01\n" +
 	"\x17GetConversationResponse\x12J\n" +
 	"\fconversation\x18\x01 \x01(\v2&.realchat.conversation.v1.ConversationR\fconversation\x120\n" +
-	"\x14participant_user_ids\x18\x02 \x03(\tR\x12participantUserIds\">\n" +
-	"\x13NextSequenceRequest\x12'\n" +
-	"\x0fconversation_id\x18\x01 \x01(\tR\x0econversationId\"2\n" +
-	"\x14NextSequenceResponse\x12\x1a\n" +
-	"\bsequence\x18\x01 \x01(\x03R\bsequence2\xe8\x06\n" +
+	"\x14participant_user_ids\x18\x02 \x03(\tR\x12participantUserIds\"\x7f\n" +
+	"\x17ReserveSequencesRequest\x12'\n" +
+	"\x0fconversation_id\x18\x01 \x01(\tR\x0econversationId\x12%\n" +
+	"\x0eafter_sequence\x18\x02 \x01(\x03R\rafterSequence\x12\x14\n" +
+	"\x05count\x18\x03 \x01(\x03R\x05count\"f\n" +
+	"\x18ReserveSequencesResponse\x12%\n" +
+	"\x0efirst_sequence\x18\x01 \x01(\x03R\rfirstSequence\x12#\n" +
+	"\rlast_sequence\x18\x02 \x01(\x03R\flastSequence\"\x9f\x01\n" +
+	"\x0fDeliveryReceipt\x12'\n" +
+	"\x0fconversation_id\x18\x01 \x01(\tR\x0econversationId\x12\x17\n" +
+	"\auser_id\x18\x02 \x01(\tR\x06userId\x12\x1b\n" +
+	"\tdevice_id\x18\x03 \x01(\tR\bdeviceId\x12-\n" +
+	"\x12delivered_sequence\x18\x04 \x01(\x03R\x11deliveredSequence\"f\n" +
+	"\x1dRecordDeliveryReceiptsRequest\x12E\n" +
+	"\breceipts\x18\x01 \x03(\v2).realchat.conversation.v1.DeliveryReceiptR\breceipts\" \n" +
+	"\x1eRecordDeliveryReceiptsResponse\"c\n" +
+	"\x1cRecordMessageSequenceRequest\x12'\n" +
+	"\x0fconversation_id\x18\x01 \x01(\tR\x0econversationId\x12\x1a\n" +
+	"\bsequence\x18\x02 \x01(\x03R\bsequence\"\x1f\n" +
+	"\x1dRecordMessageSequenceResponse2\xe5\n" +
+	"\n" +
 	"\x0fConversationApi\x12\x7f\n" +
 	"\x12CreateConversation\x123.realchat.conversation.v1.CreateConversationRequest\x1a4.realchat.conversation.v1.CreateConversationResponse\x12|\n" +
 	"\x11ListConversations\x122.realchat.conversation.v1.ListConversationsRequest\x1a3.realchat.conversation.v1.ListConversationsResponse\x12v\n" +
 	"\x0fGetConversation\x120.realchat.conversation.v1.GetConversationRequest\x1a1.realchat.conversation.v1.GetConversationResponse\x12s\n" +
 	"\x0eAddParticipant\x12/.realchat.conversation.v1.AddParticipantRequest\x1a0.realchat.conversation.v1.AddParticipantResponse\x12|\n" +
 	"\x11RemoveParticipant\x122.realchat.conversation.v1.RemoveParticipantRequest\x1a3.realchat.conversation.v1.RemoveParticipantResponse\x12|\n" +
-	"\x11UpdateReadReceipt\x122.realchat.conversation.v1.UpdateReadReceiptRequest\x1a3.realchat.conversation.v1.UpdateReadReceiptResponse\x12m\n" +
-	"\fNextSequence\x12-.realchat.conversation.v1.NextSequenceRequest\x1a..realchat.conversation.v1.NextSequenceResponseBXZVgithub.com/SARVESHVARADKAR123/RealChat/contracts/gen/go/conversation/v1;conversationv1b\x06proto3"
+	"\x11UpdateReadReceipt\x122.realchat.conversation.v1.UpdateReadReceiptRequest\x1a3.realchat.conversation.v1.UpdateReadReceiptResponse\x12d\n" +
+	"\tGetSeenBy\x12*.realchat.conversation.v1.GetSeenByRequest\x1a+.realchat.conversation.v1.GetSeenByResponse\x12p\n" +
+	"\rSetMessageTTL\x12..realchat.conversation.v1.SetMessageTTLRequest\x1a/.realchat.conversation.v1.SetMessageTTLResponse\x12y\n" +
+	"\x10ReserveSequences\x121.realchat.conversation.v1.ReserveSequencesRequest\x1a2.realchat.conversation.v1.ReserveSequencesResponse\x12\x8b\x01\n" +
+	"\x16RecordDeliveryReceipts\x127.realchat.conversation.v1.RecordDeliveryReceiptsRequest\x1a8.realchat.conversation.v1.RecordDeliveryReceiptsResponse\x12\x88\x01\n" +
+	"\x15RecordMessageSequence\x126.realchat.conversation.v1.RecordMessageSequenceRequest\x1a7.realchat.conversation.v1.RecordMessageSequenceResponseBXZVgithub.com/SARVESHVARADKAR123/RealChat/contracts/gen/go/conversation/v1;conversationv1b\x06proto3"
 
 var (
 	file_conversation_v1_conversation_api_proto_rawDescOnce sync.Once
@@ -764,49 +1264,70 @@ func file_conversation_v1_conversation_api_proto_rawDescGZIP() []byte {
 	return file_conversation_v1_conversation_api_proto_rawDescData
 }
 
-var file_conversation_v1_conversation_api_proto_msgTypes = make([]protoimpl.MessageInfo, 14)
+var file_conversation_v1_conversation_api_proto_msgTypes = make([]protoimpl.MessageInfo, 23)
 var file_conversation_v1_conversation_api_proto_goTypes = []any{
-	(*CreateConversationRequest)(nil),  // 0: realchat.conversation.v1.CreateConversationRequest
-	(*CreateConversationResponse)(nil), // 1: realchat.conversation.v1.CreateConversationResponse
-	(*AddParticipantRequest)(nil),      // 2: realchat.conversation.v1.AddParticipantRequest
-	(*AddParticipantResponse)(nil),     // 3: realchat.conversation.v1.AddParticipantResponse
-	(*RemoveParticipantRequest)(nil),   // 4: realchat.conversation.v1.RemoveParticipantRequest
-	(*RemoveParticipantResponse)(nil),  // 5: realchat.conversation.v1.RemoveParticipantResponse
-	(*UpdateReadReceiptRequest)(nil),   // 6: realchat.conversation.v1.UpdateReadReceiptRequest
-	(*UpdateReadReceiptResponse)(nil),  // 7: realchat.conversation.v1.UpdateReadReceiptResponse
-	(*ListConversationsRequest)(nil),   // 8: realchat.conversation.v1.ListConversationsRequest
-	(*ListConversationsResponse)(nil),  // 9: realchat.conversation.v1.ListConversationsResponse
-	(*GetConversationRequest)(nil),     // 10: realchat.conversation.v1.GetConversationRequest
-	(*GetConversationResponse)(nil),    // 11: realchat.conversation.v1.GetConversationResponse
-	(*NextSequenceRequest)(nil),        // 12: realchat.conversation.v1.NextSequenceRequest
-	(*NextSequenceResponse)(nil),       // 13: realchat.conversation.v1.NextSequenceResponse
-	(ConversationType)(0),              // 14: realchat.conversation.v1.ConversationType
-	(*Conversation)(nil),               // 15: realchat.conversation.v1.Conversation
+	(*CreateConversationRequest)(nil),      // 0: realchat.conversation.v1.CreateConversationRequest
+	(*CreateConversationResponse)(nil),     // 1: realchat.conversation.v1.CreateConversationResponse
+	(*AddParticipantRequest)(nil),          // 2: realchat.conversation.v1.AddParticipantRequest
+	(*AddParticipantResponse)(nil),         // 3: realchat.conversation.v1.AddParticipantResponse
+	(*RemoveParticipantRequest)(nil),       // 4: realchat.conversation.v1.RemoveParticipantRequest
+	(*RemoveParticipantResponse)(nil),      // 5: realchat.conversation.v1.RemoveParticipantResponse
+	(*UpdateReadReceiptRequest)(nil),       // 6: realchat.conversation.v1.UpdateReadReceiptRequest
+	(*UpdateReadReceiptResponse)(nil),      // 7: realchat.conversation.v1.UpdateReadReceiptResponse
+	(*GetSeenByRequest)(nil),               // 8: realchat.conversation.v1.GetSeenByRequest
+	(*GetSeenByResponse)(nil),              // 9: realchat.conversation.v1.GetSeenByResponse
+	(*SetMessageTTLRequest)(nil),           // 10: realchat.conversation.v1.SetMessageTTLRequest
+	(*SetMessageTTLResponse)(nil),          // 11: realchat.conversation.v1.SetMessageTTLResponse
+	(*ListConversationsRequest)(nil),       // 12: realchat.conversation.v1.ListConversationsRequest
+	(*ListConversationsResponse)(nil),      // 13: realchat.conversation.v1.ListConversationsResponse
+	(*GetConversationRequest)(nil),         // 14: realchat.conversation.v1.GetConversationRequest
+	(*GetConversationResponse)(nil),        // 15: realchat.conversation.v1.GetConversationResponse
+	(*ReserveSequencesRequest)(nil),        // 16: realchat.conversation.v1.ReserveSequencesRequest
+	(*ReserveSequencesResponse)(nil),       // 17: realchat.conversation.v1.ReserveSequencesResponse
+	(*DeliveryReceipt)(nil),                // 18: realchat.conversation.v1.DeliveryReceipt
+	(*RecordDeliveryReceiptsRequest)(nil),  // 19: realchat.conversation.v1.RecordDeliveryReceiptsRequest
+	(*RecordDeliveryReceiptsResponse)(nil), // 20: realchat.conversation.v1.RecordDeliveryReceiptsResponse
+	(*RecordMessageSequenceRequest)(nil),   // 21: realchat.conversation.v1.RecordMessageSequenceRequest
+	(*RecordMessageSequenceResponse)(nil),  // 22: realchat.conversation.v1.RecordMessageSequenceResponse
+	(ConversationType)(0),                  // 23: realchat.conversation.v1.ConversationType
+	(*Conversation)(nil),                   // 24: realchat.conversation.v1.Conversation
+	(*Participant)(nil),                    // 25: realchat.conversation.v1.Participant
 }
 var file_conversation_v1_conversation_api_proto_depIdxs = []int32{
-	14, // 0: realchat.conversation.v1.CreateConversationRequest.type:type_name -> realchat.conversation.v1.ConversationType
-	15, // 1: realchat.conversation.v1.CreateConversationResponse.conversation:type_name -> realchat.conversation.v1.Conversation
-	15, // 2: realchat.conversation.v1.ListConversationsResponse.conversations:type_name -> realchat.conversation.v1.Conversation
-	15, // 3: realchat.conversation.v1.GetConversationResponse.conversation:type_name -> realchat.conversation.v1.Conversation
-	0,  // 4: realchat.conversation.v1.ConversationApi.CreateConversation:input_type -> realchat.conversation.v1.CreateConversationRequest
-	8,  // 5: realchat.conversation.v1.ConversationApi.ListConversations:input_type -> realchat.conversation.v1.ListConversationsRequest
-	10, // 6: realchat.conversation.v1.ConversationApi.GetConversation:input_type -> realchat.conversation.v1.GetConversationRequest
-	2,  // 7: realchat.conversation.v1.ConversationApi.AddParticipant:input_type -> realchat.conversation.v1.AddParticipantRequest
-	4,  // 8: realchat.conversation.v1.ConversationApi.RemoveParticipant:input_type -> realchat.conversation.v1.RemoveParticipantRequest
-	6,  // 9: realchat.conversation.v1.ConversationApi.UpdateReadReceipt:input_type -> realchat.conversation.v1.UpdateReadReceiptRequest
-	12, // 10: realchat.conversation.v1.ConversationApi.NextSequence:input_type -> realchat.conversation.v1.NextSequenceRequest
-	1,  // 11: realchat.conversation.v1.ConversationApi.CreateConversation:output_type -> realchat.conversation.v1.CreateConversationResponse
-	9,  // 12: realchat.conversation.v1.ConversationApi.ListConversations:output_type -> realchat.conversation.v1.ListConversationsResponse
-	11, // 13: realchat.conversation.v1.ConversationApi.GetConversation:output_type -> realchat.conversation.v1.GetConversationResponse
-	3,  // 14: realchat.conversation.v1.ConversationApi.AddParticipant:output_type -> realchat.conversation.v1.AddParticipantResponse
-	5,  // 15: realchat.conversation.v1.ConversationApi.RemoveParticipant:output_type -> realchat.conversation.v1.RemoveParticipantResponse
-	7,  // 16: realchat.conversation.v1.ConversationApi.UpdateReadReceipt:output_type -> realchat.conversation.v1.UpdateReadReceiptResponse
-	13, // 17: realchat.conversation.v1.ConversationApi.NextSequence:output_type -> realchat.conversation.v1.NextSequenceResponse
-	11, // [11:18] is the sub-list for method output_type
-	4,  // [4:11] is the sub-list for method input_type
-	4,  // [4:4] is the sub-list for extension type_name
-	4,  // [4:4] is the sub-list for extension extendee
-	0,  // [0:4] is the sub-list for field type_name
+	23, // 0: realchat.conversation.v1.CreateConversationRequest.type:type_name -> realchat.conversation.v1.ConversationType
+	24, // 1: realchat.conversation.v1.CreateConversationResponse.conversation:type_name -> realchat.conversation.v1.Conversation
+	25, // 2: realchat.conversation.v1.GetSeenByResponse.readers:type_name -> realchat.conversation.v1.Participant
+	24, // 3: realchat.conversation.v1.SetMessageTTLResponse.conversation:type_name -> realchat.conversation.v1.Conversation
+	24, // 4: realchat.conversation.v1.ListConversationsResponse.conversations:type_name -> realchat.conversation.v1.Conversation
+	24, // 5: realchat.conversation.v1.GetConversationResponse.conversation:type_name -> realchat.conversation.v1.Conversation
+	18, // 6: realchat.conversation.v1.RecordDeliveryReceiptsRequest.receipts:type_name -> realchat.conversation.v1.DeliveryReceipt
+	0,  // 7: realchat.conversation.v1.ConversationApi.CreateConversation:input_type -> realchat.conversation.v1.CreateConversationRequest
+	12, // 8: realchat.conversation.v1.ConversationApi.ListConversations:input_type -> realchat.conversation.v1.ListConversationsRequest
+	14, // 9: realchat.conversation.v1.ConversationApi.GetConversation:input_type -> realchat.conversation.v1.GetConversationRequest
+	2,  // 10: realchat.conversation.v1.ConversationApi.AddParticipant:input_type -> realchat.conversation.v1.AddParticipantRequest
+	4,  // 11: realchat.conversation.v1.ConversationApi.RemoveParticipant:input_type -> realchat.conversation.v1.RemoveParticipantRequest
+	6,  // 12: realchat.conversation.v1.ConversationApi.UpdateReadReceipt:input_type -> realchat.conversation.v1.UpdateReadReceiptRequest
+	8,  // 13: realchat.conversation.v1.ConversationApi.GetSeenBy:input_type -> realchat.conversation.v1.GetSeenByRequest
+	10, // 14: realchat.conversation.v1.ConversationApi.SetMessageTTL:input_type -> realchat.conversation.v1.SetMessageTTLRequest
+	16, // 15: realchat.conversation.v1.ConversationApi.ReserveSequences:input_type -> realchat.conversation.v1.ReserveSequencesRequest
+	19, // 16: realchat.conversation.v1.ConversationApi.RecordDeliveryReceipts:input_type -> realchat.conversation.v1.RecordDeliveryReceiptsRequest
+	21, // 17: realchat.conversation.v1.ConversationApi.RecordMessageSequence:input_type -> realchat.conversation.v1.RecordMessageSequenceRequest
+	1,  // 18: realchat.conversation.v1.ConversationApi.CreateConversation:output_type -> realchat.conversation.v1.CreateConversationResponse
+	13, // 19: realchat.conversation.v1.ConversationApi.ListConversations:output_type -> realchat.conversation.v1.ListConversationsResponse
+	15, // 20: realchat.conversation.v1.ConversationApi.GetConversation:output_type -> realchat.conversation.v1.GetConversationResponse
+	3,  // 21: realchat.conversation.v1.ConversationApi.AddParticipant:output_type -> realchat.conversation.v1.AddParticipantResponse
+	5,  // 22: realchat.conversation.v1.ConversationApi.RemoveParticipant:output_type -> realchat.conversation.v1.RemoveParticipantResponse
+	7,  // 23: realchat.conversation.v1.ConversationApi.UpdateReadReceipt:output_type -> realchat.conversation.v1.UpdateReadReceiptResponse
+	9,  // 24: realchat.conversation.v1.ConversationApi.GetSeenBy:output_type -> realchat.conversation.v1.GetSeenByResponse
+	11, // 25: realchat.conversation.v1.ConversationApi.SetMessageTTL:output_type -> realchat.conversation.v1.SetMessageTTLResponse
+	17, // 26: realchat.conversation.v1.ConversationApi.ReserveSequences:output_type -> realchat.conversation.v1.ReserveSequencesResponse
+	20, // 27: realchat.conversation.v1.ConversationApi.RecordDeliveryReceipts:output_type -> realchat.conversation.v1.RecordDeliveryReceiptsResponse
+	22, // 28: realchat.conversation.v1.ConversationApi.RecordMessageSequence:output_type -> realchat.conversation.v1.RecordMessageSequenceResponse
+	18, // [18:29] is the sub-list for method output_type
+	7,  // [7:18] is the sub-list for method input_type
+	7,  // [7:7] is the sub-list for extension type_name
+	7,  // [7:7] is the sub-list for extension extendee
+	0,  // [0:7] is the sub-list for field type_name
 }
 
 func init() { file_conversation_v1_conversation_api_proto_init() }
@@ -821,7 +1342,7 @@ func file_conversation_v1_conversation_api_proto_init() {
 			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
 			RawDescriptor: unsafe.Slice(unsafe.StringData(file_conversation_v1_conversation_api_proto_rawDesc), len(file_conversation_v1_conversation_api_proto_rawDesc)),
 			NumEnums:      0,
-			NumMessages:   14,
+			NumMessages:   23,
 			NumExtensions: 0,
 			NumServices:   1,
 		},
@@ -19,13 +19,17 @@ import (
 const _ = grpc.SupportPackageIsVersion9
 
 const (
-	ConversationApi_CreateConversation_FullMethodName = "/realchat.conversation.v1.ConversationApi/CreateConversation"
-	ConversationApi_ListConversations_FullMethodName  = "/realchat.conversation.v1.ConversationApi/ListConversations"
-	ConversationApi_GetConversation_FullMethodName    = "/realchat.conversation.v1.ConversationApi/GetConversation"
-	ConversationApi_AddParticipant_FullMethodName     = "/realchat.conversation.v1.ConversationApi/AddParticipant"
-	ConversationApi_RemoveParticipant_FullMethodName  = "/realchat.conversation.v1.ConversationApi/RemoveParticipant"
-	ConversationApi_UpdateReadReceipt_FullMethodName  = "/realchat.conversation.v1.ConversationApi/UpdateReadReceipt"
-	ConversationApi_NextSequence_FullMethodName       = "/realchat.conversation.v1.ConversationApi/NextSequence"
+	ConversationApi_CreateConversation_FullMethodName     = "/realchat.conversation.v1.ConversationApi/CreateConversation"
+	ConversationApi_ListConversations_FullMethodName      = "/realchat.conversation.v1.ConversationApi/ListConversations"
+	ConversationApi_GetConversation_FullMethodName        = "/realchat.conversation.v1.ConversationApi/GetConversation"
+	ConversationApi_AddParticipant_FullMethodName         = "/realchat.conversation.v1.ConversationApi/AddParticipant"
+	ConversationApi_RemoveParticipant_FullMethodName      = "/realchat.conversation.v1.ConversationApi/RemoveParticipant"
+	ConversationApi_UpdateReadReceipt_FullMethodName      = "/realchat.conversation.v1.ConversationApi/UpdateReadReceipt"
+	ConversationApi_GetSeenBy_FullMethodName              = "/realchat.conversation.v1.ConversationApi/GetSeenBy"
+	ConversationApi_SetMessageTTL_FullMethodName          = "/realchat.conversation.v1.ConversationApi/SetMessageTTL"
+	ConversationApi_ReserveSequences_FullMethodName       = "/realchat.conversation.v1.ConversationApi/ReserveSequences"
+	ConversationApi_RecordDeliveryReceipts_FullMethodName = "/realchat.conversation.v1.ConversationApi/RecordDeliveryReceipts"
+	ConversationApi_RecordMessageSequence_FullMethodName  = "/realchat.conversation.v1.ConversationApi/RecordMessageSequence"
 )
 
 // ConversationApiClient is the client API for ConversationApi service.
@@ -38,9 +42,21 @@ type ConversationApiClient interface {
 	AddParticipant(ctx context.Context, in *AddParticipantRequest, opts ...grpc.CallOption) (*AddParticipantResponse, error)
 	RemoveParticipant(ctx context.Context, in *RemoveParticipantRequest, opts ...grpc.CallOption) (*RemoveParticipantResponse, error)
 	UpdateReadReceipt(ctx context.Context, in *UpdateReadReceiptRequest, opts ...grpc.CallOption) (*UpdateReadReceiptResponse, error)
-	// NextSequence atomically increments and returns the next message sequence
-	// number for a conversation. Called by the message service when sending a message.
-	NextSequence(ctx context.Context, in *NextSequenceRequest, opts ...grpc.CallOption) (*NextSequenceResponse, error)
+	// GetSeenBy lists the participants who have read up to a sequence.
+	GetSeenBy(ctx context.Context, in *GetSeenByRequest, opts ...grpc.CallOption) (*GetSeenByResponse, error)
+	// SetMessageTTL turns disappearing messages on or off. Only messages sent
+	// afterwards are affected.
+	SetMessageTTL(ctx context.Context, in *SetMessageTTLRequest, opts ...grpc.CallOption) (*SetMessageTTLResponse, error)
+	// ReserveSequences hands a block of message sequence numbers to the message
+	// service, which then allocates them without calling back per message.
+	ReserveSequences(ctx context.Context, in *ReserveSequencesRequest, opts ...grpc.CallOption) (*ReserveSequencesResponse, error)
+	// RecordDeliveryReceipts stores the sequences the delivery service has
+	// handed to each device. Sequences only move forward.
+	RecordDeliveryReceipts(ctx context.Context, in *RecordDeliveryReceiptsRequest, opts ...grpc.CallOption) (*RecordDeliveryReceiptsResponse, error)
+	// RecordMessageSequence raises the sequence unread counts are measured
+	// against, for messages written without a MessageSent event, such as
+	// imported history. It never moves backwards.
+	RecordMessageSequence(ctx context.Context, in *RecordMessageSequenceRequest, opts ...grpc.CallOption) (*RecordMessageSequenceResponse, error)
 }
 
 type conversationApiClient struct {
@@ -111,10 +127,50 @@ func (c *conversationApiClient) UpdateReadReceipt(ctx context.Context, in *Updat
 	return out, nil
 }
 
-func (c *conversationApiClient) NextSequence(ctx context.Context, in *NextSequenceRequest, opts ...grpc.CallOption) (*NextSequenceResponse, error) {
+func (c *conversationApiClient) GetSeenBy(ctx context.Context, in *GetSeenByRequest, opts ...grpc.CallOption) (*GetSeenByResponse, error) {
 	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
-	out := new(NextSequenceResponse)
-	err := c.cc.Invoke(ctx, ConversationApi_NextSequence_FullMethodName, in, out, cOpts...)
+	out := new(GetSeenByResponse)
+	err := c.cc.Invoke(ctx, ConversationApi_GetSeenBy_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *conversationApiClient) SetMessageTTL(ctx context.Context, in *SetMessageTTLRequest, opts ...grpc.CallOption) (*SetMessageTTLResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(SetMessageTTLResponse)
+	err := c.cc.Invoke(ctx, ConversationApi_SetMessageTTL_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *conversationApiClient) ReserveSequences(ctx context.Context, in *ReserveSequencesRequest, opts ...grpc.CallOption) (*ReserveSequencesResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ReserveSequencesResponse)
+	err := c.cc.Invoke(ctx, ConversationApi_ReserveSequences_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *conversationApiClient) RecordDeliveryReceipts(ctx context.Context, in *RecordDeliveryReceiptsRequest, opts ...grpc.CallOption) (*RecordDeliveryReceiptsResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(RecordDeliveryReceiptsResponse)
+	err := c.cc.Invoke(ctx, ConversationApi_RecordDeliveryReceipts_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *conversationApiClient) RecordMessageSequence(ctx context.Context, in *RecordMessageSequenceRequest, opts ...grpc.CallOption) (*RecordMessageSequenceResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(RecordMessageSequenceResponse)
+	err := c.cc.Invoke(ctx, ConversationApi_RecordMessageSequence_FullMethodName, in, out, cOpts...)
 	if err != nil {
 		return nil, err
 	}
@@ -131,9 +187,21 @@ type ConversationApiServer interface {
 	AddParticipant(context.Context, *AddParticipantRequest) (*AddParticipantResponse, error)
 	RemoveParticipant(context.Context, *RemoveParticipantRequest) (*RemoveParticipantResponse, error)
 	UpdateReadReceipt(context.Context, *UpdateReadReceiptRequest) (*UpdateReadReceiptResponse, error)
-	// NextSequence atomically increments and returns the next message sequence
-	// number for a conversation. Called by the message service when sending a message.
-	NextSequence(context.Context, *NextSequenceRequest) (*NextSequenceResponse, error)
+	// GetSeenBy lists the participants who have read up to a sequence.
+	GetSeenBy(context.Context, *GetSeenByRequest) (*GetSeenByResponse, error)
+	// SetMessageTTL turns disappearing messages on or off. Only messages sent
+	// afterwards are affected.
+	SetMessageTTL(context.Context, *SetMessageTTLRequest) (*SetMessageTTLResponse, error)
+	// ReserveSequences hands a block of message sequence numbers to the message
+	// service, which then allocates them without calling back per message.
+	ReserveSequences(context.Context, *ReserveSequencesRequest) (*ReserveSequencesResponse, error)
+	// RecordDeliveryReceipts stores the sequences the delivery service has
+	// handed to each device. Sequences only move forward.
+	RecordDeliveryReceipts(context.Context, *RecordDeliveryReceiptsRequest) (*RecordDeliveryReceiptsResponse, error)
+	// RecordMessageSequence raises the sequence unread counts are measured
+	// against, for messages written without a MessageSent event, such as
+	// imported history. It never moves backwards.
+	RecordMessageSequence(context.Context, *RecordMessageSequenceRequest) (*RecordMessageSequenceResponse, error)
 	mustEmbedUnimplementedConversationApiServer()
 }
 
@@ -162,8 +230,20 @@ func (UnimplementedConversationApiServer) RemoveParticipant(context.Context, *Re
 func (UnimplementedConversationApiServer) UpdateReadReceipt(context.Context, *UpdateReadReceiptRequest) (*UpdateReadReceiptResponse, error) {
 	return nil, status.Error(codes.Unimplemented, "method UpdateReadReceipt not implemented")
 }
-func (UnimplementedConversationApiServer) NextSequence(context.Context, *NextSequenceRequest) (*NextSequenceResponse, error) {
-	return nil, status.Error(codes.Unimplemented, "method NextSequence not implemented")
+func (UnimplementedConversationApiServer) GetSeenBy(context.Context, *GetSeenByRequest) (*GetSeenByResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method GetSeenBy not implemented")
+}
+func (UnimplementedConversationApiServer) SetMessageTTL(context.Context, *SetMessageTTLRequest) (*SetMessageTTLResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method SetMessageTTL not implemented")
+}
+func (UnimplementedConversationApiServer) ReserveSequences(context.Context, *ReserveSequencesRequest) (*ReserveSequencesResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method ReserveSequences not implemented")
+}
+func (UnimplementedConversationApiServer) RecordDeliveryReceipts(context.Context, *RecordDeliveryReceiptsRequest) (*RecordDeliveryReceiptsResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method RecordDeliveryReceipts not implemented")
+}
+func (UnimplementedConversationApiServer) RecordMessageSequence(context.Context, *RecordMessageSequenceRequest) (*RecordMessageSequenceResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method RecordMessageSequence not implemented")
 }
 func (UnimplementedConversationApiServer) mustEmbedUnimplementedConversationApiServer() {}
 func (UnimplementedConversationApiServer) testEmbeddedByValue()                         {}
@@ -294,20 +374,92 @@ func _ConversationApi_UpdateReadReceipt_Handler(srv interface{}, ctx context.Con
 	return interceptor(ctx, in, info, handler)
 }
 
-func _ConversationApi_NextSequence_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
-	in := new(NextSequenceRequest)
+func _ConversationApi_GetSeenBy_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetSeenByRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ConversationApiServer).GetSeenBy(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: ConversationApi_GetSeenBy_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ConversationApiServer).GetSeenBy(ctx, req.(*GetSeenByRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ConversationApi_SetMessageTTL_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(SetMessageTTLRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ConversationApiServer).SetMessageTTL(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: ConversationApi_SetMessageTTL_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ConversationApiServer).SetMessageTTL(ctx, req.(*SetMessageTTLRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ConversationApi_ReserveSequences_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ReserveSequencesRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ConversationApiServer).ReserveSequences(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: ConversationApi_ReserveSequences_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ConversationApiServer).ReserveSequences(ctx, req.(*ReserveSequencesRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ConversationApi_RecordDeliveryReceipts_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(RecordDeliveryReceiptsRequest)
 	if err := dec(in); err != nil {
 		return nil, err
 	}
 	if interceptor == nil {
-		return srv.(ConversationApiServer).NextSequence(ctx, in)
+		return srv.(ConversationApiServer).RecordDeliveryReceipts(ctx, in)
 	}
 	info := &grpc.UnaryServerInfo{
 		Server:     srv,
-		FullMethod: ConversationApi_NextSequence_FullMethodName,
+		FullMethod: ConversationApi_RecordDeliveryReceipts_FullMethodName,
 	}
 	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
-		return srv.(ConversationApiServer).NextSequence(ctx, req.(*NextSequenceRequest))
+		return srv.(ConversationApiServer).RecordDeliveryReceipts(ctx, req.(*RecordDeliveryReceiptsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ConversationApi_RecordMessageSequence_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(RecordMessageSequenceRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ConversationApiServer).RecordMessageSequence(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: ConversationApi_RecordMessageSequence_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ConversationApiServer).RecordMessageSequence(ctx, req.(*RecordMessageSequenceRequest))
 	}
 	return interceptor(ctx, in, info, handler)
 }
@@ -344,8 +496,24 @@ var ConversationApi_ServiceDesc = grpc.ServiceDesc{
 			Handler:    _ConversationApi_UpdateReadReceipt_Handler,
 		},
 		{
-			MethodName: "NextSequence",
-			Handler:    _ConversationApi_NextSequence_Handler,
+			MethodName: "GetSeenBy",
+			Handler:    _ConversationApi_GetSeenBy_Handler,
+		},
+		{
+			MethodName: "SetMessageTTL",
+			Handler:    _ConversationApi_SetMessageTTL_Handler,
+		},
+		{
+			MethodName: "ReserveSequences",
+			Handler:    _ConversationApi_ReserveSequences_Handler,
+		},
+		{
+			MethodName: "RecordDeliveryReceipts",
+			Handler:    _ConversationApi_RecordDeliveryReceipts_Handler,
+		},
+		{
+			MethodName: "RecordMessageSequence",
+			Handler:    _ConversationApi_RecordMessageSequence_Handler,
 		},
 	},
 	Streams:  []grpc.StreamDesc{},
@@ -25,6 +25,7 @@ type ConversationCreatedEvent struct {
 	state              protoimpl.MessageState `protogen:"open.v1"`
 	Conversation       *Conversation          `protobuf:"bytes,1,opt,name=conversation,proto3" json:"conversation,omitempty"`
 	ParticipantUserIds []string               `protobuf:"bytes,2,rep,name=participant_user_ids,json=participantUserIds,proto3" json:"participant_user_ids,omitempty"`
+	CreatorUserId      string                 `protobuf:"bytes,3,opt,name=creator_user_id,json=creatorUserId,proto3" json:"creator_user_id,omitempty"`
 	unknownFields      protoimpl.UnknownFields
 	sizeCache          protoimpl.SizeCache
 }
@@ -73,13 +74,22 @@ func (x *ConversationCreatedEvent) GetParticipantUserIds() []string {
 	return nil
 }
 
+func (x *ConversationCreatedEvent) GetCreatorUserId() string {
+	if x != nil {
+		return x.CreatorUserId
+	}
+	return ""
+}
+
 type MembershipChangedEvent struct {
 	state          protoimpl.MessageState `protogen:"open.v1"`
 	ConversationId string                 `protobuf:"bytes,1,opt,name=conversation_id,json=conversationId,proto3" json:"conversation_id,omitempty"`
 	UserId         string                 `protobuf:"bytes,2,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
 	Added          bool                   `protobuf:"varint,3,opt,name=added,proto3" json:"added,omitempty"`
-	unknownFields  protoimpl.UnknownFields
-	sizeCache      protoimpl.SizeCache
+	// The user who made the change; equal to user_id when a user leaves.
+	ActorUserId   string `protobuf:"bytes,4,opt,name=actor_user_id,json=actorUserId,proto3" json:"actor_user_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
 }
 
 func (x *MembershipChangedEvent) Reset() {
@@ -133,6 +143,67 @@ func (x *MembershipChangedEvent) GetAdded() bool {
 	return false
 }
 
+func (x *MembershipChangedEvent) GetActorUserId() string {
+	if x != nil {
+		return x.ActorUserId
+	}
+	return ""
+}
+
+// ConversationUpdatedEvent carries the conversation after a settings change,
+// such as a new message TTL.
+type ConversationUpdatedEvent struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Conversation  *Conversation          `protobuf:"bytes,1,opt,name=conversation,proto3" json:"conversation,omitempty"`
+	ActorUserId   string                 `protobuf:"bytes,2,opt,name=actor_user_id,json=actorUserId,proto3" json:"actor_user_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ConversationUpdatedEvent) Reset() {
+	*x = ConversationUpdatedEvent{}
+	mi := &file_conversation_v1_events_proto_msgTypes[2]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ConversationUpdatedEvent) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ConversationUpdatedEvent) ProtoMessage() {}
+
+func (x *ConversationUpdatedEvent) ProtoReflect() protoreflect.Message {
+	mi := &file_conversation_v1_events_proto_msgTypes[2]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ConversationUpdatedEvent.ProtoReflect.Descriptor instead.
+func (*ConversationUpdatedEvent) Descriptor() ([]byte, []int) {
+	return file_conversation_v1_events_proto_rawDescGZIP(), []int{2}
+}
+
+func (x *ConversationUpdatedEvent) GetConversation() *Conversation {
+	if x != nil {
+		return x.Conversation
+	}
+	return nil
+}
+
+func (x *ConversationUpdatedEvent) GetActorUserId() string {
+	if x != nil {
+		return x.ActorUserId
+	}
+	return ""
+}
+
 type ReadReceiptUpdatedEvent struct {
 	state          protoimpl.MessageState `protogen:"open.v1"`
 	ConversationId string                 `protobuf:"bytes,1,opt,name=conversation_id,json=conversationId,proto3" json:"conversation_id,omitempty"`
@@ -144,7 +215,7 @@ type ReadReceiptUpdatedEvent struct {
 
 func (x *ReadReceiptUpdatedEvent) Reset() {
 	*x = ReadReceiptUpdatedEvent{}
-	mi := &file_conversation_v1_events_proto_msgTypes[2]
+	mi := &file_conversation_v1_events_proto_msgTypes[3]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -156,7 +227,7 @@ func (x *ReadReceiptUpdatedEvent) String() string {
 func (*ReadReceiptUpdatedEvent) ProtoMessage() {}
 
 func (x *ReadReceiptUpdatedEvent) ProtoReflect() protoreflect.Message {
-	mi := &file_conversation_v1_events_proto_msgTypes[2]
+	mi := &file_conversation_v1_events_proto_msgTypes[3]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -169,7 +240,7 @@ func (x *ReadReceiptUpdatedEvent) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use ReadReceiptUpdatedEvent.ProtoReflect.Descriptor instead.
 func (*ReadReceiptUpdatedEvent) Descriptor() ([]byte, []int) {
-	return file_conversation_v1_events_proto_rawDescGZIP(), []int{2}
+	return file_conversation_v1_events_proto_rawDescGZIP(), []int{3}
 }
 
 func (x *ReadReceiptUpdatedEvent) GetConversationId() string {
@@ -193,22 +264,105 @@ func (x *ReadReceiptUpdatedEvent) GetReadSequence() int64 {
 	return 0
 }
 
+// DeliveryReceiptUpdatedEvent is emitted when a message reaches another of a
+// user's devices for the first time.
+type DeliveryReceiptUpdatedEvent struct {
+	state          protoimpl.MessageState `protogen:"open.v1"`
+	ConversationId string                 `protobuf:"bytes,1,opt,name=conversation_id,json=conversationId,proto3" json:"conversation_id,omitempty"`
+	UserId         string                 `protobuf:"bytes,2,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+	// Highest sequence delivered to any of the user's devices.
+	DeliveredSequence int64 `protobuf:"varint,3,opt,name=delivered_sequence,json=deliveredSequence,proto3" json:"delivered_sequence,omitempty"`
+	// Highest sequence every participant has received, counting a read
+	// message as received.
+	AllDeliveredSequence int64 `protobuf:"varint,4,opt,name=all_delivered_sequence,json=allDeliveredSequence,proto3" json:"all_delivered_sequence,omitempty"`
+	unknownFields        protoimpl.UnknownFields
+	sizeCache            protoimpl.SizeCache
+}
+
+func (x *DeliveryReceiptUpdatedEvent) Reset() {
+	*x = DeliveryReceiptUpdatedEvent{}
+	mi := &file_conversation_v1_events_proto_msgTypes[4]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *DeliveryReceiptUpdatedEvent) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DeliveryReceiptUpdatedEvent) ProtoMessage() {}
+
+func (x *DeliveryReceiptUpdatedEvent) ProtoReflect() protoreflect.Message {
+	mi := &file_conversation_v1_events_proto_msgTypes[4]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DeliveryReceiptUpdatedEvent.ProtoReflect.Descriptor instead.
+func (*DeliveryReceiptUpdatedEvent) Descriptor() ([]byte, []int) {
+	return file_conversation_v1_events_proto_rawDescGZIP(), []int{4}
+}
+
+func (x *DeliveryReceiptUpdatedEvent) GetConversationId() string {
+	if x != nil {
+		return x.ConversationId
+	}
+	return ""
+}
+
+func (x *DeliveryReceiptUpdatedEvent) GetUserId() string {
+	if x != nil {
+		return x.UserId
+	}
+	return ""
+}
+
+func (x *DeliveryReceiptUpdatedEvent) GetDeliveredSequence() int64 {
+	if x != nil {
+		return x.DeliveredSequence
+	}
+	return 0
+}
+
+func (x *DeliveryReceiptUpdatedEvent) GetAllDeliveredSequence() int64 {
+	if x != nil {
+		return x.AllDeliveredSequence
+	}
+	return 0
+}
+
 var File_conversation_v1_events_proto protoreflect.FileDescriptor
 
 const file_conversation_v1_events_proto_rawDesc = "" +
 	"\n" +
-	"\x1cconversation/v1/events.proto\x12\x18realchat.conversation.v1\x1a\"conversation/v1/conversation.proto\"\x98\x01\n" +
+	"\x1cconversation/v1/events.proto\x12\x18realchat.conversation.v1\x1a\"conversation/v1/conversation.proto\"\xc0\x01\n" +
 	"\x18ConversationCreatedEvent\x12J\n" +
 	"\fconversation\x18\x01 \x01(\v2&.realchat.conversation.v1.ConversationR\fconversation\x120\n" +
-	"\x14participant_user_ids\x18\x02 \x03(\tR\x12participantUserIds\"p\n" +
+	"\x14participant_user_ids\x18\x02 \x03(\tR\x12participantUserIds\x12&\n" +
+	"\x0fcreator_user_id\x18\x03 \x01(\tR\rcreatorUserId\"\x94\x01\n" +
 	"\x16MembershipChangedEvent\x12'\n" +
 	"\x0fconversation_id\x18\x01 \x01(\tR\x0econversationId\x12\x17\n" +
 	"\auser_id\x18\x02 \x01(\tR\x06userId\x12\x14\n" +
-	"\x05added\x18\x03 \x01(\bR\x05added\"\x80\x01\n" +
+	"\x05added\x18\x03 \x01(\bR\x05added\x12\"\n" +
+	"\ractor_user_id\x18\x04 \x01(\tR\vactorUserId\"\x8a\x01\n" +
+	"\x18ConversationUpdatedEvent\x12J\n" +
+	"\fconversation\x18\x01 \x01(\v2&.realchat.conversation.v1.ConversationR\fconversation\x12\"\n" +
+	"\ractor_user_id\x18\x02 \x01(\tR\vactorUserId\"\x80\x01\n" +
 	"\x17ReadReceiptUpdatedEvent\x12'\n" +
 	"\x0fconversation_id\x18\x01 \x01(\tR\x0econversationId\x12\x17\n" +
 	"\auser_id\x18\x02 \x01(\tR\x06userId\x12#\n" +
-	"\rread_sequence\x18\x03 \x01(\x03R\freadSequenceBXZVgithub.com/SARVESHVARADKAR123/RealChat/contracts/gen/go/conversation/v1;conversationv1b\x06proto3"
+	"\rread_sequence\x18\x03 \x01(\x03R\freadSequence\"\xc4\x01\n" +
+	"\x1bDeliveryReceiptUpdatedEvent\x12'\n" +
+	"\x0fconversation_id\x18\x01 \x01(\tR\x0econversationId\x12\x17\n" +
+	"\auser_id\x18\x02 \x01(\tR\x06userId\x12-\n" +
+	"\x12delivered_sequence\x18\x03 \x01(\x03R\x11deliveredSequence\x124\n" +
+	"\x16all_delivered_sequence\x18\x04 \x01(\x03R\x14allDeliveredSequenceBXZVgithub.com/SARVESHVARADKAR123/RealChat/contracts/gen/go/conversation/v1;conversationv1b\x06proto3"
 
 var (
 	file_conversation_v1_events_proto_rawDescOnce sync.Once
@@ -222,20 +376,23 @@ func file_conversation_v1_events_proto_rawDescGZIP() []byte {
 	return file_conversation_v1_events_proto_rawDescData
 }
 
-var file_conversation_v1_events_proto_msgTypes = make([]protoimpl.MessageInfo, 3)
+var file_conversation_v1_events_proto_msgTypes = make([]protoimpl.MessageInfo, 5)
 var file_conversation_v1_events_proto_goTypes = []any{
-	(*ConversationCreatedEvent)(nil), // 0: realchat.conversation.v1.ConversationCreatedEvent
-	(*MembershipChangedEvent)(nil),   // 1: realchat.conversation.v1.MembershipChangedEvent
-	(*ReadReceiptUpdatedEvent)(nil),  // 2: realchat.conversation.v1.ReadReceiptUpdatedEvent
-	(*Conversation)(nil),             // 3: realchat.conversation.v1.Conversation
+	(*ConversationCreatedEvent)(nil),    // 0: realchat.conversation.v1.ConversationCreatedEvent
+	(*MembershipChangedEvent)(nil),      // 1: realchat.conversation.v1.MembershipChangedEvent
+	(*ConversationUpdatedEvent)(nil),    // 2: realchat.conversation.v1.ConversationUpdatedEvent
+	(*ReadReceiptUpdatedEvent)(nil),     // 3: realchat.conversation.v1.ReadReceiptUpdatedEvent
+	(*DeliveryReceiptUpdatedEvent)(nil), // 4: realchat.conversation.v1.DeliveryReceiptUpdatedEvent
+	(*Conversation)(nil),                // 5: realchat.conversation.v1.Conversation
 }
 var file_conversation_v1_events_proto_depIdxs = []int32{
-	3, // 0: realchat.conversation.v1.ConversationCreatedEvent.conversation:type_name -> realchat.conversation.v1.Conversation
-	1, // [1:1] is the sub-list for method output_type
-	1, // [1:1] is the sub-list for method input_type
-	1, // [1:1] is the sub-list for extension type_name
-	1, // [1:1] is the sub-list for extension extendee
-	0, // [0:1] is the sub-list for field type_name
+	5, // 0: realchat.conversation.v1.ConversationCreatedEvent.conversation:type_name -> realchat.conversation.v1.Conversation
+	5, // 1: realchat.conversation.v1.ConversationUpdatedEvent.conversation:type_name -> realchat.conversation.v1.Conversation
+	2, // [2:2] is the sub-list for method output_type
+	2, // [2:2] is the sub-list for method input_type
+	2, // [2:2] is the sub-list for extension type_name
+	2, // [2:2] is the sub-list for extension extendee
+	0, // [0:2] is the sub-list for field type_name
 }
 
 func init() { file_conversation_v1_events_proto_init() }
@@ -250,7 +407,7 @@ func file_conversation_v1_events_proto_init() {
 			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
 			RawDescriptor: unsafe.Slice(unsafe.StringData(file_conversation_v1_events_proto_rawDesc), len(file_conversation_v1_events_proto_rawDesc)),
 			NumEnums:      0,
-			NumMessages:   3,
+			NumMessages:   5,
 			NumExtensions: 0,
 			NumServices:   0,
 		},
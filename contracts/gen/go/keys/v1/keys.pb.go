@@ -0,0 +1,304 @@
+// Code generated by protoc-gen-go. DO NOT EDIT.
+// versions:
+// 	protoc-gen-go v1.36.11
+// 	protoc        (unknown)
+// source: keys/v1/keys.proto
+
+package keysv1
+
+import (
+	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
+	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
+	timestamppb "google.golang.org/protobuf/types/known/timestamppb"
+	reflect "reflect"
+	sync "sync"
+	unsafe "unsafe"
+)
+
+const (
+	// Verify that this generated code is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(20 - protoimpl.MinVersion)
+	// Verify that runtime/protoimpl is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
+)
+
+// SignedPreKey is a medium-term X25519 public key, signed with the device's
+// Ed25519 identity key.
+type SignedPreKey struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	KeyId         uint32                 `protobuf:"varint,1,opt,name=key_id,json=keyId,proto3" json:"key_id,omitempty"`
+	PublicKey     []byte                 `protobuf:"bytes,2,opt,name=public_key,json=publicKey,proto3" json:"public_key,omitempty"`
+	Signature     []byte                 `protobuf:"bytes,3,opt,name=signature,proto3" json:"signature,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *SignedPreKey) Reset() {
+	*x = SignedPreKey{}
+	mi := &file_keys_v1_keys_proto_msgTypes[0]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SignedPreKey) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SignedPreKey) ProtoMessage() {}
+
+func (x *SignedPreKey) ProtoReflect() protoreflect.Message {
+	mi := &file_keys_v1_keys_proto_msgTypes[0]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SignedPreKey.ProtoReflect.Descriptor instead.
+func (*SignedPreKey) Descriptor() ([]byte, []int) {
+	return file_keys_v1_keys_proto_rawDescGZIP(), []int{0}
+}
+
+func (x *SignedPreKey) GetKeyId() uint32 {
+	if x != nil {
+		return x.KeyId
+	}
+	return 0
+}
+
+func (x *SignedPreKey) GetPublicKey() []byte {
+	if x != nil {
+		return x.PublicKey
+	}
+	return nil
+}
+
+func (x *SignedPreKey) GetSignature() []byte {
+	if x != nil {
+		return x.Signature
+	}
+	return nil
+}
+
+// OneTimePreKey is an X25519 public key handed out to one sender only.
+type OneTimePreKey struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	KeyId         uint32                 `protobuf:"varint,1,opt,name=key_id,json=keyId,proto3" json:"key_id,omitempty"`
+	PublicKey     []byte                 `protobuf:"bytes,2,opt,name=public_key,json=publicKey,proto3" json:"public_key,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *OneTimePreKey) Reset() {
+	*x = OneTimePreKey{}
+	mi := &file_keys_v1_keys_proto_msgTypes[1]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *OneTimePreKey) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*OneTimePreKey) ProtoMessage() {}
+
+func (x *OneTimePreKey) ProtoReflect() protoreflect.Message {
+	mi := &file_keys_v1_keys_proto_msgTypes[1]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use OneTimePreKey.ProtoReflect.Descriptor instead.
+func (*OneTimePreKey) Descriptor() ([]byte, []int) {
+	return file_keys_v1_keys_proto_rawDescGZIP(), []int{1}
+}
+
+func (x *OneTimePreKey) GetKeyId() uint32 {
+	if x != nil {
+		return x.KeyId
+	}
+	return 0
+}
+
+func (x *OneTimePreKey) GetPublicKey() []byte {
+	if x != nil {
+		return x.PublicKey
+	}
+	return nil
+}
+
+// PreKeyBundle is what a sender needs to open an encrypted session with one
+// device. Device IDs are the ones devices connect to the delivery service
+// with.
+type PreKeyBundle struct {
+	state        protoimpl.MessageState `protogen:"open.v1"`
+	UserId       string                 `protobuf:"bytes,1,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+	DeviceId     string                 `protobuf:"bytes,2,opt,name=device_id,json=deviceId,proto3" json:"device_id,omitempty"`
+	IdentityKey  []byte                 `protobuf:"bytes,3,opt,name=identity_key,json=identityKey,proto3" json:"identity_key,omitempty"`
+	SignedPrekey *SignedPreKey          `protobuf:"bytes,4,opt,name=signed_prekey,json=signedPrekey,proto3" json:"signed_prekey,omitempty"`
+	// Unset when the device has run out; the session then starts from the
+	// signed prekey alone.
+	OneTimePrekey *OneTimePreKey         `protobuf:"bytes,5,opt,name=one_time_prekey,json=oneTimePrekey,proto3" json:"one_time_prekey,omitempty"`
+	UpdatedAt     *timestamppb.Timestamp `protobuf:"bytes,6,opt,name=updated_at,json=updatedAt,proto3" json:"updated_at,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *PreKeyBundle) Reset() {
+	*x = PreKeyBundle{}
+	mi := &file_keys_v1_keys_proto_msgTypes[2]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *PreKeyBundle) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*PreKeyBundle) ProtoMessage() {}
+
+func (x *PreKeyBundle) ProtoReflect() protoreflect.Message {
+	mi := &file_keys_v1_keys_proto_msgTypes[2]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use PreKeyBundle.ProtoReflect.Descriptor instead.
+func (*PreKeyBundle) Descriptor() ([]byte, []int) {
+	return file_keys_v1_keys_proto_rawDescGZIP(), []int{2}
+}
+
+func (x *PreKeyBundle) GetUserId() string {
+	if x != nil {
+		return x.UserId
+	}
+	return ""
+}
+
+func (x *PreKeyBundle) GetDeviceId() string {
+	if x != nil {
+		return x.DeviceId
+	}
+	return ""
+}
+
+func (x *PreKeyBundle) GetIdentityKey() []byte {
+	if x != nil {
+		return x.IdentityKey
+	}
+	return nil
+}
+
+func (x *PreKeyBundle) GetSignedPrekey() *SignedPreKey {
+	if x != nil {
+		return x.SignedPrekey
+	}
+	return nil
+}
+
+func (x *PreKeyBundle) GetOneTimePrekey() *OneTimePreKey {
+	if x != nil {
+		return x.OneTimePrekey
+	}
+	return nil
+}
+
+func (x *PreKeyBundle) GetUpdatedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.UpdatedAt
+	}
+	return nil
+}
+
+var File_keys_v1_keys_proto protoreflect.FileDescriptor
+
+const file_keys_v1_keys_proto_rawDesc = "" +
+	"\n" +
+	"\x12keys/v1/keys.proto\x12\x10realchat.keys.v1\x1a\x1fgoogle/protobuf/timestamp.proto\"b\n" +
+	"\fSignedPreKey\x12\x15\n" +
+	"\x06key_id\x18\x01 \x01(\rR\x05keyId\x12\x1d\n" +
+	"\n" +
+	"public_key\x18\x02 \x01(\fR\tpublicKey\x12\x1c\n" +
+	"\tsignature\x18\x03 \x01(\fR\tsignature\"E\n" +
+	"\rOneTimePreKey\x12\x15\n" +
+	"\x06key_id\x18\x01 \x01(\rR\x05keyId\x12\x1d\n" +
+	"\n" +
+	"public_key\x18\x02 \x01(\fR\tpublicKey\"\xb0\x02\n" +
+	"\fPreKeyBundle\x12\x17\n" +
+	"\auser_id\x18\x01 \x01(\tR\x06userId\x12\x1b\n" +
+	"\tdevice_id\x18\x02 \x01(\tR\bdeviceId\x12!\n" +
+	"\fidentity_key\x18\x03 \x01(\fR\videntityKey\x12C\n" +
+	"\rsigned_prekey\x18\x04 \x01(\v2\x1e.realchat.keys.v1.SignedPreKeyR\fsignedPrekey\x12G\n" +
+	"\x0fone_time_prekey\x18\x05 \x01(\v2\x1f.realchat.keys.v1.OneTimePreKeyR\roneTimePrekey\x129\n" +
+	"\n" +
+	"updated_at\x18\x06 \x01(\v2\x1a.google.protobuf.TimestampR\tupdatedAtBHZFgithub.com/SARVESHVARADKAR123/RealChat/contracts/gen/go/keys/v1;keysv1b\x06proto3"
+
+var (
+	file_keys_v1_keys_proto_rawDescOnce sync.Once
+	file_keys_v1_keys_proto_rawDescData []byte
+)
+
+func file_keys_v1_keys_proto_rawDescGZIP() []byte {
+	file_keys_v1_keys_proto_rawDescOnce.Do(func() {
+		file_keys_v1_keys_proto_rawDescData = protoimpl.X.CompressGZIP(unsafe.Slice(unsafe.StringData(file_keys_v1_keys_proto_rawDesc), len(file_keys_v1_keys_proto_rawDesc)))
+	})
+	return file_keys_v1_keys_proto_rawDescData
+}
+
+var file_keys_v1_keys_proto_msgTypes = make([]protoimpl.MessageInfo, 3)
+var file_keys_v1_keys_proto_goTypes = []any{
+	(*SignedPreKey)(nil),          // 0: realchat.keys.v1.SignedPreKey
+	(*OneTimePreKey)(nil),         // 1: realchat.keys.v1.OneTimePreKey
+	(*PreKeyBundle)(nil),          // 2: realchat.keys.v1.PreKeyBundle
+	(*timestamppb.Timestamp)(nil), // 3: google.protobuf.Timestamp
+}
+var file_keys_v1_keys_proto_depIdxs = []int32{
+	0, // 0: realchat.keys.v1.PreKeyBundle.signed_prekey:type_name -> realchat.keys.v1.SignedPreKey
+	1, // 1: realchat.keys.v1.PreKeyBundle.one_time_prekey:type_name -> realchat.keys.v1.OneTimePreKey
+	3, // 2: realchat.keys.v1.PreKeyBundle.updated_at:type_name -> google.protobuf.Timestamp
+	3, // [3:3] is the sub-list for method output_type
+	3, // [3:3] is the sub-list for method input_type
+	3, // [3:3] is the sub-list for extension type_name
+	3, // [3:3] is the sub-list for extension extendee
+	0, // [0:3] is the sub-list for field type_name
+}
+
+func init() { file_keys_v1_keys_proto_init() }
+func file_keys_v1_keys_proto_init() {
+	if File_keys_v1_keys_proto != nil {
+		return
+	}
+	type x struct{}
+	out := protoimpl.TypeBuilder{
+		File: protoimpl.DescBuilder{
+			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
+			RawDescriptor: unsafe.Slice(unsafe.StringData(file_keys_v1_keys_proto_rawDesc), len(file_keys_v1_keys_proto_rawDesc)),
+			NumEnums:      0,
+			NumMessages:   3,
+			NumExtensions: 0,
+			NumServices:   0,
+		},
+		GoTypes:           file_keys_v1_keys_proto_goTypes,
+		DependencyIndexes: file_keys_v1_keys_proto_depIdxs,
+		MessageInfos:      file_keys_v1_keys_proto_msgTypes,
+	}.Build()
+	File_keys_v1_keys_proto = out.File
+	file_keys_v1_keys_proto_goTypes = nil
+	file_keys_v1_keys_proto_depIdxs = nil
+}
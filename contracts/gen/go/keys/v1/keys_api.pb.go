@@ -0,0 +1,618 @@
+// Code generated by protoc-gen-go. DO NOT EDIT.
+// versions:
+// 	protoc-gen-go v1.36.11
+// 	protoc        (unknown)
+// source: keys/v1/keys_api.proto
+
+package keysv1
+
+import (
+	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
+	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
+	reflect "reflect"
+	sync "sync"
+	unsafe "unsafe"
+)
+
+const (
+	// Verify that this generated code is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(20 - protoimpl.MinVersion)
+	// Verify that runtime/protoimpl is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
+)
+
+type UploadKeysRequest struct {
+	state          protoimpl.MessageState `protogen:"open.v1"`
+	DeviceId       string                 `protobuf:"bytes,1,opt,name=device_id,json=deviceId,proto3" json:"device_id,omitempty"`
+	IdentityKey    []byte                 `protobuf:"bytes,2,opt,name=identity_key,json=identityKey,proto3" json:"identity_key,omitempty"`
+	SignedPrekey   *SignedPreKey          `protobuf:"bytes,3,opt,name=signed_prekey,json=signedPrekey,proto3" json:"signed_prekey,omitempty"`
+	OneTimePrekeys []*OneTimePreKey       `protobuf:"bytes,4,rep,name=one_time_prekeys,json=oneTimePrekeys,proto3" json:"one_time_prekeys,omitempty"`
+	unknownFields  protoimpl.UnknownFields
+	sizeCache      protoimpl.SizeCache
+}
+
+func (x *UploadKeysRequest) Reset() {
+	*x = UploadKeysRequest{}
+	mi := &file_keys_v1_keys_api_proto_msgTypes[0]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *UploadKeysRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*UploadKeysRequest) ProtoMessage() {}
+
+func (x *UploadKeysRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_keys_v1_keys_api_proto_msgTypes[0]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use UploadKeysRequest.ProtoReflect.Descriptor instead.
+func (*UploadKeysRequest) Descriptor() ([]byte, []int) {
+	return file_keys_v1_keys_api_proto_rawDescGZIP(), []int{0}
+}
+
+func (x *UploadKeysRequest) GetDeviceId() string {
+	if x != nil {
+		return x.DeviceId
+	}
+	return ""
+}
+
+func (x *UploadKeysRequest) GetIdentityKey() []byte {
+	if x != nil {
+		return x.IdentityKey
+	}
+	return nil
+}
+
+func (x *UploadKeysRequest) GetSignedPrekey() *SignedPreKey {
+	if x != nil {
+		return x.SignedPrekey
+	}
+	return nil
+}
+
+func (x *UploadKeysRequest) GetOneTimePrekeys() []*OneTimePreKey {
+	if x != nil {
+		return x.OneTimePrekeys
+	}
+	return nil
+}
+
+type UploadKeysResponse struct {
+	state              protoimpl.MessageState `protogen:"open.v1"`
+	OneTimePrekeyCount int32                  `protobuf:"varint,1,opt,name=one_time_prekey_count,json=oneTimePrekeyCount,proto3" json:"one_time_prekey_count,omitempty"`
+	unknownFields      protoimpl.UnknownFields
+	sizeCache          protoimpl.SizeCache
+}
+
+func (x *UploadKeysResponse) Reset() {
+	*x = UploadKeysResponse{}
+	mi := &file_keys_v1_keys_api_proto_msgTypes[1]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *UploadKeysResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*UploadKeysResponse) ProtoMessage() {}
+
+func (x *UploadKeysResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_keys_v1_keys_api_proto_msgTypes[1]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use UploadKeysResponse.ProtoReflect.Descriptor instead.
+func (*UploadKeysResponse) Descriptor() ([]byte, []int) {
+	return file_keys_v1_keys_api_proto_rawDescGZIP(), []int{1}
+}
+
+func (x *UploadKeysResponse) GetOneTimePrekeyCount() int32 {
+	if x != nil {
+		return x.OneTimePrekeyCount
+	}
+	return 0
+}
+
+type GetPreKeyBundlesRequest struct {
+	state  protoimpl.MessageState `protogen:"open.v1"`
+	UserId string                 `protobuf:"bytes,1,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+	// Optional; empty returns every device of the user.
+	DeviceId      string `protobuf:"bytes,2,opt,name=device_id,json=deviceId,proto3" json:"device_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetPreKeyBundlesRequest) Reset() {
+	*x = GetPreKeyBundlesRequest{}
+	mi := &file_keys_v1_keys_api_proto_msgTypes[2]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetPreKeyBundlesRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetPreKeyBundlesRequest) ProtoMessage() {}
+
+func (x *GetPreKeyBundlesRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_keys_v1_keys_api_proto_msgTypes[2]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetPreKeyBundlesRequest.ProtoReflect.Descriptor instead.
+func (*GetPreKeyBundlesRequest) Descriptor() ([]byte, []int) {
+	return file_keys_v1_keys_api_proto_rawDescGZIP(), []int{2}
+}
+
+func (x *GetPreKeyBundlesRequest) GetUserId() string {
+	if x != nil {
+		return x.UserId
+	}
+	return ""
+}
+
+func (x *GetPreKeyBundlesRequest) GetDeviceId() string {
+	if x != nil {
+		return x.DeviceId
+	}
+	return ""
+}
+
+type GetPreKeyBundlesResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Bundles       []*PreKeyBundle        `protobuf:"bytes,1,rep,name=bundles,proto3" json:"bundles,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetPreKeyBundlesResponse) Reset() {
+	*x = GetPreKeyBundlesResponse{}
+	mi := &file_keys_v1_keys_api_proto_msgTypes[3]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetPreKeyBundlesResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetPreKeyBundlesResponse) ProtoMessage() {}
+
+func (x *GetPreKeyBundlesResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_keys_v1_keys_api_proto_msgTypes[3]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetPreKeyBundlesResponse.ProtoReflect.Descriptor instead.
+func (*GetPreKeyBundlesResponse) Descriptor() ([]byte, []int) {
+	return file_keys_v1_keys_api_proto_rawDescGZIP(), []int{3}
+}
+
+func (x *GetPreKeyBundlesResponse) GetBundles() []*PreKeyBundle {
+	if x != nil {
+		return x.Bundles
+	}
+	return nil
+}
+
+type ReplenishPreKeysRequest struct {
+	state          protoimpl.MessageState `protogen:"open.v1"`
+	DeviceId       string                 `protobuf:"bytes,1,opt,name=device_id,json=deviceId,proto3" json:"device_id,omitempty"`
+	OneTimePrekeys []*OneTimePreKey       `protobuf:"bytes,2,rep,name=one_time_prekeys,json=oneTimePrekeys,proto3" json:"one_time_prekeys,omitempty"`
+	// Optional; replaces the device's signed prekey.
+	SignedPrekey  *SignedPreKey `protobuf:"bytes,3,opt,name=signed_prekey,json=signedPrekey,proto3" json:"signed_prekey,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ReplenishPreKeysRequest) Reset() {
+	*x = ReplenishPreKeysRequest{}
+	mi := &file_keys_v1_keys_api_proto_msgTypes[4]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ReplenishPreKeysRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ReplenishPreKeysRequest) ProtoMessage() {}
+
+func (x *ReplenishPreKeysRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_keys_v1_keys_api_proto_msgTypes[4]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ReplenishPreKeysRequest.ProtoReflect.Descriptor instead.
+func (*ReplenishPreKeysRequest) Descriptor() ([]byte, []int) {
+	return file_keys_v1_keys_api_proto_rawDescGZIP(), []int{4}
+}
+
+func (x *ReplenishPreKeysRequest) GetDeviceId() string {
+	if x != nil {
+		return x.DeviceId
+	}
+	return ""
+}
+
+func (x *ReplenishPreKeysRequest) GetOneTimePrekeys() []*OneTimePreKey {
+	if x != nil {
+		return x.OneTimePrekeys
+	}
+	return nil
+}
+
+func (x *ReplenishPreKeysRequest) GetSignedPrekey() *SignedPreKey {
+	if x != nil {
+		return x.SignedPrekey
+	}
+	return nil
+}
+
+type ReplenishPreKeysResponse struct {
+	state              protoimpl.MessageState `protogen:"open.v1"`
+	OneTimePrekeyCount int32                  `protobuf:"varint,1,opt,name=one_time_prekey_count,json=oneTimePrekeyCount,proto3" json:"one_time_prekey_count,omitempty"`
+	unknownFields      protoimpl.UnknownFields
+	sizeCache          protoimpl.SizeCache
+}
+
+func (x *ReplenishPreKeysResponse) Reset() {
+	*x = ReplenishPreKeysResponse{}
+	mi := &file_keys_v1_keys_api_proto_msgTypes[5]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ReplenishPreKeysResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ReplenishPreKeysResponse) ProtoMessage() {}
+
+func (x *ReplenishPreKeysResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_keys_v1_keys_api_proto_msgTypes[5]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ReplenishPreKeysResponse.ProtoReflect.Descriptor instead.
+func (*ReplenishPreKeysResponse) Descriptor() ([]byte, []int) {
+	return file_keys_v1_keys_api_proto_rawDescGZIP(), []int{5}
+}
+
+func (x *ReplenishPreKeysResponse) GetOneTimePrekeyCount() int32 {
+	if x != nil {
+		return x.OneTimePrekeyCount
+	}
+	return 0
+}
+
+type GetPreKeyCountRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	DeviceId      string                 `protobuf:"bytes,1,opt,name=device_id,json=deviceId,proto3" json:"device_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetPreKeyCountRequest) Reset() {
+	*x = GetPreKeyCountRequest{}
+	mi := &file_keys_v1_keys_api_proto_msgTypes[6]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetPreKeyCountRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetPreKeyCountRequest) ProtoMessage() {}
+
+func (x *GetPreKeyCountRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_keys_v1_keys_api_proto_msgTypes[6]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetPreKeyCountRequest.ProtoReflect.Descriptor instead.
+func (*GetPreKeyCountRequest) Descriptor() ([]byte, []int) {
+	return file_keys_v1_keys_api_proto_rawDescGZIP(), []int{6}
+}
+
+func (x *GetPreKeyCountRequest) GetDeviceId() string {
+	if x != nil {
+		return x.DeviceId
+	}
+	return ""
+}
+
+type GetPreKeyCountResponse struct {
+	state              protoimpl.MessageState `protogen:"open.v1"`
+	OneTimePrekeyCount int32                  `protobuf:"varint,1,opt,name=one_time_prekey_count,json=oneTimePrekeyCount,proto3" json:"one_time_prekey_count,omitempty"`
+	unknownFields      protoimpl.UnknownFields
+	sizeCache          protoimpl.SizeCache
+}
+
+func (x *GetPreKeyCountResponse) Reset() {
+	*x = GetPreKeyCountResponse{}
+	mi := &file_keys_v1_keys_api_proto_msgTypes[7]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetPreKeyCountResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetPreKeyCountResponse) ProtoMessage() {}
+
+func (x *GetPreKeyCountResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_keys_v1_keys_api_proto_msgTypes[7]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetPreKeyCountResponse.ProtoReflect.Descriptor instead.
+func (*GetPreKeyCountResponse) Descriptor() ([]byte, []int) {
+	return file_keys_v1_keys_api_proto_rawDescGZIP(), []int{7}
+}
+
+func (x *GetPreKeyCountResponse) GetOneTimePrekeyCount() int32 {
+	if x != nil {
+		return x.OneTimePrekeyCount
+	}
+	return 0
+}
+
+type RemoveDeviceRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	DeviceId      string                 `protobuf:"bytes,1,opt,name=device_id,json=deviceId,proto3" json:"device_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *RemoveDeviceRequest) Reset() {
+	*x = RemoveDeviceRequest{}
+	mi := &file_keys_v1_keys_api_proto_msgTypes[8]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *RemoveDeviceRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RemoveDeviceRequest) ProtoMessage() {}
+
+func (x *RemoveDeviceRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_keys_v1_keys_api_proto_msgTypes[8]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RemoveDeviceRequest.ProtoReflect.Descriptor instead.
+func (*RemoveDeviceRequest) Descriptor() ([]byte, []int) {
+	return file_keys_v1_keys_api_proto_rawDescGZIP(), []int{8}
+}
+
+func (x *RemoveDeviceRequest) GetDeviceId() string {
+	if x != nil {
+		return x.DeviceId
+	}
+	return ""
+}
+
+type RemoveDeviceResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *RemoveDeviceResponse) Reset() {
+	*x = RemoveDeviceResponse{}
+	mi := &file_keys_v1_keys_api_proto_msgTypes[9]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *RemoveDeviceResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RemoveDeviceResponse) ProtoMessage() {}
+
+func (x *RemoveDeviceResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_keys_v1_keys_api_proto_msgTypes[9]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RemoveDeviceResponse.ProtoReflect.Descriptor instead.
+func (*RemoveDeviceResponse) Descriptor() ([]byte, []int) {
+	return file_keys_v1_keys_api_proto_rawDescGZIP(), []int{9}
+}
+
+var File_keys_v1_keys_api_proto protoreflect.FileDescriptor
+
+const file_keys_v1_keys_api_proto_rawDesc = "" +
+	"\n" +
+	"\x16keys/v1/keys_api.proto\x12\x10realchat.keys.v1\x1a\x12keys/v1/keys.proto\"\xe3\x01\n" +
+	"\x11UploadKeysRequest\x12\x1b\n" +
+	"\tdevice_id\x18\x01 \x01(\tR\bdeviceId\x12!\n" +
+	"\fidentity_key\x18\x02 \x01(\fR\videntityKey\x12C\n" +
+	"\rsigned_prekey\x18\x03 \x01(\v2\x1e.realchat.keys.v1.SignedPreKeyR\fsignedPrekey\x12I\n" +
+	"\x10one_time_prekeys\x18\x04 \x03(\v2\x1f.realchat.keys.v1.OneTimePreKeyR\x0eoneTimePrekeys\"G\n" +
+	"\x12UploadKeysResponse\x121\n" +
+	"\x15one_time_prekey_count\x18\x01 \x01(\x05R\x12oneTimePrekeyCount\"O\n" +
+	"\x17GetPreKeyBundlesRequest\x12\x17\n" +
+	"\auser_id\x18\x01 \x01(\tR\x06userId\x12\x1b\n" +
+	"\tdevice_id\x18\x02 \x01(\tR\bdeviceId\"T\n" +
+	"\x18GetPreKeyBundlesResponse\x128\n" +
+	"\abundles\x18\x01 \x03(\v2\x1e.realchat.keys.v1.PreKeyBundleR\abundles\"\xc6\x01\n" +
+	"\x17ReplenishPreKeysRequest\x12\x1b\n" +
+	"\tdevice_id\x18\x01 \x01(\tR\bdeviceId\x12I\n" +
+	"\x10one_time_prekeys\x18\x02 \x03(\v2\x1f.realchat.keys.v1.OneTimePreKeyR\x0eoneTimePrekeys\x12C\n" +
+	"\rsigned_prekey\x18\x03 \x01(\v2\x1e.realchat.keys.v1.SignedPreKeyR\fsignedPrekey\"M\n" +
+	"\x18ReplenishPreKeysResponse\x121\n" +
+	"\x15one_time_prekey_count\x18\x01 \x01(\x05R\x12oneTimePrekeyCount\"4\n" +
+	"\x15GetPreKeyCountRequest\x12\x1b\n" +
+	"\tdevice_id\x18\x01 \x01(\tR\bdeviceId\"K\n" +
+	"\x16GetPreKeyCountResponse\x121\n" +
+	"\x15one_time_prekey_count\x18\x01 \x01(\x05R\x12oneTimePrekeyCount\"2\n" +
+	"\x13RemoveDeviceRequest\x12\x1b\n" +
+	"\tdevice_id\x18\x01 \x01(\tR\bdeviceId\"\x16\n" +
+	"\x14RemoveDeviceResponse2\xfb\x03\n" +
+	"\x06KeyApi\x12W\n" +
+	"\n" +
+	"UploadKeys\x12#.realchat.keys.v1.UploadKeysRequest\x1a$.realchat.keys.v1.UploadKeysResponse\x12i\n" +
+	"\x10GetPreKeyBundles\x12).realchat.keys.v1.GetPreKeyBundlesRequest\x1a*.realchat.keys.v1.GetPreKeyBundlesResponse\x12i\n" +
+	"\x10ReplenishPreKeys\x12).realchat.keys.v1.ReplenishPreKeysRequest\x1a*.realchat.keys.v1.ReplenishPreKeysResponse\x12c\n" +
+	"\x0eGetPreKeyCount\x12'.realchat.keys.v1.GetPreKeyCountRequest\x1a(.realchat.keys.v1.GetPreKeyCountResponse\x12]\n" +
+	"\fRemoveDevice\x12%.realchat.keys.v1.RemoveDeviceRequest\x1a&.realchat.keys.v1.RemoveDeviceResponseBHZFgithub.com/SARVESHVARADKAR123/RealChat/contracts/gen/go/keys/v1;keysv1b\x06proto3"
+
+var (
+	file_keys_v1_keys_api_proto_rawDescOnce sync.Once
+	file_keys_v1_keys_api_proto_rawDescData []byte
+)
+
+func file_keys_v1_keys_api_proto_rawDescGZIP() []byte {
+	file_keys_v1_keys_api_proto_rawDescOnce.Do(func() {
+		file_keys_v1_keys_api_proto_rawDescData = protoimpl.X.CompressGZIP(unsafe.Slice(unsafe.StringData(file_keys_v1_keys_api_proto_rawDesc), len(file_keys_v1_keys_api_proto_rawDesc)))
+	})
+	return file_keys_v1_keys_api_proto_rawDescData
+}
+
+var file_keys_v1_keys_api_proto_msgTypes = make([]protoimpl.MessageInfo, 10)
+var file_keys_v1_keys_api_proto_goTypes = []any{
+	(*UploadKeysRequest)(nil),        // 0: realchat.keys.v1.UploadKeysRequest
+	(*UploadKeysResponse)(nil),       // 1: realchat.keys.v1.UploadKeysResponse
+	(*GetPreKeyBundlesRequest)(nil),  // 2: realchat.keys.v1.GetPreKeyBundlesRequest
+	(*GetPreKeyBundlesResponse)(nil), // 3: realchat.keys.v1.GetPreKeyBundlesResponse
+	(*ReplenishPreKeysRequest)(nil),  // 4: realchat.keys.v1.ReplenishPreKeysRequest
+	(*ReplenishPreKeysResponse)(nil), // 5: realchat.keys.v1.ReplenishPreKeysResponse
+	(*GetPreKeyCountRequest)(nil),    // 6: realchat.keys.v1.GetPreKeyCountRequest
+	(*GetPreKeyCountResponse)(nil),   // 7: realchat.keys.v1.GetPreKeyCountResponse
+	(*RemoveDeviceRequest)(nil),      // 8: realchat.keys.v1.RemoveDeviceRequest
+	(*RemoveDeviceResponse)(nil),     // 9: realchat.keys.v1.RemoveDeviceResponse
+	(*SignedPreKey)(nil),             // 10: realchat.keys.v1.SignedPreKey
+	(*OneTimePreKey)(nil),            // 11: realchat.keys.v1.OneTimePreKey
+	(*PreKeyBundle)(nil),             // 12: realchat.keys.v1.PreKeyBundle
+}
+var file_keys_v1_keys_api_proto_depIdxs = []int32{
+	10, // 0: realchat.keys.v1.UploadKeysRequest.signed_prekey:type_name -> realchat.keys.v1.SignedPreKey
+	11, // 1: realchat.keys.v1.UploadKeysRequest.one_time_prekeys:type_name -> realchat.keys.v1.OneTimePreKey
+	12, // 2: realchat.keys.v1.GetPreKeyBundlesResponse.bundles:type_name -> realchat.keys.v1.PreKeyBundle
+	11, // 3: realchat.keys.v1.ReplenishPreKeysRequest.one_time_prekeys:type_name -> realchat.keys.v1.OneTimePreKey
+	10, // 4: realchat.keys.v1.ReplenishPreKeysRequest.signed_prekey:type_name -> realchat.keys.v1.SignedPreKey
+	0,  // 5: realchat.keys.v1.KeyApi.UploadKeys:input_type -> realchat.keys.v1.UploadKeysRequest
+	2,  // 6: realchat.keys.v1.KeyApi.GetPreKeyBundles:input_type -> realchat.keys.v1.GetPreKeyBundlesRequest
+	4,  // 7: realchat.keys.v1.KeyApi.ReplenishPreKeys:input_type -> realchat.keys.v1.ReplenishPreKeysRequest
+	6,  // 8: realchat.keys.v1.KeyApi.GetPreKeyCount:input_type -> realchat.keys.v1.GetPreKeyCountRequest
+	8,  // 9: realchat.keys.v1.KeyApi.RemoveDevice:input_type -> realchat.keys.v1.RemoveDeviceRequest
+	1,  // 10: realchat.keys.v1.KeyApi.UploadKeys:output_type -> realchat.keys.v1.UploadKeysResponse
+	3,  // 11: realchat.keys.v1.KeyApi.GetPreKeyBundles:output_type -> realchat.keys.v1.GetPreKeyBundlesResponse
+	5,  // 12: realchat.keys.v1.KeyApi.ReplenishPreKeys:output_type -> realchat.keys.v1.ReplenishPreKeysResponse
+	7,  // 13: realchat.keys.v1.KeyApi.GetPreKeyCount:output_type -> realchat.keys.v1.GetPreKeyCountResponse
+	9,  // 14: realchat.keys.v1.KeyApi.RemoveDevice:output_type -> realchat.keys.v1.RemoveDeviceResponse
+	10, // [10:15] is the sub-list for method output_type
+	5,  // [5:10] is the sub-list for method input_type
+	5,  // [5:5] is the sub-list for extension type_name
+	5,  // [5:5] is the sub-list for extension extendee
+	0,  // [0:5] is the sub-list for field type_name
+}
+
+func init() { file_keys_v1_keys_api_proto_init() }
+func file_keys_v1_keys_api_proto_init() {
+	if File_keys_v1_keys_api_proto != nil {
+		return
+	}
+	file_keys_v1_keys_proto_init()
+	type x struct{}
+	out := protoimpl.TypeBuilder{
+		File: protoimpl.DescBuilder{
+			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
+			RawDescriptor: unsafe.Slice(unsafe.StringData(file_keys_v1_keys_api_proto_rawDesc), len(file_keys_v1_keys_api_proto_rawDesc)),
+			NumEnums:      0,
+			NumMessages:   10,
+			NumExtensions: 0,
+			NumServices:   1,
+		},
+		GoTypes:           file_keys_v1_keys_api_proto_goTypes,
+		DependencyIndexes: file_keys_v1_keys_api_proto_depIdxs,
+		MessageInfos:      file_keys_v1_keys_api_proto_msgTypes,
+	}.Build()
+	File_keys_v1_keys_api_proto = out.File
+	file_keys_v1_keys_api_proto_goTypes = nil
+	file_keys_v1_keys_api_proto_depIdxs = nil
+}
@@ -0,0 +1,293 @@
+// Code generated by protoc-gen-go-grpc. DO NOT EDIT.
+// versions:
+// - protoc-gen-go-grpc v1.6.1
+// - protoc             (unknown)
+// source: keys/v1/keys_api.proto
+
+package keysv1
+
+import (
+	context "context"
+	grpc "google.golang.org/grpc"
+	codes "google.golang.org/grpc/codes"
+	status "google.golang.org/grpc/status"
+)
+
+// This is a compile-time assertion to ensure that this generated file
+// is compatible with the grpc package it is being compiled against.
+// Requires gRPC-Go v1.64.0 or later.
+const _ = grpc.SupportPackageIsVersion9
+
+const (
+	KeyApi_UploadKeys_FullMethodName       = "/realchat.keys.v1.KeyApi/UploadKeys"
+	KeyApi_GetPreKeyBundles_FullMethodName = "/realchat.keys.v1.KeyApi/GetPreKeyBundles"
+	KeyApi_ReplenishPreKeys_FullMethodName = "/realchat.keys.v1.KeyApi/ReplenishPreKeys"
+	KeyApi_GetPreKeyCount_FullMethodName   = "/realchat.keys.v1.KeyApi/GetPreKeyCount"
+	KeyApi_RemoveDevice_FullMethodName     = "/realchat.keys.v1.KeyApi/RemoveDevice"
+)
+
+// KeyApiClient is the client API for KeyApi service.
+//
+// For semantics around ctx use and closing/ending streaming RPCs, please refer to https://pkg.go.dev/google.golang.org/grpc/?tab=doc#ClientConn.NewStream.
+type KeyApiClient interface {
+	// UploadKeys publishes the keys of one of the caller's devices. Uploading
+	// a different identity key for a device replaces it, dropping the one-time
+	// prekeys published under the old one.
+	UploadKeys(ctx context.Context, in *UploadKeysRequest, opts ...grpc.CallOption) (*UploadKeysResponse, error)
+	// GetPreKeyBundles returns a bundle for each of a user's devices, or for
+	// one of them. Each bundle claims one of the device's one-time prekeys,
+	// which is never handed out again.
+	GetPreKeyBundles(ctx context.Context, in *GetPreKeyBundlesRequest, opts ...grpc.CallOption) (*GetPreKeyBundlesResponse, error)
+	// ReplenishPreKeys adds one-time prekeys to one of the caller's devices
+	// and optionally rotates its signed prekey.
+	ReplenishPreKeys(ctx context.Context, in *ReplenishPreKeysRequest, opts ...grpc.CallOption) (*ReplenishPreKeysResponse, error)
+	// GetPreKeyCount tells a device how many of its one-time prekeys are left.
+	GetPreKeyCount(ctx context.Context, in *GetPreKeyCountRequest, opts ...grpc.CallOption) (*GetPreKeyCountResponse, error)
+	// RemoveDevice deletes the keys of one of the caller's devices.
+	RemoveDevice(ctx context.Context, in *RemoveDeviceRequest, opts ...grpc.CallOption) (*RemoveDeviceResponse, error)
+}
+
+type keyApiClient struct {
+	cc grpc.ClientConnInterface
+}
+
+func NewKeyApiClient(cc grpc.ClientConnInterface) KeyApiClient {
+	return &keyApiClient{cc}
+}
+
+func (c *keyApiClient) UploadKeys(ctx context.Context, in *UploadKeysRequest, opts ...grpc.CallOption) (*UploadKeysResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(UploadKeysResponse)
+	err := c.cc.Invoke(ctx, KeyApi_UploadKeys_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *keyApiClient) GetPreKeyBundles(ctx context.Context, in *GetPreKeyBundlesRequest, opts ...grpc.CallOption) (*GetPreKeyBundlesResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(GetPreKeyBundlesResponse)
+	err := c.cc.Invoke(ctx, KeyApi_GetPreKeyBundles_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *keyApiClient) ReplenishPreKeys(ctx context.Context, in *ReplenishPreKeysRequest, opts ...grpc.CallOption) (*ReplenishPreKeysResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ReplenishPreKeysResponse)
+	err := c.cc.Invoke(ctx, KeyApi_ReplenishPreKeys_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *keyApiClient) GetPreKeyCount(ctx context.Context, in *GetPreKeyCountRequest, opts ...grpc.CallOption) (*GetPreKeyCountResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(GetPreKeyCountResponse)
+	err := c.cc.Invoke(ctx, KeyApi_GetPreKeyCount_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *keyApiClient) RemoveDevice(ctx context.Context, in *RemoveDeviceRequest, opts ...grpc.CallOption) (*RemoveDeviceResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(RemoveDeviceResponse)
+	err := c.cc.Invoke(ctx, KeyApi_RemoveDevice_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// KeyApiServer is the server API for KeyApi service.
+// All implementations must embed UnimplementedKeyApiServer
+// for forward compatibility.
+type KeyApiServer interface {
+	// UploadKeys publishes the keys of one of the caller's devices. Uploading
+	// a different identity key for a device replaces it, dropping the one-time
+	// prekeys published under the old one.
+	UploadKeys(context.Context, *UploadKeysRequest) (*UploadKeysResponse, error)
+	// GetPreKeyBundles returns a bundle for each of a user's devices, or for
+	// one of them. Each bundle claims one of the device's one-time prekeys,
+	// which is never handed out again.
+	GetPreKeyBundles(context.Context, *GetPreKeyBundlesRequest) (*GetPreKeyBundlesResponse, error)
+	// ReplenishPreKeys adds one-time prekeys to one of the caller's devices
+	// and optionally rotates its signed prekey.
+	ReplenishPreKeys(context.Context, *ReplenishPreKeysRequest) (*ReplenishPreKeysResponse, error)
+	// GetPreKeyCount tells a device how many of its one-time prekeys are left.
+	GetPreKeyCount(context.Context, *GetPreKeyCountRequest) (*GetPreKeyCountResponse, error)
+	// RemoveDevice deletes the keys of one of the caller's devices.
+	RemoveDevice(context.Context, *RemoveDeviceRequest) (*RemoveDeviceResponse, error)
+	mustEmbedUnimplementedKeyApiServer()
+}
+
+// UnimplementedKeyApiServer must be embedded to have
+// forward compatible implementations.
+//
+// NOTE: this should be embedded by value instead of pointer to avoid a nil
+// pointer dereference when methods are called.
+type UnimplementedKeyApiServer struct{}
+
+func (UnimplementedKeyApiServer) UploadKeys(context.Context, *UploadKeysRequest) (*UploadKeysResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method UploadKeys not implemented")
+}
+func (UnimplementedKeyApiServer) GetPreKeyBundles(context.Context, *GetPreKeyBundlesRequest) (*GetPreKeyBundlesResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method GetPreKeyBundles not implemented")
+}
+func (UnimplementedKeyApiServer) ReplenishPreKeys(context.Context, *ReplenishPreKeysRequest) (*ReplenishPreKeysResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method ReplenishPreKeys not implemented")
+}
+func (UnimplementedKeyApiServer) GetPreKeyCount(context.Context, *GetPreKeyCountRequest) (*GetPreKeyCountResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method GetPreKeyCount not implemented")
+}
+func (UnimplementedKeyApiServer) RemoveDevice(context.Context, *RemoveDeviceRequest) (*RemoveDeviceResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method RemoveDevice not implemented")
+}
+func (UnimplementedKeyApiServer) mustEmbedUnimplementedKeyApiServer() {}
+func (UnimplementedKeyApiServer) testEmbeddedByValue()                {}
+
+// UnsafeKeyApiServer may be embedded to opt out of forward compatibility for this service.
+// Use of this interface is not recommended, as added methods to KeyApiServer will
+// result in compilation errors.
+type UnsafeKeyApiServer interface {
+	mustEmbedUnimplementedKeyApiServer()
+}
+
+func RegisterKeyApiServer(s grpc.ServiceRegistrar, srv KeyApiServer) {
+	// If the following call panics, it indicates UnimplementedKeyApiServer was
+	// embedded by pointer and is nil.  This will cause panics if an
+	// unimplemented method is ever invoked, so we test this at initialization
+	// time to prevent it from happening at runtime later due to I/O.
+	if t, ok := srv.(interface{ testEmbeddedByValue() }); ok {
+		t.testEmbeddedByValue()
+	}
+	s.RegisterService(&KeyApi_ServiceDesc, srv)
+}
+
+func _KeyApi_UploadKeys_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(UploadKeysRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(KeyApiServer).UploadKeys(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: KeyApi_UploadKeys_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(KeyApiServer).UploadKeys(ctx, req.(*UploadKeysRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _KeyApi_GetPreKeyBundles_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetPreKeyBundlesRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(KeyApiServer).GetPreKeyBundles(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: KeyApi_GetPreKeyBundles_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(KeyApiServer).GetPreKeyBundles(ctx, req.(*GetPreKeyBundlesRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _KeyApi_ReplenishPreKeys_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ReplenishPreKeysRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(KeyApiServer).ReplenishPreKeys(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: KeyApi_ReplenishPreKeys_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(KeyApiServer).ReplenishPreKeys(ctx, req.(*ReplenishPreKeysRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _KeyApi_GetPreKeyCount_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetPreKeyCountRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(KeyApiServer).GetPreKeyCount(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: KeyApi_GetPreKeyCount_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(KeyApiServer).GetPreKeyCount(ctx, req.(*GetPreKeyCountRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _KeyApi_RemoveDevice_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(RemoveDeviceRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(KeyApiServer).RemoveDevice(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: KeyApi_RemoveDevice_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(KeyApiServer).RemoveDevice(ctx, req.(*RemoveDeviceRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+// KeyApi_ServiceDesc is the grpc.ServiceDesc for KeyApi service.
+// It's only intended for direct use with grpc.RegisterService,
+// and not to be introspected or modified (even as a copy)
+var KeyApi_ServiceDesc = grpc.ServiceDesc{
+	ServiceName: "realchat.keys.v1.KeyApi",
+	HandlerType: (*KeyApiServer)(nil),
+	Methods: []grpc.MethodDesc{
+		{
+			MethodName: "UploadKeys",
+			Handler:    _KeyApi_UploadKeys_Handler,
+		},
+		{
+			MethodName: "GetPreKeyBundles",
+			Handler:    _KeyApi_GetPreKeyBundles_Handler,
+		},
+		{
+			MethodName: "ReplenishPreKeys",
+			Handler:    _KeyApi_ReplenishPreKeys_Handler,
+		},
+		{
+			MethodName: "GetPreKeyCount",
+			Handler:    _KeyApi_GetPreKeyCount_Handler,
+		},
+		{
+			MethodName: "RemoveDevice",
+			Handler:    _KeyApi_RemoveDevice_Handler,
+		},
+	},
+	Streams:  []grpc.StreamDesc{},
+	Metadata: "keys/v1/keys_api.proto",
+}
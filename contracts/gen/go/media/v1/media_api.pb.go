@@ -0,0 +1,530 @@
+// Code generated by protoc-gen-go. DO NOT EDIT.
+// versions:
+// 	protoc-gen-go v1.36.11
+// 	protoc        (unknown)
+// source: media/v1/media_api.proto
+
+package mediav1
+
+import (
+	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
+	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
+	reflect "reflect"
+	sync "sync"
+	unsafe "unsafe"
+)
+
+const (
+	// Verify that this generated code is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(20 - protoimpl.MinVersion)
+	// Verify that runtime/protoimpl is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
+)
+
+type CreateUploadSessionRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	OwnerUserId   string                 `protobuf:"bytes,1,opt,name=owner_user_id,json=ownerUserId,proto3" json:"owner_user_id,omitempty"`
+	FileName      string                 `protobuf:"bytes,2,opt,name=file_name,json=fileName,proto3" json:"file_name,omitempty"`
+	MimeType      string                 `protobuf:"bytes,3,opt,name=mime_type,json=mimeType,proto3" json:"mime_type,omitempty"`
+	SizeBytes     int64                  `protobuf:"varint,4,opt,name=size_bytes,json=sizeBytes,proto3" json:"size_bytes,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *CreateUploadSessionRequest) Reset() {
+	*x = CreateUploadSessionRequest{}
+	mi := &file_media_v1_media_api_proto_msgTypes[0]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CreateUploadSessionRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CreateUploadSessionRequest) ProtoMessage() {}
+
+func (x *CreateUploadSessionRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_media_v1_media_api_proto_msgTypes[0]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CreateUploadSessionRequest.ProtoReflect.Descriptor instead.
+func (*CreateUploadSessionRequest) Descriptor() ([]byte, []int) {
+	return file_media_v1_media_api_proto_rawDescGZIP(), []int{0}
+}
+
+func (x *CreateUploadSessionRequest) GetOwnerUserId() string {
+	if x != nil {
+		return x.OwnerUserId
+	}
+	return ""
+}
+
+func (x *CreateUploadSessionRequest) GetFileName() string {
+	if x != nil {
+		return x.FileName
+	}
+	return ""
+}
+
+func (x *CreateUploadSessionRequest) GetMimeType() string {
+	if x != nil {
+		return x.MimeType
+	}
+	return ""
+}
+
+func (x *CreateUploadSessionRequest) GetSizeBytes() int64 {
+	if x != nil {
+		return x.SizeBytes
+	}
+	return 0
+}
+
+type CreateUploadSessionResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Session       *UploadSession         `protobuf:"bytes,1,opt,name=session,proto3" json:"session,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *CreateUploadSessionResponse) Reset() {
+	*x = CreateUploadSessionResponse{}
+	mi := &file_media_v1_media_api_proto_msgTypes[1]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CreateUploadSessionResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CreateUploadSessionResponse) ProtoMessage() {}
+
+func (x *CreateUploadSessionResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_media_v1_media_api_proto_msgTypes[1]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CreateUploadSessionResponse.ProtoReflect.Descriptor instead.
+func (*CreateUploadSessionResponse) Descriptor() ([]byte, []int) {
+	return file_media_v1_media_api_proto_rawDescGZIP(), []int{1}
+}
+
+func (x *CreateUploadSessionResponse) GetSession() *UploadSession {
+	if x != nil {
+		return x.Session
+	}
+	return nil
+}
+
+type UploadContentRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	UploadId      string                 `protobuf:"bytes,1,opt,name=upload_id,json=uploadId,proto3" json:"upload_id,omitempty"`
+	Data          []byte                 `protobuf:"bytes,2,opt,name=data,proto3" json:"data,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *UploadContentRequest) Reset() {
+	*x = UploadContentRequest{}
+	mi := &file_media_v1_media_api_proto_msgTypes[2]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *UploadContentRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*UploadContentRequest) ProtoMessage() {}
+
+func (x *UploadContentRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_media_v1_media_api_proto_msgTypes[2]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use UploadContentRequest.ProtoReflect.Descriptor instead.
+func (*UploadContentRequest) Descriptor() ([]byte, []int) {
+	return file_media_v1_media_api_proto_rawDescGZIP(), []int{2}
+}
+
+func (x *UploadContentRequest) GetUploadId() string {
+	if x != nil {
+		return x.UploadId
+	}
+	return ""
+}
+
+func (x *UploadContentRequest) GetData() []byte {
+	if x != nil {
+		return x.Data
+	}
+	return nil
+}
+
+type UploadContentResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Attachment    *Attachment            `protobuf:"bytes,1,opt,name=attachment,proto3" json:"attachment,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *UploadContentResponse) Reset() {
+	*x = UploadContentResponse{}
+	mi := &file_media_v1_media_api_proto_msgTypes[3]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *UploadContentResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*UploadContentResponse) ProtoMessage() {}
+
+func (x *UploadContentResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_media_v1_media_api_proto_msgTypes[3]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use UploadContentResponse.ProtoReflect.Descriptor instead.
+func (*UploadContentResponse) Descriptor() ([]byte, []int) {
+	return file_media_v1_media_api_proto_rawDescGZIP(), []int{3}
+}
+
+func (x *UploadContentResponse) GetAttachment() *Attachment {
+	if x != nil {
+		return x.Attachment
+	}
+	return nil
+}
+
+type GetAttachmentsRequest struct {
+	state               protoimpl.MessageState `protogen:"open.v1"`
+	AttachmentIds       []string               `protobuf:"bytes,1,rep,name=attachment_ids,json=attachmentIds,proto3" json:"attachment_ids,omitempty"`
+	IncludeDownloadUrls bool                   `protobuf:"varint,2,opt,name=include_download_urls,json=includeDownloadUrls,proto3" json:"include_download_urls,omitempty"`
+	unknownFields       protoimpl.UnknownFields
+	sizeCache           protoimpl.SizeCache
+}
+
+func (x *GetAttachmentsRequest) Reset() {
+	*x = GetAttachmentsRequest{}
+	mi := &file_media_v1_media_api_proto_msgTypes[4]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetAttachmentsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetAttachmentsRequest) ProtoMessage() {}
+
+func (x *GetAttachmentsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_media_v1_media_api_proto_msgTypes[4]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetAttachmentsRequest.ProtoReflect.Descriptor instead.
+func (*GetAttachmentsRequest) Descriptor() ([]byte, []int) {
+	return file_media_v1_media_api_proto_rawDescGZIP(), []int{4}
+}
+
+func (x *GetAttachmentsRequest) GetAttachmentIds() []string {
+	if x != nil {
+		return x.AttachmentIds
+	}
+	return nil
+}
+
+func (x *GetAttachmentsRequest) GetIncludeDownloadUrls() bool {
+	if x != nil {
+		return x.IncludeDownloadUrls
+	}
+	return false
+}
+
+type GetAttachmentsResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Attachments   []*Attachment          `protobuf:"bytes,1,rep,name=attachments,proto3" json:"attachments,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetAttachmentsResponse) Reset() {
+	*x = GetAttachmentsResponse{}
+	mi := &file_media_v1_media_api_proto_msgTypes[5]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetAttachmentsResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetAttachmentsResponse) ProtoMessage() {}
+
+func (x *GetAttachmentsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_media_v1_media_api_proto_msgTypes[5]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetAttachmentsResponse.ProtoReflect.Descriptor instead.
+func (*GetAttachmentsResponse) Descriptor() ([]byte, []int) {
+	return file_media_v1_media_api_proto_rawDescGZIP(), []int{5}
+}
+
+func (x *GetAttachmentsResponse) GetAttachments() []*Attachment {
+	if x != nil {
+		return x.Attachments
+	}
+	return nil
+}
+
+type DownloadContentRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	AttachmentId  string                 `protobuf:"bytes,1,opt,name=attachment_id,json=attachmentId,proto3" json:"attachment_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *DownloadContentRequest) Reset() {
+	*x = DownloadContentRequest{}
+	mi := &file_media_v1_media_api_proto_msgTypes[6]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *DownloadContentRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DownloadContentRequest) ProtoMessage() {}
+
+func (x *DownloadContentRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_media_v1_media_api_proto_msgTypes[6]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DownloadContentRequest.ProtoReflect.Descriptor instead.
+func (*DownloadContentRequest) Descriptor() ([]byte, []int) {
+	return file_media_v1_media_api_proto_rawDescGZIP(), []int{6}
+}
+
+func (x *DownloadContentRequest) GetAttachmentId() string {
+	if x != nil {
+		return x.AttachmentId
+	}
+	return ""
+}
+
+type DownloadContentResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Attachment    *Attachment            `protobuf:"bytes,1,opt,name=attachment,proto3" json:"attachment,omitempty"`
+	Data          []byte                 `protobuf:"bytes,2,opt,name=data,proto3" json:"data,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *DownloadContentResponse) Reset() {
+	*x = DownloadContentResponse{}
+	mi := &file_media_v1_media_api_proto_msgTypes[7]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *DownloadContentResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DownloadContentResponse) ProtoMessage() {}
+
+func (x *DownloadContentResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_media_v1_media_api_proto_msgTypes[7]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DownloadContentResponse.ProtoReflect.Descriptor instead.
+func (*DownloadContentResponse) Descriptor() ([]byte, []int) {
+	return file_media_v1_media_api_proto_rawDescGZIP(), []int{7}
+}
+
+func (x *DownloadContentResponse) GetAttachment() *Attachment {
+	if x != nil {
+		return x.Attachment
+	}
+	return nil
+}
+
+func (x *DownloadContentResponse) GetData() []byte {
+	if x != nil {
+		return x.Data
+	}
+	return nil
+}
+
+var File_media_v1_media_api_proto protoreflect.FileDescriptor
+
+const file_media_v1_media_api_proto_rawDesc = "" +
+	"\n" +
+	"\x18media/v1/media_api.proto\x12\x11realchat.media.v1\x1a\x14media/v1/media.proto\"\x99\x01\n" +
+	"\x1aCreateUploadSessionRequest\x12\"\n" +
+	"\rowner_user_id\x18\x01 \x01(\tR\vownerUserId\x12\x1b\n" +
+	"\tfile_name\x18\x02 \x01(\tR\bfileName\x12\x1b\n" +
+	"\tmime_type\x18\x03 \x01(\tR\bmimeType\x12\x1d\n" +
+	"\n" +
+	"size_bytes\x18\x04 \x01(\x03R\tsizeBytes\"Y\n" +
+	"\x1bCreateUploadSessionResponse\x12:\n" +
+	"\asession\x18\x01 \x01(\v2 .realchat.media.v1.UploadSessionR\asession\"G\n" +
+	"\x14UploadContentRequest\x12\x1b\n" +
+	"\tupload_id\x18\x01 \x01(\tR\buploadId\x12\x12\n" +
+	"\x04data\x18\x02 \x01(\fR\x04data\"V\n" +
+	"\x15UploadContentResponse\x12=\n" +
+	"\n" +
+	"attachment\x18\x01 \x01(\v2\x1d.realchat.media.v1.AttachmentR\n" +
+	"attachment\"r\n" +
+	"\x15GetAttachmentsRequest\x12%\n" +
+	"\x0eattachment_ids\x18\x01 \x03(\tR\rattachmentIds\x122\n" +
+	"\x15include_download_urls\x18\x02 \x01(\bR\x13includeDownloadUrls\"Y\n" +
+	"\x16GetAttachmentsResponse\x12?\n" +
+	"\vattachments\x18\x01 \x03(\v2\x1d.realchat.media.v1.AttachmentR\vattachments\"=\n" +
+	"\x16DownloadContentRequest\x12#\n" +
+	"\rattachment_id\x18\x01 \x01(\tR\fattachmentId\"l\n" +
+	"\x17DownloadContentResponse\x12=\n" +
+	"\n" +
+	"attachment\x18\x01 \x01(\v2\x1d.realchat.media.v1.AttachmentR\n" +
+	"attachment\x12\x12\n" +
+	"\x04data\x18\x02 \x01(\fR\x04data2\xb9\x03\n" +
+	"\bMediaApi\x12t\n" +
+	"\x13CreateUploadSession\x12-.realchat.media.v1.CreateUploadSessionRequest\x1a..realchat.media.v1.CreateUploadSessionResponse\x12d\n" +
+	"\rUploadContent\x12'.realchat.media.v1.UploadContentRequest\x1a(.realchat.media.v1.UploadContentResponse(\x01\x12e\n" +
+	"\x0eGetAttachments\x12(.realchat.media.v1.GetAttachmentsRequest\x1a).realchat.media.v1.GetAttachmentsResponse\x12j\n" +
+	"\x0fDownloadContent\x12).realchat.media.v1.DownloadContentRequest\x1a*.realchat.media.v1.DownloadContentResponse0\x01BJZHgithub.com/SARVESHVARADKAR123/RealChat/contracts/gen/go/media/v1;mediav1b\x06proto3"
+
+var (
+	file_media_v1_media_api_proto_rawDescOnce sync.Once
+	file_media_v1_media_api_proto_rawDescData []byte
+)
+
+func file_media_v1_media_api_proto_rawDescGZIP() []byte {
+	file_media_v1_media_api_proto_rawDescOnce.Do(func() {
+		file_media_v1_media_api_proto_rawDescData = protoimpl.X.CompressGZIP(unsafe.Slice(unsafe.StringData(file_media_v1_media_api_proto_rawDesc), len(file_media_v1_media_api_proto_rawDesc)))
+	})
+	return file_media_v1_media_api_proto_rawDescData
+}
+
+var file_media_v1_media_api_proto_msgTypes = make([]protoimpl.MessageInfo, 8)
+var file_media_v1_media_api_proto_goTypes = []any{
+	(*CreateUploadSessionRequest)(nil),  // 0: realchat.media.v1.CreateUploadSessionRequest
+	(*CreateUploadSessionResponse)(nil), // 1: realchat.media.v1.CreateUploadSessionResponse
+	(*UploadContentRequest)(nil),        // 2: realchat.media.v1.UploadContentRequest
+	(*UploadContentResponse)(nil),       // 3: realchat.media.v1.UploadContentResponse
+	(*GetAttachmentsRequest)(nil),       // 4: realchat.media.v1.GetAttachmentsRequest
+	(*GetAttachmentsResponse)(nil),      // 5: realchat.media.v1.GetAttachmentsResponse
+	(*DownloadContentRequest)(nil),      // 6: realchat.media.v1.DownloadContentRequest
+	(*DownloadContentResponse)(nil),     // 7: realchat.media.v1.DownloadContentResponse
+	(*UploadSession)(nil),               // 8: realchat.media.v1.UploadSession
+	(*Attachment)(nil),                  // 9: realchat.media.v1.Attachment
+}
+var file_media_v1_media_api_proto_depIdxs = []int32{
+	8, // 0: realchat.media.v1.CreateUploadSessionResponse.session:type_name -> realchat.media.v1.UploadSession
+	9, // 1: realchat.media.v1.UploadContentResponse.attachment:type_name -> realchat.media.v1.Attachment
+	9, // 2: realchat.media.v1.GetAttachmentsResponse.attachments:type_name -> realchat.media.v1.Attachment
+	9, // 3: realchat.media.v1.DownloadContentResponse.attachment:type_name -> realchat.media.v1.Attachment
+	0, // 4: realchat.media.v1.MediaApi.CreateUploadSession:input_type -> realchat.media.v1.CreateUploadSessionRequest
+	2, // 5: realchat.media.v1.MediaApi.UploadContent:input_type -> realchat.media.v1.UploadContentRequest
+	4, // 6: realchat.media.v1.MediaApi.GetAttachments:input_type -> realchat.media.v1.GetAttachmentsRequest
+	6, // 7: realchat.media.v1.MediaApi.DownloadContent:input_type -> realchat.media.v1.DownloadContentRequest
+	1, // 8: realchat.media.v1.MediaApi.CreateUploadSession:output_type -> realchat.media.v1.CreateUploadSessionResponse
+	3, // 9: realchat.media.v1.MediaApi.UploadContent:output_type -> realchat.media.v1.UploadContentResponse
+	5, // 10: realchat.media.v1.MediaApi.GetAttachments:output_type -> realchat.media.v1.GetAttachmentsResponse
+	7, // 11: realchat.media.v1.MediaApi.DownloadContent:output_type -> realchat.media.v1.DownloadContentResponse
+	8, // [8:12] is the sub-list for method output_type
+	4, // [4:8] is the sub-list for method input_type
+	4, // [4:4] is the sub-list for extension type_name
+	4, // [4:4] is the sub-list for extension extendee
+	0, // [0:4] is the sub-list for field type_name
+}
+
+func init() { file_media_v1_media_api_proto_init() }
+func file_media_v1_media_api_proto_init() {
+	if File_media_v1_media_api_proto != nil {
+		return
+	}
+	file_media_v1_media_proto_init()
+	type x struct{}
+	out := protoimpl.TypeBuilder{
+		File: protoimpl.DescBuilder{
+			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
+			RawDescriptor: unsafe.Slice(unsafe.StringData(file_media_v1_media_api_proto_rawDesc), len(file_media_v1_media_api_proto_rawDesc)),
+			NumEnums:      0,
+			NumMessages:   8,
+			NumExtensions: 0,
+			NumServices:   1,
+		},
+		GoTypes:           file_media_v1_media_api_proto_goTypes,
+		DependencyIndexes: file_media_v1_media_api_proto_depIdxs,
+		MessageInfos:      file_media_v1_media_api_proto_msgTypes,
+	}.Build()
+	File_media_v1_media_api_proto = out.File
+	file_media_v1_media_api_proto_goTypes = nil
+	file_media_v1_media_api_proto_depIdxs = nil
+}
@@ -0,0 +1,248 @@
+// Code generated by protoc-gen-go-grpc. DO NOT EDIT.
+// versions:
+// - protoc-gen-go-grpc v1.6.1
+// - protoc             (unknown)
+// source: media/v1/media_api.proto
+
+package mediav1
+
+import (
+	context "context"
+	grpc "google.golang.org/grpc"
+	codes "google.golang.org/grpc/codes"
+	status "google.golang.org/grpc/status"
+)
+
+// This is a compile-time assertion to ensure that this generated file
+// is compatible with the grpc package it is being compiled against.
+// Requires gRPC-Go v1.64.0 or later.
+const _ = grpc.SupportPackageIsVersion9
+
+const (
+	MediaApi_CreateUploadSession_FullMethodName = "/realchat.media.v1.MediaApi/CreateUploadSession"
+	MediaApi_UploadContent_FullMethodName       = "/realchat.media.v1.MediaApi/UploadContent"
+	MediaApi_GetAttachments_FullMethodName      = "/realchat.media.v1.MediaApi/GetAttachments"
+	MediaApi_DownloadContent_FullMethodName     = "/realchat.media.v1.MediaApi/DownloadContent"
+)
+
+// MediaApiClient is the client API for MediaApi service.
+//
+// For semantics around ctx use and closing/ending streaming RPCs, please refer to https://pkg.go.dev/google.golang.org/grpc/?tab=doc#ClientConn.NewStream.
+type MediaApiClient interface {
+	// CreateUploadSession validates the declared size and MIME type and
+	// reserves an upload for the caller.
+	CreateUploadSession(ctx context.Context, in *CreateUploadSessionRequest, opts ...grpc.CallOption) (*CreateUploadSessionResponse, error)
+	// UploadContent streams the bytes of a session. The first chunk must carry
+	// upload_id; the session is completed into an Attachment when the stream ends.
+	UploadContent(ctx context.Context, opts ...grpc.CallOption) (grpc.ClientStreamingClient[UploadContentRequest, UploadContentResponse], error)
+	// GetAttachments is for internal callers that have already authorized the
+	// read. Unknown IDs are omitted from the response.
+	GetAttachments(ctx context.Context, in *GetAttachmentsRequest, opts ...grpc.CallOption) (*GetAttachmentsResponse, error)
+	// DownloadContent streams a blob. The first message carries the attachment,
+	// the rest carry data. Callers must have verified a signed download URL.
+	DownloadContent(ctx context.Context, in *DownloadContentRequest, opts ...grpc.CallOption) (grpc.ServerStreamingClient[DownloadContentResponse], error)
+}
+
+type mediaApiClient struct {
+	cc grpc.ClientConnInterface
+}
+
+func NewMediaApiClient(cc grpc.ClientConnInterface) MediaApiClient {
+	return &mediaApiClient{cc}
+}
+
+func (c *mediaApiClient) CreateUploadSession(ctx context.Context, in *CreateUploadSessionRequest, opts ...grpc.CallOption) (*CreateUploadSessionResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(CreateUploadSessionResponse)
+	err := c.cc.Invoke(ctx, MediaApi_CreateUploadSession_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *mediaApiClient) UploadContent(ctx context.Context, opts ...grpc.CallOption) (grpc.ClientStreamingClient[UploadContentRequest, UploadContentResponse], error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	stream, err := c.cc.NewStream(ctx, &MediaApi_ServiceDesc.Streams[0], MediaApi_UploadContent_FullMethodName, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &grpc.GenericClientStream[UploadContentRequest, UploadContentResponse]{ClientStream: stream}
+	return x, nil
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type MediaApi_UploadContentClient = grpc.ClientStreamingClient[UploadContentRequest, UploadContentResponse]
+
+func (c *mediaApiClient) GetAttachments(ctx context.Context, in *GetAttachmentsRequest, opts ...grpc.CallOption) (*GetAttachmentsResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(GetAttachmentsResponse)
+	err := c.cc.Invoke(ctx, MediaApi_GetAttachments_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *mediaApiClient) DownloadContent(ctx context.Context, in *DownloadContentRequest, opts ...grpc.CallOption) (grpc.ServerStreamingClient[DownloadContentResponse], error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	stream, err := c.cc.NewStream(ctx, &MediaApi_ServiceDesc.Streams[1], MediaApi_DownloadContent_FullMethodName, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &grpc.GenericClientStream[DownloadContentRequest, DownloadContentResponse]{ClientStream: stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type MediaApi_DownloadContentClient = grpc.ServerStreamingClient[DownloadContentResponse]
+
+// MediaApiServer is the server API for MediaApi service.
+// All implementations must embed UnimplementedMediaApiServer
+// for forward compatibility.
+type MediaApiServer interface {
+	// CreateUploadSession validates the declared size and MIME type and
+	// reserves an upload for the caller.
+	CreateUploadSession(context.Context, *CreateUploadSessionRequest) (*CreateUploadSessionResponse, error)
+	// UploadContent streams the bytes of a session. The first chunk must carry
+	// upload_id; the session is completed into an Attachment when the stream ends.
+	UploadContent(grpc.ClientStreamingServer[UploadContentRequest, UploadContentResponse]) error
+	// GetAttachments is for internal callers that have already authorized the
+	// read. Unknown IDs are omitted from the response.
+	GetAttachments(context.Context, *GetAttachmentsRequest) (*GetAttachmentsResponse, error)
+	// DownloadContent streams a blob. The first message carries the attachment,
+	// the rest carry data. Callers must have verified a signed download URL.
+	DownloadContent(*DownloadContentRequest, grpc.ServerStreamingServer[DownloadContentResponse]) error
+	mustEmbedUnimplementedMediaApiServer()
+}
+
+// UnimplementedMediaApiServer must be embedded to have
+// forward compatible implementations.
+//
+// NOTE: this should be embedded by value instead of pointer to avoid a nil
+// pointer dereference when methods are called.
+type UnimplementedMediaApiServer struct{}
+
+func (UnimplementedMediaApiServer) CreateUploadSession(context.Context, *CreateUploadSessionRequest) (*CreateUploadSessionResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method CreateUploadSession not implemented")
+}
+func (UnimplementedMediaApiServer) UploadContent(grpc.ClientStreamingServer[UploadContentRequest, UploadContentResponse]) error {
+	return status.Error(codes.Unimplemented, "method UploadContent not implemented")
+}
+func (UnimplementedMediaApiServer) GetAttachments(context.Context, *GetAttachmentsRequest) (*GetAttachmentsResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method GetAttachments not implemented")
+}
+func (UnimplementedMediaApiServer) DownloadContent(*DownloadContentRequest, grpc.ServerStreamingServer[DownloadContentResponse]) error {
+	return status.Error(codes.Unimplemented, "method DownloadContent not implemented")
+}
+func (UnimplementedMediaApiServer) mustEmbedUnimplementedMediaApiServer() {}
+func (UnimplementedMediaApiServer) testEmbeddedByValue()                  {}
+
+// UnsafeMediaApiServer may be embedded to opt out of forward compatibility for this service.
+// Use of this interface is not recommended, as added methods to MediaApiServer will
+// result in compilation errors.
+type UnsafeMediaApiServer interface {
+	mustEmbedUnimplementedMediaApiServer()
+}
+
+func RegisterMediaApiServer(s grpc.ServiceRegistrar, srv MediaApiServer) {
+	// If the following call panics, it indicates UnimplementedMediaApiServer was
+	// embedded by pointer and is nil.  This will cause panics if an
+	// unimplemented method is ever invoked, so we test this at initialization
+	// time to prevent it from happening at runtime later due to I/O.
+	if t, ok := srv.(interface{ testEmbeddedByValue() }); ok {
+		t.testEmbeddedByValue()
+	}
+	s.RegisterService(&MediaApi_ServiceDesc, srv)
+}
+
+func _MediaApi_CreateUploadSession_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(CreateUploadSessionRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(MediaApiServer).CreateUploadSession(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: MediaApi_CreateUploadSession_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(MediaApiServer).CreateUploadSession(ctx, req.(*CreateUploadSessionRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _MediaApi_UploadContent_Handler(srv interface{}, stream grpc.ServerStream) error {
+	return srv.(MediaApiServer).UploadContent(&grpc.GenericServerStream[UploadContentRequest, UploadContentResponse]{ServerStream: stream})
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type MediaApi_UploadContentServer = grpc.ClientStreamingServer[UploadContentRequest, UploadContentResponse]
+
+func _MediaApi_GetAttachments_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetAttachmentsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(MediaApiServer).GetAttachments(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: MediaApi_GetAttachments_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(MediaApiServer).GetAttachments(ctx, req.(*GetAttachmentsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _MediaApi_DownloadContent_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(DownloadContentRequest)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(MediaApiServer).DownloadContent(m, &grpc.GenericServerStream[DownloadContentRequest, DownloadContentResponse]{ServerStream: stream})
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type MediaApi_DownloadContentServer = grpc.ServerStreamingServer[DownloadContentResponse]
+
+// MediaApi_ServiceDesc is the grpc.ServiceDesc for MediaApi service.
+// It's only intended for direct use with grpc.RegisterService,
+// and not to be introspected or modified (even as a copy)
+var MediaApi_ServiceDesc = grpc.ServiceDesc{
+	ServiceName: "realchat.media.v1.MediaApi",
+	HandlerType: (*MediaApiServer)(nil),
+	Methods: []grpc.MethodDesc{
+		{
+			MethodName: "CreateUploadSession",
+			Handler:    _MediaApi_CreateUploadSession_Handler,
+		},
+		{
+			MethodName: "GetAttachments",
+			Handler:    _MediaApi_GetAttachments_Handler,
+		},
+	},
+	Streams: []grpc.StreamDesc{
+		{
+			StreamName:    "UploadContent",
+			Handler:       _MediaApi_UploadContent_Handler,
+			ClientStreams: true,
+		},
+		{
+			StreamName:    "DownloadContent",
+			Handler:       _MediaApi_DownloadContent_Handler,
+			ServerStreams: true,
+		},
+	},
+	Metadata: "media/v1/media_api.proto",
+}
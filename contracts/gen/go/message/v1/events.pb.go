@@ -9,6 +9,7 @@ package messagev1
 import (
 	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
 	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
+	timestamppb "google.golang.org/protobuf/types/known/timestamppb"
 	reflect "reflect"
 	sync "sync"
 	unsafe "unsafe"
@@ -22,10 +23,13 @@ const (
 )
 
 type MessageSentEvent struct {
-	state         protoimpl.MessageState `protogen:"open.v1"`
-	Message       *Message               `protobuf:"bytes,1,opt,name=message,proto3" json:"message,omitempty"`
-	unknownFields protoimpl.UnknownFields
-	sizeCache     protoimpl.SizeCache
+	state   protoimpl.MessageState `protogen:"open.v1"`
+	Message *Message               `protobuf:"bytes,1,opt,name=message,proto3" json:"message,omitempty"`
+	// Participants mentioned by the message, @all already expanded. The
+	// sender is never included.
+	MentionedUserIds []string `protobuf:"bytes,2,rep,name=mentioned_user_ids,json=mentionedUserIds,proto3" json:"mentioned_user_ids,omitempty"`
+	unknownFields    protoimpl.UnknownFields
+	sizeCache        protoimpl.SizeCache
 }
 
 func (x *MessageSentEvent) Reset() {
@@ -65,6 +69,13 @@ func (x *MessageSentEvent) GetMessage() *Message {
 	return nil
 }
 
+func (x *MessageSentEvent) GetMentionedUserIds() []string {
+	if x != nil {
+		return x.MentionedUserIds
+	}
+	return nil
+}
+
 type MessageDeletedEvent struct {
 	state          protoimpl.MessageState `protogen:"open.v1"`
 	ConversationId string                 `protobuf:"bytes,1,opt,name=conversation_id,json=conversationId,proto3" json:"conversation_id,omitempty"`
@@ -117,17 +128,382 @@ func (x *MessageDeletedEvent) GetMessageId() string {
 	return ""
 }
 
+// MessageEditedEvent carries the message with its latest content and edited_at.
+type MessageEditedEvent struct {
+	state   protoimpl.MessageState `protogen:"open.v1"`
+	Message *Message               `protobuf:"bytes,1,opt,name=message,proto3" json:"message,omitempty"`
+	// Participants the edit newly mentions, @all already expanded. Users the
+	// message already mentioned before the edit are left out, so they aren't
+	// notified twice.
+	MentionedUserIds []string `protobuf:"bytes,2,rep,name=mentioned_user_ids,json=mentionedUserIds,proto3" json:"mentioned_user_ids,omitempty"`
+	unknownFields    protoimpl.UnknownFields
+	sizeCache        protoimpl.SizeCache
+}
+
+func (x *MessageEditedEvent) Reset() {
+	*x = MessageEditedEvent{}
+	mi := &file_message_v1_events_proto_msgTypes[2]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *MessageEditedEvent) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*MessageEditedEvent) ProtoMessage() {}
+
+func (x *MessageEditedEvent) ProtoReflect() protoreflect.Message {
+	mi := &file_message_v1_events_proto_msgTypes[2]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use MessageEditedEvent.ProtoReflect.Descriptor instead.
+func (*MessageEditedEvent) Descriptor() ([]byte, []int) {
+	return file_message_v1_events_proto_rawDescGZIP(), []int{2}
+}
+
+func (x *MessageEditedEvent) GetMessage() *Message {
+	if x != nil {
+		return x.Message
+	}
+	return nil
+}
+
+func (x *MessageEditedEvent) GetMentionedUserIds() []string {
+	if x != nil {
+		return x.MentionedUserIds
+	}
+	return nil
+}
+
+// ReactionChangedEvent is emitted when a user adds or removes a reaction.
+// reactions holds the aggregated counts after the change.
+type ReactionChangedEvent struct {
+	state          protoimpl.MessageState `protogen:"open.v1"`
+	ConversationId string                 `protobuf:"bytes,1,opt,name=conversation_id,json=conversationId,proto3" json:"conversation_id,omitempty"`
+	MessageId      string                 `protobuf:"bytes,2,opt,name=message_id,json=messageId,proto3" json:"message_id,omitempty"`
+	UserId         string                 `protobuf:"bytes,3,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+	Emoji          string                 `protobuf:"bytes,4,opt,name=emoji,proto3" json:"emoji,omitempty"`
+	Added          bool                   `protobuf:"varint,5,opt,name=added,proto3" json:"added,omitempty"`
+	Reactions      []*ReactionSummary     `protobuf:"bytes,6,rep,name=reactions,proto3" json:"reactions,omitempty"`
+	unknownFields  protoimpl.UnknownFields
+	sizeCache      protoimpl.SizeCache
+}
+
+func (x *ReactionChangedEvent) Reset() {
+	*x = ReactionChangedEvent{}
+	mi := &file_message_v1_events_proto_msgTypes[3]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ReactionChangedEvent) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ReactionChangedEvent) ProtoMessage() {}
+
+func (x *ReactionChangedEvent) ProtoReflect() protoreflect.Message {
+	mi := &file_message_v1_events_proto_msgTypes[3]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ReactionChangedEvent.ProtoReflect.Descriptor instead.
+func (*ReactionChangedEvent) Descriptor() ([]byte, []int) {
+	return file_message_v1_events_proto_rawDescGZIP(), []int{3}
+}
+
+func (x *ReactionChangedEvent) GetConversationId() string {
+	if x != nil {
+		return x.ConversationId
+	}
+	return ""
+}
+
+func (x *ReactionChangedEvent) GetMessageId() string {
+	if x != nil {
+		return x.MessageId
+	}
+	return ""
+}
+
+func (x *ReactionChangedEvent) GetUserId() string {
+	if x != nil {
+		return x.UserId
+	}
+	return ""
+}
+
+func (x *ReactionChangedEvent) GetEmoji() string {
+	if x != nil {
+		return x.Emoji
+	}
+	return ""
+}
+
+func (x *ReactionChangedEvent) GetAdded() bool {
+	if x != nil {
+		return x.Added
+	}
+	return false
+}
+
+func (x *ReactionChangedEvent) GetReactions() []*ReactionSummary {
+	if x != nil {
+		return x.Reactions
+	}
+	return nil
+}
+
+// MessagePinChangedEvent is emitted when a message is pinned or unpinned,
+// including the automatic unpin when a pinned message is deleted.
+type MessagePinChangedEvent struct {
+	state          protoimpl.MessageState `protogen:"open.v1"`
+	ConversationId string                 `protobuf:"bytes,1,opt,name=conversation_id,json=conversationId,proto3" json:"conversation_id,omitempty"`
+	MessageId      string                 `protobuf:"bytes,2,opt,name=message_id,json=messageId,proto3" json:"message_id,omitempty"`
+	UserId         string                 `protobuf:"bytes,3,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+	Pinned         bool                   `protobuf:"varint,4,opt,name=pinned,proto3" json:"pinned,omitempty"`
+	ChangedAt      *timestamppb.Timestamp `protobuf:"bytes,5,opt,name=changed_at,json=changedAt,proto3" json:"changed_at,omitempty"`
+	unknownFields  protoimpl.UnknownFields
+	sizeCache      protoimpl.SizeCache
+}
+
+func (x *MessagePinChangedEvent) Reset() {
+	*x = MessagePinChangedEvent{}
+	mi := &file_message_v1_events_proto_msgTypes[4]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *MessagePinChangedEvent) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*MessagePinChangedEvent) ProtoMessage() {}
+
+func (x *MessagePinChangedEvent) ProtoReflect() protoreflect.Message {
+	mi := &file_message_v1_events_proto_msgTypes[4]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use MessagePinChangedEvent.ProtoReflect.Descriptor instead.
+func (*MessagePinChangedEvent) Descriptor() ([]byte, []int) {
+	return file_message_v1_events_proto_rawDescGZIP(), []int{4}
+}
+
+func (x *MessagePinChangedEvent) GetConversationId() string {
+	if x != nil {
+		return x.ConversationId
+	}
+	return ""
+}
+
+func (x *MessagePinChangedEvent) GetMessageId() string {
+	if x != nil {
+		return x.MessageId
+	}
+	return ""
+}
+
+func (x *MessagePinChangedEvent) GetUserId() string {
+	if x != nil {
+		return x.UserId
+	}
+	return ""
+}
+
+func (x *MessagePinChangedEvent) GetPinned() bool {
+	if x != nil {
+		return x.Pinned
+	}
+	return false
+}
+
+func (x *MessagePinChangedEvent) GetChangedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.ChangedAt
+	}
+	return nil
+}
+
+// PollTallyChangedEvent is emitted when a vote is cast or retracted and when
+// a poll is closed. poll holds the tally after the change.
+type PollTallyChangedEvent struct {
+	state          protoimpl.MessageState `protogen:"open.v1"`
+	ConversationId string                 `protobuf:"bytes,1,opt,name=conversation_id,json=conversationId,proto3" json:"conversation_id,omitempty"`
+	MessageId      string                 `protobuf:"bytes,2,opt,name=message_id,json=messageId,proto3" json:"message_id,omitempty"`
+	// The voter or closer. Empty for anonymous polls.
+	UserId        string     `protobuf:"bytes,3,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+	Poll          *PollState `protobuf:"bytes,4,opt,name=poll,proto3" json:"poll,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *PollTallyChangedEvent) Reset() {
+	*x = PollTallyChangedEvent{}
+	mi := &file_message_v1_events_proto_msgTypes[5]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *PollTallyChangedEvent) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*PollTallyChangedEvent) ProtoMessage() {}
+
+func (x *PollTallyChangedEvent) ProtoReflect() protoreflect.Message {
+	mi := &file_message_v1_events_proto_msgTypes[5]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use PollTallyChangedEvent.ProtoReflect.Descriptor instead.
+func (*PollTallyChangedEvent) Descriptor() ([]byte, []int) {
+	return file_message_v1_events_proto_rawDescGZIP(), []int{5}
+}
+
+func (x *PollTallyChangedEvent) GetConversationId() string {
+	if x != nil {
+		return x.ConversationId
+	}
+	return ""
+}
+
+func (x *PollTallyChangedEvent) GetMessageId() string {
+	if x != nil {
+		return x.MessageId
+	}
+	return ""
+}
+
+func (x *PollTallyChangedEvent) GetUserId() string {
+	if x != nil {
+		return x.UserId
+	}
+	return ""
+}
+
+func (x *PollTallyChangedEvent) GetPoll() *PollState {
+	if x != nil {
+		return x.Poll
+	}
+	return nil
+}
+
+// DraftUpdatedEvent is emitted when a user's draft changes or is cleared. It
+// is delivered only to the author's devices other than draft.device_id.
+type DraftUpdatedEvent struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Draft         *Draft                 `protobuf:"bytes,1,opt,name=draft,proto3" json:"draft,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *DraftUpdatedEvent) Reset() {
+	*x = DraftUpdatedEvent{}
+	mi := &file_message_v1_events_proto_msgTypes[6]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *DraftUpdatedEvent) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DraftUpdatedEvent) ProtoMessage() {}
+
+func (x *DraftUpdatedEvent) ProtoReflect() protoreflect.Message {
+	mi := &file_message_v1_events_proto_msgTypes[6]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DraftUpdatedEvent.ProtoReflect.Descriptor instead.
+func (*DraftUpdatedEvent) Descriptor() ([]byte, []int) {
+	return file_message_v1_events_proto_rawDescGZIP(), []int{6}
+}
+
+func (x *DraftUpdatedEvent) GetDraft() *Draft {
+	if x != nil {
+		return x.Draft
+	}
+	return nil
+}
+
 var File_message_v1_events_proto protoreflect.FileDescriptor
 
 const file_message_v1_events_proto_rawDesc = "" +
 	"\n" +
-	"\x17message/v1/events.proto\x12\x13realchat.message.v1\x1a\x18message/v1/message.proto\"J\n" +
+	"\x17message/v1/events.proto\x12\x13realchat.message.v1\x1a\x1fgoogle/protobuf/timestamp.proto\x1a\x18message/v1/message.proto\"x\n" +
 	"\x10MessageSentEvent\x126\n" +
-	"\amessage\x18\x01 \x01(\v2\x1c.realchat.message.v1.MessageR\amessage\"]\n" +
+	"\amessage\x18\x01 \x01(\v2\x1c.realchat.message.v1.MessageR\amessage\x12,\n" +
+	"\x12mentioned_user_ids\x18\x02 \x03(\tR\x10mentionedUserIds\"]\n" +
 	"\x13MessageDeletedEvent\x12'\n" +
 	"\x0fconversation_id\x18\x01 \x01(\tR\x0econversationId\x12\x1d\n" +
 	"\n" +
-	"message_id\x18\x02 \x01(\tR\tmessageIdBNZLgithub.com/SARVESHVARADKAR123/RealChat/contracts/gen/go/message/v1;messagev1b\x06proto3"
+	"message_id\x18\x02 \x01(\tR\tmessageId\"z\n" +
+	"\x12MessageEditedEvent\x126\n" +
+	"\amessage\x18\x01 \x01(\v2\x1c.realchat.message.v1.MessageR\amessage\x12,\n" +
+	"\x12mentioned_user_ids\x18\x02 \x03(\tR\x10mentionedUserIds\"\xe7\x01\n" +
+	"\x14ReactionChangedEvent\x12'\n" +
+	"\x0fconversation_id\x18\x01 \x01(\tR\x0econversationId\x12\x1d\n" +
+	"\n" +
+	"message_id\x18\x02 \x01(\tR\tmessageId\x12\x17\n" +
+	"\auser_id\x18\x03 \x01(\tR\x06userId\x12\x14\n" +
+	"\x05emoji\x18\x04 \x01(\tR\x05emoji\x12\x14\n" +
+	"\x05added\x18\x05 \x01(\bR\x05added\x12B\n" +
+	"\treactions\x18\x06 \x03(\v2$.realchat.message.v1.ReactionSummaryR\treactions\"\xcc\x01\n" +
+	"\x16MessagePinChangedEvent\x12'\n" +
+	"\x0fconversation_id\x18\x01 \x01(\tR\x0econversationId\x12\x1d\n" +
+	"\n" +
+	"message_id\x18\x02 \x01(\tR\tmessageId\x12\x17\n" +
+	"\auser_id\x18\x03 \x01(\tR\x06userId\x12\x16\n" +
+	"\x06pinned\x18\x04 \x01(\bR\x06pinned\x129\n" +
+	"\n" +
+	"changed_at\x18\x05 \x01(\v2\x1a.google.protobuf.TimestampR\tchangedAt\"\xac\x01\n" +
+	"\x15PollTallyChangedEvent\x12'\n" +
+	"\x0fconversation_id\x18\x01 \x01(\tR\x0econversationId\x12\x1d\n" +
+	"\n" +
+	"message_id\x18\x02 \x01(\tR\tmessageId\x12\x17\n" +
+	"\auser_id\x18\x03 \x01(\tR\x06userId\x122\n" +
+	"\x04poll\x18\x04 \x01(\v2\x1e.realchat.message.v1.PollStateR\x04poll\"E\n" +
+	"\x11DraftUpdatedEvent\x120\n" +
+	"\x05draft\x18\x01 \x01(\v2\x1a.realchat.message.v1.DraftR\x05draftBNZLgithub.com/SARVESHVARADKAR123/RealChat/contracts/gen/go/message/v1;messagev1b\x06proto3"
 
 var (
 	file_message_v1_events_proto_rawDescOnce sync.Once
@@ -141,19 +517,33 @@ func file_message_v1_events_proto_rawDescGZIP() []byte {
 	return file_message_v1_events_proto_rawDescData
 }
 
-var file_message_v1_events_proto_msgTypes = make([]protoimpl.MessageInfo, 2)
+var file_message_v1_events_proto_msgTypes = make([]protoimpl.MessageInfo, 7)
 var file_message_v1_events_proto_goTypes = []any{
-	(*MessageSentEvent)(nil),    // 0: realchat.message.v1.MessageSentEvent
-	(*MessageDeletedEvent)(nil), // 1: realchat.message.v1.MessageDeletedEvent
-	(*Message)(nil),             // 2: realchat.message.v1.Message
+	(*MessageSentEvent)(nil),       // 0: realchat.message.v1.MessageSentEvent
+	(*MessageDeletedEvent)(nil),    // 1: realchat.message.v1.MessageDeletedEvent
+	(*MessageEditedEvent)(nil),     // 2: realchat.message.v1.MessageEditedEvent
+	(*ReactionChangedEvent)(nil),   // 3: realchat.message.v1.ReactionChangedEvent
+	(*MessagePinChangedEvent)(nil), // 4: realchat.message.v1.MessagePinChangedEvent
+	(*PollTallyChangedEvent)(nil),  // 5: realchat.message.v1.PollTallyChangedEvent
+	(*DraftUpdatedEvent)(nil),      // 6: realchat.message.v1.DraftUpdatedEvent
+	(*Message)(nil),                // 7: realchat.message.v1.Message
+	(*ReactionSummary)(nil),        // 8: realchat.message.v1.ReactionSummary
+	(*timestamppb.Timestamp)(nil),  // 9: google.protobuf.Timestamp
+	(*PollState)(nil),              // 10: realchat.message.v1.PollState
+	(*Draft)(nil),                  // 11: realchat.message.v1.Draft
 }
 var file_message_v1_events_proto_depIdxs = []int32{
-	2, // 0: realchat.message.v1.MessageSentEvent.message:type_name -> realchat.message.v1.Message
-	1, // [1:1] is the sub-list for method output_type
-	1, // [1:1] is the sub-list for method input_type
-	1, // [1:1] is the sub-list for extension type_name
-	1, // [1:1] is the sub-list for extension extendee
-	0, // [0:1] is the sub-list for field type_name
+	7,  // 0: realchat.message.v1.MessageSentEvent.message:type_name -> realchat.message.v1.Message
+	7,  // 1: realchat.message.v1.MessageEditedEvent.message:type_name -> realchat.message.v1.Message
+	8,  // 2: realchat.message.v1.ReactionChangedEvent.reactions:type_name -> realchat.message.v1.ReactionSummary
+	9,  // 3: realchat.message.v1.MessagePinChangedEvent.changed_at:type_name -> google.protobuf.Timestamp
+	10, // 4: realchat.message.v1.PollTallyChangedEvent.poll:type_name -> realchat.message.v1.PollState
+	11, // 5: realchat.message.v1.DraftUpdatedEvent.draft:type_name -> realchat.message.v1.Draft
+	6,  // [6:6] is the sub-list for method output_type
+	6,  // [6:6] is the sub-list for method input_type
+	6,  // [6:6] is the sub-list for extension type_name
+	6,  // [6:6] is the sub-list for extension extendee
+	0,  // [0:6] is the sub-list for field type_name
 }
 
 func init() { file_message_v1_events_proto_init() }
@@ -168,7 +558,7 @@ func file_message_v1_events_proto_init() {
 			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
 			RawDescriptor: unsafe.Slice(unsafe.StringData(file_message_v1_events_proto_rawDesc), len(file_message_v1_events_proto_rawDesc)),
 			NumEnums:      0,
-			NumMessages:   2,
+			NumMessages:   7,
 			NumExtensions: 0,
 			NumServices:   0,
 		},
@@ -9,6 +9,7 @@ package messagev1
 import (
 	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
 	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
+	timestamppb "google.golang.org/protobuf/types/known/timestamppb"
 	reflect "reflect"
 	sync "sync"
 	unsafe "unsafe"
@@ -22,13 +23,24 @@ const (
 )
 
 type SendMessageRequest struct {
-	state          protoimpl.MessageState `protogen:"open.v1"`
-	ConversationId string                 `protobuf:"bytes,1,opt,name=conversation_id,json=conversationId,proto3" json:"conversation_id,omitempty"`
-	SenderUserId   string                 `protobuf:"bytes,2,opt,name=sender_user_id,json=senderUserId,proto3" json:"sender_user_id,omitempty"`
-	IdempotencyKey string                 `protobuf:"bytes,3,opt,name=idempotency_key,json=idempotencyKey,proto3" json:"idempotency_key,omitempty"`
-	MessageType    string                 `protobuf:"bytes,4,opt,name=message_type,json=messageType,proto3" json:"message_type,omitempty"`
-	Content        string                 `protobuf:"bytes,5,opt,name=content,proto3" json:"content,omitempty"`
-	MetadataJson   string                 `protobuf:"bytes,6,opt,name=metadata_json,json=metadataJson,proto3" json:"metadata_json,omitempty"`
+	state            protoimpl.MessageState `protogen:"open.v1"`
+	ConversationId   string                 `protobuf:"bytes,1,opt,name=conversation_id,json=conversationId,proto3" json:"conversation_id,omitempty"`
+	SenderUserId     string                 `protobuf:"bytes,2,opt,name=sender_user_id,json=senderUserId,proto3" json:"sender_user_id,omitempty"`
+	IdempotencyKey   string                 `protobuf:"bytes,3,opt,name=idempotency_key,json=idempotencyKey,proto3" json:"idempotency_key,omitempty"`
+	MessageType      string                 `protobuf:"bytes,4,opt,name=message_type,json=messageType,proto3" json:"message_type,omitempty"`
+	Content          string                 `protobuf:"bytes,5,opt,name=content,proto3" json:"content,omitempty"`
+	MetadataJson     string                 `protobuf:"bytes,6,opt,name=metadata_json,json=metadataJson,proto3" json:"metadata_json,omitempty"`
+	ReplyToMessageId string                 `protobuf:"bytes,7,opt,name=reply_to_message_id,json=replyToMessageId,proto3" json:"reply_to_message_id,omitempty"`
+	// Attachments previously uploaded by the sender through the media service.
+	AttachmentIds []string `protobuf:"bytes,8,rep,name=attachment_ids,json=attachmentIds,proto3" json:"attachment_ids,omitempty"`
+	// When set in the future the message is scheduled instead of sent; it is
+	// assigned a sequence and delivered once send_at is reached.
+	SendAt *timestamppb.Timestamp `protobuf:"bytes,9,opt,name=send_at,json=sendAt,proto3" json:"send_at,omitempty"`
+	// Required for encrypted messages: the sending device, and the content
+	// encrypted for each device it goes to, the sender's other devices
+	// included.
+	SenderDeviceId string              `protobuf:"bytes,10,opt,name=sender_device_id,json=senderDeviceId,proto3" json:"sender_device_id,omitempty"`
+	Ciphertexts    []*DeviceCiphertext `protobuf:"bytes,11,rep,name=ciphertexts,proto3" json:"ciphertexts,omitempty"`
 	unknownFields  protoimpl.UnknownFields
 	sizeCache      protoimpl.SizeCache
 }
@@ -105,9 +117,48 @@ func (x *SendMessageRequest) GetMetadataJson() string {
 	return ""
 }
 
+func (x *SendMessageRequest) GetReplyToMessageId() string {
+	if x != nil {
+		return x.ReplyToMessageId
+	}
+	return ""
+}
+
+func (x *SendMessageRequest) GetAttachmentIds() []string {
+	if x != nil {
+		return x.AttachmentIds
+	}
+	return nil
+}
+
+func (x *SendMessageRequest) GetSendAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.SendAt
+	}
+	return nil
+}
+
+func (x *SendMessageRequest) GetSenderDeviceId() string {
+	if x != nil {
+		return x.SenderDeviceId
+	}
+	return ""
+}
+
+func (x *SendMessageRequest) GetCiphertexts() []*DeviceCiphertext {
+	if x != nil {
+		return x.Ciphertexts
+	}
+	return nil
+}
+
 type SendMessageResponse struct {
-	state         protoimpl.MessageState `protogen:"open.v1"`
-	Message       *Message               `protobuf:"bytes,1,opt,name=message,proto3" json:"message,omitempty"`
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Exactly one of message, scheduled and held is set.
+	Message   *Message          `protobuf:"bytes,1,opt,name=message,proto3" json:"message,omitempty"`
+	Scheduled *ScheduledMessage `protobuf:"bytes,2,opt,name=scheduled,proto3" json:"scheduled,omitempty"`
+	// Set when moderation held the message for review instead of sending it.
+	Held          *HeldMessage `protobuf:"bytes,3,opt,name=held,proto3" json:"held,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
@@ -149,6 +200,20 @@ func (x *SendMessageResponse) GetMessage() *Message {
 	return nil
 }
 
+func (x *SendMessageResponse) GetScheduled() *ScheduledMessage {
+	if x != nil {
+		return x.Scheduled
+	}
+	return nil
+}
+
+func (x *SendMessageResponse) GetHeld() *HeldMessage {
+	if x != nil {
+		return x.Held
+	}
+	return nil
+}
+
 type DeleteMessageRequest struct {
 	state          protoimpl.MessageState `protogen:"open.v1"`
 	ConversationId string                 `protobuf:"bytes,1,opt,name=conversation_id,json=conversationId,proto3" json:"conversation_id,omitempty"`
@@ -245,18 +310,151 @@ func (*DeleteMessageResponse) Descriptor() ([]byte, []int) {
 	return file_message_v1_message_api_proto_rawDescGZIP(), []int{3}
 }
 
-type SyncMessagesRequest struct {
+type EditMessageRequest struct {
 	state          protoimpl.MessageState `protogen:"open.v1"`
 	ConversationId string                 `protobuf:"bytes,1,opt,name=conversation_id,json=conversationId,proto3" json:"conversation_id,omitempty"`
-	AfterSequence  int64                  `protobuf:"varint,2,opt,name=after_sequence,json=afterSequence,proto3" json:"after_sequence,omitempty"`
-	PageSize       int32                  `protobuf:"varint,3,opt,name=page_size,json=pageSize,proto3" json:"page_size,omitempty"`
+	MessageId      string                 `protobuf:"bytes,2,opt,name=message_id,json=messageId,proto3" json:"message_id,omitempty"`
+	ActorUserId    string                 `protobuf:"bytes,3,opt,name=actor_user_id,json=actorUserId,proto3" json:"actor_user_id,omitempty"`
+	Content        string                 `protobuf:"bytes,4,opt,name=content,proto3" json:"content,omitempty"`
+	MetadataJson   string                 `protobuf:"bytes,5,opt,name=metadata_json,json=metadataJson,proto3" json:"metadata_json,omitempty"`
 	unknownFields  protoimpl.UnknownFields
 	sizeCache      protoimpl.SizeCache
 }
 
+func (x *EditMessageRequest) Reset() {
+	*x = EditMessageRequest{}
+	mi := &file_message_v1_message_api_proto_msgTypes[4]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *EditMessageRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*EditMessageRequest) ProtoMessage() {}
+
+func (x *EditMessageRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_message_v1_message_api_proto_msgTypes[4]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use EditMessageRequest.ProtoReflect.Descriptor instead.
+func (*EditMessageRequest) Descriptor() ([]byte, []int) {
+	return file_message_v1_message_api_proto_rawDescGZIP(), []int{4}
+}
+
+func (x *EditMessageRequest) GetConversationId() string {
+	if x != nil {
+		return x.ConversationId
+	}
+	return ""
+}
+
+func (x *EditMessageRequest) GetMessageId() string {
+	if x != nil {
+		return x.MessageId
+	}
+	return ""
+}
+
+func (x *EditMessageRequest) GetActorUserId() string {
+	if x != nil {
+		return x.ActorUserId
+	}
+	return ""
+}
+
+func (x *EditMessageRequest) GetContent() string {
+	if x != nil {
+		return x.Content
+	}
+	return ""
+}
+
+func (x *EditMessageRequest) GetMetadataJson() string {
+	if x != nil {
+		return x.MetadataJson
+	}
+	return ""
+}
+
+type EditMessageResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Message       *Message               `protobuf:"bytes,1,opt,name=message,proto3" json:"message,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *EditMessageResponse) Reset() {
+	*x = EditMessageResponse{}
+	mi := &file_message_v1_message_api_proto_msgTypes[5]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *EditMessageResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*EditMessageResponse) ProtoMessage() {}
+
+func (x *EditMessageResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_message_v1_message_api_proto_msgTypes[5]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use EditMessageResponse.ProtoReflect.Descriptor instead.
+func (*EditMessageResponse) Descriptor() ([]byte, []int) {
+	return file_message_v1_message_api_proto_rawDescGZIP(), []int{5}
+}
+
+func (x *EditMessageResponse) GetMessage() *Message {
+	if x != nil {
+		return x.Message
+	}
+	return nil
+}
+
+// SyncMessagesRequest selects one page of history, always returned in
+// ascending sequence order. around_sequence takes precedence over
+// before_sequence, which takes precedence over after_sequence.
+type SyncMessagesRequest struct {
+	state          protoimpl.MessageState `protogen:"open.v1"`
+	ConversationId string                 `protobuf:"bytes,1,opt,name=conversation_id,json=conversationId,proto3" json:"conversation_id,omitempty"`
+	// Messages with sequence > after_sequence, oldest first.
+	AfterSequence int64 `protobuf:"varint,2,opt,name=after_sequence,json=afterSequence,proto3" json:"after_sequence,omitempty"`
+	PageSize      int32 `protobuf:"varint,3,opt,name=page_size,json=pageSize,proto3" json:"page_size,omitempty"`
+	// Messages with sequence < before_sequence, newest page first. A value past
+	// the head of the conversation (e.g. INT64_MAX) reads the latest page.
+	BeforeSequence int64 `protobuf:"varint,4,opt,name=before_sequence,json=beforeSequence,proto3" json:"before_sequence,omitempty"`
+	// A window of about page_size messages centred on around_sequence, for
+	// jumping to a message. The anchor itself is included when it exists.
+	AroundSequence int64 `protobuf:"varint,5,opt,name=around_sequence,json=aroundSequence,proto3" json:"around_sequence,omitempty"`
+	// The reading device. Encrypted messages carry its ciphertext, or none
+	// when empty.
+	DeviceId      string `protobuf:"bytes,6,opt,name=device_id,json=deviceId,proto3" json:"device_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
 func (x *SyncMessagesRequest) Reset() {
 	*x = SyncMessagesRequest{}
-	mi := &file_message_v1_message_api_proto_msgTypes[4]
+	mi := &file_message_v1_message_api_proto_msgTypes[6]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -268,7 +466,7 @@ func (x *SyncMessagesRequest) String() string {
 func (*SyncMessagesRequest) ProtoMessage() {}
 
 func (x *SyncMessagesRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_message_v1_message_api_proto_msgTypes[4]
+	mi := &file_message_v1_message_api_proto_msgTypes[6]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -281,7 +479,7 @@ func (x *SyncMessagesRequest) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use SyncMessagesRequest.ProtoReflect.Descriptor instead.
 func (*SyncMessagesRequest) Descriptor() ([]byte, []int) {
-	return file_message_v1_message_api_proto_rawDescGZIP(), []int{4}
+	return file_message_v1_message_api_proto_rawDescGZIP(), []int{6}
 }
 
 func (x *SyncMessagesRequest) GetConversationId() string {
@@ -305,16 +503,40 @@ func (x *SyncMessagesRequest) GetPageSize() int32 {
 	return 0
 }
 
+func (x *SyncMessagesRequest) GetBeforeSequence() int64 {
+	if x != nil {
+		return x.BeforeSequence
+	}
+	return 0
+}
+
+func (x *SyncMessagesRequest) GetAroundSequence() int64 {
+	if x != nil {
+		return x.AroundSequence
+	}
+	return 0
+}
+
+func (x *SyncMessagesRequest) GetDeviceId() string {
+	if x != nil {
+		return x.DeviceId
+	}
+	return ""
+}
+
 type SyncMessagesResponse struct {
-	state         protoimpl.MessageState `protogen:"open.v1"`
-	Messages      []*Message             `protobuf:"bytes,1,rep,name=messages,proto3" json:"messages,omitempty"`
+	state    protoimpl.MessageState `protogen:"open.v1"`
+	Messages []*Message             `protobuf:"bytes,1,rep,name=messages,proto3" json:"messages,omitempty"`
+	// Whether older or newer messages exist outside the returned page.
+	HasMoreBefore bool `protobuf:"varint,2,opt,name=has_more_before,json=hasMoreBefore,proto3" json:"has_more_before,omitempty"`
+	HasMoreAfter  bool `protobuf:"varint,3,opt,name=has_more_after,json=hasMoreAfter,proto3" json:"has_more_after,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
 func (x *SyncMessagesResponse) Reset() {
 	*x = SyncMessagesResponse{}
-	mi := &file_message_v1_message_api_proto_msgTypes[5]
+	mi := &file_message_v1_message_api_proto_msgTypes[7]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -326,7 +548,7 @@ func (x *SyncMessagesResponse) String() string {
 func (*SyncMessagesResponse) ProtoMessage() {}
 
 func (x *SyncMessagesResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_message_v1_message_api_proto_msgTypes[5]
+	mi := &file_message_v1_message_api_proto_msgTypes[7]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -339,7 +561,7 @@ func (x *SyncMessagesResponse) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use SyncMessagesResponse.ProtoReflect.Descriptor instead.
 func (*SyncMessagesResponse) Descriptor() ([]byte, []int) {
-	return file_message_v1_message_api_proto_rawDescGZIP(), []int{5}
+	return file_message_v1_message_api_proto_rawDescGZIP(), []int{7}
 }
 
 func (x *SyncMessagesResponse) GetMessages() []*Message {
@@ -349,37 +571,3591 @@ func (x *SyncMessagesResponse) GetMessages() []*Message {
 	return nil
 }
 
-var File_message_v1_message_api_proto protoreflect.FileDescriptor
+func (x *SyncMessagesResponse) GetHasMoreBefore() bool {
+	if x != nil {
+		return x.HasMoreBefore
+	}
+	return false
+}
 
-const file_message_v1_message_api_proto_rawDesc = "" +
-	"\n" +
-	"\x1cmessage/v1/message_api.proto\x12\x13realchat.message.v1\x1a\x18message/v1/message.proto\"\xee\x01\n" +
-	"\x12SendMessageRequest\x12'\n" +
-	"\x0fconversation_id\x18\x01 \x01(\tR\x0econversationId\x12$\n" +
-	"\x0esender_user_id\x18\x02 \x01(\tR\fsenderUserId\x12'\n" +
-	"\x0fidempotency_key\x18\x03 \x01(\tR\x0eidempotencyKey\x12!\n" +
-	"\fmessage_type\x18\x04 \x01(\tR\vmessageType\x12\x18\n" +
-	"\acontent\x18\x05 \x01(\tR\acontent\x12#\n" +
-	"\rmetadata_json\x18\x06 \x01(\tR\fmetadataJson\"M\n" +
-	"\x13SendMessageResponse\x126\n" +
-	"\amessage\x18\x01 \x01(\v2\x1c.realchat.message.v1.MessageR\amessage\"\x82\x01\n" +
-	"\x14DeleteMessageRequest\x12'\n" +
-	"\x0fconversation_id\x18\x01 \x01(\tR\x0econversationId\x12\x1d\n" +
-	"\n" +
-	"message_id\x18\x02 \x01(\tR\tmessageId\x12\"\n" +
-	"\ractor_user_id\x18\x03 \x01(\tR\vactorUserId\"\x17\n" +
-	"\x15DeleteMessageResponse\"\x82\x01\n" +
-	"\x13SyncMessagesRequest\x12'\n" +
-	"\x0fconversation_id\x18\x01 \x01(\tR\x0econversationId\x12%\n" +
-	"\x0eafter_sequence\x18\x02 \x01(\x03R\rafterSequence\x12\x1b\n" +
-	"\tpage_size\x18\x03 \x01(\x05R\bpageSize\"P\n" +
-	"\x14SyncMessagesResponse\x128\n" +
-	"\bmessages\x18\x01 \x03(\v2\x1c.realchat.message.v1.MessageR\bmessages2\xbb\x02\n" +
-	"\n" +
-	"MessageApi\x12`\n" +
-	"\vSendMessage\x12'.realchat.message.v1.SendMessageRequest\x1a(.realchat.message.v1.SendMessageResponse\x12f\n" +
-	"\rDeleteMessage\x12).realchat.message.v1.DeleteMessageRequest\x1a*.realchat.message.v1.DeleteMessageResponse\x12c\n" +
-	"\fSyncMessages\x12(.realchat.message.v1.SyncMessagesRequest\x1a).realchat.message.v1.SyncMessagesResponseBNZLgithub.com/SARVESHVARADKAR123/RealChat/contracts/gen/go/message/v1;messagev1b\x06proto3"
+func (x *SyncMessagesResponse) GetHasMoreAfter() bool {
+	if x != nil {
+		return x.HasMoreAfter
+	}
+	return false
+}
+
+type ListThreadRepliesRequest struct {
+	state          protoimpl.MessageState `protogen:"open.v1"`
+	ConversationId string                 `protobuf:"bytes,1,opt,name=conversation_id,json=conversationId,proto3" json:"conversation_id,omitempty"`
+	ThreadRootId   string                 `protobuf:"bytes,2,opt,name=thread_root_id,json=threadRootId,proto3" json:"thread_root_id,omitempty"`
+	AfterSequence  int64                  `protobuf:"varint,3,opt,name=after_sequence,json=afterSequence,proto3" json:"after_sequence,omitempty"`
+	PageSize       int32                  `protobuf:"varint,4,opt,name=page_size,json=pageSize,proto3" json:"page_size,omitempty"`
+	unknownFields  protoimpl.UnknownFields
+	sizeCache      protoimpl.SizeCache
+}
+
+func (x *ListThreadRepliesRequest) Reset() {
+	*x = ListThreadRepliesRequest{}
+	mi := &file_message_v1_message_api_proto_msgTypes[8]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListThreadRepliesRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListThreadRepliesRequest) ProtoMessage() {}
+
+func (x *ListThreadRepliesRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_message_v1_message_api_proto_msgTypes[8]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListThreadRepliesRequest.ProtoReflect.Descriptor instead.
+func (*ListThreadRepliesRequest) Descriptor() ([]byte, []int) {
+	return file_message_v1_message_api_proto_rawDescGZIP(), []int{8}
+}
+
+func (x *ListThreadRepliesRequest) GetConversationId() string {
+	if x != nil {
+		return x.ConversationId
+	}
+	return ""
+}
+
+func (x *ListThreadRepliesRequest) GetThreadRootId() string {
+	if x != nil {
+		return x.ThreadRootId
+	}
+	return ""
+}
+
+func (x *ListThreadRepliesRequest) GetAfterSequence() int64 {
+	if x != nil {
+		return x.AfterSequence
+	}
+	return 0
+}
+
+func (x *ListThreadRepliesRequest) GetPageSize() int32 {
+	if x != nil {
+		return x.PageSize
+	}
+	return 0
+}
+
+type ListThreadRepliesResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Root          *Message               `protobuf:"bytes,1,opt,name=root,proto3" json:"root,omitempty"`
+	Replies       []*Message             `protobuf:"bytes,2,rep,name=replies,proto3" json:"replies,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListThreadRepliesResponse) Reset() {
+	*x = ListThreadRepliesResponse{}
+	mi := &file_message_v1_message_api_proto_msgTypes[9]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListThreadRepliesResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListThreadRepliesResponse) ProtoMessage() {}
+
+func (x *ListThreadRepliesResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_message_v1_message_api_proto_msgTypes[9]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListThreadRepliesResponse.ProtoReflect.Descriptor instead.
+func (*ListThreadRepliesResponse) Descriptor() ([]byte, []int) {
+	return file_message_v1_message_api_proto_rawDescGZIP(), []int{9}
+}
+
+func (x *ListThreadRepliesResponse) GetRoot() *Message {
+	if x != nil {
+		return x.Root
+	}
+	return nil
+}
+
+func (x *ListThreadRepliesResponse) GetReplies() []*Message {
+	if x != nil {
+		return x.Replies
+	}
+	return nil
+}
+
+type AddReactionRequest struct {
+	state          protoimpl.MessageState `protogen:"open.v1"`
+	ConversationId string                 `protobuf:"bytes,1,opt,name=conversation_id,json=conversationId,proto3" json:"conversation_id,omitempty"`
+	MessageId      string                 `protobuf:"bytes,2,opt,name=message_id,json=messageId,proto3" json:"message_id,omitempty"`
+	ActorUserId    string                 `protobuf:"bytes,3,opt,name=actor_user_id,json=actorUserId,proto3" json:"actor_user_id,omitempty"`
+	Emoji          string                 `protobuf:"bytes,4,opt,name=emoji,proto3" json:"emoji,omitempty"`
+	unknownFields  protoimpl.UnknownFields
+	sizeCache      protoimpl.SizeCache
+}
+
+func (x *AddReactionRequest) Reset() {
+	*x = AddReactionRequest{}
+	mi := &file_message_v1_message_api_proto_msgTypes[10]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *AddReactionRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*AddReactionRequest) ProtoMessage() {}
+
+func (x *AddReactionRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_message_v1_message_api_proto_msgTypes[10]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use AddReactionRequest.ProtoReflect.Descriptor instead.
+func (*AddReactionRequest) Descriptor() ([]byte, []int) {
+	return file_message_v1_message_api_proto_rawDescGZIP(), []int{10}
+}
+
+func (x *AddReactionRequest) GetConversationId() string {
+	if x != nil {
+		return x.ConversationId
+	}
+	return ""
+}
+
+func (x *AddReactionRequest) GetMessageId() string {
+	if x != nil {
+		return x.MessageId
+	}
+	return ""
+}
+
+func (x *AddReactionRequest) GetActorUserId() string {
+	if x != nil {
+		return x.ActorUserId
+	}
+	return ""
+}
+
+func (x *AddReactionRequest) GetEmoji() string {
+	if x != nil {
+		return x.Emoji
+	}
+	return ""
+}
+
+type AddReactionResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Reactions     []*ReactionSummary     `protobuf:"bytes,1,rep,name=reactions,proto3" json:"reactions,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *AddReactionResponse) Reset() {
+	*x = AddReactionResponse{}
+	mi := &file_message_v1_message_api_proto_msgTypes[11]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *AddReactionResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*AddReactionResponse) ProtoMessage() {}
+
+func (x *AddReactionResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_message_v1_message_api_proto_msgTypes[11]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use AddReactionResponse.ProtoReflect.Descriptor instead.
+func (*AddReactionResponse) Descriptor() ([]byte, []int) {
+	return file_message_v1_message_api_proto_rawDescGZIP(), []int{11}
+}
+
+func (x *AddReactionResponse) GetReactions() []*ReactionSummary {
+	if x != nil {
+		return x.Reactions
+	}
+	return nil
+}
+
+type RemoveReactionRequest struct {
+	state          protoimpl.MessageState `protogen:"open.v1"`
+	ConversationId string                 `protobuf:"bytes,1,opt,name=conversation_id,json=conversationId,proto3" json:"conversation_id,omitempty"`
+	MessageId      string                 `protobuf:"bytes,2,opt,name=message_id,json=messageId,proto3" json:"message_id,omitempty"`
+	ActorUserId    string                 `protobuf:"bytes,3,opt,name=actor_user_id,json=actorUserId,proto3" json:"actor_user_id,omitempty"`
+	Emoji          string                 `protobuf:"bytes,4,opt,name=emoji,proto3" json:"emoji,omitempty"`
+	unknownFields  protoimpl.UnknownFields
+	sizeCache      protoimpl.SizeCache
+}
+
+func (x *RemoveReactionRequest) Reset() {
+	*x = RemoveReactionRequest{}
+	mi := &file_message_v1_message_api_proto_msgTypes[12]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *RemoveReactionRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RemoveReactionRequest) ProtoMessage() {}
+
+func (x *RemoveReactionRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_message_v1_message_api_proto_msgTypes[12]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RemoveReactionRequest.ProtoReflect.Descriptor instead.
+func (*RemoveReactionRequest) Descriptor() ([]byte, []int) {
+	return file_message_v1_message_api_proto_rawDescGZIP(), []int{12}
+}
+
+func (x *RemoveReactionRequest) GetConversationId() string {
+	if x != nil {
+		return x.ConversationId
+	}
+	return ""
+}
+
+func (x *RemoveReactionRequest) GetMessageId() string {
+	if x != nil {
+		return x.MessageId
+	}
+	return ""
+}
+
+func (x *RemoveReactionRequest) GetActorUserId() string {
+	if x != nil {
+		return x.ActorUserId
+	}
+	return ""
+}
+
+func (x *RemoveReactionRequest) GetEmoji() string {
+	if x != nil {
+		return x.Emoji
+	}
+	return ""
+}
+
+type RemoveReactionResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Reactions     []*ReactionSummary     `protobuf:"bytes,1,rep,name=reactions,proto3" json:"reactions,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *RemoveReactionResponse) Reset() {
+	*x = RemoveReactionResponse{}
+	mi := &file_message_v1_message_api_proto_msgTypes[13]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *RemoveReactionResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RemoveReactionResponse) ProtoMessage() {}
+
+func (x *RemoveReactionResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_message_v1_message_api_proto_msgTypes[13]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RemoveReactionResponse.ProtoReflect.Descriptor instead.
+func (*RemoveReactionResponse) Descriptor() ([]byte, []int) {
+	return file_message_v1_message_api_proto_rawDescGZIP(), []int{13}
+}
+
+func (x *RemoveReactionResponse) GetReactions() []*ReactionSummary {
+	if x != nil {
+		return x.Reactions
+	}
+	return nil
+}
+
+type SearchMessagesRequest struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Web-search style query: words, "quoted phrases", OR and -excluded terms.
+	Query string `protobuf:"bytes,1,opt,name=query,proto3" json:"query,omitempty"`
+	// Optional filters. An empty conversation_id searches every conversation
+	// the caller belongs to.
+	ConversationId string                 `protobuf:"bytes,2,opt,name=conversation_id,json=conversationId,proto3" json:"conversation_id,omitempty"`
+	SenderUserId   string                 `protobuf:"bytes,3,opt,name=sender_user_id,json=senderUserId,proto3" json:"sender_user_id,omitempty"`
+	MessageType    string                 `protobuf:"bytes,4,opt,name=message_type,json=messageType,proto3" json:"message_type,omitempty"`
+	SentAfter      *timestamppb.Timestamp `protobuf:"bytes,5,opt,name=sent_after,json=sentAfter,proto3" json:"sent_after,omitempty"`
+	SentBefore     *timestamppb.Timestamp `protobuf:"bytes,6,opt,name=sent_before,json=sentBefore,proto3" json:"sent_before,omitempty"`
+	PageSize       int32                  `protobuf:"varint,7,opt,name=page_size,json=pageSize,proto3" json:"page_size,omitempty"`
+	// Opaque cursor from a previous response's next_page_token.
+	PageToken     string `protobuf:"bytes,8,opt,name=page_token,json=pageToken,proto3" json:"page_token,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *SearchMessagesRequest) Reset() {
+	*x = SearchMessagesRequest{}
+	mi := &file_message_v1_message_api_proto_msgTypes[14]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SearchMessagesRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SearchMessagesRequest) ProtoMessage() {}
+
+func (x *SearchMessagesRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_message_v1_message_api_proto_msgTypes[14]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SearchMessagesRequest.ProtoReflect.Descriptor instead.
+func (*SearchMessagesRequest) Descriptor() ([]byte, []int) {
+	return file_message_v1_message_api_proto_rawDescGZIP(), []int{14}
+}
+
+func (x *SearchMessagesRequest) GetQuery() string {
+	if x != nil {
+		return x.Query
+	}
+	return ""
+}
+
+func (x *SearchMessagesRequest) GetConversationId() string {
+	if x != nil {
+		return x.ConversationId
+	}
+	return ""
+}
+
+func (x *SearchMessagesRequest) GetSenderUserId() string {
+	if x != nil {
+		return x.SenderUserId
+	}
+	return ""
+}
+
+func (x *SearchMessagesRequest) GetMessageType() string {
+	if x != nil {
+		return x.MessageType
+	}
+	return ""
+}
+
+func (x *SearchMessagesRequest) GetSentAfter() *timestamppb.Timestamp {
+	if x != nil {
+		return x.SentAfter
+	}
+	return nil
+}
+
+func (x *SearchMessagesRequest) GetSentBefore() *timestamppb.Timestamp {
+	if x != nil {
+		return x.SentBefore
+	}
+	return nil
+}
+
+func (x *SearchMessagesRequest) GetPageSize() int32 {
+	if x != nil {
+		return x.PageSize
+	}
+	return 0
+}
+
+func (x *SearchMessagesRequest) GetPageToken() string {
+	if x != nil {
+		return x.PageToken
+	}
+	return ""
+}
+
+type SearchHit struct {
+	state   protoimpl.MessageState `protogen:"open.v1"`
+	Message *Message               `protobuf:"bytes,1,opt,name=message,proto3" json:"message,omitempty"`
+	// HTML excerpt of the content: the text is escaped and the matches are
+	// wrapped in <mark></mark>, so it can be rendered as is.
+	Snippet       string `protobuf:"bytes,2,opt,name=snippet,proto3" json:"snippet,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *SearchHit) Reset() {
+	*x = SearchHit{}
+	mi := &file_message_v1_message_api_proto_msgTypes[15]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SearchHit) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SearchHit) ProtoMessage() {}
+
+func (x *SearchHit) ProtoReflect() protoreflect.Message {
+	mi := &file_message_v1_message_api_proto_msgTypes[15]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SearchHit.ProtoReflect.Descriptor instead.
+func (*SearchHit) Descriptor() ([]byte, []int) {
+	return file_message_v1_message_api_proto_rawDescGZIP(), []int{15}
+}
+
+func (x *SearchHit) GetMessage() *Message {
+	if x != nil {
+		return x.Message
+	}
+	return nil
+}
+
+func (x *SearchHit) GetSnippet() string {
+	if x != nil {
+		return x.Snippet
+	}
+	return ""
+}
+
+type SearchMessagesResponse struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	Hits  []*SearchHit           `protobuf:"bytes,1,rep,name=hits,proto3" json:"hits,omitempty"`
+	// Empty when there are no more results.
+	NextPageToken string `protobuf:"bytes,2,opt,name=next_page_token,json=nextPageToken,proto3" json:"next_page_token,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *SearchMessagesResponse) Reset() {
+	*x = SearchMessagesResponse{}
+	mi := &file_message_v1_message_api_proto_msgTypes[16]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SearchMessagesResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SearchMessagesResponse) ProtoMessage() {}
+
+func (x *SearchMessagesResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_message_v1_message_api_proto_msgTypes[16]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SearchMessagesResponse.ProtoReflect.Descriptor instead.
+func (*SearchMessagesResponse) Descriptor() ([]byte, []int) {
+	return file_message_v1_message_api_proto_rawDescGZIP(), []int{16}
+}
+
+func (x *SearchMessagesResponse) GetHits() []*SearchHit {
+	if x != nil {
+		return x.Hits
+	}
+	return nil
+}
+
+func (x *SearchMessagesResponse) GetNextPageToken() string {
+	if x != nil {
+		return x.NextPageToken
+	}
+	return ""
+}
+
+type PinMessageRequest struct {
+	state          protoimpl.MessageState `protogen:"open.v1"`
+	ConversationId string                 `protobuf:"bytes,1,opt,name=conversation_id,json=conversationId,proto3" json:"conversation_id,omitempty"`
+	MessageId      string                 `protobuf:"bytes,2,opt,name=message_id,json=messageId,proto3" json:"message_id,omitempty"`
+	ActorUserId    string                 `protobuf:"bytes,3,opt,name=actor_user_id,json=actorUserId,proto3" json:"actor_user_id,omitempty"`
+	unknownFields  protoimpl.UnknownFields
+	sizeCache      protoimpl.SizeCache
+}
+
+func (x *PinMessageRequest) Reset() {
+	*x = PinMessageRequest{}
+	mi := &file_message_v1_message_api_proto_msgTypes[17]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *PinMessageRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*PinMessageRequest) ProtoMessage() {}
+
+func (x *PinMessageRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_message_v1_message_api_proto_msgTypes[17]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use PinMessageRequest.ProtoReflect.Descriptor instead.
+func (*PinMessageRequest) Descriptor() ([]byte, []int) {
+	return file_message_v1_message_api_proto_rawDescGZIP(), []int{17}
+}
+
+func (x *PinMessageRequest) GetConversationId() string {
+	if x != nil {
+		return x.ConversationId
+	}
+	return ""
+}
+
+func (x *PinMessageRequest) GetMessageId() string {
+	if x != nil {
+		return x.MessageId
+	}
+	return ""
+}
+
+func (x *PinMessageRequest) GetActorUserId() string {
+	if x != nil {
+		return x.ActorUserId
+	}
+	return ""
+}
+
+type PinMessageResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Pin           *PinnedMessage         `protobuf:"bytes,1,opt,name=pin,proto3" json:"pin,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *PinMessageResponse) Reset() {
+	*x = PinMessageResponse{}
+	mi := &file_message_v1_message_api_proto_msgTypes[18]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *PinMessageResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*PinMessageResponse) ProtoMessage() {}
+
+func (x *PinMessageResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_message_v1_message_api_proto_msgTypes[18]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use PinMessageResponse.ProtoReflect.Descriptor instead.
+func (*PinMessageResponse) Descriptor() ([]byte, []int) {
+	return file_message_v1_message_api_proto_rawDescGZIP(), []int{18}
+}
+
+func (x *PinMessageResponse) GetPin() *PinnedMessage {
+	if x != nil {
+		return x.Pin
+	}
+	return nil
+}
+
+type UnpinMessageRequest struct {
+	state          protoimpl.MessageState `protogen:"open.v1"`
+	ConversationId string                 `protobuf:"bytes,1,opt,name=conversation_id,json=conversationId,proto3" json:"conversation_id,omitempty"`
+	MessageId      string                 `protobuf:"bytes,2,opt,name=message_id,json=messageId,proto3" json:"message_id,omitempty"`
+	ActorUserId    string                 `protobuf:"bytes,3,opt,name=actor_user_id,json=actorUserId,proto3" json:"actor_user_id,omitempty"`
+	unknownFields  protoimpl.UnknownFields
+	sizeCache      protoimpl.SizeCache
+}
+
+func (x *UnpinMessageRequest) Reset() {
+	*x = UnpinMessageRequest{}
+	mi := &file_message_v1_message_api_proto_msgTypes[19]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *UnpinMessageRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*UnpinMessageRequest) ProtoMessage() {}
+
+func (x *UnpinMessageRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_message_v1_message_api_proto_msgTypes[19]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use UnpinMessageRequest.ProtoReflect.Descriptor instead.
+func (*UnpinMessageRequest) Descriptor() ([]byte, []int) {
+	return file_message_v1_message_api_proto_rawDescGZIP(), []int{19}
+}
+
+func (x *UnpinMessageRequest) GetConversationId() string {
+	if x != nil {
+		return x.ConversationId
+	}
+	return ""
+}
+
+func (x *UnpinMessageRequest) GetMessageId() string {
+	if x != nil {
+		return x.MessageId
+	}
+	return ""
+}
+
+func (x *UnpinMessageRequest) GetActorUserId() string {
+	if x != nil {
+		return x.ActorUserId
+	}
+	return ""
+}
+
+type UnpinMessageResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *UnpinMessageResponse) Reset() {
+	*x = UnpinMessageResponse{}
+	mi := &file_message_v1_message_api_proto_msgTypes[20]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *UnpinMessageResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*UnpinMessageResponse) ProtoMessage() {}
+
+func (x *UnpinMessageResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_message_v1_message_api_proto_msgTypes[20]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use UnpinMessageResponse.ProtoReflect.Descriptor instead.
+func (*UnpinMessageResponse) Descriptor() ([]byte, []int) {
+	return file_message_v1_message_api_proto_rawDescGZIP(), []int{20}
+}
+
+type ListPinnedMessagesRequest struct {
+	state          protoimpl.MessageState `protogen:"open.v1"`
+	ConversationId string                 `protobuf:"bytes,1,opt,name=conversation_id,json=conversationId,proto3" json:"conversation_id,omitempty"`
+	unknownFields  protoimpl.UnknownFields
+	sizeCache      protoimpl.SizeCache
+}
+
+func (x *ListPinnedMessagesRequest) Reset() {
+	*x = ListPinnedMessagesRequest{}
+	mi := &file_message_v1_message_api_proto_msgTypes[21]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListPinnedMessagesRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListPinnedMessagesRequest) ProtoMessage() {}
+
+func (x *ListPinnedMessagesRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_message_v1_message_api_proto_msgTypes[21]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListPinnedMessagesRequest.ProtoReflect.Descriptor instead.
+func (*ListPinnedMessagesRequest) Descriptor() ([]byte, []int) {
+	return file_message_v1_message_api_proto_rawDescGZIP(), []int{21}
+}
+
+func (x *ListPinnedMessagesRequest) GetConversationId() string {
+	if x != nil {
+		return x.ConversationId
+	}
+	return ""
+}
+
+type ListPinnedMessagesResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Pins          []*PinnedMessage       `protobuf:"bytes,1,rep,name=pins,proto3" json:"pins,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListPinnedMessagesResponse) Reset() {
+	*x = ListPinnedMessagesResponse{}
+	mi := &file_message_v1_message_api_proto_msgTypes[22]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListPinnedMessagesResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListPinnedMessagesResponse) ProtoMessage() {}
+
+func (x *ListPinnedMessagesResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_message_v1_message_api_proto_msgTypes[22]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListPinnedMessagesResponse.ProtoReflect.Descriptor instead.
+func (*ListPinnedMessagesResponse) Descriptor() ([]byte, []int) {
+	return file_message_v1_message_api_proto_rawDescGZIP(), []int{22}
+}
+
+func (x *ListPinnedMessagesResponse) GetPins() []*PinnedMessage {
+	if x != nil {
+		return x.Pins
+	}
+	return nil
+}
+
+type ListScheduledMessagesRequest struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Optional; empty lists the caller's scheduled messages in every conversation.
+	ConversationId string `protobuf:"bytes,1,opt,name=conversation_id,json=conversationId,proto3" json:"conversation_id,omitempty"`
+	unknownFields  protoimpl.UnknownFields
+	sizeCache      protoimpl.SizeCache
+}
+
+func (x *ListScheduledMessagesRequest) Reset() {
+	*x = ListScheduledMessagesRequest{}
+	mi := &file_message_v1_message_api_proto_msgTypes[23]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListScheduledMessagesRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListScheduledMessagesRequest) ProtoMessage() {}
+
+func (x *ListScheduledMessagesRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_message_v1_message_api_proto_msgTypes[23]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListScheduledMessagesRequest.ProtoReflect.Descriptor instead.
+func (*ListScheduledMessagesRequest) Descriptor() ([]byte, []int) {
+	return file_message_v1_message_api_proto_rawDescGZIP(), []int{23}
+}
+
+func (x *ListScheduledMessagesRequest) GetConversationId() string {
+	if x != nil {
+		return x.ConversationId
+	}
+	return ""
+}
+
+type ListScheduledMessagesResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Scheduled     []*ScheduledMessage    `protobuf:"bytes,1,rep,name=scheduled,proto3" json:"scheduled,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListScheduledMessagesResponse) Reset() {
+	*x = ListScheduledMessagesResponse{}
+	mi := &file_message_v1_message_api_proto_msgTypes[24]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListScheduledMessagesResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListScheduledMessagesResponse) ProtoMessage() {}
+
+func (x *ListScheduledMessagesResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_message_v1_message_api_proto_msgTypes[24]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListScheduledMessagesResponse.ProtoReflect.Descriptor instead.
+func (*ListScheduledMessagesResponse) Descriptor() ([]byte, []int) {
+	return file_message_v1_message_api_proto_rawDescGZIP(), []int{24}
+}
+
+func (x *ListScheduledMessagesResponse) GetScheduled() []*ScheduledMessage {
+	if x != nil {
+		return x.Scheduled
+	}
+	return nil
+}
+
+type UpdateScheduledMessageRequest struct {
+	state        protoimpl.MessageState `protogen:"open.v1"`
+	ScheduledId  string                 `protobuf:"bytes,1,opt,name=scheduled_id,json=scheduledId,proto3" json:"scheduled_id,omitempty"`
+	ActorUserId  string                 `protobuf:"bytes,2,opt,name=actor_user_id,json=actorUserId,proto3" json:"actor_user_id,omitempty"`
+	Content      string                 `protobuf:"bytes,3,opt,name=content,proto3" json:"content,omitempty"`
+	MetadataJson string                 `protobuf:"bytes,4,opt,name=metadata_json,json=metadataJson,proto3" json:"metadata_json,omitempty"`
+	// Left unchanged when unset.
+	SendAt        *timestamppb.Timestamp `protobuf:"bytes,5,opt,name=send_at,json=sendAt,proto3" json:"send_at,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *UpdateScheduledMessageRequest) Reset() {
+	*x = UpdateScheduledMessageRequest{}
+	mi := &file_message_v1_message_api_proto_msgTypes[25]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *UpdateScheduledMessageRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*UpdateScheduledMessageRequest) ProtoMessage() {}
+
+func (x *UpdateScheduledMessageRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_message_v1_message_api_proto_msgTypes[25]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use UpdateScheduledMessageRequest.ProtoReflect.Descriptor instead.
+func (*UpdateScheduledMessageRequest) Descriptor() ([]byte, []int) {
+	return file_message_v1_message_api_proto_rawDescGZIP(), []int{25}
+}
+
+func (x *UpdateScheduledMessageRequest) GetScheduledId() string {
+	if x != nil {
+		return x.ScheduledId
+	}
+	return ""
+}
+
+func (x *UpdateScheduledMessageRequest) GetActorUserId() string {
+	if x != nil {
+		return x.ActorUserId
+	}
+	return ""
+}
+
+func (x *UpdateScheduledMessageRequest) GetContent() string {
+	if x != nil {
+		return x.Content
+	}
+	return ""
+}
+
+func (x *UpdateScheduledMessageRequest) GetMetadataJson() string {
+	if x != nil {
+		return x.MetadataJson
+	}
+	return ""
+}
+
+func (x *UpdateScheduledMessageRequest) GetSendAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.SendAt
+	}
+	return nil
+}
+
+type UpdateScheduledMessageResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Scheduled     *ScheduledMessage      `protobuf:"bytes,1,opt,name=scheduled,proto3" json:"scheduled,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *UpdateScheduledMessageResponse) Reset() {
+	*x = UpdateScheduledMessageResponse{}
+	mi := &file_message_v1_message_api_proto_msgTypes[26]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *UpdateScheduledMessageResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*UpdateScheduledMessageResponse) ProtoMessage() {}
+
+func (x *UpdateScheduledMessageResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_message_v1_message_api_proto_msgTypes[26]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use UpdateScheduledMessageResponse.ProtoReflect.Descriptor instead.
+func (*UpdateScheduledMessageResponse) Descriptor() ([]byte, []int) {
+	return file_message_v1_message_api_proto_rawDescGZIP(), []int{26}
+}
+
+func (x *UpdateScheduledMessageResponse) GetScheduled() *ScheduledMessage {
+	if x != nil {
+		return x.Scheduled
+	}
+	return nil
+}
+
+type CancelScheduledMessageRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	ScheduledId   string                 `protobuf:"bytes,1,opt,name=scheduled_id,json=scheduledId,proto3" json:"scheduled_id,omitempty"`
+	ActorUserId   string                 `protobuf:"bytes,2,opt,name=actor_user_id,json=actorUserId,proto3" json:"actor_user_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *CancelScheduledMessageRequest) Reset() {
+	*x = CancelScheduledMessageRequest{}
+	mi := &file_message_v1_message_api_proto_msgTypes[27]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CancelScheduledMessageRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CancelScheduledMessageRequest) ProtoMessage() {}
+
+func (x *CancelScheduledMessageRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_message_v1_message_api_proto_msgTypes[27]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CancelScheduledMessageRequest.ProtoReflect.Descriptor instead.
+func (*CancelScheduledMessageRequest) Descriptor() ([]byte, []int) {
+	return file_message_v1_message_api_proto_rawDescGZIP(), []int{27}
+}
+
+func (x *CancelScheduledMessageRequest) GetScheduledId() string {
+	if x != nil {
+		return x.ScheduledId
+	}
+	return ""
+}
+
+func (x *CancelScheduledMessageRequest) GetActorUserId() string {
+	if x != nil {
+		return x.ActorUserId
+	}
+	return ""
+}
+
+type CancelScheduledMessageResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *CancelScheduledMessageResponse) Reset() {
+	*x = CancelScheduledMessageResponse{}
+	mi := &file_message_v1_message_api_proto_msgTypes[28]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CancelScheduledMessageResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CancelScheduledMessageResponse) ProtoMessage() {}
+
+func (x *CancelScheduledMessageResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_message_v1_message_api_proto_msgTypes[28]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CancelScheduledMessageResponse.ProtoReflect.Descriptor instead.
+func (*CancelScheduledMessageResponse) Descriptor() ([]byte, []int) {
+	return file_message_v1_message_api_proto_rawDescGZIP(), []int{28}
+}
+
+type ForwardMessagesRequest struct {
+	state                protoimpl.MessageState `protogen:"open.v1"`
+	SourceConversationId string                 `protobuf:"bytes,1,opt,name=source_conversation_id,json=sourceConversationId,proto3" json:"source_conversation_id,omitempty"`
+	TargetConversationId string                 `protobuf:"bytes,2,opt,name=target_conversation_id,json=targetConversationId,proto3" json:"target_conversation_id,omitempty"`
+	MessageIds           []string               `protobuf:"bytes,3,rep,name=message_ids,json=messageIds,proto3" json:"message_ids,omitempty"`
+	SenderUserId         string                 `protobuf:"bytes,4,opt,name=sender_user_id,json=senderUserId,proto3" json:"sender_user_id,omitempty"`
+	IdempotencyKey       string                 `protobuf:"bytes,5,opt,name=idempotency_key,json=idempotencyKey,proto3" json:"idempotency_key,omitempty"`
+	unknownFields        protoimpl.UnknownFields
+	sizeCache            protoimpl.SizeCache
+}
+
+func (x *ForwardMessagesRequest) Reset() {
+	*x = ForwardMessagesRequest{}
+	mi := &file_message_v1_message_api_proto_msgTypes[29]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ForwardMessagesRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ForwardMessagesRequest) ProtoMessage() {}
+
+func (x *ForwardMessagesRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_message_v1_message_api_proto_msgTypes[29]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ForwardMessagesRequest.ProtoReflect.Descriptor instead.
+func (*ForwardMessagesRequest) Descriptor() ([]byte, []int) {
+	return file_message_v1_message_api_proto_rawDescGZIP(), []int{29}
+}
+
+func (x *ForwardMessagesRequest) GetSourceConversationId() string {
+	if x != nil {
+		return x.SourceConversationId
+	}
+	return ""
+}
+
+func (x *ForwardMessagesRequest) GetTargetConversationId() string {
+	if x != nil {
+		return x.TargetConversationId
+	}
+	return ""
+}
+
+func (x *ForwardMessagesRequest) GetMessageIds() []string {
+	if x != nil {
+		return x.MessageIds
+	}
+	return nil
+}
+
+func (x *ForwardMessagesRequest) GetSenderUserId() string {
+	if x != nil {
+		return x.SenderUserId
+	}
+	return ""
+}
+
+func (x *ForwardMessagesRequest) GetIdempotencyKey() string {
+	if x != nil {
+		return x.IdempotencyKey
+	}
+	return ""
+}
+
+type ForwardMessagesResponse struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// The copies in the target conversation, in source order.
+	Messages      []*Message `protobuf:"bytes,1,rep,name=messages,proto3" json:"messages,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ForwardMessagesResponse) Reset() {
+	*x = ForwardMessagesResponse{}
+	mi := &file_message_v1_message_api_proto_msgTypes[30]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ForwardMessagesResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ForwardMessagesResponse) ProtoMessage() {}
+
+func (x *ForwardMessagesResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_message_v1_message_api_proto_msgTypes[30]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ForwardMessagesResponse.ProtoReflect.Descriptor instead.
+func (*ForwardMessagesResponse) Descriptor() ([]byte, []int) {
+	return file_message_v1_message_api_proto_rawDescGZIP(), []int{30}
+}
+
+func (x *ForwardMessagesResponse) GetMessages() []*Message {
+	if x != nil {
+		return x.Messages
+	}
+	return nil
+}
+
+type GetUnreadMentionCountsRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetUnreadMentionCountsRequest) Reset() {
+	*x = GetUnreadMentionCountsRequest{}
+	mi := &file_message_v1_message_api_proto_msgTypes[31]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetUnreadMentionCountsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetUnreadMentionCountsRequest) ProtoMessage() {}
+
+func (x *GetUnreadMentionCountsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_message_v1_message_api_proto_msgTypes[31]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetUnreadMentionCountsRequest.ProtoReflect.Descriptor instead.
+func (*GetUnreadMentionCountsRequest) Descriptor() ([]byte, []int) {
+	return file_message_v1_message_api_proto_rawDescGZIP(), []int{31}
+}
+
+type GetUnreadMentionCountsResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Counts        []*UnreadMentionCount  `protobuf:"bytes,1,rep,name=counts,proto3" json:"counts,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetUnreadMentionCountsResponse) Reset() {
+	*x = GetUnreadMentionCountsResponse{}
+	mi := &file_message_v1_message_api_proto_msgTypes[32]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetUnreadMentionCountsResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetUnreadMentionCountsResponse) ProtoMessage() {}
+
+func (x *GetUnreadMentionCountsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_message_v1_message_api_proto_msgTypes[32]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetUnreadMentionCountsResponse.ProtoReflect.Descriptor instead.
+func (*GetUnreadMentionCountsResponse) Descriptor() ([]byte, []int) {
+	return file_message_v1_message_api_proto_rawDescGZIP(), []int{32}
+}
+
+func (x *GetUnreadMentionCountsResponse) GetCounts() []*UnreadMentionCount {
+	if x != nil {
+		return x.Counts
+	}
+	return nil
+}
+
+type UnreadMentionCount struct {
+	state          protoimpl.MessageState `protogen:"open.v1"`
+	ConversationId string                 `protobuf:"bytes,1,opt,name=conversation_id,json=conversationId,proto3" json:"conversation_id,omitempty"`
+	Count          int64                  `protobuf:"varint,2,opt,name=count,proto3" json:"count,omitempty"`
+	unknownFields  protoimpl.UnknownFields
+	sizeCache      protoimpl.SizeCache
+}
+
+func (x *UnreadMentionCount) Reset() {
+	*x = UnreadMentionCount{}
+	mi := &file_message_v1_message_api_proto_msgTypes[33]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *UnreadMentionCount) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*UnreadMentionCount) ProtoMessage() {}
+
+func (x *UnreadMentionCount) ProtoReflect() protoreflect.Message {
+	mi := &file_message_v1_message_api_proto_msgTypes[33]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use UnreadMentionCount.ProtoReflect.Descriptor instead.
+func (*UnreadMentionCount) Descriptor() ([]byte, []int) {
+	return file_message_v1_message_api_proto_rawDescGZIP(), []int{33}
+}
+
+func (x *UnreadMentionCount) GetConversationId() string {
+	if x != nil {
+		return x.ConversationId
+	}
+	return ""
+}
+
+func (x *UnreadMentionCount) GetCount() int64 {
+	if x != nil {
+		return x.Count
+	}
+	return 0
+}
+
+type VotePollRequest struct {
+	state          protoimpl.MessageState `protogen:"open.v1"`
+	ConversationId string                 `protobuf:"bytes,1,opt,name=conversation_id,json=conversationId,proto3" json:"conversation_id,omitempty"`
+	MessageId      string                 `protobuf:"bytes,2,opt,name=message_id,json=messageId,proto3" json:"message_id,omitempty"`
+	ActorUserId    string                 `protobuf:"bytes,3,opt,name=actor_user_id,json=actorUserId,proto3" json:"actor_user_id,omitempty"`
+	OptionIndexes  []int32                `protobuf:"varint,4,rep,packed,name=option_indexes,json=optionIndexes,proto3" json:"option_indexes,omitempty"`
+	unknownFields  protoimpl.UnknownFields
+	sizeCache      protoimpl.SizeCache
+}
+
+func (x *VotePollRequest) Reset() {
+	*x = VotePollRequest{}
+	mi := &file_message_v1_message_api_proto_msgTypes[34]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *VotePollRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*VotePollRequest) ProtoMessage() {}
+
+func (x *VotePollRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_message_v1_message_api_proto_msgTypes[34]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use VotePollRequest.ProtoReflect.Descriptor instead.
+func (*VotePollRequest) Descriptor() ([]byte, []int) {
+	return file_message_v1_message_api_proto_rawDescGZIP(), []int{34}
+}
+
+func (x *VotePollRequest) GetConversationId() string {
+	if x != nil {
+		return x.ConversationId
+	}
+	return ""
+}
+
+func (x *VotePollRequest) GetMessageId() string {
+	if x != nil {
+		return x.MessageId
+	}
+	return ""
+}
+
+func (x *VotePollRequest) GetActorUserId() string {
+	if x != nil {
+		return x.ActorUserId
+	}
+	return ""
+}
+
+func (x *VotePollRequest) GetOptionIndexes() []int32 {
+	if x != nil {
+		return x.OptionIndexes
+	}
+	return nil
+}
+
+type VotePollResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Poll          *PollState             `protobuf:"bytes,1,opt,name=poll,proto3" json:"poll,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *VotePollResponse) Reset() {
+	*x = VotePollResponse{}
+	mi := &file_message_v1_message_api_proto_msgTypes[35]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *VotePollResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*VotePollResponse) ProtoMessage() {}
+
+func (x *VotePollResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_message_v1_message_api_proto_msgTypes[35]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use VotePollResponse.ProtoReflect.Descriptor instead.
+func (*VotePollResponse) Descriptor() ([]byte, []int) {
+	return file_message_v1_message_api_proto_rawDescGZIP(), []int{35}
+}
+
+func (x *VotePollResponse) GetPoll() *PollState {
+	if x != nil {
+		return x.Poll
+	}
+	return nil
+}
+
+type RetractVoteRequest struct {
+	state          protoimpl.MessageState `protogen:"open.v1"`
+	ConversationId string                 `protobuf:"bytes,1,opt,name=conversation_id,json=conversationId,proto3" json:"conversation_id,omitempty"`
+	MessageId      string                 `protobuf:"bytes,2,opt,name=message_id,json=messageId,proto3" json:"message_id,omitempty"`
+	ActorUserId    string                 `protobuf:"bytes,3,opt,name=actor_user_id,json=actorUserId,proto3" json:"actor_user_id,omitempty"`
+	unknownFields  protoimpl.UnknownFields
+	sizeCache      protoimpl.SizeCache
+}
+
+func (x *RetractVoteRequest) Reset() {
+	*x = RetractVoteRequest{}
+	mi := &file_message_v1_message_api_proto_msgTypes[36]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *RetractVoteRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RetractVoteRequest) ProtoMessage() {}
+
+func (x *RetractVoteRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_message_v1_message_api_proto_msgTypes[36]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RetractVoteRequest.ProtoReflect.Descriptor instead.
+func (*RetractVoteRequest) Descriptor() ([]byte, []int) {
+	return file_message_v1_message_api_proto_rawDescGZIP(), []int{36}
+}
+
+func (x *RetractVoteRequest) GetConversationId() string {
+	if x != nil {
+		return x.ConversationId
+	}
+	return ""
+}
+
+func (x *RetractVoteRequest) GetMessageId() string {
+	if x != nil {
+		return x.MessageId
+	}
+	return ""
+}
+
+func (x *RetractVoteRequest) GetActorUserId() string {
+	if x != nil {
+		return x.ActorUserId
+	}
+	return ""
+}
+
+type RetractVoteResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Poll          *PollState             `protobuf:"bytes,1,opt,name=poll,proto3" json:"poll,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *RetractVoteResponse) Reset() {
+	*x = RetractVoteResponse{}
+	mi := &file_message_v1_message_api_proto_msgTypes[37]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *RetractVoteResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RetractVoteResponse) ProtoMessage() {}
+
+func (x *RetractVoteResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_message_v1_message_api_proto_msgTypes[37]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RetractVoteResponse.ProtoReflect.Descriptor instead.
+func (*RetractVoteResponse) Descriptor() ([]byte, []int) {
+	return file_message_v1_message_api_proto_rawDescGZIP(), []int{37}
+}
+
+func (x *RetractVoteResponse) GetPoll() *PollState {
+	if x != nil {
+		return x.Poll
+	}
+	return nil
+}
+
+type ClosePollRequest struct {
+	state          protoimpl.MessageState `protogen:"open.v1"`
+	ConversationId string                 `protobuf:"bytes,1,opt,name=conversation_id,json=conversationId,proto3" json:"conversation_id,omitempty"`
+	MessageId      string                 `protobuf:"bytes,2,opt,name=message_id,json=messageId,proto3" json:"message_id,omitempty"`
+	ActorUserId    string                 `protobuf:"bytes,3,opt,name=actor_user_id,json=actorUserId,proto3" json:"actor_user_id,omitempty"`
+	unknownFields  protoimpl.UnknownFields
+	sizeCache      protoimpl.SizeCache
+}
+
+func (x *ClosePollRequest) Reset() {
+	*x = ClosePollRequest{}
+	mi := &file_message_v1_message_api_proto_msgTypes[38]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ClosePollRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ClosePollRequest) ProtoMessage() {}
+
+func (x *ClosePollRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_message_v1_message_api_proto_msgTypes[38]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ClosePollRequest.ProtoReflect.Descriptor instead.
+func (*ClosePollRequest) Descriptor() ([]byte, []int) {
+	return file_message_v1_message_api_proto_rawDescGZIP(), []int{38}
+}
+
+func (x *ClosePollRequest) GetConversationId() string {
+	if x != nil {
+		return x.ConversationId
+	}
+	return ""
+}
+
+func (x *ClosePollRequest) GetMessageId() string {
+	if x != nil {
+		return x.MessageId
+	}
+	return ""
+}
+
+func (x *ClosePollRequest) GetActorUserId() string {
+	if x != nil {
+		return x.ActorUserId
+	}
+	return ""
+}
+
+type ClosePollResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Poll          *PollState             `protobuf:"bytes,1,opt,name=poll,proto3" json:"poll,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ClosePollResponse) Reset() {
+	*x = ClosePollResponse{}
+	mi := &file_message_v1_message_api_proto_msgTypes[39]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ClosePollResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ClosePollResponse) ProtoMessage() {}
+
+func (x *ClosePollResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_message_v1_message_api_proto_msgTypes[39]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ClosePollResponse.ProtoReflect.Descriptor instead.
+func (*ClosePollResponse) Descriptor() ([]byte, []int) {
+	return file_message_v1_message_api_proto_rawDescGZIP(), []int{39}
+}
+
+func (x *ClosePollResponse) GetPoll() *PollState {
+	if x != nil {
+		return x.Poll
+	}
+	return nil
+}
+
+type RetentionPolicy struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// 0 keeps messages forever.
+	RetentionSeconds int64 `protobuf:"varint,1,opt,name=retention_seconds,json=retentionSeconds,proto3" json:"retention_seconds,omitempty"`
+	// True when the conversation follows the service-wide retention.
+	IsDefault     bool `protobuf:"varint,2,opt,name=is_default,json=isDefault,proto3" json:"is_default,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *RetentionPolicy) Reset() {
+	*x = RetentionPolicy{}
+	mi := &file_message_v1_message_api_proto_msgTypes[40]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *RetentionPolicy) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RetentionPolicy) ProtoMessage() {}
+
+func (x *RetentionPolicy) ProtoReflect() protoreflect.Message {
+	mi := &file_message_v1_message_api_proto_msgTypes[40]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RetentionPolicy.ProtoReflect.Descriptor instead.
+func (*RetentionPolicy) Descriptor() ([]byte, []int) {
+	return file_message_v1_message_api_proto_rawDescGZIP(), []int{40}
+}
+
+func (x *RetentionPolicy) GetRetentionSeconds() int64 {
+	if x != nil {
+		return x.RetentionSeconds
+	}
+	return 0
+}
+
+func (x *RetentionPolicy) GetIsDefault() bool {
+	if x != nil {
+		return x.IsDefault
+	}
+	return false
+}
+
+type SetRetentionPolicyRequest struct {
+	state          protoimpl.MessageState `protogen:"open.v1"`
+	ConversationId string                 `protobuf:"bytes,1,opt,name=conversation_id,json=conversationId,proto3" json:"conversation_id,omitempty"`
+	ActorUserId    string                 `protobuf:"bytes,2,opt,name=actor_user_id,json=actorUserId,proto3" json:"actor_user_id,omitempty"`
+	// 0 keeps messages forever; otherwise at least a day.
+	RetentionSeconds int64 `protobuf:"varint,3,opt,name=retention_seconds,json=retentionSeconds,proto3" json:"retention_seconds,omitempty"`
+	// Drops the conversation's policy; retention_seconds is ignored.
+	UseDefault    bool `protobuf:"varint,4,opt,name=use_default,json=useDefault,proto3" json:"use_default,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *SetRetentionPolicyRequest) Reset() {
+	*x = SetRetentionPolicyRequest{}
+	mi := &file_message_v1_message_api_proto_msgTypes[41]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SetRetentionPolicyRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SetRetentionPolicyRequest) ProtoMessage() {}
+
+func (x *SetRetentionPolicyRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_message_v1_message_api_proto_msgTypes[41]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SetRetentionPolicyRequest.ProtoReflect.Descriptor instead.
+func (*SetRetentionPolicyRequest) Descriptor() ([]byte, []int) {
+	return file_message_v1_message_api_proto_rawDescGZIP(), []int{41}
+}
+
+func (x *SetRetentionPolicyRequest) GetConversationId() string {
+	if x != nil {
+		return x.ConversationId
+	}
+	return ""
+}
+
+func (x *SetRetentionPolicyRequest) GetActorUserId() string {
+	if x != nil {
+		return x.ActorUserId
+	}
+	return ""
+}
+
+func (x *SetRetentionPolicyRequest) GetRetentionSeconds() int64 {
+	if x != nil {
+		return x.RetentionSeconds
+	}
+	return 0
+}
+
+func (x *SetRetentionPolicyRequest) GetUseDefault() bool {
+	if x != nil {
+		return x.UseDefault
+	}
+	return false
+}
+
+type SetRetentionPolicyResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Policy        *RetentionPolicy       `protobuf:"bytes,1,opt,name=policy,proto3" json:"policy,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *SetRetentionPolicyResponse) Reset() {
+	*x = SetRetentionPolicyResponse{}
+	mi := &file_message_v1_message_api_proto_msgTypes[42]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SetRetentionPolicyResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SetRetentionPolicyResponse) ProtoMessage() {}
+
+func (x *SetRetentionPolicyResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_message_v1_message_api_proto_msgTypes[42]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SetRetentionPolicyResponse.ProtoReflect.Descriptor instead.
+func (*SetRetentionPolicyResponse) Descriptor() ([]byte, []int) {
+	return file_message_v1_message_api_proto_rawDescGZIP(), []int{42}
+}
+
+func (x *SetRetentionPolicyResponse) GetPolicy() *RetentionPolicy {
+	if x != nil {
+		return x.Policy
+	}
+	return nil
+}
+
+type GetRetentionPolicyRequest struct {
+	state          protoimpl.MessageState `protogen:"open.v1"`
+	ConversationId string                 `protobuf:"bytes,1,opt,name=conversation_id,json=conversationId,proto3" json:"conversation_id,omitempty"`
+	unknownFields  protoimpl.UnknownFields
+	sizeCache      protoimpl.SizeCache
+}
+
+func (x *GetRetentionPolicyRequest) Reset() {
+	*x = GetRetentionPolicyRequest{}
+	mi := &file_message_v1_message_api_proto_msgTypes[43]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetRetentionPolicyRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetRetentionPolicyRequest) ProtoMessage() {}
+
+func (x *GetRetentionPolicyRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_message_v1_message_api_proto_msgTypes[43]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetRetentionPolicyRequest.ProtoReflect.Descriptor instead.
+func (*GetRetentionPolicyRequest) Descriptor() ([]byte, []int) {
+	return file_message_v1_message_api_proto_rawDescGZIP(), []int{43}
+}
+
+func (x *GetRetentionPolicyRequest) GetConversationId() string {
+	if x != nil {
+		return x.ConversationId
+	}
+	return ""
+}
+
+type GetRetentionPolicyResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Policy        *RetentionPolicy       `protobuf:"bytes,1,opt,name=policy,proto3" json:"policy,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetRetentionPolicyResponse) Reset() {
+	*x = GetRetentionPolicyResponse{}
+	mi := &file_message_v1_message_api_proto_msgTypes[44]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetRetentionPolicyResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetRetentionPolicyResponse) ProtoMessage() {}
+
+func (x *GetRetentionPolicyResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_message_v1_message_api_proto_msgTypes[44]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetRetentionPolicyResponse.ProtoReflect.Descriptor instead.
+func (*GetRetentionPolicyResponse) Descriptor() ([]byte, []int) {
+	return file_message_v1_message_api_proto_rawDescGZIP(), []int{44}
+}
+
+func (x *GetRetentionPolicyResponse) GetPolicy() *RetentionPolicy {
+	if x != nil {
+		return x.Policy
+	}
+	return nil
+}
+
+type ConversationExport struct {
+	state          protoimpl.MessageState `protogen:"open.v1"`
+	ExportId       string                 `protobuf:"bytes,1,opt,name=export_id,json=exportId,proto3" json:"export_id,omitempty"`
+	ConversationId string                 `protobuf:"bytes,2,opt,name=conversation_id,json=conversationId,proto3" json:"conversation_id,omitempty"`
+	UserId         string                 `protobuf:"bytes,3,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+	// pending, running, done or failed.
+	Status        string                 `protobuf:"bytes,4,opt,name=status,proto3" json:"status,omitempty"`
+	MessageCount  int64                  `protobuf:"varint,5,opt,name=message_count,json=messageCount,proto3" json:"message_count,omitempty"`
+	SizeBytes     int64                  `protobuf:"varint,6,opt,name=size_bytes,json=sizeBytes,proto3" json:"size_bytes,omitempty"`
+	FailureReason string                 `protobuf:"bytes,7,opt,name=failure_reason,json=failureReason,proto3" json:"failure_reason,omitempty"`
+	CreatedAt     *timestamppb.Timestamp `protobuf:"bytes,8,opt,name=created_at,json=createdAt,proto3" json:"created_at,omitempty"`
+	CompletedAt   *timestamppb.Timestamp `protobuf:"bytes,9,opt,name=completed_at,json=completedAt,proto3" json:"completed_at,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ConversationExport) Reset() {
+	*x = ConversationExport{}
+	mi := &file_message_v1_message_api_proto_msgTypes[45]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ConversationExport) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ConversationExport) ProtoMessage() {}
+
+func (x *ConversationExport) ProtoReflect() protoreflect.Message {
+	mi := &file_message_v1_message_api_proto_msgTypes[45]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ConversationExport.ProtoReflect.Descriptor instead.
+func (*ConversationExport) Descriptor() ([]byte, []int) {
+	return file_message_v1_message_api_proto_rawDescGZIP(), []int{45}
+}
+
+func (x *ConversationExport) GetExportId() string {
+	if x != nil {
+		return x.ExportId
+	}
+	return ""
+}
+
+func (x *ConversationExport) GetConversationId() string {
+	if x != nil {
+		return x.ConversationId
+	}
+	return ""
+}
+
+func (x *ConversationExport) GetUserId() string {
+	if x != nil {
+		return x.UserId
+	}
+	return ""
+}
+
+func (x *ConversationExport) GetStatus() string {
+	if x != nil {
+		return x.Status
+	}
+	return ""
+}
+
+func (x *ConversationExport) GetMessageCount() int64 {
+	if x != nil {
+		return x.MessageCount
+	}
+	return 0
+}
+
+func (x *ConversationExport) GetSizeBytes() int64 {
+	if x != nil {
+		return x.SizeBytes
+	}
+	return 0
+}
+
+func (x *ConversationExport) GetFailureReason() string {
+	if x != nil {
+		return x.FailureReason
+	}
+	return ""
+}
+
+func (x *ConversationExport) GetCreatedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.CreatedAt
+	}
+	return nil
+}
+
+func (x *ConversationExport) GetCompletedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.CompletedAt
+	}
+	return nil
+}
+
+type RequestExportRequest struct {
+	state          protoimpl.MessageState `protogen:"open.v1"`
+	ConversationId string                 `protobuf:"bytes,1,opt,name=conversation_id,json=conversationId,proto3" json:"conversation_id,omitempty"`
+	UserId         string                 `protobuf:"bytes,2,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+	unknownFields  protoimpl.UnknownFields
+	sizeCache      protoimpl.SizeCache
+}
+
+func (x *RequestExportRequest) Reset() {
+	*x = RequestExportRequest{}
+	mi := &file_message_v1_message_api_proto_msgTypes[46]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *RequestExportRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RequestExportRequest) ProtoMessage() {}
+
+func (x *RequestExportRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_message_v1_message_api_proto_msgTypes[46]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RequestExportRequest.ProtoReflect.Descriptor instead.
+func (*RequestExportRequest) Descriptor() ([]byte, []int) {
+	return file_message_v1_message_api_proto_rawDescGZIP(), []int{46}
+}
+
+func (x *RequestExportRequest) GetConversationId() string {
+	if x != nil {
+		return x.ConversationId
+	}
+	return ""
+}
+
+func (x *RequestExportRequest) GetUserId() string {
+	if x != nil {
+		return x.UserId
+	}
+	return ""
+}
+
+type RequestExportResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Export        *ConversationExport    `protobuf:"bytes,1,opt,name=export,proto3" json:"export,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *RequestExportResponse) Reset() {
+	*x = RequestExportResponse{}
+	mi := &file_message_v1_message_api_proto_msgTypes[47]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *RequestExportResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RequestExportResponse) ProtoMessage() {}
+
+func (x *RequestExportResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_message_v1_message_api_proto_msgTypes[47]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RequestExportResponse.ProtoReflect.Descriptor instead.
+func (*RequestExportResponse) Descriptor() ([]byte, []int) {
+	return file_message_v1_message_api_proto_rawDescGZIP(), []int{47}
+}
+
+func (x *RequestExportResponse) GetExport() *ConversationExport {
+	if x != nil {
+		return x.Export
+	}
+	return nil
+}
+
+type GetExportRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	ExportId      string                 `protobuf:"bytes,1,opt,name=export_id,json=exportId,proto3" json:"export_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetExportRequest) Reset() {
+	*x = GetExportRequest{}
+	mi := &file_message_v1_message_api_proto_msgTypes[48]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetExportRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetExportRequest) ProtoMessage() {}
+
+func (x *GetExportRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_message_v1_message_api_proto_msgTypes[48]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetExportRequest.ProtoReflect.Descriptor instead.
+func (*GetExportRequest) Descriptor() ([]byte, []int) {
+	return file_message_v1_message_api_proto_rawDescGZIP(), []int{48}
+}
+
+func (x *GetExportRequest) GetExportId() string {
+	if x != nil {
+		return x.ExportId
+	}
+	return ""
+}
+
+type GetExportResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Export        *ConversationExport    `protobuf:"bytes,1,opt,name=export,proto3" json:"export,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetExportResponse) Reset() {
+	*x = GetExportResponse{}
+	mi := &file_message_v1_message_api_proto_msgTypes[49]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetExportResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetExportResponse) ProtoMessage() {}
+
+func (x *GetExportResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_message_v1_message_api_proto_msgTypes[49]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetExportResponse.ProtoReflect.Descriptor instead.
+func (*GetExportResponse) Descriptor() ([]byte, []int) {
+	return file_message_v1_message_api_proto_rawDescGZIP(), []int{49}
+}
+
+func (x *GetExportResponse) GetExport() *ConversationExport {
+	if x != nil {
+		return x.Export
+	}
+	return nil
+}
+
+type DownloadExportRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	ExportId      string                 `protobuf:"bytes,1,opt,name=export_id,json=exportId,proto3" json:"export_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *DownloadExportRequest) Reset() {
+	*x = DownloadExportRequest{}
+	mi := &file_message_v1_message_api_proto_msgTypes[50]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *DownloadExportRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DownloadExportRequest) ProtoMessage() {}
+
+func (x *DownloadExportRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_message_v1_message_api_proto_msgTypes[50]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DownloadExportRequest.ProtoReflect.Descriptor instead.
+func (*DownloadExportRequest) Descriptor() ([]byte, []int) {
+	return file_message_v1_message_api_proto_rawDescGZIP(), []int{50}
+}
+
+func (x *DownloadExportRequest) GetExportId() string {
+	if x != nil {
+		return x.ExportId
+	}
+	return ""
+}
+
+type DownloadExportResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Export        *ConversationExport    `protobuf:"bytes,1,opt,name=export,proto3" json:"export,omitempty"`
+	Data          []byte                 `protobuf:"bytes,2,opt,name=data,proto3" json:"data,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *DownloadExportResponse) Reset() {
+	*x = DownloadExportResponse{}
+	mi := &file_message_v1_message_api_proto_msgTypes[51]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *DownloadExportResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DownloadExportResponse) ProtoMessage() {}
+
+func (x *DownloadExportResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_message_v1_message_api_proto_msgTypes[51]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DownloadExportResponse.ProtoReflect.Descriptor instead.
+func (*DownloadExportResponse) Descriptor() ([]byte, []int) {
+	return file_message_v1_message_api_proto_rawDescGZIP(), []int{51}
+}
+
+func (x *DownloadExportResponse) GetExport() *ConversationExport {
+	if x != nil {
+		return x.Export
+	}
+	return nil
+}
+
+func (x *DownloadExportResponse) GetData() []byte {
+	if x != nil {
+		return x.Data
+	}
+	return nil
+}
+
+type MessageImport struct {
+	state    protoimpl.MessageState `protogen:"open.v1"`
+	ImportId string                 `protobuf:"bytes,1,opt,name=import_id,json=importId,proto3" json:"import_id,omitempty"`
+	// The conversation the history is written to.
+	ConversationId string `protobuf:"bytes,2,opt,name=conversation_id,json=conversationId,proto3" json:"conversation_id,omitempty"`
+	// slack or whatsapp.
+	Source    string `protobuf:"bytes,3,opt,name=source,proto3" json:"source,omitempty"`
+	Name      string `protobuf:"bytes,4,opt,name=name,proto3" json:"name,omitempty"`
+	CreatedBy string `protobuf:"bytes,5,opt,name=created_by,json=createdBy,proto3" json:"created_by,omitempty"`
+	// pending, running, done or failed.
+	Status           string                 `protobuf:"bytes,6,opt,name=status,proto3" json:"status,omitempty"`
+	TotalMessages    int64                  `protobuf:"varint,7,opt,name=total_messages,json=totalMessages,proto3" json:"total_messages,omitempty"`
+	ImportedMessages int64                  `protobuf:"varint,8,opt,name=imported_messages,json=importedMessages,proto3" json:"imported_messages,omitempty"`
+	FailureReason    string                 `protobuf:"bytes,9,opt,name=failure_reason,json=failureReason,proto3" json:"failure_reason,omitempty"`
+	CreatedAt        *timestamppb.Timestamp `protobuf:"bytes,10,opt,name=created_at,json=createdAt,proto3" json:"created_at,omitempty"`
+	CompletedAt      *timestamppb.Timestamp `protobuf:"bytes,11,opt,name=completed_at,json=completedAt,proto3" json:"completed_at,omitempty"`
+	unknownFields    protoimpl.UnknownFields
+	sizeCache        protoimpl.SizeCache
+}
+
+func (x *MessageImport) Reset() {
+	*x = MessageImport{}
+	mi := &file_message_v1_message_api_proto_msgTypes[52]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *MessageImport) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*MessageImport) ProtoMessage() {}
+
+func (x *MessageImport) ProtoReflect() protoreflect.Message {
+	mi := &file_message_v1_message_api_proto_msgTypes[52]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use MessageImport.ProtoReflect.Descriptor instead.
+func (*MessageImport) Descriptor() ([]byte, []int) {
+	return file_message_v1_message_api_proto_rawDescGZIP(), []int{52}
+}
+
+func (x *MessageImport) GetImportId() string {
+	if x != nil {
+		return x.ImportId
+	}
+	return ""
+}
+
+func (x *MessageImport) GetConversationId() string {
+	if x != nil {
+		return x.ConversationId
+	}
+	return ""
+}
+
+func (x *MessageImport) GetSource() string {
+	if x != nil {
+		return x.Source
+	}
+	return ""
+}
+
+func (x *MessageImport) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *MessageImport) GetCreatedBy() string {
+	if x != nil {
+		return x.CreatedBy
+	}
+	return ""
+}
+
+func (x *MessageImport) GetStatus() string {
+	if x != nil {
+		return x.Status
+	}
+	return ""
+}
+
+func (x *MessageImport) GetTotalMessages() int64 {
+	if x != nil {
+		return x.TotalMessages
+	}
+	return 0
+}
+
+func (x *MessageImport) GetImportedMessages() int64 {
+	if x != nil {
+		return x.ImportedMessages
+	}
+	return 0
+}
+
+func (x *MessageImport) GetFailureReason() string {
+	if x != nil {
+		return x.FailureReason
+	}
+	return ""
+}
+
+func (x *MessageImport) GetCreatedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.CreatedAt
+	}
+	return nil
+}
+
+func (x *MessageImport) GetCompletedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.CompletedAt
+	}
+	return nil
+}
+
+type RequestImportRequest struct {
+	state  protoimpl.MessageState `protogen:"open.v1"`
+	UserId string                 `protobuf:"bytes,1,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+	Source string                 `protobuf:"bytes,2,opt,name=source,proto3" json:"source,omitempty"`
+	// Display name of the new conversation.
+	Name         string `protobuf:"bytes,3,opt,name=name,proto3" json:"name,omitempty"`
+	AttachmentId string `protobuf:"bytes,4,opt,name=attachment_id,json=attachmentId,proto3" json:"attachment_id,omitempty"`
+	// Export sender (Slack user ID or WhatsApp display name) -> RealChat
+	// user ID.
+	Mapping map[string]string `protobuf:"bytes,5,rep,name=mapping,proto3" json:"mapping,omitempty" protobuf_key:"bytes,1,opt,name=key" protobuf_val:"bytes,2,opt,name=value"`
+	// Slack channel to import, when the export holds several.
+	Channel string `protobuf:"bytes,6,opt,name=channel,proto3" json:"channel,omitempty"`
+	// IANA time zone of WhatsApp timestamps. Defaults to UTC.
+	TimeZone string `protobuf:"bytes,7,opt,name=time_zone,json=timeZone,proto3" json:"time_zone,omitempty"`
+	// Read WhatsApp dates as day/month rather than month/day.
+	DayFirst      bool `protobuf:"varint,8,opt,name=day_first,json=dayFirst,proto3" json:"day_first,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *RequestImportRequest) Reset() {
+	*x = RequestImportRequest{}
+	mi := &file_message_v1_message_api_proto_msgTypes[53]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *RequestImportRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RequestImportRequest) ProtoMessage() {}
+
+func (x *RequestImportRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_message_v1_message_api_proto_msgTypes[53]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RequestImportRequest.ProtoReflect.Descriptor instead.
+func (*RequestImportRequest) Descriptor() ([]byte, []int) {
+	return file_message_v1_message_api_proto_rawDescGZIP(), []int{53}
+}
+
+func (x *RequestImportRequest) GetUserId() string {
+	if x != nil {
+		return x.UserId
+	}
+	return ""
+}
+
+func (x *RequestImportRequest) GetSource() string {
+	if x != nil {
+		return x.Source
+	}
+	return ""
+}
+
+func (x *RequestImportRequest) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *RequestImportRequest) GetAttachmentId() string {
+	if x != nil {
+		return x.AttachmentId
+	}
+	return ""
+}
+
+func (x *RequestImportRequest) GetMapping() map[string]string {
+	if x != nil {
+		return x.Mapping
+	}
+	return nil
+}
+
+func (x *RequestImportRequest) GetChannel() string {
+	if x != nil {
+		return x.Channel
+	}
+	return ""
+}
+
+func (x *RequestImportRequest) GetTimeZone() string {
+	if x != nil {
+		return x.TimeZone
+	}
+	return ""
+}
+
+func (x *RequestImportRequest) GetDayFirst() bool {
+	if x != nil {
+		return x.DayFirst
+	}
+	return false
+}
+
+type RequestImportResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Import        *MessageImport         `protobuf:"bytes,1,opt,name=import,proto3" json:"import,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *RequestImportResponse) Reset() {
+	*x = RequestImportResponse{}
+	mi := &file_message_v1_message_api_proto_msgTypes[54]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *RequestImportResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RequestImportResponse) ProtoMessage() {}
+
+func (x *RequestImportResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_message_v1_message_api_proto_msgTypes[54]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RequestImportResponse.ProtoReflect.Descriptor instead.
+func (*RequestImportResponse) Descriptor() ([]byte, []int) {
+	return file_message_v1_message_api_proto_rawDescGZIP(), []int{54}
+}
+
+func (x *RequestImportResponse) GetImport() *MessageImport {
+	if x != nil {
+		return x.Import
+	}
+	return nil
+}
+
+type GetImportRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	ImportId      string                 `protobuf:"bytes,1,opt,name=import_id,json=importId,proto3" json:"import_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetImportRequest) Reset() {
+	*x = GetImportRequest{}
+	mi := &file_message_v1_message_api_proto_msgTypes[55]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetImportRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetImportRequest) ProtoMessage() {}
+
+func (x *GetImportRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_message_v1_message_api_proto_msgTypes[55]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetImportRequest.ProtoReflect.Descriptor instead.
+func (*GetImportRequest) Descriptor() ([]byte, []int) {
+	return file_message_v1_message_api_proto_rawDescGZIP(), []int{55}
+}
+
+func (x *GetImportRequest) GetImportId() string {
+	if x != nil {
+		return x.ImportId
+	}
+	return ""
+}
+
+type GetImportResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Import        *MessageImport         `protobuf:"bytes,1,opt,name=import,proto3" json:"import,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetImportResponse) Reset() {
+	*x = GetImportResponse{}
+	mi := &file_message_v1_message_api_proto_msgTypes[56]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetImportResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetImportResponse) ProtoMessage() {}
+
+func (x *GetImportResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_message_v1_message_api_proto_msgTypes[56]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetImportResponse.ProtoReflect.Descriptor instead.
+func (*GetImportResponse) Descriptor() ([]byte, []int) {
+	return file_message_v1_message_api_proto_rawDescGZIP(), []int{56}
+}
+
+func (x *GetImportResponse) GetImport() *MessageImport {
+	if x != nil {
+		return x.Import
+	}
+	return nil
+}
+
+type SaveDraftRequest struct {
+	state          protoimpl.MessageState `protogen:"open.v1"`
+	ConversationId string                 `protobuf:"bytes,1,opt,name=conversation_id,json=conversationId,proto3" json:"conversation_id,omitempty"`
+	Text           string                 `protobuf:"bytes,2,opt,name=text,proto3" json:"text,omitempty"`
+	// When the device wrote the draft; defaults to now.
+	UpdatedAt     *timestamppb.Timestamp `protobuf:"bytes,3,opt,name=updated_at,json=updatedAt,proto3" json:"updated_at,omitempty"`
+	DeviceId      string                 `protobuf:"bytes,4,opt,name=device_id,json=deviceId,proto3" json:"device_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *SaveDraftRequest) Reset() {
+	*x = SaveDraftRequest{}
+	mi := &file_message_v1_message_api_proto_msgTypes[57]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SaveDraftRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SaveDraftRequest) ProtoMessage() {}
+
+func (x *SaveDraftRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_message_v1_message_api_proto_msgTypes[57]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SaveDraftRequest.ProtoReflect.Descriptor instead.
+func (*SaveDraftRequest) Descriptor() ([]byte, []int) {
+	return file_message_v1_message_api_proto_rawDescGZIP(), []int{57}
+}
+
+func (x *SaveDraftRequest) GetConversationId() string {
+	if x != nil {
+		return x.ConversationId
+	}
+	return ""
+}
+
+func (x *SaveDraftRequest) GetText() string {
+	if x != nil {
+		return x.Text
+	}
+	return ""
+}
+
+func (x *SaveDraftRequest) GetUpdatedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.UpdatedAt
+	}
+	return nil
+}
+
+func (x *SaveDraftRequest) GetDeviceId() string {
+	if x != nil {
+		return x.DeviceId
+	}
+	return ""
+}
+
+type SaveDraftResponse struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	Draft *Draft                 `protobuf:"bytes,1,opt,name=draft,proto3" json:"draft,omitempty"`
+	// False when a later draft was already stored; draft is that one.
+	Applied       bool `protobuf:"varint,2,opt,name=applied,proto3" json:"applied,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *SaveDraftResponse) Reset() {
+	*x = SaveDraftResponse{}
+	mi := &file_message_v1_message_api_proto_msgTypes[58]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SaveDraftResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SaveDraftResponse) ProtoMessage() {}
+
+func (x *SaveDraftResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_message_v1_message_api_proto_msgTypes[58]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SaveDraftResponse.ProtoReflect.Descriptor instead.
+func (*SaveDraftResponse) Descriptor() ([]byte, []int) {
+	return file_message_v1_message_api_proto_rawDescGZIP(), []int{58}
+}
+
+func (x *SaveDraftResponse) GetDraft() *Draft {
+	if x != nil {
+		return x.Draft
+	}
+	return nil
+}
+
+func (x *SaveDraftResponse) GetApplied() bool {
+	if x != nil {
+		return x.Applied
+	}
+	return false
+}
+
+type ClearDraftRequest struct {
+	state          protoimpl.MessageState `protogen:"open.v1"`
+	ConversationId string                 `protobuf:"bytes,1,opt,name=conversation_id,json=conversationId,proto3" json:"conversation_id,omitempty"`
+	// When the device cleared the draft; defaults to now.
+	ClearedAt     *timestamppb.Timestamp `protobuf:"bytes,2,opt,name=cleared_at,json=clearedAt,proto3" json:"cleared_at,omitempty"`
+	DeviceId      string                 `protobuf:"bytes,3,opt,name=device_id,json=deviceId,proto3" json:"device_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ClearDraftRequest) Reset() {
+	*x = ClearDraftRequest{}
+	mi := &file_message_v1_message_api_proto_msgTypes[59]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ClearDraftRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ClearDraftRequest) ProtoMessage() {}
+
+func (x *ClearDraftRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_message_v1_message_api_proto_msgTypes[59]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ClearDraftRequest.ProtoReflect.Descriptor instead.
+func (*ClearDraftRequest) Descriptor() ([]byte, []int) {
+	return file_message_v1_message_api_proto_rawDescGZIP(), []int{59}
+}
+
+func (x *ClearDraftRequest) GetConversationId() string {
+	if x != nil {
+		return x.ConversationId
+	}
+	return ""
+}
+
+func (x *ClearDraftRequest) GetClearedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.ClearedAt
+	}
+	return nil
+}
+
+func (x *ClearDraftRequest) GetDeviceId() string {
+	if x != nil {
+		return x.DeviceId
+	}
+	return ""
+}
+
+type ClearDraftResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Draft         *Draft                 `protobuf:"bytes,1,opt,name=draft,proto3" json:"draft,omitempty"`
+	Applied       bool                   `protobuf:"varint,2,opt,name=applied,proto3" json:"applied,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ClearDraftResponse) Reset() {
+	*x = ClearDraftResponse{}
+	mi := &file_message_v1_message_api_proto_msgTypes[60]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ClearDraftResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ClearDraftResponse) ProtoMessage() {}
+
+func (x *ClearDraftResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_message_v1_message_api_proto_msgTypes[60]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ClearDraftResponse.ProtoReflect.Descriptor instead.
+func (*ClearDraftResponse) Descriptor() ([]byte, []int) {
+	return file_message_v1_message_api_proto_rawDescGZIP(), []int{60}
+}
+
+func (x *ClearDraftResponse) GetDraft() *Draft {
+	if x != nil {
+		return x.Draft
+	}
+	return nil
+}
+
+func (x *ClearDraftResponse) GetApplied() bool {
+	if x != nil {
+		return x.Applied
+	}
+	return false
+}
+
+type GetDraftsRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetDraftsRequest) Reset() {
+	*x = GetDraftsRequest{}
+	mi := &file_message_v1_message_api_proto_msgTypes[61]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetDraftsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetDraftsRequest) ProtoMessage() {}
+
+func (x *GetDraftsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_message_v1_message_api_proto_msgTypes[61]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetDraftsRequest.ProtoReflect.Descriptor instead.
+func (*GetDraftsRequest) Descriptor() ([]byte, []int) {
+	return file_message_v1_message_api_proto_rawDescGZIP(), []int{61}
+}
+
+type GetDraftsResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Drafts        []*Draft               `protobuf:"bytes,1,rep,name=drafts,proto3" json:"drafts,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetDraftsResponse) Reset() {
+	*x = GetDraftsResponse{}
+	mi := &file_message_v1_message_api_proto_msgTypes[62]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetDraftsResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetDraftsResponse) ProtoMessage() {}
+
+func (x *GetDraftsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_message_v1_message_api_proto_msgTypes[62]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetDraftsResponse.ProtoReflect.Descriptor instead.
+func (*GetDraftsResponse) Descriptor() ([]byte, []int) {
+	return file_message_v1_message_api_proto_rawDescGZIP(), []int{62}
+}
+
+func (x *GetDraftsResponse) GetDrafts() []*Draft {
+	if x != nil {
+		return x.Drafts
+	}
+	return nil
+}
+
+type ListHeldMessagesRequest struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// pending (the default), approved or rejected
+	Status   string `protobuf:"bytes,1,opt,name=status,proto3" json:"status,omitempty"`
+	PageSize int32  `protobuf:"varint,2,opt,name=page_size,json=pageSize,proto3" json:"page_size,omitempty"`
+	// Opaque cursor from a previous response's next_page_token.
+	PageToken     string `protobuf:"bytes,3,opt,name=page_token,json=pageToken,proto3" json:"page_token,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListHeldMessagesRequest) Reset() {
+	*x = ListHeldMessagesRequest{}
+	mi := &file_message_v1_message_api_proto_msgTypes[63]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListHeldMessagesRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListHeldMessagesRequest) ProtoMessage() {}
+
+func (x *ListHeldMessagesRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_message_v1_message_api_proto_msgTypes[63]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListHeldMessagesRequest.ProtoReflect.Descriptor instead.
+func (*ListHeldMessagesRequest) Descriptor() ([]byte, []int) {
+	return file_message_v1_message_api_proto_rawDescGZIP(), []int{63}
+}
+
+func (x *ListHeldMessagesRequest) GetStatus() string {
+	if x != nil {
+		return x.Status
+	}
+	return ""
+}
+
+func (x *ListHeldMessagesRequest) GetPageSize() int32 {
+	if x != nil {
+		return x.PageSize
+	}
+	return 0
+}
+
+func (x *ListHeldMessagesRequest) GetPageToken() string {
+	if x != nil {
+		return x.PageToken
+	}
+	return ""
+}
+
+type ListHeldMessagesResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Held          []*HeldMessage         `protobuf:"bytes,1,rep,name=held,proto3" json:"held,omitempty"`
+	NextPageToken string                 `protobuf:"bytes,2,opt,name=next_page_token,json=nextPageToken,proto3" json:"next_page_token,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListHeldMessagesResponse) Reset() {
+	*x = ListHeldMessagesResponse{}
+	mi := &file_message_v1_message_api_proto_msgTypes[64]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListHeldMessagesResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListHeldMessagesResponse) ProtoMessage() {}
+
+func (x *ListHeldMessagesResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_message_v1_message_api_proto_msgTypes[64]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListHeldMessagesResponse.ProtoReflect.Descriptor instead.
+func (*ListHeldMessagesResponse) Descriptor() ([]byte, []int) {
+	return file_message_v1_message_api_proto_rawDescGZIP(), []int{64}
+}
+
+func (x *ListHeldMessagesResponse) GetHeld() []*HeldMessage {
+	if x != nil {
+		return x.Held
+	}
+	return nil
+}
+
+func (x *ListHeldMessagesResponse) GetNextPageToken() string {
+	if x != nil {
+		return x.NextPageToken
+	}
+	return ""
+}
+
+type ReviewHeldMessageRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	HeldId        string                 `protobuf:"bytes,1,opt,name=held_id,json=heldId,proto3" json:"held_id,omitempty"`
+	Approve       bool                   `protobuf:"varint,2,opt,name=approve,proto3" json:"approve,omitempty"`
+	Note          string                 `protobuf:"bytes,3,opt,name=note,proto3" json:"note,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ReviewHeldMessageRequest) Reset() {
+	*x = ReviewHeldMessageRequest{}
+	mi := &file_message_v1_message_api_proto_msgTypes[65]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ReviewHeldMessageRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ReviewHeldMessageRequest) ProtoMessage() {}
+
+func (x *ReviewHeldMessageRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_message_v1_message_api_proto_msgTypes[65]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ReviewHeldMessageRequest.ProtoReflect.Descriptor instead.
+func (*ReviewHeldMessageRequest) Descriptor() ([]byte, []int) {
+	return file_message_v1_message_api_proto_rawDescGZIP(), []int{65}
+}
+
+func (x *ReviewHeldMessageRequest) GetHeldId() string {
+	if x != nil {
+		return x.HeldId
+	}
+	return ""
+}
+
+func (x *ReviewHeldMessageRequest) GetApprove() bool {
+	if x != nil {
+		return x.Approve
+	}
+	return false
+}
+
+func (x *ReviewHeldMessageRequest) GetNote() string {
+	if x != nil {
+		return x.Note
+	}
+	return ""
+}
+
+type ReviewHeldMessageResponse struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	Held  *HeldMessage           `protobuf:"bytes,1,opt,name=held,proto3" json:"held,omitempty"`
+	// The message sent on approval.
+	Message       *Message `protobuf:"bytes,2,opt,name=message,proto3" json:"message,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ReviewHeldMessageResponse) Reset() {
+	*x = ReviewHeldMessageResponse{}
+	mi := &file_message_v1_message_api_proto_msgTypes[66]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ReviewHeldMessageResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ReviewHeldMessageResponse) ProtoMessage() {}
+
+func (x *ReviewHeldMessageResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_message_v1_message_api_proto_msgTypes[66]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ReviewHeldMessageResponse.ProtoReflect.Descriptor instead.
+func (*ReviewHeldMessageResponse) Descriptor() ([]byte, []int) {
+	return file_message_v1_message_api_proto_rawDescGZIP(), []int{66}
+}
+
+func (x *ReviewHeldMessageResponse) GetHeld() *HeldMessage {
+	if x != nil {
+		return x.Held
+	}
+	return nil
+}
+
+func (x *ReviewHeldMessageResponse) GetMessage() *Message {
+	if x != nil {
+		return x.Message
+	}
+	return nil
+}
+
+var File_message_v1_message_api_proto protoreflect.FileDescriptor
+
+const file_message_v1_message_api_proto_rawDesc = "" +
+	"\n" +
+	"\x1cmessage/v1/message_api.proto\x12\x13realchat.message.v1\x1a\x1fgoogle/protobuf/timestamp.proto\x1a\x18message/v1/message.proto\"\xec\x03\n" +
+	"\x12SendMessageRequest\x12'\n" +
+	"\x0fconversation_id\x18\x01 \x01(\tR\x0econversationId\x12$\n" +
+	"\x0esender_user_id\x18\x02 \x01(\tR\fsenderUserId\x12'\n" +
+	"\x0fidempotency_key\x18\x03 \x01(\tR\x0eidempotencyKey\x12!\n" +
+	"\fmessage_type\x18\x04 \x01(\tR\vmessageType\x12\x18\n" +
+	"\acontent\x18\x05 \x01(\tR\acontent\x12#\n" +
+	"\rmetadata_json\x18\x06 \x01(\tR\fmetadataJson\x12-\n" +
+	"\x13reply_to_message_id\x18\a \x01(\tR\x10replyToMessageId\x12%\n" +
+	"\x0eattachment_ids\x18\b \x03(\tR\rattachmentIds\x123\n" +
+	"\asend_at\x18\t \x01(\v2\x1a.google.protobuf.TimestampR\x06sendAt\x12(\n" +
+	"\x10sender_device_id\x18\n" +
+	" \x01(\tR\x0esenderDeviceId\x12G\n" +
+	"\vciphertexts\x18\v \x03(\v2%.realchat.message.v1.DeviceCiphertextR\vciphertexts\"\xc8\x01\n" +
+	"\x13SendMessageResponse\x126\n" +
+	"\amessage\x18\x01 \x01(\v2\x1c.realchat.message.v1.MessageR\amessage\x12C\n" +
+	"\tscheduled\x18\x02 \x01(\v2%.realchat.message.v1.ScheduledMessageR\tscheduled\x124\n" +
+	"\x04held\x18\x03 \x01(\v2 .realchat.message.v1.HeldMessageR\x04held\"\x82\x01\n" +
+	"\x14DeleteMessageRequest\x12'\n" +
+	"\x0fconversation_id\x18\x01 \x01(\tR\x0econversationId\x12\x1d\n" +
+	"\n" +
+	"message_id\x18\x02 \x01(\tR\tmessageId\x12\"\n" +
+	"\ractor_user_id\x18\x03 \x01(\tR\vactorUserId\"\x17\n" +
+	"\x15DeleteMessageResponse\"\xbf\x01\n" +
+	"\x12EditMessageRequest\x12'\n" +
+	"\x0fconversation_id\x18\x01 \x01(\tR\x0econversationId\x12\x1d\n" +
+	"\n" +
+	"message_id\x18\x02 \x01(\tR\tmessageId\x12\"\n" +
+	"\ractor_user_id\x18\x03 \x01(\tR\vactorUserId\x12\x18\n" +
+	"\acontent\x18\x04 \x01(\tR\acontent\x12#\n" +
+	"\rmetadata_json\x18\x05 \x01(\tR\fmetadataJson\"M\n" +
+	"\x13EditMessageResponse\x126\n" +
+	"\amessage\x18\x01 \x01(\v2\x1c.realchat.message.v1.MessageR\amessage\"\xf1\x01\n" +
+	"\x13SyncMessagesRequest\x12'\n" +
+	"\x0fconversation_id\x18\x01 \x01(\tR\x0econversationId\x12%\n" +
+	"\x0eafter_sequence\x18\x02 \x01(\x03R\rafterSequence\x12\x1b\n" +
+	"\tpage_size\x18\x03 \x01(\x05R\bpageSize\x12'\n" +
+	"\x0fbefore_sequence\x18\x04 \x01(\x03R\x0ebeforeSequence\x12'\n" +
+	"\x0faround_sequence\x18\x05 \x01(\x03R\x0earoundSequence\x12\x1b\n" +
+	"\tdevice_id\x18\x06 \x01(\tR\bdeviceId\"\x9e\x01\n" +
+	"\x14SyncMessagesResponse\x128\n" +
+	"\bmessages\x18\x01 \x03(\v2\x1c.realchat.message.v1.MessageR\bmessages\x12&\n" +
+	"\x0fhas_more_before\x18\x02 \x01(\bR\rhasMoreBefore\x12$\n" +
+	"\x0ehas_more_after\x18\x03 \x01(\bR\fhasMoreAfter\"\xad\x01\n" +
+	"\x18ListThreadRepliesRequest\x12'\n" +
+	"\x0fconversation_id\x18\x01 \x01(\tR\x0econversationId\x12$\n" +
+	"\x0ethread_root_id\x18\x02 \x01(\tR\fthreadRootId\x12%\n" +
+	"\x0eafter_sequence\x18\x03 \x01(\x03R\rafterSequence\x12\x1b\n" +
+	"\tpage_size\x18\x04 \x01(\x05R\bpageSize\"\x85\x01\n" +
+	"\x19ListThreadRepliesResponse\x120\n" +
+	"\x04root\x18\x01 \x01(\v2\x1c.realchat.message.v1.MessageR\x04root\x126\n" +
+	"\areplies\x18\x02 \x03(\v2\x1c.realchat.message.v1.MessageR\areplies\"\x96\x01\n" +
+	"\x12AddReactionRequest\x12'\n" +
+	"\x0fconversation_id\x18\x01 \x01(\tR\x0econversationId\x12\x1d\n" +
+	"\n" +
+	"message_id\x18\x02 \x01(\tR\tmessageId\x12\"\n" +
+	"\ractor_user_id\x18\x03 \x01(\tR\vactorUserId\x12\x14\n" +
+	"\x05emoji\x18\x04 \x01(\tR\x05emoji\"Y\n" +
+	"\x13AddReactionResponse\x12B\n" +
+	"\treactions\x18\x01 \x03(\v2$.realchat.message.v1.ReactionSummaryR\treactions\"\x99\x01\n" +
+	"\x15RemoveReactionRequest\x12'\n" +
+	"\x0fconversation_id\x18\x01 \x01(\tR\x0econversationId\x12\x1d\n" +
+	"\n" +
+	"message_id\x18\x02 \x01(\tR\tmessageId\x12\"\n" +
+	"\ractor_user_id\x18\x03 \x01(\tR\vactorUserId\x12\x14\n" +
+	"\x05emoji\x18\x04 \x01(\tR\x05emoji\"\\\n" +
+	"\x16RemoveReactionResponse\x12B\n" +
+	"\treactions\x18\x01 \x03(\v2$.realchat.message.v1.ReactionSummaryR\treactions\"\xd3\x02\n" +
+	"\x15SearchMessagesRequest\x12\x14\n" +
+	"\x05query\x18\x01 \x01(\tR\x05query\x12'\n" +
+	"\x0fconversation_id\x18\x02 \x01(\tR\x0econversationId\x12$\n" +
+	"\x0esender_user_id\x18\x03 \x01(\tR\fsenderUserId\x12!\n" +
+	"\fmessage_type\x18\x04 \x01(\tR\vmessageType\x129\n" +
+	"\n" +
+	"sent_after\x18\x05 \x01(\v2\x1a.google.protobuf.TimestampR\tsentAfter\x12;\n" +
+	"\vsent_before\x18\x06 \x01(\v2\x1a.google.protobuf.TimestampR\n" +
+	"sentBefore\x12\x1b\n" +
+	"\tpage_size\x18\a \x01(\x05R\bpageSize\x12\x1d\n" +
+	"\n" +
+	"page_token\x18\b \x01(\tR\tpageToken\"]\n" +
+	"\tSearchHit\x126\n" +
+	"\amessage\x18\x01 \x01(\v2\x1c.realchat.message.v1.MessageR\amessage\x12\x18\n" +
+	"\asnippet\x18\x02 \x01(\tR\asnippet\"t\n" +
+	"\x16SearchMessagesResponse\x122\n" +
+	"\x04hits\x18\x01 \x03(\v2\x1e.realchat.message.v1.SearchHitR\x04hits\x12&\n" +
+	"\x0fnext_page_token\x18\x02 \x01(\tR\rnextPageToken\"\x7f\n" +
+	"\x11PinMessageRequest\x12'\n" +
+	"\x0fconversation_id\x18\x01 \x01(\tR\x0econversationId\x12\x1d\n" +
+	"\n" +
+	"message_id\x18\x02 \x01(\tR\tmessageId\x12\"\n" +
+	"\ractor_user_id\x18\x03 \x01(\tR\vactorUserId\"J\n" +
+	"\x12PinMessageResponse\x124\n" +
+	"\x03pin\x18\x01 \x01(\v2\".realchat.message.v1.PinnedMessageR\x03pin\"\x81\x01\n" +
+	"\x13UnpinMessageRequest\x12'\n" +
+	"\x0fconversation_id\x18\x01 \x01(\tR\x0econversationId\x12\x1d\n" +
+	"\n" +
+	"message_id\x18\x02 \x01(\tR\tmessageId\x12\"\n" +
+	"\ractor_user_id\x18\x03 \x01(\tR\vactorUserId\"\x16\n" +
+	"\x14UnpinMessageResponse\"D\n" +
+	"\x19ListPinnedMessagesRequest\x12'\n" +
+	"\x0fconversation_id\x18\x01 \x01(\tR\x0econversationId\"T\n" +
+	"\x1aListPinnedMessagesResponse\x126\n" +
+	"\x04pins\x18\x01 \x03(\v2\".realchat.message.v1.PinnedMessageR\x04pins\"G\n" +
+	"\x1cListScheduledMessagesRequest\x12'\n" +
+	"\x0fconversation_id\x18\x01 \x01(\tR\x0econversationId\"d\n" +
+	"\x1dListScheduledMessagesResponse\x12C\n" +
+	"\tscheduled\x18\x01 \x03(\v2%.realchat.message.v1.ScheduledMessageR\tscheduled\"\xda\x01\n" +
+	"\x1dUpdateScheduledMessageRequest\x12!\n" +
+	"\fscheduled_id\x18\x01 \x01(\tR\vscheduledId\x12\"\n" +
+	"\ractor_user_id\x18\x02 \x01(\tR\vactorUserId\x12\x18\n" +
+	"\acontent\x18\x03 \x01(\tR\acontent\x12#\n" +
+	"\rmetadata_json\x18\x04 \x01(\tR\fmetadataJson\x123\n" +
+	"\asend_at\x18\x05 \x01(\v2\x1a.google.protobuf.TimestampR\x06sendAt\"e\n" +
+	"\x1eUpdateScheduledMessageResponse\x12C\n" +
+	"\tscheduled\x18\x01 \x01(\v2%.realchat.message.v1.ScheduledMessageR\tscheduled\"f\n" +
+	"\x1dCancelScheduledMessageRequest\x12!\n" +
+	"\fscheduled_id\x18\x01 \x01(\tR\vscheduledId\x12\"\n" +
+	"\ractor_user_id\x18\x02 \x01(\tR\vactorUserId\" \n" +
+	"\x1eCancelScheduledMessageResponse\"\xf4\x01\n" +
+	"\x16ForwardMessagesRequest\x124\n" +
+	"\x16source_conversation_id\x18\x01 \x01(\tR\x14sourceConversationId\x124\n" +
+	"\x16target_conversation_id\x18\x02 \x01(\tR\x14targetConversationId\x12\x1f\n" +
+	"\vmessage_ids\x18\x03 \x03(\tR\n" +
+	"messageIds\x12$\n" +
+	"\x0esender_user_id\x18\x04 \x01(\tR\fsenderUserId\x12'\n" +
+	"\x0fidempotency_key\x18\x05 \x01(\tR\x0eidempotencyKey\"S\n" +
+	"\x17ForwardMessagesResponse\x128\n" +
+	"\bmessages\x18\x01 \x03(\v2\x1c.realchat.message.v1.MessageR\bmessages\"\x1f\n" +
+	"\x1dGetUnreadMentionCountsRequest\"a\n" +
+	"\x1eGetUnreadMentionCountsResponse\x12?\n" +
+	"\x06counts\x18\x01 \x03(\v2'.realchat.message.v1.UnreadMentionCountR\x06counts\"S\n" +
+	"\x12UnreadMentionCount\x12'\n" +
+	"\x0fconversation_id\x18\x01 \x01(\tR\x0econversationId\x12\x14\n" +
+	"\x05count\x18\x02 \x01(\x03R\x05count\"\xa4\x01\n" +
+	"\x0fVotePollRequest\x12'\n" +
+	"\x0fconversation_id\x18\x01 \x01(\tR\x0econversationId\x12\x1d\n" +
+	"\n" +
+	"message_id\x18\x02 \x01(\tR\tmessageId\x12\"\n" +
+	"\ractor_user_id\x18\x03 \x01(\tR\vactorUserId\x12%\n" +
+	"\x0eoption_indexes\x18\x04 \x03(\x05R\roptionIndexes\"F\n" +
+	"\x10VotePollResponse\x122\n" +
+	"\x04poll\x18\x01 \x01(\v2\x1e.realchat.message.v1.PollStateR\x04poll\"\x80\x01\n" +
+	"\x12RetractVoteRequest\x12'\n" +
+	"\x0fconversation_id\x18\x01 \x01(\tR\x0econversationId\x12\x1d\n" +
+	"\n" +
+	"message_id\x18\x02 \x01(\tR\tmessageId\x12\"\n" +
+	"\ractor_user_id\x18\x03 \x01(\tR\vactorUserId\"I\n" +
+	"\x13RetractVoteResponse\x122\n" +
+	"\x04poll\x18\x01 \x01(\v2\x1e.realchat.message.v1.PollStateR\x04poll\"~\n" +
+	"\x10ClosePollRequest\x12'\n" +
+	"\x0fconversation_id\x18\x01 \x01(\tR\x0econversationId\x12\x1d\n" +
+	"\n" +
+	"message_id\x18\x02 \x01(\tR\tmessageId\x12\"\n" +
+	"\ractor_user_id\x18\x03 \x01(\tR\vactorUserId\"G\n" +
+	"\x11ClosePollResponse\x122\n" +
+	"\x04poll\x18\x01 \x01(\v2\x1e.realchat.message.v1.PollStateR\x04poll\"]\n" +
+	"\x0fRetentionPolicy\x12+\n" +
+	"\x11retention_seconds\x18\x01 \x01(\x03R\x10retentionSeconds\x12\x1d\n" +
+	"\n" +
+	"is_default\x18\x02 \x01(\bR\tisDefault\"\xb6\x01\n" +
+	"\x19SetRetentionPolicyRequest\x12'\n" +
+	"\x0fconversation_id\x18\x01 \x01(\tR\x0econversationId\x12\"\n" +
+	"\ractor_user_id\x18\x02 \x01(\tR\vactorUserId\x12+\n" +
+	"\x11retention_seconds\x18\x03 \x01(\x03R\x10retentionSeconds\x12\x1f\n" +
+	"\vuse_default\x18\x04 \x01(\bR\n" +
+	"useDefault\"Z\n" +
+	"\x1aSetRetentionPolicyResponse\x12<\n" +
+	"\x06policy\x18\x01 \x01(\v2$.realchat.message.v1.RetentionPolicyR\x06policy\"D\n" +
+	"\x19GetRetentionPolicyRequest\x12'\n" +
+	"\x0fconversation_id\x18\x01 \x01(\tR\x0econversationId\"Z\n" +
+	"\x1aGetRetentionPolicyResponse\x12<\n" +
+	"\x06policy\x18\x01 \x01(\v2$.realchat.message.v1.RetentionPolicyR\x06policy\"\xf0\x02\n" +
+	"\x12ConversationExport\x12\x1b\n" +
+	"\texport_id\x18\x01 \x01(\tR\bexportId\x12'\n" +
+	"\x0fconversation_id\x18\x02 \x01(\tR\x0econversationId\x12\x17\n" +
+	"\auser_id\x18\x03 \x01(\tR\x06userId\x12\x16\n" +
+	"\x06status\x18\x04 \x01(\tR\x06status\x12#\n" +
+	"\rmessage_count\x18\x05 \x01(\x03R\fmessageCount\x12\x1d\n" +
+	"\n" +
+	"size_bytes\x18\x06 \x01(\x03R\tsizeBytes\x12%\n" +
+	"\x0efailure_reason\x18\a \x01(\tR\rfailureReason\x129\n" +
+	"\n" +
+	"created_at\x18\b \x01(\v2\x1a.google.protobuf.TimestampR\tcreatedAt\x12=\n" +
+	"\fcompleted_at\x18\t \x01(\v2\x1a.google.protobuf.TimestampR\vcompletedAt\"X\n" +
+	"\x14RequestExportRequest\x12'\n" +
+	"\x0fconversation_id\x18\x01 \x01(\tR\x0econversationId\x12\x17\n" +
+	"\auser_id\x18\x02 \x01(\tR\x06userId\"X\n" +
+	"\x15RequestExportResponse\x12?\n" +
+	"\x06export\x18\x01 \x01(\v2'.realchat.message.v1.ConversationExportR\x06export\"/\n" +
+	"\x10GetExportRequest\x12\x1b\n" +
+	"\texport_id\x18\x01 \x01(\tR\bexportId\"T\n" +
+	"\x11GetExportResponse\x12?\n" +
+	"\x06export\x18\x01 \x01(\v2'.realchat.message.v1.ConversationExportR\x06export\"4\n" +
+	"\x15DownloadExportRequest\x12\x1b\n" +
+	"\texport_id\x18\x01 \x01(\tR\bexportId\"m\n" +
+	"\x16DownloadExportResponse\x12?\n" +
+	"\x06export\x18\x01 \x01(\v2'.realchat.message.v1.ConversationExportR\x06export\x12\x12\n" +
+	"\x04data\x18\x02 \x01(\fR\x04data\"\xad\x03\n" +
+	"\rMessageImport\x12\x1b\n" +
+	"\timport_id\x18\x01 \x01(\tR\bimportId\x12'\n" +
+	"\x0fconversation_id\x18\x02 \x01(\tR\x0econversationId\x12\x16\n" +
+	"\x06source\x18\x03 \x01(\tR\x06source\x12\x12\n" +
+	"\x04name\x18\x04 \x01(\tR\x04name\x12\x1d\n" +
+	"\n" +
+	"created_by\x18\x05 \x01(\tR\tcreatedBy\x12\x16\n" +
+	"\x06status\x18\x06 \x01(\tR\x06status\x12%\n" +
+	"\x0etotal_messages\x18\a \x01(\x03R\rtotalMessages\x12+\n" +
+	"\x11imported_messages\x18\b \x01(\x03R\x10importedMessages\x12%\n" +
+	"\x0efailure_reason\x18\t \x01(\tR\rfailureReason\x129\n" +
+	"\n" +
+	"created_at\x18\n" +
+	" \x01(\v2\x1a.google.protobuf.TimestampR\tcreatedAt\x12=\n" +
+	"\fcompleted_at\x18\v \x01(\v2\x1a.google.protobuf.TimestampR\vcompletedAt\"\xe2\x02\n" +
+	"\x14RequestImportRequest\x12\x17\n" +
+	"\auser_id\x18\x01 \x01(\tR\x06userId\x12\x16\n" +
+	"\x06source\x18\x02 \x01(\tR\x06source\x12\x12\n" +
+	"\x04name\x18\x03 \x01(\tR\x04name\x12#\n" +
+	"\rattachment_id\x18\x04 \x01(\tR\fattachmentId\x12P\n" +
+	"\amapping\x18\x05 \x03(\v26.realchat.message.v1.RequestImportRequest.MappingEntryR\amapping\x12\x18\n" +
+	"\achannel\x18\x06 \x01(\tR\achannel\x12\x1b\n" +
+	"\ttime_zone\x18\a \x01(\tR\btimeZone\x12\x1b\n" +
+	"\tday_first\x18\b \x01(\bR\bdayFirst\x1a:\n" +
+	"\fMappingEntry\x12\x10\n" +
+	"\x03key\x18\x01 \x01(\tR\x03key\x12\x14\n" +
+	"\x05value\x18\x02 \x01(\tR\x05value:\x028\x01\"S\n" +
+	"\x15RequestImportResponse\x12:\n" +
+	"\x06import\x18\x01 \x01(\v2\".realchat.message.v1.MessageImportR\x06import\"/\n" +
+	"\x10GetImportRequest\x12\x1b\n" +
+	"\timport_id\x18\x01 \x01(\tR\bimportId\"O\n" +
+	"\x11GetImportResponse\x12:\n" +
+	"\x06import\x18\x01 \x01(\v2\".realchat.message.v1.MessageImportR\x06import\"\xa7\x01\n" +
+	"\x10SaveDraftRequest\x12'\n" +
+	"\x0fconversation_id\x18\x01 \x01(\tR\x0econversationId\x12\x12\n" +
+	"\x04text\x18\x02 \x01(\tR\x04text\x129\n" +
+	"\n" +
+	"updated_at\x18\x03 \x01(\v2\x1a.google.protobuf.TimestampR\tupdatedAt\x12\x1b\n" +
+	"\tdevice_id\x18\x04 \x01(\tR\bdeviceId\"_\n" +
+	"\x11SaveDraftResponse\x120\n" +
+	"\x05draft\x18\x01 \x01(\v2\x1a.realchat.message.v1.DraftR\x05draft\x12\x18\n" +
+	"\aapplied\x18\x02 \x01(\bR\aapplied\"\x94\x01\n" +
+	"\x11ClearDraftRequest\x12'\n" +
+	"\x0fconversation_id\x18\x01 \x01(\tR\x0econversationId\x129\n" +
+	"\n" +
+	"cleared_at\x18\x02 \x01(\v2\x1a.google.protobuf.TimestampR\tclearedAt\x12\x1b\n" +
+	"\tdevice_id\x18\x03 \x01(\tR\bdeviceId\"`\n" +
+	"\x12ClearDraftResponse\x120\n" +
+	"\x05draft\x18\x01 \x01(\v2\x1a.realchat.message.v1.DraftR\x05draft\x12\x18\n" +
+	"\aapplied\x18\x02 \x01(\bR\aapplied\"\x12\n" +
+	"\x10GetDraftsRequest\"G\n" +
+	"\x11GetDraftsResponse\x122\n" +
+	"\x06drafts\x18\x01 \x03(\v2\x1a.realchat.message.v1.DraftR\x06drafts\"m\n" +
+	"\x17ListHeldMessagesRequest\x12\x16\n" +
+	"\x06status\x18\x01 \x01(\tR\x06status\x12\x1b\n" +
+	"\tpage_size\x18\x02 \x01(\x05R\bpageSize\x12\x1d\n" +
+	"\n" +
+	"page_token\x18\x03 \x01(\tR\tpageToken\"x\n" +
+	"\x18ListHeldMessagesResponse\x124\n" +
+	"\x04held\x18\x01 \x03(\v2 .realchat.message.v1.HeldMessageR\x04held\x12&\n" +
+	"\x0fnext_page_token\x18\x02 \x01(\tR\rnextPageToken\"a\n" +
+	"\x18ReviewHeldMessageRequest\x12\x17\n" +
+	"\aheld_id\x18\x01 \x01(\tR\x06heldId\x12\x18\n" +
+	"\aapprove\x18\x02 \x01(\bR\aapprove\x12\x12\n" +
+	"\x04note\x18\x03 \x01(\tR\x04note\"\x89\x01\n" +
+	"\x19ReviewHeldMessageResponse\x124\n" +
+	"\x04held\x18\x01 \x01(\v2 .realchat.message.v1.HeldMessageR\x04held\x126\n" +
+	"\amessage\x18\x02 \x01(\v2\x1c.realchat.message.v1.MessageR\amessage2\xf4\x19\n" +
+	"\n" +
+	"MessageApi\x12`\n" +
+	"\vSendMessage\x12'.realchat.message.v1.SendMessageRequest\x1a(.realchat.message.v1.SendMessageResponse\x12f\n" +
+	"\rDeleteMessage\x12).realchat.message.v1.DeleteMessageRequest\x1a*.realchat.message.v1.DeleteMessageResponse\x12c\n" +
+	"\fSyncMessages\x12(.realchat.message.v1.SyncMessagesRequest\x1a).realchat.message.v1.SyncMessagesResponse\x12`\n" +
+	"\vEditMessage\x12'.realchat.message.v1.EditMessageRequest\x1a(.realchat.message.v1.EditMessageResponse\x12r\n" +
+	"\x11ListThreadReplies\x12-.realchat.message.v1.ListThreadRepliesRequest\x1a..realchat.message.v1.ListThreadRepliesResponse\x12`\n" +
+	"\vAddReaction\x12'.realchat.message.v1.AddReactionRequest\x1a(.realchat.message.v1.AddReactionResponse\x12i\n" +
+	"\x0eRemoveReaction\x12*.realchat.message.v1.RemoveReactionRequest\x1a+.realchat.message.v1.RemoveReactionResponse\x12i\n" +
+	"\x0eSearchMessages\x12*.realchat.message.v1.SearchMessagesRequest\x1a+.realchat.message.v1.SearchMessagesResponse\x12]\n" +
+	"\n" +
+	"PinMessage\x12&.realchat.message.v1.PinMessageRequest\x1a'.realchat.message.v1.PinMessageResponse\x12c\n" +
+	"\fUnpinMessage\x12(.realchat.message.v1.UnpinMessageRequest\x1a).realchat.message.v1.UnpinMessageResponse\x12u\n" +
+	"\x12ListPinnedMessages\x12..realchat.message.v1.ListPinnedMessagesRequest\x1a/.realchat.message.v1.ListPinnedMessagesResponse\x12~\n" +
+	"\x15ListScheduledMessages\x121.realchat.message.v1.ListScheduledMessagesRequest\x1a2.realchat.message.v1.ListScheduledMessagesResponse\x12\x81\x01\n" +
+	"\x16UpdateScheduledMessage\x122.realchat.message.v1.UpdateScheduledMessageRequest\x1a3.realchat.message.v1.UpdateScheduledMessageResponse\x12\x81\x01\n" +
+	"\x16CancelScheduledMessage\x122.realchat.message.v1.CancelScheduledMessageRequest\x1a3.realchat.message.v1.CancelScheduledMessageResponse\x12l\n" +
+	"\x0fForwardMessages\x12+.realchat.message.v1.ForwardMessagesRequest\x1a,.realchat.message.v1.ForwardMessagesResponse\x12\x81\x01\n" +
+	"\x16GetUnreadMentionCounts\x122.realchat.message.v1.GetUnreadMentionCountsRequest\x1a3.realchat.message.v1.GetUnreadMentionCountsResponse\x12W\n" +
+	"\bVotePoll\x12$.realchat.message.v1.VotePollRequest\x1a%.realchat.message.v1.VotePollResponse\x12`\n" +
+	"\vRetractVote\x12'.realchat.message.v1.RetractVoteRequest\x1a(.realchat.message.v1.RetractVoteResponse\x12Z\n" +
+	"\tClosePoll\x12%.realchat.message.v1.ClosePollRequest\x1a&.realchat.message.v1.ClosePollResponse\x12u\n" +
+	"\x12SetRetentionPolicy\x12..realchat.message.v1.SetRetentionPolicyRequest\x1a/.realchat.message.v1.SetRetentionPolicyResponse\x12u\n" +
+	"\x12GetRetentionPolicy\x12..realchat.message.v1.GetRetentionPolicyRequest\x1a/.realchat.message.v1.GetRetentionPolicyResponse\x12f\n" +
+	"\rRequestExport\x12).realchat.message.v1.RequestExportRequest\x1a*.realchat.message.v1.RequestExportResponse\x12Z\n" +
+	"\tGetExport\x12%.realchat.message.v1.GetExportRequest\x1a&.realchat.message.v1.GetExportResponse\x12k\n" +
+	"\x0eDownloadExport\x12*.realchat.message.v1.DownloadExportRequest\x1a+.realchat.message.v1.DownloadExportResponse0\x01\x12f\n" +
+	"\rRequestImport\x12).realchat.message.v1.RequestImportRequest\x1a*.realchat.message.v1.RequestImportResponse\x12Z\n" +
+	"\tGetImport\x12%.realchat.message.v1.GetImportRequest\x1a&.realchat.message.v1.GetImportResponse\x12Z\n" +
+	"\tSaveDraft\x12%.realchat.message.v1.SaveDraftRequest\x1a&.realchat.message.v1.SaveDraftResponse\x12]\n" +
+	"\n" +
+	"ClearDraft\x12&.realchat.message.v1.ClearDraftRequest\x1a'.realchat.message.v1.ClearDraftResponse\x12Z\n" +
+	"\tGetDrafts\x12%.realchat.message.v1.GetDraftsRequest\x1a&.realchat.message.v1.GetDraftsResponse\x12o\n" +
+	"\x10ListHeldMessages\x12,.realchat.message.v1.ListHeldMessagesRequest\x1a-.realchat.message.v1.ListHeldMessagesResponse\x12r\n" +
+	"\x11ReviewHeldMessage\x12-.realchat.message.v1.ReviewHeldMessageRequest\x1a..realchat.message.v1.ReviewHeldMessageResponseBNZLgithub.com/SARVESHVARADKAR123/RealChat/contracts/gen/go/message/v1;messagev1b\x06proto3"
 
 var (
 	file_message_v1_message_api_proto_rawDescOnce sync.Once
@@ -393,30 +4169,199 @@ func file_message_v1_message_api_proto_rawDescGZIP() []byte {
 	return file_message_v1_message_api_proto_rawDescData
 }
 
-var file_message_v1_message_api_proto_msgTypes = make([]protoimpl.MessageInfo, 6)
+var file_message_v1_message_api_proto_msgTypes = make([]protoimpl.MessageInfo, 68)
 var file_message_v1_message_api_proto_goTypes = []any{
-	(*SendMessageRequest)(nil),    // 0: realchat.message.v1.SendMessageRequest
-	(*SendMessageResponse)(nil),   // 1: realchat.message.v1.SendMessageResponse
-	(*DeleteMessageRequest)(nil),  // 2: realchat.message.v1.DeleteMessageRequest
-	(*DeleteMessageResponse)(nil), // 3: realchat.message.v1.DeleteMessageResponse
-	(*SyncMessagesRequest)(nil),   // 4: realchat.message.v1.SyncMessagesRequest
-	(*SyncMessagesResponse)(nil),  // 5: realchat.message.v1.SyncMessagesResponse
-	(*Message)(nil),               // 6: realchat.message.v1.Message
+	(*SendMessageRequest)(nil),             // 0: realchat.message.v1.SendMessageRequest
+	(*SendMessageResponse)(nil),            // 1: realchat.message.v1.SendMessageResponse
+	(*DeleteMessageRequest)(nil),           // 2: realchat.message.v1.DeleteMessageRequest
+	(*DeleteMessageResponse)(nil),          // 3: realchat.message.v1.DeleteMessageResponse
+	(*EditMessageRequest)(nil),             // 4: realchat.message.v1.EditMessageRequest
+	(*EditMessageResponse)(nil),            // 5: realchat.message.v1.EditMessageResponse
+	(*SyncMessagesRequest)(nil),            // 6: realchat.message.v1.SyncMessagesRequest
+	(*SyncMessagesResponse)(nil),           // 7: realchat.message.v1.SyncMessagesResponse
+	(*ListThreadRepliesRequest)(nil),       // 8: realchat.message.v1.ListThreadRepliesRequest
+	(*ListThreadRepliesResponse)(nil),      // 9: realchat.message.v1.ListThreadRepliesResponse
+	(*AddReactionRequest)(nil),             // 10: realchat.message.v1.AddReactionRequest
+	(*AddReactionResponse)(nil),            // 11: realchat.message.v1.AddReactionResponse
+	(*RemoveReactionRequest)(nil),          // 12: realchat.message.v1.RemoveReactionRequest
+	(*RemoveReactionResponse)(nil),         // 13: realchat.message.v1.RemoveReactionResponse
+	(*SearchMessagesRequest)(nil),          // 14: realchat.message.v1.SearchMessagesRequest
+	(*SearchHit)(nil),                      // 15: realchat.message.v1.SearchHit
+	(*SearchMessagesResponse)(nil),         // 16: realchat.message.v1.SearchMessagesResponse
+	(*PinMessageRequest)(nil),              // 17: realchat.message.v1.PinMessageRequest
+	(*PinMessageResponse)(nil),             // 18: realchat.message.v1.PinMessageResponse
+	(*UnpinMessageRequest)(nil),            // 19: realchat.message.v1.UnpinMessageRequest
+	(*UnpinMessageResponse)(nil),           // 20: realchat.message.v1.UnpinMessageResponse
+	(*ListPinnedMessagesRequest)(nil),      // 21: realchat.message.v1.ListPinnedMessagesRequest
+	(*ListPinnedMessagesResponse)(nil),     // 22: realchat.message.v1.ListPinnedMessagesResponse
+	(*ListScheduledMessagesRequest)(nil),   // 23: realchat.message.v1.ListScheduledMessagesRequest
+	(*ListScheduledMessagesResponse)(nil),  // 24: realchat.message.v1.ListScheduledMessagesResponse
+	(*UpdateScheduledMessageRequest)(nil),  // 25: realchat.message.v1.UpdateScheduledMessageRequest
+	(*UpdateScheduledMessageResponse)(nil), // 26: realchat.message.v1.UpdateScheduledMessageResponse
+	(*CancelScheduledMessageRequest)(nil),  // 27: realchat.message.v1.CancelScheduledMessageRequest
+	(*CancelScheduledMessageResponse)(nil), // 28: realchat.message.v1.CancelScheduledMessageResponse
+	(*ForwardMessagesRequest)(nil),         // 29: realchat.message.v1.ForwardMessagesRequest
+	(*ForwardMessagesResponse)(nil),        // 30: realchat.message.v1.ForwardMessagesResponse
+	(*GetUnreadMentionCountsRequest)(nil),  // 31: realchat.message.v1.GetUnreadMentionCountsRequest
+	(*GetUnreadMentionCountsResponse)(nil), // 32: realchat.message.v1.GetUnreadMentionCountsResponse
+	(*UnreadMentionCount)(nil),             // 33: realchat.message.v1.UnreadMentionCount
+	(*VotePollRequest)(nil),                // 34: realchat.message.v1.VotePollRequest
+	(*VotePollResponse)(nil),               // 35: realchat.message.v1.VotePollResponse
+	(*RetractVoteRequest)(nil),             // 36: realchat.message.v1.RetractVoteRequest
+	(*RetractVoteResponse)(nil),            // 37: realchat.message.v1.RetractVoteResponse
+	(*ClosePollRequest)(nil),               // 38: realchat.message.v1.ClosePollRequest
+	(*ClosePollResponse)(nil),              // 39: realchat.message.v1.ClosePollResponse
+	(*RetentionPolicy)(nil),                // 40: realchat.message.v1.RetentionPolicy
+	(*SetRetentionPolicyRequest)(nil),      // 41: realchat.message.v1.SetRetentionPolicyRequest
+	(*SetRetentionPolicyResponse)(nil),     // 42: realchat.message.v1.SetRetentionPolicyResponse
+	(*GetRetentionPolicyRequest)(nil),      // 43: realchat.message.v1.GetRetentionPolicyRequest
+	(*GetRetentionPolicyResponse)(nil),     // 44: realchat.message.v1.GetRetentionPolicyResponse
+	(*ConversationExport)(nil),             // 45: realchat.message.v1.ConversationExport
+	(*RequestExportRequest)(nil),           // 46: realchat.message.v1.RequestExportRequest
+	(*RequestExportResponse)(nil),          // 47: realchat.message.v1.RequestExportResponse
+	(*GetExportRequest)(nil),               // 48: realchat.message.v1.GetExportRequest
+	(*GetExportResponse)(nil),              // 49: realchat.message.v1.GetExportResponse
+	(*DownloadExportRequest)(nil),          // 50: realchat.message.v1.DownloadExportRequest
+	(*DownloadExportResponse)(nil),         // 51: realchat.message.v1.DownloadExportResponse
+	(*MessageImport)(nil),                  // 52: realchat.message.v1.MessageImport
+	(*RequestImportRequest)(nil),           // 53: realchat.message.v1.RequestImportRequest
+	(*RequestImportResponse)(nil),          // 54: realchat.message.v1.RequestImportResponse
+	(*GetImportRequest)(nil),               // 55: realchat.message.v1.GetImportRequest
+	(*GetImportResponse)(nil),              // 56: realchat.message.v1.GetImportResponse
+	(*SaveDraftRequest)(nil),               // 57: realchat.message.v1.SaveDraftRequest
+	(*SaveDraftResponse)(nil),              // 58: realchat.message.v1.SaveDraftResponse
+	(*ClearDraftRequest)(nil),              // 59: realchat.message.v1.ClearDraftRequest
+	(*ClearDraftResponse)(nil),             // 60: realchat.message.v1.ClearDraftResponse
+	(*GetDraftsRequest)(nil),               // 61: realchat.message.v1.GetDraftsRequest
+	(*GetDraftsResponse)(nil),              // 62: realchat.message.v1.GetDraftsResponse
+	(*ListHeldMessagesRequest)(nil),        // 63: realchat.message.v1.ListHeldMessagesRequest
+	(*ListHeldMessagesResponse)(nil),       // 64: realchat.message.v1.ListHeldMessagesResponse
+	(*ReviewHeldMessageRequest)(nil),       // 65: realchat.message.v1.ReviewHeldMessageRequest
+	(*ReviewHeldMessageResponse)(nil),      // 66: realchat.message.v1.ReviewHeldMessageResponse
+	nil,                                    // 67: realchat.message.v1.RequestImportRequest.MappingEntry
+	(*timestamppb.Timestamp)(nil),          // 68: google.protobuf.Timestamp
+	(*DeviceCiphertext)(nil),               // 69: realchat.message.v1.DeviceCiphertext
+	(*Message)(nil),                        // 70: realchat.message.v1.Message
+	(*ScheduledMessage)(nil),               // 71: realchat.message.v1.ScheduledMessage
+	(*HeldMessage)(nil),                    // 72: realchat.message.v1.HeldMessage
+	(*ReactionSummary)(nil),                // 73: realchat.message.v1.ReactionSummary
+	(*PinnedMessage)(nil),                  // 74: realchat.message.v1.PinnedMessage
+	(*PollState)(nil),                      // 75: realchat.message.v1.PollState
+	(*Draft)(nil),                          // 76: realchat.message.v1.Draft
 }
 var file_message_v1_message_api_proto_depIdxs = []int32{
-	6, // 0: realchat.message.v1.SendMessageResponse.message:type_name -> realchat.message.v1.Message
-	6, // 1: realchat.message.v1.SyncMessagesResponse.messages:type_name -> realchat.message.v1.Message
-	0, // 2: realchat.message.v1.MessageApi.SendMessage:input_type -> realchat.message.v1.SendMessageRequest
-	2, // 3: realchat.message.v1.MessageApi.DeleteMessage:input_type -> realchat.message.v1.DeleteMessageRequest
-	4, // 4: realchat.message.v1.MessageApi.SyncMessages:input_type -> realchat.message.v1.SyncMessagesRequest
-	1, // 5: realchat.message.v1.MessageApi.SendMessage:output_type -> realchat.message.v1.SendMessageResponse
-	3, // 6: realchat.message.v1.MessageApi.DeleteMessage:output_type -> realchat.message.v1.DeleteMessageResponse
-	5, // 7: realchat.message.v1.MessageApi.SyncMessages:output_type -> realchat.message.v1.SyncMessagesResponse
-	5, // [5:8] is the sub-list for method output_type
-	2, // [2:5] is the sub-list for method input_type
-	2, // [2:2] is the sub-list for extension type_name
-	2, // [2:2] is the sub-list for extension extendee
-	0, // [0:2] is the sub-list for field type_name
+	68, // 0: realchat.message.v1.SendMessageRequest.send_at:type_name -> google.protobuf.Timestamp
+	69, // 1: realchat.message.v1.SendMessageRequest.ciphertexts:type_name -> realchat.message.v1.DeviceCiphertext
+	70, // 2: realchat.message.v1.SendMessageResponse.message:type_name -> realchat.message.v1.Message
+	71, // 3: realchat.message.v1.SendMessageResponse.scheduled:type_name -> realchat.message.v1.ScheduledMessage
+	72, // 4: realchat.message.v1.SendMessageResponse.held:type_name -> realchat.message.v1.HeldMessage
+	70, // 5: realchat.message.v1.EditMessageResponse.message:type_name -> realchat.message.v1.Message
+	70, // 6: realchat.message.v1.SyncMessagesResponse.messages:type_name -> realchat.message.v1.Message
+	70, // 7: realchat.message.v1.ListThreadRepliesResponse.root:type_name -> realchat.message.v1.Message
+	70, // 8: realchat.message.v1.ListThreadRepliesResponse.replies:type_name -> realchat.message.v1.Message
+	73, // 9: realchat.message.v1.AddReactionResponse.reactions:type_name -> realchat.message.v1.ReactionSummary
+	73, // 10: realchat.message.v1.RemoveReactionResponse.reactions:type_name -> realchat.message.v1.ReactionSummary
+	68, // 11: realchat.message.v1.SearchMessagesRequest.sent_after:type_name -> google.protobuf.Timestamp
+	68, // 12: realchat.message.v1.SearchMessagesRequest.sent_before:type_name -> google.protobuf.Timestamp
+	70, // 13: realchat.message.v1.SearchHit.message:type_name -> realchat.message.v1.Message
+	15, // 14: realchat.message.v1.SearchMessagesResponse.hits:type_name -> realchat.message.v1.SearchHit
+	74, // 15: realchat.message.v1.PinMessageResponse.pin:type_name -> realchat.message.v1.PinnedMessage
+	74, // 16: realchat.message.v1.ListPinnedMessagesResponse.pins:type_name -> realchat.message.v1.PinnedMessage
+	71, // 17: realchat.message.v1.ListScheduledMessagesResponse.scheduled:type_name -> realchat.message.v1.ScheduledMessage
+	68, // 18: realchat.message.v1.UpdateScheduledMessageRequest.send_at:type_name -> google.protobuf.Timestamp
+	71, // 19: realchat.message.v1.UpdateScheduledMessageResponse.scheduled:type_name -> realchat.message.v1.ScheduledMessage
+	70, // 20: realchat.message.v1.ForwardMessagesResponse.messages:type_name -> realchat.message.v1.Message
+	33, // 21: realchat.message.v1.GetUnreadMentionCountsResponse.counts:type_name -> realchat.message.v1.UnreadMentionCount
+	75, // 22: realchat.message.v1.VotePollResponse.poll:type_name -> realchat.message.v1.PollState
+	75, // 23: realchat.message.v1.RetractVoteResponse.poll:type_name -> realchat.message.v1.PollState
+	75, // 24: realchat.message.v1.ClosePollResponse.poll:type_name -> realchat.message.v1.PollState
+	40, // 25: realchat.message.v1.SetRetentionPolicyResponse.policy:type_name -> realchat.message.v1.RetentionPolicy
+	40, // 26: realchat.message.v1.GetRetentionPolicyResponse.policy:type_name -> realchat.message.v1.RetentionPolicy
+	68, // 27: realchat.message.v1.ConversationExport.created_at:type_name -> google.protobuf.Timestamp
+	68, // 28: realchat.message.v1.ConversationExport.completed_at:type_name -> google.protobuf.Timestamp
+	45, // 29: realchat.message.v1.RequestExportResponse.export:type_name -> realchat.message.v1.ConversationExport
+	45, // 30: realchat.message.v1.GetExportResponse.export:type_name -> realchat.message.v1.ConversationExport
+	45, // 31: realchat.message.v1.DownloadExportResponse.export:type_name -> realchat.message.v1.ConversationExport
+	68, // 32: realchat.message.v1.MessageImport.created_at:type_name -> google.protobuf.Timestamp
+	68, // 33: realchat.message.v1.MessageImport.completed_at:type_name -> google.protobuf.Timestamp
+	67, // 34: realchat.message.v1.RequestImportRequest.mapping:type_name -> realchat.message.v1.RequestImportRequest.MappingEntry
+	52, // 35: realchat.message.v1.RequestImportResponse.import:type_name -> realchat.message.v1.MessageImport
+	52, // 36: realchat.message.v1.GetImportResponse.import:type_name -> realchat.message.v1.MessageImport
+	68, // 37: realchat.message.v1.SaveDraftRequest.updated_at:type_name -> google.protobuf.Timestamp
+	76, // 38: realchat.message.v1.SaveDraftResponse.draft:type_name -> realchat.message.v1.Draft
+	68, // 39: realchat.message.v1.ClearDraftRequest.cleared_at:type_name -> google.protobuf.Timestamp
+	76, // 40: realchat.message.v1.ClearDraftResponse.draft:type_name -> realchat.message.v1.Draft
+	76, // 41: realchat.message.v1.GetDraftsResponse.drafts:type_name -> realchat.message.v1.Draft
+	72, // 42: realchat.message.v1.ListHeldMessagesResponse.held:type_name -> realchat.message.v1.HeldMessage
+	72, // 43: realchat.message.v1.ReviewHeldMessageResponse.held:type_name -> realchat.message.v1.HeldMessage
+	70, // 44: realchat.message.v1.ReviewHeldMessageResponse.message:type_name -> realchat.message.v1.Message
+	0,  // 45: realchat.message.v1.MessageApi.SendMessage:input_type -> realchat.message.v1.SendMessageRequest
+	2,  // 46: realchat.message.v1.MessageApi.DeleteMessage:input_type -> realchat.message.v1.DeleteMessageRequest
+	6,  // 47: realchat.message.v1.MessageApi.SyncMessages:input_type -> realchat.message.v1.SyncMessagesRequest
+	4,  // 48: realchat.message.v1.MessageApi.EditMessage:input_type -> realchat.message.v1.EditMessageRequest
+	8,  // 49: realchat.message.v1.MessageApi.ListThreadReplies:input_type -> realchat.message.v1.ListThreadRepliesRequest
+	10, // 50: realchat.message.v1.MessageApi.AddReaction:input_type -> realchat.message.v1.AddReactionRequest
+	12, // 51: realchat.message.v1.MessageApi.RemoveReaction:input_type -> realchat.message.v1.RemoveReactionRequest
+	14, // 52: realchat.message.v1.MessageApi.SearchMessages:input_type -> realchat.message.v1.SearchMessagesRequest
+	17, // 53: realchat.message.v1.MessageApi.PinMessage:input_type -> realchat.message.v1.PinMessageRequest
+	19, // 54: realchat.message.v1.MessageApi.UnpinMessage:input_type -> realchat.message.v1.UnpinMessageRequest
+	21, // 55: realchat.message.v1.MessageApi.ListPinnedMessages:input_type -> realchat.message.v1.ListPinnedMessagesRequest
+	23, // 56: realchat.message.v1.MessageApi.ListScheduledMessages:input_type -> realchat.message.v1.ListScheduledMessagesRequest
+	25, // 57: realchat.message.v1.MessageApi.UpdateScheduledMessage:input_type -> realchat.message.v1.UpdateScheduledMessageRequest
+	27, // 58: realchat.message.v1.MessageApi.CancelScheduledMessage:input_type -> realchat.message.v1.CancelScheduledMessageRequest
+	29, // 59: realchat.message.v1.MessageApi.ForwardMessages:input_type -> realchat.message.v1.ForwardMessagesRequest
+	31, // 60: realchat.message.v1.MessageApi.GetUnreadMentionCounts:input_type -> realchat.message.v1.GetUnreadMentionCountsRequest
+	34, // 61: realchat.message.v1.MessageApi.VotePoll:input_type -> realchat.message.v1.VotePollRequest
+	36, // 62: realchat.message.v1.MessageApi.RetractVote:input_type -> realchat.message.v1.RetractVoteRequest
+	38, // 63: realchat.message.v1.MessageApi.ClosePoll:input_type -> realchat.message.v1.ClosePollRequest
+	41, // 64: realchat.message.v1.MessageApi.SetRetentionPolicy:input_type -> realchat.message.v1.SetRetentionPolicyRequest
+	43, // 65: realchat.message.v1.MessageApi.GetRetentionPolicy:input_type -> realchat.message.v1.GetRetentionPolicyRequest
+	46, // 66: realchat.message.v1.MessageApi.RequestExport:input_type -> realchat.message.v1.RequestExportRequest
+	48, // 67: realchat.message.v1.MessageApi.GetExport:input_type -> realchat.message.v1.GetExportRequest
+	50, // 68: realchat.message.v1.MessageApi.DownloadExport:input_type -> realchat.message.v1.DownloadExportRequest
+	53, // 69: realchat.message.v1.MessageApi.RequestImport:input_type -> realchat.message.v1.RequestImportRequest
+	55, // 70: realchat.message.v1.MessageApi.GetImport:input_type -> realchat.message.v1.GetImportRequest
+	57, // 71: realchat.message.v1.MessageApi.SaveDraft:input_type -> realchat.message.v1.SaveDraftRequest
+	59, // 72: realchat.message.v1.MessageApi.ClearDraft:input_type -> realchat.message.v1.ClearDraftRequest
+	61, // 73: realchat.message.v1.MessageApi.GetDrafts:input_type -> realchat.message.v1.GetDraftsRequest
+	63, // 74: realchat.message.v1.MessageApi.ListHeldMessages:input_type -> realchat.message.v1.ListHeldMessagesRequest
+	65, // 75: realchat.message.v1.MessageApi.ReviewHeldMessage:input_type -> realchat.message.v1.ReviewHeldMessageRequest
+	1,  // 76: realchat.message.v1.MessageApi.SendMessage:output_type -> realchat.message.v1.SendMessageResponse
+	3,  // 77: realchat.message.v1.MessageApi.DeleteMessage:output_type -> realchat.message.v1.DeleteMessageResponse
+	7,  // 78: realchat.message.v1.MessageApi.SyncMessages:output_type -> realchat.message.v1.SyncMessagesResponse
+	5,  // 79: realchat.message.v1.MessageApi.EditMessage:output_type -> realchat.message.v1.EditMessageResponse
+	9,  // 80: realchat.message.v1.MessageApi.ListThreadReplies:output_type -> realchat.message.v1.ListThreadRepliesResponse
+	11, // 81: realchat.message.v1.MessageApi.AddReaction:output_type -> realchat.message.v1.AddReactionResponse
+	13, // 82: realchat.message.v1.MessageApi.RemoveReaction:output_type -> realchat.message.v1.RemoveReactionResponse
+	16, // 83: realchat.message.v1.MessageApi.SearchMessages:output_type -> realchat.message.v1.SearchMessagesResponse
+	18, // 84: realchat.message.v1.MessageApi.PinMessage:output_type -> realchat.message.v1.PinMessageResponse
+	20, // 85: realchat.message.v1.MessageApi.UnpinMessage:output_type -> realchat.message.v1.UnpinMessageResponse
+	22, // 86: realchat.message.v1.MessageApi.ListPinnedMessages:output_type -> realchat.message.v1.ListPinnedMessagesResponse
+	24, // 87: realchat.message.v1.MessageApi.ListScheduledMessages:output_type -> realchat.message.v1.ListScheduledMessagesResponse
+	26, // 88: realchat.message.v1.MessageApi.UpdateScheduledMessage:output_type -> realchat.message.v1.UpdateScheduledMessageResponse
+	28, // 89: realchat.message.v1.MessageApi.CancelScheduledMessage:output_type -> realchat.message.v1.CancelScheduledMessageResponse
+	30, // 90: realchat.message.v1.MessageApi.ForwardMessages:output_type -> realchat.message.v1.ForwardMessagesResponse
+	32, // 91: realchat.message.v1.MessageApi.GetUnreadMentionCounts:output_type -> realchat.message.v1.GetUnreadMentionCountsResponse
+	35, // 92: realchat.message.v1.MessageApi.VotePoll:output_type -> realchat.message.v1.VotePollResponse
+	37, // 93: realchat.message.v1.MessageApi.RetractVote:output_type -> realchat.message.v1.RetractVoteResponse
+	39, // 94: realchat.message.v1.MessageApi.ClosePoll:output_type -> realchat.message.v1.ClosePollResponse
+	42, // 95: realchat.message.v1.MessageApi.SetRetentionPolicy:output_type -> realchat.message.v1.SetRetentionPolicyResponse
+	44, // 96: realchat.message.v1.MessageApi.GetRetentionPolicy:output_type -> realchat.message.v1.GetRetentionPolicyResponse
+	47, // 97: realchat.message.v1.MessageApi.RequestExport:output_type -> realchat.message.v1.RequestExportResponse
+	49, // 98: realchat.message.v1.MessageApi.GetExport:output_type -> realchat.message.v1.GetExportResponse
+	51, // 99: realchat.message.v1.MessageApi.DownloadExport:output_type -> realchat.message.v1.DownloadExportResponse
+	54, // 100: realchat.message.v1.MessageApi.RequestImport:output_type -> realchat.message.v1.RequestImportResponse
+	56, // 101: realchat.message.v1.MessageApi.GetImport:output_type -> realchat.message.v1.GetImportResponse
+	58, // 102: realchat.message.v1.MessageApi.SaveDraft:output_type -> realchat.message.v1.SaveDraftResponse
+	60, // 103: realchat.message.v1.MessageApi.ClearDraft:output_type -> realchat.message.v1.ClearDraftResponse
+	62, // 104: realchat.message.v1.MessageApi.GetDrafts:output_type -> realchat.message.v1.GetDraftsResponse
+	64, // 105: realchat.message.v1.MessageApi.ListHeldMessages:output_type -> realchat.message.v1.ListHeldMessagesResponse
+	66, // 106: realchat.message.v1.MessageApi.ReviewHeldMessage:output_type -> realchat.message.v1.ReviewHeldMessageResponse
+	76, // [76:107] is the sub-list for method output_type
+	45, // [45:76] is the sub-list for method input_type
+	45, // [45:45] is the sub-list for extension type_name
+	45, // [45:45] is the sub-list for extension extendee
+	0,  // [0:45] is the sub-list for field type_name
 }
 
 func init() { file_message_v1_message_api_proto_init() }
@@ -431,7 +4376,7 @@ func file_message_v1_message_api_proto_init() {
 			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
 			RawDescriptor: unsafe.Slice(unsafe.StringData(file_message_v1_message_api_proto_rawDesc), len(file_message_v1_message_api_proto_rawDesc)),
 			NumEnums:      0,
-			NumMessages:   6,
+			NumMessages:   68,
 			NumExtensions: 0,
 			NumServices:   1,
 		},
@@ -19,9 +19,37 @@ import (
 const _ = grpc.SupportPackageIsVersion9
 
 const (
-	MessageApi_SendMessage_FullMethodName   = "/realchat.message.v1.MessageApi/SendMessage"
-	MessageApi_DeleteMessage_FullMethodName = "/realchat.message.v1.MessageApi/DeleteMessage"
-	MessageApi_SyncMessages_FullMethodName  = "/realchat.message.v1.MessageApi/SyncMessages"
+	MessageApi_SendMessage_FullMethodName            = "/realchat.message.v1.MessageApi/SendMessage"
+	MessageApi_DeleteMessage_FullMethodName          = "/realchat.message.v1.MessageApi/DeleteMessage"
+	MessageApi_SyncMessages_FullMethodName           = "/realchat.message.v1.MessageApi/SyncMessages"
+	MessageApi_EditMessage_FullMethodName            = "/realchat.message.v1.MessageApi/EditMessage"
+	MessageApi_ListThreadReplies_FullMethodName      = "/realchat.message.v1.MessageApi/ListThreadReplies"
+	MessageApi_AddReaction_FullMethodName            = "/realchat.message.v1.MessageApi/AddReaction"
+	MessageApi_RemoveReaction_FullMethodName         = "/realchat.message.v1.MessageApi/RemoveReaction"
+	MessageApi_SearchMessages_FullMethodName         = "/realchat.message.v1.MessageApi/SearchMessages"
+	MessageApi_PinMessage_FullMethodName             = "/realchat.message.v1.MessageApi/PinMessage"
+	MessageApi_UnpinMessage_FullMethodName           = "/realchat.message.v1.MessageApi/UnpinMessage"
+	MessageApi_ListPinnedMessages_FullMethodName     = "/realchat.message.v1.MessageApi/ListPinnedMessages"
+	MessageApi_ListScheduledMessages_FullMethodName  = "/realchat.message.v1.MessageApi/ListScheduledMessages"
+	MessageApi_UpdateScheduledMessage_FullMethodName = "/realchat.message.v1.MessageApi/UpdateScheduledMessage"
+	MessageApi_CancelScheduledMessage_FullMethodName = "/realchat.message.v1.MessageApi/CancelScheduledMessage"
+	MessageApi_ForwardMessages_FullMethodName        = "/realchat.message.v1.MessageApi/ForwardMessages"
+	MessageApi_GetUnreadMentionCounts_FullMethodName = "/realchat.message.v1.MessageApi/GetUnreadMentionCounts"
+	MessageApi_VotePoll_FullMethodName               = "/realchat.message.v1.MessageApi/VotePoll"
+	MessageApi_RetractVote_FullMethodName            = "/realchat.message.v1.MessageApi/RetractVote"
+	MessageApi_ClosePoll_FullMethodName              = "/realchat.message.v1.MessageApi/ClosePoll"
+	MessageApi_SetRetentionPolicy_FullMethodName     = "/realchat.message.v1.MessageApi/SetRetentionPolicy"
+	MessageApi_GetRetentionPolicy_FullMethodName     = "/realchat.message.v1.MessageApi/GetRetentionPolicy"
+	MessageApi_RequestExport_FullMethodName          = "/realchat.message.v1.MessageApi/RequestExport"
+	MessageApi_GetExport_FullMethodName              = "/realchat.message.v1.MessageApi/GetExport"
+	MessageApi_DownloadExport_FullMethodName         = "/realchat.message.v1.MessageApi/DownloadExport"
+	MessageApi_RequestImport_FullMethodName          = "/realchat.message.v1.MessageApi/RequestImport"
+	MessageApi_GetImport_FullMethodName              = "/realchat.message.v1.MessageApi/GetImport"
+	MessageApi_SaveDraft_FullMethodName              = "/realchat.message.v1.MessageApi/SaveDraft"
+	MessageApi_ClearDraft_FullMethodName             = "/realchat.message.v1.MessageApi/ClearDraft"
+	MessageApi_GetDrafts_FullMethodName              = "/realchat.message.v1.MessageApi/GetDrafts"
+	MessageApi_ListHeldMessages_FullMethodName       = "/realchat.message.v1.MessageApi/ListHeldMessages"
+	MessageApi_ReviewHeldMessage_FullMethodName      = "/realchat.message.v1.MessageApi/ReviewHeldMessage"
 )
 
 // MessageApiClient is the client API for MessageApi service.
@@ -31,6 +59,75 @@ type MessageApiClient interface {
 	SendMessage(ctx context.Context, in *SendMessageRequest, opts ...grpc.CallOption) (*SendMessageResponse, error)
 	DeleteMessage(ctx context.Context, in *DeleteMessageRequest, opts ...grpc.CallOption) (*DeleteMessageResponse, error)
 	SyncMessages(ctx context.Context, in *SyncMessagesRequest, opts ...grpc.CallOption) (*SyncMessagesResponse, error)
+	// EditMessage replaces the content of a message sent by the caller, as long
+	// as it is still inside the edit window. The previous content is kept as a revision.
+	EditMessage(ctx context.Context, in *EditMessageRequest, opts ...grpc.CallOption) (*EditMessageResponse, error)
+	// ListThreadReplies pages the replies of a thread in sequence order.
+	ListThreadReplies(ctx context.Context, in *ListThreadRepliesRequest, opts ...grpc.CallOption) (*ListThreadRepliesResponse, error)
+	// AddReaction and RemoveReaction are idempotent per (message, user, emoji).
+	AddReaction(ctx context.Context, in *AddReactionRequest, opts ...grpc.CallOption) (*AddReactionResponse, error)
+	RemoveReaction(ctx context.Context, in *RemoveReactionRequest, opts ...grpc.CallOption) (*RemoveReactionResponse, error)
+	// SearchMessages runs a full-text query over the caller's conversations,
+	// newest matches first.
+	SearchMessages(ctx context.Context, in *SearchMessagesRequest, opts ...grpc.CallOption) (*SearchMessagesResponse, error)
+	// PinMessage and UnpinMessage are idempotent. In groups only admins may
+	// change pins; in direct conversations either participant may.
+	PinMessage(ctx context.Context, in *PinMessageRequest, opts ...grpc.CallOption) (*PinMessageResponse, error)
+	UnpinMessage(ctx context.Context, in *UnpinMessageRequest, opts ...grpc.CallOption) (*UnpinMessageResponse, error)
+	// ListPinnedMessages returns the pins of a conversation, newest first.
+	ListPinnedMessages(ctx context.Context, in *ListPinnedMessagesRequest, opts ...grpc.CallOption) (*ListPinnedMessagesResponse, error)
+	// ListScheduledMessages returns the caller's pending scheduled messages,
+	// soonest first. Scheduling itself is SendMessage with a future send_at.
+	ListScheduledMessages(ctx context.Context, in *ListScheduledMessagesRequest, opts ...grpc.CallOption) (*ListScheduledMessagesResponse, error)
+	// UpdateScheduledMessage and CancelScheduledMessage only apply while the
+	// scheduled message is still pending.
+	UpdateScheduledMessage(ctx context.Context, in *UpdateScheduledMessageRequest, opts ...grpc.CallOption) (*UpdateScheduledMessageResponse, error)
+	CancelScheduledMessage(ctx context.Context, in *CancelScheduledMessageRequest, opts ...grpc.CallOption) (*CancelScheduledMessageResponse, error)
+	// ForwardMessages copies messages from one conversation into another. All
+	// copies are sent together or not at all; retries with the same
+	// idempotency_key return the original copies.
+	ForwardMessages(ctx context.Context, in *ForwardMessagesRequest, opts ...grpc.CallOption) (*ForwardMessagesResponse, error)
+	// GetUnreadMentionCounts returns, per conversation, how many messages after
+	// the caller's last read sequence mention them. Conversations without
+	// unread mentions are omitted.
+	GetUnreadMentionCounts(ctx context.Context, in *GetUnreadMentionCountsRequest, opts ...grpc.CallOption) (*GetUnreadMentionCountsResponse, error)
+	// VotePoll replaces the caller's votes on a poll; RetractVote clears them.
+	// Polls are created with SendMessage and message_type "poll".
+	VotePoll(ctx context.Context, in *VotePollRequest, opts ...grpc.CallOption) (*VotePollResponse, error)
+	RetractVote(ctx context.Context, in *RetractVoteRequest, opts ...grpc.CallOption) (*RetractVoteResponse, error)
+	// ClosePoll stops voting. Only the poll's creator or a conversation admin
+	// may close it; closing a closed poll is a no-op.
+	ClosePoll(ctx context.Context, in *ClosePollRequest, opts ...grpc.CallOption) (*ClosePollResponse, error)
+	// SetRetentionPolicy sets how long a conversation's messages are kept
+	// before they are archived, with the same permissions as pinning.
+	// GetRetentionPolicy returns the retention in effect.
+	SetRetentionPolicy(ctx context.Context, in *SetRetentionPolicyRequest, opts ...grpc.CallOption) (*SetRetentionPolicyResponse, error)
+	GetRetentionPolicy(ctx context.Context, in *GetRetentionPolicyRequest, opts ...grpc.CallOption) (*GetRetentionPolicyResponse, error)
+	// RequestExport queues an export of a conversation's history for a
+	// participant, or returns the one already queued. GetExport reports its
+	// progress. DownloadExport streams the finished zip: the first message
+	// carries the export, the rest carry data.
+	RequestExport(ctx context.Context, in *RequestExportRequest, opts ...grpc.CallOption) (*RequestExportResponse, error)
+	GetExport(ctx context.Context, in *GetExportRequest, opts ...grpc.CallOption) (*GetExportResponse, error)
+	DownloadExport(ctx context.Context, in *DownloadExportRequest, opts ...grpc.CallOption) (grpc.ServerStreamingClient[DownloadExportResponse], error)
+	// RequestImport queues an import of a Slack or WhatsApp export, uploaded
+	// through the media service, into a new conversation. The same request
+	// returns the existing import and resumes it if it failed. Restricted to
+	// the service's import admins. GetImport reports its progress.
+	RequestImport(ctx context.Context, in *RequestImportRequest, opts ...grpc.CallOption) (*RequestImportResponse, error)
+	GetImport(ctx context.Context, in *GetImportRequest, opts ...grpc.CallOption) (*GetImportResponse, error)
+	// SaveDraft stores the caller's draft for a conversation unless a later
+	// one is already stored, and returns the draft in effect. ClearDraft
+	// clears it the same way. Sending a message clears the draft too.
+	// GetDrafts returns the caller's drafts that are not empty.
+	SaveDraft(ctx context.Context, in *SaveDraftRequest, opts ...grpc.CallOption) (*SaveDraftResponse, error)
+	ClearDraft(ctx context.Context, in *ClearDraftRequest, opts ...grpc.CallOption) (*ClearDraftResponse, error)
+	GetDrafts(ctx context.Context, in *GetDraftsRequest, opts ...grpc.CallOption) (*GetDraftsResponse, error)
+	// ListHeldMessages pages the moderation queue, oldest first.
+	// ReviewHeldMessage approves a held message, sending it, or rejects it.
+	// Both are restricted to the service's moderators.
+	ListHeldMessages(ctx context.Context, in *ListHeldMessagesRequest, opts ...grpc.CallOption) (*ListHeldMessagesResponse, error)
+	ReviewHeldMessage(ctx context.Context, in *ReviewHeldMessageRequest, opts ...grpc.CallOption) (*ReviewHeldMessageResponse, error)
 }
 
 type messageApiClient struct {
@@ -71,6 +168,295 @@ func (c *messageApiClient) SyncMessages(ctx context.Context, in *SyncMessagesReq
 	return out, nil
 }
 
+func (c *messageApiClient) EditMessage(ctx context.Context, in *EditMessageRequest, opts ...grpc.CallOption) (*EditMessageResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(EditMessageResponse)
+	err := c.cc.Invoke(ctx, MessageApi_EditMessage_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *messageApiClient) ListThreadReplies(ctx context.Context, in *ListThreadRepliesRequest, opts ...grpc.CallOption) (*ListThreadRepliesResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ListThreadRepliesResponse)
+	err := c.cc.Invoke(ctx, MessageApi_ListThreadReplies_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *messageApiClient) AddReaction(ctx context.Context, in *AddReactionRequest, opts ...grpc.CallOption) (*AddReactionResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(AddReactionResponse)
+	err := c.cc.Invoke(ctx, MessageApi_AddReaction_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *messageApiClient) RemoveReaction(ctx context.Context, in *RemoveReactionRequest, opts ...grpc.CallOption) (*RemoveReactionResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(RemoveReactionResponse)
+	err := c.cc.Invoke(ctx, MessageApi_RemoveReaction_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *messageApiClient) SearchMessages(ctx context.Context, in *SearchMessagesRequest, opts ...grpc.CallOption) (*SearchMessagesResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(SearchMessagesResponse)
+	err := c.cc.Invoke(ctx, MessageApi_SearchMessages_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *messageApiClient) PinMessage(ctx context.Context, in *PinMessageRequest, opts ...grpc.CallOption) (*PinMessageResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(PinMessageResponse)
+	err := c.cc.Invoke(ctx, MessageApi_PinMessage_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *messageApiClient) UnpinMessage(ctx context.Context, in *UnpinMessageRequest, opts ...grpc.CallOption) (*UnpinMessageResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(UnpinMessageResponse)
+	err := c.cc.Invoke(ctx, MessageApi_UnpinMessage_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *messageApiClient) ListPinnedMessages(ctx context.Context, in *ListPinnedMessagesRequest, opts ...grpc.CallOption) (*ListPinnedMessagesResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ListPinnedMessagesResponse)
+	err := c.cc.Invoke(ctx, MessageApi_ListPinnedMessages_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *messageApiClient) ListScheduledMessages(ctx context.Context, in *ListScheduledMessagesRequest, opts ...grpc.CallOption) (*ListScheduledMessagesResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ListScheduledMessagesResponse)
+	err := c.cc.Invoke(ctx, MessageApi_ListScheduledMessages_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *messageApiClient) UpdateScheduledMessage(ctx context.Context, in *UpdateScheduledMessageRequest, opts ...grpc.CallOption) (*UpdateScheduledMessageResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(UpdateScheduledMessageResponse)
+	err := c.cc.Invoke(ctx, MessageApi_UpdateScheduledMessage_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *messageApiClient) CancelScheduledMessage(ctx context.Context, in *CancelScheduledMessageRequest, opts ...grpc.CallOption) (*CancelScheduledMessageResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(CancelScheduledMessageResponse)
+	err := c.cc.Invoke(ctx, MessageApi_CancelScheduledMessage_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *messageApiClient) ForwardMessages(ctx context.Context, in *ForwardMessagesRequest, opts ...grpc.CallOption) (*ForwardMessagesResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ForwardMessagesResponse)
+	err := c.cc.Invoke(ctx, MessageApi_ForwardMessages_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *messageApiClient) GetUnreadMentionCounts(ctx context.Context, in *GetUnreadMentionCountsRequest, opts ...grpc.CallOption) (*GetUnreadMentionCountsResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(GetUnreadMentionCountsResponse)
+	err := c.cc.Invoke(ctx, MessageApi_GetUnreadMentionCounts_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *messageApiClient) VotePoll(ctx context.Context, in *VotePollRequest, opts ...grpc.CallOption) (*VotePollResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(VotePollResponse)
+	err := c.cc.Invoke(ctx, MessageApi_VotePoll_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *messageApiClient) RetractVote(ctx context.Context, in *RetractVoteRequest, opts ...grpc.CallOption) (*RetractVoteResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(RetractVoteResponse)
+	err := c.cc.Invoke(ctx, MessageApi_RetractVote_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *messageApiClient) ClosePoll(ctx context.Context, in *ClosePollRequest, opts ...grpc.CallOption) (*ClosePollResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ClosePollResponse)
+	err := c.cc.Invoke(ctx, MessageApi_ClosePoll_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *messageApiClient) SetRetentionPolicy(ctx context.Context, in *SetRetentionPolicyRequest, opts ...grpc.CallOption) (*SetRetentionPolicyResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(SetRetentionPolicyResponse)
+	err := c.cc.Invoke(ctx, MessageApi_SetRetentionPolicy_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *messageApiClient) GetRetentionPolicy(ctx context.Context, in *GetRetentionPolicyRequest, opts ...grpc.CallOption) (*GetRetentionPolicyResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(GetRetentionPolicyResponse)
+	err := c.cc.Invoke(ctx, MessageApi_GetRetentionPolicy_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *messageApiClient) RequestExport(ctx context.Context, in *RequestExportRequest, opts ...grpc.CallOption) (*RequestExportResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(RequestExportResponse)
+	err := c.cc.Invoke(ctx, MessageApi_RequestExport_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *messageApiClient) GetExport(ctx context.Context, in *GetExportRequest, opts ...grpc.CallOption) (*GetExportResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(GetExportResponse)
+	err := c.cc.Invoke(ctx, MessageApi_GetExport_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *messageApiClient) DownloadExport(ctx context.Context, in *DownloadExportRequest, opts ...grpc.CallOption) (grpc.ServerStreamingClient[DownloadExportResponse], error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	stream, err := c.cc.NewStream(ctx, &MessageApi_ServiceDesc.Streams[0], MessageApi_DownloadExport_FullMethodName, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &grpc.GenericClientStream[DownloadExportRequest, DownloadExportResponse]{ClientStream: stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type MessageApi_DownloadExportClient = grpc.ServerStreamingClient[DownloadExportResponse]
+
+func (c *messageApiClient) RequestImport(ctx context.Context, in *RequestImportRequest, opts ...grpc.CallOption) (*RequestImportResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(RequestImportResponse)
+	err := c.cc.Invoke(ctx, MessageApi_RequestImport_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *messageApiClient) GetImport(ctx context.Context, in *GetImportRequest, opts ...grpc.CallOption) (*GetImportResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(GetImportResponse)
+	err := c.cc.Invoke(ctx, MessageApi_GetImport_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *messageApiClient) SaveDraft(ctx context.Context, in *SaveDraftRequest, opts ...grpc.CallOption) (*SaveDraftResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(SaveDraftResponse)
+	err := c.cc.Invoke(ctx, MessageApi_SaveDraft_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *messageApiClient) ClearDraft(ctx context.Context, in *ClearDraftRequest, opts ...grpc.CallOption) (*ClearDraftResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ClearDraftResponse)
+	err := c.cc.Invoke(ctx, MessageApi_ClearDraft_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *messageApiClient) GetDrafts(ctx context.Context, in *GetDraftsRequest, opts ...grpc.CallOption) (*GetDraftsResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(GetDraftsResponse)
+	err := c.cc.Invoke(ctx, MessageApi_GetDrafts_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *messageApiClient) ListHeldMessages(ctx context.Context, in *ListHeldMessagesRequest, opts ...grpc.CallOption) (*ListHeldMessagesResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ListHeldMessagesResponse)
+	err := c.cc.Invoke(ctx, MessageApi_ListHeldMessages_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *messageApiClient) ReviewHeldMessage(ctx context.Context, in *ReviewHeldMessageRequest, opts ...grpc.CallOption) (*ReviewHeldMessageResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ReviewHeldMessageResponse)
+	err := c.cc.Invoke(ctx, MessageApi_ReviewHeldMessage_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 // MessageApiServer is the server API for MessageApi service.
 // All implementations must embed UnimplementedMessageApiServer
 // for forward compatibility.
@@ -78,6 +464,75 @@ type MessageApiServer interface {
 	SendMessage(context.Context, *SendMessageRequest) (*SendMessageResponse, error)
 	DeleteMessage(context.Context, *DeleteMessageRequest) (*DeleteMessageResponse, error)
 	SyncMessages(context.Context, *SyncMessagesRequest) (*SyncMessagesResponse, error)
+	// EditMessage replaces the content of a message sent by the caller, as long
+	// as it is still inside the edit window. The previous content is kept as a revision.
+	EditMessage(context.Context, *EditMessageRequest) (*EditMessageResponse, error)
+	// ListThreadReplies pages the replies of a thread in sequence order.
+	ListThreadReplies(context.Context, *ListThreadRepliesRequest) (*ListThreadRepliesResponse, error)
+	// AddReaction and RemoveReaction are idempotent per (message, user, emoji).
+	AddReaction(context.Context, *AddReactionRequest) (*AddReactionResponse, error)
+	RemoveReaction(context.Context, *RemoveReactionRequest) (*RemoveReactionResponse, error)
+	// SearchMessages runs a full-text query over the caller's conversations,
+	// newest matches first.
+	SearchMessages(context.Context, *SearchMessagesRequest) (*SearchMessagesResponse, error)
+	// PinMessage and UnpinMessage are idempotent. In groups only admins may
+	// change pins; in direct conversations either participant may.
+	PinMessage(context.Context, *PinMessageRequest) (*PinMessageResponse, error)
+	UnpinMessage(context.Context, *UnpinMessageRequest) (*UnpinMessageResponse, error)
+	// ListPinnedMessages returns the pins of a conversation, newest first.
+	ListPinnedMessages(context.Context, *ListPinnedMessagesRequest) (*ListPinnedMessagesResponse, error)
+	// ListScheduledMessages returns the caller's pending scheduled messages,
+	// soonest first. Scheduling itself is SendMessage with a future send_at.
+	ListScheduledMessages(context.Context, *ListScheduledMessagesRequest) (*ListScheduledMessagesResponse, error)
+	// UpdateScheduledMessage and CancelScheduledMessage only apply while the
+	// scheduled message is still pending.
+	UpdateScheduledMessage(context.Context, *UpdateScheduledMessageRequest) (*UpdateScheduledMessageResponse, error)
+	CancelScheduledMessage(context.Context, *CancelScheduledMessageRequest) (*CancelScheduledMessageResponse, error)
+	// ForwardMessages copies messages from one conversation into another. All
+	// copies are sent together or not at all; retries with the same
+	// idempotency_key return the original copies.
+	ForwardMessages(context.Context, *ForwardMessagesRequest) (*ForwardMessagesResponse, error)
+	// GetUnreadMentionCounts returns, per conversation, how many messages after
+	// the caller's last read sequence mention them. Conversations without
+	// unread mentions are omitted.
+	GetUnreadMentionCounts(context.Context, *GetUnreadMentionCountsRequest) (*GetUnreadMentionCountsResponse, error)
+	// VotePoll replaces the caller's votes on a poll; RetractVote clears them.
+	// Polls are created with SendMessage and message_type "poll".
+	VotePoll(context.Context, *VotePollRequest) (*VotePollResponse, error)
+	RetractVote(context.Context, *RetractVoteRequest) (*RetractVoteResponse, error)
+	// ClosePoll stops voting. Only the poll's creator or a conversation admin
+	// may close it; closing a closed poll is a no-op.
+	ClosePoll(context.Context, *ClosePollRequest) (*ClosePollResponse, error)
+	// SetRetentionPolicy sets how long a conversation's messages are kept
+	// before they are archived, with the same permissions as pinning.
+	// GetRetentionPolicy returns the retention in effect.
+	SetRetentionPolicy(context.Context, *SetRetentionPolicyRequest) (*SetRetentionPolicyResponse, error)
+	GetRetentionPolicy(context.Context, *GetRetentionPolicyRequest) (*GetRetentionPolicyResponse, error)
+	// RequestExport queues an export of a conversation's history for a
+	// participant, or returns the one already queued. GetExport reports its
+	// progress. DownloadExport streams the finished zip: the first message
+	// carries the export, the rest carry data.
+	RequestExport(context.Context, *RequestExportRequest) (*RequestExportResponse, error)
+	GetExport(context.Context, *GetExportRequest) (*GetExportResponse, error)
+	DownloadExport(*DownloadExportRequest, grpc.ServerStreamingServer[DownloadExportResponse]) error
+	// RequestImport queues an import of a Slack or WhatsApp export, uploaded
+	// through the media service, into a new conversation. The same request
+	// returns the existing import and resumes it if it failed. Restricted to
+	// the service's import admins. GetImport reports its progress.
+	RequestImport(context.Context, *RequestImportRequest) (*RequestImportResponse, error)
+	GetImport(context.Context, *GetImportRequest) (*GetImportResponse, error)
+	// SaveDraft stores the caller's draft for a conversation unless a later
+	// one is already stored, and returns the draft in effect. ClearDraft
+	// clears it the same way. Sending a message clears the draft too.
+	// GetDrafts returns the caller's drafts that are not empty.
+	SaveDraft(context.Context, *SaveDraftRequest) (*SaveDraftResponse, error)
+	ClearDraft(context.Context, *ClearDraftRequest) (*ClearDraftResponse, error)
+	GetDrafts(context.Context, *GetDraftsRequest) (*GetDraftsResponse, error)
+	// ListHeldMessages pages the moderation queue, oldest first.
+	// ReviewHeldMessage approves a held message, sending it, or rejects it.
+	// Both are restricted to the service's moderators.
+	ListHeldMessages(context.Context, *ListHeldMessagesRequest) (*ListHeldMessagesResponse, error)
+	ReviewHeldMessage(context.Context, *ReviewHeldMessageRequest) (*ReviewHeldMessageResponse, error)
 	mustEmbedUnimplementedMessageApiServer()
 }
 
@@ -97,6 +552,90 @@ func (UnimplementedMessageApiServer) DeleteMessage(context.Context, *DeleteMessa
 func (UnimplementedMessageApiServer) SyncMessages(context.Context, *SyncMessagesRequest) (*SyncMessagesResponse, error) {
 	return nil, status.Error(codes.Unimplemented, "method SyncMessages not implemented")
 }
+func (UnimplementedMessageApiServer) EditMessage(context.Context, *EditMessageRequest) (*EditMessageResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method EditMessage not implemented")
+}
+func (UnimplementedMessageApiServer) ListThreadReplies(context.Context, *ListThreadRepliesRequest) (*ListThreadRepliesResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method ListThreadReplies not implemented")
+}
+func (UnimplementedMessageApiServer) AddReaction(context.Context, *AddReactionRequest) (*AddReactionResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method AddReaction not implemented")
+}
+func (UnimplementedMessageApiServer) RemoveReaction(context.Context, *RemoveReactionRequest) (*RemoveReactionResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method RemoveReaction not implemented")
+}
+func (UnimplementedMessageApiServer) SearchMessages(context.Context, *SearchMessagesRequest) (*SearchMessagesResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method SearchMessages not implemented")
+}
+func (UnimplementedMessageApiServer) PinMessage(context.Context, *PinMessageRequest) (*PinMessageResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method PinMessage not implemented")
+}
+func (UnimplementedMessageApiServer) UnpinMessage(context.Context, *UnpinMessageRequest) (*UnpinMessageResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method UnpinMessage not implemented")
+}
+func (UnimplementedMessageApiServer) ListPinnedMessages(context.Context, *ListPinnedMessagesRequest) (*ListPinnedMessagesResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method ListPinnedMessages not implemented")
+}
+func (UnimplementedMessageApiServer) ListScheduledMessages(context.Context, *ListScheduledMessagesRequest) (*ListScheduledMessagesResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method ListScheduledMessages not implemented")
+}
+func (UnimplementedMessageApiServer) UpdateScheduledMessage(context.Context, *UpdateScheduledMessageRequest) (*UpdateScheduledMessageResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method UpdateScheduledMessage not implemented")
+}
+func (UnimplementedMessageApiServer) CancelScheduledMessage(context.Context, *CancelScheduledMessageRequest) (*CancelScheduledMessageResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method CancelScheduledMessage not implemented")
+}
+func (UnimplementedMessageApiServer) ForwardMessages(context.Context, *ForwardMessagesRequest) (*ForwardMessagesResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method ForwardMessages not implemented")
+}
+func (UnimplementedMessageApiServer) GetUnreadMentionCounts(context.Context, *GetUnreadMentionCountsRequest) (*GetUnreadMentionCountsResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method GetUnreadMentionCounts not implemented")
+}
+func (UnimplementedMessageApiServer) VotePoll(context.Context, *VotePollRequest) (*VotePollResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method VotePoll not implemented")
+}
+func (UnimplementedMessageApiServer) RetractVote(context.Context, *RetractVoteRequest) (*RetractVoteResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method RetractVote not implemented")
+}
+func (UnimplementedMessageApiServer) ClosePoll(context.Context, *ClosePollRequest) (*ClosePollResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method ClosePoll not implemented")
+}
+func (UnimplementedMessageApiServer) SetRetentionPolicy(context.Context, *SetRetentionPolicyRequest) (*SetRetentionPolicyResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method SetRetentionPolicy not implemented")
+}
+func (UnimplementedMessageApiServer) GetRetentionPolicy(context.Context, *GetRetentionPolicyRequest) (*GetRetentionPolicyResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method GetRetentionPolicy not implemented")
+}
+func (UnimplementedMessageApiServer) RequestExport(context.Context, *RequestExportRequest) (*RequestExportResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method RequestExport not implemented")
+}
+func (UnimplementedMessageApiServer) GetExport(context.Context, *GetExportRequest) (*GetExportResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method GetExport not implemented")
+}
+func (UnimplementedMessageApiServer) DownloadExport(*DownloadExportRequest, grpc.ServerStreamingServer[DownloadExportResponse]) error {
+	return status.Error(codes.Unimplemented, "method DownloadExport not implemented")
+}
+func (UnimplementedMessageApiServer) RequestImport(context.Context, *RequestImportRequest) (*RequestImportResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method RequestImport not implemented")
+}
+func (UnimplementedMessageApiServer) GetImport(context.Context, *GetImportRequest) (*GetImportResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method GetImport not implemented")
+}
+func (UnimplementedMessageApiServer) SaveDraft(context.Context, *SaveDraftRequest) (*SaveDraftResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method SaveDraft not implemented")
+}
+func (UnimplementedMessageApiServer) ClearDraft(context.Context, *ClearDraftRequest) (*ClearDraftResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method ClearDraft not implemented")
+}
+func (UnimplementedMessageApiServer) GetDrafts(context.Context, *GetDraftsRequest) (*GetDraftsResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method GetDrafts not implemented")
+}
+func (UnimplementedMessageApiServer) ListHeldMessages(context.Context, *ListHeldMessagesRequest) (*ListHeldMessagesResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method ListHeldMessages not implemented")
+}
+func (UnimplementedMessageApiServer) ReviewHeldMessage(context.Context, *ReviewHeldMessageRequest) (*ReviewHeldMessageResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method ReviewHeldMessage not implemented")
+}
 func (UnimplementedMessageApiServer) mustEmbedUnimplementedMessageApiServer() {}
 func (UnimplementedMessageApiServer) testEmbeddedByValue()                    {}
 
@@ -172,26 +711,637 @@ func _MessageApi_SyncMessages_Handler(srv interface{}, ctx context.Context, dec
 	return interceptor(ctx, in, info, handler)
 }
 
-// MessageApi_ServiceDesc is the grpc.ServiceDesc for MessageApi service.
-// It's only intended for direct use with grpc.RegisterService,
-// and not to be introspected or modified (even as a copy)
-var MessageApi_ServiceDesc = grpc.ServiceDesc{
-	ServiceName: "realchat.message.v1.MessageApi",
-	HandlerType: (*MessageApiServer)(nil),
-	Methods: []grpc.MethodDesc{
-		{
-			MethodName: "SendMessage",
-			Handler:    _MessageApi_SendMessage_Handler,
-		},
-		{
-			MethodName: "DeleteMessage",
-			Handler:    _MessageApi_DeleteMessage_Handler,
-		},
-		{
-			MethodName: "SyncMessages",
-			Handler:    _MessageApi_SyncMessages_Handler,
+func _MessageApi_EditMessage_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(EditMessageRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(MessageApiServer).EditMessage(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: MessageApi_EditMessage_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(MessageApiServer).EditMessage(ctx, req.(*EditMessageRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _MessageApi_ListThreadReplies_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListThreadRepliesRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(MessageApiServer).ListThreadReplies(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: MessageApi_ListThreadReplies_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(MessageApiServer).ListThreadReplies(ctx, req.(*ListThreadRepliesRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _MessageApi_AddReaction_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(AddReactionRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(MessageApiServer).AddReaction(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: MessageApi_AddReaction_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(MessageApiServer).AddReaction(ctx, req.(*AddReactionRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _MessageApi_RemoveReaction_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(RemoveReactionRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(MessageApiServer).RemoveReaction(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: MessageApi_RemoveReaction_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(MessageApiServer).RemoveReaction(ctx, req.(*RemoveReactionRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _MessageApi_SearchMessages_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(SearchMessagesRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(MessageApiServer).SearchMessages(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: MessageApi_SearchMessages_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(MessageApiServer).SearchMessages(ctx, req.(*SearchMessagesRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _MessageApi_PinMessage_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(PinMessageRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(MessageApiServer).PinMessage(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: MessageApi_PinMessage_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(MessageApiServer).PinMessage(ctx, req.(*PinMessageRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _MessageApi_UnpinMessage_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(UnpinMessageRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(MessageApiServer).UnpinMessage(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: MessageApi_UnpinMessage_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(MessageApiServer).UnpinMessage(ctx, req.(*UnpinMessageRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _MessageApi_ListPinnedMessages_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListPinnedMessagesRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(MessageApiServer).ListPinnedMessages(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: MessageApi_ListPinnedMessages_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(MessageApiServer).ListPinnedMessages(ctx, req.(*ListPinnedMessagesRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _MessageApi_ListScheduledMessages_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListScheduledMessagesRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(MessageApiServer).ListScheduledMessages(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: MessageApi_ListScheduledMessages_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(MessageApiServer).ListScheduledMessages(ctx, req.(*ListScheduledMessagesRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _MessageApi_UpdateScheduledMessage_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(UpdateScheduledMessageRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(MessageApiServer).UpdateScheduledMessage(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: MessageApi_UpdateScheduledMessage_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(MessageApiServer).UpdateScheduledMessage(ctx, req.(*UpdateScheduledMessageRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _MessageApi_CancelScheduledMessage_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(CancelScheduledMessageRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(MessageApiServer).CancelScheduledMessage(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: MessageApi_CancelScheduledMessage_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(MessageApiServer).CancelScheduledMessage(ctx, req.(*CancelScheduledMessageRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _MessageApi_ForwardMessages_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ForwardMessagesRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(MessageApiServer).ForwardMessages(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: MessageApi_ForwardMessages_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(MessageApiServer).ForwardMessages(ctx, req.(*ForwardMessagesRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _MessageApi_GetUnreadMentionCounts_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetUnreadMentionCountsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(MessageApiServer).GetUnreadMentionCounts(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: MessageApi_GetUnreadMentionCounts_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(MessageApiServer).GetUnreadMentionCounts(ctx, req.(*GetUnreadMentionCountsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _MessageApi_VotePoll_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(VotePollRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(MessageApiServer).VotePoll(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: MessageApi_VotePoll_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(MessageApiServer).VotePoll(ctx, req.(*VotePollRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _MessageApi_RetractVote_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(RetractVoteRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(MessageApiServer).RetractVote(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: MessageApi_RetractVote_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(MessageApiServer).RetractVote(ctx, req.(*RetractVoteRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _MessageApi_ClosePoll_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ClosePollRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(MessageApiServer).ClosePoll(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: MessageApi_ClosePoll_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(MessageApiServer).ClosePoll(ctx, req.(*ClosePollRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _MessageApi_SetRetentionPolicy_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(SetRetentionPolicyRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(MessageApiServer).SetRetentionPolicy(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: MessageApi_SetRetentionPolicy_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(MessageApiServer).SetRetentionPolicy(ctx, req.(*SetRetentionPolicyRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _MessageApi_GetRetentionPolicy_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetRetentionPolicyRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(MessageApiServer).GetRetentionPolicy(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: MessageApi_GetRetentionPolicy_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(MessageApiServer).GetRetentionPolicy(ctx, req.(*GetRetentionPolicyRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _MessageApi_RequestExport_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(RequestExportRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(MessageApiServer).RequestExport(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: MessageApi_RequestExport_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(MessageApiServer).RequestExport(ctx, req.(*RequestExportRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _MessageApi_GetExport_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetExportRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(MessageApiServer).GetExport(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: MessageApi_GetExport_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(MessageApiServer).GetExport(ctx, req.(*GetExportRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _MessageApi_DownloadExport_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(DownloadExportRequest)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(MessageApiServer).DownloadExport(m, &grpc.GenericServerStream[DownloadExportRequest, DownloadExportResponse]{ServerStream: stream})
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type MessageApi_DownloadExportServer = grpc.ServerStreamingServer[DownloadExportResponse]
+
+func _MessageApi_RequestImport_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(RequestImportRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(MessageApiServer).RequestImport(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: MessageApi_RequestImport_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(MessageApiServer).RequestImport(ctx, req.(*RequestImportRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _MessageApi_GetImport_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetImportRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(MessageApiServer).GetImport(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: MessageApi_GetImport_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(MessageApiServer).GetImport(ctx, req.(*GetImportRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _MessageApi_SaveDraft_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(SaveDraftRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(MessageApiServer).SaveDraft(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: MessageApi_SaveDraft_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(MessageApiServer).SaveDraft(ctx, req.(*SaveDraftRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _MessageApi_ClearDraft_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ClearDraftRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(MessageApiServer).ClearDraft(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: MessageApi_ClearDraft_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(MessageApiServer).ClearDraft(ctx, req.(*ClearDraftRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _MessageApi_GetDrafts_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetDraftsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(MessageApiServer).GetDrafts(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: MessageApi_GetDrafts_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(MessageApiServer).GetDrafts(ctx, req.(*GetDraftsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _MessageApi_ListHeldMessages_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListHeldMessagesRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(MessageApiServer).ListHeldMessages(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: MessageApi_ListHeldMessages_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(MessageApiServer).ListHeldMessages(ctx, req.(*ListHeldMessagesRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _MessageApi_ReviewHeldMessage_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ReviewHeldMessageRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(MessageApiServer).ReviewHeldMessage(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: MessageApi_ReviewHeldMessage_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(MessageApiServer).ReviewHeldMessage(ctx, req.(*ReviewHeldMessageRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+// MessageApi_ServiceDesc is the grpc.ServiceDesc for MessageApi service.
+// It's only intended for direct use with grpc.RegisterService,
+// and not to be introspected or modified (even as a copy)
+var MessageApi_ServiceDesc = grpc.ServiceDesc{
+	ServiceName: "realchat.message.v1.MessageApi",
+	HandlerType: (*MessageApiServer)(nil),
+	Methods: []grpc.MethodDesc{
+		{
+			MethodName: "SendMessage",
+			Handler:    _MessageApi_SendMessage_Handler,
+		},
+		{
+			MethodName: "DeleteMessage",
+			Handler:    _MessageApi_DeleteMessage_Handler,
+		},
+		{
+			MethodName: "SyncMessages",
+			Handler:    _MessageApi_SyncMessages_Handler,
+		},
+		{
+			MethodName: "EditMessage",
+			Handler:    _MessageApi_EditMessage_Handler,
+		},
+		{
+			MethodName: "ListThreadReplies",
+			Handler:    _MessageApi_ListThreadReplies_Handler,
+		},
+		{
+			MethodName: "AddReaction",
+			Handler:    _MessageApi_AddReaction_Handler,
+		},
+		{
+			MethodName: "RemoveReaction",
+			Handler:    _MessageApi_RemoveReaction_Handler,
+		},
+		{
+			MethodName: "SearchMessages",
+			Handler:    _MessageApi_SearchMessages_Handler,
+		},
+		{
+			MethodName: "PinMessage",
+			Handler:    _MessageApi_PinMessage_Handler,
+		},
+		{
+			MethodName: "UnpinMessage",
+			Handler:    _MessageApi_UnpinMessage_Handler,
+		},
+		{
+			MethodName: "ListPinnedMessages",
+			Handler:    _MessageApi_ListPinnedMessages_Handler,
+		},
+		{
+			MethodName: "ListScheduledMessages",
+			Handler:    _MessageApi_ListScheduledMessages_Handler,
+		},
+		{
+			MethodName: "UpdateScheduledMessage",
+			Handler:    _MessageApi_UpdateScheduledMessage_Handler,
+		},
+		{
+			MethodName: "CancelScheduledMessage",
+			Handler:    _MessageApi_CancelScheduledMessage_Handler,
+		},
+		{
+			MethodName: "ForwardMessages",
+			Handler:    _MessageApi_ForwardMessages_Handler,
+		},
+		{
+			MethodName: "GetUnreadMentionCounts",
+			Handler:    _MessageApi_GetUnreadMentionCounts_Handler,
+		},
+		{
+			MethodName: "VotePoll",
+			Handler:    _MessageApi_VotePoll_Handler,
+		},
+		{
+			MethodName: "RetractVote",
+			Handler:    _MessageApi_RetractVote_Handler,
+		},
+		{
+			MethodName: "ClosePoll",
+			Handler:    _MessageApi_ClosePoll_Handler,
+		},
+		{
+			MethodName: "SetRetentionPolicy",
+			Handler:    _MessageApi_SetRetentionPolicy_Handler,
+		},
+		{
+			MethodName: "GetRetentionPolicy",
+			Handler:    _MessageApi_GetRetentionPolicy_Handler,
+		},
+		{
+			MethodName: "RequestExport",
+			Handler:    _MessageApi_RequestExport_Handler,
+		},
+		{
+			MethodName: "GetExport",
+			Handler:    _MessageApi_GetExport_Handler,
+		},
+		{
+			MethodName: "RequestImport",
+			Handler:    _MessageApi_RequestImport_Handler,
+		},
+		{
+			MethodName: "GetImport",
+			Handler:    _MessageApi_GetImport_Handler,
+		},
+		{
+			MethodName: "SaveDraft",
+			Handler:    _MessageApi_SaveDraft_Handler,
+		},
+		{
+			MethodName: "ClearDraft",
+			Handler:    _MessageApi_ClearDraft_Handler,
+		},
+		{
+			MethodName: "GetDrafts",
+			Handler:    _MessageApi_GetDrafts_Handler,
+		},
+		{
+			MethodName: "ListHeldMessages",
+			Handler:    _MessageApi_ListHeldMessages_Handler,
+		},
+		{
+			MethodName: "ReviewHeldMessage",
+			Handler:    _MessageApi_ReviewHeldMessage_Handler,
+		},
+	},
+	Streams: []grpc.StreamDesc{
+		{
+			StreamName:    "DownloadExport",
+			Handler:       _MessageApi_DownloadExport_Handler,
+			ServerStreams: true,
 		},
 	},
-	Streams:  []grpc.StreamDesc{},
 	Metadata: "message/v1/message_api.proto",
 }
@@ -29,10 +29,17 @@ const (
 	// Conversation events
 	EventType_EVENT_TYPE_CONVERSATION_CREATED EventType = 1
 	EventType_EVENT_TYPE_MEMBERSHIP_CHANGED   EventType = 2
+	EventType_EVENT_TYPE_CONVERSATION_UPDATED EventType = 3
 	// Message events
-	EventType_EVENT_TYPE_MESSAGE_SENT         EventType = 10
-	EventType_EVENT_TYPE_MESSAGE_DELETED      EventType = 11
-	EventType_EVENT_TYPE_READ_RECEIPT_UPDATED EventType = 12
+	EventType_EVENT_TYPE_MESSAGE_SENT             EventType = 10
+	EventType_EVENT_TYPE_MESSAGE_DELETED          EventType = 11
+	EventType_EVENT_TYPE_READ_RECEIPT_UPDATED     EventType = 12
+	EventType_EVENT_TYPE_MESSAGE_EDITED           EventType = 13
+	EventType_EVENT_TYPE_REACTION_CHANGED         EventType = 14
+	EventType_EVENT_TYPE_MESSAGE_PIN_CHANGED      EventType = 15
+	EventType_EVENT_TYPE_POLL_TALLY_CHANGED       EventType = 16
+	EventType_EVENT_TYPE_DELIVERY_RECEIPT_UPDATED EventType = 17
+	EventType_EVENT_TYPE_DRAFT_UPDATED            EventType = 18
 	// Presence events
 	EventType_EVENT_TYPE_PRESENCE_UPDATED EventType = 20
 )
@@ -43,19 +50,33 @@ var (
 		0:  "EVENT_TYPE_UNSPECIFIED",
 		1:  "EVENT_TYPE_CONVERSATION_CREATED",
 		2:  "EVENT_TYPE_MEMBERSHIP_CHANGED",
+		3:  "EVENT_TYPE_CONVERSATION_UPDATED",
 		10: "EVENT_TYPE_MESSAGE_SENT",
 		11: "EVENT_TYPE_MESSAGE_DELETED",
 		12: "EVENT_TYPE_READ_RECEIPT_UPDATED",
+		13: "EVENT_TYPE_MESSAGE_EDITED",
+		14: "EVENT_TYPE_REACTION_CHANGED",
+		15: "EVENT_TYPE_MESSAGE_PIN_CHANGED",
+		16: "EVENT_TYPE_POLL_TALLY_CHANGED",
+		17: "EVENT_TYPE_DELIVERY_RECEIPT_UPDATED",
+		18: "EVENT_TYPE_DRAFT_UPDATED",
 		20: "EVENT_TYPE_PRESENCE_UPDATED",
 	}
 	EventType_value = map[string]int32{
-		"EVENT_TYPE_UNSPECIFIED":          0,
-		"EVENT_TYPE_CONVERSATION_CREATED": 1,
-		"EVENT_TYPE_MEMBERSHIP_CHANGED":   2,
-		"EVENT_TYPE_MESSAGE_SENT":         10,
-		"EVENT_TYPE_MESSAGE_DELETED":      11,
-		"EVENT_TYPE_READ_RECEIPT_UPDATED": 12,
-		"EVENT_TYPE_PRESENCE_UPDATED":     20,
+		"EVENT_TYPE_UNSPECIFIED":              0,
+		"EVENT_TYPE_CONVERSATION_CREATED":     1,
+		"EVENT_TYPE_MEMBERSHIP_CHANGED":       2,
+		"EVENT_TYPE_CONVERSATION_UPDATED":     3,
+		"EVENT_TYPE_MESSAGE_SENT":             10,
+		"EVENT_TYPE_MESSAGE_DELETED":          11,
+		"EVENT_TYPE_READ_RECEIPT_UPDATED":     12,
+		"EVENT_TYPE_MESSAGE_EDITED":           13,
+		"EVENT_TYPE_REACTION_CHANGED":         14,
+		"EVENT_TYPE_MESSAGE_PIN_CHANGED":      15,
+		"EVENT_TYPE_POLL_TALLY_CHANGED":       16,
+		"EVENT_TYPE_DELIVERY_RECEIPT_UPDATED": 17,
+		"EVENT_TYPE_DRAFT_UPDATED":            18,
+		"EVENT_TYPE_PRESENCE_UPDATED":         20,
 	}
 )
 
@@ -92,6 +113,9 @@ type EventEnvelope struct {
 	SchemaVersion int32                  `protobuf:"varint,2,opt,name=schema_version,json=schemaVersion,proto3" json:"schema_version,omitempty"`
 	OccurredAt    *timestamppb.Timestamp `protobuf:"bytes,3,opt,name=occurred_at,json=occurredAt,proto3" json:"occurred_at,omitempty"`
 	Payload       []byte                 `protobuf:"bytes,4,opt,name=payload,proto3" json:"payload,omitempty"`
+	// Unique per event and stable across redeliveries, so consumers can
+	// deduplicate. Empty on events written before it was introduced.
+	EventId       string `protobuf:"bytes,5,opt,name=event_id,json=eventId,proto3" json:"event_id,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
@@ -154,26 +178,41 @@ func (x *EventEnvelope) GetPayload() []byte {
 	return nil
 }
 
+func (x *EventEnvelope) GetEventId() string {
+	if x != nil {
+		return x.EventId
+	}
+	return ""
+}
+
 var File_shared_v1_event_proto protoreflect.FileDescriptor
 
 const file_shared_v1_event_proto_rawDesc = "" +
 	"\n" +
-	"\x15shared/v1/event.proto\x12\x12realchat.shared.v1\x1a\x1fgoogle/protobuf/timestamp.proto\"\xcb\x01\n" +
+	"\x15shared/v1/event.proto\x12\x12realchat.shared.v1\x1a\x1fgoogle/protobuf/timestamp.proto\"\xe6\x01\n" +
 	"\rEventEnvelope\x12<\n" +
 	"\n" +
 	"event_type\x18\x01 \x01(\x0e2\x1d.realchat.shared.v1.EventTypeR\teventType\x12%\n" +
 	"\x0eschema_version\x18\x02 \x01(\x05R\rschemaVersion\x12;\n" +
 	"\voccurred_at\x18\x03 \x01(\v2\x1a.google.protobuf.TimestampR\n" +
 	"occurredAt\x12\x18\n" +
-	"\apayload\x18\x04 \x01(\fR\apayload*\xf2\x01\n" +
+	"\apayload\x18\x04 \x01(\fR\apayload\x12\x19\n" +
+	"\bevent_id\x18\x05 \x01(\tR\aeventId*\xe5\x03\n" +
 	"\tEventType\x12\x1a\n" +
 	"\x16EVENT_TYPE_UNSPECIFIED\x10\x00\x12#\n" +
 	"\x1fEVENT_TYPE_CONVERSATION_CREATED\x10\x01\x12!\n" +
-	"\x1dEVENT_TYPE_MEMBERSHIP_CHANGED\x10\x02\x12\x1b\n" +
+	"\x1dEVENT_TYPE_MEMBERSHIP_CHANGED\x10\x02\x12#\n" +
+	"\x1fEVENT_TYPE_CONVERSATION_UPDATED\x10\x03\x12\x1b\n" +
 	"\x17EVENT_TYPE_MESSAGE_SENT\x10\n" +
 	"\x12\x1e\n" +
 	"\x1aEVENT_TYPE_MESSAGE_DELETED\x10\v\x12#\n" +
-	"\x1fEVENT_TYPE_READ_RECEIPT_UPDATED\x10\f\x12\x1f\n" +
+	"\x1fEVENT_TYPE_READ_RECEIPT_UPDATED\x10\f\x12\x1d\n" +
+	"\x19EVENT_TYPE_MESSAGE_EDITED\x10\r\x12\x1f\n" +
+	"\x1bEVENT_TYPE_REACTION_CHANGED\x10\x0e\x12\"\n" +
+	"\x1eEVENT_TYPE_MESSAGE_PIN_CHANGED\x10\x0f\x12!\n" +
+	"\x1dEVENT_TYPE_POLL_TALLY_CHANGED\x10\x10\x12'\n" +
+	"#EVENT_TYPE_DELIVERY_RECEIPT_UPDATED\x10\x11\x12\x1c\n" +
+	"\x18EVENT_TYPE_DRAFT_UPDATED\x10\x12\x12\x1f\n" +
 	"\x1bEVENT_TYPE_PRESENCE_UPDATED\x10\x14BLZJgithub.com/SARVESHVARADKAR123/RealChat/contracts/gen/go/shared/v1;sharedv1b\x06proto3"
 
 var (
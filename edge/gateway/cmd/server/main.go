@@ -48,12 +48,18 @@ func main() {
 	msgConn := mustDial(cfg.MessagingGRPCAddr)
 	convConn := mustDial(cfg.ConversationGRPCAddr)
 	presenceConn := mustDial(cfg.PresenceGRPCAddr)
+	mediaConn := mustDial(cfg.MediaGRPCAddr)
+	keysConn := mustDial(cfg.KeysGRPCAddr)
+	webhookConn := mustDial(cfg.WebhookGRPCAddr)
 
 	defer authConn.Close()
 	defer profileConn.Close()
 	defer msgConn.Close()
 	defer convConn.Close()
 	defer presenceConn.Close()
+	defer mediaConn.Close()
+	defer keysConn.Close()
+	defer webhookConn.Close()
 
 	// HTTP Server for Observability (Metrics & Health)
 	obsMux := http.NewServeMux()
@@ -69,7 +75,7 @@ func main() {
 		}
 	}()
 
-	factory := clients.NewFactory(authConn, profileConn, convConn, msgConn, presenceConn)
+	factory := clients.NewFactory(authConn, profileConn, convConn, msgConn, presenceConn, mediaConn, keysConn, webhookConn)
 
 	authH := handlers.NewAuthHandler(factory.Auth)
 	profileH := handlers.NewProfileHandler(factory.Profile)
@@ -77,8 +83,11 @@ func main() {
 
 	msgH := handlers.NewMessageHandler(factory.Message)
 	presenceH := handlers.NewPresenceHandler(factory.Presence)
+	mediaH := handlers.NewMediaHandler(factory.Media, cfg.MediaURLSigningKey, cfg.MediaMaxUploadBytes)
+	keysH := handlers.NewKeysHandler(factory.Keys)
+	webhookH := handlers.NewWebhookHandler(factory.Webhook)
 
-	r := router.NewRouter(authH, profileH, convH, msgH, presenceH, cfg)
+	r := router.NewRouter(authH, profileH, convH, msgH, presenceH, mediaH, keysH, webhookH, cfg)
 
 	srv := &http.Server{
 		Addr:              ":" + cfg.Port,
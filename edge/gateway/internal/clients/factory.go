@@ -3,9 +3,12 @@ package clients
 import (
 	authv1 "github.com/SARVESHVARADKAR123/RealChat/contracts/gen/go/auth/v1"
 	conversationv1 "github.com/SARVESHVARADKAR123/RealChat/contracts/gen/go/conversation/v1"
+	keysv1 "github.com/SARVESHVARADKAR123/RealChat/contracts/gen/go/keys/v1"
+	mediav1 "github.com/SARVESHVARADKAR123/RealChat/contracts/gen/go/media/v1"
 	messagev1 "github.com/SARVESHVARADKAR123/RealChat/contracts/gen/go/message/v1"
 	presencev1 "github.com/SARVESHVARADKAR123/RealChat/contracts/gen/go/presence/v1"
 	profilev1 "github.com/SARVESHVARADKAR123/RealChat/contracts/gen/go/profile/v1"
+	webhookv1 "github.com/SARVESHVARADKAR123/RealChat/contracts/gen/go/webhook/v1"
 	"google.golang.org/grpc"
 )
 
@@ -15,14 +18,20 @@ type Factory struct {
 	Conversation conversationv1.ConversationApiClient
 	Message      messagev1.MessageApiClient
 	Presence     presencev1.PresenceApiClient
+	Media        mediav1.MediaApiClient
+	Keys         keysv1.KeyApiClient
+	Webhook      webhookv1.WebhookApiClient
 }
 
-func NewFactory(a, p, c, m, pr *grpc.ClientConn) *Factory {
+func NewFactory(a, p, c, m, pr, md, k, wh *grpc.ClientConn) *Factory {
 	return &Factory{
 		Auth:         authv1.NewAuthApiClient(a),
 		Profile:      profilev1.NewProfileApiClient(p),
 		Conversation: conversationv1.NewConversationApiClient(c),
 		Message:      messagev1.NewMessageApiClient(m),
 		Presence:     presencev1.NewPresenceApiClient(pr),
+		Media:        mediav1.NewMediaApiClient(md),
+		Keys:         keysv1.NewKeyApiClient(k),
+		Webhook:      webhookv1.NewWebhookApiClient(wh),
 	}
 }
@@ -15,6 +15,9 @@ type Config struct {
 	MessagingGRPCAddr    string
 	ConversationGRPCAddr string
 	PresenceGRPCAddr     string
+	MediaGRPCAddr        string
+	KeysGRPCAddr         string
+	WebhookGRPCAddr      string
 	ServiceName          string
 	JWTIssuer            string
 	JWTAudience          string
@@ -26,6 +29,10 @@ type Config struct {
 	// Rate Limiting
 	RateLimitRequests int
 	RateLimitWindow   string
+
+	// Media: the signing key is shared with the media service
+	MediaURLSigningKey  string
+	MediaMaxUploadBytes int64
 }
 
 func Load() *Config {
@@ -37,6 +44,9 @@ func Load() *Config {
 		MessagingGRPCAddr:    mustEnv("MSG_GRPC_ADDR"),
 		ConversationGRPCAddr: mustEnv("CONV_GRPC_ADDR"),
 		PresenceGRPCAddr:     mustEnv("PRESENCE_GRPC_ADDR"),
+		MediaGRPCAddr:        mustEnv("MEDIA_GRPC_ADDR"),
+		KeysGRPCAddr:         mustEnv("KEYS_GRPC_ADDR"),
+		WebhookGRPCAddr:      mustEnv("WEBHOOK_GRPC_ADDR"),
 		ServiceName:          mustEnv("SERVICE_NAME"),
 		JWTIssuer:            getEnv("JWT_ISSUER", "realchat-auth"), // Keep sensible defaults for internal constants
 		JWTAudience:          getEnv("JWT_AUDIENCE", "realchat-clients"),
@@ -46,6 +56,8 @@ func Load() *Config {
 		ObsHTTPAddr:          fixPort(mustEnv("HTTP_ADDR")),
 		RateLimitRequests:    getEnvInt("RATE_LIMIT_REQUESTS", 100),
 		RateLimitWindow:      getEnv("RATE_LIMIT_WINDOW", "1m"),
+		MediaURLSigningKey:   mustEnv("MEDIA_URL_SIGNING_KEY"),
+		MediaMaxUploadBytes:  int64(getEnvInt("MEDIA_MAX_UPLOAD_BYTES", 25<<20)),
 	}
 }
 
@@ -5,6 +5,7 @@ import (
 	"encoding/json"
 	"log/slog"
 	"net/http"
+	"strconv"
 	"time"
 
 	"strings"
@@ -165,6 +166,39 @@ func (h *ConversationHandler) GetConversation(w http.ResponseWriter, r *http.Req
 	transport.WriteJSON(w, http.StatusOK, resp)
 }
 
+// SetMessageTTL PUT /api/conversations/{id}/message-ttl
+func (h *ConversationHandler) SetMessageTTL(w http.ResponseWriter, r *http.Request) {
+	userID := middleware.UserID(r.Context())
+	reqID := middleware.RequestIDFromContext(r.Context())
+
+	convID := chi.URLParam(r, "id")
+
+	var req struct {
+		MessageTTLSeconds int64 `json:"message_ttl_seconds"`
+	}
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		transport.WriteError(w, http.StatusBadRequest, errInvalidBody, msgInvalidJSON)
+		return
+	}
+
+	ctx, cancel := transport.WithTimeout(r.Context(), 2*time.Second)
+	defer cancel()
+	ctx = transport.WithMeta(ctx, userID, reqID)
+
+	resp, err := h.client.SetMessageTTL(ctx, &conversationv1.SetMessageTTLRequest{
+		ConversationId:    convID,
+		ActorUserId:       userID,
+		MessageTtlSeconds: req.MessageTTLSeconds,
+	})
+	if err != nil {
+		transport.GRPCError(w, err)
+		return
+	}
+
+	h.resolveConversation(ctx, userID, resp.Conversation)
+	transport.WriteJSON(w, http.StatusOK, resp)
+}
+
 // AddParticipant POST /api/participants
 func (h *ConversationHandler) AddParticipant(w http.ResponseWriter, r *http.Request) {
 	userID := middleware.UserID(r.Context())
@@ -275,6 +309,34 @@ func (h *ConversationHandler) ReadReceipt(w http.ResponseWriter, r *http.Request
 	transport.WriteJSON(w, http.StatusOK, map[string]string{"status": "ok"})
 }
 
+// GetSeenBy GET /api/conversations/{id}/seen?sequence=N
+func (h *ConversationHandler) GetSeenBy(w http.ResponseWriter, r *http.Request) {
+	userID := middleware.UserID(r.Context())
+	reqID := middleware.RequestIDFromContext(r.Context())
+
+	convID := chi.URLParam(r, "id")
+	seq, err := strconv.ParseInt(r.URL.Query().Get("sequence"), 10, 64)
+	if err != nil || seq <= 0 {
+		transport.WriteError(w, http.StatusBadRequest, errInvalidSeq, "sequence must be a positive integer")
+		return
+	}
+
+	ctx, cancel := transport.WithTimeout(r.Context(), 2*time.Second)
+	defer cancel()
+	ctx = transport.WithMeta(ctx, userID, reqID)
+
+	resp, err := h.client.GetSeenBy(ctx, &conversationv1.GetSeenByRequest{
+		ConversationId: convID,
+		Sequence:       seq,
+	})
+	if err != nil {
+		transport.GRPCError(w, err)
+		return
+	}
+
+	transport.WriteJSON(w, http.StatusOK, resp)
+}
+
 func (h *ConversationHandler) resolveConversations(ctx context.Context, currentUserID string, convs []*conversationv1.Conversation) {
 	// 1. Identify direct conversations and the "other" user IDs
 	otherUserIDs := h.collectOtherUserIDs(currentUserID, convs)
@@ -0,0 +1,191 @@
+package handlers
+
+import (
+	"encoding/json"
+	"net/http"
+	"time"
+
+	keysv1 "github.com/SARVESHVARADKAR123/RealChat/contracts/gen/go/keys/v1"
+	"github.com/SARVESHVARADKAR123/RealChat/edge/gateway/internal/middleware"
+	"github.com/SARVESHVARADKAR123/RealChat/edge/gateway/internal/transport"
+	"github.com/go-chi/chi/v5"
+)
+
+// KeysHandler publishes and fetches device keys for end-to-end encryption.
+// Keys are base64 in JSON.
+type KeysHandler struct {
+	client keysv1.KeyApiClient
+}
+
+func NewKeysHandler(c keysv1.KeyApiClient) *KeysHandler {
+	return &KeysHandler{client: c}
+}
+
+type signedPreKeyBody struct {
+	KeyID     uint32 `json:"key_id"`
+	PublicKey []byte `json:"public_key"`
+	Signature []byte `json:"signature"`
+}
+
+func (k *signedPreKeyBody) proto() *keysv1.SignedPreKey {
+	if k == nil {
+		return nil
+	}
+	return &keysv1.SignedPreKey{KeyId: k.KeyID, PublicKey: k.PublicKey, Signature: k.Signature}
+}
+
+type preKeyBody struct {
+	KeyID     uint32 `json:"key_id"`
+	PublicKey []byte `json:"public_key"`
+}
+
+func preKeysProto(keys []preKeyBody) []*keysv1.OneTimePreKey {
+	out := make([]*keysv1.OneTimePreKey, len(keys))
+	for i, k := range keys {
+		out[i] = &keysv1.OneTimePreKey{KeyId: k.KeyID, PublicKey: k.PublicKey}
+	}
+	return out
+}
+
+// UploadKeys PUT /api/keys
+func (h *KeysHandler) UploadKeys(w http.ResponseWriter, r *http.Request) {
+	userID := middleware.UserID(r.Context())
+	reqID := middleware.RequestIDFromContext(r.Context())
+
+	var req struct {
+		DeviceID       string            `json:"device_id"`
+		IdentityKey    []byte            `json:"identity_key"`
+		SignedPreKey   *signedPreKeyBody `json:"signed_prekey"`
+		OneTimePreKeys []preKeyBody      `json:"one_time_prekeys"`
+	}
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		transport.WriteError(w, http.StatusBadRequest, errInvalidBody, msgInvalidJSON)
+		return
+	}
+	if req.DeviceID == "" || len(req.IdentityKey) == 0 || req.SignedPreKey == nil {
+		transport.WriteError(w, http.StatusBadRequest, "missing_fields", "device_id, identity_key and signed_prekey are required")
+		return
+	}
+
+	ctx, cancel := transport.WithTimeout(r.Context(), 5*time.Second)
+	defer cancel()
+	ctx = transport.WithMeta(ctx, userID, reqID)
+
+	resp, err := h.client.UploadKeys(ctx, &keysv1.UploadKeysRequest{
+		DeviceId:       req.DeviceID,
+		IdentityKey:    req.IdentityKey,
+		SignedPrekey:   req.SignedPreKey.proto(),
+		OneTimePrekeys: preKeysProto(req.OneTimePreKeys),
+	})
+	if err != nil {
+		transport.GRPCError(w, err)
+		return
+	}
+
+	transport.WriteJSON(w, http.StatusOK, resp)
+}
+
+// ReplenishPreKeys POST /api/keys/prekeys
+func (h *KeysHandler) ReplenishPreKeys(w http.ResponseWriter, r *http.Request) {
+	userID := middleware.UserID(r.Context())
+	reqID := middleware.RequestIDFromContext(r.Context())
+
+	var req struct {
+		DeviceID       string            `json:"device_id"`
+		OneTimePreKeys []preKeyBody      `json:"one_time_prekeys"`
+		SignedPreKey   *signedPreKeyBody `json:"signed_prekey"`
+	}
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		transport.WriteError(w, http.StatusBadRequest, errInvalidBody, msgInvalidJSON)
+		return
+	}
+	if req.DeviceID == "" {
+		transport.WriteError(w, http.StatusBadRequest, "missing_device_id", "device_id is required")
+		return
+	}
+
+	ctx, cancel := transport.WithTimeout(r.Context(), 5*time.Second)
+	defer cancel()
+	ctx = transport.WithMeta(ctx, userID, reqID)
+
+	resp, err := h.client.ReplenishPreKeys(ctx, &keysv1.ReplenishPreKeysRequest{
+		DeviceId:       req.DeviceID,
+		OneTimePrekeys: preKeysProto(req.OneTimePreKeys),
+		SignedPrekey:   req.SignedPreKey.proto(),
+	})
+	if err != nil {
+		transport.GRPCError(w, err)
+		return
+	}
+
+	transport.WriteJSON(w, http.StatusOK, resp)
+}
+
+// GetPreKeyCount GET /api/keys/count
+func (h *KeysHandler) GetPreKeyCount(w http.ResponseWriter, r *http.Request) {
+	userID := middleware.UserID(r.Context())
+	reqID := middleware.RequestIDFromContext(r.Context())
+
+	deviceID := r.URL.Query().Get("device_id")
+	if deviceID == "" {
+		transport.WriteError(w, http.StatusBadRequest, "missing_device_id", "device_id is required")
+		return
+	}
+
+	ctx, cancel := transport.WithTimeout(r.Context(), 2*time.Second)
+	defer cancel()
+	ctx = transport.WithMeta(ctx, userID, reqID)
+
+	resp, err := h.client.GetPreKeyCount(ctx, &keysv1.GetPreKeyCountRequest{DeviceId: deviceID})
+	if err != nil {
+		transport.GRPCError(w, err)
+		return
+	}
+
+	transport.WriteJSON(w, http.StatusOK, resp)
+}
+
+// RemoveDevice DELETE /api/keys
+func (h *KeysHandler) RemoveDevice(w http.ResponseWriter, r *http.Request) {
+	userID := middleware.UserID(r.Context())
+	reqID := middleware.RequestIDFromContext(r.Context())
+
+	deviceID := r.URL.Query().Get("device_id")
+	if deviceID == "" {
+		transport.WriteError(w, http.StatusBadRequest, "missing_device_id", "device_id is required")
+		return
+	}
+
+	ctx, cancel := transport.WithTimeout(r.Context(), 2*time.Second)
+	defer cancel()
+	ctx = transport.WithMeta(ctx, userID, reqID)
+
+	resp, err := h.client.RemoveDevice(ctx, &keysv1.RemoveDeviceRequest{DeviceId: deviceID})
+	if err != nil {
+		transport.GRPCError(w, err)
+		return
+	}
+
+	transport.WriteJSON(w, http.StatusOK, resp)
+}
+
+// GetPreKeyBundles GET /api/keys/users/{user_id}
+func (h *KeysHandler) GetPreKeyBundles(w http.ResponseWriter, r *http.Request) {
+	userID := middleware.UserID(r.Context())
+	reqID := middleware.RequestIDFromContext(r.Context())
+
+	ctx, cancel := transport.WithTimeout(r.Context(), 5*time.Second)
+	defer cancel()
+	ctx = transport.WithMeta(ctx, userID, reqID)
+
+	resp, err := h.client.GetPreKeyBundles(ctx, &keysv1.GetPreKeyBundlesRequest{
+		UserId:   chi.URLParam(r, "user_id"),
+		DeviceId: r.URL.Query().Get("device_id"),
+	})
+	if err != nil {
+		transport.GRPCError(w, err)
+		return
+	}
+
+	transport.WriteJSON(w, http.StatusOK, resp)
+}
@@ -0,0 +1,219 @@
+package handlers
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"io"
+	"log/slog"
+	"mime"
+	"net/http"
+	"strconv"
+	"time"
+
+	mediav1 "github.com/SARVESHVARADKAR123/RealChat/contracts/gen/go/media/v1"
+	"github.com/SARVESHVARADKAR123/RealChat/edge/gateway/internal/middleware"
+	"github.com/SARVESHVARADKAR123/RealChat/edge/gateway/internal/transport"
+	"github.com/go-chi/chi/v5"
+)
+
+const (
+	// uploadChunkSize keeps UploadContent messages well under the default
+	// 4 MiB gRPC limit.
+	uploadChunkSize = 64 << 10
+
+	// transferTimeout bounds a single upload or download. The server-wide
+	// read/write timeouts are extended to match for these routes.
+	transferTimeout = 5 * time.Minute
+)
+
+// MediaHandler handles uploads and signed downloads through the media service.
+type MediaHandler struct {
+	client         mediav1.MediaApiClient
+	signingKey     []byte
+	maxUploadBytes int64
+}
+
+func NewMediaHandler(c mediav1.MediaApiClient, signingKey string, maxUploadBytes int64) *MediaHandler {
+	return &MediaHandler{client: c, signingKey: []byte(signingKey), maxUploadBytes: maxUploadBytes}
+}
+
+// CreateUpload POST /api/media/uploads
+func (h *MediaHandler) CreateUpload(w http.ResponseWriter, r *http.Request) {
+	userID := middleware.UserID(r.Context())
+	reqID := middleware.RequestIDFromContext(r.Context())
+
+	var req struct {
+		FileName  string `json:"file_name"`
+		MimeType  string `json:"mime_type"`
+		SizeBytes int64  `json:"size_bytes"`
+	}
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		transport.WriteError(w, http.StatusBadRequest, "invalid_body", "invalid json")
+		return
+	}
+	if req.FileName == "" || req.MimeType == "" || req.SizeBytes <= 0 {
+		transport.WriteError(w, http.StatusBadRequest, "missing_fields", "file_name, mime_type and size_bytes are required")
+		return
+	}
+
+	ctx, cancel := transport.WithTimeout(r.Context(), 5*time.Second)
+	defer cancel()
+	ctx = transport.WithMeta(ctx, userID, reqID)
+
+	resp, err := h.client.CreateUploadSession(ctx, &mediav1.CreateUploadSessionRequest{
+		OwnerUserId: userID,
+		FileName:    req.FileName,
+		MimeType:    req.MimeType,
+		SizeBytes:   req.SizeBytes,
+	})
+	if err != nil {
+		transport.GRPCError(w, err)
+		return
+	}
+
+	transport.WriteJSON(w, http.StatusOK, resp)
+}
+
+// UploadContent PUT /api/media/uploads/{id}
+// The raw request body is the file content.
+func (h *MediaHandler) UploadContent(w http.ResponseWriter, r *http.Request) {
+	userID := middleware.UserID(r.Context())
+	reqID := middleware.RequestIDFromContext(r.Context())
+	uploadID := chi.URLParam(r, "id")
+
+	extendDeadlines(w)
+	body := http.MaxBytesReader(w, r.Body, h.maxUploadBytes)
+
+	ctx, cancel := transport.WithTimeout(r.Context(), transferTimeout)
+	defer cancel()
+	ctx = transport.WithMeta(ctx, userID, reqID)
+
+	stream, err := h.client.UploadContent(ctx)
+	if err != nil {
+		transport.GRPCError(w, err)
+		return
+	}
+
+	buf := make([]byte, uploadChunkSize)
+	first := true
+	for {
+		n, readErr := io.ReadFull(body, buf)
+		if n > 0 || first {
+			chunk := &mediav1.UploadContentRequest{Data: append([]byte(nil), buf[:n]...)}
+			if first {
+				chunk.UploadId = uploadID
+				first = false
+			}
+			if err := stream.Send(chunk); err != nil {
+				// The server closed the stream; CloseAndRecv carries the reason
+				break
+			}
+		}
+		if errors.Is(readErr, io.EOF) || errors.Is(readErr, io.ErrUnexpectedEOF) {
+			break
+		}
+		if readErr != nil {
+			var tooLarge *http.MaxBytesError
+			if errors.As(readErr, &tooLarge) {
+				transport.WriteError(w, http.StatusRequestEntityTooLarge, "too_large", "upload exceeds the size limit")
+			} else {
+				transport.WriteError(w, http.StatusBadRequest, "invalid_body", "failed to read upload")
+			}
+			return
+		}
+	}
+
+	resp, err := stream.CloseAndRecv()
+	if err != nil {
+		transport.GRPCError(w, err)
+		return
+	}
+
+	transport.WriteJSON(w, http.StatusOK, resp)
+}
+
+// Download GET /api/media/{id}?exp=...&sig=...
+// Served without a JWT: the signature issued by the media service is the
+// credential, so links work in plain <img> and <a> tags.
+func (h *MediaHandler) Download(w http.ResponseWriter, r *http.Request) {
+	attachmentID := chi.URLParam(r, "id")
+	exp, err := strconv.ParseInt(r.URL.Query().Get("exp"), 10, 64)
+	if err != nil || !h.validSignature(attachmentID, exp, r.URL.Query().Get("sig")) {
+		transport.WriteError(w, http.StatusForbidden, "forbidden", "invalid download link")
+		return
+	}
+	if time.Now().Unix() > exp {
+		transport.WriteError(w, http.StatusGone, "expired", "download link expired")
+		return
+	}
+
+	extendDeadlines(w)
+
+	ctx, cancel := transport.WithTimeout(r.Context(), transferTimeout)
+	defer cancel()
+	ctx = transport.WithRequestID(ctx, middleware.RequestIDFromContext(r.Context()))
+
+	stream, err := h.client.DownloadContent(ctx, &mediav1.DownloadContentRequest{
+		AttachmentId: attachmentID,
+	})
+	if err != nil {
+		transport.GRPCError(w, err)
+		return
+	}
+
+	head, err := stream.Recv()
+	if err != nil {
+		transport.GRPCError(w, err)
+		return
+	}
+	att := head.GetAttachment()
+
+	// Served as what the content was verified to be, never as declared
+	contentType := att.GetContentType()
+	if contentType == "" {
+		contentType = "application/octet-stream"
+	}
+
+	w.Header().Set("Content-Type", contentType)
+	w.Header().Set("Content-Length", strconv.FormatInt(att.GetSizeBytes(), 10))
+	w.Header().Set("Content-Disposition", mime.FormatMediaType("inline", map[string]string{"filename": att.GetFileName()}))
+	w.Header().Set("X-Content-Type-Options", "nosniff")
+	w.Header().Set("Cache-Control", "private, max-age="+strconv.FormatInt(max(exp-time.Now().Unix(), 0), 10))
+	w.WriteHeader(http.StatusOK)
+
+	for {
+		chunk, err := stream.Recv()
+		if errors.Is(err, io.EOF) {
+			return
+		}
+		if err != nil {
+			// Headers are already sent; all we can do is cut the response short
+			slog.Error("media download aborted", "attachment_id", attachmentID, "error", err)
+			return
+		}
+		if _, err := w.Write(chunk.GetData()); err != nil {
+			return
+		}
+	}
+}
+
+func (h *MediaHandler) validSignature(attachmentID string, exp int64, sig string) bool {
+	got, err := hex.DecodeString(sig)
+	if err != nil {
+		return false
+	}
+	mac := hmac.New(sha256.New, h.signingKey)
+	mac.Write([]byte(attachmentID + ":" + strconv.FormatInt(exp, 10)))
+	return hmac.Equal(got, mac.Sum(nil))
+}
+
+// extendDeadlines lifts the server-wide read/write timeouts for a transfer.
+func extendDeadlines(w http.ResponseWriter) {
+	rc := http.NewResponseController(w)
+	deadline := time.Now().Add(transferTimeout)
+	_ = rc.SetReadDeadline(deadline)
+	_ = rc.SetWriteDeadline(deadline)
+}
@@ -2,14 +2,22 @@ package handlers
 
 import (
 	"encoding/json"
+	"errors"
 	"fmt"
+	"io"
+	"log/slog"
+	"math"
+	"mime"
 	"net/http"
+	"strconv"
 	"time"
 
 	messagev1 "github.com/SARVESHVARADKAR123/RealChat/contracts/gen/go/message/v1"
 	"github.com/SARVESHVARADKAR123/RealChat/edge/gateway/internal/middleware"
 	"github.com/SARVESHVARADKAR123/RealChat/edge/gateway/internal/transport"
+	"github.com/go-chi/chi/v5"
 	"github.com/google/uuid"
+	"google.golang.org/protobuf/types/known/timestamppb"
 )
 
 // MessageHandler handles all routes that talk to the message service.
@@ -27,10 +35,21 @@ func (h *MessageHandler) SendMessage(w http.ResponseWriter, r *http.Request) {
 	reqID := middleware.RequestIDFromContext(r.Context())
 
 	var req struct {
-		ConversationID string `json:"conversation_id"`
-		Content        string `json:"content"`
-		IdempotencyKey string `json:"idempotency_key"`
-		Type           string `json:"type"`
+		ConversationID string   `json:"conversation_id"`
+		Content        string   `json:"content"`
+		IdempotencyKey string   `json:"idempotency_key"`
+		Type           string   `json:"type"`
+		ReplyTo        string   `json:"reply_to_message_id"`
+		MetadataJSON   string   `json:"metadata_json"`
+		AttachmentIDs  []string `json:"attachment_ids"`
+		SendAt         string   `json:"send_at"`
+		// Encrypted messages; ciphertexts are base64
+		SenderDeviceID string `json:"sender_device_id"`
+		Ciphertexts    []struct {
+			UserID     string `json:"user_id"`
+			DeviceID   string `json:"device_id"`
+			Ciphertext []byte `json:"ciphertext"`
+		} `json:"ciphertexts"`
 	}
 	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
 		transport.WriteError(w, http.StatusBadRequest, "invalid_body", "invalid json")
@@ -40,8 +59,9 @@ func (h *MessageHandler) SendMessage(w http.ResponseWriter, r *http.Request) {
 		transport.WriteError(w, http.StatusBadRequest, "missing_conv_id", "conversation_id is required")
 		return
 	}
-	if req.Content == "" {
-		transport.WriteError(w, http.StatusBadRequest, "missing_content", "content is required")
+
+	sendAt, ok := parseSendAt(w, req.SendAt)
+	if !ok {
 		return
 	}
 
@@ -53,16 +73,79 @@ func (h *MessageHandler) SendMessage(w http.ResponseWriter, r *http.Request) {
 		msgType = "text"
 	}
 
+	ciphertexts := make([]*messagev1.DeviceCiphertext, len(req.Ciphertexts))
+	for i, ct := range req.Ciphertexts {
+		ciphertexts[i] = &messagev1.DeviceCiphertext{
+			UserId:     ct.UserID,
+			DeviceId:   ct.DeviceID,
+			Ciphertext: ct.Ciphertext,
+		}
+	}
+
 	ctx, cancel := transport.WithTimeout(r.Context(), 5*time.Second)
 	defer cancel()
 	ctx = transport.WithMeta(ctx, userID, reqID)
 
 	resp, err := h.client.SendMessage(ctx, &messagev1.SendMessageRequest{
-		SenderUserId:   userID,
-		ConversationId: req.ConversationID,
-		Content:        req.Content,
-		IdempotencyKey: req.IdempotencyKey,
-		MessageType:    msgType,
+		SenderUserId:     userID,
+		ConversationId:   req.ConversationID,
+		Content:          req.Content,
+		IdempotencyKey:   req.IdempotencyKey,
+		MessageType:      msgType,
+		MetadataJson:     req.MetadataJSON,
+		ReplyToMessageId: req.ReplyTo,
+		AttachmentIds:    req.AttachmentIDs,
+		SendAt:           sendAt,
+		SenderDeviceId:   req.SenderDeviceID,
+		Ciphertexts:      ciphertexts,
+	})
+	if err != nil {
+		transport.GRPCError(w, err)
+		return
+	}
+
+	// Held for moderation review: accepted, but not sent yet
+	if resp.Held != nil {
+		transport.WriteJSON(w, http.StatusAccepted, resp)
+		return
+	}
+	transport.WriteJSON(w, http.StatusOK, resp)
+}
+
+// ForwardMessages POST /api/messages/forward
+func (h *MessageHandler) ForwardMessages(w http.ResponseWriter, r *http.Request) {
+	userID := middleware.UserID(r.Context())
+	reqID := middleware.RequestIDFromContext(r.Context())
+
+	var req struct {
+		SourceConversationID string   `json:"source_conversation_id"`
+		TargetConversationID string   `json:"target_conversation_id"`
+		MessageIDs           []string `json:"message_ids"`
+		IdempotencyKey       string   `json:"idempotency_key"`
+	}
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		transport.WriteError(w, http.StatusBadRequest, "invalid_body", "invalid json")
+		return
+	}
+	if req.SourceConversationID == "" || req.TargetConversationID == "" || len(req.MessageIDs) == 0 {
+		transport.WriteError(w, http.StatusBadRequest, "missing_params", "source_conversation_id, target_conversation_id and message_ids are required")
+		return
+	}
+
+	if req.IdempotencyKey == "" {
+		req.IdempotencyKey = uuid.NewString()
+	}
+
+	ctx, cancel := transport.WithTimeout(r.Context(), 10*time.Second)
+	defer cancel()
+	ctx = transport.WithMeta(ctx, userID, reqID)
+
+	resp, err := h.client.ForwardMessages(ctx, &messagev1.ForwardMessagesRequest{
+		SourceConversationId: req.SourceConversationID,
+		TargetConversationId: req.TargetConversationID,
+		MessageIds:           req.MessageIDs,
+		SenderUserId:         userID,
+		IdempotencyKey:       req.IdempotencyKey,
 	})
 	if err != nil {
 		transport.GRPCError(w, err)
@@ -83,6 +166,72 @@ func (h *MessageHandler) SyncMessages(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
+	// Paging: after=N pages forward, before=N (or before=latest) pages back,
+	// around=N returns a window centred on N. At most one may be given.
+	var after, before, around int64
+	var limit int32 = 50
+	cursors := 0
+	for _, p := range []struct {
+		name string
+		dst  *int64
+	}{{"after", &after}, {"before", &before}, {"around", &around}} {
+		val := r.URL.Query().Get(p.name)
+		if val == "" {
+			continue
+		}
+		cursors++
+		if p.name == "before" && val == "latest" {
+			*p.dst = math.MaxInt64
+			continue
+		}
+		if _, err := fmt.Sscanf(val, "%d", p.dst); err != nil {
+			transport.WriteError(w, http.StatusBadRequest, "invalid_"+p.name, p.name+" must be an integer")
+			return
+		}
+	}
+	if cursors > 1 {
+		transport.WriteError(w, http.StatusBadRequest, "conflicting_cursors", "only one of after, before or around may be set")
+		return
+	}
+	if val := r.URL.Query().Get("limit"); val != "" {
+		var parseLimit int32
+		if _, err := fmt.Sscanf(val, "%d", &parseLimit); err == nil && parseLimit > 0 {
+			limit = parseLimit
+		}
+	}
+
+	ctx, cancel := transport.WithTimeout(r.Context(), 5*time.Second)
+	defer cancel()
+	ctx = transport.WithMeta(ctx, userID, reqID)
+
+	resp, err := h.client.SyncMessages(ctx, &messagev1.SyncMessagesRequest{
+		ConversationId: convID,
+		AfterSequence:  after,
+		BeforeSequence: before,
+		AroundSequence: around,
+		PageSize:       limit,
+		DeviceId:       r.URL.Query().Get("device_id"),
+	})
+	if err != nil {
+		transport.GRPCError(w, err)
+		return
+	}
+
+	transport.WriteJSON(w, http.StatusOK, resp)
+}
+
+// ListThreadReplies GET /api/messages/thread
+func (h *MessageHandler) ListThreadReplies(w http.ResponseWriter, r *http.Request) {
+	userID := middleware.UserID(r.Context())
+	reqID := middleware.RequestIDFromContext(r.Context())
+
+	convID := r.URL.Query().Get("conversation_id")
+	rootID := r.URL.Query().Get("thread_root_id")
+	if convID == "" || rootID == "" {
+		transport.WriteError(w, http.StatusBadRequest, "missing_params", "conversation_id and thread_root_id query parameters are required")
+		return
+	}
+
 	var after int64
 	var limit int32 = 50
 	if val := r.URL.Query().Get("after"); val != "" {
@@ -102,8 +251,9 @@ func (h *MessageHandler) SyncMessages(w http.ResponseWriter, r *http.Request) {
 	defer cancel()
 	ctx = transport.WithMeta(ctx, userID, reqID)
 
-	resp, err := h.client.SyncMessages(ctx, &messagev1.SyncMessagesRequest{
+	resp, err := h.client.ListThreadReplies(ctx, &messagev1.ListThreadRepliesRequest{
 		ConversationId: convID,
+		ThreadRootId:   rootID,
 		AfterSequence:  after,
 		PageSize:       limit,
 	})
@@ -115,6 +265,103 @@ func (h *MessageHandler) SyncMessages(w http.ResponseWriter, r *http.Request) {
 	transport.WriteJSON(w, http.StatusOK, resp)
 }
 
+// SearchMessages GET /api/messages/search
+func (h *MessageHandler) SearchMessages(w http.ResponseWriter, r *http.Request) {
+	userID := middleware.UserID(r.Context())
+	reqID := middleware.RequestIDFromContext(r.Context())
+
+	query := r.URL.Query()
+	req := &messagev1.SearchMessagesRequest{
+		Query:          query.Get("q"),
+		ConversationId: query.Get("conversation_id"),
+		SenderUserId:   query.Get("sender_id"),
+		MessageType:    query.Get("type"),
+		PageToken:      query.Get("page_token"),
+	}
+	if req.Query == "" {
+		transport.WriteError(w, http.StatusBadRequest, "missing_query", "q query parameter is required")
+		return
+	}
+
+	for _, p := range []struct {
+		name string
+		dst  **timestamppb.Timestamp
+	}{{"from", &req.SentAfter}, {"to", &req.SentBefore}} {
+		val := query.Get(p.name)
+		if val == "" {
+			continue
+		}
+		t, err := time.Parse(time.RFC3339, val)
+		if err != nil {
+			transport.WriteError(w, http.StatusBadRequest, "invalid_"+p.name, p.name+" must be an RFC 3339 timestamp")
+			return
+		}
+		*p.dst = timestamppb.New(t)
+	}
+
+	if val := query.Get("limit"); val != "" {
+		var parseLimit int32
+		if _, err := fmt.Sscanf(val, "%d", &parseLimit); err == nil && parseLimit > 0 {
+			req.PageSize = parseLimit
+		}
+	}
+
+	ctx, cancel := transport.WithTimeout(r.Context(), 5*time.Second)
+	defer cancel()
+	ctx = transport.WithMeta(ctx, userID, reqID)
+
+	resp, err := h.client.SearchMessages(ctx, req)
+	if err != nil {
+		transport.GRPCError(w, err)
+		return
+	}
+
+	transport.WriteJSON(w, http.StatusOK, resp)
+}
+
+// EditMessage PATCH /api/messages
+func (h *MessageHandler) EditMessage(w http.ResponseWriter, r *http.Request) {
+	userID := middleware.UserID(r.Context())
+	reqID := middleware.RequestIDFromContext(r.Context())
+
+	var req struct {
+		ConversationID string `json:"conversation_id"`
+		MessageID      string `json:"message_id"`
+		Content        string `json:"content"`
+		MetadataJSON   string `json:"metadata_json"`
+	}
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		transport.WriteError(w, http.StatusBadRequest, "invalid_body", "invalid json")
+		return
+	}
+	if req.ConversationID == "" || req.MessageID == "" {
+		transport.WriteError(w, http.StatusBadRequest, "missing_params", "conversation_id and message_id are required")
+		return
+	}
+	if req.Content == "" {
+		transport.WriteError(w, http.StatusBadRequest, "missing_content", "content is required")
+		return
+	}
+
+	ctx, cancel := transport.WithTimeout(r.Context(), 5*time.Second)
+	defer cancel()
+	ctx = transport.WithMeta(ctx, userID, reqID)
+
+	resp, err := h.client.EditMessage(ctx, &messagev1.EditMessageRequest{
+		ConversationId: req.ConversationID,
+		MessageId:      req.MessageID,
+		ActorUserId:    userID,
+		Content:        req.Content,
+		MetadataJson:   req.MetadataJSON,
+	})
+	if err != nil {
+		transport.GRPCError(w, err)
+		return
+	}
+
+	transport.WriteJSON(w, http.StatusOK, resp)
+}
+
 // DeleteMessage DELETE /api/messages
 func (h *MessageHandler) DeleteMessage(w http.ResponseWriter, r *http.Request) {
 	userID := middleware.UserID(r.Context())
@@ -149,3 +396,784 @@ func (h *MessageHandler) DeleteMessage(w http.ResponseWriter, r *http.Request) {
 
 	transport.WriteJSON(w, http.StatusOK, map[string]string{"status": "ok"})
 }
+
+type reactionRequest struct {
+	ConversationID string `json:"conversation_id"`
+	MessageID      string `json:"message_id"`
+	Emoji          string `json:"emoji"`
+}
+
+func decodeReactionRequest(w http.ResponseWriter, r *http.Request) (*reactionRequest, bool) {
+	var req reactionRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		transport.WriteError(w, http.StatusBadRequest, "invalid_body", "invalid json")
+		return nil, false
+	}
+	if req.ConversationID == "" || req.MessageID == "" || req.Emoji == "" {
+		transport.WriteError(w, http.StatusBadRequest, "missing_params", "conversation_id, message_id and emoji are required")
+		return nil, false
+	}
+	return &req, true
+}
+
+// AddReaction POST /api/messages/reactions
+func (h *MessageHandler) AddReaction(w http.ResponseWriter, r *http.Request) {
+	userID := middleware.UserID(r.Context())
+	reqID := middleware.RequestIDFromContext(r.Context())
+
+	req, ok := decodeReactionRequest(w, r)
+	if !ok {
+		return
+	}
+
+	ctx, cancel := transport.WithTimeout(r.Context(), 2*time.Second)
+	defer cancel()
+	ctx = transport.WithMeta(ctx, userID, reqID)
+
+	resp, err := h.client.AddReaction(ctx, &messagev1.AddReactionRequest{
+		ConversationId: req.ConversationID,
+		MessageId:      req.MessageID,
+		ActorUserId:    userID,
+		Emoji:          req.Emoji,
+	})
+	if err != nil {
+		transport.GRPCError(w, err)
+		return
+	}
+
+	transport.WriteJSON(w, http.StatusOK, resp)
+}
+
+// RemoveReaction DELETE /api/messages/reactions
+func (h *MessageHandler) RemoveReaction(w http.ResponseWriter, r *http.Request) {
+	userID := middleware.UserID(r.Context())
+	reqID := middleware.RequestIDFromContext(r.Context())
+
+	req, ok := decodeReactionRequest(w, r)
+	if !ok {
+		return
+	}
+
+	ctx, cancel := transport.WithTimeout(r.Context(), 2*time.Second)
+	defer cancel()
+	ctx = transport.WithMeta(ctx, userID, reqID)
+
+	resp, err := h.client.RemoveReaction(ctx, &messagev1.RemoveReactionRequest{
+		ConversationId: req.ConversationID,
+		MessageId:      req.MessageID,
+		ActorUserId:    userID,
+		Emoji:          req.Emoji,
+	})
+	if err != nil {
+		transport.GRPCError(w, err)
+		return
+	}
+
+	transport.WriteJSON(w, http.StatusOK, resp)
+}
+
+type pinRequest struct {
+	ConversationID string `json:"conversation_id"`
+	MessageID      string `json:"message_id"`
+}
+
+func decodePinRequest(w http.ResponseWriter, r *http.Request) (*pinRequest, bool) {
+	var req pinRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		transport.WriteError(w, http.StatusBadRequest, "invalid_body", "invalid json")
+		return nil, false
+	}
+	if req.ConversationID == "" || req.MessageID == "" {
+		transport.WriteError(w, http.StatusBadRequest, "missing_params", "conversation_id and message_id are required")
+		return nil, false
+	}
+	return &req, true
+}
+
+// PinMessage POST /api/messages/pins
+func (h *MessageHandler) PinMessage(w http.ResponseWriter, r *http.Request) {
+	userID := middleware.UserID(r.Context())
+	reqID := middleware.RequestIDFromContext(r.Context())
+
+	req, ok := decodePinRequest(w, r)
+	if !ok {
+		return
+	}
+
+	ctx, cancel := transport.WithTimeout(r.Context(), 2*time.Second)
+	defer cancel()
+	ctx = transport.WithMeta(ctx, userID, reqID)
+
+	resp, err := h.client.PinMessage(ctx, &messagev1.PinMessageRequest{
+		ConversationId: req.ConversationID,
+		MessageId:      req.MessageID,
+		ActorUserId:    userID,
+	})
+	if err != nil {
+		transport.GRPCError(w, err)
+		return
+	}
+
+	transport.WriteJSON(w, http.StatusOK, resp)
+}
+
+// UnpinMessage DELETE /api/messages/pins
+func (h *MessageHandler) UnpinMessage(w http.ResponseWriter, r *http.Request) {
+	userID := middleware.UserID(r.Context())
+	reqID := middleware.RequestIDFromContext(r.Context())
+
+	req, ok := decodePinRequest(w, r)
+	if !ok {
+		return
+	}
+
+	ctx, cancel := transport.WithTimeout(r.Context(), 2*time.Second)
+	defer cancel()
+	ctx = transport.WithMeta(ctx, userID, reqID)
+
+	_, err := h.client.UnpinMessage(ctx, &messagev1.UnpinMessageRequest{
+		ConversationId: req.ConversationID,
+		MessageId:      req.MessageID,
+		ActorUserId:    userID,
+	})
+	if err != nil {
+		transport.GRPCError(w, err)
+		return
+	}
+
+	transport.WriteJSON(w, http.StatusOK, map[string]string{"status": "ok"})
+}
+
+// ListPinnedMessages GET /api/messages/pins?conversation_id=...
+func (h *MessageHandler) ListPinnedMessages(w http.ResponseWriter, r *http.Request) {
+	userID := middleware.UserID(r.Context())
+	reqID := middleware.RequestIDFromContext(r.Context())
+
+	convID := r.URL.Query().Get("conversation_id")
+	if convID == "" {
+		transport.WriteError(w, http.StatusBadRequest, "missing_conv_id", "conversation_id query parameter is required")
+		return
+	}
+
+	ctx, cancel := transport.WithTimeout(r.Context(), 2*time.Second)
+	defer cancel()
+	ctx = transport.WithMeta(ctx, userID, reqID)
+
+	resp, err := h.client.ListPinnedMessages(ctx, &messagev1.ListPinnedMessagesRequest{
+		ConversationId: convID,
+	})
+	if err != nil {
+		transport.GRPCError(w, err)
+		return
+	}
+
+	transport.WriteJSON(w, http.StatusOK, resp)
+}
+
+type pollRequest struct {
+	ConversationID string  `json:"conversation_id"`
+	MessageID      string  `json:"message_id"`
+	OptionIndexes  []int32 `json:"option_indexes"`
+}
+
+func decodePollRequest(w http.ResponseWriter, r *http.Request) (*pollRequest, bool) {
+	var req pollRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		transport.WriteError(w, http.StatusBadRequest, "invalid_body", "invalid json")
+		return nil, false
+	}
+	if req.ConversationID == "" || req.MessageID == "" {
+		transport.WriteError(w, http.StatusBadRequest, "missing_params", "conversation_id and message_id are required")
+		return nil, false
+	}
+	return &req, true
+}
+
+// VotePoll POST /api/messages/polls/votes
+func (h *MessageHandler) VotePoll(w http.ResponseWriter, r *http.Request) {
+	userID := middleware.UserID(r.Context())
+	reqID := middleware.RequestIDFromContext(r.Context())
+
+	req, ok := decodePollRequest(w, r)
+	if !ok {
+		return
+	}
+	if len(req.OptionIndexes) == 0 {
+		transport.WriteError(w, http.StatusBadRequest, "missing_params", "option_indexes is required")
+		return
+	}
+
+	ctx, cancel := transport.WithTimeout(r.Context(), 2*time.Second)
+	defer cancel()
+	ctx = transport.WithMeta(ctx, userID, reqID)
+
+	resp, err := h.client.VotePoll(ctx, &messagev1.VotePollRequest{
+		ConversationId: req.ConversationID,
+		MessageId:      req.MessageID,
+		ActorUserId:    userID,
+		OptionIndexes:  req.OptionIndexes,
+	})
+	if err != nil {
+		transport.GRPCError(w, err)
+		return
+	}
+
+	transport.WriteJSON(w, http.StatusOK, resp)
+}
+
+// RetractVote DELETE /api/messages/polls/votes
+func (h *MessageHandler) RetractVote(w http.ResponseWriter, r *http.Request) {
+	userID := middleware.UserID(r.Context())
+	reqID := middleware.RequestIDFromContext(r.Context())
+
+	req, ok := decodePollRequest(w, r)
+	if !ok {
+		return
+	}
+
+	ctx, cancel := transport.WithTimeout(r.Context(), 2*time.Second)
+	defer cancel()
+	ctx = transport.WithMeta(ctx, userID, reqID)
+
+	resp, err := h.client.RetractVote(ctx, &messagev1.RetractVoteRequest{
+		ConversationId: req.ConversationID,
+		MessageId:      req.MessageID,
+		ActorUserId:    userID,
+	})
+	if err != nil {
+		transport.GRPCError(w, err)
+		return
+	}
+
+	transport.WriteJSON(w, http.StatusOK, resp)
+}
+
+// ClosePoll POST /api/messages/polls/close
+func (h *MessageHandler) ClosePoll(w http.ResponseWriter, r *http.Request) {
+	userID := middleware.UserID(r.Context())
+	reqID := middleware.RequestIDFromContext(r.Context())
+
+	req, ok := decodePollRequest(w, r)
+	if !ok {
+		return
+	}
+
+	ctx, cancel := transport.WithTimeout(r.Context(), 2*time.Second)
+	defer cancel()
+	ctx = transport.WithMeta(ctx, userID, reqID)
+
+	resp, err := h.client.ClosePoll(ctx, &messagev1.ClosePollRequest{
+		ConversationId: req.ConversationID,
+		MessageId:      req.MessageID,
+		ActorUserId:    userID,
+	})
+	if err != nil {
+		transport.GRPCError(w, err)
+		return
+	}
+
+	transport.WriteJSON(w, http.StatusOK, resp)
+}
+
+// parseSendAt parses an optional RFC 3339 send_at, writing a 400 on failure.
+func parseSendAt(w http.ResponseWriter, raw string) (*timestamppb.Timestamp, bool) {
+	if raw == "" {
+		return nil, true
+	}
+	t, err := time.Parse(time.RFC3339, raw)
+	if err != nil {
+		transport.WriteError(w, http.StatusBadRequest, "invalid_send_at", "send_at must be an RFC 3339 timestamp")
+		return nil, false
+	}
+	return timestamppb.New(t), true
+}
+
+// ListScheduledMessages GET /api/messages/scheduled?conversation_id=...
+func (h *MessageHandler) ListScheduledMessages(w http.ResponseWriter, r *http.Request) {
+	userID := middleware.UserID(r.Context())
+	reqID := middleware.RequestIDFromContext(r.Context())
+
+	ctx, cancel := transport.WithTimeout(r.Context(), 2*time.Second)
+	defer cancel()
+	ctx = transport.WithMeta(ctx, userID, reqID)
+
+	resp, err := h.client.ListScheduledMessages(ctx, &messagev1.ListScheduledMessagesRequest{
+		ConversationId: r.URL.Query().Get("conversation_id"),
+	})
+	if err != nil {
+		transport.GRPCError(w, err)
+		return
+	}
+
+	transport.WriteJSON(w, http.StatusOK, resp)
+}
+
+// UpdateScheduledMessage PATCH /api/messages/scheduled
+func (h *MessageHandler) UpdateScheduledMessage(w http.ResponseWriter, r *http.Request) {
+	userID := middleware.UserID(r.Context())
+	reqID := middleware.RequestIDFromContext(r.Context())
+
+	var req struct {
+		ScheduledID string `json:"scheduled_id"`
+		Content     string `json:"content"`
+		SendAt      string `json:"send_at"`
+	}
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		transport.WriteError(w, http.StatusBadRequest, "invalid_body", "invalid json")
+		return
+	}
+	if req.ScheduledID == "" || req.Content == "" {
+		transport.WriteError(w, http.StatusBadRequest, "missing_params", "scheduled_id and content are required")
+		return
+	}
+
+	sendAt, ok := parseSendAt(w, req.SendAt)
+	if !ok {
+		return
+	}
+
+	ctx, cancel := transport.WithTimeout(r.Context(), 2*time.Second)
+	defer cancel()
+	ctx = transport.WithMeta(ctx, userID, reqID)
+
+	resp, err := h.client.UpdateScheduledMessage(ctx, &messagev1.UpdateScheduledMessageRequest{
+		ScheduledId: req.ScheduledID,
+		ActorUserId: userID,
+		Content:     req.Content,
+		SendAt:      sendAt,
+	})
+	if err != nil {
+		transport.GRPCError(w, err)
+		return
+	}
+
+	transport.WriteJSON(w, http.StatusOK, resp)
+}
+
+// CancelScheduledMessage DELETE /api/messages/scheduled
+func (h *MessageHandler) CancelScheduledMessage(w http.ResponseWriter, r *http.Request) {
+	userID := middleware.UserID(r.Context())
+	reqID := middleware.RequestIDFromContext(r.Context())
+
+	var req struct {
+		ScheduledID string `json:"scheduled_id"`
+	}
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		transport.WriteError(w, http.StatusBadRequest, "invalid_body", "invalid json")
+		return
+	}
+	if req.ScheduledID == "" {
+		transport.WriteError(w, http.StatusBadRequest, "missing_params", "scheduled_id is required")
+		return
+	}
+
+	ctx, cancel := transport.WithTimeout(r.Context(), 2*time.Second)
+	defer cancel()
+	ctx = transport.WithMeta(ctx, userID, reqID)
+
+	_, err := h.client.CancelScheduledMessage(ctx, &messagev1.CancelScheduledMessageRequest{
+		ScheduledId: req.ScheduledID,
+		ActorUserId: userID,
+	})
+	if err != nil {
+		transport.GRPCError(w, err)
+		return
+	}
+
+	transport.WriteJSON(w, http.StatusOK, map[string]string{"status": "ok"})
+}
+
+// GetUnreadMentionCounts GET /api/messages/mentions/unread
+func (h *MessageHandler) GetUnreadMentionCounts(w http.ResponseWriter, r *http.Request) {
+	userID := middleware.UserID(r.Context())
+	reqID := middleware.RequestIDFromContext(r.Context())
+
+	ctx, cancel := transport.WithTimeout(r.Context(), 2*time.Second)
+	defer cancel()
+	ctx = transport.WithMeta(ctx, userID, reqID)
+
+	resp, err := h.client.GetUnreadMentionCounts(ctx, &messagev1.GetUnreadMentionCountsRequest{})
+	if err != nil {
+		transport.GRPCError(w, err)
+		return
+	}
+
+	transport.WriteJSON(w, http.StatusOK, resp)
+}
+
+// GetRetentionPolicy GET /api/conversations/{id}/retention
+func (h *MessageHandler) GetRetentionPolicy(w http.ResponseWriter, r *http.Request) {
+	userID := middleware.UserID(r.Context())
+	reqID := middleware.RequestIDFromContext(r.Context())
+
+	ctx, cancel := transport.WithTimeout(r.Context(), 2*time.Second)
+	defer cancel()
+	ctx = transport.WithMeta(ctx, userID, reqID)
+
+	resp, err := h.client.GetRetentionPolicy(ctx, &messagev1.GetRetentionPolicyRequest{
+		ConversationId: chi.URLParam(r, "id"),
+	})
+	if err != nil {
+		transport.GRPCError(w, err)
+		return
+	}
+
+	transport.WriteJSON(w, http.StatusOK, resp)
+}
+
+// SetRetentionPolicy PUT /api/conversations/{id}/retention
+func (h *MessageHandler) SetRetentionPolicy(w http.ResponseWriter, r *http.Request) {
+	userID := middleware.UserID(r.Context())
+	reqID := middleware.RequestIDFromContext(r.Context())
+
+	var req struct {
+		RetentionSeconds int64 `json:"retention_seconds"`
+		UseDefault       bool  `json:"use_default"`
+	}
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		transport.WriteError(w, http.StatusBadRequest, errInvalidBody, msgInvalidJSON)
+		return
+	}
+
+	ctx, cancel := transport.WithTimeout(r.Context(), 2*time.Second)
+	defer cancel()
+	ctx = transport.WithMeta(ctx, userID, reqID)
+
+	resp, err := h.client.SetRetentionPolicy(ctx, &messagev1.SetRetentionPolicyRequest{
+		ConversationId:   chi.URLParam(r, "id"),
+		ActorUserId:      userID,
+		RetentionSeconds: req.RetentionSeconds,
+		UseDefault:       req.UseDefault,
+	})
+	if err != nil {
+		transport.GRPCError(w, err)
+		return
+	}
+
+	transport.WriteJSON(w, http.StatusOK, resp)
+}
+
+// RequestExport POST /api/conversations/{id}/exports
+func (h *MessageHandler) RequestExport(w http.ResponseWriter, r *http.Request) {
+	userID := middleware.UserID(r.Context())
+	reqID := middleware.RequestIDFromContext(r.Context())
+
+	ctx, cancel := transport.WithTimeout(r.Context(), 2*time.Second)
+	defer cancel()
+	ctx = transport.WithMeta(ctx, userID, reqID)
+
+	resp, err := h.client.RequestExport(ctx, &messagev1.RequestExportRequest{
+		ConversationId: chi.URLParam(r, "id"),
+		UserId:         userID,
+	})
+	if err != nil {
+		transport.GRPCError(w, err)
+		return
+	}
+
+	transport.WriteJSON(w, http.StatusAccepted, resp)
+}
+
+// GetExport GET /api/exports/{id}
+func (h *MessageHandler) GetExport(w http.ResponseWriter, r *http.Request) {
+	userID := middleware.UserID(r.Context())
+	reqID := middleware.RequestIDFromContext(r.Context())
+
+	ctx, cancel := transport.WithTimeout(r.Context(), 2*time.Second)
+	defer cancel()
+	ctx = transport.WithMeta(ctx, userID, reqID)
+
+	resp, err := h.client.GetExport(ctx, &messagev1.GetExportRequest{
+		ExportId: chi.URLParam(r, "id"),
+	})
+	if err != nil {
+		transport.GRPCError(w, err)
+		return
+	}
+
+	transport.WriteJSON(w, http.StatusOK, resp)
+}
+
+// DownloadExport GET /api/exports/{id}/download
+func (h *MessageHandler) DownloadExport(w http.ResponseWriter, r *http.Request) {
+	userID := middleware.UserID(r.Context())
+	reqID := middleware.RequestIDFromContext(r.Context())
+	exportID := chi.URLParam(r, "id")
+
+	extendDeadlines(w)
+
+	ctx, cancel := transport.WithTimeout(r.Context(), transferTimeout)
+	defer cancel()
+	ctx = transport.WithMeta(ctx, userID, reqID)
+
+	stream, err := h.client.DownloadExport(ctx, &messagev1.DownloadExportRequest{
+		ExportId: exportID,
+	})
+	if err != nil {
+		transport.GRPCError(w, err)
+		return
+	}
+
+	head, err := stream.Recv()
+	if err != nil {
+		transport.GRPCError(w, err)
+		return
+	}
+	exp := head.GetExport()
+
+	w.Header().Set("Content-Type", "application/zip")
+	w.Header().Set("Content-Length", strconv.FormatInt(exp.GetSizeBytes(), 10))
+	w.Header().Set("Content-Disposition", mime.FormatMediaType("attachment", map[string]string{
+		"filename": "conversation-" + exp.GetConversationId() + ".zip",
+	}))
+	w.Header().Set("X-Content-Type-Options", "nosniff")
+	w.Header().Set("Cache-Control", "private, no-store")
+	w.WriteHeader(http.StatusOK)
+
+	for {
+		chunk, err := stream.Recv()
+		if errors.Is(err, io.EOF) {
+			return
+		}
+		if err != nil {
+			// Headers are already sent; all we can do is cut the response short
+			slog.Error("export download aborted", "export_id", exportID, "error", err)
+			return
+		}
+		if _, err := w.Write(chunk.GetData()); err != nil {
+			return
+		}
+	}
+}
+
+// RequestImport POST /api/imports
+func (h *MessageHandler) RequestImport(w http.ResponseWriter, r *http.Request) {
+	userID := middleware.UserID(r.Context())
+	reqID := middleware.RequestIDFromContext(r.Context())
+
+	var req struct {
+		Source       string            `json:"source"`
+		Name         string            `json:"name"`
+		AttachmentID string            `json:"attachment_id"`
+		Mapping      map[string]string `json:"mapping"`
+		Channel      string            `json:"channel"`
+		TimeZone     string            `json:"time_zone"`
+		DayFirst     bool              `json:"day_first"`
+	}
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		transport.WriteError(w, http.StatusBadRequest, errInvalidBody, msgInvalidJSON)
+		return
+	}
+
+	ctx, cancel := transport.WithTimeout(r.Context(), 5*time.Second)
+	defer cancel()
+	ctx = transport.WithMeta(ctx, userID, reqID)
+
+	resp, err := h.client.RequestImport(ctx, &messagev1.RequestImportRequest{
+		UserId:       userID,
+		Source:       req.Source,
+		Name:         req.Name,
+		AttachmentId: req.AttachmentID,
+		Mapping:      req.Mapping,
+		Channel:      req.Channel,
+		TimeZone:     req.TimeZone,
+		DayFirst:     req.DayFirst,
+	})
+	if err != nil {
+		transport.GRPCError(w, err)
+		return
+	}
+
+	transport.WriteJSON(w, http.StatusAccepted, resp)
+}
+
+// GetImport GET /api/imports/{id}
+func (h *MessageHandler) GetImport(w http.ResponseWriter, r *http.Request) {
+	userID := middleware.UserID(r.Context())
+	reqID := middleware.RequestIDFromContext(r.Context())
+
+	ctx, cancel := transport.WithTimeout(r.Context(), 2*time.Second)
+	defer cancel()
+	ctx = transport.WithMeta(ctx, userID, reqID)
+
+	resp, err := h.client.GetImport(ctx, &messagev1.GetImportRequest{
+		ImportId: chi.URLParam(r, "id"),
+	})
+	if err != nil {
+		transport.GRPCError(w, err)
+		return
+	}
+
+	transport.WriteJSON(w, http.StatusOK, resp)
+}
+
+// SaveDraft PUT /api/drafts
+func (h *MessageHandler) SaveDraft(w http.ResponseWriter, r *http.Request) {
+	userID := middleware.UserID(r.Context())
+	reqID := middleware.RequestIDFromContext(r.Context())
+
+	var req struct {
+		ConversationID string `json:"conversation_id"`
+		Text           string `json:"text"`
+		DeviceID       string `json:"device_id"`
+		UpdatedAt      string `json:"updated_at"`
+	}
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		transport.WriteError(w, http.StatusBadRequest, "invalid_body", "invalid json")
+		return
+	}
+	if req.ConversationID == "" {
+		transport.WriteError(w, http.StatusBadRequest, "missing_conv_id", "conversation_id is required")
+		return
+	}
+	updatedAt, ok := parseDraftTime(w, req.UpdatedAt)
+	if !ok {
+		return
+	}
+
+	ctx, cancel := transport.WithTimeout(r.Context(), 2*time.Second)
+	defer cancel()
+	ctx = transport.WithMeta(ctx, userID, reqID)
+
+	resp, err := h.client.SaveDraft(ctx, &messagev1.SaveDraftRequest{
+		ConversationId: req.ConversationID,
+		Text:           req.Text,
+		DeviceId:       req.DeviceID,
+		UpdatedAt:      updatedAt,
+	})
+	if err != nil {
+		transport.GRPCError(w, err)
+		return
+	}
+
+	transport.WriteJSON(w, http.StatusOK, resp)
+}
+
+// ClearDraft DELETE /api/drafts?conversation_id=...&device_id=...&cleared_at=...
+func (h *MessageHandler) ClearDraft(w http.ResponseWriter, r *http.Request) {
+	userID := middleware.UserID(r.Context())
+	reqID := middleware.RequestIDFromContext(r.Context())
+
+	q := r.URL.Query()
+	if q.Get("conversation_id") == "" {
+		transport.WriteError(w, http.StatusBadRequest, "missing_conv_id", "conversation_id is required")
+		return
+	}
+	clearedAt, ok := parseDraftTime(w, q.Get("cleared_at"))
+	if !ok {
+		return
+	}
+
+	ctx, cancel := transport.WithTimeout(r.Context(), 2*time.Second)
+	defer cancel()
+	ctx = transport.WithMeta(ctx, userID, reqID)
+
+	resp, err := h.client.ClearDraft(ctx, &messagev1.ClearDraftRequest{
+		ConversationId: q.Get("conversation_id"),
+		DeviceId:       q.Get("device_id"),
+		ClearedAt:      clearedAt,
+	})
+	if err != nil {
+		transport.GRPCError(w, err)
+		return
+	}
+
+	transport.WriteJSON(w, http.StatusOK, resp)
+}
+
+// GetDrafts GET /api/drafts
+func (h *MessageHandler) GetDrafts(w http.ResponseWriter, r *http.Request) {
+	userID := middleware.UserID(r.Context())
+	reqID := middleware.RequestIDFromContext(r.Context())
+
+	ctx, cancel := transport.WithTimeout(r.Context(), 2*time.Second)
+	defer cancel()
+	ctx = transport.WithMeta(ctx, userID, reqID)
+
+	resp, err := h.client.GetDrafts(ctx, &messagev1.GetDraftsRequest{})
+	if err != nil {
+		transport.GRPCError(w, err)
+		return
+	}
+
+	transport.WriteJSON(w, http.StatusOK, resp)
+}
+
+// parseDraftTime parses an optional RFC 3339 draft timestamp, writing a 400
+// on failure.
+func parseDraftTime(w http.ResponseWriter, raw string) (*timestamppb.Timestamp, bool) {
+	if raw == "" {
+		return nil, true
+	}
+	t, err := time.Parse(time.RFC3339Nano, raw)
+	if err != nil {
+		transport.WriteError(w, http.StatusBadRequest, "invalid_timestamp", "draft timestamps must be RFC 3339")
+		return nil, false
+	}
+	return timestamppb.New(t), true
+}
+
+// ListHeldMessages GET /api/moderation/held
+func (h *MessageHandler) ListHeldMessages(w http.ResponseWriter, r *http.Request) {
+	userID := middleware.UserID(r.Context())
+	reqID := middleware.RequestIDFromContext(r.Context())
+
+	query := r.URL.Query()
+	req := &messagev1.ListHeldMessagesRequest{
+		Status:    query.Get("status"),
+		PageToken: query.Get("page_token"),
+	}
+	if val := query.Get("limit"); val != "" {
+		var parseLimit int32
+		if _, err := fmt.Sscanf(val, "%d", &parseLimit); err == nil && parseLimit > 0 {
+			req.PageSize = parseLimit
+		}
+	}
+
+	ctx, cancel := transport.WithTimeout(r.Context(), 2*time.Second)
+	defer cancel()
+	ctx = transport.WithMeta(ctx, userID, reqID)
+
+	resp, err := h.client.ListHeldMessages(ctx, req)
+	if err != nil {
+		transport.GRPCError(w, err)
+		return
+	}
+
+	transport.WriteJSON(w, http.StatusOK, resp)
+}
+
+// ReviewHeldMessage POST /api/moderation/held/{id}/review
+func (h *MessageHandler) ReviewHeldMessage(w http.ResponseWriter, r *http.Request) {
+	userID := middleware.UserID(r.Context())
+	reqID := middleware.RequestIDFromContext(r.Context())
+
+	var req struct {
+		Approve *bool  `json:"approve"`
+		Note    string `json:"note"`
+	}
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		transport.WriteError(w, http.StatusBadRequest, errInvalidBody, msgInvalidJSON)
+		return
+	}
+	if req.Approve == nil {
+		transport.WriteError(w, http.StatusBadRequest, "missing_approve", "approve is required")
+		return
+	}
+
+	ctx, cancel := transport.WithTimeout(r.Context(), 5*time.Second)
+	defer cancel()
+	ctx = transport.WithMeta(ctx, userID, reqID)
+
+	resp, err := h.client.ReviewHeldMessage(ctx, &messagev1.ReviewHeldMessageRequest{
+		HeldId:  chi.URLParam(r, "id"),
+		Approve: *req.Approve,
+		Note:    req.Note,
+	})
+	if err != nil {
+		transport.GRPCError(w, err)
+		return
+	}
+
+	transport.WriteJSON(w, http.StatusOK, resp)
+}
@@ -0,0 +1,146 @@
+package handlers
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"time"
+
+	webhookv1 "github.com/SARVESHVARADKAR123/RealChat/contracts/gen/go/webhook/v1"
+	"github.com/SARVESHVARADKAR123/RealChat/edge/gateway/internal/middleware"
+	"github.com/SARVESHVARADKAR123/RealChat/edge/gateway/internal/transport"
+	"github.com/go-chi/chi/v5"
+)
+
+// WebhookHandler lets conversation admins manage webhooks and inspect
+// deliveries that were given up on.
+type WebhookHandler struct {
+	client webhookv1.WebhookApiClient
+}
+
+func NewWebhookHandler(c webhookv1.WebhookApiClient) *WebhookHandler {
+	return &WebhookHandler{client: c}
+}
+
+// CreateWebhook POST /api/conversations/{id}/webhooks
+func (h *WebhookHandler) CreateWebhook(w http.ResponseWriter, r *http.Request) {
+	userID := middleware.UserID(r.Context())
+	reqID := middleware.RequestIDFromContext(r.Context())
+
+	var req struct {
+		URL string `json:"url"`
+	}
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		transport.WriteError(w, http.StatusBadRequest, errInvalidBody, msgInvalidJSON)
+		return
+	}
+	if req.URL == "" {
+		transport.WriteError(w, http.StatusBadRequest, "missing_fields", "url is required")
+		return
+	}
+
+	ctx, cancel := transport.WithTimeout(r.Context(), 2*time.Second)
+	defer cancel()
+	ctx = transport.WithMeta(ctx, userID, reqID)
+
+	resp, err := h.client.CreateWebhook(ctx, &webhookv1.CreateWebhookRequest{
+		ConversationId: chi.URLParam(r, "id"),
+		Url:            req.URL,
+	})
+	if err != nil {
+		transport.GRPCError(w, err)
+		return
+	}
+
+	transport.WriteJSON(w, http.StatusCreated, resp)
+}
+
+// ListWebhooks GET /api/conversations/{id}/webhooks
+func (h *WebhookHandler) ListWebhooks(w http.ResponseWriter, r *http.Request) {
+	userID := middleware.UserID(r.Context())
+	reqID := middleware.RequestIDFromContext(r.Context())
+
+	ctx, cancel := transport.WithTimeout(r.Context(), 2*time.Second)
+	defer cancel()
+	ctx = transport.WithMeta(ctx, userID, reqID)
+
+	resp, err := h.client.ListWebhooks(ctx, &webhookv1.ListWebhooksRequest{
+		ConversationId: chi.URLParam(r, "id"),
+	})
+	if err != nil {
+		transport.GRPCError(w, err)
+		return
+	}
+
+	transport.WriteJSON(w, http.StatusOK, resp)
+}
+
+// DeleteWebhook DELETE /api/webhooks/{id}
+func (h *WebhookHandler) DeleteWebhook(w http.ResponseWriter, r *http.Request) {
+	userID := middleware.UserID(r.Context())
+	reqID := middleware.RequestIDFromContext(r.Context())
+
+	ctx, cancel := transport.WithTimeout(r.Context(), 2*time.Second)
+	defer cancel()
+	ctx = transport.WithMeta(ctx, userID, reqID)
+
+	_, err := h.client.DeleteWebhook(ctx, &webhookv1.DeleteWebhookRequest{
+		WebhookId: chi.URLParam(r, "id"),
+	})
+	if err != nil {
+		transport.GRPCError(w, err)
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// EnableWebhook POST /api/webhooks/{id}/enable
+func (h *WebhookHandler) EnableWebhook(w http.ResponseWriter, r *http.Request) {
+	userID := middleware.UserID(r.Context())
+	reqID := middleware.RequestIDFromContext(r.Context())
+
+	ctx, cancel := transport.WithTimeout(r.Context(), 2*time.Second)
+	defer cancel()
+	ctx = transport.WithMeta(ctx, userID, reqID)
+
+	resp, err := h.client.EnableWebhook(ctx, &webhookv1.EnableWebhookRequest{
+		WebhookId: chi.URLParam(r, "id"),
+	})
+	if err != nil {
+		transport.GRPCError(w, err)
+		return
+	}
+
+	transport.WriteJSON(w, http.StatusOK, resp)
+}
+
+// ListDeadLetters GET /api/webhooks/{id}/dead-letters
+func (h *WebhookHandler) ListDeadLetters(w http.ResponseWriter, r *http.Request) {
+	userID := middleware.UserID(r.Context())
+	reqID := middleware.RequestIDFromContext(r.Context())
+
+	query := r.URL.Query()
+	req := &webhookv1.ListDeadLettersRequest{
+		WebhookId: chi.URLParam(r, "id"),
+		PageToken: query.Get("page_token"),
+	}
+	if val := query.Get("limit"); val != "" {
+		var parseLimit int32
+		if _, err := fmt.Sscanf(val, "%d", &parseLimit); err == nil && parseLimit > 0 {
+			req.PageSize = parseLimit
+		}
+	}
+
+	ctx, cancel := transport.WithTimeout(r.Context(), 2*time.Second)
+	defer cancel()
+	ctx = transport.WithMeta(ctx, userID, reqID)
+
+	resp, err := h.client.ListDeadLetters(ctx, req)
+	if err != nil {
+		transport.GRPCError(w, err)
+		return
+	}
+
+	transport.WriteJSON(w, http.StatusOK, resp)
+}
@@ -17,6 +17,9 @@ func NewRouter(
 	convH *handlers.ConversationHandler,
 	msgH *handlers.MessageHandler,
 	presenceH *handlers.PresenceHandler,
+	mediaH *handlers.MediaHandler,
+	keysH *handlers.KeysHandler,
+	webhookH *handlers.WebhookHandler,
 	cfg *config.Config,
 ) http.Handler {
 
@@ -32,6 +35,9 @@ func NewRouter(
 	r.Post("/api/refresh", authH.Refresh)
 	r.Post("/api/logout", authH.Logout)
 
+	// Signed media links carry their own credential
+	r.Get("/api/media/{id}", mediaH.Download)
+
 	r.Group(func(p chi.Router) {
 		p.Use(middleware.JWT(cfg.JWTSecret, cfg.JWTIssuer, cfg.JWTAudience))
 
@@ -43,11 +49,51 @@ func NewRouter(
 		p.Post(convPath, convH.CreateConversation)
 		p.Get(convPath, convH.ListConversations)
 		p.Get(convPath+"/{id}", convH.GetConversation)
+		p.Put(convPath+"/{id}/message-ttl", convH.SetMessageTTL)
+		p.Get(convPath+"/{id}/seen", convH.GetSeenBy)
+		p.Get(convPath+"/{id}/retention", msgH.GetRetentionPolicy)
+		p.Put(convPath+"/{id}/retention", msgH.SetRetentionPolicy)
+		p.Post(convPath+"/{id}/exports", msgH.RequestExport)
+		p.Get(convPath+"/{id}/webhooks", webhookH.ListWebhooks)
+		p.Post(convPath+"/{id}/webhooks", webhookH.CreateWebhook)
+
+		exportPath := "/api/exports"
+		p.Get(exportPath+"/{id}", msgH.GetExport)
+		p.Get(exportPath+"/{id}/download", msgH.DownloadExport)
+
+		importPath := "/api/imports"
+		p.Post(importPath, msgH.RequestImport)
+		p.Get(importPath+"/{id}", msgH.GetImport)
+
+		draftPath := "/api/drafts"
+		p.Get(draftPath, msgH.GetDrafts)
+		p.Put(draftPath, msgH.SaveDraft)
+		p.Delete(draftPath, msgH.ClearDraft)
+
+		moderationPath := "/api/moderation/held"
+		p.Get(moderationPath, msgH.ListHeldMessages)
+		p.Post(moderationPath+"/{id}/review", msgH.ReviewHeldMessage)
 
 		mesPath := "/api/messages"
 		p.Get(mesPath, msgH.SyncMessages)
 		p.Post(mesPath, msgH.SendMessage)
+		p.Patch(mesPath, msgH.EditMessage)
 		p.Delete(mesPath, msgH.DeleteMessage)
+		p.Post(mesPath+"/forward", msgH.ForwardMessages)
+		p.Get(mesPath+"/thread", msgH.ListThreadReplies)
+		p.Get(mesPath+"/search", msgH.SearchMessages)
+		p.Get(mesPath+"/mentions/unread", msgH.GetUnreadMentionCounts)
+		p.Post(mesPath+"/reactions", msgH.AddReaction)
+		p.Delete(mesPath+"/reactions", msgH.RemoveReaction)
+		p.Get(mesPath+"/pins", msgH.ListPinnedMessages)
+		p.Post(mesPath+"/pins", msgH.PinMessage)
+		p.Delete(mesPath+"/pins", msgH.UnpinMessage)
+		p.Post(mesPath+"/polls/votes", msgH.VotePoll)
+		p.Delete(mesPath+"/polls/votes", msgH.RetractVote)
+		p.Post(mesPath+"/polls/close", msgH.ClosePoll)
+		p.Get(mesPath+"/scheduled", msgH.ListScheduledMessages)
+		p.Patch(mesPath+"/scheduled", msgH.UpdateScheduledMessage)
+		p.Delete(mesPath+"/scheduled", msgH.CancelScheduledMessage)
 
 		partPath := "/api/participants"
 		p.Post(partPath, convH.AddParticipant)
@@ -58,6 +104,22 @@ func NewRouter(
 
 		presencePath := "/api/presence"
 		p.Get(presencePath, presenceH.GetPresence)
+
+		uploadPath := "/api/media/uploads"
+		p.Post(uploadPath, mediaH.CreateUpload)
+		p.Put(uploadPath+"/{id}", mediaH.UploadContent)
+
+		keysPath := "/api/keys"
+		p.Put(keysPath, keysH.UploadKeys)
+		p.Delete(keysPath, keysH.RemoveDevice)
+		p.Post(keysPath+"/prekeys", keysH.ReplenishPreKeys)
+		p.Get(keysPath+"/count", keysH.GetPreKeyCount)
+		p.Get(keysPath+"/users/{user_id}", keysH.GetPreKeyBundles)
+
+		webhookPath := "/api/webhooks"
+		p.Delete(webhookPath+"/{id}", webhookH.DeleteWebhook)
+		p.Post(webhookPath+"/{id}/enable", webhookH.EnableWebhook)
+		p.Get(webhookPath+"/{id}/dead-letters", webhookH.ListDeadLetters)
 	})
 
 	return otelhttp.NewHandler(r, "gateway")
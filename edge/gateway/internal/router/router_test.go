@@ -19,7 +19,7 @@ func TestRateLimiting(t *testing.T) {
 
 	// 3. Instead of initializing all handlers, just plug in the bare middleware to
 	// a mock endpoint
-	handler := NewRouter(nil, nil, nil, nil, nil, cfg)
+	handler := NewRouter(nil, nil, nil, nil, nil, nil, nil, nil, cfg)
 
 	server := httptest.NewServer(handler)
 	defer server.Close()
@@ -30,6 +30,8 @@ func GRPCError(w http.ResponseWriter, err error) {
 		WriteError(w, 403, "forbidden", "access denied")
 	case codes.AlreadyExists:
 		WriteError(w, 409, "already_exists", st.Message())
+	case codes.FailedPrecondition:
+		WriteError(w, 409, "failed_precondition", st.Message())
 	case codes.Unavailable:
 		WriteError(w, 503, "unavailable", "service temporarily unavailable")
 	case codes.DeadlineExceeded:
@@ -2,3 +2,6 @@ CREATE DATABASE auth;
 CREATE DATABASE profile;
 CREATE DATABASE messaging;
 CREATE DATABASE conversation;
+CREATE DATABASE media;
+CREATE DATABASE keys;
+CREATE DATABASE webhooks;
@@ -20,6 +20,7 @@ import (
 	"github.com/SARVESHVARADKAR123/RealChat/services/conversation/internal/kafka"
 	"github.com/SARVESHVARADKAR123/RealChat/services/conversation/internal/observability"
 	"github.com/SARVESHVARADKAR123/RealChat/services/conversation/internal/outbox"
+	"github.com/SARVESHVARADKAR123/RealChat/services/conversation/internal/projection"
 	"github.com/SARVESHVARADKAR123/RealChat/services/conversation/internal/repository/postgres"
 	"github.com/SARVESHVARADKAR123/RealChat/services/conversation/internal/transport/grpc"
 	"github.com/SARVESHVARADKAR123/RealChat/services/conversation/internal/tx"
@@ -91,11 +92,24 @@ func main() {
 		PollDelay: 2 * time.Second,
 	}
 
+	// Message events keep each conversation's last message sequence current
+	messageConsumer, err := kafka.NewConsumer(
+		cfg.KafkaBrokers,
+		"conversation-service-message-sequences",
+		[]string{cfg.MessageTopic},
+		&projection.Handler{Recorder: app},
+	)
+	if err != nil {
+		log.Fatal("kafka consumer failed", zap.Error(err))
+	}
+	defer messageConsumer.Close()
+
 	// Cancellable context for background workers
 	ctx, cancel := context.WithCancel(context.Background())
 	defer cancel()
 
 	go worker.Start(ctx)
+	go messageConsumer.Start(ctx)
 
 	// gRPC Server
 	server := grpc.New(app)
@@ -9,8 +9,8 @@ import (
 	conversationv1 "github.com/SARVESHVARADKAR123/RealChat/contracts/gen/go/conversation/v1"
 	sharedv1 "github.com/SARVESHVARADKAR123/RealChat/contracts/gen/go/shared/v1"
 	"github.com/SARVESHVARADKAR123/RealChat/services/conversation/internal/domain"
+	"github.com/google/uuid"
 	"google.golang.org/protobuf/proto"
-	"google.golang.org/protobuf/types/known/timestamppb"
 )
 
 type CreateConversationCommand struct {
@@ -126,7 +126,7 @@ func (s *Service) doCreateConversation(
 	}
 
 	// 4️⃣ Emit Event and return
-	return s.emitConversationCreated(ctx, tx, cmd.ID)
+	return s.emitConversationCreated(ctx, tx, cmd.ID, cmd.Participants[0])
 }
 
 func (s *Service) insertParticipants(ctx context.Context, tx *sql.Tx, cmd CreateConversationCommand) error {
@@ -142,35 +142,18 @@ func (s *Service) insertParticipants(ctx context.Context, tx *sql.Tx, cmd Create
 	return nil
 }
 
-func (s *Service) emitConversationCreated(ctx context.Context, tx *sql.Tx, convID string) (*domain.Conversation, error) {
+func (s *Service) emitConversationCreated(ctx context.Context, tx *sql.Tx, convID, creatorID string) (*domain.Conversation, error) {
 	conv, err := s.repo.GetConversationLocked(ctx, tx, convID)
 	if err != nil {
 		return nil, fmt.Errorf("failed to lock conversation after creation: %w", err)
 	}
 
-	pbParticipants := make([]string, 0, len(conv.Participants))
-	for uid := range conv.Participants {
-		pbParticipants = append(pbParticipants, uid)
-	}
-
-	var pbType conversationv1.ConversationType
-	if conv.Type == domain.ConversationGroup {
-		pbType = conversationv1.ConversationType_GROUP
-	} else {
-		pbType = conversationv1.ConversationType_DIRECT
-	}
-
-	pbConv := &conversationv1.Conversation{
-		ConversationId: conv.ID,
-		DisplayName:    conv.DisplayName,
-		AvatarUrl:      conv.AvatarURL,
-		Type:           pbType,
-		CreatedAt:      timestamppb.New(conv.CreatedAt),
-	}
+	pbConv := eventConversation(conv)
 
 	event := &conversationv1.ConversationCreatedEvent{
 		Conversation:       pbConv,
-		ParticipantUserIds: pbParticipants,
+		ParticipantUserIds: pbConv.ParticipantUserIds,
+		CreatorUserId:      creatorID,
 	}
 	eventPayload, err := proto.Marshal(event)
 	if err != nil {
@@ -182,6 +165,7 @@ func (s *Service) emitConversationCreated(ctx context.Context, tx *sql.Tx, convI
 		SchemaVersion: 1,
 		OccurredAt:    pbConv.CreatedAt, // Align with domain event timestamp
 		Payload:       eventPayload,
+		EventId:       uuid.NewString(),
 	}
 	envPayload, err := proto.Marshal(env)
 	if err != nil {
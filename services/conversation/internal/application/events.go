@@ -0,0 +1,41 @@
+package application
+
+import (
+	"time"
+
+	conversationv1 "github.com/SARVESHVARADKAR123/RealChat/contracts/gen/go/conversation/v1"
+	"github.com/SARVESHVARADKAR123/RealChat/services/conversation/internal/domain"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+// eventConversation is conv as carried by events. It includes roles and
+// settings, so consumers can keep their own copy of the conversation.
+func eventConversation(conv *domain.Conversation) *conversationv1.Conversation {
+	pbType := conversationv1.ConversationType_DIRECT
+	if conv.Type == domain.ConversationGroup {
+		pbType = conversationv1.ConversationType_GROUP
+	}
+
+	pbConv := &conversationv1.Conversation{
+		ConversationId:    conv.ID,
+		DisplayName:       conv.DisplayName,
+		AvatarUrl:         conv.AvatarURL,
+		Type:              pbType,
+		CreatedAt:         timestamppb.New(conv.CreatedAt),
+		MessageTtlSeconds: int64(conv.MessageTTL / time.Second),
+	}
+
+	for uid, p := range conv.Participants {
+		role := conversationv1.ParticipantRole_MEMBER
+		if p.Role == domain.RoleAdmin {
+			role = conversationv1.ParticipantRole_ADMIN
+		}
+		pbConv.ParticipantUserIds = append(pbConv.ParticipantUserIds, uid)
+		pbConv.ParticipantsWithRoles = append(pbConv.ParticipantsWithRoles, &conversationv1.Participant{
+			UserId: uid,
+			Role:   role,
+		})
+	}
+
+	return pbConv
+}
@@ -0,0 +1,29 @@
+package application
+
+import (
+	"context"
+
+	"github.com/SARVESHVARADKAR123/RealChat/services/conversation/internal/domain"
+)
+
+// GetSeenBy returns the participants who have read convID up to sequence.
+// Only participants may ask.
+func (s *Service) GetSeenBy(
+	ctx context.Context,
+	convID, userID string,
+	sequence int64,
+) ([]domain.Participant, error) {
+	if convID == "" || sequence <= 0 {
+		return nil, domain.ErrInvalidInput
+	}
+
+	conv, err := s.repo.GetConversation(ctx, nil, convID)
+	if err != nil {
+		return nil, err
+	}
+	if err := conv.CanSend(userID); err != nil {
+		return nil, err
+	}
+
+	return s.repo.ListReaders(ctx, convID, sequence)
+}
@@ -0,0 +1,295 @@
+package application
+
+import (
+	"context"
+	"database/sql"
+	"testing"
+	"time"
+
+	conversationv1 "github.com/SARVESHVARADKAR123/RealChat/contracts/gen/go/conversation/v1"
+	sharedv1 "github.com/SARVESHVARADKAR123/RealChat/contracts/gen/go/shared/v1"
+	"github.com/SARVESHVARADKAR123/RealChat/services/conversation/internal/domain"
+	"github.com/SARVESHVARADKAR123/RealChat/services/conversation/internal/repository"
+	"google.golang.org/protobuf/proto"
+)
+
+// memRepo keeps conversations, sequences and receipts in memory. Methods
+// the tests don't reach are left to the embedded nil interface.
+type memRepo struct {
+	repository.Repository
+
+	convs   map[string]*domain.Conversation
+	next    map[string]int64 // next_sequence, the reservation high-water mark
+	last    map[string]int64 // last_message_sequence
+	devices map[domain.DeliveryReceipt]int64
+	outbox  []*sharedv1.EventEnvelope
+}
+
+func newMemRepo() *memRepo {
+	return &memRepo{
+		convs:   map[string]*domain.Conversation{},
+		next:    map[string]int64{},
+		last:    map[string]int64{},
+		devices: map[domain.DeliveryReceipt]int64{},
+	}
+}
+
+// addConversation adds a group conversation with the given participants.
+func (r *memRepo) addConversation(id string, userIDs ...string) *domain.Conversation {
+	c := &domain.Conversation{ID: id, Type: domain.ConversationGroup, Participants: map[string]domain.Participant{}}
+	for _, u := range userIDs {
+		c.Participants[u] = domain.Participant{UserID: u, Role: domain.RoleMember}
+	}
+	r.convs[id] = c
+	return c
+}
+
+func (r *memRepo) GetConversation(ctx context.Context, tx *sql.Tx, convID string) (*domain.Conversation, error) {
+	c, ok := r.convs[convID]
+	if !ok {
+		return nil, domain.ErrConversationNotFound
+	}
+	return c, nil
+}
+
+func (r *memRepo) ReserveSequences(ctx context.Context, tx *sql.Tx, convID string, after, count int64) (int64, int64, error) {
+	if after == 0 {
+		after = r.next[convID]
+	}
+	r.next[convID] = max(r.next[convID], after+count)
+	return after + 1, after + count, nil
+}
+
+func (r *memRepo) RecordMessageSequence(ctx context.Context, convID string, sequence int64) error {
+	r.last[convID] = max(r.last[convID], sequence)
+	return nil
+}
+
+func (r *memRepo) GetLastMessageSequences(ctx context.Context, tx *sql.Tx, convIDs []string) (map[string]int64, error) {
+	out := make(map[string]int64, len(convIDs))
+	for _, id := range convIDs {
+		if seq, ok := r.last[id]; ok {
+			out[id] = seq
+		}
+	}
+	return out, nil
+}
+
+func (r *memRepo) UpdateLastReadSequence(ctx context.Context, tx *sql.Tx, convID, userID string, seq int64) error {
+	p, ok := r.convs[convID].Participants[userID]
+	if !ok || seq <= p.LastReadSequence {
+		return nil
+	}
+	p.LastReadSequence, p.LastReadAt = seq, time.Now()
+	r.convs[convID].Participants[userID] = p
+	return nil
+}
+
+func (r *memRepo) UpsertDeliveryReceipts(ctx context.Context, tx *sql.Tx, receipts []domain.DeliveryReceipt) ([]domain.DeliveryUpdate, error) {
+	// Like the query, store every device and raise each participant once
+	type participantKey struct{ conv, user string }
+	var order []participantKey
+	highest := map[participantKey]int64{}
+	for _, rc := range receipts {
+		c, ok := r.convs[rc.ConversationID]
+		if !ok {
+			continue
+		}
+		if _, ok := c.Participants[rc.UserID]; !ok {
+			continue
+		}
+		key := rc
+		key.DeliveredSequence = 0
+		r.devices[key] = max(r.devices[key], rc.DeliveredSequence)
+
+		pk := participantKey{rc.ConversationID, rc.UserID}
+		if _, ok := highest[pk]; !ok {
+			order = append(order, pk)
+		}
+		highest[pk] = max(highest[pk], rc.DeliveredSequence)
+	}
+
+	var updates []domain.DeliveryUpdate
+	for _, pk := range order {
+		c := r.convs[pk.conv]
+		p := c.Participants[pk.user]
+		if highest[pk] <= p.LastDeliveredSequence {
+			continue
+		}
+		p.LastDeliveredSequence = highest[pk]
+		c.Participants[pk.user] = p
+		updates = append(updates, domain.DeliveryUpdate{
+			ConversationID:    pk.conv,
+			UserID:            pk.user,
+			DeliveredSequence: highest[pk],
+		})
+	}
+	return updates, nil
+}
+
+func (r *memRepo) GetAllDeliveredSequences(ctx context.Context, tx *sql.Tx, convIDs []string) (map[string]int64, error) {
+	out := make(map[string]int64, len(convIDs))
+	for _, id := range convIDs {
+		first := true
+		for _, p := range r.convs[id].Participants {
+			seq := max(p.LastDeliveredSequence, p.LastReadSequence)
+			if first || seq < out[id] {
+				out[id] = seq
+			}
+			first = false
+		}
+	}
+	return out, nil
+}
+
+func (r *memRepo) InsertOutbox(ctx context.Context, tx *sql.Tx, aggregateType, aggregateID, eventType string, payload []byte) error {
+	var env sharedv1.EventEnvelope
+	if err := proto.Unmarshal(payload, &env); err != nil {
+		return err
+	}
+	r.outbox = append(r.outbox, &env)
+	return nil
+}
+
+// noTx runs the function without a transaction.
+type noTx struct{}
+
+func (noTx) WithTx(ctx context.Context, fn func(ctx context.Context, tx *sql.Tx) error) error {
+	return fn(ctx, nil)
+}
+
+func TestUpdateReadReceipt_ClampsToLastMessage(t *testing.T) {
+	ctx := context.Background()
+	repo := newMemRepo()
+	repo.addConversation("conv-1", "alice", "bob")
+	svc := New(repo, noTx{})
+
+	// Three messages sent out of a reserved block of a hundred
+	if _, _, err := svc.ReserveSequences(ctx, "conv-1", 0, 100); err != nil {
+		t.Fatal(err)
+	}
+	for seq := int64(1); seq <= 3; seq++ {
+		if err := svc.RecordMessageSequence(ctx, "conv-1", seq); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	if err := svc.UpdateReadReceipt(ctx, "conv-1", "alice", 50); err != nil {
+		t.Fatal(err)
+	}
+	if got := repo.convs["conv-1"].Participants["alice"].LastReadSequence; got != 3 {
+		t.Fatalf("read sequence = %d, want 3, the last message", got)
+	}
+
+	var event conversationv1.ReadReceiptUpdatedEvent
+	if err := proto.Unmarshal(repo.outbox[0].GetPayload(), &event); err != nil {
+		t.Fatal(err)
+	}
+	if event.GetReadSequence() != 3 {
+		t.Fatalf("event read sequence = %d, want 3", event.GetReadSequence())
+	}
+}
+
+func TestRecordDeliveryReceipts_ClampsToLastMessage(t *testing.T) {
+	ctx := context.Background()
+	repo := newMemRepo()
+	repo.addConversation("conv-1", "alice", "bob")
+	svc := New(repo, noTx{})
+
+	if _, _, err := svc.ReserveSequences(ctx, "conv-1", 0, 100); err != nil {
+		t.Fatal(err)
+	}
+	if err := svc.RecordMessageSequence(ctx, "conv-1", 2); err != nil {
+		t.Fatal(err)
+	}
+
+	err := svc.RecordDeliveryReceipts(ctx, []domain.DeliveryReceipt{
+		{ConversationID: "conv-1", UserID: "bob", DeviceID: "phone", DeliveredSequence: 80},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got := repo.convs["conv-1"].Participants["bob"].LastDeliveredSequence; got != 2 {
+		t.Fatalf("delivered sequence = %d, want 2, the last message", got)
+	}
+}
+
+func TestRecordDeliveryReceipts_OneEventPerParticipant(t *testing.T) {
+	ctx := context.Background()
+	repo := newMemRepo()
+	repo.addConversation("conv-1", "alice", "bob", "carol")
+	svc := New(repo, noTx{})
+
+	if err := svc.RecordMessageSequence(ctx, "conv-1", 10); err != nil {
+		t.Fatal(err)
+	}
+
+	// Bob's devices report several times; alice reports once. Carol has
+	// received nothing, which holds everyone's sequence back.
+	err := svc.RecordDeliveryReceipts(ctx, []domain.DeliveryReceipt{
+		{ConversationID: "conv-1", UserID: "bob", DeviceID: "phone", DeliveredSequence: 4},
+		{ConversationID: "conv-1", UserID: "bob", DeviceID: "laptop", DeliveredSequence: 7},
+		{ConversationID: "conv-1", UserID: "bob", DeviceID: "phone", DeliveredSequence: 6},
+		{ConversationID: "conv-1", UserID: "alice", DeviceID: "phone", DeliveredSequence: 5},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	events := deliveryEvents(t, repo)
+	if len(events) != 2 {
+		t.Fatalf("expected one event per participant, got %d", len(events))
+	}
+	want := map[string]int64{"bob": 7, "alice": 5}
+	for _, e := range events {
+		if e.GetDeliveredSequence() != want[e.GetUserId()] {
+			t.Errorf("%s delivered sequence = %d, want %d", e.GetUserId(), e.GetDeliveredSequence(), want[e.GetUserId()])
+		}
+		if e.GetAllDeliveredSequence() != 0 {
+			t.Errorf("all delivered sequence = %d, want 0 while carol has nothing", e.GetAllDeliveredSequence())
+		}
+	}
+
+	// Once carol catches up, everyone has received at least alice's 5
+	repo.outbox = nil
+	err = svc.RecordDeliveryReceipts(ctx, []domain.DeliveryReceipt{
+		{ConversationID: "conv-1", UserID: "carol", DeviceID: "tablet", DeliveredSequence: 9},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	events = deliveryEvents(t, repo)
+	if len(events) != 1 || events[0].GetUserId() != "carol" || events[0].GetAllDeliveredSequence() != 5 {
+		t.Fatalf("events = %v, want carol's with all delivered sequence 5", events)
+	}
+
+	// Receipts that move nobody forward emit nothing
+	repo.outbox = nil
+	err = svc.RecordDeliveryReceipts(ctx, []domain.DeliveryReceipt{
+		{ConversationID: "conv-1", UserID: "bob", DeviceID: "phone", DeliveredSequence: 7},
+		{ConversationID: "conv-1", UserID: "alice", DeviceID: "laptop", DeliveredSequence: 3},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(repo.outbox) != 0 {
+		t.Fatalf("expected no events, got %d", len(repo.outbox))
+	}
+}
+
+// deliveryEvents decodes the DeliveryReceiptUpdated events in the outbox.
+func deliveryEvents(t *testing.T, repo *memRepo) []*conversationv1.DeliveryReceiptUpdatedEvent {
+	t.Helper()
+	var events []*conversationv1.DeliveryReceiptUpdatedEvent
+	for _, env := range repo.outbox {
+		if env.GetEventType() != sharedv1.EventType_EVENT_TYPE_DELIVERY_RECEIPT_UPDATED {
+			continue
+		}
+		var e conversationv1.DeliveryReceiptUpdatedEvent
+		if err := proto.Unmarshal(env.GetPayload(), &e); err != nil {
+			t.Fatal(err)
+		}
+		events = append(events, &e)
+	}
+	return events
+}
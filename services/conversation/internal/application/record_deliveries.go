@@ -0,0 +1,139 @@
+package application
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+
+	conversationv1 "github.com/SARVESHVARADKAR123/RealChat/contracts/gen/go/conversation/v1"
+	sharedv1 "github.com/SARVESHVARADKAR123/RealChat/contracts/gen/go/shared/v1"
+	"github.com/SARVESHVARADKAR123/RealChat/services/conversation/internal/domain"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+// maxDeliveryReceipts caps a single RecordDeliveryReceipts batch.
+const maxDeliveryReceipts = 5000
+
+// RecordDeliveryReceipts stores the highest sequence delivered to each
+// device. The whole batch is written in one transaction, and one
+// DeliveryReceiptUpdated event is emitted for each participant whose
+// delivered sequence moved forward, however many of their devices reported.
+func (s *Service) RecordDeliveryReceipts(ctx context.Context, receipts []domain.DeliveryReceipt) error {
+	if len(receipts) > maxDeliveryReceipts {
+		return domain.ErrInvalidInput
+	}
+
+	// Keep the highest sequence reported for each device
+	type deviceKey struct{ conv, user, device string }
+	index := make(map[deviceKey]int, len(receipts))
+	batch := make([]domain.DeliveryReceipt, 0, len(receipts))
+	for _, rc := range receipts {
+		if rc.ConversationID == "" || rc.UserID == "" || rc.DeviceID == "" {
+			return domain.ErrInvalidInput
+		}
+		if rc.DeliveredSequence <= 0 {
+			continue
+		}
+		key := deviceKey{rc.ConversationID, rc.UserID, rc.DeviceID}
+		if i, ok := index[key]; ok {
+			if rc.DeliveredSequence > batch[i].DeliveredSequence {
+				batch[i].DeliveredSequence = rc.DeliveredSequence
+			}
+			continue
+		}
+		index[key] = len(batch)
+		batch = append(batch, rc)
+	}
+	if len(batch) == 0 {
+		return nil
+	}
+
+	var convIDs []string
+	seen := make(map[string]bool)
+	for _, rc := range batch {
+		if !seen[rc.ConversationID] {
+			seen[rc.ConversationID] = true
+			convIDs = append(convIDs, rc.ConversationID)
+		}
+	}
+
+	return s.tx.WithTx(ctx, func(ctx context.Context, tx *sql.Tx) error {
+		// Nothing past the last message can have been delivered, whatever
+		// block of sequences the message service holds
+		lastSeqs, err := s.repo.GetLastMessageSequences(ctx, tx, convIDs)
+		if err != nil {
+			return err
+		}
+		clamped := make([]domain.DeliveryReceipt, 0, len(batch))
+		for _, rc := range batch {
+			rc.DeliveredSequence = min(rc.DeliveredSequence, lastSeqs[rc.ConversationID])
+			if rc.DeliveredSequence > 0 {
+				clamped = append(clamped, rc)
+			}
+		}
+		if len(clamped) == 0 {
+			return nil
+		}
+
+		updates, err := s.repo.UpsertDeliveryReceipts(ctx, tx, clamped)
+		if err != nil {
+			return fmt.Errorf("failed to record delivery receipts: %w", err)
+		}
+		if len(updates) == 0 {
+			return nil
+		}
+
+		var updatedIDs []string
+		updated := make(map[string]bool)
+		for _, u := range updates {
+			if !updated[u.ConversationID] {
+				updated[u.ConversationID] = true
+				updatedIDs = append(updatedIDs, u.ConversationID)
+			}
+		}
+		allDelivered, err := s.repo.GetAllDeliveredSequences(ctx, tx, updatedIDs)
+		if err != nil {
+			return err
+		}
+
+		for _, u := range updates {
+			if err := s.emitDeliveryReceipt(ctx, tx, u, allDelivered[u.ConversationID]); err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+}
+
+func (s *Service) emitDeliveryReceipt(ctx context.Context, tx *sql.Tx, u domain.DeliveryUpdate, allDelivered int64) error {
+	event := &conversationv1.DeliveryReceiptUpdatedEvent{
+		ConversationId:       u.ConversationID,
+		UserId:               u.UserID,
+		DeliveredSequence:    u.DeliveredSequence,
+		AllDeliveredSequence: allDelivered,
+	}
+	eventPayload, err := proto.Marshal(event)
+	if err != nil {
+		return err
+	}
+
+	env := &sharedv1.EventEnvelope{
+		EventType:     sharedv1.EventType_EVENT_TYPE_DELIVERY_RECEIPT_UPDATED,
+		SchemaVersion: 1,
+		OccurredAt:    timestamppb.Now(),
+		Payload:       eventPayload,
+	}
+	envPayload, err := proto.Marshal(env)
+	if err != nil {
+		return err
+	}
+
+	return s.repo.InsertOutbox(
+		ctx, tx,
+		"message",
+		u.ConversationID,
+		"DELIVERY_RECEIPT_UPDATED",
+		envPayload,
+	)
+}
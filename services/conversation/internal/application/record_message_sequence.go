@@ -0,0 +1,16 @@
+package application
+
+import (
+	"context"
+
+	"github.com/SARVESHVARADKAR123/RealChat/services/conversation/internal/domain"
+)
+
+// RecordMessageSequence notes that a message with sequence was sent into
+// convID. Unread counts are measured against the highest one recorded.
+func (s *Service) RecordMessageSequence(ctx context.Context, convID string, sequence int64) error {
+	if convID == "" || sequence <= 0 {
+		return domain.ErrInvalidInput
+	}
+	return s.repo.RecordMessageSequence(ctx, convID, sequence)
+}
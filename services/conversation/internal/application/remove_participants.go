@@ -7,6 +7,7 @@ import (
 	conversationv1 "github.com/SARVESHVARADKAR123/RealChat/contracts/gen/go/conversation/v1"
 	sharedv1 "github.com/SARVESHVARADKAR123/RealChat/contracts/gen/go/shared/v1"
 	"github.com/SARVESHVARADKAR123/RealChat/services/conversation/internal/domain"
+	"github.com/google/uuid"
 	"google.golang.org/protobuf/proto"
 	"google.golang.org/protobuf/types/known/timestamppb"
 )
@@ -77,6 +78,7 @@ func (s *Service) RemoveParticipant(
 			ConversationId: cmd.ConversationID,
 			UserId:         cmd.TargetID,
 			Added:          false,
+			ActorUserId:    cmd.ActorID,
 		}
 		eventPayload, err := proto.Marshal(event)
 		if err != nil {
@@ -88,6 +90,7 @@ func (s *Service) RemoveParticipant(
 			SchemaVersion: 1,
 			OccurredAt:    timestamppb.Now(),
 			Payload:       eventPayload,
+			EventId:       uuid.NewString(),
 		}
 		envPayload, err := proto.Marshal(env)
 		if err != nil {
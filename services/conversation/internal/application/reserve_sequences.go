@@ -0,0 +1,37 @@
+package application
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+
+	"github.com/SARVESHVARADKAR123/RealChat/services/conversation/internal/domain"
+)
+
+// maxSequenceReservation caps a single ReserveSequences block.
+const maxSequenceReservation = 10000
+
+// ReserveSequences reserves count message sequences following after, or
+// following everything reserved so far when after is 0, and returns the
+// reserved range. Reserving the same range twice is harmless, which lets
+// the message service retry after a rollback without leaving a gap.
+func (s *Service) ReserveSequences(ctx context.Context, conversationID string, after, count int64) (int64, int64, error) {
+	if conversationID == "" || after < 0 || count <= 0 || count > maxSequenceReservation {
+		return 0, 0, domain.ErrInvalidInput
+	}
+
+	var first, last int64
+	err := s.tx.WithTx(ctx, func(ctx context.Context, tx *sql.Tx) error {
+		var err error
+		first, last, err = s.repo.ReserveSequences(ctx, tx, conversationID, after, count)
+		if errors.Is(err, sql.ErrNoRows) {
+			return domain.ErrConversationNotFound
+		}
+		if err != nil {
+			return fmt.Errorf("failed to reserve sequences for conversation %s: %w", conversationID, err)
+		}
+		return nil
+	})
+	return first, last, err
+}
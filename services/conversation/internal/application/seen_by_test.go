@@ -0,0 +1,133 @@
+package application
+
+import (
+	"context"
+	"errors"
+	"sort"
+	"testing"
+	"time"
+
+	messagev1 "github.com/SARVESHVARADKAR123/RealChat/contracts/gen/go/message/v1"
+	sharedv1 "github.com/SARVESHVARADKAR123/RealChat/contracts/gen/go/shared/v1"
+	"github.com/SARVESHVARADKAR123/RealChat/services/conversation/internal/domain"
+	"github.com/SARVESHVARADKAR123/RealChat/services/conversation/internal/projection"
+	"google.golang.org/protobuf/proto"
+)
+
+// ListReaders orders readers like the query: most recent first.
+func (r *memRepo) ListReaders(ctx context.Context, convID string, sequence int64) ([]domain.Participant, error) {
+	var readers []domain.Participant
+	for _, p := range r.convs[convID].Participants {
+		if p.LastReadSequence >= sequence {
+			readers = append(readers, p)
+		}
+	}
+	sort.Slice(readers, func(i, j int) bool { return readers[i].LastReadAt.After(readers[j].LastReadAt) })
+	return readers, nil
+}
+
+func TestGetSeenBy(t *testing.T) {
+	ctx := context.Background()
+	repo := newMemRepo()
+	repo.addConversation("conv-1", "alice", "bob", "carol")
+	svc := New(repo, noTx{})
+
+	if err := svc.RecordMessageSequence(ctx, "conv-1", 10); err != nil {
+		t.Fatal(err)
+	}
+	for _, read := range []struct {
+		user string
+		seq  int64
+	}{{"alice", 4}, {"bob", 7}, {"carol", 2}} {
+		if err := svc.UpdateReadReceipt(ctx, "conv-1", read.user, read.seq); err != nil {
+			t.Fatal(err)
+		}
+		time.Sleep(time.Millisecond) // distinct read times
+	}
+
+	readers, err := svc.GetSeenBy(ctx, "conv-1", "carol", 4)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(readers) != 2 || readers[0].UserID != "bob" || readers[1].UserID != "alice" {
+		t.Fatalf("readers = %v, want bob then alice", readers)
+	}
+	for _, p := range readers {
+		want := repo.convs["conv-1"].Participants[p.UserID].LastReadAt
+		if p.LastReadAt.IsZero() || !p.LastReadAt.Equal(want) {
+			t.Errorf("%s read at %v, want %v", p.UserID, p.LastReadAt, want)
+		}
+	}
+
+	// Past everyone's read sequence
+	readers, err = svc.GetSeenBy(ctx, "conv-1", "alice", 8)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(readers) != 0 {
+		t.Fatalf("readers = %v, want none", readers)
+	}
+
+	t.Run("Non participant", func(t *testing.T) {
+		_, err := svc.GetSeenBy(ctx, "conv-1", "mallory", 1)
+		if !errors.Is(err, domain.ErrNotParticipant) {
+			t.Fatalf("err = %v, want ErrNotParticipant", err)
+		}
+	})
+
+	t.Run("Invalid sequence", func(t *testing.T) {
+		_, err := svc.GetSeenBy(ctx, "conv-1", "alice", 0)
+		if !errors.Is(err, domain.ErrInvalidInput) {
+			t.Fatalf("err = %v, want ErrInvalidInput", err)
+		}
+	})
+}
+
+func sentEvent(t *testing.T, convID string, seq int64) []byte {
+	t.Helper()
+	payload, err := proto.Marshal(&messagev1.MessageSentEvent{
+		Message: &messagev1.Message{ConversationId: convID, Sequence: seq},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	b, err := proto.Marshal(&sharedv1.EventEnvelope{EventType: sharedv1.EventType_EVENT_TYPE_MESSAGE_SENT, Payload: payload})
+	if err != nil {
+		t.Fatal(err)
+	}
+	return b
+}
+
+func TestProjection_RecordsMessageSequence(t *testing.T) {
+	ctx := context.Background()
+	repo := newMemRepo()
+	repo.addConversation("conv-1", "alice", "bob")
+	svc := New(repo, noTx{})
+	h := &projection.Handler{Recorder: svc}
+
+	// Events can arrive out of order; the last sequence never moves back
+	for _, seq := range []int64{1, 3, 2} {
+		if err := h.Handle(ctx, sentEvent(t, "conv-1", seq)); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if got := repo.last["conv-1"]; got != 3 {
+		t.Fatalf("last message sequence = %d, want 3", got)
+	}
+
+	// Events that can't be recorded are dropped, not retried
+	if err := h.Handle(ctx, sentEvent(t, "", 4)); err != nil {
+		t.Fatalf("invalid event: %v", err)
+	}
+	if err := h.Handle(ctx, []byte("not a proto")); err != nil {
+		t.Fatalf("malformed event: %v", err)
+	}
+
+	// Reads are clamped to what the projection has seen
+	if err := svc.UpdateReadReceipt(ctx, "conv-1", "bob", 9); err != nil {
+		t.Fatal(err)
+	}
+	if got := repo.convs["conv-1"].Participants["bob"].LastReadSequence; got != 3 {
+		t.Fatalf("read sequence = %d, want 3", got)
+	}
+}
@@ -0,0 +1,83 @@
+package application
+
+import (
+	"context"
+	"database/sql"
+	"time"
+
+	conversationv1 "github.com/SARVESHVARADKAR123/RealChat/contracts/gen/go/conversation/v1"
+	sharedv1 "github.com/SARVESHVARADKAR123/RealChat/contracts/gen/go/shared/v1"
+	"github.com/SARVESHVARADKAR123/RealChat/services/conversation/internal/domain"
+	"github.com/google/uuid"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+type SetMessageTTLCommand struct {
+	ConversationID string
+	ActorID        string
+	TTL            time.Duration
+}
+
+func (s *Service) SetMessageTTL(
+	ctx context.Context,
+	cmd SetMessageTTLCommand,
+) (*domain.Conversation, error) {
+
+	var result *domain.Conversation
+
+	err := s.tx.WithTx(ctx, func(ctx context.Context, tx *sql.Tx) error {
+
+		conv, err := s.repo.GetConversationLocked(ctx, tx, cmd.ConversationID)
+		if err != nil {
+			return err
+		}
+
+		if err := conv.SetMessageTTL(cmd.ActorID, cmd.TTL); err != nil {
+			return err
+		}
+
+		if err := s.repo.UpdateMessageTTL(ctx, tx, cmd.ConversationID, cmd.TTL); err != nil {
+			return err
+		}
+
+		event := &conversationv1.ConversationUpdatedEvent{
+			Conversation: eventConversation(conv),
+			ActorUserId:  cmd.ActorID,
+		}
+		eventPayload, err := proto.Marshal(event)
+		if err != nil {
+			return err
+		}
+
+		env := &sharedv1.EventEnvelope{
+			EventType:     sharedv1.EventType_EVENT_TYPE_CONVERSATION_UPDATED,
+			SchemaVersion: 1,
+			OccurredAt:    timestamppb.Now(),
+			Payload:       eventPayload,
+			EventId:       uuid.NewString(),
+		}
+		envPayload, err := proto.Marshal(env)
+		if err != nil {
+			return err
+		}
+
+		if err := s.repo.InsertOutbox(
+			ctx, tx,
+			"message",
+			cmd.ConversationID,
+			"CONVERSATION_UPDATED",
+			envPayload,
+		); err != nil {
+			return err
+		}
+
+		result = conv
+		return s.repo.InvalidateConversation(ctx, cmd.ConversationID)
+	})
+
+	if err != nil {
+		return nil, err
+	}
+	return result, nil
+}
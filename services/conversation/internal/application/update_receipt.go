@@ -18,12 +18,13 @@ func (s *Service) UpdateReadReceipt(
 
 	return s.tx.WithTx(ctx, func(ctx context.Context, tx *sql.Tx) error {
 
-		maxSeq, err := s.repo.GetCurrentMaxSequence(ctx, tx, convID)
+		// Sequences reserved ahead of the last message are not messages yet
+		lastSeqs, err := s.repo.GetLastMessageSequences(ctx, tx, []string{convID})
 		if err != nil {
 			return err
 		}
 
-		if readSequence > maxSeq {
+		if maxSeq := lastSeqs[convID]; readSequence > maxSeq {
 			readSequence = maxSeq
 		}
 
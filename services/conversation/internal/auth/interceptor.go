@@ -19,6 +19,15 @@ const (
 	HeaderRequestID            = "x-request-id"
 )
 
+// internalMethods are called by other services without a user; they are
+// exempt from the x-user-id requirement.
+var internalMethods = map[string]bool{
+	"/realchat.conversation.v1.ConversationApi/GetConversation":        true,
+	"/realchat.conversation.v1.ConversationApi/ReserveSequences":       true,
+	"/realchat.conversation.v1.ConversationApi/RecordDeliveryReceipts": true,
+	"/realchat.conversation.v1.ConversationApi/RecordMessageSequence":  true,
+}
+
 // Interceptor extracts the x-user-id and x-request-id headers and injects them into the context.
 func Interceptor(
 	ctx context.Context,
@@ -29,9 +38,8 @@ func Interceptor(
 
 	md, ok := metadata.FromIncomingContext(ctx)
 	if !ok {
-		// If metadata is missing but it's GetConversation or NextSequence, we allow it (internal call)
-		if info.FullMethod == "/realchat.conversation.v1.ConversationApi/GetConversation" ||
-			info.FullMethod == "/realchat.conversation.v1.ConversationApi/NextSequence" {
+		// If metadata is missing but it's an internal call, we allow it
+		if internalMethods[info.FullMethod] {
 			return handler(ctx, req)
 		}
 		return nil, status.Error(codes.Unauthenticated, "metadata is not provided")
@@ -43,9 +51,8 @@ func Interceptor(
 	if len(userValues) > 0 && userValues[0] != "" {
 		newCtx = context.WithValue(newCtx, UserIDKey, userValues[0])
 	} else {
-		// Exempt GetConversation and NextSequence from mandatory user ID
-		if info.FullMethod == "/realchat.conversation.v1.ConversationApi/GetConversation" ||
-			info.FullMethod == "/realchat.conversation.v1.ConversationApi/NextSequence" {
+		// Exempt internal calls from mandatory user ID
+		if internalMethods[info.FullMethod] {
 			return handler(ctx, req)
 		}
 		return nil, status.Error(codes.Unauthenticated, "x-user-id header is missing")
@@ -11,6 +11,7 @@ type Config struct {
 	DatabaseURL    string
 	KafkaBrokers   string
 	KafkaTopic     string
+	MessageTopic   string
 	RedisAddr      string
 	ServiceName    string
 	ObsHTTPAddr    string
@@ -25,6 +26,7 @@ func Load() *Config {
 		DatabaseURL:    mustEnv("DATABASE_URL"),
 		KafkaBrokers:   mustEnv("KAFKA_BROKERS"),
 		KafkaTopic:     mustEnv("KAFKA_TOPIC"),
+		MessageTopic:   mustEnv("MESSAGE_KAFKA_TOPIC"),
 		RedisAddr:      mustEnv("REDIS_ADDR"),
 		ServiceName:    mustEnv("SERVICE_NAME"),
 		ObsHTTPAddr:    fixPort(mustEnv("HTTP_ADDR")),
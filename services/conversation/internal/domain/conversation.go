@@ -19,12 +19,42 @@ const (
 type Participant struct {
 	UserID string
 	Role   Role
+
+	// LastReadSequence is the highest sequence the participant has read.
+	LastReadSequence int64
+
+	// LastReadAt is when LastReadSequence last moved forward; zero until the
+	// participant first reads.
+	LastReadAt time.Time
+
+	// LastDeliveredSequence is the highest sequence delivered to any of the
+	// participant's devices.
+	LastDeliveredSequence int64
 }
 
+// DeliveryReceipt records the highest sequence handed to one device.
+type DeliveryReceipt struct {
+	ConversationID    string
+	UserID            string
+	DeviceID          string
+	DeliveredSequence int64
+}
+
+// DeliveryUpdate is a participant whose delivered sequence moved forward.
+type DeliveryUpdate struct {
+	ConversationID    string
+	UserID            string
+	DeliveredSequence int64
+}
+
+// MaxMessageTTL caps how long a disappearing message may live.
+const MaxMessageTTL = 365 * 24 * time.Hour
+
 // Conversation Invariants:
 // 1. Membership (Direct): Exactly 2 participants.
 // 2. Membership (Group): At least 1 admin. Cannot remove last admin.
 // 3. Modification: Only admins can Add/Remove participants.
+// 4. Message TTL: Set by group admins, or by either participant of a direct conversation.
 type Conversation struct {
 	ID           string
 	Type         ConversationType
@@ -32,6 +62,14 @@ type Conversation struct {
 	AvatarURL    string
 	CreatedAt    time.Time
 	Participants map[string]Participant
+
+	// MessageTTL is how long messages live after being sent. Zero keeps
+	// them forever.
+	MessageTTL time.Duration
+
+	// UnreadCount is how many messages follow the requesting user's last
+	// read sequence. Only set when listing a user's conversations.
+	UnreadCount int64
 }
 
 func (c *Conversation) CanSend(userID string) error {
@@ -71,3 +109,20 @@ func (c *Conversation) RemoveParticipant(requesterID, targetID string) error {
 	delete(c.Participants, targetID)
 	return nil
 }
+
+func (c *Conversation) SetMessageTTL(requesterID string, ttl time.Duration) error {
+	if ttl < 0 || ttl > MaxMessageTTL || ttl%time.Second != 0 {
+		return ErrInvalidInput
+	}
+
+	req, ok := c.Participants[requesterID]
+	if !ok {
+		return ErrNotParticipant
+	}
+	if c.Type == ConversationGroup && req.Role != RoleAdmin {
+		return ErrNotAdmin
+	}
+
+	c.MessageTTL = ttl
+	return nil
+}
@@ -0,0 +1,104 @@
+package kafka
+
+import (
+	"context"
+	"time"
+
+	"github.com/SARVESHVARADKAR123/RealChat/services/conversation/internal/observability"
+	"github.com/confluentinc/confluent-kafka-go/v2/kafka"
+	"go.opentelemetry.io/otel"
+	"go.uber.org/zap"
+)
+
+// Handler processes one record. A returned error means the record should be
+// retried; records that can never succeed should be logged and dropped.
+type Handler interface {
+	Handle(ctx context.Context, record []byte) error
+}
+
+// Consumer reads records one at a time and commits each offset only after
+// the handler succeeds, so records are processed in partition order at
+// least once.
+type Consumer struct {
+	c       *kafka.Consumer
+	handler Handler
+}
+
+func NewConsumer(brokers, groupID string, topics []string, handler Handler) (*Consumer, error) {
+
+	c, err := kafka.NewConsumer(&kafka.ConfigMap{
+		"bootstrap.servers":  brokers,
+		"group.id":           groupID,
+		"auto.offset.reset":  "earliest",
+		"enable.auto.commit": false,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	if err := c.SubscribeTopics(topics, nil); err != nil {
+		c.Close()
+		return nil, err
+	}
+
+	return &Consumer{c: c, handler: handler}, nil
+}
+
+// Start consumes until ctx is cancelled.
+func (c *Consumer) Start(ctx context.Context) {
+
+	log := observability.GetLogger(ctx)
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		default:
+		}
+
+		msg, err := c.c.ReadMessage(100 * time.Millisecond)
+		if err != nil {
+			if kerr, ok := err.(kafka.Error); ok && kerr.IsTimeout() {
+				continue
+			}
+			log.Error("kafka read error", zap.Error(err))
+			continue
+		}
+
+		recordCtx := otel.GetTextMapPropagator().Extract(ctx, kafkaHeaderCarrier{headers: &msg.Headers})
+		if !c.handle(recordCtx, msg.Value) {
+			return
+		}
+
+		if _, err := c.c.CommitMessage(msg); err != nil {
+			log.Error("kafka commit failed", zap.Error(err))
+		}
+	}
+}
+
+// handle retries the handler with backoff until it succeeds. It returns
+// false if ctx is cancelled first.
+func (c *Consumer) handle(ctx context.Context, record []byte) bool {
+	log := observability.GetLogger(ctx)
+	backoff := 100 * time.Millisecond
+
+	for {
+		err := c.handler.Handle(ctx, record)
+		if err == nil {
+			return true
+		}
+		log.Error("kafka handler failed, retrying", zap.Error(err), zap.Duration("backoff", backoff))
+
+		select {
+		case <-ctx.Done():
+			return false
+		case <-time.After(backoff):
+		}
+		if backoff < 10*time.Second {
+			backoff *= 2
+		}
+	}
+}
+
+func (c *Consumer) Close() error {
+	return c.c.Close()
+}
@@ -0,0 +1,55 @@
+package projection
+
+import (
+	"context"
+	"errors"
+
+	messagev1 "github.com/SARVESHVARADKAR123/RealChat/contracts/gen/go/message/v1"
+	sharedv1 "github.com/SARVESHVARADKAR123/RealChat/contracts/gen/go/shared/v1"
+	"github.com/SARVESHVARADKAR123/RealChat/services/conversation/internal/domain"
+	"github.com/SARVESHVARADKAR123/RealChat/services/conversation/internal/observability"
+	"go.uber.org/zap"
+	"google.golang.org/protobuf/proto"
+)
+
+// Recorder records the latest message sequence of a conversation.
+type Recorder interface {
+	RecordMessageSequence(ctx context.Context, convID string, sequence int64) error
+}
+
+// Handler follows MessageSent events so the conversation service knows the
+// last message of each conversation, which sequence reservation alone does
+// not tell it.
+type Handler struct {
+	Recorder Recorder
+}
+
+// Handle applies one message event. Other event types are skipped and
+// malformed events dropped; other failures are returned for the consumer to
+// retry.
+func (h *Handler) Handle(ctx context.Context, record []byte) error {
+	log := observability.GetLogger(ctx)
+
+	var env sharedv1.EventEnvelope
+	if err := proto.Unmarshal(record, &env); err != nil {
+		log.Error("projection: error unmarshaling event", zap.Error(err))
+		return nil
+	}
+	if env.GetEventType() != sharedv1.EventType_EVENT_TYPE_MESSAGE_SENT {
+		return nil
+	}
+
+	var event messagev1.MessageSentEvent
+	if err := proto.Unmarshal(env.GetPayload(), &event); err != nil {
+		log.Error("projection: error unmarshaling message event", zap.Error(err))
+		return nil
+	}
+
+	msg := event.GetMessage()
+	err := h.Recorder.RecordMessageSequence(ctx, msg.GetConversationId(), msg.GetSequence())
+	if errors.Is(err, domain.ErrInvalidInput) {
+		log.Warn("projection: dropping event", zap.String("message_id", msg.GetMessageId()), zap.Error(err))
+		return nil
+	}
+	return err
+}
@@ -3,9 +3,11 @@ package postgres
 import (
 	"context"
 	"database/sql"
+	"time"
 
 	"github.com/SARVESHVARADKAR123/RealChat/services/conversation/internal/cache"
 	"github.com/SARVESHVARADKAR123/RealChat/services/conversation/internal/domain"
+	"github.com/lib/pq"
 )
 
 type Repository struct {
@@ -53,6 +55,21 @@ func (r *Repository) DeleteParticipant(
 	return err
 }
 
+func (r *Repository) UpdateMessageTTL(
+	ctx context.Context,
+	tx *sql.Tx,
+	convID string,
+	ttl time.Duration,
+) error {
+	q := r.getter(tx)
+	_, err := q.ExecContext(ctx, `
+		UPDATE conversations
+		SET message_ttl_seconds = $2, updated_at = now()
+		WHERE id = $1
+	`, convID, int64(ttl/time.Second))
+	return err
+}
+
 func (r *Repository) UpdateLastReadSequence(
 	ctx context.Context,
 	tx *sql.Tx,
@@ -64,7 +81,8 @@ func (r *Repository) UpdateLastReadSequence(
 	q := r.getter(tx)
 	_, err := q.ExecContext(ctx, `
 		UPDATE conversation_participants
-		SET last_read_sequence = GREATEST(last_read_sequence, $3)
+		SET last_read_at = CASE WHEN $3 > last_read_sequence THEN now() ELSE last_read_at END,
+		    last_read_sequence = GREATEST(last_read_sequence, $3)
 		WHERE conversation_id = $1
 		  AND user_id = $2
 	`, convID, userID, sequence)
@@ -72,33 +90,180 @@ func (r *Repository) UpdateLastReadSequence(
 	return err
 }
 
-func (r *Repository) GetCurrentMaxSequence(
+// ListReaders returns the participants of convID who have read up to
+// sequence, most recent reader first.
+func (r *Repository) ListReaders(
 	ctx context.Context,
-	tx *sql.Tx,
 	convID string,
-) (int64, error) {
+	sequence int64,
+) ([]domain.Participant, error) {
+	rows, err := r.DB.QueryContext(ctx, `
+		SELECT user_id, role, last_read_sequence, last_read_at, last_delivered_sequence
+		FROM conversation_participants
+		WHERE conversation_id = $1
+		  AND last_read_sequence >= $2
+		ORDER BY last_read_at DESC NULLS LAST, user_id
+	`, convID, sequence)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
 
-	var maxSeq sql.NullInt64
+	var readers []domain.Participant
+	for rows.Next() {
+		var p domain.Participant
+		var readAt sql.NullTime
+		if err := rows.Scan(&p.UserID, &p.Role, &p.LastReadSequence, &readAt, &p.LastDeliveredSequence); err != nil {
+			return nil, err
+		}
+		p.LastReadAt = readAt.Time
+		readers = append(readers, p)
+	}
+	return readers, rows.Err()
+}
 
-	q := r.getter(tx)
-	err := q.QueryRowContext(ctx, `
-		SELECT next_sequence - 1
-		FROM conversation_sequences
+// RecordMessageSequence raises the conversation's last message sequence.
+// Events may arrive more than once or late, so it never moves backwards.
+func (r *Repository) RecordMessageSequence(
+	ctx context.Context,
+	convID string,
+	sequence int64,
+) error {
+	_, err := r.DB.ExecContext(ctx, `
+		UPDATE conversation_sequences
+		SET last_message_sequence = GREATEST(last_message_sequence, $2)
 		WHERE conversation_id = $1
-	`, convID).Scan(&maxSeq)
+	`, convID, sequence)
+	return err
+}
+
+// UpsertDeliveryReceipts raises each device's delivered sequence and then
+// each participant's. Receipts for users who are not participants are
+// ignored. It returns the participants whose delivered sequence moved
+// forward. Receipts must be unique per device and already clamped to the
+// conversation's last message sequence.
+func (r *Repository) UpsertDeliveryReceipts(
+	ctx context.Context,
+	tx *sql.Tx,
+	receipts []domain.DeliveryReceipt,
+) ([]domain.DeliveryUpdate, error) {
+	convIDs := make([]string, len(receipts))
+	userIDs := make([]string, len(receipts))
+	deviceIDs := make([]string, len(receipts))
+	sequences := make([]int64, len(receipts))
+	for i, rc := range receipts {
+		convIDs[i] = rc.ConversationID
+		userIDs[i] = rc.UserID
+		deviceIDs[i] = rc.DeviceID
+		sequences[i] = rc.DeliveredSequence
+	}
 
+	q := r.getter(tx)
+	rows, err := q.QueryContext(ctx, `
+		WITH receipts AS (
+			SELECT i.conversation_id, i.user_id, i.device_id, i.delivered_sequence
+			FROM unnest($1::TEXT[], $2::TEXT[], $3::TEXT[], $4::BIGINT[])
+			     AS i (conversation_id, user_id, device_id, delivered_sequence)
+			JOIN conversation_participants p
+			  ON p.conversation_id = i.conversation_id AND p.user_id = i.user_id
+		),
+		devices AS (
+			INSERT INTO delivery_receipts (conversation_id, user_id, device_id, delivered_sequence)
+			SELECT conversation_id, user_id, device_id, delivered_sequence
+			FROM receipts
+			WHERE delivered_sequence > 0
+			ON CONFLICT (conversation_id, user_id, device_id) DO UPDATE
+			SET delivered_sequence = EXCLUDED.delivered_sequence, updated_at = now()
+			WHERE delivery_receipts.delivered_sequence < EXCLUDED.delivered_sequence
+		)
+		UPDATE conversation_participants p
+		SET last_delivered_sequence = r.delivered_sequence
+		FROM (
+			SELECT conversation_id, user_id, MAX(delivered_sequence) AS delivered_sequence
+			FROM receipts
+			GROUP BY conversation_id, user_id
+		) r
+		WHERE p.conversation_id = r.conversation_id
+		  AND p.user_id = r.user_id
+		  AND p.last_delivered_sequence < r.delivered_sequence
+		RETURNING p.conversation_id, p.user_id, p.last_delivered_sequence
+	`, pq.Array(convIDs), pq.Array(userIDs), pq.Array(deviceIDs), pq.Array(sequences))
 	if err != nil {
-		if err == sql.ErrNoRows {
-			return 0, nil
+		return nil, err
+	}
+	defer rows.Close()
+
+	var updates []domain.DeliveryUpdate
+	for rows.Next() {
+		var u domain.DeliveryUpdate
+		if err := rows.Scan(&u.ConversationID, &u.UserID, &u.DeliveredSequence); err != nil {
+			return nil, err
+		}
+		updates = append(updates, u)
+	}
+	return updates, rows.Err()
+}
+
+// GetAllDeliveredSequences returns, per conversation, the highest sequence
+// every participant has received. A read message counts as received.
+func (r *Repository) GetAllDeliveredSequences(
+	ctx context.Context,
+	tx *sql.Tx,
+	convIDs []string,
+) (map[string]int64, error) {
+	q := r.getter(tx)
+	rows, err := q.QueryContext(ctx, `
+		SELECT conversation_id, MIN(GREATEST(last_delivered_sequence, last_read_sequence))
+		FROM conversation_participants
+		WHERE conversation_id = ANY($1)
+		GROUP BY conversation_id
+	`, pq.Array(convIDs))
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	sequences := make(map[string]int64, len(convIDs))
+	for rows.Next() {
+		var convID string
+		var seq int64
+		if err := rows.Scan(&convID, &seq); err != nil {
+			return nil, err
 		}
-		return 0, err
+		sequences[convID] = seq
 	}
+	return sequences, rows.Err()
+}
 
-	if !maxSeq.Valid {
-		return 0, nil
+// GetLastMessageSequences returns the sequence of the latest message sent
+// into each conversation. next_sequence runs ahead of it by whatever block
+// the message service has reserved, so receipts are clamped against this.
+func (r *Repository) GetLastMessageSequences(
+	ctx context.Context,
+	tx *sql.Tx,
+	convIDs []string,
+) (map[string]int64, error) {
+	q := r.getter(tx)
+	rows, err := q.QueryContext(ctx, `
+		SELECT conversation_id, last_message_sequence
+		FROM conversation_sequences
+		WHERE conversation_id = ANY($1)
+	`, pq.Array(convIDs))
+	if err != nil {
+		return nil, err
 	}
+	defer rows.Close()
 
-	return maxSeq.Int64, nil
+	sequences := make(map[string]int64, len(convIDs))
+	for rows.Next() {
+		var convID string
+		var seq int64
+		if err := rows.Scan(&convID, &seq); err != nil {
+			return nil, err
+		}
+		sequences[convID] = seq
+	}
+	return sequences, rows.Err()
 }
 
 func (r *Repository) GetConversationLocked(
@@ -142,9 +307,11 @@ func (r *Repository) ListConversationsByUser(
 	userID string,
 ) ([]*domain.Conversation, error) {
 	rows, err := r.DB.QueryContext(ctx, `
-		SELECT c.id, c.display_name, c.avatar_url, c.type, c.created_at
+		SELECT c.id, c.display_name, c.avatar_url, c.type, c.created_at, c.message_ttl_seconds,
+		       GREATEST(COALESCE(s.last_message_sequence, 0) - cp.last_read_sequence, 0)
 		FROM conversations c
 		JOIN conversation_participants cp ON c.id = cp.conversation_id
+		LEFT JOIN conversation_sequences s ON s.conversation_id = c.id
 		WHERE cp.user_id = $1
 		ORDER BY c.updated_at DESC
 	`, userID)
@@ -160,17 +327,21 @@ func (r *Repository) ListConversationsByUser(
 	for rows.Next() {
 		c := &domain.Conversation{}
 		var displayName, avatarURL sql.NullString
+		var ttlSeconds int64
 		if err := rows.Scan(
 			&c.ID,
 			&displayName,
 			&avatarURL,
 			&c.Type,
 			&c.CreatedAt,
+			&ttlSeconds,
+			&c.UnreadCount,
 		); err != nil {
 			return nil, err
 		}
 		c.DisplayName = displayName.String
 		c.AvatarURL = avatarURL.String
+		c.MessageTTL = time.Duration(ttlSeconds) * time.Second
 		c.Participants = make(map[string]domain.Participant)
 		conversations = append(conversations, c)
 		convIDs = append(convIDs, c.ID)
@@ -183,10 +354,10 @@ func (r *Repository) ListConversationsByUser(
 
 	// Fetch all participants for these conversations
 	pRows, err := r.DB.QueryContext(ctx, `
-		SELECT conversation_id, user_id, role
+		SELECT conversation_id, user_id, role, last_read_sequence, last_read_at, last_delivered_sequence
 		FROM conversation_participants
 		WHERE conversation_id = ANY($1)
-	`, convIDs)
+	`, pq.Array(convIDs))
 	if err != nil {
 		return nil, err
 	}
@@ -195,9 +366,11 @@ func (r *Repository) ListConversationsByUser(
 	for pRows.Next() {
 		var convID string
 		var p domain.Participant
-		if err := pRows.Scan(&convID, &p.UserID, &p.Role); err != nil {
+		var readAt sql.NullTime
+		if err := pRows.Scan(&convID, &p.UserID, &p.Role, &p.LastReadSequence, &readAt, &p.LastDeliveredSequence); err != nil {
 			return nil, err
 		}
+		p.LastReadAt = readAt.Time
 		if c, ok := convMap[convID]; ok {
 			c.Participants[p.UserID] = p
 		}
@@ -223,7 +396,7 @@ func (r *Repository) fetchConversation(
 	forUpdate bool,
 ) (*domain.Conversation, error) {
 	query := `
-		SELECT id, type, display_name, avatar_url, created_at
+		SELECT id, type, display_name, avatar_url, created_at, message_ttl_seconds
 		FROM conversations
 		WHERE id = $1
 	`
@@ -236,12 +409,14 @@ func (r *Repository) fetchConversation(
 	// 1. Get Conversation
 	var conv domain.Conversation
 	var displayName, avatarURL sql.NullString
+	var ttlSeconds int64
 	err := q.QueryRowContext(ctx, query, convID).Scan(
 		&conv.ID,
 		&conv.Type,
 		&displayName,
 		&avatarURL,
 		&conv.CreatedAt,
+		&ttlSeconds,
 	)
 	if err != nil {
 		if err == sql.ErrNoRows {
@@ -251,10 +426,11 @@ func (r *Repository) fetchConversation(
 	}
 	conv.DisplayName = displayName.String
 	conv.AvatarURL = avatarURL.String
+	conv.MessageTTL = time.Duration(ttlSeconds) * time.Second
 
 	// 2. Get Participants
 	rows, err := q.QueryContext(ctx, `
-		SELECT user_id, role
+		SELECT user_id, role, last_read_sequence, last_read_at, last_delivered_sequence
 		FROM conversation_participants
 		WHERE conversation_id = $1
 	`, convID)
@@ -266,9 +442,11 @@ func (r *Repository) fetchConversation(
 	conv.Participants = make(map[string]domain.Participant)
 	for rows.Next() {
 		var p domain.Participant
-		if err := rows.Scan(&p.UserID, &p.Role); err != nil {
+		var readAt sql.NullTime
+		if err := rows.Scan(&p.UserID, &p.Role, &p.LastReadSequence, &readAt, &p.LastDeliveredSequence); err != nil {
 			return nil, err
 		}
+		p.LastReadAt = readAt.Time
 		conv.Participants[p.UserID] = p
 	}
 
@@ -315,8 +493,7 @@ func (r *Repository) InitSequence(
 	tx *sql.Tx,
 	id string,
 ) error {
-	// Starts at 0; NextSequence increments first then returns the new value,
-	// so the first message gets sequence number 1.
+	// Starts at 0, so the first reserved block starts at sequence number 1.
 	q := r.getter(tx)
 	_, err := q.ExecContext(ctx, `
 		INSERT INTO conversation_sequences (conversation_id, next_sequence)
@@ -325,23 +502,32 @@ func (r *Repository) InitSequence(
 	return err
 }
 
-func (r *Repository) NextSequence(
+// ReserveSequences raises the sequence high-water mark to cover count
+// sequences after after and returns them. With after 0 the block starts at
+// the high-water mark, so a caller that lost track of its block can never
+// be handed sequences reserved before.
+func (r *Repository) ReserveSequences(
 	ctx context.Context,
 	tx *sql.Tx,
 	convID string,
-) (int64, error) {
-	var next int64
+	after, count int64,
+) (int64, int64, error) {
+	var base int64
 	q := r.getter(tx)
 	err := q.QueryRowContext(ctx, `
 		UPDATE conversation_sequences
-		SET next_sequence = next_sequence + 1
+		SET reserved_from = COALESCE(reserved_from, next_sequence),
+		    next_sequence = GREATEST(
+		        next_sequence,
+		        CASE WHEN $2::BIGINT > 0 THEN $2::BIGINT ELSE next_sequence END + $3::BIGINT
+		    )
 		WHERE conversation_id = $1
-		RETURNING next_sequence
-	`, convID).Scan(&next)
+		RETURNING CASE WHEN $2::BIGINT > 0 THEN $2::BIGINT ELSE next_sequence - $3::BIGINT END
+	`, convID, after, count).Scan(&base)
 	if err != nil {
-		return 0, err
+		return 0, 0, err
 	}
-	return next, nil
+	return base + 1, base + count, nil
 }
 
 func (r *Repository) InsertOutbox(
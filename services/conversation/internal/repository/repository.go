@@ -3,6 +3,7 @@ package repository
 import (
 	"context"
 	"database/sql"
+	"time"
 
 	"github.com/SARVESHVARADKAR123/RealChat/services/conversation/internal/domain"
 )
@@ -23,14 +24,21 @@ type Repository interface {
 	InvalidateConversation(ctx context.Context, convID string) error
 
 	InitSequence(ctx context.Context, tx *sql.Tx, id string) error
-	NextSequence(ctx context.Context, tx *sql.Tx, convID string) (int64, error)
+	ReserveSequences(ctx context.Context, tx *sql.Tx, convID string, after, count int64) (first, last int64, err error)
 
 	ListConversationsByUser(ctx context.Context, userID string) ([]*domain.Conversation, error)
+	UpdateMessageTTL(ctx context.Context, tx *sql.Tx, convID string, ttl time.Duration) error
+
 	InsertParticipant(ctx context.Context, tx *sql.Tx, convID, userID string, role domain.Role) error
 	DeleteParticipant(ctx context.Context, tx *sql.Tx, convID, userID string) error
 
 	UpdateLastReadSequence(ctx context.Context, tx *sql.Tx, convID, userID string, seq int64) error
-	GetCurrentMaxSequence(ctx context.Context, tx *sql.Tx, convID string) (int64, error)
+	ListReaders(ctx context.Context, convID string, sequence int64) ([]domain.Participant, error)
+	RecordMessageSequence(ctx context.Context, convID string, sequence int64) error
+	GetLastMessageSequences(ctx context.Context, tx *sql.Tx, convIDs []string) (map[string]int64, error)
+
+	UpsertDeliveryReceipts(ctx context.Context, tx *sql.Tx, receipts []domain.DeliveryReceipt) ([]domain.DeliveryUpdate, error)
+	GetAllDeliveredSequences(ctx context.Context, tx *sql.Tx, convIDs []string) (map[string]int64, error)
 
 	InsertOutbox(ctx context.Context, tx *sql.Tx, aggregateType, aggregateID, eventType string, payload []byte) error
 }
@@ -3,6 +3,7 @@ package grpc
 import (
 	"context"
 	"log/slog"
+	"time"
 
 	conversationv1 "github.com/SARVESHVARADKAR123/RealChat/contracts/gen/go/conversation/v1"
 	"github.com/SARVESHVARADKAR123/RealChat/services/conversation/internal/application"
@@ -60,10 +61,7 @@ func (s *Server) toProtoConversation(conv *domain.Conversation) *conversationv1.
 
 	for uid, p := range conv.Participants {
 		pbParticipants = append(pbParticipants, uid)
-		pbParticipantsWithRoles = append(pbParticipantsWithRoles, &conversationv1.Participant{
-			UserId: uid,
-			Role:   domainRoleToProto(p.Role),
-		})
+		pbParticipantsWithRoles = append(pbParticipantsWithRoles, toProtoParticipant(p))
 	}
 
 	return &conversationv1.Conversation{
@@ -74,7 +72,22 @@ func (s *Server) toProtoConversation(conv *domain.Conversation) *conversationv1.
 		CreatedAt:             timestamppb.New(conv.CreatedAt),
 		ParticipantUserIds:    pbParticipants,
 		ParticipantsWithRoles: pbParticipantsWithRoles,
+		MessageTtlSeconds:     int64(conv.MessageTTL / time.Second),
+		UnreadCount:           conv.UnreadCount,
+	}
+}
+
+func toProtoParticipant(p domain.Participant) *conversationv1.Participant {
+	pb := &conversationv1.Participant{
+		UserId:                p.UserID,
+		Role:                  domainRoleToProto(p.Role),
+		LastReadSequence:      p.LastReadSequence,
+		LastDeliveredSequence: p.LastDeliveredSequence,
 	}
+	if !p.LastReadAt.IsZero() {
+		pb.LastReadAt = timestamppb.New(p.LastReadAt)
+	}
+	return pb
 }
 
 func (s *Server) CreateConversation(
@@ -207,6 +220,33 @@ func (s *Server) RemoveParticipant(
 	return &conversationv1.RemoveParticipantResponse{}, nil
 }
 
+func (s *Server) SetMessageTTL(
+	ctx context.Context,
+	req *conversationv1.SetMessageTTLRequest,
+) (*conversationv1.SetMessageTTLResponse, error) {
+
+	userID, err := auth.GetUserID(ctx)
+	if err != nil {
+		return nil, status.Error(codes.Unauthenticated, err.Error())
+	}
+	if req.ActorUserId != userID {
+		return nil, status.Error(codes.PermissionDenied, errActorMismatch)
+	}
+
+	conv, err := s.app.SetMessageTTL(ctx, application.SetMessageTTLCommand{
+		ConversationID: req.ConversationId,
+		ActorID:        req.ActorUserId,
+		TTL:            time.Duration(req.MessageTtlSeconds) * time.Second,
+	})
+	if err != nil {
+		return nil, MapError(err)
+	}
+
+	return &conversationv1.SetMessageTTLResponse{
+		Conversation: s.toProtoConversation(conv),
+	}, nil
+}
+
 func (s *Server) UpdateReadReceipt(
 	ctx context.Context,
 	req *conversationv1.UpdateReadReceiptRequest,
@@ -233,22 +273,86 @@ func (s *Server) UpdateReadReceipt(
 	return &conversationv1.UpdateReadReceiptResponse{}, nil
 }
 
-// NextSequence is an internal RPC called by the message service to atomically
-// claim the next message sequence number for a conversation.
+func (s *Server) GetSeenBy(
+	ctx context.Context,
+	req *conversationv1.GetSeenByRequest,
+) (*conversationv1.GetSeenByResponse, error) {
+
+	userID, err := auth.GetUserID(ctx)
+	if err != nil {
+		return nil, status.Error(codes.Unauthenticated, err.Error())
+	}
+
+	readers, err := s.app.GetSeenBy(ctx, req.ConversationId, userID, req.Sequence)
+	if err != nil {
+		return nil, MapError(err)
+	}
+
+	resp := &conversationv1.GetSeenByResponse{
+		Readers: make([]*conversationv1.Participant, 0, len(readers)),
+	}
+	for _, p := range readers {
+		resp.Readers = append(resp.Readers, toProtoParticipant(p))
+	}
+	return resp, nil
+}
+
+// ReserveSequences is an internal RPC called by the message service to claim
+// a block of message sequence numbers for a conversation.
 // No user-auth check — this is a trusted internal peer call.
-func (s *Server) NextSequence(
+func (s *Server) ReserveSequences(
 	ctx context.Context,
-	req *conversationv1.NextSequenceRequest,
-) (*conversationv1.NextSequenceResponse, error) {
+	req *conversationv1.ReserveSequencesRequest,
+) (*conversationv1.ReserveSequencesResponse, error) {
 
 	if req.ConversationId == "" {
 		return nil, status.Error(codes.InvalidArgument, "conversation_id is required")
 	}
 
-	seq, err := s.app.NextSequence(ctx, req.ConversationId)
+	first, last, err := s.app.ReserveSequences(ctx, req.ConversationId, req.AfterSequence, req.Count)
 	if err != nil {
 		return nil, MapError(err)
 	}
 
-	return &conversationv1.NextSequenceResponse{Sequence: seq}, nil
+	return &conversationv1.ReserveSequencesResponse{FirstSequence: first, LastSequence: last}, nil
+}
+
+// RecordDeliveryReceipts is an internal RPC called by the delivery service
+// with the sequences it has handed to each device.
+// No user-auth check — this is a trusted internal peer call.
+func (s *Server) RecordDeliveryReceipts(
+	ctx context.Context,
+	req *conversationv1.RecordDeliveryReceiptsRequest,
+) (*conversationv1.RecordDeliveryReceiptsResponse, error) {
+
+	receipts := make([]domain.DeliveryReceipt, 0, len(req.Receipts))
+	for _, rc := range req.Receipts {
+		receipts = append(receipts, domain.DeliveryReceipt{
+			ConversationID:    rc.ConversationId,
+			UserID:            rc.UserId,
+			DeviceID:          rc.DeviceId,
+			DeliveredSequence: rc.DeliveredSequence,
+		})
+	}
+
+	if err := s.app.RecordDeliveryReceipts(ctx, receipts); err != nil {
+		return nil, MapError(err)
+	}
+
+	return &conversationv1.RecordDeliveryReceiptsResponse{}, nil
+}
+
+// RecordMessageSequence is an internal RPC called by the message service
+// after writing messages that raise no MessageSent event, such as imports.
+// No user-auth check — this is a trusted internal peer call.
+func (s *Server) RecordMessageSequence(
+	ctx context.Context,
+	req *conversationv1.RecordMessageSequenceRequest,
+) (*conversationv1.RecordMessageSequenceResponse, error) {
+
+	if err := s.app.RecordMessageSequence(ctx, req.ConversationId, req.Sequence); err != nil {
+		return nil, MapError(err)
+	}
+
+	return &conversationv1.RecordMessageSequenceResponse{}, nil
 }
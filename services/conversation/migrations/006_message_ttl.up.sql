@@ -0,0 +1,2 @@
+-- Zero disables disappearing messages
+ALTER TABLE conversations ADD COLUMN message_ttl_seconds BIGINT NOT NULL DEFAULT 0;
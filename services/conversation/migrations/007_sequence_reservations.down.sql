@@ -0,0 +1 @@
+ALTER TABLE conversation_sequences DROP COLUMN IF EXISTS reserved_from;
@@ -0,0 +1,4 @@
+-- The last sequence handed out by NextSequence when the message service made
+-- its first reservation. First reservations start after it, so retrying one
+-- returns the same block.
+ALTER TABLE conversation_sequences ADD COLUMN reserved_from BIGINT;
@@ -0,0 +1,2 @@
+ALTER TABLE conversation_participants DROP COLUMN IF EXISTS last_delivered_sequence;
+DROP TABLE IF EXISTS delivery_receipts;
@@ -0,0 +1,12 @@
+-- Highest sequence delivered to each of a user's devices.
+CREATE TABLE delivery_receipts (
+    conversation_id    TEXT NOT NULL REFERENCES conversations (id) ON DELETE CASCADE,
+    user_id            TEXT NOT NULL,
+    device_id          TEXT NOT NULL,
+    delivered_sequence BIGINT NOT NULL DEFAULT 0,
+    updated_at         TIMESTAMPTZ NOT NULL DEFAULT now(),
+    PRIMARY KEY (conversation_id, user_id, device_id)
+);
+
+-- Highest sequence delivered to any of the participant's devices.
+ALTER TABLE conversation_participants ADD COLUMN last_delivered_sequence BIGINT NOT NULL DEFAULT 0;
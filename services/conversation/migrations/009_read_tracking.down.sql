@@ -0,0 +1,2 @@
+ALTER TABLE conversation_sequences DROP COLUMN IF EXISTS last_message_sequence;
+ALTER TABLE conversation_participants DROP COLUMN IF EXISTS last_read_at;
@@ -0,0 +1,13 @@
+-- When each participant's read sequence last moved forward.
+ALTER TABLE conversation_participants ADD COLUMN last_read_at TIMESTAMPTZ;
+
+-- Sequence of the latest message sent, kept up to date from MessageSent
+-- events. next_sequence runs ahead of it once the message service reserves
+-- sequences in blocks.
+ALTER TABLE conversation_sequences ADD COLUMN last_message_sequence BIGINT NOT NULL DEFAULT 0;
+
+-- Sequences handed out by NextSequence were all used. For conversations that
+-- already reserve blocks, the hand-over point is a lower bound until their
+-- next message.
+UPDATE conversation_sequences
+SET last_message_sequence = COALESCE(reserved_from, next_sequence);
@@ -14,6 +14,7 @@ import (
 	"github.com/SARVESHVARADKAR123/RealChat/services/delivery/internal/membership"
 	"github.com/SARVESHVARADKAR123/RealChat/services/delivery/internal/observability"
 	"github.com/SARVESHVARADKAR123/RealChat/services/delivery/internal/presencewatcher"
+	"github.com/SARVESHVARADKAR123/RealChat/services/delivery/internal/receipts"
 	"github.com/SARVESHVARADKAR123/RealChat/services/delivery/internal/router"
 	"github.com/SARVESHVARADKAR123/RealChat/services/delivery/internal/server"
 	"github.com/SARVESHVARADKAR123/RealChat/services/delivery/internal/websocket"
@@ -69,7 +70,12 @@ func main() {
 	pw.Start(ctx)
 
 	rtr.Subscribe(ctx, disp.DeliverRemote)
-	wsHandler := websocket.NewHandler(reg, presenceClient, convClient, msgClient, instanceID)
+
+	// Delivery receipts are coalesced and written periodically
+	receiptBatcher := receipts.NewBatcher(convClient, cfg.ReceiptFlushInterval)
+	go receiptBatcher.Start(ctx)
+
+	wsHandler := websocket.NewHandler(reg, presenceClient, convClient, msgClient, receiptBatcher, instanceID)
 
 	// Kafka Consumer
 	consumer := initKafka(ctx, cfg, disp, log)
@@ -82,7 +88,7 @@ func main() {
 	startServers(cfg, obsSrv, wsSrv, log)
 
 	<-ctx.Done()
-	performGracefulShutdown(obsSrv, wsSrv, reg, log)
+	performGracefulShutdown(obsSrv, wsSrv, reg, receiptBatcher, log)
 }
 
 func setupSignalHandler(log *zap.Logger) (context.Context, context.CancelFunc) {
@@ -175,7 +181,7 @@ func startServers(cfg *config.Config, obsSrv *http.Server, wsSrv *server.Server,
 	}()
 }
 
-func performGracefulShutdown(obs *http.Server, ws *server.Server, reg *websocket.Registry, log *zap.Logger) {
+func performGracefulShutdown(obs *http.Server, ws *server.Server, reg *websocket.Registry, rb *receipts.Batcher, log *zap.Logger) {
 	log.Info("shutting down...")
 	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
 	defer cancel()
@@ -187,5 +193,6 @@ func performGracefulShutdown(obs *http.Server, ws *server.Server, reg *websocket
 		log.Error("error during observability server shutdown", zap.Error(err))
 	}
 	reg.CloseAll()
+	rb.Flush(ctx)
 	log.Info("shutdown complete, exiting")
 }
@@ -4,6 +4,7 @@ import (
 	"log"
 	"os"
 	"strings"
+	"time"
 )
 
 type Config struct {
@@ -21,6 +22,10 @@ type Config struct {
 	TracingEnabled      bool
 	JaegerURL           string
 	ObsHTTPAddr         string
+
+	// ReceiptFlushInterval is how often delivery receipts are written to
+	// the conversation service.
+	ReceiptFlushInterval time.Duration
 }
 
 func Load() *Config {
@@ -39,6 +44,8 @@ func Load() *Config {
 		TracingEnabled:      getEnvBool("TRACING_ENABLED", false),
 		JaegerURL:           getEnv("JAEGER_URL", "http://jaeger:14268/api/traces"),
 		ObsHTTPAddr:         fixPort(mustEnv("HTTP_ADDR")),
+
+		ReceiptFlushInterval: getEnvDuration("RECEIPT_FLUSH_INTERVAL", time.Second),
 	}
 }
 
@@ -71,3 +78,15 @@ func getEnv(key, fallback string) string {
 	}
 	return fallback
 }
+
+func getEnvDuration(key string, fallback time.Duration) time.Duration {
+	v := os.Getenv(key)
+	if v == "" {
+		return fallback
+	}
+	d, err := time.ParseDuration(v)
+	if err != nil {
+		log.Fatalf("invalid %s: %v", key, err)
+	}
+	return d
+}
@@ -50,13 +50,52 @@ func (d *Dispatcher) Handle(ctx context.Context, record []byte) {
 	switch env.GetEventType() {
 	case sharedv1.EventType_EVENT_TYPE_MESSAGE_SENT,
 		sharedv1.EventType_EVENT_TYPE_MESSAGE_DELETED,
+		sharedv1.EventType_EVENT_TYPE_MESSAGE_EDITED,
+		sharedv1.EventType_EVENT_TYPE_REACTION_CHANGED,
+		sharedv1.EventType_EVENT_TYPE_MESSAGE_PIN_CHANGED,
+		sharedv1.EventType_EVENT_TYPE_POLL_TALLY_CHANGED,
 		sharedv1.EventType_EVENT_TYPE_READ_RECEIPT_UPDATED,
+		sharedv1.EventType_EVENT_TYPE_DELIVERY_RECEIPT_UPDATED,
 		sharedv1.EventType_EVENT_TYPE_MEMBERSHIP_CHANGED,
-		sharedv1.EventType_EVENT_TYPE_CONVERSATION_CREATED:
+		sharedv1.EventType_EVENT_TYPE_CONVERSATION_CREATED,
+		sharedv1.EventType_EVENT_TYPE_CONVERSATION_UPDATED:
 		d.handleEvent(ctx, &env, record)
+	case sharedv1.EventType_EVENT_TYPE_DRAFT_UPDATED:
+		d.handleDraft(ctx, &env, record)
 	}
 }
 
+// draftAuthor returns the author of a draft event and the device that wrote
+// the draft, which already has it.
+func draftAuthor(env *sharedv1.EventEnvelope) (userID, deviceID string, err error) {
+	var event messagev1.DraftUpdatedEvent
+	if err := proto.Unmarshal(env.GetPayload(), &event); err != nil {
+		return "", "", err
+	}
+	return event.GetDraft().GetUserId(), event.GetDraft().GetDeviceId(), nil
+}
+
+// handleDraft delivers a draft change to the author's other devices only.
+func (d *Dispatcher) handleDraft(ctx context.Context, env *sharedv1.EventEnvelope, rawPayload []byte) {
+	log := observability.GetLogger(ctx)
+	userID, originDevice, err := draftAuthor(env)
+	if err != nil {
+		log.Error("dispatcher: fail to decode draft event", zap.Error(err))
+		return
+	}
+
+	remoteInstances := sync.Map{}
+	d.deliverToUser(ctx, userID, env, rawPayload, nil, &remoteInstances, originDevice)
+
+	remoteInstances.Range(func(key, value interface{}) bool {
+		instance := key.(string)
+		if err := d.router.Publish(ctx, instance, rawPayload); err != nil {
+			log.Error("dispatcher: remote routing failed", zap.String("instance", instance), zap.Error(err))
+		}
+		return true
+	})
+}
+
 func (d *Dispatcher) getConversationID(env *sharedv1.EventEnvelope) (string, error) {
 	switch env.GetEventType() {
 	case sharedv1.EventType_EVENT_TYPE_MESSAGE_SENT:
@@ -73,6 +112,34 @@ func (d *Dispatcher) getConversationID(env *sharedv1.EventEnvelope) (string, err
 		}
 		return event.GetConversationId(), nil
 
+	case sharedv1.EventType_EVENT_TYPE_MESSAGE_EDITED:
+		var event messagev1.MessageEditedEvent
+		if err := proto.Unmarshal(env.GetPayload(), &event); err != nil {
+			return "", err
+		}
+		return event.GetMessage().GetConversationId(), nil
+
+	case sharedv1.EventType_EVENT_TYPE_REACTION_CHANGED:
+		var event messagev1.ReactionChangedEvent
+		if err := proto.Unmarshal(env.GetPayload(), &event); err != nil {
+			return "", err
+		}
+		return event.GetConversationId(), nil
+
+	case sharedv1.EventType_EVENT_TYPE_MESSAGE_PIN_CHANGED:
+		var event messagev1.MessagePinChangedEvent
+		if err := proto.Unmarshal(env.GetPayload(), &event); err != nil {
+			return "", err
+		}
+		return event.GetConversationId(), nil
+
+	case sharedv1.EventType_EVENT_TYPE_POLL_TALLY_CHANGED:
+		var event messagev1.PollTallyChangedEvent
+		if err := proto.Unmarshal(env.GetPayload(), &event); err != nil {
+			return "", err
+		}
+		return event.GetConversationId(), nil
+
 	case sharedv1.EventType_EVENT_TYPE_READ_RECEIPT_UPDATED:
 		var event conversationv1.ReadReceiptUpdatedEvent
 		if err := proto.Unmarshal(env.GetPayload(), &event); err != nil {
@@ -80,6 +147,13 @@ func (d *Dispatcher) getConversationID(env *sharedv1.EventEnvelope) (string, err
 		}
 		return event.GetConversationId(), nil
 
+	case sharedv1.EventType_EVENT_TYPE_DELIVERY_RECEIPT_UPDATED:
+		var event conversationv1.DeliveryReceiptUpdatedEvent
+		if err := proto.Unmarshal(env.GetPayload(), &event); err != nil {
+			return "", err
+		}
+		return event.GetConversationId(), nil
+
 	case sharedv1.EventType_EVENT_TYPE_MEMBERSHIP_CHANGED:
 		var event conversationv1.MembershipChangedEvent
 		if err := proto.Unmarshal(env.GetPayload(), &event); err != nil {
@@ -94,6 +168,13 @@ func (d *Dispatcher) getConversationID(env *sharedv1.EventEnvelope) (string, err
 		}
 		return event.GetConversation().GetConversationId(), nil
 
+	case sharedv1.EventType_EVENT_TYPE_CONVERSATION_UPDATED:
+		var event conversationv1.ConversationUpdatedEvent
+		if err := proto.Unmarshal(env.GetPayload(), &event); err != nil {
+			return "", err
+		}
+		return event.GetConversation().GetConversationId(), nil
+
 	default:
 		return "", errors.New("unsupported event type")
 	}
@@ -123,12 +204,13 @@ func (d *Dispatcher) handleEvent(ctx context.Context, env *sharedv1.EventEnvelop
 
 	remoteInstances := sync.Map{} // map[string]struct{}
 	var wg sync.WaitGroup
+	sealed := sealedMessage(env)
 
 	for _, userID := range members {
 		wg.Add(1)
 		go func(uid string) {
 			defer wg.Done()
-			d.deliverToUser(ctx, uid, env, rawPayload, &remoteInstances)
+			d.deliverToUser(ctx, uid, env, rawPayload, sealed, &remoteInstances, "")
 		}(userID)
 	}
 	wg.Wait()
@@ -150,7 +232,11 @@ func (d *Dispatcher) handleEvent(ctx context.Context, env *sharedv1.EventEnvelop
 	}
 }
 
-func (d *Dispatcher) deliverToUser(ctx context.Context, userID string, env *sharedv1.EventEnvelope, rawPayload []byte, remoteInstances *sync.Map) {
+// deliverToUser sends the event to userID's devices on this instance and
+// notes the instances holding the others. skipDevice, if set, is left out.
+// sealed, if set, is the encrypted message the event carries; each device
+// gets only its own ciphertext.
+func (d *Dispatcher) deliverToUser(ctx context.Context, userID string, env *sharedv1.EventEnvelope, rawPayload []byte, sealed *messagev1.MessageSentEvent, remoteInstances *sync.Map, skipDevice string) {
 	log := observability.GetLogger(ctx)
 	devResp, err := d.presenceClient.GetUserDevices(ctx, &presencev1.GetUserDevicesRequest{
 		UserId: userID,
@@ -161,13 +247,24 @@ func (d *Dispatcher) deliverToUser(ctx context.Context, userID string, env *shar
 	}
 
 	for _, device := range devResp.GetDevices() {
+		if skipDevice != "" && device.DeviceId == skipDevice {
+			continue
+		}
 		if device.InstanceId == d.instanceID {
 			// Deliver to local session
+			devEnv, devPayload := env, rawPayload
+			if sealed != nil {
+				devEnv, devPayload, err = forDevice(env, sealed, userID, device.DeviceId)
+				if err != nil {
+					log.Error("dispatcher: failed to seal message for device", zap.String("device_id", device.DeviceId), zap.Error(err))
+					continue
+				}
+			}
 			sessions := d.registry.GetUserSessions(userID)
 			for _, s := range sessions {
 				if s.DeviceID == device.DeviceId {
-					if !s.Buffer(env, rawPayload) {
-						if s.TrySend(rawPayload) {
+					if !s.Buffer(devEnv, devPayload) {
+						if s.TrySend(devPayload) {
 							log.Info("dispatcher: local delivery success", zap.String("user_id", userID), zap.String("device_id", device.DeviceId))
 						}
 					}
@@ -216,6 +313,11 @@ func (d *Dispatcher) DeliverRemote(payload []byte) {
 		return
 	}
 
+	if env.GetEventType() == sharedv1.EventType_EVENT_TYPE_DRAFT_UPDATED {
+		d.deliverRemoteDraft(&env, payload)
+		return
+	}
+
 	conversationID, err := d.getConversationID(&env)
 	if err != nil {
 		return
@@ -226,14 +328,24 @@ func (d *Dispatcher) DeliverRemote(payload []byte) {
 	}
 
 	var wg sync.WaitGroup
+	sealed := sealedMessage(&env)
 	for _, userID := range members {
 		wg.Add(1)
 		go func(uid string) {
 			defer wg.Done()
 			sessions := d.registry.GetUserSessions(uid)
 			for _, s := range sessions {
-				if !s.Buffer(&env, payload) {
-					if s.TrySend(payload) {
+				devEnv, devPayload := &env, payload
+				if sealed != nil {
+					var err error
+					devEnv, devPayload, err = forDevice(&env, sealed, uid, s.DeviceID)
+					if err != nil {
+						log.Error("dispatcher: failed to seal message for device", zap.String("device_id", s.DeviceID), zap.Error(err))
+						continue
+					}
+				}
+				if !s.Buffer(devEnv, devPayload) {
+					if s.TrySend(devPayload) {
 						log.Info("dispatcher: remote delivery (pubsub) success", zap.String("user_id", uid), zap.String("conversation_id", conversationID))
 					}
 				} else {
@@ -265,3 +377,20 @@ func (d *Dispatcher) resolveMembers(ctx context.Context, conversationID string)
 	d.membership.SetMembers(conversationID, resp.ParticipantUserIds)
 	return resp.ParticipantUserIds, nil
 }
+
+// deliverRemoteDraft hands a draft event routed here to the author's local
+// sessions, except the device that wrote the draft.
+func (d *Dispatcher) deliverRemoteDraft(env *sharedv1.EventEnvelope, payload []byte) {
+	userID, originDevice, err := draftAuthor(env)
+	if err != nil {
+		return
+	}
+	for _, s := range d.registry.GetUserSessions(userID) {
+		if originDevice != "" && s.DeviceID == originDevice {
+			continue
+		}
+		if !s.Buffer(env, payload) {
+			s.TrySend(payload)
+		}
+	}
+}
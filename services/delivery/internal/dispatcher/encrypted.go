@@ -0,0 +1,56 @@
+package dispatcher
+
+import (
+	messagev1 "github.com/SARVESHVARADKAR123/RealChat/contracts/gen/go/message/v1"
+	sharedv1 "github.com/SARVESHVARADKAR123/RealChat/contracts/gen/go/shared/v1"
+	"google.golang.org/protobuf/proto"
+)
+
+// encryptedMessageType is the message type whose content travels as
+// per-device ciphertexts.
+const encryptedMessageType = "encrypted"
+
+// sealedMessage returns the event of an encrypted MESSAGE_SENT, or nil for
+// any other event.
+func sealedMessage(env *sharedv1.EventEnvelope) *messagev1.MessageSentEvent {
+	if env.GetEventType() != sharedv1.EventType_EVENT_TYPE_MESSAGE_SENT {
+		return nil
+	}
+	var event messagev1.MessageSentEvent
+	if err := proto.Unmarshal(env.GetPayload(), &event); err != nil {
+		return nil
+	}
+	if event.GetMessage().GetMessageType() != encryptedMessageType {
+		return nil
+	}
+	return &event
+}
+
+// forDevice narrows a sealed message to the ciphertext addressed to one
+// device, so no device is sent what was encrypted for another. A device
+// with no ciphertext, e.g. one that published its keys after the send,
+// still learns that the message exists.
+func forDevice(env *sharedv1.EventEnvelope, sealed *messagev1.MessageSentEvent, userID, deviceID string) (*sharedv1.EventEnvelope, []byte, error) {
+	event := proto.Clone(sealed).(*messagev1.MessageSentEvent)
+
+	var own []*messagev1.DeviceCiphertext
+	for _, ct := range sealed.GetMessage().GetCiphertexts() {
+		if ct.GetUserId() == userID && ct.GetDeviceId() == deviceID {
+			own = append(own, ct)
+		}
+	}
+	event.Message.Ciphertexts = own
+
+	payload, err := proto.Marshal(event)
+	if err != nil {
+		return nil, nil, err
+	}
+	out := proto.Clone(env).(*sharedv1.EventEnvelope)
+	out.Payload = payload
+
+	raw, err := proto.Marshal(out)
+	if err != nil {
+		return nil, nil, err
+	}
+	return out, raw, nil
+}
@@ -47,4 +47,12 @@ var (
 		},
 		[]string{"service"},
 	)
+
+	DeliveryReceiptsTotal = promauto.NewCounterVec(
+		prometheus.CounterOpts{
+			Name: "delivery_receipts_total",
+			Help: "Delivery receipts flushed to the conversation service, by outcome",
+		},
+		[]string{"service", "status"},
+	)
 )
@@ -0,0 +1,149 @@
+package receipts
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	conversationv1 "github.com/SARVESHVARADKAR123/RealChat/contracts/gen/go/conversation/v1"
+	"github.com/SARVESHVARADKAR123/RealChat/services/delivery/internal/observability"
+	"go.uber.org/zap"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// maxBatch caps the receipts sent in one RecordDeliveryReceipts call.
+const maxBatch = 1000
+
+// finalFlushTimeout bounds the flush Start makes once its context is done.
+const finalFlushTimeout = 3 * time.Second
+
+type device struct {
+	convID   string
+	userID   string
+	deviceID string
+}
+
+// Batcher coalesces delivery receipts in memory and writes them to the
+// conversation service periodically. A device acknowledging many messages
+// between flushes costs a single receipt holding the highest sequence.
+type Batcher struct {
+	client        conversationv1.ConversationApiClient
+	flushInterval time.Duration
+
+	mu      sync.Mutex
+	pending map[device]int64
+}
+
+func NewBatcher(client conversationv1.ConversationApiClient, flushInterval time.Duration) *Batcher {
+	return &Batcher{
+		client:        client,
+		flushInterval: flushInterval,
+		pending:       make(map[device]int64),
+	}
+}
+
+// Record notes that sequence has been delivered to a device.
+func (b *Batcher) Record(convID, userID, deviceID string, sequence int64) {
+	k := device{convID: convID, userID: userID, deviceID: deviceID}
+
+	b.mu.Lock()
+	if sequence > b.pending[k] {
+		b.pending[k] = sequence
+	}
+	b.mu.Unlock()
+}
+
+// Start flushes pending receipts every flush interval until ctx is done,
+// then flushes once more so receipts recorded since the last tick aren't
+// lost.
+func (b *Batcher) Start(ctx context.Context) {
+	ticker := time.NewTicker(b.flushInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			flushCtx, cancel := context.WithTimeout(context.Background(), finalFlushTimeout)
+			b.Flush(flushCtx)
+			cancel()
+			return
+		case <-ticker.C:
+			b.Flush(ctx)
+		}
+	}
+}
+
+// Flush writes the pending receipts. Receipts that fail to be written are
+// kept for the next flush unless a newer one for the same device arrived.
+// Receipts the conversation service rejects outright are dropped.
+func (b *Batcher) Flush(ctx context.Context) {
+	b.mu.Lock()
+	pending := b.pending
+	b.pending = make(map[device]int64)
+	b.mu.Unlock()
+
+	if len(pending) == 0 {
+		return
+	}
+
+	batch := make([]*conversationv1.DeliveryReceipt, 0, min(len(pending), maxBatch))
+	for k, seq := range pending {
+		batch = append(batch, &conversationv1.DeliveryReceipt{
+			ConversationId:    k.convID,
+			UserId:            k.userID,
+			DeviceId:          k.deviceID,
+			DeliveredSequence: seq,
+		})
+		if len(batch) == maxBatch {
+			b.send(ctx, batch)
+			batch = batch[:0:0]
+		}
+	}
+	if len(batch) > 0 {
+		b.send(ctx, batch)
+	}
+}
+
+func (b *Batcher) send(ctx context.Context, batch []*conversationv1.DeliveryReceipt) {
+	sendCtx, cancel := context.WithTimeout(ctx, 5*time.Second)
+	_, err := b.client.RecordDeliveryReceipts(sendCtx, &conversationv1.RecordDeliveryReceiptsRequest{
+		Receipts: batch,
+	})
+	cancel()
+
+	switch {
+	case err == nil:
+		observability.DeliveryReceiptsTotal.WithLabelValues("delivery", "recorded").Add(float64(len(batch)))
+
+	case permanent(err) && len(batch) > 1:
+		// One bad receipt fails the whole call, so find it and keep the rest
+		for _, r := range batch {
+			b.send(ctx, []*conversationv1.DeliveryReceipt{r})
+		}
+
+	case permanent(err):
+		observability.Log.Warn("receipts: receipt rejected, dropping it",
+			zap.String("conversation_id", batch[0].ConversationId),
+			zap.String("device_id", batch[0].DeviceId),
+			zap.Error(err))
+		observability.DeliveryReceiptsTotal.WithLabelValues("delivery", "dropped").Inc()
+
+	default:
+		observability.Log.Error("receipts: flush failed", zap.Int("receipts", len(batch)), zap.Error(err))
+		observability.DeliveryReceiptsTotal.WithLabelValues("delivery", "failed").Add(float64(len(batch)))
+		for _, r := range batch {
+			b.Record(r.ConversationId, r.UserId, r.DeviceId, r.DeliveredSequence)
+		}
+	}
+}
+
+// permanent reports whether err would come back on every retry.
+func permanent(err error) bool {
+	switch status.Code(err) {
+	case codes.InvalidArgument, codes.NotFound, codes.PermissionDenied,
+		codes.FailedPrecondition, codes.Unimplemented:
+		return true
+	}
+	return false
+}
@@ -0,0 +1,158 @@
+package receipts
+
+import (
+	"context"
+	"errors"
+	"sort"
+	"testing"
+	"time"
+
+	conversationv1 "github.com/SARVESHVARADKAR123/RealChat/contracts/gen/go/conversation/v1"
+	"github.com/SARVESHVARADKAR123/RealChat/services/delivery/internal/observability"
+	"go.uber.org/zap"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// fakeConvClient records the receipt batches it is sent. While fail is set
+// it rejects them, after running during, which stands in for acks arriving
+// mid-flush. Batches holding a device listed in invalid are refused as
+// invalid arguments.
+type fakeConvClient struct {
+	conversationv1.ConversationApiClient
+	batches [][]*conversationv1.DeliveryReceipt
+	fail    bool
+	during  func()
+	invalid map[string]bool
+}
+
+func (c *fakeConvClient) RecordDeliveryReceipts(ctx context.Context, req *conversationv1.RecordDeliveryReceiptsRequest, opts ...grpc.CallOption) (*conversationv1.RecordDeliveryReceiptsResponse, error) {
+	if c.during != nil {
+		c.during()
+	}
+	if c.fail {
+		return nil, errors.New("conversation service unavailable")
+	}
+	for _, r := range req.GetReceipts() {
+		if c.invalid[r.DeviceId] {
+			return nil, status.Error(codes.InvalidArgument, "invalid input")
+		}
+	}
+	c.batches = append(c.batches, req.GetReceipts())
+	return &conversationv1.RecordDeliveryReceiptsResponse{}, nil
+}
+
+func sorted(receipts []*conversationv1.DeliveryReceipt) []*conversationv1.DeliveryReceipt {
+	sort.Slice(receipts, func(i, j int) bool { return receipts[i].DeviceId < receipts[j].DeviceId })
+	return receipts
+}
+
+func TestBatcher_Coalesces(t *testing.T) {
+	client := &fakeConvClient{}
+	b := NewBatcher(client, time.Hour)
+
+	for seq := int64(1); seq <= 100; seq++ {
+		b.Record("conv-1", "user-1", "phone", seq)
+		b.Record("conv-1", "user-1", "laptop", 101-seq)
+	}
+	b.Flush(context.Background())
+
+	if len(client.batches) != 1 {
+		t.Fatalf("expected one batch, got %d", len(client.batches))
+	}
+	got := sorted(client.batches[0])
+	if len(got) != 2 {
+		t.Fatalf("expected one receipt per device, got %v", got)
+	}
+	if got[0].DeviceId != "laptop" || got[0].DeliveredSequence != 100 {
+		t.Errorf("laptop receipt = %v, want sequence 100", got[0])
+	}
+	if got[1].DeviceId != "phone" || got[1].DeliveredSequence != 100 {
+		t.Errorf("phone receipt = %v, want sequence 100", got[1])
+	}
+
+	// Nothing pending, nothing sent
+	b.Flush(context.Background())
+	if len(client.batches) != 1 {
+		t.Errorf("expected no further batch, got %d", len(client.batches))
+	}
+}
+
+func TestBatcher_RequeuesFailedFlush(t *testing.T) {
+	observability.Log = zap.NewNop()
+
+	client := &fakeConvClient{fail: true}
+	b := NewBatcher(client, time.Hour)
+
+	b.Record("conv-1", "user-1", "phone", 5)
+	b.Record("conv-1", "user-1", "laptop", 7)
+	// The phone acks further while the failing flush is in flight
+	client.during = func() { b.Record("conv-1", "user-1", "phone", 9) }
+	b.Flush(context.Background())
+
+	client.fail, client.during = false, nil
+	b.Flush(context.Background())
+
+	if len(client.batches) != 1 {
+		t.Fatalf("expected one successful batch, got %d", len(client.batches))
+	}
+	got := sorted(client.batches[0])
+	if len(got) != 2 {
+		t.Fatalf("expected both devices, got %v", got)
+	}
+	if got[0].DeviceId != "laptop" || got[0].DeliveredSequence != 7 {
+		t.Errorf("laptop receipt = %v, want the requeued sequence 7", got[0])
+	}
+	if got[1].DeviceId != "phone" || got[1].DeliveredSequence != 9 {
+		t.Errorf("phone receipt = %v, want the newer sequence 9", got[1])
+	}
+}
+
+func TestBatcher_DropsRejectedReceipts(t *testing.T) {
+	observability.Log = zap.NewNop()
+
+	client := &fakeConvClient{invalid: map[string]bool{"broken": true}}
+	b := NewBatcher(client, time.Hour)
+
+	b.Record("conv-1", "user-1", "phone", 5)
+	b.Record("conv-1", "user-1", "broken", 6)
+	b.Record("conv-1", "user-1", "laptop", 7)
+	b.Flush(context.Background())
+
+	var got []*conversationv1.DeliveryReceipt
+	for _, batch := range client.batches {
+		got = append(got, batch...)
+	}
+	got = sorted(got)
+	if len(got) != 2 || got[0].DeviceId != "laptop" || got[1].DeviceId != "phone" {
+		t.Fatalf("expected the laptop and phone receipts, got %v", got)
+	}
+
+	// The rejected receipt isn't retried
+	sent := len(client.batches)
+	b.Flush(context.Background())
+	if len(client.batches) != sent {
+		t.Errorf("expected no further batch, got %d", len(client.batches)-sent)
+	}
+}
+
+func TestBatcher_FlushesOnStop(t *testing.T) {
+	client := &fakeConvClient{}
+	b := NewBatcher(client, time.Hour)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	done := make(chan struct{})
+	go func() {
+		b.Start(ctx)
+		close(done)
+	}()
+
+	b.Record("conv-1", "user-1", "phone", 5)
+	cancel()
+	<-done
+
+	if len(client.batches) != 1 || client.batches[0][0].DeliveredSequence != 5 {
+		t.Fatalf("expected the pending receipt to be flushed on stop, got %v", client.batches)
+	}
+}
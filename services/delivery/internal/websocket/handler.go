@@ -3,6 +3,7 @@ package websocket
 import (
 	"context"
 	"encoding/json"
+	"math"
 	"net/http"
 	"time"
 
@@ -23,6 +24,7 @@ type Handler struct {
 	presenceClient presencev1.PresenceApiClient
 	convClient     conversationv1.ConversationApiClient
 	msgClient      messagev1.MessageApiClient
+	receipts       ReceiptRecorder
 	instanceID     string
 }
 
@@ -30,12 +32,24 @@ type ResumeRequest struct {
 	LastSequences map[string]int64 `json:"last_sequences"`
 }
 
-func NewHandler(registry *Registry, pc presencev1.PresenceApiClient, cc conversationv1.ConversationApiClient, mc messagev1.MessageApiClient, instanceID string) *Handler {
+// AckRequest is sent by the client, after the resume request, to confirm the
+// messages it has received: the highest sequence per conversation.
+type AckRequest struct {
+	Acks map[string]int64 `json:"acks"`
+}
+
+// ReceiptRecorder collects delivery receipts for the conversation service.
+type ReceiptRecorder interface {
+	Record(convID, userID, deviceID string, sequence int64)
+}
+
+func NewHandler(registry *Registry, pc presencev1.PresenceApiClient, cc conversationv1.ConversationApiClient, mc messagev1.MessageApiClient, receipts ReceiptRecorder, instanceID string) *Handler {
 	return &Handler{
 		registry:       registry,
 		presenceClient: pc,
 		convClient:     cc,
 		msgClient:      mc,
+		receipts:       receipts,
 		instanceID:     instanceID,
 	}
 }
@@ -111,13 +125,30 @@ func (h *Handler) readLoop(s *Session) {
 	// handleResume handles reading this message and syncing history
 	h.handleResume(s)
 
+	// Later messages acknowledge received messages
 	for {
-		if _, _, err := s.Conn.ReadMessage(); err != nil {
+		_, msg, err := s.Conn.ReadMessage()
+		if err != nil {
 			if websocket.IsUnexpectedCloseError(err, websocket.CloseGoingAway, websocket.CloseAbnormalClosure) {
 				observability.Log.Error("read loop error", zap.String("user_id", s.UserID), zap.String("device_id", s.DeviceID), zap.Error(err))
 			}
 			return
 		}
+		h.handleAck(s, msg)
+	}
+}
+
+func (h *Handler) handleAck(s *Session, msg []byte) {
+	var req AckRequest
+	if err := json.Unmarshal(msg, &req); err != nil {
+		observability.Log.Debug("ignoring malformed ack", zap.String("user_id", s.UserID), zap.String("device_id", s.DeviceID), zap.Error(err))
+		return
+	}
+
+	for convID, seq := range req.Acks {
+		if delivered, ok := s.Ack(convID, seq); ok {
+			h.receipts.Record(convID, s.UserID, s.DeviceID, delivered)
+		}
 	}
 }
 
@@ -168,17 +199,22 @@ func (h *Handler) handleResume(s *Session) {
 	s.FlushBufferSorted()
 }
 
+// syncConversation replays what the client missed in convID. Conversations
+// the client has never seen (lastSeq == 0) only get their latest page; older
+// history is fetched on demand with before_sequence.
 func (h *Handler) syncConversation(ctx context.Context, s *Session, convID string, lastSeq int64) {
-	currentSeq := lastSeq
+	req := &messagev1.SyncMessagesRequest{
+		ConversationId: convID,
+		AfterSequence:  lastSeq,
+		PageSize:       100,
+		DeviceId:       s.DeviceID,
+	}
+	if lastSeq == 0 {
+		req.BeforeSequence = math.MaxInt64
+	}
+
 	for {
-		resp, err := h.msgClient.SyncMessages(
-			ctx,
-			&messagev1.SyncMessagesRequest{
-				ConversationId: convID,
-				AfterSequence:  currentSeq,
-				PageSize:       100,
-			},
-		)
+		resp, err := h.msgClient.SyncMessages(ctx, req)
 		if err != nil {
 			observability.Log.Error("resume: error syncing messages", zap.String("conversation_id", convID), zap.Error(err))
 			break
@@ -191,14 +227,18 @@ func (h *Handler) syncConversation(ctx context.Context, s *Session, convID strin
 
 		for _, m := range msgs {
 			h.sendMsgAsEvent(s, m)
-			if m.Sequence > currentSeq {
-				currentSeq = m.Sequence
-			}
 		}
 
-		if len(msgs) < 100 {
+		if !resp.GetHasMoreAfter() {
 			break // No more pages
 		}
+
+		req = &messagev1.SyncMessagesRequest{
+			ConversationId: convID,
+			AfterSequence:  msgs[len(msgs)-1].Sequence,
+			PageSize:       100,
+			DeviceId:       s.DeviceID,
+		}
 	}
 }
 
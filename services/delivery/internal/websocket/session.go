@@ -33,6 +33,12 @@ type Session struct {
 
 	resumeBuffer []bufferedEvent
 	resumeMu     sync.Mutex
+
+	// written is the highest message sequence written to the connection per
+	// conversation, and acked the highest one the client has confirmed.
+	written   map[string]int64
+	acked     map[string]int64
+	receiptMu sync.Mutex
 }
 
 type bufferedEvent struct {
@@ -48,6 +54,8 @@ func NewSession(id, userID, deviceID string, conn *websocket.Conn) *Session {
 		Conn:      conn,
 		SendQueue: make(chan []byte, SendQueueSize),
 		done:      make(chan struct{}),
+		written:   make(map[string]int64),
+		acked:     make(map[string]int64),
 	}
 }
 
@@ -131,6 +139,47 @@ func getSequence(env *sharedv1.EventEnvelope) int64 {
 	return event.GetMessage().GetSequence()
 }
 
+// Ack records that the client has received convID up to sequence and returns
+// the sequence that now counts as delivered. Only messages this session has
+// actually written count, so a client cannot acknowledge ahead of the stream.
+// It reports false when nothing new was delivered.
+func (s *Session) Ack(convID string, sequence int64) (int64, bool) {
+	s.receiptMu.Lock()
+	defer s.receiptMu.Unlock()
+
+	delivered := min(sequence, s.written[convID])
+	if delivered <= s.acked[convID] {
+		return 0, false
+	}
+	s.acked[convID] = delivered
+	return delivered, true
+}
+
+// noteWritten remembers the sequence of a MESSAGE_SENT frame once it has been
+// written to the connection.
+func (s *Session) noteWritten(msg []byte) {
+	var env sharedv1.EventEnvelope
+	if err := proto.Unmarshal(msg, &env); err != nil {
+		return
+	}
+	if env.GetEventType() != sharedv1.EventType_EVENT_TYPE_MESSAGE_SENT {
+		return
+	}
+
+	var event messagev1.MessageSentEvent
+	if err := proto.Unmarshal(env.GetPayload(), &event); err != nil {
+		return
+	}
+	convID := event.GetMessage().GetConversationId()
+	seq := event.GetMessage().GetSequence()
+
+	s.receiptMu.Lock()
+	if seq > s.written[convID] {
+		s.written[convID] = seq
+	}
+	s.receiptMu.Unlock()
+}
+
 func (s *Session) TrySend(msg []byte) bool {
 	if s.closed.Load() == 1 {
 		return false
@@ -184,6 +233,7 @@ func (s *Session) writeLoop() {
 				log.Printf("session: write error user=%s device=%s: %v", s.UserID, s.DeviceID, err)
 				return
 			}
+			s.noteWritten(msg)
 		case <-ticker.C:
 			_ = s.Conn.SetWriteDeadline(time.Now().Add(writeWait))
 			if err := s.Conn.WriteMessage(websocket.PingMessage, nil); err != nil {
@@ -0,0 +1,66 @@
+package websocket
+
+import (
+	"testing"
+
+	messagev1 "github.com/SARVESHVARADKAR123/RealChat/contracts/gen/go/message/v1"
+	sharedv1 "github.com/SARVESHVARADKAR123/RealChat/contracts/gen/go/shared/v1"
+	"google.golang.org/protobuf/proto"
+)
+
+// frame builds the envelope written to a client for an event.
+func frame(t *testing.T, eventType sharedv1.EventType, event proto.Message) []byte {
+	t.Helper()
+	payload, err := proto.Marshal(event)
+	if err != nil {
+		t.Fatal(err)
+	}
+	b, err := proto.Marshal(&sharedv1.EventEnvelope{EventType: eventType, Payload: payload})
+	if err != nil {
+		t.Fatal(err)
+	}
+	return b
+}
+
+func sentFrame(t *testing.T, convID string, seq int64) []byte {
+	return frame(t, sharedv1.EventType_EVENT_TYPE_MESSAGE_SENT, &messagev1.MessageSentEvent{
+		Message: &messagev1.Message{ConversationId: convID, Sequence: seq},
+	})
+}
+
+func TestSession_Ack(t *testing.T) {
+	s := NewSession("s1", "user1", "device1", nil)
+
+	// Nothing written yet, so nothing can be acknowledged
+	if _, ok := s.Ack("conv-1", 3); ok {
+		t.Fatal("ack before any write should not count")
+	}
+
+	s.noteWritten(sentFrame(t, "conv-1", 1))
+	s.noteWritten(sentFrame(t, "conv-1", 4))
+	s.noteWritten(sentFrame(t, "conv-1", 2)) // a late frame doesn't lower it
+	s.noteWritten(frame(t, sharedv1.EventType_EVENT_TYPE_MESSAGE_DELETED, &messagev1.MessageDeletedEvent{ConversationId: "conv-1"}))
+
+	// Acks ahead of the stream are capped at the highest written sequence
+	if got, ok := s.Ack("conv-1", 10); !ok || got != 4 {
+		t.Fatalf("Ack(10) = %d, %v; want 4, true", got, ok)
+	}
+
+	// Acks that don't move forward are ignored
+	if _, ok := s.Ack("conv-1", 4); ok {
+		t.Error("repeated ack should be ignored")
+	}
+	if _, ok := s.Ack("conv-1", 2); ok {
+		t.Error("older ack should be ignored")
+	}
+
+	// Conversations are tracked separately
+	if _, ok := s.Ack("conv-2", 4); ok {
+		t.Error("ack for a conversation with nothing written should be ignored")
+	}
+
+	s.noteWritten(sentFrame(t, "conv-1", 6))
+	if got, ok := s.Ack("conv-1", 5); !ok || got != 5 {
+		t.Fatalf("Ack(5) = %d, %v; want 5, true", got, ok)
+	}
+}
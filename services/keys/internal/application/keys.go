@@ -0,0 +1,228 @@
+package application
+
+import (
+	"bytes"
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/SARVESHVARADKAR123/RealChat/services/keys/internal/domain"
+	"github.com/SARVESHVARADKAR123/RealChat/services/keys/internal/observability"
+	"go.uber.org/zap"
+)
+
+type UploadKeysCommand struct {
+	UserID         string
+	DeviceID       string
+	IdentityKey    []byte
+	SignedPreKey   domain.SignedPreKey
+	OneTimePreKeys []domain.OneTimePreKey
+}
+
+// UploadKeys publishes a device's keys and returns how many one-time
+// prekeys it has in stock. A new identity key means the device was reset:
+// prekeys published under the old one are dropped.
+func (s *Service) UploadKeys(ctx context.Context, cmd UploadKeysCommand) (int, error) {
+	if cmd.UserID == "" {
+		return 0, domain.ErrInvalidInput
+	}
+	if err := domain.ValidateDeviceID(cmd.DeviceID); err != nil {
+		return 0, err
+	}
+	if err := cmd.SignedPreKey.Verify(cmd.IdentityKey); err != nil {
+		return 0, err
+	}
+	if err := domain.ValidatePreKeys(cmd.OneTimePreKeys); err != nil {
+		return 0, err
+	}
+
+	var count int
+	err := s.tx.WithTx(ctx, func(ctx context.Context, tx *sql.Tx) error {
+		now := time.Now().UTC()
+		device := &domain.Device{
+			UserID:       cmd.UserID,
+			DeviceID:     cmd.DeviceID,
+			IdentityKey:  cmd.IdentityKey,
+			SignedPreKey: cmd.SignedPreKey,
+			CreatedAt:    now,
+			UpdatedAt:    now,
+		}
+
+		existing, err := s.repo.GetDeviceForUpdate(ctx, tx, cmd.UserID, cmd.DeviceID)
+		switch {
+		case errors.Is(err, domain.ErrDeviceNotFound):
+		case err != nil:
+			return err
+		default:
+			device.CreatedAt = existing.CreatedAt
+			if !bytes.Equal(existing.IdentityKey, cmd.IdentityKey) {
+				if err := s.repo.DeleteOneTimePreKeys(ctx, tx, cmd.UserID, cmd.DeviceID); err != nil {
+					return fmt.Errorf("failed to drop stale prekeys: %w", err)
+				}
+			}
+		}
+
+		if err := s.repo.PutDevice(ctx, tx, device); err != nil {
+			return fmt.Errorf("failed to store device keys: %w", err)
+		}
+		count, err = s.addPreKeys(ctx, tx, cmd.UserID, cmd.DeviceID, cmd.OneTimePreKeys)
+		return err
+	})
+	if err != nil {
+		return 0, err
+	}
+	return count, nil
+}
+
+type ReplenishPreKeysCommand struct {
+	UserID         string
+	DeviceID       string
+	OneTimePreKeys []domain.OneTimePreKey
+	// SignedPreKey, when set, rotates the device's signed prekey.
+	SignedPreKey *domain.SignedPreKey
+}
+
+// ReplenishPreKeys tops up a device's one-time prekeys and returns how many
+// it has in stock.
+func (s *Service) ReplenishPreKeys(ctx context.Context, cmd ReplenishPreKeysCommand) (int, error) {
+	if cmd.UserID == "" {
+		return 0, domain.ErrInvalidInput
+	}
+	if err := domain.ValidateDeviceID(cmd.DeviceID); err != nil {
+		return 0, err
+	}
+	if err := domain.ValidatePreKeys(cmd.OneTimePreKeys); err != nil {
+		return 0, err
+	}
+
+	var count int
+	err := s.tx.WithTx(ctx, func(ctx context.Context, tx *sql.Tx) error {
+		device, err := s.repo.GetDeviceForUpdate(ctx, tx, cmd.UserID, cmd.DeviceID)
+		if err != nil {
+			return err
+		}
+
+		if cmd.SignedPreKey != nil {
+			if err := cmd.SignedPreKey.Verify(device.IdentityKey); err != nil {
+				return err
+			}
+			device.SignedPreKey = *cmd.SignedPreKey
+			device.UpdatedAt = time.Now().UTC()
+			if err := s.repo.PutDevice(ctx, tx, device); err != nil {
+				return fmt.Errorf("failed to rotate signed prekey: %w", err)
+			}
+		}
+
+		count, err = s.addPreKeys(ctx, tx, cmd.UserID, cmd.DeviceID, cmd.OneTimePreKeys)
+		return err
+	})
+	if err != nil {
+		return 0, err
+	}
+	return count, nil
+}
+
+// addPreKeys stores keys unless that would take the device over its stock
+// limit, and returns the resulting stock.
+func (s *Service) addPreKeys(ctx context.Context, tx *sql.Tx, userID, deviceID string, keys []domain.OneTimePreKey) (int, error) {
+	count, err := s.repo.CountOneTimePreKeys(ctx, tx, userID, deviceID)
+	if err != nil {
+		return 0, err
+	}
+	if len(keys) == 0 {
+		return count, nil
+	}
+	if count+len(keys) > domain.MaxStoredPreKeys {
+		return 0, fmt.Errorf("%w: %d of %d already stored", domain.ErrTooManyPreKeys, count, domain.MaxStoredPreKeys)
+	}
+
+	if err := s.repo.InsertOneTimePreKeys(ctx, tx, userID, deviceID, keys); err != nil {
+		return 0, fmt.Errorf("failed to store prekeys: %w", err)
+	}
+	// Key IDs already in stock are skipped, so count again
+	return s.repo.CountOneTimePreKeys(ctx, tx, userID, deviceID)
+}
+
+// GetPreKeyBundles returns a bundle for each of a user's devices, or for
+// the one given. Every bundle claims one of its device's one-time prekeys;
+// a device that has run out gets a bundle without one.
+func (s *Service) GetPreKeyBundles(ctx context.Context, userID, deviceID string) ([]*domain.Bundle, error) {
+	if userID == "" {
+		return nil, domain.ErrInvalidInput
+	}
+	if deviceID != "" {
+		if err := domain.ValidateDeviceID(deviceID); err != nil {
+			return nil, err
+		}
+	}
+
+	var bundles []*domain.Bundle
+	err := s.tx.WithTx(ctx, func(ctx context.Context, tx *sql.Tx) error {
+		devices, err := s.repo.ListDevices(ctx, tx, userID, deviceID)
+		if err != nil {
+			return err
+		}
+		if len(devices) == 0 && deviceID != "" {
+			return domain.ErrDeviceNotFound
+		}
+
+		bundles = make([]*domain.Bundle, 0, len(devices))
+		for _, d := range devices {
+			otk, err := s.repo.ClaimOneTimePreKey(ctx, tx, d.UserID, d.DeviceID)
+			if err != nil {
+				return fmt.Errorf("failed to claim prekey: %w", err)
+			}
+			bundles = append(bundles, &domain.Bundle{Device: *d, OneTimePreKey: otk})
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	for _, b := range bundles {
+		result := "claimed"
+		if b.OneTimePreKey == nil {
+			result = "exhausted"
+			s.log.Warn("device out of one-time prekeys",
+				zap.String("user_id", b.UserID),
+				zap.String("device_id", b.DeviceID),
+			)
+		}
+		observability.PreKeyClaimsTotal.WithLabelValues(result).Inc()
+	}
+	return bundles, nil
+}
+
+// GetPreKeyCount returns how many one-time prekeys a device has left.
+func (s *Service) GetPreKeyCount(ctx context.Context, userID, deviceID string) (int, error) {
+	if userID == "" {
+		return 0, domain.ErrInvalidInput
+	}
+	if err := domain.ValidateDeviceID(deviceID); err != nil {
+		return 0, err
+	}
+
+	devices, err := s.repo.ListDevices(ctx, nil, userID, deviceID)
+	if err != nil {
+		return 0, err
+	}
+	if len(devices) == 0 {
+		return 0, domain.ErrDeviceNotFound
+	}
+	return s.repo.CountOneTimePreKeys(ctx, nil, userID, deviceID)
+}
+
+// RemoveDevice deletes a device's keys, so no new sessions are opened with
+// it.
+func (s *Service) RemoveDevice(ctx context.Context, userID, deviceID string) error {
+	if userID == "" {
+		return domain.ErrInvalidInput
+	}
+	if err := domain.ValidateDeviceID(deviceID); err != nil {
+		return err
+	}
+	return s.repo.DeleteDevice(ctx, nil, userID, deviceID)
+}
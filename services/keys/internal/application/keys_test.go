@@ -0,0 +1,335 @@
+package application
+
+import (
+	"bytes"
+	"context"
+	"crypto/ed25519"
+	"crypto/rand"
+	"database/sql"
+	"errors"
+	"sort"
+	"testing"
+
+	"github.com/SARVESHVARADKAR123/RealChat/services/keys/internal/domain"
+	"go.uber.org/zap"
+)
+
+type deviceKey struct{ userID, deviceID string }
+
+type memRepo struct {
+	devices map[deviceKey]*domain.Device
+	prekeys map[deviceKey][]domain.OneTimePreKey
+}
+
+func newMemRepo() *memRepo {
+	return &memRepo{
+		devices: map[deviceKey]*domain.Device{},
+		prekeys: map[deviceKey][]domain.OneTimePreKey{},
+	}
+}
+
+func (m *memRepo) GetDeviceForUpdate(ctx context.Context, tx *sql.Tx, userID, deviceID string) (*domain.Device, error) {
+	d, ok := m.devices[deviceKey{userID, deviceID}]
+	if !ok {
+		return nil, domain.ErrDeviceNotFound
+	}
+	cp := *d
+	return &cp, nil
+}
+func (m *memRepo) PutDevice(ctx context.Context, tx *sql.Tx, d *domain.Device) error {
+	cp := *d
+	m.devices[deviceKey{d.UserID, d.DeviceID}] = &cp
+	return nil
+}
+func (m *memRepo) ListDevices(ctx context.Context, tx *sql.Tx, userID, deviceID string) ([]*domain.Device, error) {
+	var out []*domain.Device
+	for k, d := range m.devices {
+		if k.userID == userID && (deviceID == "" || k.deviceID == deviceID) {
+			cp := *d
+			out = append(out, &cp)
+		}
+	}
+	sort.Slice(out, func(i, j int) bool { return out[i].DeviceID < out[j].DeviceID })
+	return out, nil
+}
+func (m *memRepo) DeleteDevice(ctx context.Context, tx *sql.Tx, userID, deviceID string) error {
+	k := deviceKey{userID, deviceID}
+	if _, ok := m.devices[k]; !ok {
+		return domain.ErrDeviceNotFound
+	}
+	delete(m.devices, k)
+	delete(m.prekeys, k)
+	return nil
+}
+func (m *memRepo) InsertOneTimePreKeys(ctx context.Context, tx *sql.Tx, userID, deviceID string, keys []domain.OneTimePreKey) error {
+	k := deviceKey{userID, deviceID}
+next:
+	for _, key := range keys {
+		for _, have := range m.prekeys[k] {
+			if have.KeyID == key.KeyID {
+				continue next
+			}
+		}
+		m.prekeys[k] = append(m.prekeys[k], key)
+	}
+	return nil
+}
+func (m *memRepo) DeleteOneTimePreKeys(ctx context.Context, tx *sql.Tx, userID, deviceID string) error {
+	delete(m.prekeys, deviceKey{userID, deviceID})
+	return nil
+}
+func (m *memRepo) CountOneTimePreKeys(ctx context.Context, tx *sql.Tx, userID, deviceID string) (int, error) {
+	return len(m.prekeys[deviceKey{userID, deviceID}]), nil
+}
+func (m *memRepo) ClaimOneTimePreKey(ctx context.Context, tx *sql.Tx, userID, deviceID string) (*domain.OneTimePreKey, error) {
+	k := deviceKey{userID, deviceID}
+	if len(m.prekeys[k]) == 0 {
+		return nil, nil
+	}
+	key := m.prekeys[k][0]
+	m.prekeys[k] = m.prekeys[k][1:]
+	return &key, nil
+}
+
+type noTx struct{}
+
+func (noTx) WithTx(ctx context.Context, fn func(ctx context.Context, tx *sql.Tx) error) error {
+	return fn(ctx, nil)
+}
+
+// testDevice is a client's key material.
+type testDevice struct {
+	identity ed25519.PrivateKey
+	spk      domain.SignedPreKey
+}
+
+func newTestDevice(t *testing.T) *testDevice {
+	_, priv, err := ed25519.GenerateKey(rand.Reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+	d := &testDevice{identity: priv}
+	d.spk = d.signedPreKey(1)
+	return d
+}
+
+func (d *testDevice) identityKey() []byte {
+	return d.identity.Public().(ed25519.PublicKey)
+}
+
+func (d *testDevice) signedPreKey(id uint32) domain.SignedPreKey {
+	pub := bytes.Repeat([]byte{byte(id)}, domain.KeySize)
+	return domain.SignedPreKey{KeyID: id, PublicKey: pub, Signature: ed25519.Sign(d.identity, pub)}
+}
+
+func preKeys(from, n int) []domain.OneTimePreKey {
+	keys := make([]domain.OneTimePreKey, n)
+	for i := range keys {
+		keys[i] = domain.OneTimePreKey{
+			KeyID:     uint32(from + i),
+			PublicKey: bytes.Repeat([]byte{byte(from + i)}, domain.KeySize),
+		}
+	}
+	return keys
+}
+
+func (d *testDevice) upload(svc *Service, userID, deviceID string, keys []domain.OneTimePreKey) (int, error) {
+	return svc.UploadKeys(context.Background(), UploadKeysCommand{
+		UserID:         userID,
+		DeviceID:       deviceID,
+		IdentityKey:    d.identityKey(),
+		SignedPreKey:   d.spk,
+		OneTimePreKeys: keys,
+	})
+}
+
+func TestUploadKeys(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("Signed prekey must verify", func(t *testing.T) {
+		svc := New(newMemRepo(), noTx{}, zap.NewNop())
+		d := newTestDevice(t)
+		d.spk.Signature = newTestDevice(t).spk.Signature
+		if _, err := d.upload(svc, "u1", "phone", nil); !errors.Is(err, domain.ErrInvalidSignature) {
+			t.Fatalf("err = %v, want ErrInvalidSignature", err)
+		}
+	})
+
+	t.Run("Prekeys must be well formed", func(t *testing.T) {
+		svc := New(newMemRepo(), noTx{}, zap.NewNop())
+		d := newTestDevice(t)
+
+		dup := append(preKeys(1, 2), preKeys(1, 1)...)
+		if _, err := d.upload(svc, "u1", "phone", dup); !errors.Is(err, domain.ErrInvalidKey) {
+			t.Fatalf("duplicate ids: err = %v, want ErrInvalidKey", err)
+		}
+		short := []domain.OneTimePreKey{{KeyID: 1, PublicKey: []byte("short")}}
+		if _, err := d.upload(svc, "u1", "phone", short); !errors.Is(err, domain.ErrInvalidKey) {
+			t.Fatalf("short key: err = %v, want ErrInvalidKey", err)
+		}
+		if _, err := d.upload(svc, "u1", "phone", preKeys(0, domain.MaxPreKeysPerUpload+1)); !errors.Is(err, domain.ErrTooManyPreKeys) {
+			t.Fatalf("oversized upload: err = %v, want ErrTooManyPreKeys", err)
+		}
+		if _, err := d.upload(svc, "u1", "", nil); !errors.Is(err, domain.ErrInvalidInput) {
+			t.Fatalf("no device: err = %v, want ErrInvalidInput", err)
+		}
+	})
+
+	t.Run("New identity drops old prekeys", func(t *testing.T) {
+		svc := New(newMemRepo(), noTx{}, zap.NewNop())
+		if n, err := newTestDevice(t).upload(svc, "u1", "phone", preKeys(1, 10)); err != nil || n != 10 {
+			t.Fatalf("first upload: n = %d, err = %v", n, err)
+		}
+		n, err := newTestDevice(t).upload(svc, "u1", "phone", preKeys(100, 3))
+		if err != nil {
+			t.Fatal(err)
+		}
+		if n != 3 {
+			t.Fatalf("count = %d after reset, want 3", n)
+		}
+	})
+
+	t.Run("Same identity keeps prekeys", func(t *testing.T) {
+		svc := New(newMemRepo(), noTx{}, zap.NewNop())
+		d := newTestDevice(t)
+		if _, err := d.upload(svc, "u1", "phone", preKeys(1, 10)); err != nil {
+			t.Fatal(err)
+		}
+		n, err := d.upload(svc, "u1", "phone", preKeys(5, 10))
+		if err != nil {
+			t.Fatal(err)
+		}
+		if n != 14 {
+			t.Fatalf("count = %d, want 14", n)
+		}
+		got, err := svc.GetPreKeyCount(ctx, "u1", "phone")
+		if err != nil || got != n {
+			t.Fatalf("GetPreKeyCount = %d, %v; want %d", got, err, n)
+		}
+	})
+}
+
+func TestGetPreKeyBundles(t *testing.T) {
+	ctx := context.Background()
+	svc := New(newMemRepo(), noTx{}, zap.NewNop())
+
+	phone, laptop := newTestDevice(t), newTestDevice(t)
+	if _, err := phone.upload(svc, "bob", "phone", preKeys(1, 2)); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := laptop.upload(svc, "bob", "laptop", nil); err != nil {
+		t.Fatal(err)
+	}
+
+	bundles, err := svc.GetPreKeyBundles(ctx, "bob", "")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(bundles) != 2 {
+		t.Fatalf("got %d bundles, want 2", len(bundles))
+	}
+	byDevice := map[string]*domain.Bundle{}
+	for _, b := range bundles {
+		byDevice[b.DeviceID] = b
+	}
+	if b := byDevice["laptop"]; b == nil || b.OneTimePreKey != nil {
+		t.Fatalf("laptop bundle = %+v, want one without a one-time prekey", b)
+	}
+	first := byDevice["phone"]
+	if first == nil || first.OneTimePreKey == nil {
+		t.Fatalf("phone bundle = %+v, want one with a one-time prekey", first)
+	}
+	if !bytes.Equal(first.IdentityKey, phone.identityKey()) {
+		t.Fatal("phone bundle carries the wrong identity key")
+	}
+
+	// Each one-time prekey is handed out once
+	bundles, err = svc.GetPreKeyBundles(ctx, "bob", "phone")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(bundles) != 1 || bundles[0].OneTimePreKey == nil {
+		t.Fatalf("second claim = %+v, want one bundle with a prekey", bundles)
+	}
+	if bundles[0].OneTimePreKey.KeyID == first.OneTimePreKey.KeyID {
+		t.Fatalf("prekey %d handed out twice", first.OneTimePreKey.KeyID)
+	}
+	if n, _ := svc.GetPreKeyCount(ctx, "bob", "phone"); n != 0 {
+		t.Fatalf("count = %d after claiming both, want 0", n)
+	}
+
+	if _, err := svc.GetPreKeyBundles(ctx, "bob", "tablet"); !errors.Is(err, domain.ErrDeviceNotFound) {
+		t.Fatalf("err = %v, want ErrDeviceNotFound", err)
+	}
+}
+
+func TestReplenishPreKeys(t *testing.T) {
+	ctx := context.Background()
+	svc := New(newMemRepo(), noTx{}, zap.NewNop())
+
+	_, err := svc.ReplenishPreKeys(ctx, ReplenishPreKeysCommand{UserID: "u1", DeviceID: "phone", OneTimePreKeys: preKeys(1, 1)})
+	if !errors.Is(err, domain.ErrDeviceNotFound) {
+		t.Fatalf("unknown device: err = %v, want ErrDeviceNotFound", err)
+	}
+
+	d := newTestDevice(t)
+	if _, err := d.upload(svc, "u1", "phone", nil); err != nil {
+		t.Fatal(err)
+	}
+
+	// A signed prekey signed by another identity is refused
+	bad := newTestDevice(t).signedPreKey(2)
+	_, err = svc.ReplenishPreKeys(ctx, ReplenishPreKeysCommand{UserID: "u1", DeviceID: "phone", SignedPreKey: &bad})
+	if !errors.Is(err, domain.ErrInvalidSignature) {
+		t.Fatalf("foreign signature: err = %v, want ErrInvalidSignature", err)
+	}
+
+	spk := d.signedPreKey(2)
+	n, err := svc.ReplenishPreKeys(ctx, ReplenishPreKeysCommand{
+		UserID: "u1", DeviceID: "phone", OneTimePreKeys: preKeys(1, 50), SignedPreKey: &spk,
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if n != 50 {
+		t.Fatalf("count = %d, want 50", n)
+	}
+	bundles, err := svc.GetPreKeyBundles(ctx, "u1", "phone")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if bundles[0].SignedPreKey.KeyID != 2 {
+		t.Fatalf("signed prekey %d, want rotated key 2", bundles[0].SignedPreKey.KeyID)
+	}
+
+	// The stock is capped
+	for from := 100; from <= domain.MaxStoredPreKeys; from += domain.MaxPreKeysPerUpload {
+		if _, err := svc.ReplenishPreKeys(ctx, ReplenishPreKeysCommand{
+			UserID: "u1", DeviceID: "phone", OneTimePreKeys: preKeys(from, domain.MaxPreKeysPerUpload),
+		}); err != nil {
+			if !errors.Is(err, domain.ErrTooManyPreKeys) {
+				t.Fatalf("err = %v, want ErrTooManyPreKeys", err)
+			}
+			return
+		}
+	}
+	t.Fatalf("stock grew past %d", domain.MaxStoredPreKeys)
+}
+
+func TestRemoveDevice(t *testing.T) {
+	ctx := context.Background()
+	svc := New(newMemRepo(), noTx{}, zap.NewNop())
+
+	if _, err := newTestDevice(t).upload(svc, "u1", "phone", preKeys(1, 5)); err != nil {
+		t.Fatal(err)
+	}
+	if err := svc.RemoveDevice(ctx, "u1", "phone"); err != nil {
+		t.Fatal(err)
+	}
+	if err := svc.RemoveDevice(ctx, "u1", "phone"); !errors.Is(err, domain.ErrDeviceNotFound) {
+		t.Fatalf("err = %v, want ErrDeviceNotFound", err)
+	}
+	if bundles, err := svc.GetPreKeyBundles(ctx, "u1", ""); err != nil || len(bundles) != 0 {
+		t.Fatalf("bundles = %v, %v; want none", bundles, err)
+	}
+}
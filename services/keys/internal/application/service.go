@@ -0,0 +1,17 @@
+package application
+
+import (
+	"github.com/SARVESHVARADKAR123/RealChat/services/keys/internal/repository"
+	"github.com/SARVESHVARADKAR123/RealChat/services/keys/internal/tx"
+	"go.uber.org/zap"
+)
+
+type Service struct {
+	repo repository.Repository
+	tx   tx.Transactor
+	log  *zap.Logger
+}
+
+func New(repo repository.Repository, transactor tx.Transactor, log *zap.Logger) *Service {
+	return &Service{repo: repo, tx: transactor, log: log}
+}
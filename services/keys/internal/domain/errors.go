@@ -0,0 +1,11 @@
+package domain
+
+import "errors"
+
+var (
+	ErrInvalidInput     = errors.New("invalid input")
+	ErrInvalidKey       = errors.New("invalid key")
+	ErrInvalidSignature = errors.New("signed prekey signature does not verify")
+	ErrTooManyPreKeys   = errors.New("too many one-time prekeys")
+	ErrDeviceNotFound   = errors.New("device keys not found")
+)
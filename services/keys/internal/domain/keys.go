@@ -0,0 +1,96 @@
+package domain
+
+import (
+	"crypto/ed25519"
+	"fmt"
+	"time"
+)
+
+const (
+	// KeySize is the size of X25519 and Ed25519 public keys.
+	KeySize = 32
+
+	// MaxDeviceIDLength bounds the device IDs clients connect with.
+	MaxDeviceIDLength = 128
+
+	// MaxPreKeysPerUpload and MaxStoredPreKeys bound one upload and what a
+	// device may keep in stock.
+	MaxPreKeysPerUpload = 100
+	MaxStoredPreKeys    = 500
+)
+
+// SignedPreKey is a medium-term X25519 key signed with the device's
+// identity key.
+type SignedPreKey struct {
+	KeyID     uint32
+	PublicKey []byte
+	Signature []byte
+}
+
+// Verify checks that the key is well formed and signed by identityKey, an
+// Ed25519 public key.
+func (k SignedPreKey) Verify(identityKey []byte) error {
+	if len(identityKey) != ed25519.PublicKeySize {
+		return fmt.Errorf("%w: identity key must be %d bytes", ErrInvalidKey, ed25519.PublicKeySize)
+	}
+	if len(k.PublicKey) != KeySize {
+		return fmt.Errorf("%w: signed prekey must be %d bytes", ErrInvalidKey, KeySize)
+	}
+	if len(k.Signature) != ed25519.SignatureSize {
+		return ErrInvalidSignature
+	}
+	if !ed25519.Verify(ed25519.PublicKey(identityKey), k.PublicKey, k.Signature) {
+		return ErrInvalidSignature
+	}
+	return nil
+}
+
+// OneTimePreKey is an X25519 key handed out to a single sender.
+type OneTimePreKey struct {
+	KeyID     uint32
+	PublicKey []byte
+}
+
+// ValidatePreKeys checks one upload of one-time prekeys. Key IDs must be
+// unique within the upload.
+func ValidatePreKeys(keys []OneTimePreKey) error {
+	if len(keys) > MaxPreKeysPerUpload {
+		return ErrTooManyPreKeys
+	}
+	seen := make(map[uint32]bool, len(keys))
+	for _, k := range keys {
+		if len(k.PublicKey) != KeySize {
+			return fmt.Errorf("%w: one-time prekey %d must be %d bytes", ErrInvalidKey, k.KeyID, KeySize)
+		}
+		if seen[k.KeyID] {
+			return fmt.Errorf("%w: duplicate one-time prekey id %d", ErrInvalidKey, k.KeyID)
+		}
+		seen[k.KeyID] = true
+	}
+	return nil
+}
+
+// ValidateDeviceID checks a device ID as the delivery service accepts it.
+func ValidateDeviceID(id string) error {
+	if id == "" || len(id) > MaxDeviceIDLength {
+		return ErrInvalidInput
+	}
+	return nil
+}
+
+// Device is the published key material of one of a user's devices.
+type Device struct {
+	UserID       string
+	DeviceID     string
+	IdentityKey  []byte
+	SignedPreKey SignedPreKey
+	CreatedAt    time.Time
+	UpdatedAt    time.Time
+}
+
+// Bundle is what a sender needs to open a session with a device.
+// OneTimePreKey is nil once the device has run out.
+type Bundle struct {
+	Device
+	OneTimePreKey *OneTimePreKey
+}
@@ -0,0 +1,42 @@
+package observability
+
+import (
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promauto"
+)
+
+var (
+	HttpRequestsTotal = promauto.NewCounterVec(
+		prometheus.CounterOpts{
+			Name: "http_requests_total",
+			Help: "Total number of HTTP requests",
+		},
+		[]string{"service", "method", "path", "status"},
+	)
+
+	HttpRequestDuration = promauto.NewHistogramVec(
+		prometheus.HistogramOpts{
+			Name:    "http_request_duration_seconds",
+			Help:    "Duration of HTTP requests in seconds",
+			Buckets: prometheus.DefBuckets,
+		},
+		[]string{"service", "method", "path"},
+	)
+
+	PreKeyClaimsTotal = promauto.NewCounterVec(
+		prometheus.CounterOpts{
+			Name: "keys_prekey_claims_total",
+			Help: "Total number of bundles handed out, by whether a one-time prekey was left",
+		},
+		[]string{"result"},
+	)
+
+	DbQueryDuration = promauto.NewHistogramVec(
+		prometheus.HistogramOpts{
+			Name:    "db_query_duration_seconds",
+			Help:    "Duration of database queries in seconds",
+			Buckets: prometheus.DefBuckets,
+		},
+		[]string{"service", "query_type"},
+	)
+)
@@ -0,0 +1,246 @@
+package postgres
+
+import (
+	"context"
+	"database/sql"
+
+	"github.com/SARVESHVARADKAR123/RealChat/services/keys/internal/domain"
+	"github.com/lib/pq"
+)
+
+type Repository struct {
+	DB *sql.DB
+}
+
+type queryable interface {
+	QueryRowContext(ctx context.Context, query string, args ...interface{}) *sql.Row
+	QueryContext(ctx context.Context, query string, args ...interface{}) (*sql.Rows, error)
+	ExecContext(ctx context.Context, query string, args ...interface{}) (sql.Result, error)
+}
+
+func (r *Repository) getter(tx *sql.Tx) queryable {
+	if tx != nil {
+		return tx
+	}
+	return r.DB
+}
+
+type rowScanner interface {
+	Scan(dest ...interface{}) error
+}
+
+const deviceColumns = `user_id, device_id, identity_key,
+		       signed_prekey_id, signed_prekey, signed_prekey_signature,
+		       created_at, updated_at`
+
+func scanDevice(row rowScanner) (*domain.Device, error) {
+	var d domain.Device
+	var signedID int64
+
+	if err := row.Scan(
+		&d.UserID,
+		&d.DeviceID,
+		&d.IdentityKey,
+		&signedID,
+		&d.SignedPreKey.PublicKey,
+		&d.SignedPreKey.Signature,
+		&d.CreatedAt,
+		&d.UpdatedAt,
+	); err != nil {
+		if err == sql.ErrNoRows {
+			return nil, domain.ErrDeviceNotFound
+		}
+		return nil, err
+	}
+
+	d.SignedPreKey.KeyID = uint32(signedID)
+	return &d, nil
+}
+
+func (r *Repository) GetDeviceForUpdate(
+	ctx context.Context,
+	tx *sql.Tx,
+	userID, deviceID string,
+) (*domain.Device, error) {
+	q := r.getter(tx)
+	return scanDevice(q.QueryRowContext(ctx, `
+		SELECT `+deviceColumns+`
+		FROM device_keys
+		WHERE user_id = $1 AND device_id = $2
+		FOR UPDATE
+	`, userID, deviceID))
+}
+
+func (r *Repository) PutDevice(
+	ctx context.Context,
+	tx *sql.Tx,
+	d *domain.Device,
+) error {
+	q := r.getter(tx)
+	_, err := q.ExecContext(ctx, `
+		INSERT INTO device_keys (
+			user_id, device_id, identity_key,
+			signed_prekey_id, signed_prekey, signed_prekey_signature,
+			created_at, updated_at
+		)
+		VALUES ($1,$2,$3,$4,$5,$6,$7,$8)
+		ON CONFLICT (user_id, device_id) DO UPDATE
+		SET identity_key            = EXCLUDED.identity_key,
+		    signed_prekey_id        = EXCLUDED.signed_prekey_id,
+		    signed_prekey           = EXCLUDED.signed_prekey,
+		    signed_prekey_signature = EXCLUDED.signed_prekey_signature,
+		    updated_at              = EXCLUDED.updated_at
+	`,
+		d.UserID,
+		d.DeviceID,
+		d.IdentityKey,
+		int64(d.SignedPreKey.KeyID),
+		d.SignedPreKey.PublicKey,
+		d.SignedPreKey.Signature,
+		d.CreatedAt,
+		d.UpdatedAt,
+	)
+	return err
+}
+
+func (r *Repository) ListDevices(
+	ctx context.Context,
+	tx *sql.Tx,
+	userID, deviceID string,
+) ([]*domain.Device, error) {
+	q := r.getter(tx)
+	rows, err := q.QueryContext(ctx, `
+		SELECT `+deviceColumns+`
+		FROM device_keys
+		WHERE user_id = $1
+		  AND ($2 = '' OR device_id = $2)
+		ORDER BY device_id
+	`, userID, deviceID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var out []*domain.Device
+	for rows.Next() {
+		d, err := scanDevice(rows)
+		if err != nil {
+			return nil, err
+		}
+		out = append(out, d)
+	}
+	return out, rows.Err()
+}
+
+func (r *Repository) DeleteDevice(
+	ctx context.Context,
+	tx *sql.Tx,
+	userID, deviceID string,
+) error {
+	q := r.getter(tx)
+	res, err := q.ExecContext(ctx, `
+		DELETE FROM device_keys
+		WHERE user_id = $1 AND device_id = $2
+	`, userID, deviceID)
+	if err != nil {
+		return err
+	}
+	n, err := res.RowsAffected()
+	if err != nil {
+		return err
+	}
+	if n == 0 {
+		return domain.ErrDeviceNotFound
+	}
+	return nil
+}
+
+func (r *Repository) InsertOneTimePreKeys(
+	ctx context.Context,
+	tx *sql.Tx,
+	userID, deviceID string,
+	keys []domain.OneTimePreKey,
+) error {
+	if len(keys) == 0 {
+		return nil
+	}
+
+	ids := make([]int64, len(keys))
+	pubs := make([][]byte, len(keys))
+	for i, k := range keys {
+		ids[i] = int64(k.KeyID)
+		pubs[i] = k.PublicKey
+	}
+
+	// Re-uploading a key ID keeps the key already stored under it
+	q := r.getter(tx)
+	_, err := q.ExecContext(ctx, `
+		INSERT INTO one_time_prekeys (user_id, device_id, key_id, public_key)
+		SELECT $1, $2, k.key_id, k.public_key
+		FROM unnest($3::bigint[], $4::bytea[]) AS k(key_id, public_key)
+		ON CONFLICT (user_id, device_id, key_id) DO NOTHING
+	`, userID, deviceID, pq.Array(ids), pq.Array(pubs))
+	return err
+}
+
+func (r *Repository) DeleteOneTimePreKeys(
+	ctx context.Context,
+	tx *sql.Tx,
+	userID, deviceID string,
+) error {
+	q := r.getter(tx)
+	_, err := q.ExecContext(ctx, `
+		DELETE FROM one_time_prekeys
+		WHERE user_id = $1 AND device_id = $2
+	`, userID, deviceID)
+	return err
+}
+
+func (r *Repository) CountOneTimePreKeys(
+	ctx context.Context,
+	tx *sql.Tx,
+	userID, deviceID string,
+) (int, error) {
+	q := r.getter(tx)
+	var n int
+	err := q.QueryRowContext(ctx, `
+		SELECT count(*)
+		FROM one_time_prekeys
+		WHERE user_id = $1 AND device_id = $2
+	`, userID, deviceID).Scan(&n)
+	return n, err
+}
+
+func (r *Repository) ClaimOneTimePreKey(
+	ctx context.Context,
+	tx *sql.Tx,
+	userID, deviceID string,
+) (*domain.OneTimePreKey, error) {
+	q := r.getter(tx)
+
+	// Concurrent claims skip each other's rows, so no key is handed out twice
+	var (
+		id  int64
+		key domain.OneTimePreKey
+	)
+	err := q.QueryRowContext(ctx, `
+		DELETE FROM one_time_prekeys
+		WHERE (user_id, device_id, key_id) = (
+			SELECT user_id, device_id, key_id
+			FROM one_time_prekeys
+			WHERE user_id = $1 AND device_id = $2
+			ORDER BY key_id
+			LIMIT 1
+			FOR UPDATE SKIP LOCKED
+		)
+		RETURNING key_id, public_key
+	`, userID, deviceID).Scan(&id, &key.PublicKey)
+	if err == sql.ErrNoRows {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	key.KeyID = uint32(id)
+	return &key, nil
+}
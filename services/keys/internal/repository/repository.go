@@ -0,0 +1,24 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+
+	"github.com/SARVESHVARADKAR123/RealChat/services/keys/internal/domain"
+)
+
+type Repository interface {
+	// Devices
+	GetDeviceForUpdate(ctx context.Context, tx *sql.Tx, userID, deviceID string) (*domain.Device, error)
+	PutDevice(ctx context.Context, tx *sql.Tx, d *domain.Device) error
+	ListDevices(ctx context.Context, tx *sql.Tx, userID, deviceID string) ([]*domain.Device, error)
+	DeleteDevice(ctx context.Context, tx *sql.Tx, userID, deviceID string) error
+
+	// One-time prekeys
+	InsertOneTimePreKeys(ctx context.Context, tx *sql.Tx, userID, deviceID string, keys []domain.OneTimePreKey) error
+	DeleteOneTimePreKeys(ctx context.Context, tx *sql.Tx, userID, deviceID string) error
+	CountOneTimePreKeys(ctx context.Context, tx *sql.Tx, userID, deviceID string) (int, error)
+	// ClaimOneTimePreKey removes and returns one of the device's prekeys,
+	// or nil when it has none left.
+	ClaimOneTimePreKey(ctx context.Context, tx *sql.Tx, userID, deviceID string) (*domain.OneTimePreKey, error)
+}
@@ -0,0 +1,166 @@
+package grpc
+
+import (
+	"context"
+
+	keysv1 "github.com/SARVESHVARADKAR123/RealChat/contracts/gen/go/keys/v1"
+	"github.com/SARVESHVARADKAR123/RealChat/services/keys/internal/application"
+	"github.com/SARVESHVARADKAR123/RealChat/services/keys/internal/auth"
+	"github.com/SARVESHVARADKAR123/RealChat/services/keys/internal/domain"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+func (s *Server) UploadKeys(
+	ctx context.Context,
+	req *keysv1.UploadKeysRequest,
+) (*keysv1.UploadKeysResponse, error) {
+
+	userID, err := auth.GetUserID(ctx)
+	if err != nil {
+		return nil, status.Error(codes.Unauthenticated, err.Error())
+	}
+	if req.SignedPrekey == nil {
+		return nil, status.Error(codes.InvalidArgument, "signed_prekey is required")
+	}
+
+	count, err := s.app.UploadKeys(ctx, application.UploadKeysCommand{
+		UserID:         userID,
+		DeviceID:       req.DeviceId,
+		IdentityKey:    req.IdentityKey,
+		SignedPreKey:   fromProtoSignedPreKey(req.SignedPrekey),
+		OneTimePreKeys: fromProtoPreKeys(req.OneTimePrekeys),
+	})
+	if err != nil {
+		return nil, MapError(err)
+	}
+
+	return &keysv1.UploadKeysResponse{OneTimePrekeyCount: int32(count)}, nil
+}
+
+func (s *Server) GetPreKeyBundles(
+	ctx context.Context,
+	req *keysv1.GetPreKeyBundlesRequest,
+) (*keysv1.GetPreKeyBundlesResponse, error) {
+
+	if _, err := auth.GetUserID(ctx); err != nil {
+		return nil, status.Error(codes.Unauthenticated, err.Error())
+	}
+
+	bundles, err := s.app.GetPreKeyBundles(ctx, req.UserId, req.DeviceId)
+	if err != nil {
+		return nil, MapError(err)
+	}
+
+	resp := &keysv1.GetPreKeyBundlesResponse{
+		Bundles: make([]*keysv1.PreKeyBundle, 0, len(bundles)),
+	}
+	for _, b := range bundles {
+		resp.Bundles = append(resp.Bundles, toProtoBundle(b))
+	}
+	return resp, nil
+}
+
+func (s *Server) ReplenishPreKeys(
+	ctx context.Context,
+	req *keysv1.ReplenishPreKeysRequest,
+) (*keysv1.ReplenishPreKeysResponse, error) {
+
+	userID, err := auth.GetUserID(ctx)
+	if err != nil {
+		return nil, status.Error(codes.Unauthenticated, err.Error())
+	}
+
+	cmd := application.ReplenishPreKeysCommand{
+		UserID:         userID,
+		DeviceID:       req.DeviceId,
+		OneTimePreKeys: fromProtoPreKeys(req.OneTimePrekeys),
+	}
+	if req.SignedPrekey != nil {
+		spk := fromProtoSignedPreKey(req.SignedPrekey)
+		cmd.SignedPreKey = &spk
+	}
+
+	count, err := s.app.ReplenishPreKeys(ctx, cmd)
+	if err != nil {
+		return nil, MapError(err)
+	}
+
+	return &keysv1.ReplenishPreKeysResponse{OneTimePrekeyCount: int32(count)}, nil
+}
+
+func (s *Server) GetPreKeyCount(
+	ctx context.Context,
+	req *keysv1.GetPreKeyCountRequest,
+) (*keysv1.GetPreKeyCountResponse, error) {
+
+	userID, err := auth.GetUserID(ctx)
+	if err != nil {
+		return nil, status.Error(codes.Unauthenticated, err.Error())
+	}
+
+	count, err := s.app.GetPreKeyCount(ctx, userID, req.DeviceId)
+	if err != nil {
+		return nil, MapError(err)
+	}
+
+	return &keysv1.GetPreKeyCountResponse{OneTimePrekeyCount: int32(count)}, nil
+}
+
+func (s *Server) RemoveDevice(
+	ctx context.Context,
+	req *keysv1.RemoveDeviceRequest,
+) (*keysv1.RemoveDeviceResponse, error) {
+
+	userID, err := auth.GetUserID(ctx)
+	if err != nil {
+		return nil, status.Error(codes.Unauthenticated, err.Error())
+	}
+
+	if err := s.app.RemoveDevice(ctx, userID, req.DeviceId); err != nil {
+		return nil, MapError(err)
+	}
+
+	return &keysv1.RemoveDeviceResponse{}, nil
+}
+
+func fromProtoSignedPreKey(k *keysv1.SignedPreKey) domain.SignedPreKey {
+	return domain.SignedPreKey{
+		KeyID:     k.KeyId,
+		PublicKey: k.PublicKey,
+		Signature: k.Signature,
+	}
+}
+
+func fromProtoPreKeys(keys []*keysv1.OneTimePreKey) []domain.OneTimePreKey {
+	out := make([]domain.OneTimePreKey, 0, len(keys))
+	for _, k := range keys {
+		if k == nil {
+			continue
+		}
+		out = append(out, domain.OneTimePreKey{KeyID: k.KeyId, PublicKey: k.PublicKey})
+	}
+	return out
+}
+
+func toProtoBundle(b *domain.Bundle) *keysv1.PreKeyBundle {
+	pb := &keysv1.PreKeyBundle{
+		UserId:      b.UserID,
+		DeviceId:    b.DeviceID,
+		IdentityKey: b.IdentityKey,
+		SignedPrekey: &keysv1.SignedPreKey{
+			KeyId:     b.SignedPreKey.KeyID,
+			PublicKey: b.SignedPreKey.PublicKey,
+			Signature: b.SignedPreKey.Signature,
+		},
+		UpdatedAt: timestamppb.New(b.UpdatedAt),
+	}
+	if b.OneTimePreKey != nil {
+		pb.OneTimePrekey = &keysv1.OneTimePreKey{
+			KeyId:     b.OneTimePreKey.KeyID,
+			PublicKey: b.OneTimePreKey.PublicKey,
+		}
+	}
+	return pb
+}
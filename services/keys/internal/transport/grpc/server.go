@@ -0,0 +1,55 @@
+package grpc
+
+import (
+	"log"
+	"net"
+
+	"go.opentelemetry.io/contrib/instrumentation/google.golang.org/grpc/otelgrpc"
+	"google.golang.org/grpc"
+
+	keysv1 "github.com/SARVESHVARADKAR123/RealChat/contracts/gen/go/keys/v1"
+	"github.com/SARVESHVARADKAR123/RealChat/services/keys/internal/application"
+	"github.com/SARVESHVARADKAR123/RealChat/services/keys/internal/auth"
+)
+
+type Server struct {
+	keysv1.UnimplementedKeyApiServer
+	grpcServer *grpc.Server
+	app        *application.Service
+}
+
+func New(app *application.Service) *Server {
+	grpcServer := grpc.NewServer(
+		grpc.StatsHandler(otelgrpc.NewServerHandler()),
+		grpc.UnaryInterceptor(auth.Interceptor),
+	)
+
+	s := &Server{
+		grpcServer: grpcServer,
+		app:        app,
+	}
+
+	keysv1.RegisterKeyApiServer(
+		grpcServer,
+		s,
+	)
+
+	return s
+}
+
+func (s *Server) Start(port string) {
+	lis, err := net.Listen("tcp", port)
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	log.Println("gRPC listening on", port)
+	if err := s.grpcServer.Serve(lis); err != nil {
+		log.Println("gRPC server stopped:", err)
+	}
+}
+
+func (s *Server) Stop() {
+	log.Println("shutting down gRPC...")
+	s.grpcServer.GracefulStop()
+}
@@ -0,0 +1,31 @@
+CREATE TABLE device_keys (
+    user_id                 TEXT NOT NULL,
+    -- the device_id the device connects to delivery with
+    device_id               TEXT NOT NULL,
+
+    -- Ed25519 public key
+    identity_key            BYTEA NOT NULL,
+
+    -- X25519 public key signed with the identity key
+    signed_prekey_id        BIGINT NOT NULL,
+    signed_prekey           BYTEA NOT NULL,
+    signed_prekey_signature BYTEA NOT NULL,
+
+    created_at              TIMESTAMPTZ NOT NULL DEFAULT now(),
+    updated_at              TIMESTAMPTZ NOT NULL DEFAULT now(),
+
+    PRIMARY KEY (user_id, device_id)
+);
+
+CREATE TABLE one_time_prekeys (
+    user_id    TEXT NOT NULL,
+    device_id  TEXT NOT NULL,
+    key_id     BIGINT NOT NULL,
+    public_key BYTEA NOT NULL,
+
+    created_at TIMESTAMPTZ NOT NULL DEFAULT now(),
+
+    PRIMARY KEY (user_id, device_id, key_id),
+    FOREIGN KEY (user_id, device_id)
+        REFERENCES device_keys(user_id, device_id) ON DELETE CASCADE
+);
@@ -0,0 +1,36 @@
+package application
+
+import (
+	"context"
+	"io"
+	"time"
+
+	"github.com/SARVESHVARADKAR123/RealChat/services/media/internal/domain"
+)
+
+func (s *Service) GetAttachments(ctx context.Context, ids []string) ([]*domain.Attachment, error) {
+	if len(ids) == 0 {
+		return nil, nil
+	}
+	return s.repo.GetAttachments(ctx, ids)
+}
+
+// DownloadURL returns a signed, expiring URL for the attachment.
+func (s *Service) DownloadURL(attachmentID string) (string, time.Time) {
+	return s.signer.SignedURL(attachmentID, time.Now())
+}
+
+// OpenContent returns the attachment and a reader over its content. The
+// caller must close the reader.
+func (s *Service) OpenContent(ctx context.Context, attachmentID string) (*domain.Attachment, io.ReadCloser, error) {
+	att, err := s.repo.GetAttachment(ctx, attachmentID)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	rc, err := s.blobs.Open(ctx, att.ContentHash)
+	if err != nil {
+		return nil, nil, err
+	}
+	return att, rc, nil
+}
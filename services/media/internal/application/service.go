@@ -0,0 +1,34 @@
+package application
+
+import (
+	"time"
+
+	"github.com/SARVESHVARADKAR123/RealChat/services/media/internal/blob"
+	"github.com/SARVESHVARADKAR123/RealChat/services/media/internal/domain"
+	"github.com/SARVESHVARADKAR123/RealChat/services/media/internal/repository"
+	"github.com/SARVESHVARADKAR123/RealChat/services/media/internal/signer"
+	"github.com/SARVESHVARADKAR123/RealChat/services/media/internal/tx"
+	"go.uber.org/zap"
+)
+
+// Options holds the upload policy of the service.
+type Options struct {
+	Limits     domain.Limits
+	SessionTTL time.Duration
+	// SpoolDir holds uploads while they are hashed and checked. Empty means
+	// the OS temp directory.
+	SpoolDir string
+}
+
+type Service struct {
+	repo   repository.Repository
+	tx     tx.Transactor
+	blobs  blob.Store
+	signer *signer.Signer
+	log    *zap.Logger
+	opts   Options
+}
+
+func New(repo repository.Repository, transactor tx.Transactor, blobs blob.Store, signer *signer.Signer, log *zap.Logger, opts Options) *Service {
+	return &Service{repo: repo, tx: transactor, blobs: blobs, signer: signer, log: log, opts: opts}
+}
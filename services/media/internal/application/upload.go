@@ -0,0 +1,197 @@
+package application
+
+import (
+	"context"
+	"crypto/sha256"
+	"database/sql"
+	"encoding/hex"
+	"fmt"
+	"io"
+	"mime"
+	"net/http"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/SARVESHVARADKAR123/RealChat/services/media/internal/domain"
+	"github.com/SARVESHVARADKAR123/RealChat/services/media/internal/observability"
+	"github.com/google/uuid"
+	"go.uber.org/zap"
+)
+
+type CreateUploadCommand struct {
+	OwnerID   string
+	FileName  string
+	MimeType  string
+	SizeBytes int64
+}
+
+func (s *Service) CreateUploadSession(
+	ctx context.Context,
+	cmd CreateUploadCommand,
+) (*domain.UploadSession, error) {
+
+	session, err := domain.NewUploadSession(
+		uuid.NewString(),
+		cmd.OwnerID,
+		cmd.FileName,
+		cmd.MimeType,
+		cmd.SizeBytes,
+		s.opts.Limits,
+		time.Now().UTC(),
+		s.opts.SessionTTL,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := s.repo.InsertUploadSession(ctx, nil, session); err != nil {
+		return nil, err
+	}
+	return session, nil
+}
+
+// UploadContent reads the content of an upload session from r, checks it
+// against the declared size and the MIME allow list, stores it under its
+// content hash and completes the session into an attachment.
+func (s *Service) UploadContent(
+	ctx context.Context,
+	uploadID string,
+	userID string,
+	r io.Reader,
+) (*domain.Attachment, error) {
+
+	att, err := s.uploadContent(ctx, uploadID, userID, r)
+	if err != nil {
+		observability.UploadsTotal.WithLabelValues("rejected").Inc()
+		return nil, err
+	}
+
+	observability.UploadsTotal.WithLabelValues("completed").Inc()
+	observability.UploadedBytesTotal.Add(float64(att.SizeBytes))
+	return att, nil
+}
+
+func (s *Service) uploadContent(
+	ctx context.Context,
+	uploadID string,
+	userID string,
+	r io.Reader,
+) (*domain.Attachment, error) {
+
+	// 1️⃣ Fail fast before reading any bytes
+	session, err := s.repo.GetUploadSession(ctx, nil, uploadID)
+	if err != nil {
+		return nil, err
+	}
+	if err := session.CanUpload(userID, time.Now().UTC()); err != nil {
+		return nil, err
+	}
+
+	// 2️⃣ Spool to disk while hashing; never accept more than declared
+	spool, err := os.CreateTemp(s.opts.SpoolDir, "upload-*")
+	if err != nil {
+		return nil, err
+	}
+	defer os.Remove(spool.Name())
+	defer spool.Close()
+
+	hash := sha256.New()
+	n, err := io.Copy(io.MultiWriter(spool, hash), io.LimitReader(r, session.SizeBytes+1))
+	if err != nil {
+		return nil, err
+	}
+	if n != session.SizeBytes {
+		return nil, domain.ErrSizeMismatch
+	}
+
+	// 3️⃣ The sniffed type must be allowed too, whatever the client declared
+	head := make([]byte, 512)
+	hn, err := spool.ReadAt(head, 0)
+	if err != nil && err != io.EOF {
+		return nil, err
+	}
+	verified := contentType(session.MimeType, head[:hn])
+	if !s.opts.Limits.AllowsMIME(verified) {
+		s.log.Info("upload rejected by content sniffing",
+			zap.String("upload_id", uploadID),
+			zap.String("declared", session.MimeType),
+			zap.String("sniffed", verified),
+		)
+		return nil, domain.ErrUnsupportedType
+	}
+
+	// 4️⃣ Content-addressed write; identical content is stored once
+	key := hex.EncodeToString(hash.Sum(nil))
+	exists, err := s.blobs.Exists(ctx, key)
+	if err != nil {
+		return nil, err
+	}
+	if !exists {
+		if _, err := spool.Seek(0, io.SeekStart); err != nil {
+			return nil, err
+		}
+		if err := s.blobs.Put(ctx, key, spool); err != nil {
+			return nil, fmt.Errorf("failed to store blob: %w", err)
+		}
+	}
+
+	// 5️⃣ Complete the session exactly once
+	att := &domain.Attachment{
+		ID:          uuid.NewString(),
+		OwnerID:     session.OwnerID,
+		FileName:    session.FileName,
+		MimeType:    session.MimeType,
+		SizeBytes:   session.SizeBytes,
+		ContentHash: key,
+		CreatedAt:   time.Now().UTC(),
+		ContentType: verified,
+	}
+
+	err = s.tx.WithTx(ctx, func(ctx context.Context, tx *sql.Tx) error {
+		locked, err := s.repo.GetUploadSessionForUpdate(ctx, tx, uploadID)
+		if err != nil {
+			return err
+		}
+		if err := locked.CanUpload(userID, time.Now().UTC()); err != nil {
+			return err
+		}
+		if err := s.repo.InsertAttachment(ctx, tx, att); err != nil {
+			return err
+		}
+		return s.repo.CompleteUploadSession(ctx, tx, uploadID, att.ID)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return att, nil
+}
+
+// contentType returns the type of the content starting with head. Some
+// types are only known to the sniffer by a more general name, Ogg audio as
+// application/ogg and MP3s without an ID3 tag as plain binary; those are
+// narrowed to the declared type when the content fits it.
+func contentType(declared string, head []byte) string {
+	sniffed := http.DetectContentType(head)
+	base, _, _ := mime.ParseMediaType(declared)
+	base = strings.ToLower(base)
+
+	switch {
+	case sniffed == "application/ogg" && (base == "audio/ogg" || base == "video/ogg"):
+		return base
+	case sniffed == "application/octet-stream" && base == "audio/mpeg" && mp3FrameSync(head):
+		return base
+	}
+	return sniffed
+}
+
+// mp3FrameSync reports whether head starts with an MPEG audio frame header:
+// eleven set sync bits, then a valid version, layer and bitrate.
+func mp3FrameSync(head []byte) bool {
+	if len(head) < 3 || head[0] != 0xFF || head[1]&0xE0 != 0xE0 {
+		return false
+	}
+	version, layer, bitrate := head[1]>>3&0x3, head[1]>>1&0x3, head[2]>>4
+	return version != 1 && layer != 0 && bitrate != 0xF
+}
@@ -0,0 +1,214 @@
+package application
+
+import (
+	"bytes"
+	"context"
+	"database/sql"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/SARVESHVARADKAR123/RealChat/services/media/internal/blob"
+	"github.com/SARVESHVARADKAR123/RealChat/services/media/internal/domain"
+	"github.com/SARVESHVARADKAR123/RealChat/services/media/internal/signer"
+	"go.uber.org/zap"
+)
+
+type memRepo struct {
+	sessions    map[string]*domain.UploadSession
+	attachments map[string]*domain.Attachment
+}
+
+func newMemRepo() *memRepo {
+	return &memRepo{
+		sessions:    map[string]*domain.UploadSession{},
+		attachments: map[string]*domain.Attachment{},
+	}
+}
+
+func (m *memRepo) InsertUploadSession(ctx context.Context, tx *sql.Tx, s *domain.UploadSession) error {
+	cp := *s
+	m.sessions[s.ID] = &cp
+	return nil
+}
+func (m *memRepo) GetUploadSession(ctx context.Context, tx *sql.Tx, id string) (*domain.UploadSession, error) {
+	s, ok := m.sessions[id]
+	if !ok {
+		return nil, domain.ErrUploadNotFound
+	}
+	cp := *s
+	return &cp, nil
+}
+func (m *memRepo) GetUploadSessionForUpdate(ctx context.Context, tx *sql.Tx, id string) (*domain.UploadSession, error) {
+	return m.GetUploadSession(ctx, tx, id)
+}
+func (m *memRepo) CompleteUploadSession(ctx context.Context, tx *sql.Tx, id, attachmentID string) error {
+	m.sessions[id].AttachmentID = attachmentID
+	return nil
+}
+func (m *memRepo) InsertAttachment(ctx context.Context, tx *sql.Tx, a *domain.Attachment) error {
+	m.attachments[a.ID] = a
+	return nil
+}
+func (m *memRepo) GetAttachment(ctx context.Context, id string) (*domain.Attachment, error) {
+	a, ok := m.attachments[id]
+	if !ok {
+		return nil, domain.ErrAttachmentNotFound
+	}
+	return a, nil
+}
+func (m *memRepo) GetAttachments(ctx context.Context, ids []string) ([]*domain.Attachment, error) {
+	var out []*domain.Attachment
+	for _, id := range ids {
+		if a, ok := m.attachments[id]; ok {
+			out = append(out, a)
+		}
+	}
+	return out, nil
+}
+
+type noTx struct{}
+
+func (noTx) WithTx(ctx context.Context, fn func(ctx context.Context, tx *sql.Tx) error) error {
+	return fn(ctx, nil)
+}
+
+func newTestService(t *testing.T) *Service {
+	return New(newMemRepo(), noTx{}, &blob.LocalFS{Root: t.TempDir()},
+		&signer.Signer{BaseURL: "http://gw/api/media", Key: []byte("k"), TTL: time.Hour},
+		zap.NewNop(),
+		Options{
+			Limits:     domain.Limits{MaxBytes: 1 << 20, AllowedMIMETypes: []string{"text/plain", "image/png"}},
+			SessionTTL: time.Hour,
+			SpoolDir:   t.TempDir(),
+		},
+	)
+}
+
+func TestUploadContent(t *testing.T) {
+	ctx := context.Background()
+	content := []byte("hello, attachments")
+
+	upload := func(svc *Service, owner, uploader, mime string, declared int64, body []byte) (*domain.Attachment, error) {
+		session, err := svc.CreateUploadSession(ctx, CreateUploadCommand{
+			OwnerID: owner, FileName: "notes.txt", MimeType: mime, SizeBytes: declared,
+		})
+		if err != nil {
+			return nil, err
+		}
+		return svc.UploadContent(ctx, session.ID, uploader, bytes.NewReader(body))
+	}
+
+	t.Run("Identical content shares one blob", func(t *testing.T) {
+		svc := newTestService(t)
+		a1, err := upload(svc, "u1", "u1", "text/plain", int64(len(content)), content)
+		if err != nil {
+			t.Fatalf("first upload: %v", err)
+		}
+		a2, err := upload(svc, "u2", "u2", "text/plain", int64(len(content)), content)
+		if err != nil {
+			t.Fatalf("second upload: %v", err)
+		}
+		if a1.ID == a2.ID || a1.ContentHash != a2.ContentHash {
+			t.Fatalf("want distinct attachments over one blob, got %+v and %+v", a1, a2)
+		}
+	})
+
+	t.Run("Size must match the declaration", func(t *testing.T) {
+		svc := newTestService(t)
+		_, err := upload(svc, "u1", "u1", "text/plain", int64(len(content))-1, content)
+		if !errors.Is(err, domain.ErrSizeMismatch) {
+			t.Fatalf("err = %v, want ErrSizeMismatch", err)
+		}
+	})
+
+	t.Run("Sniffed type must be allowed", func(t *testing.T) {
+		svc := newTestService(t)
+		html := []byte("<html><script>alert(1)</script></html>")
+		_, err := upload(svc, "u1", "u1", "image/png", int64(len(html)), html)
+		if !errors.Is(err, domain.ErrUnsupportedType) {
+			t.Fatalf("err = %v, want ErrUnsupportedType", err)
+		}
+	})
+
+	t.Run("Only the owner can upload", func(t *testing.T) {
+		svc := newTestService(t)
+		_, err := upload(svc, "u1", "u2", "text/plain", int64(len(content)), content)
+		if !errors.Is(err, domain.ErrNotOwner) {
+			t.Fatalf("err = %v, want ErrNotOwner", err)
+		}
+	})
+
+	t.Run("Declared limits are enforced", func(t *testing.T) {
+		svc := newTestService(t)
+		if _, err := upload(svc, "u1", "u1", "application/x-msdownload", 10, nil); !errors.Is(err, domain.ErrUnsupportedType) {
+			t.Fatalf("err = %v, want ErrUnsupportedType", err)
+		}
+		if _, err := upload(svc, "u1", "u1", "text/plain", 2<<20, nil); !errors.Is(err, domain.ErrFileTooLarge) {
+			t.Fatalf("err = %v, want ErrFileTooLarge", err)
+		}
+	})
+}
+
+func TestUploadContent_DefaultTypes(t *testing.T) {
+	ctx := context.Background()
+	pad := func(head string) []byte {
+		return append([]byte(head), make([]byte, 64)...)
+	}
+
+	tests := []struct {
+		mime    string
+		content []byte
+		want    string // verified type; empty when the upload is refused
+	}{
+		{"image/jpeg", pad("\xFF\xD8\xFF\xE0\x00\x10JFIF\x00"), "image/jpeg"},
+		{"image/png", pad("\x89PNG\r\n\x1a\n"), "image/png"},
+		{"image/gif", pad("GIF89a"), "image/gif"},
+		{"image/webp", pad("RIFF\x00\x00\x00\x00WEBPVP8 "), "image/webp"},
+		{"video/mp4", pad("\x00\x00\x00\x18ftypmp42\x00\x00\x00\x00mp42isom"), "video/mp4"},
+		{"audio/mpeg", pad("ID3\x03\x00\x00\x00\x00\x00\x00"), "audio/mpeg"},
+		{"audio/mpeg", pad("\xFF\xFB\x90\x64"), "audio/mpeg"},
+		{"audio/ogg", pad("OggS\x00\x02"), "audio/ogg"},
+		{"application/pdf", pad("%PDF-1.7\n"), "application/pdf"},
+		{"text/plain", []byte("just some notes\n"), "text/plain; charset=utf-8"},
+
+		// Binary that only passes for MP3 or Ogg when declared as such
+		{"audio/mpeg", pad("\x00\x01\x02\x03"), ""},
+		{"audio/mpeg", pad("\xFF\xFF\xF0\x00"), ""},
+		{"text/plain", pad("OggS\x00\x02"), ""},
+		{"text/plain", pad("\xFF\xFB\x90\x64"), ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.mime, func(t *testing.T) {
+			svc := newTestService(t)
+			svc.opts.Limits.AllowedMIMETypes = []string{
+				"image/jpeg", "image/png", "image/gif", "image/webp", "video/mp4",
+				"audio/mpeg", "audio/ogg", "application/pdf", "text/plain",
+			}
+
+			session, err := svc.CreateUploadSession(ctx, CreateUploadCommand{
+				OwnerID: "u1", FileName: "file", MimeType: tt.mime, SizeBytes: int64(len(tt.content)),
+			})
+			if err != nil {
+				t.Fatal(err)
+			}
+			att, err := svc.UploadContent(ctx, session.ID, "u1", bytes.NewReader(tt.content))
+			if tt.want == "" {
+				if !errors.Is(err, domain.ErrUnsupportedType) {
+					t.Fatalf("err = %v, want ErrUnsupportedType", err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatal(err)
+			}
+			if att.ContentType != tt.want {
+				t.Fatalf("content type = %q, want %q", att.ContentType, tt.want)
+			}
+			if att.MimeType != tt.mime {
+				t.Fatalf("mime type = %q, want the declared %q", att.MimeType, tt.mime)
+			}
+		})
+	}
+}
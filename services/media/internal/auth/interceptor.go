@@ -0,0 +1,96 @@
+package auth
+
+import (
+	"context"
+	"errors"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+)
+
+type contextKey string
+
+const (
+	UserIDKey       contextKey = "user_id"
+	RequestIDKey    contextKey = "request_id"
+	HeaderUserID               = "x-user-id"
+	HeaderRequestID            = "x-request-id"
+)
+
+// DownloadContent is called by the gateway after it has verified a signed
+// URL, on behalf of whoever holds the link, so it carries no user ID.
+const downloadMethod = "/realchat.media.v1.MediaApi/DownloadContent"
+
+// withIdentity extracts the x-user-id and x-request-id headers into the context.
+func withIdentity(ctx context.Context, method string) (context.Context, error) {
+	md, _ := metadata.FromIncomingContext(ctx)
+
+	var newCtx context.Context = ctx
+	userValues := md.Get(HeaderUserID)
+	if len(userValues) > 0 && userValues[0] != "" {
+		newCtx = context.WithValue(newCtx, UserIDKey, userValues[0])
+	} else if method != downloadMethod {
+		return nil, status.Error(codes.Unauthenticated, "x-user-id header is missing")
+	}
+
+	reqValues := md.Get(HeaderRequestID)
+	if len(reqValues) > 0 && reqValues[0] != "" {
+		newCtx = context.WithValue(newCtx, RequestIDKey, reqValues[0])
+	}
+
+	return newCtx, nil
+}
+
+// Interceptor extracts the x-user-id and x-request-id headers and injects them into the context.
+func Interceptor(
+	ctx context.Context,
+	req interface{},
+	info *grpc.UnaryServerInfo,
+	handler grpc.UnaryHandler,
+) (interface{}, error) {
+
+	newCtx, err := withIdentity(ctx, info.FullMethod)
+	if err != nil {
+		return nil, err
+	}
+	return handler(newCtx, req)
+}
+
+// StreamInterceptor is the streaming counterpart of Interceptor.
+func StreamInterceptor(
+	srv interface{},
+	ss grpc.ServerStream,
+	info *grpc.StreamServerInfo,
+	handler grpc.StreamHandler,
+) error {
+
+	newCtx, err := withIdentity(ss.Context(), info.FullMethod)
+	if err != nil {
+		return err
+	}
+	return handler(srv, &wrappedStream{ServerStream: ss, ctx: newCtx})
+}
+
+type wrappedStream struct {
+	grpc.ServerStream
+	ctx context.Context
+}
+
+func (w *wrappedStream) Context() context.Context {
+	return w.ctx
+}
+
+// GetUserID retrieves the authenticated user ID from context.
+func GetUserID(ctx context.Context) (string, error) {
+	val := ctx.Value(UserIDKey)
+	if val == nil {
+		return "", errors.New("user id not found in context")
+	}
+	id, ok := val.(string)
+	if !ok {
+		return "", errors.New("invalid user id type")
+	}
+	return id, nil
+}
@@ -0,0 +1,71 @@
+package blob
+
+import (
+	"context"
+	"errors"
+	"io"
+	"io/fs"
+	"os"
+	"path/filepath"
+)
+
+// LocalFS stores blobs under Root, fanned out by the first two bytes of the
+// key (ab/cd/abcd...) to keep directories small. Meant for dev and tests.
+type LocalFS struct {
+	Root string
+}
+
+func (l *LocalFS) path(key string) string {
+	return filepath.Join(l.Root, key[:2], key[2:4], key)
+}
+
+func (l *LocalFS) Exists(ctx context.Context, key string) (bool, error) {
+	if !ValidKey(key) {
+		return false, ErrInvalidKey
+	}
+	_, err := os.Stat(l.path(key))
+	if errors.Is(err, fs.ErrNotExist) {
+		return false, nil
+	}
+	return err == nil, err
+}
+
+// Put writes to a temporary file and renames it into place, so readers never
+// observe a partial blob.
+func (l *LocalFS) Put(ctx context.Context, key string, r io.Reader) error {
+	if !ValidKey(key) {
+		return ErrInvalidKey
+	}
+
+	dst := l.path(key)
+	if err := os.MkdirAll(filepath.Dir(dst), 0o755); err != nil {
+		return err
+	}
+
+	tmp, err := os.CreateTemp(filepath.Dir(dst), ".upload-*")
+	if err != nil {
+		return err
+	}
+	defer os.Remove(tmp.Name())
+
+	if _, err := io.Copy(tmp, r); err != nil {
+		tmp.Close()
+		return err
+	}
+	if err := tmp.Close(); err != nil {
+		return err
+	}
+
+	return os.Rename(tmp.Name(), dst)
+}
+
+func (l *LocalFS) Open(ctx context.Context, key string) (io.ReadCloser, error) {
+	if !ValidKey(key) {
+		return nil, ErrInvalidKey
+	}
+	f, err := os.Open(l.path(key))
+	if errors.Is(err, fs.ErrNotExist) {
+		return nil, ErrNotFound
+	}
+	return f, err
+}
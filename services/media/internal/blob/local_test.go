@@ -0,0 +1,49 @@
+package blob
+
+import (
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"io"
+	"testing"
+)
+
+func TestLocalFS(t *testing.T) {
+	ctx := context.Background()
+	store := &LocalFS{Root: t.TempDir()}
+
+	content := []byte("hello attachments")
+	sum := sha256.Sum256(content)
+	key := hex.EncodeToString(sum[:])
+
+	if ok, err := store.Exists(ctx, key); err != nil || ok {
+		t.Fatalf("Exists before Put = %v, %v; want false, nil", ok, err)
+	}
+
+	if err := store.Put(ctx, key, bytes.NewReader(content)); err != nil {
+		t.Fatalf("Put: %v", err)
+	}
+
+	if ok, err := store.Exists(ctx, key); err != nil || !ok {
+		t.Fatalf("Exists after Put = %v, %v; want true, nil", ok, err)
+	}
+
+	rc, err := store.Open(ctx, key)
+	if err != nil {
+		t.Fatalf("Open: %v", err)
+	}
+	defer rc.Close()
+
+	got, err := io.ReadAll(rc)
+	if err != nil {
+		t.Fatalf("ReadAll: %v", err)
+	}
+	if !bytes.Equal(got, content) {
+		t.Fatalf("content = %q, want %q", got, content)
+	}
+
+	if _, err := store.Open(ctx, "../../etc/passwd"); err != ErrInvalidKey {
+		t.Fatalf("Open with traversal key = %v, want ErrInvalidKey", err)
+	}
+}
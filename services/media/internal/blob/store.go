@@ -0,0 +1,30 @@
+package blob
+
+import (
+	"context"
+	"encoding/hex"
+	"errors"
+	"io"
+)
+
+var (
+	ErrNotFound   = errors.New("blob not found")
+	ErrInvalidKey = errors.New("invalid blob key")
+)
+
+// Store is a content-addressed blob store. Keys are hex SHA-256 digests of
+// the content, so writing a key that already exists can be skipped.
+type Store interface {
+	Exists(ctx context.Context, key string) (bool, error)
+	Put(ctx context.Context, key string, r io.Reader) error
+	Open(ctx context.Context, key string) (io.ReadCloser, error)
+}
+
+// ValidKey reports whether key looks like a hex SHA-256 digest.
+func ValidKey(key string) bool {
+	if len(key) != 64 {
+		return false
+	}
+	_, err := hex.DecodeString(key)
+	return err == nil
+}
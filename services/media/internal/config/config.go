@@ -0,0 +1,131 @@
+package config
+
+import (
+	"log"
+	"os"
+	"strconv"
+	"strings"
+	"time"
+)
+
+type Config struct {
+	GRPCAddr       string
+	DatabaseURL    string
+	ServiceName    string
+	ObsHTTPAddr    string
+	MetricsEnabled bool
+	TracingEnabled bool
+	JaegerURL      string
+
+	// Storage
+	BlobDir string
+
+	// Upload limits
+	MaxUploadBytes   int64
+	AllowedMIMETypes []string
+	UploadSessionTTL time.Duration
+
+	// Signed download URLs, verified by the gateway with the same secret.
+	DownloadBaseURL string
+	URLSigningKey   string
+	DownloadURLTTL  time.Duration
+}
+
+func Load() *Config {
+	return &Config{
+		GRPCAddr:         fixPort(mustEnv("GRPC_ADDR")),
+		DatabaseURL:      mustEnv("DATABASE_URL"),
+		ServiceName:      mustEnv("SERVICE_NAME"),
+		ObsHTTPAddr:      fixPort(mustEnv("HTTP_ADDR")),
+		MetricsEnabled:   getEnvBool("METRICS_ENABLED", false),
+		TracingEnabled:   getEnvBool("TRACING_ENABLED", false),
+		JaegerURL:        getEnv("JAEGER_URL", "http://jaeger:14268/api/traces"),
+		BlobDir:          getEnv("MEDIA_BLOB_DIR", "/var/lib/realchat/media"),
+		MaxUploadBytes:   getEnvInt64("MEDIA_MAX_UPLOAD_BYTES", 25<<20),
+		AllowedMIMETypes: getEnvList("MEDIA_ALLOWED_MIME_TYPES", defaultMIMETypes),
+		UploadSessionTTL: getEnvDuration("MEDIA_UPLOAD_SESSION_TTL", time.Hour),
+		DownloadBaseURL:  mustEnv("MEDIA_DOWNLOAD_BASE_URL"),
+		URLSigningKey:    mustEnv("MEDIA_URL_SIGNING_KEY"),
+		DownloadURLTTL:   getEnvDuration("MEDIA_DOWNLOAD_URL_TTL", time.Hour),
+	}
+}
+
+var defaultMIMETypes = []string{
+	"image/jpeg",
+	"image/png",
+	"image/gif",
+	"image/webp",
+	"video/mp4",
+	"audio/mpeg",
+	"audio/ogg",
+	"application/pdf",
+	"text/plain",
+}
+
+func fixPort(port string) string {
+	if port != "" && !strings.Contains(port, ":") {
+		return ":" + port
+	}
+	return port
+}
+
+func getEnvBool(key string, fallback bool) bool {
+	v := os.Getenv(key)
+	if v == "" {
+		return fallback
+	}
+	return v == "true"
+}
+
+func mustEnv(k string) string {
+	v := os.Getenv(k)
+	if v == "" {
+		log.Fatalf("missing required env: %s", k)
+	}
+	return v
+}
+
+func getEnv(key, fallback string) string {
+	if value, exists := os.LookupEnv(key); exists {
+		return value
+	}
+	return fallback
+}
+
+func getEnvInt64(key string, fallback int64) int64 {
+	v := os.Getenv(key)
+	if v == "" {
+		return fallback
+	}
+	n, err := strconv.ParseInt(v, 10, 64)
+	if err != nil {
+		log.Fatalf("invalid integer for %s: %v", key, err)
+	}
+	return n
+}
+
+func getEnvDuration(key string, fallback time.Duration) time.Duration {
+	v := os.Getenv(key)
+	if v == "" {
+		return fallback
+	}
+	d, err := time.ParseDuration(v)
+	if err != nil {
+		log.Fatalf("invalid duration for %s: %v", key, err)
+	}
+	return d
+}
+
+func getEnvList(key string, fallback []string) []string {
+	v := os.Getenv(key)
+	if v == "" {
+		return fallback
+	}
+	var out []string
+	for _, item := range strings.Split(v, ",") {
+		if item = strings.TrimSpace(item); item != "" {
+			out = append(out, item)
+		}
+	}
+	return out
+}
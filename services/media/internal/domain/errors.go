@@ -0,0 +1,15 @@
+package domain
+
+import "errors"
+
+var (
+	ErrInvalidInput       = errors.New("invalid input")
+	ErrFileTooLarge       = errors.New("file exceeds the upload size limit")
+	ErrUnsupportedType    = errors.New("unsupported media type")
+	ErrSizeMismatch       = errors.New("uploaded size does not match the declared size")
+	ErrUploadNotFound     = errors.New("upload session not found")
+	ErrUploadExpired      = errors.New("upload session expired")
+	ErrUploadCompleted    = errors.New("upload session already completed")
+	ErrNotOwner           = errors.New("upload session belongs to another user")
+	ErrAttachmentNotFound = errors.New("attachment not found")
+)
@@ -0,0 +1,114 @@
+package domain
+
+import (
+	"mime"
+	"path/filepath"
+	"strings"
+	"time"
+)
+
+const MaxFileNameLength = 255
+
+// Limits are the upload constraints enforced on every session.
+type Limits struct {
+	MaxBytes         int64
+	AllowedMIMETypes []string
+}
+
+// AllowsMIME reports whether the base type of mimeType (parameters such as
+// charset are ignored) is on the allow list.
+func (l Limits) AllowsMIME(mimeType string) bool {
+	base, _, err := mime.ParseMediaType(mimeType)
+	if err != nil {
+		return false
+	}
+	for _, allowed := range l.AllowedMIMETypes {
+		if strings.EqualFold(base, allowed) {
+			return true
+		}
+	}
+	return false
+}
+
+// UploadSession is a reserved upload. It is completed at most once, when its
+// content has been received and stored.
+type UploadSession struct {
+	ID           string
+	OwnerID      string
+	FileName     string
+	MimeType     string
+	SizeBytes    int64
+	AttachmentID string
+	CreatedAt    time.Time
+	ExpiresAt    time.Time
+}
+
+func NewUploadSession(
+	id string,
+	ownerID string,
+	fileName string,
+	mimeType string,
+	sizeBytes int64,
+	limits Limits,
+	now time.Time,
+	ttl time.Duration,
+) (*UploadSession, error) {
+
+	if id == "" || ownerID == "" {
+		return nil, ErrInvalidInput
+	}
+
+	fileName = filepath.Base(strings.TrimSpace(fileName))
+	if fileName == "." || fileName == "/" || len(fileName) > MaxFileNameLength {
+		return nil, ErrInvalidInput
+	}
+
+	if sizeBytes <= 0 {
+		return nil, ErrInvalidInput
+	}
+	if sizeBytes > limits.MaxBytes {
+		return nil, ErrFileTooLarge
+	}
+
+	if !limits.AllowsMIME(mimeType) {
+		return nil, ErrUnsupportedType
+	}
+
+	return &UploadSession{
+		ID:        id,
+		OwnerID:   ownerID,
+		FileName:  fileName,
+		MimeType:  mimeType,
+		SizeBytes: sizeBytes,
+		CreatedAt: now,
+		ExpiresAt: now.Add(ttl),
+	}, nil
+}
+
+// CanUpload checks that userID may send content to the session at now.
+func (s *UploadSession) CanUpload(userID string, now time.Time) error {
+	if s.OwnerID != userID {
+		return ErrNotOwner
+	}
+	if s.AttachmentID != "" {
+		return ErrUploadCompleted
+	}
+	if now.After(s.ExpiresAt) {
+		return ErrUploadExpired
+	}
+	return nil
+}
+
+// Attachment is stored content that messages can reference by ID.
+type Attachment struct {
+	ID          string
+	OwnerID     string
+	FileName    string
+	MimeType    string
+	SizeBytes   int64
+	ContentHash string
+	CreatedAt   time.Time
+	// ContentType is the type verified from the content, which downloads
+	// are served as. MimeType is the one the uploader declared.
+	ContentType string
+}
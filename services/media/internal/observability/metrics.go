@@ -0,0 +1,49 @@
+package observability
+
+import (
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promauto"
+)
+
+var (
+	HttpRequestsTotal = promauto.NewCounterVec(
+		prometheus.CounterOpts{
+			Name: "http_requests_total",
+			Help: "Total number of HTTP requests",
+		},
+		[]string{"service", "method", "path", "status"},
+	)
+
+	HttpRequestDuration = promauto.NewHistogramVec(
+		prometheus.HistogramOpts{
+			Name:    "http_request_duration_seconds",
+			Help:    "Duration of HTTP requests in seconds",
+			Buckets: prometheus.DefBuckets,
+		},
+		[]string{"service", "method", "path"},
+	)
+
+	UploadsTotal = promauto.NewCounterVec(
+		prometheus.CounterOpts{
+			Name: "media_uploads_total",
+			Help: "Total number of completed or rejected uploads",
+		},
+		[]string{"result"},
+	)
+
+	UploadedBytesTotal = promauto.NewCounter(
+		prometheus.CounterOpts{
+			Name: "media_uploaded_bytes_total",
+			Help: "Total number of bytes accepted by the upload API",
+		},
+	)
+
+	DbQueryDuration = promauto.NewHistogramVec(
+		prometheus.HistogramOpts{
+			Name:    "db_query_duration_seconds",
+			Help:    "Duration of database queries in seconds",
+			Buckets: prometheus.DefBuckets,
+		},
+		[]string{"service", "query_type"},
+	)
+)
@@ -0,0 +1,29 @@
+package observability
+
+import (
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/go-chi/chi/v5/middleware"
+)
+
+func MetricsMiddleware(serviceName string) func(http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			start := time.Now()
+
+			// Use chi's response writer to capture status code
+			ww := middleware.NewWrapResponseWriter(w, r.ProtoMajor)
+
+			next.ServeHTTP(ww, r)
+
+			duration := time.Since(start).Seconds()
+			status := strconv.Itoa(ww.Status())
+			path := r.URL.Path
+
+			HttpRequestsTotal.WithLabelValues(serviceName, r.Method, path, status).Inc()
+			HttpRequestDuration.WithLabelValues(serviceName, r.Method, path).Observe(duration)
+		})
+	}
+}
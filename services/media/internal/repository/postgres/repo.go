@@ -0,0 +1,210 @@
+package postgres
+
+import (
+	"context"
+	"database/sql"
+
+	"github.com/SARVESHVARADKAR123/RealChat/services/media/internal/domain"
+	"github.com/lib/pq"
+)
+
+type Repository struct {
+	DB *sql.DB
+}
+
+type queryable interface {
+	QueryRowContext(ctx context.Context, query string, args ...interface{}) *sql.Row
+	QueryContext(ctx context.Context, query string, args ...interface{}) (*sql.Rows, error)
+	ExecContext(ctx context.Context, query string, args ...interface{}) (sql.Result, error)
+}
+
+func (r *Repository) getter(tx *sql.Tx) queryable {
+	if tx != nil {
+		return tx
+	}
+	return r.DB
+}
+
+type rowScanner interface {
+	Scan(dest ...interface{}) error
+}
+
+const sessionColumns = `id, owner_id, file_name, mime_type, size_bytes,
+		       attachment_id, created_at, expires_at`
+
+func scanSession(row rowScanner) (*domain.UploadSession, error) {
+	var s domain.UploadSession
+	var attachmentID sql.NullString
+
+	if err := row.Scan(
+		&s.ID,
+		&s.OwnerID,
+		&s.FileName,
+		&s.MimeType,
+		&s.SizeBytes,
+		&attachmentID,
+		&s.CreatedAt,
+		&s.ExpiresAt,
+	); err != nil {
+		if err == sql.ErrNoRows {
+			return nil, domain.ErrUploadNotFound
+		}
+		return nil, err
+	}
+
+	s.AttachmentID = attachmentID.String
+	return &s, nil
+}
+
+const attachmentColumns = `id, owner_id, file_name, mime_type, size_bytes,
+		       content_hash, created_at, content_type`
+
+func scanAttachment(row rowScanner) (*domain.Attachment, error) {
+	var a domain.Attachment
+	var contentType sql.NullString
+	if err := row.Scan(
+		&a.ID,
+		&a.OwnerID,
+		&a.FileName,
+		&a.MimeType,
+		&a.SizeBytes,
+		&a.ContentHash,
+		&a.CreatedAt,
+		&contentType,
+	); err != nil {
+		if err == sql.ErrNoRows {
+			return nil, domain.ErrAttachmentNotFound
+		}
+		return nil, err
+	}
+	a.ContentType = contentType.String
+	return &a, nil
+}
+
+func (r *Repository) InsertUploadSession(
+	ctx context.Context,
+	tx *sql.Tx,
+	s *domain.UploadSession,
+) error {
+	q := r.getter(tx)
+	_, err := q.ExecContext(ctx, `
+		INSERT INTO upload_sessions (
+			id, owner_id, file_name, mime_type, size_bytes,
+			created_at, expires_at
+		)
+		VALUES ($1,$2,$3,$4,$5,$6,$7)
+	`,
+		s.ID,
+		s.OwnerID,
+		s.FileName,
+		s.MimeType,
+		s.SizeBytes,
+		s.CreatedAt,
+		s.ExpiresAt,
+	)
+	return err
+}
+
+func (r *Repository) GetUploadSession(
+	ctx context.Context,
+	tx *sql.Tx,
+	id string,
+) (*domain.UploadSession, error) {
+	q := r.getter(tx)
+	return scanSession(q.QueryRowContext(ctx, `
+		SELECT `+sessionColumns+`
+		FROM upload_sessions
+		WHERE id = $1
+	`, id))
+}
+
+func (r *Repository) GetUploadSessionForUpdate(
+	ctx context.Context,
+	tx *sql.Tx,
+	id string,
+) (*domain.UploadSession, error) {
+	q := r.getter(tx)
+	return scanSession(q.QueryRowContext(ctx, `
+		SELECT `+sessionColumns+`
+		FROM upload_sessions
+		WHERE id = $1
+		FOR UPDATE
+	`, id))
+}
+
+func (r *Repository) CompleteUploadSession(
+	ctx context.Context,
+	tx *sql.Tx,
+	id string,
+	attachmentID string,
+) error {
+	q := r.getter(tx)
+	_, err := q.ExecContext(ctx, `
+		UPDATE upload_sessions
+		SET attachment_id = $2
+		WHERE id = $1
+	`, id, attachmentID)
+	return err
+}
+
+func (r *Repository) InsertAttachment(
+	ctx context.Context,
+	tx *sql.Tx,
+	a *domain.Attachment,
+) error {
+	q := r.getter(tx)
+	_, err := q.ExecContext(ctx, `
+		INSERT INTO attachments (
+			id, owner_id, file_name, mime_type, size_bytes,
+			content_hash, created_at, content_type
+		)
+		VALUES ($1,$2,$3,$4,$5,$6,$7,$8)
+	`,
+		a.ID,
+		a.OwnerID,
+		a.FileName,
+		a.MimeType,
+		a.SizeBytes,
+		a.ContentHash,
+		a.CreatedAt,
+		a.ContentType,
+	)
+	return err
+}
+
+func (r *Repository) GetAttachment(
+	ctx context.Context,
+	id string,
+) (*domain.Attachment, error) {
+	return scanAttachment(r.DB.QueryRowContext(ctx, `
+		SELECT `+attachmentColumns+`
+		FROM attachments
+		WHERE id = $1
+	`, id))
+}
+
+func (r *Repository) GetAttachments(
+	ctx context.Context,
+	ids []string,
+) ([]*domain.Attachment, error) {
+
+	rows, err := r.DB.QueryContext(ctx, `
+		SELECT `+attachmentColumns+`
+		FROM attachments
+		WHERE id = ANY($1)
+	`, pq.Array(ids))
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var out []*domain.Attachment
+	for rows.Next() {
+		a, err := scanAttachment(rows)
+		if err != nil {
+			return nil, err
+		}
+		out = append(out, a)
+	}
+	return out, rows.Err()
+}
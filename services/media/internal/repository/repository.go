@@ -0,0 +1,21 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+
+	"github.com/SARVESHVARADKAR123/RealChat/services/media/internal/domain"
+)
+
+type Repository interface {
+	// Upload sessions
+	InsertUploadSession(ctx context.Context, tx *sql.Tx, s *domain.UploadSession) error
+	GetUploadSession(ctx context.Context, tx *sql.Tx, id string) (*domain.UploadSession, error)
+	GetUploadSessionForUpdate(ctx context.Context, tx *sql.Tx, id string) (*domain.UploadSession, error)
+	CompleteUploadSession(ctx context.Context, tx *sql.Tx, id, attachmentID string) error
+
+	// Attachments
+	InsertAttachment(ctx context.Context, tx *sql.Tx, a *domain.Attachment) error
+	GetAttachment(ctx context.Context, id string) (*domain.Attachment, error)
+	GetAttachments(ctx context.Context, ids []string) ([]*domain.Attachment, error)
+}
@@ -0,0 +1,46 @@
+package grpc
+
+import (
+	"errors"
+	"log"
+
+	"github.com/SARVESHVARADKAR123/RealChat/services/media/internal/domain"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// MapError converts a domain error into a gRPC status error.
+func MapError(err error) error {
+	if err == nil {
+		return nil
+	}
+
+	// Check if it's already a gRPC status error
+	if _, ok := status.FromError(err); ok {
+		return err
+	}
+
+	switch {
+	case errors.Is(err, domain.ErrUploadNotFound),
+		errors.Is(err, domain.ErrAttachmentNotFound):
+		return status.Error(codes.NotFound, err.Error())
+
+	case errors.Is(err, domain.ErrNotOwner):
+		return status.Error(codes.PermissionDenied, err.Error())
+
+	case errors.Is(err, domain.ErrUploadExpired),
+		errors.Is(err, domain.ErrUploadCompleted):
+		return status.Error(codes.FailedPrecondition, err.Error())
+
+	case errors.Is(err, domain.ErrInvalidInput),
+		errors.Is(err, domain.ErrFileTooLarge),
+		errors.Is(err, domain.ErrUnsupportedType),
+		errors.Is(err, domain.ErrSizeMismatch):
+		return status.Error(codes.InvalidArgument, err.Error())
+
+	default:
+		// Log actual error to help debugging
+		log.Printf("internal gRPC error: %v", err)
+		return status.Error(codes.Internal, "internal server error")
+	}
+}
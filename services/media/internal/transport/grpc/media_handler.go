@@ -0,0 +1,178 @@
+package grpc
+
+import (
+	"context"
+	"errors"
+	"io"
+
+	mediav1 "github.com/SARVESHVARADKAR123/RealChat/contracts/gen/go/media/v1"
+	"github.com/SARVESHVARADKAR123/RealChat/services/media/internal/application"
+	"github.com/SARVESHVARADKAR123/RealChat/services/media/internal/auth"
+	"github.com/SARVESHVARADKAR123/RealChat/services/media/internal/domain"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+// downloadChunkSize keeps DownloadContent messages well under the default
+// 4 MiB gRPC limit.
+const downloadChunkSize = 64 << 10
+
+func (s *Server) CreateUploadSession(
+	ctx context.Context,
+	req *mediav1.CreateUploadSessionRequest,
+) (*mediav1.CreateUploadSessionResponse, error) {
+
+	userID, err := auth.GetUserID(ctx)
+	if err != nil {
+		return nil, status.Error(codes.Unauthenticated, err.Error())
+	}
+	if req.OwnerUserId != userID {
+		return nil, status.Error(codes.PermissionDenied, "owner id mismatch")
+	}
+
+	session, err := s.app.CreateUploadSession(ctx, application.CreateUploadCommand{
+		OwnerID:   req.OwnerUserId,
+		FileName:  req.FileName,
+		MimeType:  req.MimeType,
+		SizeBytes: req.SizeBytes,
+	})
+	if err != nil {
+		return nil, MapError(err)
+	}
+
+	return &mediav1.CreateUploadSessionResponse{
+		Session: &mediav1.UploadSession{
+			UploadId:    session.ID,
+			OwnerUserId: session.OwnerID,
+			FileName:    session.FileName,
+			MimeType:    session.MimeType,
+			SizeBytes:   session.SizeBytes,
+			ExpiresAt:   timestamppb.New(session.ExpiresAt),
+		},
+	}, nil
+}
+
+func (s *Server) UploadContent(
+	stream grpc.ClientStreamingServer[mediav1.UploadContentRequest, mediav1.UploadContentResponse],
+) error {
+
+	ctx := stream.Context()
+	userID, err := auth.GetUserID(ctx)
+	if err != nil {
+		return status.Error(codes.Unauthenticated, err.Error())
+	}
+
+	first, err := stream.Recv()
+	if err != nil {
+		if errors.Is(err, io.EOF) {
+			return status.Error(codes.InvalidArgument, "empty upload stream")
+		}
+		return err
+	}
+	if first.UploadId == "" {
+		return status.Error(codes.InvalidArgument, "first chunk must carry upload_id")
+	}
+
+	att, err := s.app.UploadContent(ctx, first.UploadId, userID, &chunkReader{
+		stream: stream,
+		buf:    first.Data,
+	})
+	if err != nil {
+		return MapError(err)
+	}
+
+	return stream.SendAndClose(&mediav1.UploadContentResponse{
+		Attachment: s.toProtoAttachment(att, true),
+	})
+}
+
+func (s *Server) GetAttachments(
+	ctx context.Context,
+	req *mediav1.GetAttachmentsRequest,
+) (*mediav1.GetAttachmentsResponse, error) {
+
+	atts, err := s.app.GetAttachments(ctx, req.AttachmentIds)
+	if err != nil {
+		return nil, MapError(err)
+	}
+
+	resp := &mediav1.GetAttachmentsResponse{}
+	for _, a := range atts {
+		resp.Attachments = append(resp.Attachments, s.toProtoAttachment(a, req.IncludeDownloadUrls))
+	}
+	return resp, nil
+}
+
+func (s *Server) DownloadContent(
+	req *mediav1.DownloadContentRequest,
+	stream grpc.ServerStreamingServer[mediav1.DownloadContentResponse],
+) error {
+
+	att, rc, err := s.app.OpenContent(stream.Context(), req.AttachmentId)
+	if err != nil {
+		return MapError(err)
+	}
+	defer rc.Close()
+
+	if err := stream.Send(&mediav1.DownloadContentResponse{
+		Attachment: s.toProtoAttachment(att, false),
+	}); err != nil {
+		return err
+	}
+
+	buf := make([]byte, downloadChunkSize)
+	for {
+		n, err := rc.Read(buf)
+		if n > 0 {
+			if sendErr := stream.Send(&mediav1.DownloadContentResponse{Data: buf[:n]}); sendErr != nil {
+				return sendErr
+			}
+		}
+		if errors.Is(err, io.EOF) {
+			return nil
+		}
+		if err != nil {
+			return MapError(err)
+		}
+	}
+}
+
+func (s *Server) toProtoAttachment(a *domain.Attachment, withURL bool) *mediav1.Attachment {
+	pa := &mediav1.Attachment{
+		AttachmentId: a.ID,
+		OwnerUserId:  a.OwnerID,
+		FileName:     a.FileName,
+		MimeType:     a.MimeType,
+		SizeBytes:    a.SizeBytes,
+		ContentHash:  a.ContentHash,
+		CreatedAt:    timestamppb.New(a.CreatedAt),
+		ContentType:  a.ContentType,
+	}
+	if withURL {
+		url, expiresAt := s.app.DownloadURL(a.ID)
+		pa.DownloadUrl = url
+		pa.DownloadUrlExpiresAt = timestamppb.New(expiresAt)
+	}
+	return pa
+}
+
+// chunkReader adapts an upload stream to io.Reader.
+type chunkReader struct {
+	stream grpc.ClientStreamingServer[mediav1.UploadContentRequest, mediav1.UploadContentResponse]
+	buf    []byte
+}
+
+func (r *chunkReader) Read(p []byte) (int, error) {
+	for len(r.buf) == 0 {
+		chunk, err := r.stream.Recv()
+		if err != nil {
+			return 0, err
+		}
+		r.buf = chunk.Data
+	}
+	n := copy(p, r.buf)
+	r.buf = r.buf[n:]
+	return n, nil
+}
@@ -0,0 +1,56 @@
+package grpc
+
+import (
+	"log"
+	"net"
+
+	"go.opentelemetry.io/contrib/instrumentation/google.golang.org/grpc/otelgrpc"
+	"google.golang.org/grpc"
+
+	mediav1 "github.com/SARVESHVARADKAR123/RealChat/contracts/gen/go/media/v1"
+	"github.com/SARVESHVARADKAR123/RealChat/services/media/internal/application"
+	"github.com/SARVESHVARADKAR123/RealChat/services/media/internal/auth"
+)
+
+type Server struct {
+	mediav1.UnimplementedMediaApiServer
+	grpcServer *grpc.Server
+	app        *application.Service
+}
+
+func New(app *application.Service) *Server {
+	grpcServer := grpc.NewServer(
+		grpc.StatsHandler(otelgrpc.NewServerHandler()),
+		grpc.UnaryInterceptor(auth.Interceptor),
+		grpc.StreamInterceptor(auth.StreamInterceptor),
+	)
+
+	s := &Server{
+		grpcServer: grpcServer,
+		app:        app,
+	}
+
+	mediav1.RegisterMediaApiServer(
+		grpcServer,
+		s,
+	)
+
+	return s
+}
+
+func (s *Server) Start(port string) {
+	lis, err := net.Listen("tcp", port)
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	log.Println("gRPC listening on", port)
+	if err := s.grpcServer.Serve(lis); err != nil {
+		log.Println("gRPC server stopped:", err)
+	}
+}
+
+func (s *Server) Stop() {
+	log.Println("shutting down gRPC...")
+	s.grpcServer.GracefulStop()
+}
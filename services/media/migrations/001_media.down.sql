@@ -0,0 +1,2 @@
+DROP TABLE IF EXISTS attachments;
+DROP TABLE IF EXISTS upload_sessions;
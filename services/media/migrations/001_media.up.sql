@@ -0,0 +1,32 @@
+CREATE TABLE upload_sessions (
+    id            TEXT PRIMARY KEY,
+    owner_id      TEXT NOT NULL,
+    file_name     TEXT NOT NULL,
+    mime_type     TEXT NOT NULL,
+    size_bytes    BIGINT NOT NULL,
+
+    -- set once the content has been received
+    attachment_id TEXT,
+
+    created_at    TIMESTAMPTZ NOT NULL DEFAULT now(),
+    expires_at    TIMESTAMPTZ NOT NULL
+);
+
+CREATE TABLE attachments (
+    id           TEXT PRIMARY KEY,
+    owner_id     TEXT NOT NULL,
+    file_name    TEXT NOT NULL,
+    mime_type    TEXT NOT NULL,
+    size_bytes   BIGINT NOT NULL,
+
+    -- hex sha256 of the content, also the blob store key
+    content_hash TEXT NOT NULL,
+
+    created_at   TIMESTAMPTZ NOT NULL DEFAULT now()
+);
+
+CREATE INDEX idx_attachments_owner
+ON attachments(owner_id, created_at DESC);
+
+CREATE INDEX idx_attachments_content_hash
+ON attachments(content_hash);
@@ -0,0 +1 @@
+ALTER TABLE attachments DROP COLUMN IF EXISTS content_type;
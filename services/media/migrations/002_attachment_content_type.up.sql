@@ -0,0 +1,3 @@
+-- The type verified from the content at upload, which downloads are served
+-- as. NULL for attachments uploaded before it was recorded.
+ALTER TABLE attachments ADD COLUMN content_type TEXT;
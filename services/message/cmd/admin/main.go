@@ -0,0 +1,243 @@
+// Command admin runs one-off maintenance tasks against the message database.
+//
+// Usage:
+//
+//	admin restore -conversation <id> -from 2024-01 -to 2024-03
+//	admin import -source slack -file export.zip -mapping users.json -as <user-id> -name <name>
+//
+// restore makes the archived months from..to (inclusive) of a conversation
+// readable through SyncMessages again, until MESSAGE_ARCHIVE_RESTORE_HOLD has
+// passed. It reads DATABASE_URL and MESSAGE_ARCHIVE_DIR like the server.
+//
+// import writes the history of a Slack or WhatsApp export into a new group
+// conversation created by the -as user. The mapping file is a JSON object
+// from export senders (Slack user IDs, WhatsApp display names) to RealChat
+// user IDs. Running the same import again resumes it if it was interrupted.
+// It also reads CONVERSATION_SVC_ADDR and PROFILE_SVC_ADDR.
+package main
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"flag"
+	"fmt"
+	"os"
+	"time"
+
+	_ "github.com/lib/pq"
+	"go.uber.org/zap"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials/insecure"
+
+	conversationv1 "github.com/SARVESHVARADKAR123/RealChat/contracts/gen/go/conversation/v1"
+	profilev1 "github.com/SARVESHVARADKAR123/RealChat/contracts/gen/go/profile/v1"
+	"github.com/SARVESHVARADKAR123/RealChat/services/message/internal/application"
+	"github.com/SARVESHVARADKAR123/RealChat/services/message/internal/archive"
+	"github.com/SARVESHVARADKAR123/RealChat/services/message/internal/auth"
+	"github.com/SARVESHVARADKAR123/RealChat/services/message/internal/imports"
+	"github.com/SARVESHVARADKAR123/RealChat/services/message/internal/observability"
+	"github.com/SARVESHVARADKAR123/RealChat/services/message/internal/repository/postgres"
+	"github.com/SARVESHVARADKAR123/RealChat/services/message/internal/tx"
+)
+
+func main() {
+	if len(os.Args) < 2 {
+		usage()
+	}
+	observability.InitLogger("message-admin")
+
+	switch os.Args[1] {
+	case "restore":
+		restore(os.Args[2:])
+	case "import":
+		importHistory(os.Args[2:])
+	default:
+		usage()
+	}
+}
+
+func usage() {
+	fmt.Fprintln(os.Stderr, "usage: admin restore -conversation <id> -from YYYY-MM -to YYYY-MM")
+	fmt.Fprintln(os.Stderr, "       admin import -source slack|whatsapp -file <export> -mapping <users.json> -as <user-id> -name <name>")
+	fmt.Fprintln(os.Stderr, "                    [-channel <name>] [-timezone <zone>] [-day-first]")
+	os.Exit(2)
+}
+
+func restore(args []string) {
+	fs := flag.NewFlagSet("restore", flag.ExitOnError)
+	conversationID := fs.String("conversation", "", "conversation to restore")
+	from := fs.String("from", "", "first month to restore, YYYY-MM")
+	to := fs.String("to", "", "last month to restore, YYYY-MM; defaults to -from")
+	_ = fs.Parse(args)
+
+	if *to == "" {
+		*to = *from
+	}
+	start, err := time.Parse("2006-01", *from)
+	if err != nil || *conversationID == "" {
+		usage()
+	}
+	last, err := time.Parse("2006-01", *to)
+	if err != nil {
+		usage()
+	}
+
+	app, closeDB := newService(clients{})
+	defer closeDB()
+
+	n, err := app.RestoreArchive(context.Background(), application.RestoreCommand{
+		ConversationID: *conversationID,
+		From:           start,
+		To:             last.AddDate(0, 1, 0),
+	})
+	if err != nil {
+		observability.Log.Fatal("restore failed", zap.Int("restored", n), zap.Error(err))
+	}
+	observability.Log.Info("restore complete",
+		zap.String("conversation_id", *conversationID),
+		zap.Int("restored", n),
+	)
+}
+
+func importHistory(args []string) {
+	fs := flag.NewFlagSet("import", flag.ExitOnError)
+	source := fs.String("source", "", "export format: slack or whatsapp")
+	file := fs.String("file", "", "export to import")
+	mappingFile := fs.String("mapping", "", "JSON object of export senders to RealChat user IDs")
+	as := fs.String("as", "", "RealChat user who creates the conversation")
+	name := fs.String("name", "", "name of the new conversation")
+	channel := fs.String("channel", "", "Slack channel, when the export holds several")
+	timeZone := fs.String("timezone", "", "IANA time zone of WhatsApp timestamps; defaults to UTC")
+	dayFirst := fs.Bool("day-first", false, "read WhatsApp dates as day/month")
+	_ = fs.Parse(args)
+
+	if !imports.ValidSource(*source) || *file == "" || *mappingFile == "" || *as == "" || *name == "" {
+		usage()
+	}
+
+	data, err := os.ReadFile(*file)
+	if err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		os.Exit(1)
+	}
+	raw, err := os.ReadFile(*mappingFile)
+	if err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		os.Exit(1)
+	}
+	var mapping map[string]string
+	if err := json.Unmarshal(raw, &mapping); err != nil {
+		fmt.Fprintf(os.Stderr, "invalid mapping file: %v\n", err)
+		os.Exit(1)
+	}
+
+	remote, closeClients := dialClients()
+	defer closeClients()
+	app, closeDB := newService(remote)
+	defer closeDB()
+
+	// Calls to other services are made as the importer
+	ctx := context.WithValue(context.Background(), auth.UserIDKey, *as)
+	imp, err := app.ImportFile(ctx, application.ImportCommand{
+		Source:    *source,
+		Name:      *name,
+		CreatedBy: *as,
+		Mapping:   mapping,
+		Options: imports.Options{
+			Channel:  *channel,
+			TimeZone: *timeZone,
+			DayFirst: *dayFirst,
+		},
+	}, data)
+	if err != nil {
+		fields := []zap.Field{zap.Error(err)}
+		if imp != nil {
+			fields = append(fields,
+				zap.String("import_id", imp.ID),
+				zap.Int64("imported", imp.ImportedMessages),
+				zap.Int64("total", imp.TotalMessages),
+			)
+		}
+		observability.Log.Fatal("import failed", fields...)
+	}
+	observability.Log.Info("import complete",
+		zap.String("import_id", imp.ID),
+		zap.String("conversation_id", imp.ConversationID),
+		zap.Int64("imported", imp.ImportedMessages),
+	)
+}
+
+// clients are the other services a maintenance task talks to.
+type clients struct {
+	conversations conversationv1.ConversationApiClient
+	profiles      profilev1.ProfileApiClient
+}
+
+func dialClients() (clients, func()) {
+	log := observability.Log
+
+	dial := func(env string) *grpc.ClientConn {
+		addr := os.Getenv(env)
+		if addr == "" {
+			log.Fatal("missing required env: " + env)
+		}
+		conn, err := grpc.Dial(
+			addr,
+			grpc.WithTransportCredentials(insecure.NewCredentials()),
+			grpc.WithUnaryInterceptor(auth.ClientInterceptor),
+		)
+		if err != nil {
+			log.Fatal("failed to dial "+addr, zap.Error(err))
+		}
+		return conn
+	}
+
+	convConn := dial("CONVERSATION_SVC_ADDR")
+	profileConn := dial("PROFILE_SVC_ADDR")
+	remote := clients{
+		conversations: conversationv1.NewConversationApiClient(convConn),
+		profiles:      profilev1.NewProfileApiClient(profileConn),
+	}
+	return remote, func() {
+		convConn.Close()
+		profileConn.Close()
+	}
+}
+
+// newService builds the parts of the service that maintenance tasks use.
+// Tasks that don't call other services leave remote empty.
+func newService(remote clients) (*application.Service, func()) {
+	log := observability.Log
+
+	dsn := os.Getenv("DATABASE_URL")
+	if dsn == "" {
+		log.Fatal("missing required env: DATABASE_URL")
+	}
+	db, err := sql.Open("postgres", dsn)
+	if err != nil {
+		log.Fatal("db open failed", zap.Error(err))
+	}
+
+	dir := os.Getenv("MESSAGE_ARCHIVE_DIR")
+	if dir == "" {
+		dir = "/var/lib/realchat/archive"
+	}
+
+	hold := 7 * 24 * time.Hour
+	if v := os.Getenv("MESSAGE_ARCHIVE_RESTORE_HOLD"); v != "" {
+		if hold, err = time.ParseDuration(v); err != nil {
+			log.Fatal("invalid MESSAGE_ARCHIVE_RESTORE_HOLD", zap.Error(err))
+		}
+	}
+
+	app := application.New(
+		&postgres.Repository{DB: db},
+		&tx.Manager{DB: db},
+		remote.conversations, nil, remote.profiles,
+		&archive.LocalFS{Root: dir},
+		log,
+		application.Options{RestoreHold: hold},
+	)
+	return app, func() { db.Close() }
+}
@@ -15,14 +15,27 @@ import (
 	"go.uber.org/zap"
 
 	conversationv1 "github.com/SARVESHVARADKAR123/RealChat/contracts/gen/go/conversation/v1"
+	mediav1 "github.com/SARVESHVARADKAR123/RealChat/contracts/gen/go/media/v1"
+	profilev1 "github.com/SARVESHVARADKAR123/RealChat/contracts/gen/go/profile/v1"
 	"github.com/SARVESHVARADKAR123/RealChat/services/message/internal/application"
+	"github.com/SARVESHVARADKAR123/RealChat/services/message/internal/archive"
+	"github.com/SARVESHVARADKAR123/RealChat/services/message/internal/archiver"
 	"github.com/SARVESHVARADKAR123/RealChat/services/message/internal/auth"
 	"github.com/SARVESHVARADKAR123/RealChat/services/message/internal/cache"
 	"github.com/SARVESHVARADKAR123/RealChat/services/message/internal/config"
+	"github.com/SARVESHVARADKAR123/RealChat/services/message/internal/domain"
+	"github.com/SARVESHVARADKAR123/RealChat/services/message/internal/exporter"
+	"github.com/SARVESHVARADKAR123/RealChat/services/message/internal/importer"
+	"github.com/SARVESHVARADKAR123/RealChat/services/message/internal/janitor"
 	"github.com/SARVESHVARADKAR123/RealChat/services/message/internal/kafka"
+	"github.com/SARVESHVARADKAR123/RealChat/services/message/internal/moderation"
 	"github.com/SARVESHVARADKAR123/RealChat/services/message/internal/observability"
 	"github.com/SARVESHVARADKAR123/RealChat/services/message/internal/outbox"
+	"github.com/SARVESHVARADKAR123/RealChat/services/message/internal/projection"
+	"github.com/SARVESHVARADKAR123/RealChat/services/message/internal/reaper"
 	"github.com/SARVESHVARADKAR123/RealChat/services/message/internal/repository/postgres"
+	"github.com/SARVESHVARADKAR123/RealChat/services/message/internal/scheduler"
+	"github.com/SARVESHVARADKAR123/RealChat/services/message/internal/systemmsg"
 	grpc_transport "github.com/SARVESHVARADKAR123/RealChat/services/message/internal/transport/grpc"
 	"github.com/SARVESHVARADKAR123/RealChat/services/message/internal/tx"
 	"google.golang.org/grpc"
@@ -86,12 +99,75 @@ func main() {
 	defer conn.Close()
 	convSvcClient := conversationv1.NewConversationApiClient(conn)
 
+	// gRPC Client to Media Service
+	mediaConn, err := grpc.Dial(
+		cfg.MediaSvcAddr,
+		grpc.WithTransportCredentials(insecure.NewCredentials()),
+		grpc.WithUnaryInterceptor(auth.ClientInterceptor),
+	)
+	if err != nil {
+		log.Fatal("failed to connect to media service", zap.Error(err))
+	}
+	defer mediaConn.Close()
+	mediaClient := mediav1.NewMediaApiClient(mediaConn)
+
+	// gRPC Client to Profile Service
+	profileConn, err := grpc.Dial(
+		cfg.ProfileSvcAddr,
+		grpc.WithTransportCredentials(insecure.NewCredentials()),
+		grpc.WithUnaryInterceptor(auth.ClientInterceptor),
+	)
+	if err != nil {
+		log.Fatal("failed to connect to profile service", zap.Error(err))
+	}
+	defer profileConn.Close()
+	profileClient := profilev1.NewProfileApiClient(profileConn)
+
 	repo := &postgres.Repository{
 		DB:    db,
 		Cache: cacheClient,
 	}
 	txMgr := &tx.Manager{DB: db}
-	app := application.New(repo, txMgr, convSvcClient, log)
+
+	// Archive store for messages past their retention
+	if err := domain.ValidateRetention(cfg.Retention); err != nil {
+		log.Fatal("invalid MESSAGE_RETENTION", zap.Error(err))
+	}
+	if err := os.MkdirAll(cfg.ArchiveDir, 0o755); err != nil {
+		log.Fatal("archive dir unavailable", zap.Error(err))
+	}
+	archives := &archive.LocalFS{Root: cfg.ArchiveDir}
+
+	// Message types; register custom types here
+	messageTypes := domain.DefaultMessageTypes()
+
+	// Moderation chain, if the deployment configures one
+	var moderator application.Moderator
+	if cfg.ModerationConfig != "" {
+		data, err := os.ReadFile(cfg.ModerationConfig)
+		if err != nil {
+			log.Fatal("moderation config unavailable", zap.Error(err))
+		}
+		chain, err := moderation.Load(data)
+		if err != nil {
+			log.Fatal("invalid MESSAGE_MODERATION_CONFIG", zap.Error(err))
+		}
+		log.Info("moderation enabled", zap.Int("filters", chain.Len()))
+		moderator = chain
+	}
+
+	app := application.New(repo, txMgr, convSvcClient, mediaClient, profileClient, archives, log, application.Options{
+		EditWindow:     cfg.EditWindow,
+		MaxPins:        cfg.MaxPins,
+		MessageTypes:   messageTypes,
+		SequenceBlock:  cfg.SequenceBlock,
+		Retention:      cfg.Retention,
+		RestoreHold:    cfg.RestoreHold,
+		IdempotencyTTL: cfg.IdempotencyTTL,
+		ImportAdmins:   cfg.ImportAdmins,
+		Moderation:     moderator,
+		Moderators:     cfg.Moderators,
+	})
 
 	// Kafka Producer
 	producer, err := kafka.NewProducer(cfg.KafkaBrokers, cfg.KafkaTopic)
@@ -107,11 +183,87 @@ func main() {
 		PollDelay: 2 * time.Second,
 	}
 
+	// Scheduled message worker
+	scheduleWorker := &scheduler.Worker{
+		Repo:      repo,
+		Sender:    app,
+		BatchSize: 50,
+		PollDelay: time.Second,
+	}
+
+	// Expired message reaper
+	reaperWorker := &reaper.Worker{
+		Reaper:    app,
+		BatchSize: 500,
+		PollDelay: 5 * time.Second,
+	}
+
+	// Archival of messages past their retention, and partition upkeep
+	archiveWorker := &archiver.Worker{
+		Archiver:          app,
+		BatchSize:         50,
+		PollDelay:         10 * time.Minute,
+		PartitionInterval: time.Hour,
+	}
+
+	// Expired idempotency key janitor
+	janitorWorker := &janitor.Worker{
+		Janitor:   app,
+		BatchSize: 1000,
+		PollDelay: time.Minute,
+	}
+
+	// Conversation export jobs
+	exportWorker := &exporter.Worker{
+		Exporter:  app,
+		BatchSize: 5,
+		PollDelay: 5 * time.Second,
+	}
+
+	// History imports requested through the API
+	importWorker := &importer.Worker{
+		Importer:  app,
+		BatchSize: 1,
+		PollDelay: 5 * time.Second,
+	}
+
+	// Conversation events become system messages
+	systemConsumer, err := kafka.NewConsumer(
+		cfg.KafkaBrokers,
+		"message-service-system-messages",
+		[]string{cfg.ConversationTopic},
+		&systemmsg.Handler{Poster: app},
+	)
+	if err != nil {
+		log.Fatal("kafka consumer failed", zap.Error(err))
+	}
+	defer systemConsumer.Close()
+
+	// Conversation events keep the local membership projection current
+	projectionConsumer, err := kafka.NewConsumer(
+		cfg.KafkaBrokers,
+		"message-service-conversation-projection",
+		[]string{cfg.ConversationTopic},
+		&projection.Handler{Applier: app},
+	)
+	if err != nil {
+		log.Fatal("kafka consumer failed", zap.Error(err))
+	}
+	defer projectionConsumer.Close()
+
 	// Cancellable context for background workers
 	ctx, cancel := context.WithCancel(context.Background())
 	defer cancel()
 
 	go worker.Start(ctx)
+	go scheduleWorker.Start(ctx)
+	go reaperWorker.Start(ctx)
+	go archiveWorker.Start(ctx)
+	go janitorWorker.Start(ctx)
+	go exportWorker.Start(ctx)
+	go importWorker.Start(ctx)
+	go systemConsumer.Start(ctx)
+	go projectionConsumer.Start(ctx)
 
 	// gRPC Server
 	server := grpc_transport.New(app)
@@ -0,0 +1,338 @@
+package application
+
+import (
+	"bytes"
+	"context"
+	"database/sql"
+	"fmt"
+	"sort"
+	"time"
+
+	"github.com/SARVESHVARADKAR123/RealChat/services/message/internal/archive"
+	"github.com/SARVESHVARADKAR123/RealChat/services/message/internal/domain"
+	"go.uber.org/zap"
+)
+
+const defaultRestoreHold = 7 * 24 * time.Hour
+
+func (s *Service) restoreHold() time.Duration {
+	if s.opts.RestoreHold > 0 {
+		return s.opts.RestoreHold
+	}
+	return defaultRestoreHold
+}
+
+// ArchiveExpiredMessages archives up to limit conversation months whose
+// messages have all outlived their retention at now. Each month is written
+// to the archive store and then deleted from messages, in its own
+// transaction. No events are emitted: archived history simply stops being
+// served. It returns how many months and messages were archived.
+func (s *Service) ArchiveExpiredMessages(ctx context.Context, now time.Time, limit int) (units, messages int, err error) {
+	todo, err := s.repo.ListArchiveUnits(ctx, now, s.opts.Retention, limit)
+	if err != nil {
+		return 0, 0, fmt.Errorf("failed to list archive units: %w", err)
+	}
+
+	for _, u := range todo {
+		n, err := s.archiveUnit(ctx, u, now)
+		if err != nil {
+			return units, messages, fmt.Errorf("failed to archive %s %s: %w",
+				u.ConversationID, u.PeriodStart.Format("2006-01"), err)
+		}
+		units++
+		messages += n
+	}
+	return units, messages, nil
+}
+
+// archiveUnit moves one conversation month into the archive store, each
+// message with everything stored alongside it. A month archived before,
+// e.g. one that was restored, is merged with its existing archive so
+// nothing archived earlier is lost.
+func (s *Service) archiveUnit(ctx context.Context, u domain.ArchiveUnit, now time.Time) (int, error) {
+	start, end := domain.ArchivePeriod(u.PeriodStart)
+	var archived int
+
+	err := s.tx.WithTx(ctx, func(ctx context.Context, tx *sql.Tx) error {
+		msgs, err := s.repo.LockMessagesInPeriod(ctx, tx, u.ConversationID, start, end)
+		if err != nil {
+			return err
+		}
+		if len(msgs) == 0 {
+			return nil
+		}
+
+		ids := make([]string, len(msgs))
+		for i, m := range msgs {
+			ids[i] = m.ID
+		}
+		atts, err := s.repo.ListMessageAttachments(ctx, tx, ids)
+		if err != nil {
+			return err
+		}
+		for _, m := range msgs {
+			m.Attachments = atts[m.ID]
+		}
+		if err := s.attachCiphertexts(ctx, tx, msgs, "", ""); err != nil {
+			return err
+		}
+		if err := s.attachArchiveExtras(ctx, tx, msgs); err != nil {
+			return err
+		}
+
+		prev, err := s.repo.GetArchiveForUpdate(ctx, tx, u.ConversationID, start)
+		if err != nil {
+			return err
+		}
+		records := msgs
+		if prev != nil {
+			older, err := s.readArchive(ctx, prev.ObjectKey)
+			if err != nil {
+				return err
+			}
+			records = mergeArchived(older, msgs, now)
+		}
+
+		a := domain.NewArchive(u.ConversationID, start, records, now)
+		var buf bytes.Buffer
+		if err := archive.Encode(&buf, records); err != nil {
+			return fmt.Errorf("failed to encode archive: %w", err)
+		}
+		// Written before the rows go, so a failed transaction leaves at
+		// worst an archive that is ahead of the table
+		if err := s.archive.Put(ctx, a.ObjectKey, &buf); err != nil {
+			return fmt.Errorf("failed to store archive: %w", err)
+		}
+
+		if err := s.repo.DeleteMessagesInPeriod(ctx, tx, u.ConversationID, start, end, ids); err != nil {
+			return err
+		}
+		if err := s.repo.SaveArchive(ctx, tx, a); err != nil {
+			return err
+		}
+
+		archived = len(msgs)
+		return nil
+	})
+	if err != nil {
+		return 0, err
+	}
+	return archived, nil
+}
+
+// attachArchiveExtras loads what else deleting msgs would lose: revisions,
+// reactions, pins, mentions and polls with their ballots.
+func (s *Service) attachArchiveExtras(ctx context.Context, tx *sql.Tx, msgs []*domain.Message) error {
+	ids := make([]string, len(msgs))
+	for i, m := range msgs {
+		ids[i] = m.ID
+	}
+
+	extras, err := s.repo.ListMessageExtras(ctx, tx, ids)
+	if err != nil {
+		return err
+	}
+	mentions, err := s.repo.ListMessageMentions(ctx, tx, ids)
+	if err != nil {
+		return err
+	}
+	polls, err := s.repo.ListPolls(ctx, tx, ids)
+	if err != nil {
+		return err
+	}
+
+	for _, m := range msgs {
+		m.Extras = extras[m.ID]
+		m.Mentions = mentions[m.ID]
+		m.Poll = polls[m.ID]
+	}
+	return nil
+}
+
+// mergeArchived combines an earlier archive with the current rows of the
+// same month. Current rows win, and archived messages that have expired
+// since are dropped.
+func mergeArchived(older, current []*domain.Message, now time.Time) []*domain.Message {
+	byID := make(map[string]*domain.Message, len(older)+len(current))
+	for _, m := range older {
+		if m.ExpiresAt != nil && !m.ExpiresAt.After(now) {
+			continue
+		}
+		byID[m.ID] = m
+	}
+	for _, m := range current {
+		byID[m.ID] = m
+	}
+
+	out := make([]*domain.Message, 0, len(byID))
+	for _, m := range byID {
+		out = append(out, m)
+	}
+	sort.Slice(out, func(i, j int) bool { return out[i].Sequence < out[j].Sequence })
+	return out
+}
+
+func (s *Service) readArchive(ctx context.Context, key string) ([]*domain.Message, error) {
+	r, err := s.archive.Open(ctx, key)
+	if err != nil {
+		return nil, fmt.Errorf("failed to open archive %s: %w", key, err)
+	}
+	defer r.Close()
+
+	msgs, err := archive.Decode(r)
+	if err != nil {
+		return nil, fmt.Errorf("failed to decode archive %s: %w", key, err)
+	}
+	return msgs, nil
+}
+
+// RestoreCommand selects the archived months of a conversation overlapping
+// [From, To).
+type RestoreCommand struct {
+	ConversationID string
+	From           time.Time
+	To             time.Time
+}
+
+// RestoreArchive puts archived messages back into messages, where
+// SyncMessages serves them again, and returns how many were restored. Whole
+// months are restored, and they are archived again once the restore hold
+// has passed. Messages still in the table and messages that expired while
+// archived are skipped.
+func (s *Service) RestoreArchive(ctx context.Context, cmd RestoreCommand) (int, error) {
+	if cmd.ConversationID == "" || !cmd.From.Before(cmd.To) {
+		return 0, domain.ErrInvalidInput
+	}
+
+	archives, err := s.repo.ListArchives(ctx, nil, cmd.ConversationID, cmd.From, cmd.To)
+	if err != nil {
+		return 0, fmt.Errorf("failed to list archives: %w", err)
+	}
+
+	var restored int
+	for _, a := range archives {
+		n, err := s.restoreArchive(ctx, a)
+		if err != nil {
+			return restored, fmt.Errorf("failed to restore %s %s: %w",
+				a.ConversationID, a.PeriodStart.Format("2006-01"), err)
+		}
+		restored += n
+	}
+	return restored, nil
+}
+
+func (s *Service) restoreArchive(ctx context.Context, a *domain.Archive) (int, error) {
+	// The month's partition may have been dropped once it emptied
+	if err := s.tx.WithTx(ctx, func(ctx context.Context, tx *sql.Tx) error {
+		_, err := s.repo.EnsurePartition(ctx, tx, a.PeriodStart)
+		return err
+	}); err != nil {
+		return 0, fmt.Errorf("failed to create partition: %w", err)
+	}
+
+	msgs, err := s.readArchive(ctx, a.ObjectKey)
+	if err != nil {
+		return 0, err
+	}
+
+	var restored int
+	err = s.tx.WithTx(ctx, func(ctx context.Context, tx *sql.Tx) error {
+		restored = 0
+		now := time.Now().UTC()
+
+		locked, err := s.repo.GetArchiveForUpdate(ctx, tx, a.ConversationID, a.PeriodStart)
+		if err != nil {
+			return err
+		}
+		if locked == nil {
+			return nil
+		}
+
+		for _, m := range msgs {
+			if m.ExpiresAt != nil && !m.ExpiresAt.After(now) {
+				continue
+			}
+			inserted, err := s.repo.RestoreMessage(ctx, tx, m)
+			if err != nil {
+				return err
+			}
+			if !inserted {
+				continue
+			}
+			if len(m.Attachments) > 0 {
+				if err := s.repo.InsertMessageAttachments(ctx, tx, m.ID, m.Attachments); err != nil {
+					return err
+				}
+			}
+			if len(m.Ciphertexts) > 0 {
+				if err := s.repo.InsertMessageCiphertexts(ctx, tx, m.ID, m.Ciphertexts); err != nil {
+					return err
+				}
+			}
+			if err := s.restoreArchiveExtras(ctx, tx, m); err != nil {
+				return err
+			}
+			restored++
+		}
+
+		until := now.Add(s.restoreHold())
+		locked.RestoredAt = &now
+		locked.RestoredUntil = &until
+		return s.repo.SaveArchive(ctx, tx, locked)
+	})
+	if err != nil {
+		return 0, err
+	}
+	return restored, nil
+}
+
+// restoreArchiveExtras puts back the rows attachArchiveExtras archived with
+// m.
+func (s *Service) restoreArchiveExtras(ctx context.Context, tx *sql.Tx, m *domain.Message) error {
+	if m.Extras != nil {
+		if err := s.repo.RestoreMessageExtras(ctx, tx, m); err != nil {
+			return err
+		}
+	}
+	if len(m.Mentions) > 0 {
+		if err := s.repo.InsertMentions(ctx, tx, m); err != nil {
+			return err
+		}
+	}
+	if m.Poll != nil {
+		if err := s.repo.InsertPoll(ctx, tx, m.Poll); err != nil {
+			return err
+		}
+		if err := s.repo.RestorePollBallots(ctx, tx, m.Poll); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// MaintainPartitions creates the monthly partitions of messages up to two
+// months ahead of now, and drops past ones that archival has emptied.
+func (s *Service) MaintainPartitions(ctx context.Context, now time.Time) error {
+	current, _ := domain.ArchivePeriod(now)
+
+	return s.tx.WithTx(ctx, func(ctx context.Context, tx *sql.Tx) error {
+		for i := 0; i <= 2; i++ {
+			created, err := s.repo.EnsurePartition(ctx, tx, current.AddDate(0, i, 0))
+			if err != nil {
+				return fmt.Errorf("failed to create partition: %w", err)
+			}
+			if created {
+				s.log.Info("created messages partition", zap.Time("period_start", current.AddDate(0, i, 0)))
+			}
+		}
+
+		dropped, err := s.repo.DropEmptyPartitions(ctx, tx, current)
+		if err != nil {
+			return fmt.Errorf("failed to drop empty partitions: %w", err)
+		}
+		if len(dropped) > 0 {
+			s.log.Info("dropped empty messages partitions", zap.Strings("partitions", dropped))
+		}
+		return nil
+	})
+}
@@ -0,0 +1,283 @@
+package application
+
+import (
+	"bytes"
+	"context"
+	"database/sql"
+	"testing"
+	"time"
+
+	"github.com/SARVESHVARADKAR123/RealChat/services/message/internal/archive"
+	"github.com/SARVESHVARADKAR123/RealChat/services/message/internal/domain"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+// MockArchiveRepo adds the calls archiving and restoring a month makes.
+type MockArchiveRepo struct {
+	MockRepo
+}
+
+func (m *MockArchiveRepo) ListArchiveUnits(ctx context.Context, now time.Time, defaultRetention time.Duration, limit int) ([]domain.ArchiveUnit, error) {
+	args := m.Called(ctx, now, defaultRetention, limit)
+	return args.Get(0).([]domain.ArchiveUnit), args.Error(1)
+}
+func (m *MockArchiveRepo) LockMessagesInPeriod(ctx context.Context, tx *sql.Tx, conversationID string, start, end time.Time) ([]*domain.Message, error) {
+	args := m.Called(ctx, tx, conversationID, start, end)
+	return args.Get(0).([]*domain.Message), args.Error(1)
+}
+func (m *MockArchiveRepo) DeleteMessagesInPeriod(ctx context.Context, tx *sql.Tx, conversationID string, start, end time.Time, ids []string) error {
+	return m.Called(ctx, tx, conversationID, start, end, ids).Error(0)
+}
+func (m *MockArchiveRepo) RestoreMessage(ctx context.Context, tx *sql.Tx, msg *domain.Message) (bool, error) {
+	args := m.Called(ctx, tx, msg)
+	return args.Bool(0), args.Error(1)
+}
+func (m *MockArchiveRepo) GetArchiveForUpdate(ctx context.Context, tx *sql.Tx, conversationID string, periodStart time.Time) (*domain.Archive, error) {
+	args := m.Called(ctx, tx, conversationID, periodStart)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*domain.Archive), args.Error(1)
+}
+func (m *MockArchiveRepo) SaveArchive(ctx context.Context, tx *sql.Tx, a *domain.Archive) error {
+	return m.Called(ctx, tx, a).Error(0)
+}
+func (m *MockArchiveRepo) ListArchives(ctx context.Context, tx *sql.Tx, conversationID string, from, to time.Time) ([]*domain.Archive, error) {
+	args := m.Called(ctx, tx, conversationID, from, to)
+	return args.Get(0).([]*domain.Archive), args.Error(1)
+}
+func (m *MockArchiveRepo) EnsurePartition(ctx context.Context, tx *sql.Tx, periodStart time.Time) (bool, error) {
+	args := m.Called(ctx, tx, periodStart)
+	return args.Bool(0), args.Error(1)
+}
+func (m *MockArchiveRepo) ListMessageExtras(ctx context.Context, tx *sql.Tx, messageIDs []string) (map[string]*domain.MessageExtras, error) {
+	args := m.Called(ctx, tx, messageIDs)
+	return args.Get(0).(map[string]*domain.MessageExtras), args.Error(1)
+}
+func (m *MockArchiveRepo) RestoreMessageExtras(ctx context.Context, tx *sql.Tx, msg *domain.Message) error {
+	return m.Called(ctx, tx, msg).Error(0)
+}
+func (m *MockArchiveRepo) ListMessageMentions(ctx context.Context, tx *sql.Tx, messageIDs []string) (map[string][]string, error) {
+	args := m.Called(ctx, tx, messageIDs)
+	return args.Get(0).(map[string][]string), args.Error(1)
+}
+func (m *MockArchiveRepo) RestorePollBallots(ctx context.Context, tx *sql.Tx, p *domain.Poll) error {
+	return m.Called(ctx, tx, p).Error(0)
+}
+func (m *MockArchiveRepo) InsertMessageAttachments(ctx context.Context, tx *sql.Tx, messageID string, atts []domain.Attachment) error {
+	return m.Called(ctx, tx, messageID, atts).Error(0)
+}
+func (m *MockArchiveRepo) InsertPoll(ctx context.Context, tx *sql.Tx, p *domain.Poll) error {
+	return m.Called(ctx, tx, p).Error(0)
+}
+func (m *MockArchiveRepo) ListPolls(ctx context.Context, tx *sql.Tx, messageIDs []string) (map[string]*domain.Poll, error) {
+	args := m.Called(ctx, tx, messageIDs)
+	return args.Get(0).(map[string]*domain.Poll), args.Error(1)
+}
+
+func TestArchivePeriod(t *testing.T) {
+	start, end := domain.ArchivePeriod(time.Date(2024, 2, 29, 23, 30, 0, 0, time.FixedZone("", -3*3600)))
+	// 23:30 at UTC-3 is already March in UTC
+	assert.Equal(t, time.Date(2024, 3, 1, 0, 0, 0, 0, time.UTC), start)
+	assert.Equal(t, time.Date(2024, 4, 1, 0, 0, 0, 0, time.UTC), end)
+	assert.Equal(t, "messages/conv-1/2024-03.jsonl.gz", domain.ArchiveObjectKey("conv-1", start))
+}
+
+func readArchiveObject(t *testing.T, store archive.Store, key string) []*domain.Message {
+	r, err := store.Open(context.Background(), key)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer r.Close()
+	msgs, err := archive.Decode(r)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return msgs
+}
+
+// putArchive stores msgs as the archive object key.
+func putArchive(t *testing.T, store archive.Store, key string, msgs []*domain.Message) {
+	var buf bytes.Buffer
+	if err := archive.Encode(&buf, msgs); err != nil {
+		t.Fatal(err)
+	}
+	if err := store.Put(context.Background(), key, &buf); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestArchiveExpiredMessages(t *testing.T) {
+	ctx := context.Background()
+	now := time.Date(2025, 6, 15, 0, 0, 0, 0, time.UTC)
+	start := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	end := start.AddDate(0, 1, 0)
+	unit := domain.ArchiveUnit{ConversationID: "conv-1", PeriodStart: start}
+
+	t.Run("Moves a month to the store", func(t *testing.T) {
+		repo := new(MockArchiveRepo)
+		store := &archive.LocalFS{Root: t.TempDir()}
+		svc := &Service{repo: repo, tx: new(MockTransactor), archive: store, opts: Options{Retention: 365 * 24 * time.Hour}}
+
+		msgs := []*domain.Message{
+			{ID: "msg-1", ConversationID: "conv-1", Sequence: 1, Content: "hi", SentAt: start.Add(time.Hour)},
+			{ID: "msg-2", ConversationID: "conv-1", Sequence: 2, Content: "file", SentAt: start.Add(2 * time.Hour)},
+		}
+		att := domain.Attachment{ID: "att-1", FileName: "a.png", MimeType: "image/png", SizeBytes: 10}
+		pinnedAt := start.Add(3 * time.Hour)
+		extras := &domain.MessageExtras{
+			Revisions: []domain.Revision{{Number: 1, Content: "hello", ReplacedAt: start.Add(90 * time.Minute)}},
+			Reactions: []domain.Reaction{{UserID: "user-2", Emoji: "👍", CreatedAt: start.Add(2 * time.Hour)}},
+			PinnedBy:  "user-2",
+			PinnedAt:  &pinnedAt,
+		}
+		poll := &domain.Poll{
+			MessageID: "msg-2", ConversationID: "conv-1", CreatorID: "user-1", Options: []string{"yes", "no"},
+			Ballots: []domain.PollBallot{{UserID: "user-2", OptionIndex: 1, VotedAt: start.Add(4 * time.Hour)}},
+		}
+
+		repo.On("ListArchiveUnits", ctx, now, 365*24*time.Hour, 10).Return([]domain.ArchiveUnit{unit}, nil).Once()
+		repo.On("LockMessagesInPeriod", ctx, mock.Anything, "conv-1", start, end).Return(msgs, nil).Once()
+		repo.On("ListMessageAttachments", ctx, mock.Anything, []string{"msg-1", "msg-2"}).
+			Return(map[string][]domain.Attachment{"msg-2": {att}}, nil).Once()
+		repo.On("ListMessageExtras", ctx, mock.Anything, []string{"msg-1", "msg-2"}).
+			Return(map[string]*domain.MessageExtras{"msg-1": extras}, nil).Once()
+		repo.On("ListMessageMentions", ctx, mock.Anything, []string{"msg-1", "msg-2"}).
+			Return(map[string][]string{"msg-1": {"user-2"}}, nil).Once()
+		repo.On("ListPolls", ctx, mock.Anything, []string{"msg-1", "msg-2"}).
+			Return(map[string]*domain.Poll{"msg-2": poll}, nil).Once()
+		repo.On("GetArchiveForUpdate", ctx, mock.Anything, "conv-1", start).Return(nil, nil).Once()
+		repo.On("DeleteMessagesInPeriod", ctx, mock.Anything, "conv-1", start, end, []string{"msg-1", "msg-2"}).Return(nil).Once()
+		repo.On("SaveArchive", ctx, mock.Anything, mock.MatchedBy(func(a *domain.Archive) bool {
+			return a.MessageCount == 2 && a.FirstSequence == 1 && a.LastSequence == 2 &&
+				a.PeriodEnd.Equal(end) && a.RestoredUntil == nil
+		})).Return(nil).Once()
+
+		units, n, err := svc.ArchiveExpiredMessages(ctx, now, 10)
+		assert.NoError(t, err)
+		assert.Equal(t, 1, units)
+		assert.Equal(t, 2, n)
+		repo.AssertExpectations(t)
+
+		archived := readArchiveObject(t, store, "messages/conv-1/2024-01.jsonl.gz")
+		if assert.Len(t, archived, 2) {
+			assert.Equal(t, "hi", archived[0].Content)
+			assert.Equal(t, []domain.Attachment{att}, archived[1].Attachments)
+
+			// Everything deleting the rows takes with them is kept too
+			assert.Equal(t, extras, archived[0].Extras)
+			assert.Equal(t, []string{"user-2"}, archived[0].Mentions)
+			assert.Nil(t, archived[1].Extras)
+			if assert.NotNil(t, archived[1].Poll) {
+				assert.Equal(t, []string{"yes", "no"}, archived[1].Poll.Options)
+				assert.Equal(t, poll.Ballots, archived[1].Poll.Ballots)
+			}
+		}
+	})
+
+	t.Run("Merges with an earlier archive", func(t *testing.T) {
+		repo := new(MockArchiveRepo)
+		store := &archive.LocalFS{Root: t.TempDir()}
+		svc := &Service{repo: repo, tx: new(MockTransactor), archive: store}
+
+		// Archived before, then partly restored; msg-2 has expired since
+		expired := now.Add(-time.Hour)
+		key := domain.ArchiveObjectKey("conv-1", start)
+		putArchive(t, store, key, []*domain.Message{
+			{ID: "msg-1", ConversationID: "conv-1", Sequence: 1, Content: "old"},
+			{ID: "msg-2", ConversationID: "conv-1", Sequence: 2, ExpiresAt: &expired},
+			{ID: "msg-3", ConversationID: "conv-1", Sequence: 3, Content: "kept"},
+		})
+
+		until := now.Add(-time.Minute)
+		prev := &domain.Archive{ConversationID: "conv-1", PeriodStart: start, ObjectKey: key, RestoredUntil: &until}
+		current := []*domain.Message{{ID: "msg-1", ConversationID: "conv-1", Sequence: 1, Content: "edited"}}
+
+		repo.On("ListArchiveUnits", ctx, now, time.Duration(0), 10).Return([]domain.ArchiveUnit{unit}, nil).Once()
+		repo.On("LockMessagesInPeriod", ctx, mock.Anything, "conv-1", start, end).Return(current, nil).Once()
+		repo.On("ListMessageAttachments", ctx, mock.Anything, []string{"msg-1"}).Return(map[string][]domain.Attachment{}, nil).Once()
+		repo.On("ListMessageExtras", ctx, mock.Anything, []string{"msg-1"}).Return(map[string]*domain.MessageExtras{}, nil).Once()
+		repo.On("ListMessageMentions", ctx, mock.Anything, []string{"msg-1"}).Return(map[string][]string{}, nil).Once()
+		repo.On("ListPolls", ctx, mock.Anything, []string{"msg-1"}).Return(map[string]*domain.Poll{}, nil).Once()
+		repo.On("GetArchiveForUpdate", ctx, mock.Anything, "conv-1", start).Return(prev, nil).Once()
+		repo.On("DeleteMessagesInPeriod", ctx, mock.Anything, "conv-1", start, end, []string{"msg-1"}).Return(nil).Once()
+		repo.On("SaveArchive", ctx, mock.Anything, mock.MatchedBy(func(a *domain.Archive) bool {
+			return a.MessageCount == 2 && a.RestoredUntil == nil
+		})).Return(nil).Once()
+
+		_, n, err := svc.ArchiveExpiredMessages(ctx, now, 10)
+		assert.NoError(t, err)
+		assert.Equal(t, 1, n)
+		repo.AssertExpectations(t)
+
+		archived := readArchiveObject(t, store, key)
+		if assert.Len(t, archived, 2) {
+			assert.Equal(t, "edited", archived[0].Content)
+			assert.Equal(t, "msg-3", archived[1].ID)
+		}
+	})
+}
+
+func TestRestoreArchive(t *testing.T) {
+	ctx := context.Background()
+	start := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+
+	repo := new(MockArchiveRepo)
+	store := &archive.LocalFS{Root: t.TempDir()}
+	svc := &Service{repo: repo, tx: new(MockTransactor), archive: store, opts: Options{RestoreHold: time.Hour}}
+
+	att := domain.Attachment{ID: "att-1", FileName: "a.png", MimeType: "image/png", SizeBytes: 10}
+	key := domain.ArchiveObjectKey("conv-1", start)
+	pinnedAt := start.Add(time.Hour)
+	extras := &domain.MessageExtras{
+		Revisions: []domain.Revision{{Number: 1, Content: "first", ReplacedAt: start.Add(time.Minute)}},
+		Reactions: []domain.Reaction{{UserID: "user-2", Emoji: "🎉", CreatedAt: start.Add(2 * time.Minute)}},
+		PinnedBy:  "user-2",
+		PinnedAt:  &pinnedAt,
+	}
+	poll := &domain.Poll{
+		MessageID: "msg-1", ConversationID: "conv-1", CreatorID: "user-1", Options: []string{"a", "b"},
+		Ballots: []domain.PollBallot{{UserID: "user-2", OptionIndex: 0, VotedAt: start.Add(3 * time.Minute)}},
+	}
+	putArchive(t, store, key, []*domain.Message{
+		{
+			ID: "msg-1", ConversationID: "conv-1", Sequence: 1, Attachments: []domain.Attachment{att},
+			Extras: extras, Mentions: []string{"user-2"}, Poll: poll,
+		},
+		{ID: "msg-2", ConversationID: "conv-1", Sequence: 2, Mentions: []string{"user-3"}},
+	})
+	a := &domain.Archive{ConversationID: "conv-1", PeriodStart: start, ObjectKey: key}
+
+	from, to := start.AddDate(0, 0, 10), start.AddDate(0, 0, 20)
+	repo.On("ListArchives", ctx, mock.Anything, "conv-1", from, to).Return([]*domain.Archive{a}, nil).Once()
+	repo.On("EnsurePartition", ctx, mock.Anything, start).Return(true, nil).Once()
+	repo.On("GetArchiveForUpdate", ctx, mock.Anything, "conv-1", start).Return(a, nil).Once()
+	repo.On("RestoreMessage", ctx, mock.Anything, mock.MatchedBy(func(m *domain.Message) bool { return m.ID == "msg-1" })).Return(true, nil).Once()
+	// Still in the table from an earlier restore
+	repo.On("RestoreMessage", ctx, mock.Anything, mock.MatchedBy(func(m *domain.Message) bool { return m.ID == "msg-2" })).Return(false, nil).Once()
+	repo.On("InsertMessageAttachments", ctx, mock.Anything, "msg-1", []domain.Attachment{att}).Return(nil).Once()
+	repo.On("RestoreMessageExtras", ctx, mock.Anything, mock.MatchedBy(func(m *domain.Message) bool {
+		return m.ID == "msg-1" && assert.ObjectsAreEqual(extras, m.Extras)
+	})).Return(nil).Once()
+	repo.On("InsertMentions", ctx, mock.Anything, mock.MatchedBy(func(m *domain.Message) bool {
+		return m.ID == "msg-1" && assert.ObjectsAreEqual([]string{"user-2"}, m.Mentions)
+	})).Return(nil).Once()
+	repo.On("InsertPoll", ctx, mock.Anything, mock.MatchedBy(func(p *domain.Poll) bool {
+		return p.MessageID == "msg-1" && assert.ObjectsAreEqual(poll.Options, p.Options)
+	})).Return(nil).Once()
+	repo.On("RestorePollBallots", ctx, mock.Anything, mock.MatchedBy(func(p *domain.Poll) bool {
+		return p.MessageID == "msg-1" && assert.ObjectsAreEqual(poll.Ballots, p.Ballots)
+	})).Return(nil).Once()
+	repo.On("SaveArchive", ctx, mock.Anything, mock.MatchedBy(func(a *domain.Archive) bool {
+		return a.RestoredAt != nil && a.RestoredUntil != nil && a.RestoredUntil.Sub(*a.RestoredAt) == time.Hour
+	})).Return(nil).Once()
+
+	n, err := svc.RestoreArchive(ctx, RestoreCommand{ConversationID: "conv-1", From: from, To: to})
+	assert.NoError(t, err)
+	assert.Equal(t, 1, n)
+	repo.AssertExpectations(t)
+
+	_, err = svc.RestoreArchive(ctx, RestoreCommand{ConversationID: "conv-1", From: to, To: from})
+	assert.ErrorIs(t, err, domain.ErrInvalidInput)
+}
@@ -0,0 +1,125 @@
+package application
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	mediav1 "github.com/SARVESHVARADKAR123/RealChat/contracts/gen/go/media/v1"
+	"github.com/SARVESHVARADKAR123/RealChat/services/message/internal/domain"
+	"go.uber.org/zap"
+)
+
+// resolveAttachments looks up ids in the media service. Every attachment must
+// exist and belong to senderID; unknown and foreign IDs are rejected alike so
+// that other users' uploads can't be probed.
+func (s *Service) resolveAttachments(
+	ctx context.Context,
+	senderID string,
+	ids []string,
+) ([]domain.Attachment, error) {
+
+	if err := domain.ValidateAttachmentIDs(ids); err != nil {
+		return nil, err
+	}
+	if len(ids) == 0 {
+		return nil, nil
+	}
+
+	resp, err := s.media.GetAttachments(ctx, &mediav1.GetAttachmentsRequest{
+		AttachmentIds: ids,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch attachments via gRPC: %w", err)
+	}
+
+	byID := make(map[string]*mediav1.Attachment, len(resp.Attachments))
+	for _, a := range resp.Attachments {
+		byID[a.AttachmentId] = a
+	}
+
+	atts := make([]domain.Attachment, 0, len(ids))
+	for _, id := range ids {
+		a, ok := byID[id]
+		if !ok || a.OwnerUserId != senderID {
+			return nil, domain.ErrInvalidAttachment
+		}
+		atts = append(atts, domain.Attachment{
+			ID:        a.AttachmentId,
+			FileName:  a.FileName,
+			MimeType:  a.MimeType,
+			SizeBytes: a.SizeBytes,
+		})
+	}
+	return atts, nil
+}
+
+// attachAttachments loads the attachments of msgs and signs their download URLs.
+func (s *Service) attachAttachments(ctx context.Context, msgs []*domain.Message) error {
+	if len(msgs) == 0 {
+		return nil
+	}
+
+	ids := make([]string, 0, len(msgs))
+	for _, m := range msgs {
+		ids = append(ids, m.ID)
+	}
+
+	atts, err := s.repo.ListMessageAttachments(ctx, nil, ids)
+	if err != nil {
+		return err
+	}
+
+	for _, m := range msgs {
+		m.Attachments = atts[m.ID]
+	}
+
+	s.signAttachmentURLs(ctx, msgs)
+	return nil
+}
+
+// signAttachmentURLs fills the download URLs of attachments that don't have
+// one yet. Messages are still readable without URLs, so a media service
+// failure is logged rather than returned.
+func (s *Service) signAttachmentURLs(ctx context.Context, msgs []*domain.Message) {
+	var ids []string
+	for _, m := range msgs {
+		for _, a := range m.Attachments {
+			if a.DownloadURL == "" {
+				ids = append(ids, a.ID)
+			}
+		}
+	}
+	if len(ids) == 0 {
+		return
+	}
+
+	resp, err := s.media.GetAttachments(ctx, &mediav1.GetAttachmentsRequest{
+		AttachmentIds:       ids,
+		IncludeDownloadUrls: true,
+	})
+	if err != nil {
+		s.log.Warn("failed to sign attachment URLs", zap.Error(err))
+		return
+	}
+
+	signed := make(map[string]*mediav1.Attachment, len(resp.Attachments))
+	for _, a := range resp.Attachments {
+		signed[a.AttachmentId] = a
+	}
+
+	for _, m := range msgs {
+		for i := range m.Attachments {
+			a := &m.Attachments[i]
+			sa, ok := signed[a.ID]
+			if !ok || a.DownloadURL != "" {
+				continue
+			}
+			a.DownloadURL = sa.DownloadUrl
+			if sa.DownloadUrlExpiresAt != nil {
+				exp := sa.DownloadUrlExpiresAt.AsTime().In(time.UTC)
+				a.DownloadURLExpiresAt = &exp
+			}
+		}
+	}
+}
@@ -0,0 +1,157 @@
+package application
+
+import (
+	"context"
+	"database/sql"
+	"testing"
+	"time"
+
+	mediav1 "github.com/SARVESHVARADKAR123/RealChat/contracts/gen/go/media/v1"
+	messagev1 "github.com/SARVESHVARADKAR123/RealChat/contracts/gen/go/message/v1"
+	sharedv1 "github.com/SARVESHVARADKAR123/RealChat/contracts/gen/go/shared/v1"
+	"github.com/SARVESHVARADKAR123/RealChat/services/message/internal/domain"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"go.uber.org/zap"
+	"google.golang.org/grpc"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+// MockMediaClient is a mock for the MediaApiClient interface
+type MockMediaClient struct {
+	mock.Mock
+	mediav1.MediaApiClient
+}
+
+func (m *MockMediaClient) GetAttachments(ctx context.Context, req *mediav1.GetAttachmentsRequest, opts ...grpc.CallOption) (*mediav1.GetAttachmentsResponse, error) {
+	args := m.Called(ctx, req)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*mediav1.GetAttachmentsResponse), args.Error(1)
+}
+
+func TestResolveAttachments(t *testing.T) {
+	ctx := context.Background()
+	resp := &mediav1.GetAttachmentsResponse{
+		Attachments: []*mediav1.Attachment{
+			{AttachmentId: "a1", OwnerUserId: "user-1", FileName: "cat.png", MimeType: "image/png", SizeBytes: 42},
+			{AttachmentId: "a2", OwnerUserId: "user-2", FileName: "dog.png", MimeType: "image/png", SizeBytes: 7},
+		},
+	}
+
+	t.Run("Keeps request order for owned attachments", func(t *testing.T) {
+		media := new(MockMediaClient)
+		svc := &Service{media: media}
+
+		media.On("GetAttachments", ctx, &mediav1.GetAttachmentsRequest{AttachmentIds: []string{"a1"}}).Return(resp, nil).Once()
+
+		atts, err := svc.resolveAttachments(ctx, "user-1", []string{"a1"})
+		assert.NoError(t, err)
+		assert.Equal(t, []domain.Attachment{{ID: "a1", FileName: "cat.png", MimeType: "image/png", SizeBytes: 42}}, atts)
+		media.AssertExpectations(t)
+	})
+
+	t.Run("Foreign and unknown attachments are rejected", func(t *testing.T) {
+		media := new(MockMediaClient)
+		svc := &Service{media: media}
+
+		media.On("GetAttachments", ctx, mock.Anything).Return(resp, nil).Twice()
+
+		_, err := svc.resolveAttachments(ctx, "user-1", []string{"a1", "a2"})
+		assert.ErrorIs(t, err, domain.ErrInvalidAttachment)
+
+		_, err = svc.resolveAttachments(ctx, "user-1", []string{"a9"})
+		assert.ErrorIs(t, err, domain.ErrInvalidAttachment)
+		media.AssertExpectations(t)
+	})
+
+	t.Run("Duplicates fail before calling media", func(t *testing.T) {
+		media := new(MockMediaClient)
+		svc := &Service{media: media}
+
+		_, err := svc.resolveAttachments(ctx, "user-1", []string{"a1", "a1"})
+		assert.ErrorIs(t, err, domain.ErrInvalidAttachment)
+		media.AssertExpectations(t)
+	})
+}
+
+// attachmentRepo sends like benchRepo and keeps the outbox.
+type attachmentRepo struct {
+	benchRepo
+	outbox [][]byte
+}
+
+func (r *attachmentRepo) InsertMessageAttachments(ctx context.Context, tx *sql.Tx, messageID string, atts []domain.Attachment) error {
+	return nil
+}
+
+func (r *attachmentRepo) InsertOutbox(ctx context.Context, tx *sql.Tx, aggregateType, aggregateID, eventType string, payload []byte) error {
+	r.outbox = append(r.outbox, payload)
+	return nil
+}
+
+// trackingTx notes whether a transaction is open.
+type trackingTx struct{ open bool }
+
+func (t *trackingTx) WithTx(ctx context.Context, fn func(ctx context.Context, tx *sql.Tx) error) error {
+	t.open = true
+	defer func() { t.open = false }()
+	return fn(ctx, nil)
+}
+
+func TestSendMessage_SignsAttachmentsAfterCommit(t *testing.T) {
+	ctx := context.Background()
+	repo := &attachmentRepo{benchRepo: benchRepo{
+		blockRepo: blockRepo{MockRepo: new(MockRepo), blocks: map[string]domain.SequenceBlock{}},
+		info:      &domain.ConversationInfo{ID: "conv-1", Group: true, Members: []domain.Member{{UserID: "user-1"}}},
+	}}
+	tx := &trackingTx{}
+	media := new(MockMediaClient)
+	svc := &Service{repo: repo, tx: tx, convSvc: &slowConvClient{}, media: media, log: zap.NewNop()}
+
+	expires := time.Now().Add(time.Hour).UTC().Truncate(time.Second)
+	media.On("GetAttachments", mock.Anything, &mediav1.GetAttachmentsRequest{AttachmentIds: []string{"a1"}}).Return(&mediav1.GetAttachmentsResponse{
+		Attachments: []*mediav1.Attachment{{AttachmentId: "a1", OwnerUserId: "user-1", FileName: "cat.png", MimeType: "image/png", SizeBytes: 42}},
+	}, nil).Once()
+	media.On("GetAttachments", mock.Anything, &mediav1.GetAttachmentsRequest{AttachmentIds: []string{"a1"}, IncludeDownloadUrls: true}).Run(func(args mock.Arguments) {
+		assert.False(t, tx.open, "URLs signed inside the transaction")
+	}).Return(&mediav1.GetAttachmentsResponse{
+		Attachments: []*mediav1.Attachment{{AttachmentId: "a1", DownloadUrl: "https://media/a1?sig", DownloadUrlExpiresAt: timestamppb.New(expires)}},
+	}, nil).Once()
+
+	msg, err := svc.SendMessage(ctx, SendMessageCommand{
+		ConversationID: "conv-1",
+		UserID:         "user-1",
+		ClientMsgID:    "c1",
+		Content:        "look",
+		AttachmentIDs:  []string{"a1"},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	media.AssertExpectations(t)
+
+	// The sender gets a signed URL
+	if assert.Len(t, msg.Attachments, 1) {
+		assert.Equal(t, "https://media/a1?sig", msg.Attachments[0].DownloadURL)
+		if assert.NotNil(t, msg.Attachments[0].DownloadURLExpiresAt) {
+			assert.Equal(t, expires, *msg.Attachments[0].DownloadURLExpiresAt)
+		}
+	}
+
+	// The event does not, so a replay never hands out an expired one
+	if assert.Len(t, repo.outbox, 1) {
+		var env sharedv1.EventEnvelope
+		var event messagev1.MessageSentEvent
+		assert.NoError(t, proto.Unmarshal(repo.outbox[0], &env))
+		assert.NoError(t, proto.Unmarshal(env.GetPayload(), &event))
+		atts := event.GetMessage().GetAttachments()
+		if assert.Len(t, atts, 1) {
+			assert.Equal(t, "a1", atts[0].GetAttachmentId())
+			assert.Empty(t, atts[0].GetDownloadUrl())
+			assert.Nil(t, atts[0].GetDownloadUrlExpiresAt())
+		}
+	}
+}
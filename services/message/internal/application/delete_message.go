@@ -41,15 +41,13 @@ func (s *Service) DeleteMessage(
 
 		if msg.SenderID != cmd.RequesterID {
 			// If not sender, check if requester is an admin
-			convResp, err := s.convSvc.GetConversation(ctx, &conversationv1.GetConversationRequest{
-				ConversationId: cmd.ConversationID,
-			})
+			conv, err := s.conversation(ctx, cmd.ConversationID, cmd.RequesterID)
 			if err != nil {
 				return err
 			}
 
 			isAdmin := false
-			for _, p := range convResp.Conversation.ParticipantsWithRoles {
+			for _, p := range conv.GetParticipantsWithRoles() {
 				if p.UserId == cmd.RequesterID && p.Role == conversationv1.ParticipantRole_ADMIN {
 					isAdmin = true
 					break
@@ -75,6 +73,18 @@ func (s *Service) DeleteMessage(
 			return err
 		}
 
+		// A deleted reply no longer counts towards its thread
+		if msg.ThreadRootID != "" {
+			if err := s.repo.RefreshThreadSummaries(ctx, tx, []string{msg.ThreadRootID}); err != nil {
+				return err
+			}
+		}
+
+		// Deleted messages don't stay pinned
+		if err := s.unpin(ctx, tx, cmd.ConversationID, cmd.MessageID, cmd.RequesterID); err != nil {
+			return err
+		}
+
 		// 3️⃣ Emit outbox event
 		event := &messagev1.MessageDeletedEvent{
 			ConversationId: cmd.ConversationID,
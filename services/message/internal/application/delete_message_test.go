@@ -4,74 +4,29 @@ import (
 	"context"
 	"database/sql"
 	"testing"
-	"time"
 
 	conversationv1 "github.com/SARVESHVARADKAR123/RealChat/contracts/gen/go/conversation/v1"
 	"github.com/SARVESHVARADKAR123/RealChat/services/message/internal/domain"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/mock"
-	"google.golang.org/grpc"
 )
 
-// MockRepo is a mock for the Repository interface
-type MockRepo struct {
-	mock.Mock
+// MockDeleteRepo adds the calls deleting a message makes.
+type MockDeleteRepo struct {
+	MockRepo
 }
 
-func (m *MockRepo) InsertMessage(ctx context.Context, tx *sql.Tx, msg *domain.Message) error {
-	return m.Called(ctx, tx, msg).Error(0)
-}
-func (m *MockRepo) MarkMessageDeleted(ctx context.Context, tx *sql.Tx, msgID string) error {
+func (m *MockDeleteRepo) MarkMessageDeleted(ctx context.Context, tx *sql.Tx, msgID string) error {
 	return m.Called(ctx, tx, msgID).Error(0)
 }
-func (m *MockRepo) GetMessageForUpdate(ctx context.Context, tx *sql.Tx, messageID string) (*domain.Message, error) {
-	args := m.Called(ctx, tx, messageID)
-	if args.Get(0) == nil {
-		return nil, args.Error(1)
-	}
-	return args.Get(0).(*domain.Message), args.Error(1)
-}
-func (m *MockRepo) FetchMessages(ctx context.Context, convID string, lastSeq int64, limit int) ([]*domain.Message, error) {
-	args := m.Called(ctx, convID, lastSeq, limit)
-	return args.Get(0).([]*domain.Message), args.Error(1)
-}
-func (m *MockRepo) TryInsertIdempotency(ctx context.Context, tx *sql.Tx, key, userID, conversationID string, expiresAt time.Time) (bool, error) {
-	return true, nil
-}
-func (m *MockRepo) GetIdempotencyForUpdate(ctx context.Context, tx *sql.Tx, key, userID, conversationID string) ([]byte, error) {
-	return nil, nil
-}
-func (m *MockRepo) UpdateIdempotencyResponse(ctx context.Context, tx *sql.Tx, key, userID, conversationID string, payload []byte) error {
-	return nil
-}
-func (m *MockRepo) InsertOutbox(ctx context.Context, tx *sql.Tx, aggregateType, aggregateID, eventType string, payload []byte) error {
-	return m.Called(ctx, tx, aggregateType, aggregateID, eventType, payload).Error(0)
-}
-
-// MockConvClient is a mock for the ConversationApiClient interface
-type MockConvClient struct {
-	mock.Mock
-	conversationv1.ConversationApiClient
-}
-
-func (m *MockConvClient) GetConversation(ctx context.Context, req *conversationv1.GetConversationRequest, opts ...grpc.CallOption) (*conversationv1.GetConversationResponse, error) {
-	args := m.Called(ctx, req)
-	if args.Get(0) == nil {
-		return nil, args.Error(1)
-	}
-	return args.Get(0).(*conversationv1.GetConversationResponse), args.Error(1)
-}
-
-// MockTransactor is a mock for the Transactor interface
-type MockTransactor struct{}
-
-func (m *MockTransactor) WithTx(ctx context.Context, fn func(ctx context.Context, tx *sql.Tx) error) error {
-	return fn(ctx, nil)
+func (m *MockDeleteRepo) UnpinMessage(ctx context.Context, tx *sql.Tx, conversationID, messageID string) (bool, error) {
+	args := m.Called(ctx, tx, conversationID, messageID)
+	return args.Bool(0), args.Error(1)
 }
 
 func TestDeleteMessage_Admin(t *testing.T) {
 	ctx := context.Background()
-	repo := new(MockRepo)
+	repo := new(MockDeleteRepo)
 	tx := new(MockTransactor)
 	convSvc := new(MockConvClient)
 	svc := &Service{repo: repo, tx: tx, convSvc: convSvc}
@@ -91,6 +46,7 @@ func TestDeleteMessage_Admin(t *testing.T) {
 	t.Run("Sender can delete", func(t *testing.T) {
 		repo.On("GetMessageForUpdate", ctx, mock.Anything, msgID).Return(msg, nil).Once()
 		repo.On("MarkMessageDeleted", ctx, mock.Anything, msgID).Return(nil).Once()
+		repo.On("UnpinMessage", ctx, mock.Anything, convID, msgID).Return(false, nil).Once()
 		repo.On("InsertOutbox", ctx, mock.Anything, "message", convID, "MESSAGE_DELETED", mock.Anything).Return(nil).Once()
 
 		err := svc.DeleteMessage(ctx, DeleteMessageCommand{
@@ -112,6 +68,7 @@ func TestDeleteMessage_Admin(t *testing.T) {
 			},
 		}, nil).Once()
 		repo.On("MarkMessageDeleted", ctx, mock.Anything, msgID).Return(nil).Once()
+		repo.On("UnpinMessage", ctx, mock.Anything, convID, msgID).Return(false, nil).Once()
 		repo.On("InsertOutbox", ctx, mock.Anything, "message", convID, "MESSAGE_DELETED", mock.Anything).Return(nil).Once()
 
 		err := svc.DeleteMessage(ctx, DeleteMessageCommand{
@@ -0,0 +1,126 @@
+package application
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"time"
+
+	messagev1 "github.com/SARVESHVARADKAR123/RealChat/contracts/gen/go/message/v1"
+	sharedv1 "github.com/SARVESHVARADKAR123/RealChat/contracts/gen/go/shared/v1"
+	"github.com/SARVESHVARADKAR123/RealChat/services/message/internal/domain"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+type SaveDraftCommand struct {
+	ConversationID string
+	UserID         string
+	DeviceID       string
+	Text           string
+	// UpdatedAt is when the device wrote the draft; zero means now.
+	UpdatedAt time.Time
+}
+
+// SaveDraft stores the draft in cmd unless a later one is stored already.
+// It returns the draft in effect and whether cmd's draft became it. An empty
+// Text clears the draft.
+func (s *Service) SaveDraft(ctx context.Context, cmd SaveDraftCommand) (*domain.Draft, bool, error) {
+	d, err := domain.NewDraft(cmd.ConversationID, cmd.UserID, cmd.DeviceID, cmd.Text, cmd.UpdatedAt, time.Now().UTC())
+	if err != nil {
+		return nil, false, err
+	}
+
+	if _, err := s.requireParticipant(ctx, cmd.ConversationID, cmd.UserID); err != nil {
+		return nil, false, err
+	}
+
+	var result *domain.Draft
+	var applied bool
+	err = s.tx.WithTx(ctx, func(ctx context.Context, tx *sql.Tx) error {
+		var err error
+		result, applied, err = s.putDraft(ctx, tx, d)
+		return err
+	})
+	if err != nil {
+		return nil, false, err
+	}
+	return result, applied, nil
+}
+
+// GetDrafts returns userID's drafts that hold text.
+func (s *Service) GetDrafts(ctx context.Context, userID string) ([]*domain.Draft, error) {
+	return s.repo.ListDrafts(ctx, userID)
+}
+
+// putDraft stores d within tx if it supersedes the stored draft and
+// returns the draft in effect.
+func (s *Service) putDraft(ctx context.Context, tx *sql.Tx, d *domain.Draft) (*domain.Draft, bool, error) {
+	current, err := s.repo.GetDraftForUpdate(ctx, tx, d.UserID, d.ConversationID)
+	if err != nil {
+		return nil, false, fmt.Errorf("failed to load draft: %w", err)
+	}
+	return s.replaceDraft(ctx, tx, current, d)
+}
+
+// replaceDraft stores d in place of current, the locked stored draft if any,
+// when d is later, and tells the author's other devices. Clearing a draft
+// that is already clear is recorded but not announced.
+func (s *Service) replaceDraft(ctx context.Context, tx *sql.Tx, current, d *domain.Draft) (*domain.Draft, bool, error) {
+	if !d.Supersedes(current) {
+		return current, false, nil
+	}
+
+	applied, err := s.repo.PutDraft(ctx, tx, d)
+	if err != nil {
+		return nil, false, fmt.Errorf("failed to save draft: %w", err)
+	}
+	if !applied {
+		// A concurrent first save won the insert
+		current, err := s.repo.GetDraftForUpdate(ctx, tx, d.UserID, d.ConversationID)
+		if err != nil {
+			return nil, false, fmt.Errorf("failed to load draft: %w", err)
+		}
+		return current, false, nil
+	}
+
+	if d.Cleared() && (current == nil || current.Cleared()) {
+		return d, true, nil
+	}
+	if err := s.emitEvent(ctx, tx, d.ConversationID,
+		sharedv1.EventType_EVENT_TYPE_DRAFT_UPDATED, "DRAFT_UPDATED",
+		&messagev1.DraftUpdatedEvent{Draft: ToProtoDraft(d)},
+	); err != nil {
+		return nil, false, err
+	}
+	return d, true, nil
+}
+
+// clearDraftOnSend clears the sender's draft of the conversation a message
+// was just sent into, unless the draft was written after the message. Users
+// without a draft there cost a lookup and no write.
+func (s *Service) clearDraftOnSend(ctx context.Context, tx *sql.Tx, conversationID, userID string, sentAt time.Time) error {
+	current, err := s.repo.GetDraftForUpdate(ctx, tx, userID, conversationID)
+	if err != nil {
+		return fmt.Errorf("failed to load draft: %w", err)
+	}
+	if current == nil || current.Cleared() {
+		return nil
+	}
+	_, _, err = s.replaceDraft(ctx, tx, current, &domain.Draft{
+		ConversationID: conversationID,
+		UserID:         userID,
+		UpdatedAt:      sentAt,
+	})
+	return err
+}
+
+// ToProtoDraft converts d for events and RPC responses alike.
+func ToProtoDraft(d *domain.Draft) *messagev1.Draft {
+	return &messagev1.Draft{
+		ConversationId: d.ConversationID,
+		UserId:         d.UserID,
+		Text:           d.Text,
+		UpdatedAt:      timestamppb.New(d.UpdatedAt),
+		DeviceId:       d.DeviceID,
+	}
+}
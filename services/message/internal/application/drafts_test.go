@@ -0,0 +1,165 @@
+package application
+
+import (
+	"context"
+	"database/sql"
+	"testing"
+	"time"
+
+	messagev1 "github.com/SARVESHVARADKAR123/RealChat/contracts/gen/go/message/v1"
+	sharedv1 "github.com/SARVESHVARADKAR123/RealChat/contracts/gen/go/shared/v1"
+	"github.com/SARVESHVARADKAR123/RealChat/services/message/internal/domain"
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/zap"
+	"google.golang.org/protobuf/proto"
+)
+
+// draftRepo keeps drafts in memory and records the draft events written.
+type draftRepo struct {
+	*MockRepo
+	drafts map[string]domain.Draft
+	events []*messagev1.DraftUpdatedEvent
+}
+
+func newDraftRepo() *draftRepo {
+	return &draftRepo{MockRepo: new(MockRepo), drafts: map[string]domain.Draft{}}
+}
+
+func (r *draftRepo) GetConversationInfo(ctx context.Context, tx *sql.Tx, conversationID string) (*domain.ConversationInfo, error) {
+	return &domain.ConversationInfo{ID: conversationID, Group: true, Members: []domain.Member{{UserID: "user-1"}}}, nil
+}
+
+func (r *draftRepo) GetDraftForUpdate(ctx context.Context, tx *sql.Tx, userID, conversationID string) (*domain.Draft, error) {
+	d, ok := r.drafts[userID+"/"+conversationID]
+	if !ok {
+		return nil, nil
+	}
+	return &d, nil
+}
+
+func (r *draftRepo) PutDraft(ctx context.Context, tx *sql.Tx, d *domain.Draft) (bool, error) {
+	key := d.UserID + "/" + d.ConversationID
+	if cur, ok := r.drafts[key]; ok && !cur.UpdatedAt.Before(d.UpdatedAt) {
+		return false, nil
+	}
+	r.drafts[key] = *d
+	return true, nil
+}
+
+func (r *draftRepo) InsertOutbox(ctx context.Context, tx *sql.Tx, aggregateType, aggregateID, eventType string, payload []byte) error {
+	var env sharedv1.EventEnvelope
+	if err := proto.Unmarshal(payload, &env); err != nil {
+		return err
+	}
+	var event messagev1.DraftUpdatedEvent
+	if err := proto.Unmarshal(env.GetPayload(), &event); err != nil {
+		return err
+	}
+	r.events = append(r.events, &event)
+	return nil
+}
+
+func TestNewDraft(t *testing.T) {
+	now := time.Date(2026, 3, 1, 12, 0, 0, 0, time.UTC)
+
+	d, err := domain.NewDraft("conv-1", "user-1", "phone", "hi", time.Time{}, now)
+	assert.NoError(t, err)
+	assert.Equal(t, now, d.UpdatedAt)
+
+	// A clock far ahead is pulled back so it cannot pin the draft
+	d, err = domain.NewDraft("conv-1", "user-1", "phone", "hi", now.Add(time.Hour), now)
+	assert.NoError(t, err)
+	assert.Equal(t, now, d.UpdatedAt)
+
+	_, err = domain.NewDraft("conv-1", "user-1", "phone", string(make([]byte, domain.MaxMessageSize+1)), now, now)
+	assert.ErrorIs(t, err, domain.ErrMessageTooLarge)
+
+	_, err = domain.NewDraft("", "user-1", "phone", "hi", now, now)
+	assert.ErrorIs(t, err, domain.ErrInvalidInput)
+}
+
+func TestSaveDraft(t *testing.T) {
+	ctx := context.Background()
+	base := time.Now().UTC().Add(-time.Hour)
+
+	repo := newDraftRepo()
+	svc := &Service{repo: repo, tx: new(MockTransactor), log: zap.NewNop()}
+	save := func(device, text string, at time.Time) (*domain.Draft, bool) {
+		d, applied, err := svc.SaveDraft(ctx, SaveDraftCommand{
+			ConversationID: "conv-1",
+			UserID:         "user-1",
+			DeviceID:       device,
+			Text:           text,
+			UpdatedAt:      at,
+		})
+		assert.NoError(t, err)
+		return d, applied
+	}
+
+	d, applied := save("phone", "hello", base)
+	assert.True(t, applied)
+	assert.Equal(t, "hello", d.Text)
+
+	// The laptop's later edit wins
+	_, applied = save("laptop", "hello there", base.Add(time.Second))
+	assert.True(t, applied)
+
+	// A stale save from the phone loses and returns the laptop's draft
+	d, applied = save("phone", "hello again", base.Add(500*time.Millisecond))
+	assert.False(t, applied)
+	assert.Equal(t, "hello there", d.Text)
+	assert.Equal(t, "laptop", d.DeviceID)
+
+	// Clearing is a save of empty text, and a second clear is silent
+	_, applied = save("phone", "", base.Add(2*time.Second))
+	assert.True(t, applied)
+	_, applied = save("laptop", "", base.Add(3*time.Second))
+	assert.True(t, applied)
+
+	// An edit made before the clear stays cleared
+	_, applied = save("laptop", "hello there!", base.Add(1500*time.Millisecond))
+	assert.False(t, applied)
+
+	if assert.Len(t, repo.events, 3) {
+		assert.Equal(t, "phone", repo.events[0].Draft.DeviceId)
+		assert.Equal(t, "laptop", repo.events[1].Draft.DeviceId)
+		assert.Equal(t, "", repo.events[2].Draft.Text)
+	}
+}
+
+func TestClearDraftOnSend(t *testing.T) {
+	ctx := context.Background()
+	sentAt := time.Now().UTC()
+
+	t.Run("Draft written before the send is cleared", func(t *testing.T) {
+		repo := newDraftRepo()
+		repo.drafts["user-1/conv-1"] = domain.Draft{ConversationID: "conv-1", UserID: "user-1", Text: "hi", DeviceID: "phone", UpdatedAt: sentAt.Add(-time.Second)}
+		svc := &Service{repo: repo, tx: new(MockTransactor), log: zap.NewNop()}
+
+		assert.NoError(t, svc.clearDraftOnSend(ctx, nil, "conv-1", "user-1", sentAt))
+		assert.Empty(t, repo.drafts["user-1/conv-1"].Text)
+		if assert.Len(t, repo.events, 1) {
+			assert.Equal(t, "", repo.events[0].Draft.Text)
+			assert.Equal(t, "", repo.events[0].Draft.DeviceId)
+		}
+	})
+
+	t.Run("Draft written after the send is kept", func(t *testing.T) {
+		repo := newDraftRepo()
+		repo.drafts["user-1/conv-1"] = domain.Draft{ConversationID: "conv-1", UserID: "user-1", Text: "next", UpdatedAt: sentAt.Add(time.Second)}
+		svc := &Service{repo: repo, tx: new(MockTransactor), log: zap.NewNop()}
+
+		assert.NoError(t, svc.clearDraftOnSend(ctx, nil, "conv-1", "user-1", sentAt))
+		assert.Equal(t, "next", repo.drafts["user-1/conv-1"].Text)
+		assert.Empty(t, repo.events)
+	})
+
+	t.Run("No draft, no write", func(t *testing.T) {
+		repo := newDraftRepo()
+		svc := &Service{repo: repo, tx: new(MockTransactor), log: zap.NewNop()}
+
+		assert.NoError(t, svc.clearDraftOnSend(ctx, nil, "conv-1", "user-1", sentAt))
+		assert.Empty(t, repo.drafts)
+		assert.Empty(t, repo.events)
+	})
+}
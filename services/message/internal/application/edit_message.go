@@ -0,0 +1,185 @@
+package application
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"time"
+
+	messagev1 "github.com/SARVESHVARADKAR123/RealChat/contracts/gen/go/message/v1"
+	sharedv1 "github.com/SARVESHVARADKAR123/RealChat/contracts/gen/go/shared/v1"
+	"github.com/SARVESHVARADKAR123/RealChat/services/message/internal/domain"
+	"github.com/SARVESHVARADKAR123/RealChat/services/message/internal/moderation"
+)
+
+type EditMessageCommand struct {
+	ConversationID string
+	MessageID      string
+	RequesterID    string
+	Content        string
+	Metadata       string
+}
+
+func (s *Service) EditMessage(
+	ctx context.Context,
+	cmd EditMessageCommand,
+) (*domain.Message, error) {
+
+	// Screened before the transaction, as hooks may be slow
+	screened, err := s.moderateEdit(ctx, &cmd)
+	if err != nil {
+		return nil, err
+	}
+	// Edits can't wait in the review queue, so held ones are refused too
+	refused := screened != nil && (screened.Action == moderation.Reject || screened.Action == moderation.Hold)
+
+	// Mentions are resolved against the members, like on send
+	conv, err := s.conversation(ctx, cmd.ConversationID, cmd.RequesterID)
+	if err != nil {
+		return nil, err
+	}
+
+	var result *domain.Message
+
+	err = s.tx.WithTx(ctx, func(ctx context.Context, tx *sql.Tx) error {
+
+		// 1️⃣ Lock the message and apply the edit rules
+		msg, err := s.repo.GetMessageForUpdate(ctx, tx, cmd.MessageID)
+		if err != nil {
+			return err
+		}
+
+		if msg.ConversationID != cmd.ConversationID {
+			return domain.ErrInvalidInput
+		}
+
+		// The service can't re-encrypt; clients send a new message instead
+		if msg.Type == domain.TypeEncrypted {
+			return domain.ErrMessageEncrypted
+		}
+
+		if refused {
+			return fmt.Errorf("%w: %s", domain.ErrMessageRejected, screened.Reason)
+		}
+
+		prevContent, prevMetadata := msg.Content, msg.Metadata
+
+		// A poll's options are fixed once votes can reference them
+		if msg.Type == domain.TypePoll && cmd.Metadata != msg.Metadata {
+			return &domain.ValidationError{Field: "metadata_json", Reason: "can't be edited on a poll"}
+		}
+
+		// Messages of types that are no longer registered keep the old rules
+		if t, ok := s.messageTypes().Lookup(msg.Type); ok {
+			atts, err := s.repo.ListMessageAttachments(ctx, tx, []string{msg.ID})
+			if err != nil {
+				return err
+			}
+			if err := t.Validate(cmd.Content, cmd.Metadata, len(atts[msg.ID])); err != nil {
+				return err
+			}
+		}
+
+		if err := msg.Edit(
+			cmd.RequesterID,
+			cmd.Content,
+			cmd.Metadata,
+			s.opts.EditWindow,
+			time.Now().UTC(),
+		); err != nil {
+			return err
+		}
+
+		// 2️⃣ Keep the replaced content as a revision, then persist the edit
+		if err := s.repo.InsertMessageRevision(ctx, tx, msg.ID, prevContent, prevMetadata); err != nil {
+			return err
+		}
+		if err := s.repo.UpdateMessageContent(ctx, tx, msg); err != nil {
+			return err
+		}
+		if screened != nil {
+			if err := s.recordModeration(ctx, tx, msg.ConversationID, cmd.RequesterID, screened, msg.ID, ""); err != nil {
+				return err
+			}
+		}
+
+		// 3️⃣ Re-parse mentions; only users the edit adds are notified
+		msg.Mentions = domain.ParseMentions(msg.Content, msg.Metadata).Resolve(
+			cmd.RequesterID,
+			conv.GetParticipantUserIds(),
+			hasAdminRights(conv, cmd.RequesterID),
+		)
+		added, err := s.repo.ReplaceMentions(ctx, tx, msg)
+		if err != nil {
+			return fmt.Errorf("failed to save mentions: %w", err)
+		}
+
+		// 4️⃣ Emit outbox event
+		if err := s.emitEvent(
+			ctx, tx,
+			msg.ConversationID,
+			sharedv1.EventType_EVENT_TYPE_MESSAGE_EDITED,
+			"MESSAGE_EDITED",
+			&messagev1.MessageEditedEvent{
+				Message:          ToProtoMessage(msg),
+				MentionedUserIds: added,
+			},
+		); err != nil {
+			return err
+		}
+
+		result = msg
+		return nil
+	})
+
+	if errors.Is(err, domain.ErrMessageRejected) && refused {
+		// The refusal rolled back the edit, so it is recorded on its own
+		if auditErr := s.recordModeration(ctx, nil, cmd.ConversationID, cmd.RequesterID, screened, cmd.MessageID, ""); auditErr != nil {
+			return nil, auditErr
+		}
+	}
+	if err != nil {
+		return nil, err
+	}
+	return result, nil
+}
+
+// moderateEdit runs the edited content through the moderation chain and
+// applies redactions to cmd. It returns nil when the edit isn't screened.
+// Edits that break the edit rules are turned down before reaching the
+// chain, so they are never screened or audited.
+func (s *Service) moderateEdit(ctx context.Context, cmd *EditMessageCommand) (*moderation.Result, error) {
+	if s.opts.Moderation == nil || cmd.Content == "" {
+		return nil, nil
+	}
+
+	msg, err := s.repo.GetMessage(ctx, nil, cmd.MessageID)
+	if err != nil {
+		return nil, err
+	}
+	if msg.ConversationID != cmd.ConversationID {
+		return nil, domain.ErrInvalidInput
+	}
+	if msg.Type == domain.TypeEncrypted {
+		return nil, domain.ErrMessageEncrypted
+	}
+	// Tried on a copy; the edit itself is applied under lock
+	probe := *msg
+	if err := probe.Edit(cmd.RequesterID, cmd.Content, cmd.Metadata, s.opts.EditWindow, time.Now().UTC()); err != nil {
+		return nil, err
+	}
+
+	res, err := s.screen(ctx, moderation.Message{
+		ConversationID: cmd.ConversationID,
+		SenderID:       cmd.RequesterID,
+		Type:           msg.Type,
+		Content:        cmd.Content,
+		Metadata:       cmd.Metadata,
+	})
+	if err != nil || res == nil {
+		return nil, err
+	}
+	cmd.Content = res.Content
+	return res, nil
+}
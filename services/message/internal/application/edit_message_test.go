@@ -0,0 +1,126 @@
+package application
+
+import (
+	"context"
+	"database/sql"
+	"testing"
+	"time"
+
+	"github.com/SARVESHVARADKAR123/RealChat/services/message/internal/domain"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+// MockEditRepo adds the calls editing a message makes.
+type MockEditRepo struct {
+	MockRepo
+}
+
+func (m *MockEditRepo) UpdateMessageContent(ctx context.Context, tx *sql.Tx, msg *domain.Message) error {
+	return m.Called(ctx, tx, msg).Error(0)
+}
+func (m *MockEditRepo) InsertMessageRevision(ctx context.Context, tx *sql.Tx, messageID, content, metadata string) error {
+	return m.Called(ctx, tx, messageID, content, metadata).Error(0)
+}
+func (m *MockEditRepo) ReplaceMentions(ctx context.Context, tx *sql.Tx, msg *domain.Message) ([]string, error) {
+	args := m.Called(ctx, tx, msg)
+	added, _ := args.Get(0).([]string)
+	return added, args.Error(1)
+}
+
+func TestEditMessage(t *testing.T) {
+	ctx := context.Background()
+	convID := "conv-1"
+	msgID := "msg-1"
+	senderID := "user-sender"
+
+	newMsg := func(sentAt time.Time) *domain.Message {
+		return &domain.Message{
+			ID:             msgID,
+			ConversationID: convID,
+			SenderID:       senderID,
+			Content:        "hello",
+			SentAt:         sentAt,
+		}
+	}
+
+	t.Run("Sender can edit inside window", func(t *testing.T) {
+		repo := new(MockEditRepo)
+		svc := &Service{repo: repo, tx: new(MockTransactor), convSvc: &slowConvClient{}, opts: Options{EditWindow: 15 * time.Minute}}
+
+		repo.On("GetMessageForUpdate", ctx, mock.Anything, msgID).Return(newMsg(time.Now().UTC()), nil).Once()
+		repo.On("InsertMessageRevision", ctx, mock.Anything, msgID, "hello", "").Return(nil).Once()
+		repo.On("UpdateMessageContent", ctx, mock.Anything, mock.Anything).Return(nil).Once()
+		repo.On("ReplaceMentions", ctx, mock.Anything, mock.Anything).Return([]string(nil), nil).Once()
+		repo.On("InsertOutbox", ctx, mock.Anything, "message", convID, "MESSAGE_EDITED", mock.Anything).Return(nil).Once()
+
+		msg, err := svc.EditMessage(ctx, EditMessageCommand{
+			ConversationID: convID,
+			MessageID:      msgID,
+			RequesterID:    senderID,
+			Content:        "hello, world",
+		})
+		assert.NoError(t, err)
+		assert.Equal(t, "hello, world", msg.Content)
+		assert.NotNil(t, msg.EditedAt)
+		repo.AssertExpectations(t)
+	})
+
+	t.Run("Other user cannot edit", func(t *testing.T) {
+		repo := new(MockEditRepo)
+		svc := &Service{repo: repo, tx: new(MockTransactor), convSvc: &slowConvClient{}}
+
+		repo.On("GetMessageForUpdate", ctx, mock.Anything, msgID).Return(newMsg(time.Now().UTC()), nil).Once()
+
+		_, err := svc.EditMessage(ctx, EditMessageCommand{
+			ConversationID: convID,
+			MessageID:      msgID,
+			RequesterID:    "user-other",
+			Content:        "hijack",
+		})
+		assert.ErrorIs(t, err, domain.ErrNotSender)
+		repo.AssertExpectations(t)
+	})
+
+	t.Run("Edit window expired", func(t *testing.T) {
+		repo := new(MockEditRepo)
+		svc := &Service{repo: repo, tx: new(MockTransactor), convSvc: &slowConvClient{}, opts: Options{EditWindow: 15 * time.Minute}}
+
+		repo.On("GetMessageForUpdate", ctx, mock.Anything, msgID).Return(newMsg(time.Now().UTC().Add(-time.Hour)), nil).Once()
+
+		_, err := svc.EditMessage(ctx, EditMessageCommand{
+			ConversationID: convID,
+			MessageID:      msgID,
+			RequesterID:    senderID,
+			Content:        "too late",
+		})
+		assert.ErrorIs(t, err, domain.ErrEditWindowExpired)
+		repo.AssertExpectations(t)
+	})
+}
+
+func TestEditMessage_Expired(t *testing.T) {
+	ctx := context.Background()
+	repo := newThreadRepo()
+	svc := newThreadService(repo)
+
+	msg, err := sendReply(t, svc, "", "vanishing")
+	if err != nil {
+		t.Fatal(err)
+	}
+	// Past its TTL but not reaped yet
+	expired := time.Now().UTC().Add(-time.Second)
+	repo.find(msg.ID).ExpiresAt = &expired
+
+	_, err = svc.EditMessage(ctx, EditMessageCommand{
+		ConversationID: "conv-1",
+		MessageID:      msg.ID,
+		RequesterID:    "user-1",
+		Content:        "still here?",
+	})
+	assert.ErrorIs(t, err, domain.ErrMessageNotFound)
+	assert.Equal(t, "vanishing", repo.find(msg.ID).Content)
+
+	_, err = sendReply(t, svc, msg.ID, "replying to nothing")
+	assert.ErrorIs(t, err, domain.ErrInvalidReplyTarget)
+}
@@ -0,0 +1,44 @@
+package application
+
+import (
+	"context"
+	"database/sql"
+
+	"github.com/SARVESHVARADKAR123/RealChat/services/message/internal/domain"
+)
+
+// attachCiphertexts loads the ciphertexts of the encrypted messages in msgs
+// that are addressed to one device. An empty userID loads every device's,
+// for moving messages around whole; an empty deviceID loads none.
+func (s *Service) attachCiphertexts(
+	ctx context.Context,
+	tx *sql.Tx,
+	msgs []*domain.Message,
+	userID, deviceID string,
+) error {
+
+	if userID != "" && deviceID == "" {
+		return nil
+	}
+
+	var ids []string
+	for _, m := range msgs {
+		if m.Type == domain.TypeEncrypted {
+			ids = append(ids, m.ID)
+		}
+	}
+	if len(ids) == 0 {
+		return nil
+	}
+
+	cts, err := s.repo.ListMessageCiphertexts(ctx, tx, ids, userID, deviceID)
+	if err != nil {
+		return err
+	}
+	for _, m := range msgs {
+		if m.Type == domain.TypeEncrypted {
+			m.Ciphertexts = cts[m.ID]
+		}
+	}
+	return nil
+}
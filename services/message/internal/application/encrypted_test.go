@@ -0,0 +1,152 @@
+package application
+
+import (
+	"context"
+	"database/sql"
+	"testing"
+	"time"
+
+	"github.com/SARVESHVARADKAR123/RealChat/services/message/internal/domain"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+// MockEncryptedRepo adds the per-device ciphertexts of encrypted messages.
+type MockEncryptedRepo struct {
+	MockRepo
+}
+
+func (m *MockEncryptedRepo) ListMessageCiphertexts(ctx context.Context, tx *sql.Tx, messageIDs []string, userID, deviceID string) (map[string][]domain.DeviceCiphertext, error) {
+	args := m.Called(ctx, tx, messageIDs, userID, deviceID)
+	return args.Get(0).(map[string][]domain.DeviceCiphertext), args.Error(1)
+}
+
+func TestSendMessage_Encrypted(t *testing.T) {
+	ctx := context.Background()
+	cts := []domain.DeviceCiphertext{
+		{UserID: "user-1", DeviceID: "phone", Ciphertext: []byte{1, 2, 3}},
+		{UserID: "user-1", DeviceID: "laptop", Ciphertext: []byte{4, 5, 6}},
+	}
+	encrypted := func(key string, cts []domain.DeviceCiphertext) SendMessageCommand {
+		return SendMessageCommand{
+			ConversationID: "conv-1",
+			UserID:         "user-1",
+			ClientMsgID:    key,
+			Type:           domain.TypeEncrypted,
+			SenderDeviceID: "phone",
+			Ciphertexts:    cts,
+		}
+	}
+
+	t.Run("Ciphertexts are stored and never screened", func(t *testing.T) {
+		repo := newModerationRepo()
+		svc := newModerationService(t, repo, moderationConfig)
+
+		msg, err := svc.SendMessage(ctx, encrypted("k1", cts))
+		if err != nil {
+			t.Fatal(err)
+		}
+		assert.Empty(t, msg.Content)
+		assert.Equal(t, "phone", msg.SenderDeviceID)
+		assert.Equal(t, cts, msg.Ciphertexts)
+		assert.Empty(t, repo.audit)
+		assert.Equal(t, cts, repo.ciphertexts[msg.ID])
+	})
+
+	t.Run("Invalid sends are refused", func(t *testing.T) {
+		for name, cmd := range map[string]SendMessageCommand{
+			"no ciphertexts": encrypted("k1", nil),
+			"no sender device": func() SendMessageCommand {
+				cmd := encrypted("k1", cts)
+				cmd.SenderDeviceID = ""
+				return cmd
+			}(),
+			"plaintext content": func() SendMessageCommand {
+				cmd := encrypted("k1", cts)
+				cmd.Content = "hello"
+				return cmd
+			}(),
+			"recipient outside the conversation": encrypted("k1", []domain.DeviceCiphertext{
+				{UserID: "user-2", DeviceID: "phone", Ciphertext: []byte{1}},
+			}),
+			"duplicate device": encrypted("k1", []domain.DeviceCiphertext{cts[0], cts[0]}),
+			"empty ciphertext": encrypted("k1", []domain.DeviceCiphertext{
+				{UserID: "user-1", DeviceID: "phone"},
+			}),
+			"ciphertexts on a text message": {
+				ConversationID: "conv-1",
+				UserID:         "user-1",
+				ClientMsgID:    "k1",
+				Content:        "hello",
+				Ciphertexts:    cts,
+			},
+		} {
+			repo := newModerationRepo()
+			svc := newModerationService(t, repo, moderationConfig)
+
+			_, err := svc.SendMessage(ctx, cmd)
+			assert.ErrorIs(t, err, domain.ErrInvalidMessage, name)
+			assert.Empty(t, repo.messages, name)
+		}
+	})
+
+	t.Run("Encrypted messages cannot be edited or scheduled", func(t *testing.T) {
+		repo := new(MockEncryptedRepo)
+		svc := &Service{repo: repo, tx: new(MockTransactor), convSvc: &slowConvClient{}, opts: Options{EditWindow: 15 * time.Minute}}
+
+		repo.On("GetMessageForUpdate", ctx, mock.Anything, "msg-1").Return(&domain.Message{
+			ID:             "msg-1",
+			ConversationID: "conv-1",
+			SenderID:       "user-1",
+			Type:           domain.TypeEncrypted,
+			SentAt:         time.Now().UTC(),
+		}, nil).Once()
+
+		_, err := svc.EditMessage(ctx, EditMessageCommand{
+			ConversationID: "conv-1",
+			MessageID:      "msg-1",
+			RequesterID:    "user-1",
+			Content:        "hello",
+		})
+		assert.ErrorIs(t, err, domain.ErrMessageEncrypted)
+
+		_, err = svc.ScheduleMessage(ctx, encrypted("k1", cts), time.Now().Add(time.Hour))
+		assert.ErrorIs(t, err, domain.ErrMessageEncrypted)
+		repo.AssertExpectations(t)
+	})
+}
+
+func TestAttachCiphertexts(t *testing.T) {
+	ctx := context.Background()
+	msgs := func() []*domain.Message {
+		return []*domain.Message{
+			{ID: "msg-1", Type: domain.TypeText, Content: "hello"},
+			{ID: "msg-2", Type: domain.TypeEncrypted},
+		}
+	}
+
+	t.Run("Only the device's ciphertext is loaded", func(t *testing.T) {
+		repo := new(MockEncryptedRepo)
+		svc := &Service{repo: repo}
+		ct := domain.DeviceCiphertext{UserID: "user-1", DeviceID: "phone", Ciphertext: []byte{1}}
+
+		repo.On("ListMessageCiphertexts", ctx, mock.Anything, []string{"msg-2"}, "user-1", "phone").
+			Return(map[string][]domain.DeviceCiphertext{"msg-2": {ct}}, nil).Once()
+
+		got := msgs()
+		assert.NoError(t, svc.attachCiphertexts(ctx, nil, got, "user-1", "phone"))
+		assert.Empty(t, got[0].Ciphertexts)
+		assert.Equal(t, []domain.DeviceCiphertext{ct}, got[1].Ciphertexts)
+		repo.AssertExpectations(t)
+	})
+
+	t.Run("No device loads nothing", func(t *testing.T) {
+		repo := new(MockEncryptedRepo)
+		svc := &Service{repo: repo}
+
+		got := msgs()
+		assert.NoError(t, svc.attachCiphertexts(ctx, nil, got, "user-1", ""))
+		assert.Empty(t, got[1].Ciphertexts)
+		repo.AssertNotCalled(t, "ListMessageCiphertexts")
+	})
+}
@@ -0,0 +1,159 @@
+package application
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"time"
+
+	messagev1 "github.com/SARVESHVARADKAR123/RealChat/contracts/gen/go/message/v1"
+	sharedv1 "github.com/SARVESHVARADKAR123/RealChat/contracts/gen/go/shared/v1"
+	"github.com/SARVESHVARADKAR123/RealChat/services/message/internal/domain"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+// emitEvent wraps event in an envelope and stores it in the outbox within tx.
+func (s *Service) emitEvent(
+	ctx context.Context,
+	tx *sql.Tx,
+	conversationID string,
+	eventType sharedv1.EventType,
+	outboxType string,
+	event proto.Message,
+) error {
+	eventPayload, err := proto.Marshal(event)
+	if err != nil {
+		return fmt.Errorf("failed to marshal event payload: %w", err)
+	}
+
+	env := &sharedv1.EventEnvelope{
+		EventType:     eventType,
+		SchemaVersion: 1,
+		OccurredAt:    timestamppb.Now(),
+		Payload:       eventPayload,
+	}
+	payload, err := proto.Marshal(env)
+	if err != nil {
+		return fmt.Errorf("failed to marshal event envelope: %w", err)
+	}
+
+	if err := s.repo.InsertOutbox(ctx, tx, "message", conversationID, outboxType, payload); err != nil {
+		return fmt.Errorf("failed to save outbox event: %w", err)
+	}
+	return nil
+}
+
+// ToProtoMessage converts m for events and RPC responses alike.
+func ToProtoMessage(m *domain.Message) *messagev1.Message {
+	pm := &messagev1.Message{
+		MessageId:      m.ID,
+		ConversationId: m.ConversationID,
+		SenderUserId:   m.SenderID,
+		Sequence:       m.Sequence,
+		MessageType:    m.Type,
+		Content:        m.Content,
+		MetadataJson:   m.Metadata,
+		SentAt:         timestamppb.New(m.SentAt),
+	}
+	if m.DeletedAt != nil {
+		pm.DeletedAt = timestamppb.New(*m.DeletedAt)
+	}
+	if m.EditedAt != nil {
+		pm.EditedAt = timestamppb.New(*m.EditedAt)
+	}
+	if m.ExpiresAt != nil {
+		pm.ExpiresAt = timestamppb.New(*m.ExpiresAt)
+	}
+	if f := m.ForwardedFrom; f != nil {
+		pm.ForwardedFrom = &messagev1.ForwardedFrom{
+			MessageId:      f.MessageID,
+			ConversationId: f.ConversationID,
+			SenderUserId:   f.SenderID,
+			SentAt:         timestamppb.New(f.SentAt),
+		}
+	}
+	pm.ReplyToMessageId = m.ReplyToID
+	pm.ThreadRootId = m.ThreadRootID
+	pm.ReplyCount = m.ReplyCount
+	if m.LastReplyAt != nil {
+		pm.LastReplyAt = timestamppb.New(*m.LastReplyAt)
+	}
+	pm.Attachments = ToProtoAttachments(m.Attachments)
+	pm.Reactions = ToProtoReactions(m.Reactions)
+	if m.Poll != nil {
+		pm.Poll = ToProtoPoll(m.Poll)
+	}
+	pm.SenderDeviceId = m.SenderDeviceID
+	for _, ct := range m.Ciphertexts {
+		pm.Ciphertexts = append(pm.Ciphertexts, &messagev1.DeviceCiphertext{
+			UserId:     ct.UserID,
+			DeviceId:   ct.DeviceID,
+			Ciphertext: ct.Ciphertext,
+		})
+	}
+	return pm
+}
+
+// ToProtoAttachments converts atts, with their download links if signed.
+func ToProtoAttachments(atts []domain.Attachment) []*messagev1.MessageAttachment {
+	var out []*messagev1.MessageAttachment
+	for _, a := range atts {
+		pa := &messagev1.MessageAttachment{
+			AttachmentId: a.ID,
+			FileName:     a.FileName,
+			MimeType:     a.MimeType,
+			SizeBytes:    a.SizeBytes,
+			DownloadUrl:  a.DownloadURL,
+		}
+		if a.DownloadURLExpiresAt != nil {
+			pa.DownloadUrlExpiresAt = timestamppb.New(*a.DownloadURLExpiresAt)
+		}
+		out = append(out, pa)
+	}
+	return out
+}
+
+// ToProtoReactions converts a message's reaction summaries.
+func ToProtoReactions(reactions []domain.ReactionSummary) []*messagev1.ReactionSummary {
+	var out []*messagev1.ReactionSummary
+	for _, rs := range reactions {
+		out = append(out, &messagev1.ReactionSummary{
+			Emoji:       rs.Emoji,
+			Count:       rs.Count,
+			ReactedByMe: rs.ReactedByMe,
+		})
+	}
+	return out
+}
+
+// ToProtoPoll converts p with its current Tally.
+func ToProtoPoll(p *domain.Poll) *messagev1.PollState {
+	ps := &messagev1.PollState{
+		MultipleChoice: p.MultipleChoice,
+		Anonymous:      p.Anonymous,
+		ClosedByUserId: p.ClosedBy,
+		Closed:         p.Closed(time.Now().UTC()),
+		TotalVoters:    p.Tally.TotalVoters,
+	}
+	if p.ClosesAt != nil {
+		ps.ClosesAt = timestamppb.New(*p.ClosesAt)
+	}
+	if p.ClosedAt != nil {
+		ps.ClosedAt = timestamppb.New(*p.ClosedAt)
+	}
+	for i, text := range p.Options {
+		opt := &messagev1.PollOption{Text: text}
+		if i < len(p.Tally.Votes) {
+			opt.Votes = p.Tally.Votes[i]
+		}
+		if i < len(p.Tally.Voters) {
+			opt.VoterUserIds = p.Tally.Voters[i]
+		}
+		ps.Options = append(ps.Options, opt)
+	}
+	for _, o := range p.Tally.MyVotes {
+		ps.MyVotes = append(ps.MyVotes, int32(o))
+	}
+	return ps
+}
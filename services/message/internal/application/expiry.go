@@ -0,0 +1,60 @@
+package application
+
+import (
+	"context"
+	"database/sql"
+	"slices"
+	"time"
+
+	messagev1 "github.com/SARVESHVARADKAR123/RealChat/contracts/gen/go/message/v1"
+	sharedv1 "github.com/SARVESHVARADKAR123/RealChat/contracts/gen/go/shared/v1"
+)
+
+// ReapExpiredMessages hard-deletes up to limit messages whose TTL passed
+// before now and emits a MESSAGE_DELETED event for each, so clients drop
+// them like any other deletion. It returns how many were deleted.
+func (s *Service) ReapExpiredMessages(ctx context.Context, now time.Time, limit int) (int, error) {
+	var deleted int
+
+	err := s.tx.WithTx(ctx, func(ctx context.Context, tx *sql.Tx) error {
+		msgs, err := s.repo.DeleteExpiredMessages(ctx, tx, now, limit)
+		if err != nil {
+			return err
+		}
+
+		var roots []string
+		for _, m := range msgs {
+			if m.ThreadRootID != "" && !slices.Contains(roots, m.ThreadRootID) {
+				roots = append(roots, m.ThreadRootID)
+			}
+		}
+		if len(roots) > 0 {
+			if err := s.repo.RefreshThreadSummaries(ctx, tx, roots); err != nil {
+				return err
+			}
+		}
+
+		for _, m := range msgs {
+			if err := s.emitEvent(
+				ctx, tx,
+				m.ConversationID,
+				sharedv1.EventType_EVENT_TYPE_MESSAGE_DELETED,
+				"MESSAGE_DELETED",
+				&messagev1.MessageDeletedEvent{
+					ConversationId: m.ConversationID,
+					MessageId:      m.ID,
+				},
+			); err != nil {
+				return err
+			}
+		}
+
+		deleted = len(msgs)
+		return nil
+	})
+
+	if err != nil {
+		return 0, err
+	}
+	return deleted, nil
+}
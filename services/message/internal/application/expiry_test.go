@@ -0,0 +1,71 @@
+package application
+
+import (
+	"context"
+	"database/sql"
+	"testing"
+	"time"
+
+	"github.com/SARVESHVARADKAR123/RealChat/services/message/internal/domain"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+// MockExpiryRepo adds the reaper's deletes.
+type MockExpiryRepo struct {
+	MockRepo
+}
+
+func (m *MockExpiryRepo) DeleteExpiredMessages(ctx context.Context, tx *sql.Tx, now time.Time, limit int) ([]*domain.Message, error) {
+	args := m.Called(ctx, tx, now, limit)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]*domain.Message), args.Error(1)
+}
+
+func TestReapExpiredMessages(t *testing.T) {
+	ctx := context.Background()
+	now := time.Now().UTC()
+
+	t.Run("Emits a deletion per expired message", func(t *testing.T) {
+		repo := new(MockExpiryRepo)
+		svc := &Service{repo: repo, tx: new(MockTransactor)}
+
+		expired := []*domain.Message{
+			{ID: "msg-1", ConversationID: "conv-1"},
+			{ID: "msg-2", ConversationID: "conv-2"},
+		}
+		repo.On("DeleteExpiredMessages", ctx, mock.Anything, now, 10).Return(expired, nil).Once()
+		repo.On("InsertOutbox", ctx, mock.Anything, "message", "conv-1", "MESSAGE_DELETED", mock.Anything).Return(nil).Once()
+		repo.On("InsertOutbox", ctx, mock.Anything, "message", "conv-2", "MESSAGE_DELETED", mock.Anything).Return(nil).Once()
+
+		n, err := svc.ReapExpiredMessages(ctx, now, 10)
+		assert.NoError(t, err)
+		assert.Equal(t, 2, n)
+		repo.AssertExpectations(t)
+	})
+
+	t.Run("Nothing expired", func(t *testing.T) {
+		repo := new(MockExpiryRepo)
+		svc := &Service{repo: repo, tx: new(MockTransactor)}
+
+		repo.On("DeleteExpiredMessages", ctx, mock.Anything, now, 10).Return([]*domain.Message{}, nil).Once()
+
+		n, err := svc.ReapExpiredMessages(ctx, now, 10)
+		assert.NoError(t, err)
+		assert.Zero(t, n)
+		repo.AssertExpectations(t)
+	})
+}
+
+func TestExpireAfter(t *testing.T) {
+	sentAt := time.Date(2024, 1, 1, 12, 0, 0, 0, time.UTC)
+
+	msg := &domain.Message{SentAt: sentAt}
+	msg.ExpireAfter(0)
+	assert.Nil(t, msg.ExpiresAt)
+
+	msg.ExpireAfter(time.Hour)
+	assert.Equal(t, sentAt.Add(time.Hour), *msg.ExpiresAt)
+}
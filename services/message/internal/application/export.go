@@ -0,0 +1,400 @@
+package application
+
+import (
+	"archive/zip"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"math"
+	"sort"
+	"time"
+
+	profilev1 "github.com/SARVESHVARADKAR123/RealChat/contracts/gen/go/profile/v1"
+	"github.com/SARVESHVARADKAR123/RealChat/services/message/internal/domain"
+	"github.com/SARVESHVARADKAR123/RealChat/services/message/internal/export"
+	"github.com/google/uuid"
+	"go.uber.org/zap"
+)
+
+const (
+	// exportLease is how long a running export may go without finishing
+	// before another worker takes it over.
+	exportLease = 30 * time.Minute
+
+	exportPageSize    = 500
+	profileBatchLimit = 100
+)
+
+// ExportCommand asks for an export of a conversation on behalf of UserID.
+type ExportCommand struct {
+	ConversationID string
+	UserID         string
+}
+
+// RequestExport queues an export of the conversation for a participant. An
+// export the user already has queued or running for it is returned instead
+// of a new one.
+func (s *Service) RequestExport(ctx context.Context, cmd ExportCommand) (*domain.Export, error) {
+	if cmd.ConversationID == "" || cmd.UserID == "" {
+		return nil, domain.ErrInvalidInput
+	}
+	if _, err := s.requireParticipant(ctx, cmd.ConversationID, cmd.UserID); err != nil {
+		return nil, err
+	}
+
+	open, err := s.repo.FindOpenExport(ctx, nil, cmd.ConversationID, cmd.UserID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to look up exports: %w", err)
+	}
+	if open != nil {
+		return open, nil
+	}
+
+	e, err := domain.NewExport(uuid.NewString(), cmd.ConversationID, cmd.UserID, time.Now().UTC())
+	if err != nil {
+		return nil, err
+	}
+	if err := s.repo.InsertExport(ctx, nil, e); err != nil {
+		return nil, fmt.Errorf("failed to queue export: %w", err)
+	}
+	return e, nil
+}
+
+// GetExport returns one of userID's exports. Other users' exports are
+// reported as not found.
+func (s *Service) GetExport(ctx context.Context, exportID, userID string) (*domain.Export, error) {
+	e, err := s.repo.GetExport(ctx, nil, exportID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load export: %w", err)
+	}
+	if e == nil || e.UserID != userID {
+		return nil, domain.ErrExportNotFound
+	}
+	return e, nil
+}
+
+// OpenExport returns one of userID's finished exports along with its zip
+// archive, which the caller must close.
+func (s *Service) OpenExport(ctx context.Context, exportID, userID string) (*domain.Export, io.ReadCloser, error) {
+	e, err := s.GetExport(ctx, exportID, userID)
+	if err != nil {
+		return nil, nil, err
+	}
+	if e.Status != domain.ExportDone {
+		return nil, nil, domain.ErrExportNotReady
+	}
+
+	rc, err := s.archive.Open(ctx, e.ObjectKey)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to open export %s: %w", e.ID, err)
+	}
+	return e, rc, nil
+}
+
+// ClaimExports hands up to limit queued exports to the calling worker.
+func (s *Service) ClaimExports(ctx context.Context, now time.Time, limit int) ([]*domain.Export, error) {
+	return s.repo.ClaimExports(ctx, now, now.Add(-exportLease), limit)
+}
+
+// BuildExport writes the archive of a claimed export to the archive store
+// and records the outcome on e. Failures to build mark the export failed;
+// the returned error is for failing to record the outcome.
+//
+// The archive holds the conversation as the requester can see it: live
+// messages sent or written since they joined, with attachment references and the
+// profiles of everyone involved. The caller's context should carry the
+// requester's identity for the calls made on their behalf.
+func (s *Service) BuildExport(ctx context.Context, e *domain.Export) error {
+	count, size, err := s.buildExport(ctx, e)
+	now := time.Now().UTC()
+	e.CompletedAt = &now
+	if err != nil {
+		s.log.Warn("export failed", zap.String("export_id", e.ID), zap.Error(err))
+		e.Status = domain.ExportFailed
+		e.FailureReason = err.Error()
+	} else {
+		e.Status = domain.ExportDone
+		e.ObjectKey = domain.ExportObjectKey(e)
+		e.MessageCount = count
+		e.SizeBytes = size
+	}
+
+	finished, err := s.repo.FinishExport(ctx, nil, e)
+	if err != nil {
+		return fmt.Errorf("failed to record export %s: %w", e.ID, err)
+	}
+	if !finished {
+		s.log.Warn("export was taken over by another worker", zap.String("export_id", e.ID))
+	}
+	return nil
+}
+
+func (s *Service) buildExport(ctx context.Context, e *domain.Export) (count, size int64, err error) {
+	conv, err := s.requireParticipant(ctx, e.ConversationID, e.UserID)
+	if err != nil {
+		return 0, 0, err
+	}
+
+	// The requester sees what was sent or written since they joined, when
+	// that is known
+	var member domain.Member
+	info, err := s.repo.GetConversationInfo(ctx, nil, e.ConversationID)
+	if err != nil {
+		return 0, 0, fmt.Errorf("failed to load conversation projection: %w", err)
+	}
+	if info != nil {
+		member, _ = info.Member(e.UserID)
+	}
+
+	// Every pass stops at the newest message as of now, so all formats
+	// cover the same history
+	latest, err := s.repo.FetchMessagesBefore(ctx, e.ConversationID, math.MaxInt64, 1)
+	if err != nil {
+		return 0, 0, err
+	}
+	var upTo int64
+	if len(latest) > 0 {
+		upTo = latest[0].Sequence
+	}
+
+	people := &exportPeople{s: s, byID: map[string]export.Participant{}}
+	if err := people.resolve(ctx, append(conv.GetParticipantUserIds(), e.UserID)); err != nil {
+		return 0, 0, err
+	}
+
+	h := export.Header{
+		ConversationID: e.ConversationID,
+		ExportedBy:     people.name(e.UserID),
+		ExportedAt:     time.Now().UTC(),
+		Since:          member.JoinedAt,
+	}
+
+	pr, pw := io.Pipe()
+	written := make(chan error, 1)
+	go func() {
+		n, err := s.writeExport(ctx, pw, h, people, member, upTo)
+		count = n
+		pw.CloseWithError(err)
+		written <- err
+	}()
+
+	counter := &countingReader{r: pr}
+	putErr := s.archive.Put(ctx, domain.ExportObjectKey(e), counter)
+	// Unblocks the writer if Put gave up early
+	pr.CloseWithError(errors.New("export upload stopped"))
+	writeErr := <-written
+
+	if writeErr != nil {
+		return 0, 0, writeErr
+	}
+	if putErr != nil {
+		return 0, 0, fmt.Errorf("failed to store export: %w", putErr)
+	}
+	return count, counter.n, nil
+}
+
+// writeExport writes the zip of every export format to w. Each format is a
+// separate pass over the history, so nothing is held in memory but a page.
+func (s *Service) writeExport(ctx context.Context, w io.Writer, h export.Header, people *exportPeople, member domain.Member, upTo int64) (int64, error) {
+	zw := zip.NewWriter(w)
+
+	var count int64
+	for _, f := range export.Formats {
+		fw, err := zw.Create(f.FileName)
+		if err != nil {
+			return 0, err
+		}
+		out, err := f.New(fw, h)
+		if err != nil {
+			return 0, fmt.Errorf("failed to render %s: %w", f.FileName, err)
+		}
+
+		count = 0
+		err = s.eachExportPage(ctx, h.ConversationID, member, upTo, func(page []*domain.Message) error {
+			senders := make([]string, len(page))
+			for i, m := range page {
+				senders[i] = m.SenderID
+			}
+			if err := people.resolve(ctx, senders); err != nil {
+				return err
+			}
+
+			msgs := make([]export.Message, len(page))
+			for i, m := range page {
+				msgs[i] = exportMessage(m, people.name(m.SenderID))
+			}
+			count += int64(len(msgs))
+			return out.Messages(msgs)
+		})
+		if err != nil {
+			return 0, err
+		}
+
+		if err := out.Finish(people.list()); err != nil {
+			return 0, fmt.Errorf("failed to render %s: %w", f.FileName, err)
+		}
+	}
+
+	return count, zw.Close()
+}
+
+// eachExportPage calls fn with the live messages of the conversation up to
+// sequence upTo that member sees, a page at a time with
+// their attachments loaded. Encrypted messages are left out, as the service
+// has nothing readable to export.
+func (s *Service) eachExportPage(ctx context.Context, conversationID string, member domain.Member, upTo int64, fn func([]*domain.Message) error) error {
+	var after int64
+	for after < upTo {
+		page, err := s.repo.FetchMessages(ctx, conversationID, after, exportPageSize)
+		if err != nil {
+			return err
+		}
+		if len(page) == 0 {
+			return nil
+		}
+		after = page[len(page)-1].Sequence
+
+		var kept []*domain.Message
+		for _, m := range page {
+			if m.Sequence > upTo || m.DeletedAt != nil || m.Type == domain.TypeEncrypted {
+				continue
+			}
+			if !member.Sees(m) {
+				continue
+			}
+			kept = append(kept, m)
+		}
+		if len(kept) == 0 {
+			continue
+		}
+
+		ids := make([]string, len(kept))
+		for i, m := range kept {
+			ids[i] = m.ID
+		}
+		atts, err := s.repo.ListMessageAttachments(ctx, nil, ids)
+		if err != nil {
+			return err
+		}
+		for _, m := range kept {
+			m.Attachments = atts[m.ID]
+		}
+
+		if err := fn(kept); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func exportMessage(m *domain.Message, senderName string) export.Message {
+	out := export.Message{
+		ID:           m.ID,
+		Sequence:     m.Sequence,
+		SenderID:     m.SenderID,
+		SenderName:   senderName,
+		Type:         m.Type,
+		Content:      m.Content,
+		SentAt:       m.SentAt.UTC(),
+		EditedAt:     m.EditedAt,
+		ReplyToID:    m.ReplyToID,
+		ThreadRootID: m.ThreadRootID,
+	}
+	if m.Metadata != "" {
+		out.Metadata = json.RawMessage(m.Metadata)
+	}
+	if f := m.ForwardedFrom; f != nil {
+		out.ForwardedFrom = &export.ForwardedFrom{
+			MessageID:      f.MessageID,
+			ConversationID: f.ConversationID,
+			SenderID:       f.SenderID,
+			SentAt:         f.SentAt.UTC(),
+		}
+	}
+	for _, a := range m.Attachments {
+		out.Attachments = append(out.Attachments, export.Attachment{
+			ID:        a.ID,
+			FileName:  a.FileName,
+			MimeType:  a.MimeType,
+			SizeBytes: a.SizeBytes,
+		})
+	}
+	return out
+}
+
+// exportPeople caches the profiles of everyone appearing in an export.
+type exportPeople struct {
+	s    *Service
+	byID map[string]export.Participant
+}
+
+// resolve looks up the profiles of the given users not seen yet. Users
+// without a profile, and the system sender, are kept by ID alone.
+func (p *exportPeople) resolve(ctx context.Context, userIDs []string) error {
+	var missing []string
+	for _, id := range userIDs {
+		if _, ok := p.byID[id]; ok {
+			continue
+		}
+		p.byID[id] = export.Participant{UserID: id}
+		if id != domain.SystemSenderID {
+			missing = append(missing, id)
+		}
+	}
+
+	for len(missing) > 0 {
+		batch := missing[:min(len(missing), profileBatchLimit)]
+		missing = missing[len(batch):]
+
+		resp, err := p.s.profiles.BatchGetProfiles(ctx, &profilev1.BatchGetProfilesRequest{UserIds: batch})
+		if err != nil {
+			return fmt.Errorf("failed to load profiles: %w", err)
+		}
+		for _, prof := range resp.GetProfiles() {
+			if _, ok := p.byID[prof.GetUserId()]; !ok {
+				continue
+			}
+			p.byID[prof.GetUserId()] = export.Participant{
+				UserID:      prof.GetUserId(),
+				DisplayName: prof.GetDisplayName(),
+				AvatarURL:   prof.GetAvatarUrl(),
+			}
+		}
+	}
+	return nil
+}
+
+func (p *exportPeople) name(userID string) string {
+	return p.byID[userID].Name()
+}
+
+// list returns everyone resolved so far, ordered by name.
+func (p *exportPeople) list() []export.Participant {
+	out := make([]export.Participant, 0, len(p.byID))
+	for _, v := range p.byID {
+		if v.UserID == domain.SystemSenderID {
+			continue
+		}
+		out = append(out, v)
+	}
+	sort.Slice(out, func(i, j int) bool {
+		if out[i].Name() != out[j].Name() {
+			return out[i].Name() < out[j].Name()
+		}
+		return out[i].UserID < out[j].UserID
+	})
+	return out
+}
+
+type countingReader struct {
+	r io.Reader
+	n int64
+}
+
+func (c *countingReader) Read(b []byte) (int, error) {
+	n, err := c.r.Read(b)
+	c.n += int64(n)
+	return n, err
+}
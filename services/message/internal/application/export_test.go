@@ -0,0 +1,292 @@
+package application
+
+import (
+	"archive/zip"
+	"bytes"
+	"context"
+	"database/sql"
+	"encoding/json"
+	"io"
+	"math"
+	"testing"
+	"time"
+
+	profilev1 "github.com/SARVESHVARADKAR123/RealChat/contracts/gen/go/profile/v1"
+	"github.com/SARVESHVARADKAR123/RealChat/services/message/internal/archive"
+	"github.com/SARVESHVARADKAR123/RealChat/services/message/internal/domain"
+	"github.com/SARVESHVARADKAR123/RealChat/services/message/internal/export"
+	"github.com/SARVESHVARADKAR123/RealChat/services/message/internal/imports"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"go.uber.org/zap"
+	"google.golang.org/grpc"
+)
+
+// MockProfileClient is a mock for the ProfileApiClient interface
+type MockProfileClient struct {
+	mock.Mock
+	profilev1.ProfileApiClient
+}
+
+func (m *MockProfileClient) BatchGetProfiles(ctx context.Context, req *profilev1.BatchGetProfilesRequest, opts ...grpc.CallOption) (*profilev1.BatchGetProfilesResponse, error) {
+	args := m.Called(ctx, req)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*profilev1.BatchGetProfilesResponse), args.Error(1)
+}
+
+// readExportFiles returns the files of the export zip stored under key.
+func readExportFiles(t *testing.T, store archive.Store, key string) map[string]string {
+	r, err := store.Open(context.Background(), key)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer r.Close()
+	data, err := io.ReadAll(r)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	zr, err := zip.NewReader(bytes.NewReader(data), int64(len(data)))
+	if err != nil {
+		t.Fatal(err)
+	}
+	files := map[string]string{}
+	for _, f := range zr.File {
+		rc, err := f.Open()
+		if err != nil {
+			t.Fatal(err)
+		}
+		b, err := io.ReadAll(rc)
+		rc.Close()
+		if err != nil {
+			t.Fatal(err)
+		}
+		files[f.Name] = string(b)
+	}
+	return files
+}
+
+// MockExportRepo adds the calls requesting and building an export makes.
+type MockExportRepo struct {
+	MockRepo
+}
+
+func (m *MockExportRepo) FetchMessages(ctx context.Context, convID string, lastSeq int64, limit int) ([]*domain.Message, error) {
+	args := m.Called(ctx, convID, lastSeq, limit)
+	return args.Get(0).([]*domain.Message), args.Error(1)
+}
+func (m *MockExportRepo) FetchMessagesBefore(ctx context.Context, convID string, beforeSeq int64, limit int) ([]*domain.Message, error) {
+	args := m.Called(ctx, convID, beforeSeq, limit)
+	return args.Get(0).([]*domain.Message), args.Error(1)
+}
+func (m *MockExportRepo) InsertExport(ctx context.Context, tx *sql.Tx, e *domain.Export) error {
+	return m.Called(ctx, tx, e).Error(0)
+}
+func (m *MockExportRepo) GetExport(ctx context.Context, tx *sql.Tx, id string) (*domain.Export, error) {
+	args := m.Called(ctx, tx, id)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*domain.Export), args.Error(1)
+}
+func (m *MockExportRepo) FindOpenExport(ctx context.Context, tx *sql.Tx, conversationID, userID string) (*domain.Export, error) {
+	args := m.Called(ctx, tx, conversationID, userID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*domain.Export), args.Error(1)
+}
+func (m *MockExportRepo) FinishExport(ctx context.Context, tx *sql.Tx, e *domain.Export) (bool, error) {
+	args := m.Called(ctx, tx, e)
+	return args.Bool(0), args.Error(1)
+}
+
+func TestRequestExport(t *testing.T) {
+	ctx := context.Background()
+	info := &domain.ConversationInfo{ID: "conv-1", Members: []domain.Member{{UserID: "user-1"}}}
+
+	t.Run("Queues an export", func(t *testing.T) {
+		repo := new(MockExportRepo)
+		svc := &Service{repo: &projectionRepo{Repository: repo, info: info}, tx: new(MockTransactor), convSvc: new(MockConvClient)}
+
+		repo.On("FindOpenExport", ctx, mock.Anything, "conv-1", "user-1").Return(nil, nil).Once()
+		repo.On("InsertExport", ctx, mock.Anything, mock.MatchedBy(func(e *domain.Export) bool {
+			return e.ConversationID == "conv-1" && e.UserID == "user-1" && e.Status == domain.ExportPending
+		})).Return(nil).Once()
+
+		e, err := svc.RequestExport(ctx, ExportCommand{ConversationID: "conv-1", UserID: "user-1"})
+		assert.NoError(t, err)
+		assert.NotEmpty(t, e.ID)
+		repo.AssertExpectations(t)
+	})
+
+	t.Run("Returns the export already queued", func(t *testing.T) {
+		repo := new(MockExportRepo)
+		svc := &Service{repo: &projectionRepo{Repository: repo, info: info}, tx: new(MockTransactor), convSvc: new(MockConvClient)}
+		open := &domain.Export{ID: "exp-1", ConversationID: "conv-1", UserID: "user-1", Status: domain.ExportRunning}
+
+		repo.On("FindOpenExport", ctx, mock.Anything, "conv-1", "user-1").Return(open, nil).Once()
+
+		e, err := svc.RequestExport(ctx, ExportCommand{ConversationID: "conv-1", UserID: "user-1"})
+		assert.NoError(t, err)
+		assert.Same(t, open, e)
+		repo.AssertExpectations(t)
+	})
+}
+
+func TestGetExport(t *testing.T) {
+	ctx := context.Background()
+	repo := new(MockExportRepo)
+	svc := &Service{repo: repo}
+	e := &domain.Export{ID: "exp-1", ConversationID: "conv-1", UserID: "user-1", Status: domain.ExportRunning}
+	repo.On("GetExport", ctx, mock.Anything, "exp-1").Return(e, nil)
+
+	_, err := svc.GetExport(ctx, "exp-1", "user-2")
+	assert.ErrorIs(t, err, domain.ErrExportNotFound)
+
+	_, _, err = svc.OpenExport(ctx, "exp-1", "user-1")
+	assert.ErrorIs(t, err, domain.ErrExportNotReady)
+}
+
+func TestBuildExport(t *testing.T) {
+	ctx := context.Background()
+	joined := time.Date(2025, 3, 1, 12, 0, 0, 0, time.UTC)
+	deleted := joined.Add(time.Hour)
+
+	info := &domain.ConversationInfo{ID: "conv-1", Members: []domain.Member{
+		{UserID: "user-1", JoinedAt: &joined, JoinedSequence: 1},
+		{UserID: "user-2"},
+	}}
+	repo := new(MockExportRepo)
+	profiles := new(MockProfileClient)
+	store := &archive.LocalFS{Root: t.TempDir()}
+	svc := &Service{repo: &projectionRepo{Repository: repo, info: info}, tx: new(MockTransactor), convSvc: new(MockConvClient), profiles: profiles, archive: store, log: zap.NewNop()}
+	msgs := []*domain.Message{
+		{ID: "m1", ConversationID: "conv-1", SenderID: "user-2", Sequence: 1, Type: "text", Content: "before you came", SentAt: joined.Add(-time.Minute)},
+		{ID: "m2", ConversationID: "conv-1", SenderID: "user-2", Sequence: 2, Type: "text", Content: "oops", SentAt: joined.Add(time.Minute), DeletedAt: &deleted},
+		{ID: "m3", ConversationID: "conv-1", SenderID: "user-2", Sequence: 3, Type: "text", Content: "<b>hi</b>", SentAt: joined.Add(2 * time.Minute)},
+		{ID: "m4", ConversationID: "conv-1", SenderID: "user-3", Sequence: 4, Type: "text", Content: "see file", SentAt: joined.Add(3 * time.Minute)},
+	}
+
+	repo.On("FetchMessagesBefore", ctx, "conv-1", int64(math.MaxInt64), 1).Return(msgs[3:], nil).Once()
+	repo.On("FetchMessages", ctx, "conv-1", int64(0), exportPageSize).Return(msgs, nil)
+	repo.On("ListMessageAttachments", ctx, mock.Anything, []string{"m3", "m4"}).Return(map[string][]domain.Attachment{
+		"m4": {{ID: "att-1", FileName: "report.pdf", MimeType: "application/pdf", SizeBytes: 2048}},
+	}, nil)
+	profiles.On("BatchGetProfiles", ctx, &profilev1.BatchGetProfilesRequest{UserIds: []string{"user-1", "user-2"}}).Return(&profilev1.BatchGetProfilesResponse{
+		Profiles: []*profilev1.Profile{{UserId: "user-1", DisplayName: "Alice"}, {UserId: "user-2", DisplayName: "Bob"}},
+	}, nil).Once()
+	// A former member, resolved when first seen
+	profiles.On("BatchGetProfiles", ctx, &profilev1.BatchGetProfilesRequest{UserIds: []string{"user-3"}}).Return(&profilev1.BatchGetProfilesResponse{}, nil).Once()
+
+	var finished *domain.Export
+	repo.On("FinishExport", ctx, mock.Anything, mock.Anything).Run(func(args mock.Arguments) {
+		finished = args.Get(2).(*domain.Export)
+	}).Return(true, nil).Once()
+
+	started := joined.Add(24 * time.Hour)
+	e := &domain.Export{ID: "exp-1", ConversationID: "conv-1", UserID: "user-1", Status: domain.ExportRunning, StartedAt: &started}
+	assert.NoError(t, svc.BuildExport(ctx, e))
+
+	if assert.NotNil(t, finished) {
+		assert.Equal(t, domain.ExportDone, finished.Status)
+		assert.Equal(t, int64(2), finished.MessageCount)
+		assert.Equal(t, "exports/conv-1/exp-1.zip", finished.ObjectKey)
+		assert.Positive(t, finished.SizeBytes)
+	}
+
+	files := readExportFiles(t, store, "exports/conv-1/exp-1.zip")
+	assert.Len(t, files, len(export.Formats))
+
+	var doc struct {
+		export.Header
+		Messages     []export.Message     `json:"messages"`
+		Participants []export.Participant `json:"participants"`
+	}
+	if err := json.Unmarshal([]byte(files["conversation.json"]), &doc); err != nil {
+		t.Fatal(err)
+	}
+	assert.Equal(t, "Alice", doc.ExportedBy)
+	assert.Equal(t, &joined, doc.Since)
+	if assert.Len(t, doc.Messages, 2) {
+		assert.Equal(t, "m3", doc.Messages[0].ID)
+		assert.Equal(t, "Bob", doc.Messages[0].SenderName)
+		assert.Equal(t, "user-3", doc.Messages[1].SenderName)
+		assert.Equal(t, []export.Attachment{{ID: "att-1", FileName: "report.pdf", MimeType: "application/pdf", SizeBytes: 2048}}, doc.Messages[1].Attachments)
+	}
+	assert.Equal(t, []export.Participant{
+		{UserID: "user-1", DisplayName: "Alice"},
+		{UserID: "user-2", DisplayName: "Bob"},
+		{UserID: "user-3"},
+	}, doc.Participants)
+
+	assert.Contains(t, files["conversation.html"], "&lt;b&gt;hi&lt;/b&gt;")
+	assert.NotContains(t, files["conversation.html"], "before you came")
+	assert.Contains(t, files["conversation.txt"], "[2025-03-01 12:02:00 UTC] Bob: <b>hi</b>\n")
+	assert.Contains(t, files["conversation.txt"], "    [attachment] report.pdf (application/pdf, 2.0 KB)\n")
+	assert.NotContains(t, files["conversation.txt"], "oops")
+
+	repo.AssertExpectations(t)
+	profiles.AssertExpectations(t)
+}
+
+// exportedRepo records the exports finished over an import's history.
+type exportedRepo struct {
+	*importRepo
+	finished []*domain.Export
+}
+
+func (r *exportedRepo) FinishExport(ctx context.Context, tx *sql.Tx, e *domain.Export) (bool, error) {
+	r.finished = append(r.finished, e)
+	return true, nil
+}
+
+func TestBuildExport_ImportedHistory(t *testing.T) {
+	ctx := context.Background()
+	chat := []byte("1/1/22, 10:00 - Alice: hi\n1/1/22, 10:01 - Bob: hello\n1/1/22, 10:02 - Alice: bye\n")
+
+	repo := &exportedRepo{importRepo: newImportRepo()}
+	store := &archive.LocalFS{Root: t.TempDir()}
+	svc := &Service{repo: repo, tx: repo, convSvc: &importConvClient{}, profiles: knownProfiles("user-1", "user-2", "user-3"), archive: store, log: zap.NewNop()}
+
+	// Bob joined before the history was written, so long after it was sent
+	joined := time.Now().UTC().Add(-time.Hour)
+	repo.info = &domain.ConversationInfo{ID: "conv-1", Group: true, Members: []domain.Member{
+		{UserID: "user-1"},
+		{UserID: "user-2", JoinedAt: &joined},
+	}}
+	imp, err := svc.ImportFile(ctx, ImportCommand{
+		Source:    imports.SourceWhatsApp,
+		Name:      "chat",
+		CreatedBy: "user-1",
+		Mapping:   map[string]string{"Alice": "user-1", "Bob": "user-2"},
+	}, chat)
+	if err != nil {
+		t.Fatal(err)
+	}
+	repo.info.ID = imp.ConversationID
+
+	// Carol joined after it, and sees none of it
+	later := time.Now().UTC()
+	repo.info.Members = append(repo.info.Members, domain.Member{UserID: "user-3", JoinedAt: &later, JoinedSequence: 3})
+
+	started := time.Now().UTC()
+	for _, userID := range []string{"user-2", "user-3"} {
+		e := &domain.Export{ID: "exp-" + userID, ConversationID: imp.ConversationID, UserID: userID, Status: domain.ExportRunning, StartedAt: &started}
+		if err := svc.BuildExport(ctx, e); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	finished := repo.finished
+	if assert.Len(t, finished, 2) {
+		assert.Equal(t, domain.ExportDone, finished[0].Status)
+		assert.Equal(t, int64(3), finished[0].MessageCount)
+		assert.Equal(t, domain.ExportDone, finished[1].Status)
+		assert.Zero(t, finished[1].MessageCount)
+	}
+	files := readExportFiles(t, store, domain.ExportObjectKey(finished[0]))
+	assert.Contains(t, files["conversation.txt"], "hello")
+}
@@ -0,0 +1,275 @@
+package application
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"fmt"
+	"sort"
+	"time"
+
+	messagev1 "github.com/SARVESHVARADKAR123/RealChat/contracts/gen/go/message/v1"
+	sharedv1 "github.com/SARVESHVARADKAR123/RealChat/contracts/gen/go/shared/v1"
+	"github.com/SARVESHVARADKAR123/RealChat/services/message/internal/domain"
+	"github.com/SARVESHVARADKAR123/RealChat/services/message/internal/moderation"
+	"github.com/google/uuid"
+)
+
+type ForwardMessagesCommand struct {
+	SourceConversationID string
+	TargetConversationID string
+	MessageIDs           []string
+	UserID               string
+	ClientMsgID          string
+}
+
+// ForwardMessages copies messages from the source conversation into the
+// target one, in source sequence order. The caller must be a participant of
+// both. All copies are written in one transaction, and a retry with the same
+// ClientMsgID returns the copies made the first time.
+func (s *Service) ForwardMessages(
+	ctx context.Context,
+	cmd ForwardMessagesCommand,
+) ([]*domain.Message, error) {
+
+	if err := domain.ValidateForwardIDs(cmd.MessageIDs); err != nil {
+		return nil, err
+	}
+	if cmd.ClientMsgID == "" {
+		return nil, domain.ErrInvalidInput
+	}
+
+	// A retry gets the original copies, even if the checks below would turn
+	// it down now
+	payload, err := s.repo.GetIdempotencyResponse(ctx, cmd.ClientMsgID, cmd.UserID, cmd.TargetConversationID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch idempotency response: %w", err)
+	}
+	if payload != nil {
+		var copies []*domain.Message
+		if err := json.Unmarshal(payload, &copies); err != nil {
+			return nil, fmt.Errorf("failed to unmarshal cached messages: %w", err)
+		}
+		s.signAttachmentURLs(ctx, copies)
+		return copies, nil
+	}
+
+	if _, err := s.requireParticipant(ctx, cmd.SourceConversationID, cmd.UserID); err != nil {
+		return nil, err
+	}
+	target, err := s.requireParticipant(ctx, cmd.TargetConversationID, cmd.UserID)
+	if err != nil {
+		return nil, err
+	}
+
+	sources, err := s.loadForwardSources(ctx, nil, cmd)
+	if err != nil {
+		return nil, err
+	}
+	atts, err := s.repo.ListMessageAttachments(ctx, nil, cmd.MessageIDs)
+	if err != nil {
+		return nil, err
+	}
+	// Screened before the transaction, as hooks may be slow and the
+	// sequence block stays locked until commit
+	screened, err := s.screenForwards(ctx, cmd, sources, atts)
+	if err != nil {
+		return nil, err
+	}
+
+	var result []*domain.Message
+
+	err = s.tx.WithTx(ctx, func(ctx context.Context, tx *sql.Tx) error {
+
+		owned, err := s.repo.TryInsertIdempotency(
+			ctx, tx,
+			cmd.ClientMsgID,
+			cmd.UserID,
+			cmd.TargetConversationID,
+			time.Now().Add(s.idempotencyTTL()),
+		)
+		if err != nil {
+			return fmt.Errorf("failed to check idempotency: %w", err)
+		}
+
+		if !owned {
+			payload, err := s.repo.GetIdempotencyForUpdate(
+				ctx, tx,
+				cmd.ClientMsgID,
+				cmd.UserID,
+				cmd.TargetConversationID,
+			)
+			if err != nil {
+				return fmt.Errorf("failed to fetch idempotency response: %w", err)
+			}
+			if payload != nil {
+				if err := json.Unmarshal(payload, &result); err != nil {
+					return fmt.Errorf("failed to unmarshal cached messages: %w", err)
+				}
+				return nil
+			}
+		}
+
+		ttl := time.Duration(target.GetMessageTtlSeconds()) * time.Second
+		copies := make([]*domain.Message, 0, len(sources))
+
+		first, err := s.allocateSequences(ctx, tx, cmd.TargetConversationID, len(sources))
+		if err != nil {
+			return err
+		}
+
+		for i, src := range sources {
+			msg, err := src.Forward(
+				uuid.NewString(),
+				cmd.TargetConversationID,
+				cmd.UserID,
+				first+int64(i),
+				time.Now().UTC(),
+			)
+			if err != nil {
+				return err
+			}
+			msg.ExpireAfter(ttl)
+
+			if err := s.repo.InsertMessage(ctx, tx, msg); err != nil {
+				return fmt.Errorf("failed to save message: %w", err)
+			}
+
+			// Forwarded polls start over without votes
+			if msg.Type == domain.TypePoll {
+				if err := s.createPoll(ctx, tx, msg); err != nil {
+					return err
+				}
+			}
+
+			if screened[i] != nil {
+				if err := s.recordModeration(ctx, tx, msg.ConversationID, cmd.UserID, screened[i], msg.ID, ""); err != nil {
+					return err
+				}
+			}
+
+			if a := atts[src.ID]; len(a) > 0 {
+				msg.Attachments = a
+				if err := s.repo.InsertMessageAttachments(ctx, tx, msg.ID, a); err != nil {
+					return fmt.Errorf("failed to save message attachments: %w", err)
+				}
+			}
+
+			if err := s.emitEvent(
+				ctx, tx,
+				msg.ConversationID,
+				sharedv1.EventType_EVENT_TYPE_MESSAGE_SENT,
+				"MESSAGE_SENT",
+				&messagev1.MessageSentEvent{Message: ToProtoMessage(msg)},
+			); err != nil {
+				return err
+			}
+
+			copies = append(copies, msg)
+		}
+
+		payload, err := json.Marshal(copies)
+		if err != nil {
+			return fmt.Errorf("failed to marshal messages for idempotency: %w", err)
+		}
+		if err := s.repo.UpdateIdempotencyResponse(
+			ctx, tx,
+			cmd.ClientMsgID,
+			cmd.UserID,
+			cmd.TargetConversationID,
+			payload,
+		); err != nil {
+			return fmt.Errorf("failed to update idempotency response: %w", err)
+		}
+
+		result = copies
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	s.signAttachmentURLs(ctx, result)
+	return result, nil
+}
+
+// screenForwards checks each of sources as a message sent by the caller into
+// the target: against its message type, so messages the service wrote
+// itself can't be passed off as the caller's, and through the moderation
+// chain. Redactions are applied to sources. A copy the chain holds can't
+// wait in the review queue without the rest, so it fails the forward like
+// a rejected one. It returns the screening of each source, nil when not
+// screened.
+func (s *Service) screenForwards(
+	ctx context.Context,
+	cmd ForwardMessagesCommand,
+	sources []*domain.Message,
+	atts map[string][]domain.Attachment,
+) ([]*moderation.Result, error) {
+
+	screened := make([]*moderation.Result, len(sources))
+	for i, src := range sources {
+		send := SendMessageCommand{
+			ConversationID: cmd.TargetConversationID,
+			UserID:         cmd.UserID,
+			Type:           src.Type,
+			Content:        src.Content,
+			Metadata:       src.Metadata,
+		}
+		for _, a := range atts[src.ID] {
+			send.AttachmentIDs = append(send.AttachmentIDs, a.ID)
+		}
+		if err := s.validateSend(&send); err != nil {
+			return nil, err
+		}
+
+		res, err := s.moderate(ctx, &send)
+		if err != nil {
+			return nil, err
+		}
+		if res != nil && (res.Action == moderation.Reject || res.Action == moderation.Hold) {
+			if err := s.recordModeration(ctx, nil, cmd.TargetConversationID, cmd.UserID, res, "", ""); err != nil {
+				return nil, err
+			}
+			return nil, fmt.Errorf("%w: %s", domain.ErrMessageRejected, res.Reason)
+		}
+		src.Content = send.Content
+		screened[i] = res
+	}
+	return screened, nil
+}
+
+// loadForwardSources loads the messages to forward in sequence order. Messages
+// outside the source conversation, or already expired, count as not found.
+func (s *Service) loadForwardSources(
+	ctx context.Context,
+	tx *sql.Tx,
+	cmd ForwardMessagesCommand,
+) ([]*domain.Message, error) {
+
+	now := time.Now()
+	sources := make([]*domain.Message, 0, len(cmd.MessageIDs))
+
+	for _, id := range cmd.MessageIDs {
+		msg, err := s.repo.GetMessage(ctx, tx, id)
+		if err != nil {
+			return nil, err
+		}
+		if msg.ConversationID != cmd.SourceConversationID {
+			return nil, domain.ErrMessageNotFound
+		}
+		if msg.ExpiresAt != nil && !msg.ExpiresAt.After(now) {
+			return nil, domain.ErrMessageNotFound
+		}
+		// Its ciphertexts are only readable by the original recipients
+		if msg.Type == domain.TypeEncrypted {
+			return nil, domain.ErrMessageEncrypted
+		}
+		sources = append(sources, msg)
+	}
+
+	sort.Slice(sources, func(i, j int) bool {
+		return sources[i].Sequence < sources[j].Sequence
+	})
+	return sources, nil
+}
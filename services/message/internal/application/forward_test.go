@@ -0,0 +1,164 @@
+package application
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	conversationv1 "github.com/SARVESHVARADKAR123/RealChat/contracts/gen/go/conversation/v1"
+	"github.com/SARVESHVARADKAR123/RealChat/services/message/internal/domain"
+	"github.com/SARVESHVARADKAR123/RealChat/services/message/internal/moderation"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func TestForwardMessages(t *testing.T) {
+	ctx := context.Background()
+	sentAt := time.Date(2024, 1, 1, 12, 0, 0, 0, time.UTC)
+
+	convResp := func(id string, participants ...string) *conversationv1.GetConversationResponse {
+		return &conversationv1.GetConversationResponse{
+			ParticipantUserIds: participants,
+			Conversation:       &conversationv1.Conversation{ConversationId: id},
+		}
+	}
+	convReq := func(id string) interface{} {
+		return mock.MatchedBy(func(r *conversationv1.GetConversationRequest) bool { return r.ConversationId == id })
+	}
+
+	cmd := ForwardMessagesCommand{
+		SourceConversationID: "conv-src",
+		TargetConversationID: "conv-dst",
+		MessageIDs:           []string{"msg-2", "msg-1"},
+		UserID:               "user-1",
+		ClientMsgID:          "fwd-1",
+	}
+
+	t.Run("Copies in source order with provenance", func(t *testing.T) {
+		repo := new(MockRepo)
+		convSvc := new(MockConvClient)
+		svc := &Service{repo: repo, tx: new(MockTransactor), convSvc: convSvc}
+
+		original := &domain.Message{ID: "msg-1", ConversationID: "conv-src", SenderID: "user-2", Sequence: 4, Type: "text", Content: "hello", SentAt: sentAt}
+		// msg-2 is itself a forward, so its copy points at the original
+		forwarded := &domain.Message{ID: "msg-2", ConversationID: "conv-src", SenderID: "user-3", Sequence: 7, Type: "text", Content: "fwd", SentAt: sentAt,
+			ForwardedFrom: &domain.ForwardedFrom{MessageID: "msg-0", ConversationID: "conv-old", SenderID: "user-9", SentAt: sentAt}}
+
+		convSvc.On("GetConversation", ctx, convReq("conv-src")).Return(convResp("conv-src", "user-1", "user-2"), nil).Once()
+		convSvc.On("GetConversation", ctx, convReq("conv-dst")).Return(convResp("conv-dst", "user-1"), nil).Once()
+		convSvc.On("ReserveSequences", ctx, mock.MatchedBy(func(r *conversationv1.ReserveSequencesRequest) bool {
+			return r.ConversationId == "conv-dst" && r.AfterSequence == 0 && r.Count >= 2
+		})).Return(&conversationv1.ReserveSequencesResponse{FirstSequence: 10, LastSequence: 109}, nil).Once()
+		repo.On("GetMessage", ctx, mock.Anything, "msg-2").Return(forwarded, nil).Once()
+		repo.On("GetMessage", ctx, mock.Anything, "msg-1").Return(original, nil).Once()
+		repo.On("ListMessageAttachments", ctx, mock.Anything, cmd.MessageIDs).Return(map[string][]domain.Attachment{}, nil).Once()
+		repo.On("InsertMessage", ctx, mock.Anything, mock.Anything).Return(nil).Twice()
+		repo.On("InsertOutbox", ctx, mock.Anything, "message", "conv-dst", "MESSAGE_SENT", mock.Anything).Return(nil).Twice()
+
+		msgs, err := svc.ForwardMessages(ctx, cmd)
+		assert.NoError(t, err)
+		assert.Len(t, msgs, 2)
+
+		assert.Equal(t, int64(10), msgs[0].Sequence)
+		assert.Equal(t, "user-1", msgs[0].SenderID)
+		assert.Equal(t, "hello", msgs[0].Content)
+		assert.Equal(t, &domain.ForwardedFrom{MessageID: "msg-1", ConversationID: "conv-src", SenderID: "user-2", SentAt: sentAt}, msgs[0].ForwardedFrom)
+
+		assert.Equal(t, int64(11), msgs[1].Sequence)
+		assert.Equal(t, "msg-0", msgs[1].ForwardedFrom.MessageID)
+		repo.AssertExpectations(t)
+		convSvc.AssertExpectations(t)
+	})
+
+	t.Run("Caller must be in the target", func(t *testing.T) {
+		repo := new(MockRepo)
+		convSvc := new(MockConvClient)
+		svc := &Service{repo: repo, tx: new(MockTransactor), convSvc: convSvc}
+
+		convSvc.On("GetConversation", ctx, convReq("conv-src")).Return(convResp("conv-src", "user-1"), nil).Once()
+		convSvc.On("GetConversation", ctx, convReq("conv-dst")).Return(convResp("conv-dst", "user-2"), nil).Once()
+
+		_, err := svc.ForwardMessages(ctx, cmd)
+		assert.ErrorIs(t, err, domain.ErrNotParticipant)
+		repo.AssertExpectations(t)
+	})
+
+	t.Run("Message from another conversation", func(t *testing.T) {
+		repo := new(MockRepo)
+		convSvc := new(MockConvClient)
+		svc := &Service{repo: repo, tx: new(MockTransactor), convSvc: convSvc}
+
+		convSvc.On("GetConversation", ctx, convReq("conv-src")).Return(convResp("conv-src", "user-1"), nil).Once()
+		convSvc.On("GetConversation", ctx, convReq("conv-dst")).Return(convResp("conv-dst", "user-1"), nil).Once()
+		repo.On("GetMessage", ctx, mock.Anything, "msg-2").Return(&domain.Message{ID: "msg-2", ConversationID: "conv-other"}, nil).Once()
+
+		_, err := svc.ForwardMessages(ctx, cmd)
+		assert.ErrorIs(t, err, domain.ErrMessageNotFound)
+		repo.AssertExpectations(t)
+	})
+}
+
+func TestForwardMessages_Screened(t *testing.T) {
+	ctx := context.Background()
+	forward := func(svc *Service, key string, ids ...string) ([]*domain.Message, error) {
+		return svc.ForwardMessages(ctx, ForwardMessagesCommand{
+			SourceConversationID: "conv-1",
+			TargetConversationID: "conv-1",
+			MessageIDs:           ids,
+			UserID:               "user-1",
+			ClientMsgID:          key,
+		})
+	}
+	newRepo := func() *moderationRepo {
+		repo := newModerationRepo()
+		repo.messages = []*domain.Message{
+			{ID: "notice", ConversationID: "conv-1", Sequence: 1, Type: domain.TypeSystem, Content: "user-2 was made an admin"},
+			{ID: "mild", ConversationID: "conv-1", SenderID: "user-2", Sequence: 2, Type: domain.TypeText, Content: "darn"},
+			{ID: "abuse", ConversationID: "conv-1", SenderID: "user-2", Sequence: 3, Type: domain.TypeText, Content: "what a slur"},
+		}
+		return repo
+	}
+
+	t.Run("System messages can't be forwarded", func(t *testing.T) {
+		repo := newRepo()
+		svc := newModerationService(t, repo, moderationConfig)
+
+		_, err := forward(svc, "f1", "notice")
+		var ve *domain.ValidationError
+		if assert.ErrorAs(t, err, &ve) {
+			assert.Equal(t, "message_type", ve.Field)
+		}
+		assert.Len(t, repo.messages, 3)
+	})
+
+	t.Run("A rejected copy fails the forward", func(t *testing.T) {
+		repo := newRepo()
+		svc := newModerationService(t, repo, moderationConfig)
+
+		_, err := forward(svc, "f2", "mild", "abuse")
+		assert.ErrorIs(t, err, domain.ErrMessageRejected)
+		assert.Len(t, repo.messages, 3)
+		if assert.Len(t, repo.audit, 1) {
+			assert.Equal(t, string(moderation.Reject), repo.audit[0].Action)
+			assert.Empty(t, repo.audit[0].MessageID)
+		}
+	})
+
+	t.Run("Redactions reach the copy", func(t *testing.T) {
+		repo := newRepo()
+		svc := newModerationService(t, repo, moderationConfig)
+
+		copies, err := forward(svc, "f3", "mild")
+		if err != nil {
+			t.Fatal(err)
+		}
+		if assert.Len(t, copies, 1) {
+			assert.Equal(t, "****", copies[0].Content)
+			assert.Equal(t, "mild", copies[0].ForwardedFrom.MessageID)
+			if assert.Len(t, repo.audit, 1) {
+				assert.Equal(t, copies[0].ID, repo.audit[0].MessageID)
+			}
+		}
+		assert.Equal(t, "darn", repo.messages[1].Content)
+	})
+}
@@ -0,0 +1,30 @@
+package application
+
+import (
+	"context"
+	"time"
+)
+
+const defaultIdempotencyTTL = 24 * time.Hour
+
+// idempotencyTTL is how long a client's idempotency key is honoured. After
+// that the key can be reused for a new request.
+func (s *Service) idempotencyTTL() time.Duration {
+	if s.opts.IdempotencyTTL > 0 {
+		return s.opts.IdempotencyTTL
+	}
+	return defaultIdempotencyTTL
+}
+
+// PurgeExpiredIdempotencyKeys deletes up to limit idempotency keys that
+// expired before now and returns how many it deleted. Expired keys are
+// already ignored by lookups, so this only reclaims space.
+func (s *Service) PurgeExpiredIdempotencyKeys(ctx context.Context, now time.Time, limit int) (int, error) {
+	return s.repo.DeleteExpiredIdempotencyKeys(ctx, nil, now, limit)
+}
+
+// EstimateIdempotencyKeys returns the approximate size of the idempotency
+// table.
+func (s *Service) EstimateIdempotencyKeys(ctx context.Context) (int64, error) {
+	return s.repo.EstimateIdempotencyKeys(ctx)
+}
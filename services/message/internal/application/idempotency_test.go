@@ -0,0 +1,93 @@
+package application
+
+import (
+	"context"
+	"database/sql"
+	"testing"
+	"time"
+
+	conversationv1 "github.com/SARVESHVARADKAR123/RealChat/contracts/gen/go/conversation/v1"
+	"github.com/SARVESHVARADKAR123/RealChat/services/message/internal/domain"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"go.uber.org/zap"
+)
+
+// MockIdempotencyRepo adds the idempotency key janitor's calls.
+type MockIdempotencyRepo struct {
+	MockRepo
+}
+
+func (m *MockIdempotencyRepo) DeleteExpiredIdempotencyKeys(ctx context.Context, tx *sql.Tx, now time.Time, limit int) (int, error) {
+	args := m.Called(ctx, tx, now, limit)
+	return args.Int(0), args.Error(1)
+}
+func (m *MockIdempotencyRepo) EstimateIdempotencyKeys(ctx context.Context) (int64, error) {
+	args := m.Called(ctx)
+	return args.Get(0).(int64), args.Error(1)
+}
+
+func TestIdempotencyTTL(t *testing.T) {
+	assert.Equal(t, 24*time.Hour, (&Service{}).idempotencyTTL())
+	assert.Equal(t, time.Hour, (&Service{opts: Options{IdempotencyTTL: time.Hour}}).idempotencyTTL())
+}
+
+func TestPurgeExpiredIdempotencyKeys(t *testing.T) {
+	ctx := context.Background()
+	now := time.Date(2026, 3, 1, 12, 0, 0, 0, time.UTC)
+
+	repo := new(MockIdempotencyRepo)
+	svc := &Service{repo: repo, tx: new(MockTransactor)}
+
+	repo.On("DeleteExpiredIdempotencyKeys", ctx, mock.Anything, now, 500).Return(500, nil).Once()
+	repo.On("EstimateIdempotencyKeys", ctx).Return(int64(1200), nil).Once()
+
+	n, err := svc.PurgeExpiredIdempotencyKeys(ctx, now, 500)
+	assert.NoError(t, err)
+	assert.Equal(t, 500, n)
+
+	size, err := svc.EstimateIdempotencyKeys(ctx)
+	assert.NoError(t, err)
+	assert.Equal(t, int64(1200), size)
+	repo.AssertExpectations(t)
+}
+
+func TestSendMessage_ReplaysBeforeChecks(t *testing.T) {
+	ctx := context.Background()
+	repo := newThreadRepo()
+	svc := &Service{
+		repo:    &memIdempotencyRepo{Repository: repo, keys: map[string][]byte{}},
+		tx:      new(MockTransactor),
+		convSvc: &slowConvClient{},
+		log:     zap.NewNop(),
+	}
+	send := func(key string) (*domain.Message, error) {
+		return svc.SendMessage(ctx, SendMessageCommand{
+			ConversationID: "conv-1",
+			UserID:         "user-1",
+			ClientMsgID:    key,
+			Content:        "hello",
+		})
+	}
+
+	first, err := send("k1")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// user-1 leaves before the retry arrives
+	repo.info = nil
+	convSvc := new(MockConvClient)
+	convSvc.On("GetConversation", ctx, mock.Anything).Return(&conversationv1.GetConversationResponse{
+		Conversation: &conversationv1.Conversation{ConversationId: "conv-1", ParticipantUserIds: []string{"user-2"}},
+	}, nil)
+	svc.convSvc = convSvc
+
+	again, err := send("k1")
+	assert.NoError(t, err)
+	assert.Equal(t, first.ID, again.ID)
+	assert.Len(t, repo.messages, 1)
+
+	_, err = send("k2")
+	assert.ErrorIs(t, err, domain.ErrNotParticipant)
+}
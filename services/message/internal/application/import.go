@@ -0,0 +1,471 @@
+package application
+
+import (
+	"context"
+	"crypto/sha256"
+	"database/sql"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"slices"
+	"strings"
+	"time"
+	"unicode/utf8"
+
+	conversationv1 "github.com/SARVESHVARADKAR123/RealChat/contracts/gen/go/conversation/v1"
+	mediav1 "github.com/SARVESHVARADKAR123/RealChat/contracts/gen/go/media/v1"
+	profilev1 "github.com/SARVESHVARADKAR123/RealChat/contracts/gen/go/profile/v1"
+	"github.com/SARVESHVARADKAR123/RealChat/services/message/internal/domain"
+	"github.com/SARVESHVARADKAR123/RealChat/services/message/internal/imports"
+	"github.com/SARVESHVARADKAR123/RealChat/services/message/internal/observability"
+	"github.com/google/uuid"
+	"go.uber.org/zap"
+)
+
+const (
+	// importLease is how long a running import may go without progress
+	// before it is claimed again.
+	importLease = 10 * time.Minute
+
+	importBatchSize = 500
+)
+
+// importNamespace seeds the name-based UUIDs of imports and everything they
+// create.
+var importNamespace = uuid.MustParse("6f1c1c3e-8d0a-4f55-9a57-2b7f3a7f0c11")
+
+// errImportTakenOver stops a run whose import was claimed by another one.
+var errImportTakenOver = errors.New("import was taken over by another run")
+
+// ImportCommand describes an import of an export from another chat app.
+type ImportCommand struct {
+	Source string
+	// Name becomes the display name of the new conversation.
+	Name      string
+	CreatedBy string
+	// Mapping turns the export's senders into RealChat user IDs: Slack user
+	// IDs or WhatsApp display names.
+	Mapping map[string]string
+	Options imports.Options
+
+	// AttachmentID is the export, uploaded through the media service by
+	// CreatedBy. Only imports requested through the API have one.
+	AttachmentID string
+}
+
+// RequestImport queues an import of an uploaded export. Asking again for
+// the same import returns the existing one, and requeues it if it failed so
+// that it resumes where it stopped.
+//
+// Imported messages are attributed to other users, so only the users
+// listed in Options.ImportAdmins may request imports.
+func (s *Service) RequestImport(ctx context.Context, cmd ImportCommand) (*domain.Import, error) {
+	if !slices.Contains(s.opts.ImportAdmins, cmd.CreatedBy) {
+		return nil, domain.ErrImportForbidden
+	}
+	if cmd.AttachmentID == "" {
+		return nil, domain.ErrInvalidInput
+	}
+	if _, err := s.resolveAttachments(ctx, cmd.CreatedBy, []string{cmd.AttachmentID}); err != nil {
+		return nil, err
+	}
+
+	imp, err := newImport(cmd, "attachment:"+cmd.AttachmentID)
+	if err != nil {
+		return nil, err
+	}
+	return s.queueImport(ctx, imp)
+}
+
+// GetImport returns one of userID's imports. Other users' imports are
+// reported as not found.
+func (s *Service) GetImport(ctx context.Context, importID, userID string) (*domain.Import, error) {
+	imp, err := s.repo.GetImport(ctx, nil, importID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load import: %w", err)
+	}
+	if imp == nil || imp.CreatedBy != userID {
+		return nil, domain.ErrImportNotFound
+	}
+	return imp, nil
+}
+
+// ClaimImports hands up to limit queued imports to the calling worker.
+func (s *Service) ClaimImports(ctx context.Context, now time.Time, limit int) ([]*domain.Import, error) {
+	return s.repo.ClaimImports(ctx, now, now.Add(-importLease), limit)
+}
+
+// RunImport downloads the export of a claimed import, writes what is left
+// of it and records the outcome on imp. Failures to import mark the import
+// failed; the returned error is for failing to record the outcome. The
+// caller's context should carry the importer's identity.
+func (s *Service) RunImport(ctx context.Context, imp *domain.Import) error {
+	data, err := s.downloadImport(ctx, imp)
+	if err == nil {
+		err = s.runImport(ctx, imp, data)
+	}
+	return s.finishImport(ctx, imp, err)
+}
+
+// ImportFile imports a local export on behalf of cmd.CreatedBy, for the
+// admin command. It runs to completion in the caller; running it again
+// with the same export and settings resumes a failed or interrupted import
+// and returns a finished one as it is. The caller's context should carry
+// the importer's identity.
+func (s *Service) ImportFile(ctx context.Context, cmd ImportCommand, data []byte) (*domain.Import, error) {
+	sum := sha256.Sum256(data)
+	imp, err := newImport(cmd, "sha256:"+hex.EncodeToString(sum[:]))
+	if err != nil {
+		return nil, err
+	}
+	if imp, err = s.queueImport(ctx, imp); err != nil {
+		return nil, err
+	}
+	if imp.Status == domain.ImportDone {
+		return imp, nil
+	}
+
+	now := time.Now().UTC()
+	claimed, err := s.repo.ClaimImport(ctx, imp.ID, now, now.Add(-importLease))
+	if err != nil {
+		return nil, fmt.Errorf("failed to claim import: %w", err)
+	}
+	if claimed == nil {
+		return imp, fmt.Errorf("import %s is already running", imp.ID)
+	}
+
+	runErr := s.runImport(ctx, claimed, data)
+	if err := s.finishImport(ctx, claimed, runErr); err != nil {
+		return claimed, err
+	}
+	return claimed, runErr
+}
+
+// newImport builds the import of cmd. Its ID is derived from source, which
+// identifies the export, and from everything else that shapes the result,
+// so the same request always names the same import and conversation.
+func newImport(cmd ImportCommand, source string) (*domain.Import, error) {
+	if !imports.ValidSource(cmd.Source) {
+		return nil, domain.ErrInvalidInput
+	}
+
+	// encoding/json sorts map keys, so the mapping encodes the same way
+	// every time
+	key, err := json.Marshal(struct {
+		Input     string            `json:"input"`
+		Source    string            `json:"source"`
+		Name      string            `json:"name"`
+		CreatedBy string            `json:"created_by"`
+		Mapping   map[string]string `json:"mapping"`
+		Options   imports.Options   `json:"options"`
+	}{source, cmd.Source, cmd.Name, cmd.CreatedBy, cmd.Mapping, cmd.Options})
+	if err != nil {
+		return nil, err
+	}
+
+	id := uuid.NewSHA1(importNamespace, key).String()
+	conversationID := uuid.NewSHA1(importNamespace, []byte("conversation/"+id)).String()
+
+	imp, err := domain.NewImport(id, conversationID, cmd.Source, cmd.Name, cmd.CreatedBy, cmd.Mapping, time.Now().UTC())
+	if err != nil {
+		return nil, err
+	}
+	imp.AttachmentID = cmd.AttachmentID
+	imp.Channel = cmd.Options.Channel
+	imp.TimeZone = cmd.Options.TimeZone
+	imp.DayFirst = cmd.Options.DayFirst
+	return imp, nil
+}
+
+// queueImport stores imp unless it exists already, in which case the
+// stored import is returned, requeued if it had failed.
+func (s *Service) queueImport(ctx context.Context, imp *domain.Import) (*domain.Import, error) {
+	inserted, err := s.repo.InsertImport(ctx, nil, imp)
+	if err != nil {
+		return nil, fmt.Errorf("failed to queue import: %w", err)
+	}
+	if inserted {
+		return imp, nil
+	}
+
+	existing, err := s.repo.GetImport(ctx, nil, imp.ID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load import: %w", err)
+	}
+	if existing == nil {
+		return nil, domain.ErrImportNotFound
+	}
+	if existing.Status == domain.ImportFailed {
+		if _, err := s.repo.RequeueImport(ctx, nil, existing.ID, time.Now().UTC()); err != nil {
+			return nil, fmt.Errorf("failed to requeue import: %w", err)
+		}
+		existing.Status = domain.ImportPending
+		existing.FailureReason = ""
+		existing.CompletedAt = nil
+	}
+	return existing, nil
+}
+
+// finishImport records the outcome of a run of imp.
+func (s *Service) finishImport(ctx context.Context, imp *domain.Import, runErr error) error {
+	if errors.Is(runErr, errImportTakenOver) {
+		s.log.Warn("import was taken over by another run", zap.String("import_id", imp.ID))
+		return nil
+	}
+
+	now := time.Now().UTC()
+	imp.CompletedAt = &now
+	if runErr != nil {
+		s.log.Warn("import failed", zap.String("import_id", imp.ID), zap.Error(runErr))
+		imp.Status = domain.ImportFailed
+		imp.FailureReason = runErr.Error()
+	} else {
+		imp.Status = domain.ImportDone
+	}
+
+	finished, err := s.repo.FinishImport(ctx, nil, imp)
+	if err != nil {
+		return fmt.Errorf("failed to record import %s: %w", imp.ID, err)
+	}
+	if !finished {
+		s.log.Warn("import was taken over by another run", zap.String("import_id", imp.ID))
+	}
+	return nil
+}
+
+// downloadImport fetches the uploaded export of imp from the media service.
+func (s *Service) downloadImport(ctx context.Context, imp *domain.Import) ([]byte, error) {
+	stream, err := s.media.DownloadContent(ctx, &mediav1.DownloadContentRequest{AttachmentId: imp.AttachmentID})
+	if err != nil {
+		return nil, fmt.Errorf("failed to download export: %w", err)
+	}
+
+	first, err := stream.Recv()
+	if err != nil {
+		return nil, fmt.Errorf("failed to download export: %w", err)
+	}
+	if first.GetAttachment().GetOwnerUserId() != imp.CreatedBy {
+		return nil, domain.ErrInvalidAttachment
+	}
+
+	data := make([]byte, 0, first.GetAttachment().GetSizeBytes())
+	data = append(data, first.GetData()...)
+	for {
+		chunk, err := stream.Recv()
+		if err == io.EOF {
+			return data, nil
+		}
+		if err != nil {
+			return nil, fmt.Errorf("failed to download export: %w", err)
+		}
+		data = append(data, chunk.GetData()...)
+	}
+}
+
+// runImport writes the messages of data that imp has not written yet.
+//
+// Every batch is one transaction that takes its sequences in one go, writes
+// the messages with their original timestamps and advances the import's
+// progress. Nothing goes through the outbox: the history is there for
+// whoever opens the conversation, not pushed to devices as new messages.
+// Once everything is written the conversation service is told the last
+// sequence directly instead.
+func (s *Service) runImport(ctx context.Context, imp *domain.Import, data []byte) error {
+	msgs, err := imports.Parse(imp.Source, data, imports.Options{
+		Channel:  imp.Channel,
+		TimeZone: imp.TimeZone,
+		DayFirst: imp.DayFirst,
+	})
+	if err != nil {
+		return fmt.Errorf("%w: %v", domain.ErrInvalidImport, err)
+	}
+	msgs = splitImported(msgs, domain.MaxMessageSize)
+
+	senders := imports.Senders(msgs)
+	var unmapped []string
+	for _, sender := range senders {
+		if imp.Mapping[sender] == "" {
+			unmapped = append(unmapped, sender)
+		}
+	}
+	if len(unmapped) > 0 {
+		slices.Sort(unmapped)
+		return fmt.Errorf("%w: no user mapped for %s", domain.ErrInvalidImport, strings.Join(unmapped, ", "))
+	}
+
+	if int64(len(msgs)) < imp.ImportedMessages {
+		return fmt.Errorf("%w: export has fewer messages than already imported", domain.ErrInvalidImport)
+	}
+	imp.TotalMessages = int64(len(msgs))
+
+	participants := imp.Participants(senders)
+	if err := s.requireUsers(ctx, participants); err != nil {
+		return err
+	}
+
+	// Idempotent by ID, so a resumed import finds the conversation it made
+	if _, err := s.convSvc.CreateConversation(ctx, &conversationv1.CreateConversationRequest{
+		ConversationId:     imp.ConversationID,
+		Type:               conversationv1.ConversationType_GROUP,
+		DisplayName:        imp.Name,
+		ParticipantUserIds: participants,
+	}); err != nil {
+		return fmt.Errorf("failed to create conversation: %w", err)
+	}
+
+	present := make(map[string]bool, len(msgs))
+	for _, m := range msgs {
+		present[m.ExternalID] = true
+	}
+
+	for imp.ImportedMessages < imp.TotalMessages {
+		from := imp.ImportedMessages
+		batch := msgs[from:min(from+importBatchSize, imp.TotalMessages)]
+
+		err := s.tx.WithTx(ctx, func(ctx context.Context, tx *sql.Tx) error {
+			imp.ImportedMessages = from + int64(len(batch))
+			imp.UpdatedAt = time.Now().UTC()
+
+			// Locks the import, so no other run writes this batch too
+			ok, err := s.repo.SaveImportProgress(ctx, tx, imp, from)
+			if err != nil {
+				return fmt.Errorf("failed to save import progress: %w", err)
+			}
+			if !ok {
+				return errImportTakenOver
+			}
+
+			first, err := s.allocateSequences(ctx, tx, imp.ConversationID, len(batch))
+			if err != nil {
+				return err
+			}
+
+			for k, m := range batch {
+				msg, err := domain.NewMessage(
+					importedMessageID(imp, m.ExternalID),
+					imp.ConversationID,
+					imp.Mapping[m.Sender],
+					first+int64(k),
+					domain.TypeText,
+					m.Text,
+					"",
+					m.SentAt,
+				)
+				if err != nil {
+					return err
+				}
+
+				if m.ThreadID != "" && present[m.ThreadID] {
+					rootID := importedMessageID(imp, m.ThreadID)
+					msg.ReplyToID = rootID
+					msg.ThreadRootID = rootID
+				}
+
+				if err := s.repo.InsertMessage(ctx, tx, msg); err != nil {
+					return fmt.Errorf("failed to insert imported message: %w", err)
+				}
+				if msg.ThreadRootID != "" {
+					if err := s.repo.RecordThreadReply(ctx, tx, msg.ThreadRootID, msg.SentAt); err != nil {
+						return fmt.Errorf("failed to record thread reply: %w", err)
+					}
+				}
+			}
+			return nil
+		})
+		if err != nil {
+			imp.ImportedMessages = from
+			return err
+		}
+		observability.ImportedMessagesTotal.Add(float64(len(batch)))
+	}
+	return s.recordImportedSequence(ctx, imp, msgs)
+}
+
+// recordImportedSequence tells the conversation service the sequence of the
+// last imported message. Imports emit no MessageSent events, so without it
+// imported history would not count towards unread messages. Looking the
+// message up, rather than remembering the last batch, covers a run that
+// failed after writing everything.
+func (s *Service) recordImportedSequence(ctx context.Context, imp *domain.Import, msgs []imports.Message) error {
+	if len(msgs) == 0 {
+		return nil
+	}
+	last, err := s.repo.GetMessage(ctx, nil, importedMessageID(imp, msgs[len(msgs)-1].ExternalID))
+	if err != nil {
+		return fmt.Errorf("failed to load last imported message: %w", err)
+	}
+	if _, err := s.convSvc.RecordMessageSequence(ctx, &conversationv1.RecordMessageSequenceRequest{
+		ConversationId: imp.ConversationID,
+		Sequence:       last.Sequence,
+	}); err != nil {
+		return fmt.Errorf("failed to record imported sequence: %w", err)
+	}
+	return nil
+}
+
+// requireUsers fails unless every one of userIDs has a profile.
+func (s *Service) requireUsers(ctx context.Context, userIDs []string) error {
+	found := make(map[string]bool, len(userIDs))
+	for rest := userIDs; len(rest) > 0; {
+		batch := rest[:min(len(rest), profileBatchLimit)]
+		rest = rest[len(batch):]
+
+		resp, err := s.profiles.BatchGetProfiles(ctx, &profilev1.BatchGetProfilesRequest{UserIds: batch})
+		if err != nil {
+			return fmt.Errorf("failed to load profiles: %w", err)
+		}
+		for _, p := range resp.GetProfiles() {
+			found[p.GetUserId()] = true
+		}
+	}
+
+	var unknown []string
+	for _, id := range userIDs {
+		if !found[id] {
+			unknown = append(unknown, id)
+		}
+	}
+	if len(unknown) > 0 {
+		return fmt.Errorf("%w: unknown users %s", domain.ErrInvalidImport, strings.Join(unknown, ", "))
+	}
+	return nil
+}
+
+// importedMessageID names the message of an import made from the export's
+// message externalID.
+func importedMessageID(imp *domain.Import, externalID string) string {
+	return uuid.NewSHA1(importNamespace, []byte(imp.ID+"/"+externalID)).String()
+}
+
+// splitImported breaks messages longer than limit bytes into consecutive
+// parts. The first part keeps the message's ExternalID, so replies still
+// find it.
+func splitImported(msgs []imports.Message, limit int) []imports.Message {
+	out := make([]imports.Message, 0, len(msgs))
+	for _, m := range msgs {
+		if len(m.Text) <= limit {
+			out = append(out, m)
+			continue
+		}
+
+		text := m.Text
+		for part := 0; text != ""; part++ {
+			n := min(len(text), limit)
+			// Back off to a rune boundary
+			for n < len(text) && !utf8.RuneStart(text[n]) {
+				n--
+			}
+
+			p := m
+			p.Text = text[:n]
+			if part > 0 {
+				p.ExternalID = fmt.Sprintf("%s#%d", m.ExternalID, part)
+			}
+			out = append(out, p)
+			text = text[n:]
+		}
+	}
+	return out
+}
@@ -0,0 +1,497 @@
+package application
+
+import (
+	"archive/zip"
+	"bytes"
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"maps"
+	"sort"
+	"strings"
+	"testing"
+	"time"
+
+	conversationv1 "github.com/SARVESHVARADKAR123/RealChat/contracts/gen/go/conversation/v1"
+	mediav1 "github.com/SARVESHVARADKAR123/RealChat/contracts/gen/go/media/v1"
+	profilev1 "github.com/SARVESHVARADKAR123/RealChat/contracts/gen/go/profile/v1"
+	"github.com/SARVESHVARADKAR123/RealChat/services/message/internal/domain"
+	"github.com/SARVESHVARADKAR123/RealChat/services/message/internal/imports"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"go.uber.org/zap"
+	"google.golang.org/grpc"
+)
+
+// importRepo keeps imports, sequence blocks and messages in memory, and
+// doubles as a transactor that rolls them back when a transaction fails.
+type importRepo struct {
+	*blockRepo
+	imports  map[string]domain.Import
+	messages map[string]*domain.Message
+	info     *domain.ConversationInfo
+
+	// failAt makes InsertMessage fail once, when the store holds that many
+	// messages.
+	failAt int
+}
+
+func newImportRepo() *importRepo {
+	return &importRepo{
+		blockRepo: &blockRepo{MockRepo: new(MockRepo), blocks: map[string]domain.SequenceBlock{}},
+		imports:   map[string]domain.Import{},
+		messages:  map[string]*domain.Message{},
+	}
+}
+
+func (r *importRepo) WithTx(ctx context.Context, fn func(ctx context.Context, tx *sql.Tx) error) error {
+	imports, messages, blocks := maps.Clone(r.imports), maps.Clone(r.messages), maps.Clone(r.blocks)
+	if err := fn(ctx, nil); err != nil {
+		r.imports, r.messages, r.blocks = imports, messages, blocks
+		return err
+	}
+	return nil
+}
+
+func (r *importRepo) InsertImport(ctx context.Context, tx *sql.Tx, i *domain.Import) (bool, error) {
+	if _, ok := r.imports[i.ID]; ok {
+		return false, nil
+	}
+	r.imports[i.ID] = *i
+	return true, nil
+}
+
+func (r *importRepo) GetImport(ctx context.Context, tx *sql.Tx, id string) (*domain.Import, error) {
+	i, ok := r.imports[id]
+	if !ok {
+		return nil, nil
+	}
+	return &i, nil
+}
+
+func (r *importRepo) RequeueImport(ctx context.Context, tx *sql.Tx, id string, now time.Time) (bool, error) {
+	i, ok := r.imports[id]
+	if !ok || i.Status != domain.ImportFailed {
+		return false, nil
+	}
+	i.Status, i.FailureReason, i.CompletedAt = domain.ImportPending, "", nil
+	r.imports[id] = i
+	return true, nil
+}
+
+func (r *importRepo) ClaimImport(ctx context.Context, id string, now, staleBefore time.Time) (*domain.Import, error) {
+	i, ok := r.imports[id]
+	if !ok || (i.Status != domain.ImportPending && i.Status != domain.ImportFailed) {
+		return nil, nil
+	}
+	i.Status, i.StartedAt, i.UpdatedAt = domain.ImportRunning, &now, now
+	r.imports[id] = i
+	return &i, nil
+}
+
+func (r *importRepo) SaveImportProgress(ctx context.Context, tx *sql.Tx, i *domain.Import, from int64) (bool, error) {
+	stored := r.imports[i.ID]
+	if stored.Status != domain.ImportRunning || stored.ImportedMessages != from {
+		return false, nil
+	}
+	stored.ImportedMessages, stored.TotalMessages = i.ImportedMessages, i.TotalMessages
+	r.imports[i.ID] = stored
+	return true, nil
+}
+
+func (r *importRepo) FinishImport(ctx context.Context, tx *sql.Tx, i *domain.Import) (bool, error) {
+	r.imports[i.ID] = *i
+	return true, nil
+}
+
+func (r *importRepo) InsertMessage(ctx context.Context, tx *sql.Tx, msg *domain.Message) error {
+	if r.failAt > 0 && len(r.messages) == r.failAt {
+		r.failAt = 0
+		return errors.New("connection reset")
+	}
+	if _, ok := r.messages[msg.ID]; ok {
+		return errors.New("duplicate key")
+	}
+	r.messages[msg.ID] = msg
+	return nil
+}
+
+func (r *importRepo) GetMessage(ctx context.Context, tx *sql.Tx, id string) (*domain.Message, error) {
+	m, ok := r.messages[id]
+	if !ok {
+		return nil, domain.ErrMessageNotFound
+	}
+	return m, nil
+}
+
+func (r *importRepo) RecordThreadReply(ctx context.Context, tx *sql.Tx, rootID string, repliedAt time.Time) error {
+	root := r.messages[rootID]
+	root.ReplyCount++
+	root.LastReplyAt = &repliedAt
+	return nil
+}
+
+func (r *importRepo) GetConversationInfo(ctx context.Context, tx *sql.Tx, conversationID string) (*domain.ConversationInfo, error) {
+	if r.info == nil || r.info.ID != conversationID {
+		return nil, nil
+	}
+	return r.info, nil
+}
+
+func (r *importRepo) FetchMessages(ctx context.Context, convID string, lastSeq int64, limit int) ([]*domain.Message, error) {
+	var out []*domain.Message
+	for _, m := range r.sorted() {
+		if m.ConversationID == convID && m.Sequence > lastSeq && len(out) < limit {
+			out = append(out, m)
+		}
+	}
+	return out, nil
+}
+
+func (r *importRepo) FetchMessagesBefore(ctx context.Context, convID string, beforeSeq int64, limit int) ([]*domain.Message, error) {
+	var out []*domain.Message
+	msgs := r.sorted()
+	for i := len(msgs) - 1; i >= 0 && len(out) < limit; i-- {
+		if m := msgs[i]; m.ConversationID == convID && m.Sequence < beforeSeq {
+			out = append(out, m)
+		}
+	}
+	return out, nil
+}
+
+func (r *importRepo) ListMessageAttachments(ctx context.Context, tx *sql.Tx, ids []string) (map[string][]domain.Attachment, error) {
+	return map[string][]domain.Attachment{}, nil
+}
+
+// sorted returns the stored messages in sequence order.
+func (r *importRepo) sorted() []*domain.Message {
+	out := make([]*domain.Message, 0, len(r.messages))
+	for _, m := range r.messages {
+		out = append(out, m)
+	}
+	sort.Slice(out, func(i, j int) bool { return out[i].Sequence < out[j].Sequence })
+	return out
+}
+
+// importConvClient creates conversations, reserves sequences and records
+// the last one like the conversation service.
+type importConvClient struct {
+	conversationv1.ConversationApiClient
+	created  []*conversationv1.CreateConversationRequest
+	reserved int
+	recorded map[string]int64
+}
+
+func (c *importConvClient) CreateConversation(ctx context.Context, req *conversationv1.CreateConversationRequest, opts ...grpc.CallOption) (*conversationv1.CreateConversationResponse, error) {
+	c.created = append(c.created, req)
+	return &conversationv1.CreateConversationResponse{}, nil
+}
+
+func (c *importConvClient) ReserveSequences(ctx context.Context, req *conversationv1.ReserveSequencesRequest, opts ...grpc.CallOption) (*conversationv1.ReserveSequencesResponse, error) {
+	c.reserved++
+	return &conversationv1.ReserveSequencesResponse{FirstSequence: req.AfterSequence + 1, LastSequence: req.AfterSequence + req.Count}, nil
+}
+
+func (c *importConvClient) RecordMessageSequence(ctx context.Context, req *conversationv1.RecordMessageSequenceRequest, opts ...grpc.CallOption) (*conversationv1.RecordMessageSequenceResponse, error) {
+	if c.recorded == nil {
+		c.recorded = map[string]int64{}
+	}
+	c.recorded[req.ConversationId] = max(c.recorded[req.ConversationId], req.Sequence)
+	return &conversationv1.RecordMessageSequenceResponse{}, nil
+}
+
+func knownProfiles(userIDs ...string) *MockProfileClient {
+	profiles := new(MockProfileClient)
+	resp := &profilev1.BatchGetProfilesResponse{}
+	for _, id := range userIDs {
+		resp.Profiles = append(resp.Profiles, &profilev1.Profile{UserId: id})
+	}
+	profiles.On("BatchGetProfiles", mock.Anything, mock.Anything).Return(resp, nil)
+	return profiles
+}
+
+func zipOf(t *testing.T, files map[string]string) []byte {
+	var buf bytes.Buffer
+	zw := zip.NewWriter(&buf)
+	for name, content := range files {
+		w, err := zw.Create(name)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if _, err := w.Write([]byte(content)); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if err := zw.Close(); err != nil {
+		t.Fatal(err)
+	}
+	return buf.Bytes()
+}
+
+func TestParseSlack(t *testing.T) {
+	export := zipOf(t, map[string]string{
+		"users.json":    `[]`,
+		"channels.json": `[]`,
+		"general/2023-01-02.json": `[
+			{"type": "message", "user": "U2", "text": "second day", "ts": "1672617600.000100"}
+		]`,
+		"general/2023-01-01.json": `[
+			{"type": "message", "subtype": "channel_join", "user": "U1", "text": "<@U1> has joined", "ts": "1672531100.000000"},
+			{"type": "message", "user": "U1", "text": "fish &amp; chips?", "ts": "1672531200.000200", "thread_ts": "1672531200.000200"},
+			{"type": "message", "user": "U2", "text": "yes", "ts": "1672531260.000000", "thread_ts": "1672531200.000200"},
+			{"type": "message", "subtype": "bot_message", "text": "beep", "ts": "1672531300.000000"},
+			{"type": "message", "subtype": "file_share", "user": "U2", "text": "", "ts": "1672531320.000000", "files": [{"name": "menu.pdf"}]}
+		]`,
+		"random/2023-01-01.json": `[{"type": "message", "user": "U3", "text": "elsewhere", "ts": "1672531200.000000"}]`,
+	})
+
+	msgs, err := imports.Parse(imports.SourceSlack, export, imports.Options{Channel: "general"})
+	assert.NoError(t, err)
+	assert.Equal(t, []imports.Message{
+		{ExternalID: "1672531200.000200", Sender: "U1", SentAt: time.Unix(1672531200, 200000).UTC(), Text: "fish & chips?"},
+		{ExternalID: "1672531260.000000", Sender: "U2", SentAt: time.Unix(1672531260, 0).UTC(), Text: "yes", ThreadID: "1672531200.000200"},
+		{ExternalID: "1672531320.000000", Sender: "U2", SentAt: time.Unix(1672531320, 0).UTC(), Text: "[file: menu.pdf]"},
+		{ExternalID: "1672617600.000100", Sender: "U2", SentAt: time.Unix(1672617600, 100000).UTC(), Text: "second day"},
+	}, msgs)
+
+	// A workspace export needs a channel picked
+	_, err = imports.Parse(imports.SourceSlack, export, imports.Options{})
+	assert.ErrorIs(t, err, imports.ErrUnsupported)
+	_, err = imports.Parse(imports.SourceSlack, export, imports.Options{Channel: "missing"})
+	assert.ErrorIs(t, err, imports.ErrUnsupported)
+
+	// A single channel's messages need none
+	msgs, err = imports.Parse(imports.SourceSlack, []byte(`[{"type": "message", "user": "U1", "text": "hi", "ts": "1.5"}]`), imports.Options{})
+	assert.NoError(t, err)
+	if assert.Len(t, msgs, 1) {
+		assert.Equal(t, time.Unix(1, 500000000).UTC(), msgs[0].SentAt)
+	}
+}
+
+func TestParseWhatsApp(t *testing.T) {
+	t.Run("Android export with continuations and notices", func(t *testing.T) {
+		chat := "31/12/23, 11:58 pm - Messages and calls are end-to-end encrypted.\n" +
+			"31/12/23, 11:59 pm - Alice: Almost\n" +
+			"there\n" +
+			"1/1/24, 12:00 am - Bob: Happy new year: 2024!\n" +
+			"1/1/24, 12:01 am - Alice added Carol\n"
+
+		msgs, err := imports.Parse(imports.SourceWhatsApp, []byte(chat), imports.Options{DayFirst: true, TimeZone: "Europe/Berlin"})
+		assert.NoError(t, err)
+		assert.Equal(t, []imports.Message{
+			{ExternalID: "2", Sender: "Alice", SentAt: time.Date(2023, 12, 31, 22, 59, 0, 0, time.UTC), Text: "Almost\nthere"},
+			{ExternalID: "4", Sender: "Bob", SentAt: time.Date(2023, 12, 31, 23, 0, 0, 0, time.UTC), Text: "Happy new year: 2024!"},
+		}, msgs)
+	})
+
+	t.Run("iOS export in a zip", func(t *testing.T) {
+		chat := "\u200e[1/2/24, 9:05:07 PM] Alice: <attached: photo.jpg>\n" +
+			"[1/2/24, 21:06:00] Bob: nice\n"
+		export := zipOf(t, map[string]string{"_chat.txt": chat, "photo.jpg": "jpeg"})
+
+		msgs, err := imports.Parse(imports.SourceWhatsApp, export, imports.Options{})
+		assert.NoError(t, err)
+		if assert.Len(t, msgs, 2) {
+			// Month first unless asked otherwise
+			assert.Equal(t, time.Date(2024, 1, 2, 21, 5, 7, 0, time.UTC), msgs[0].SentAt)
+			assert.Equal(t, "<attached: photo.jpg>", msgs[0].Text)
+			assert.Equal(t, time.Date(2024, 1, 2, 21, 6, 0, 0, time.UTC), msgs[1].SentAt)
+		}
+	})
+
+	t.Run("Impossible dates are rejected", func(t *testing.T) {
+		_, err := imports.Parse(imports.SourceWhatsApp, []byte("31/02/24, 10:00 - Alice: hi\n"), imports.Options{DayFirst: true})
+		assert.ErrorIs(t, err, imports.ErrUnsupported)
+	})
+}
+
+func TestSplitImported(t *testing.T) {
+	long := strings.Repeat("é", 6) // 12 bytes
+	msgs := splitImported([]imports.Message{
+		{ExternalID: "1", Text: "short"},
+		{ExternalID: "2", Text: long, ThreadID: "1"},
+	}, 5)
+
+	if assert.Len(t, msgs, 4) {
+		assert.Equal(t, "short", msgs[0].Text)
+		// Parts end on rune boundaries and only the first keeps the ID
+		assert.Equal(t, []string{"éé", "éé", "éé"}, []string{msgs[1].Text, msgs[2].Text, msgs[3].Text})
+		assert.Equal(t, []string{"2", "2#1", "2#2"}, []string{msgs[1].ExternalID, msgs[2].ExternalID, msgs[3].ExternalID})
+		assert.Equal(t, "1", msgs[3].ThreadID)
+	}
+}
+
+func TestImportFile(t *testing.T) {
+	ctx := context.Background()
+	start := time.Date(2022, 3, 1, 8, 0, 0, 0, time.UTC)
+
+	var chat strings.Builder
+	for i := range 1201 {
+		sender := "Alice"
+		if i%2 == 1 {
+			sender = "Bob"
+		}
+		fmt.Fprintf(&chat, "%s - %s: message %d\n", start.Add(time.Duration(i)*time.Minute).Format("2/1/06, 15:04"), sender, i)
+	}
+	data := []byte(chat.String())
+
+	cmd := ImportCommand{
+		Source:    imports.SourceWhatsApp,
+		Name:      "Family",
+		CreatedBy: "user-1",
+		Mapping:   map[string]string{"Alice": "user-1", "Bob": "user-2"},
+		Options:   imports.Options{DayFirst: true},
+	}
+
+	repo := newImportRepo()
+	convSvc := &importConvClient{}
+	svc := &Service{repo: repo, tx: repo, convSvc: convSvc, profiles: knownProfiles("user-1", "user-2"), log: zap.NewNop()}
+
+	// The second batch fails part way and is rolled back
+	repo.failAt = 700
+	imp, err := svc.ImportFile(ctx, cmd, data)
+	assert.Error(t, err)
+	if assert.NotNil(t, imp) {
+		assert.Equal(t, domain.ImportFailed, imp.Status)
+		assert.Equal(t, int64(500), imp.ImportedMessages)
+		assert.Equal(t, int64(1201), imp.TotalMessages)
+	}
+	assert.Len(t, repo.messages, 500)
+	assert.Empty(t, convSvc.recorded)
+
+	// Running it again resumes after the first batch
+	resumed, err := svc.ImportFile(ctx, cmd, data)
+	assert.NoError(t, err)
+	if assert.NotNil(t, resumed) {
+		assert.Equal(t, imp.ID, resumed.ID)
+		assert.Equal(t, domain.ImportDone, resumed.Status)
+		assert.Equal(t, int64(1201), resumed.ImportedMessages)
+	}
+
+	msgs := repo.sorted()
+	if assert.Len(t, msgs, 1201) {
+		for i, m := range msgs {
+			assert.Equal(t, int64(i+1), m.Sequence)
+			assert.Equal(t, start.Add(time.Duration(i)*time.Minute), m.SentAt)
+			assert.Equal(t, fmt.Sprintf("message %d", i), m.Content)
+			assert.Equal(t, imp.ConversationID, m.ConversationID)
+		}
+		assert.Equal(t, "user-1", msgs[0].SenderID)
+		assert.Equal(t, "user-2", msgs[1].SenderID)
+	}
+
+	// Unread counts take in the whole history
+	assert.Equal(t, map[string]int64{imp.ConversationID: 1201}, convSvc.recorded)
+
+	// One reservation per batch: two in the first run, of which the failed
+	// one is asked again, and the last batch
+	assert.Equal(t, 4, convSvc.reserved)
+
+	// Both runs asked for the same conversation, importer first
+	if assert.Len(t, convSvc.created, 2) {
+		assert.Equal(t, convSvc.created[0], convSvc.created[1])
+		assert.Equal(t, imp.ConversationID, convSvc.created[0].ConversationId)
+		assert.Equal(t, conversationv1.ConversationType_GROUP, convSvc.created[0].Type)
+		assert.Equal(t, []string{"user-1", "user-2"}, convSvc.created[0].ParticipantUserIds)
+	}
+
+	// A finished import is returned as it is
+	again, err := svc.ImportFile(ctx, cmd, data)
+	assert.NoError(t, err)
+	assert.Equal(t, domain.ImportDone, again.Status)
+	assert.Len(t, convSvc.created, 2)
+
+	// History never goes through the outbox
+	repo.MockRepo.AssertNotCalled(t, "InsertOutbox", mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+}
+
+func TestImportSlackThreads(t *testing.T) {
+	ctx := context.Background()
+	export := []byte(`[
+		{"type": "message", "user": "U1", "text": "lunch?", "ts": "100.000000", "thread_ts": "100.000000"},
+		{"type": "message", "user": "U2", "text": "sure", "ts": "160.000000", "thread_ts": "100.000000"},
+		{"type": "message", "user": "U2", "text": "orphan", "ts": "170.000000", "thread_ts": "50.000000"}
+	]`)
+
+	repo := newImportRepo()
+	svc := &Service{repo: repo, tx: repo, convSvc: &importConvClient{}, profiles: knownProfiles("user-1", "user-2"), log: zap.NewNop()}
+
+	_, err := svc.ImportFile(ctx, ImportCommand{
+		Source:    imports.SourceSlack,
+		Name:      "lunch",
+		CreatedBy: "user-1",
+		Mapping:   map[string]string{"U1": "user-1", "U2": "user-2"},
+	}, export)
+	assert.NoError(t, err)
+
+	msgs := repo.sorted()
+	if assert.Len(t, msgs, 3) {
+		assert.Equal(t, msgs[0].ID, msgs[1].ThreadRootID)
+		assert.Equal(t, msgs[0].ID, msgs[1].ReplyToID)
+		assert.Equal(t, int64(1), msgs[0].ReplyCount)
+		// Replies to threads outside the export stand alone
+		assert.Empty(t, msgs[2].ThreadRootID)
+	}
+}
+
+func TestImportValidation(t *testing.T) {
+	ctx := context.Background()
+	chat := []byte("1/1/24, 10:00 - Alice: hi\n1/1/24, 10:01 - Carol: hello\n")
+
+	t.Run("Unmapped senders fail the import", func(t *testing.T) {
+		repo := newImportRepo()
+		svc := &Service{repo: repo, tx: repo, convSvc: &importConvClient{}, profiles: knownProfiles("user-1"), log: zap.NewNop()}
+
+		imp, err := svc.ImportFile(ctx, ImportCommand{
+			Source:    imports.SourceWhatsApp,
+			Name:      "chat",
+			CreatedBy: "user-1",
+			Mapping:   map[string]string{"Alice": "user-1"},
+		}, chat)
+		assert.ErrorIs(t, err, domain.ErrInvalidImport)
+		assert.Contains(t, imp.FailureReason, "Carol")
+		assert.Empty(t, repo.messages)
+	})
+
+	t.Run("Mapped users must exist", func(t *testing.T) {
+		repo := newImportRepo()
+		svc := &Service{repo: repo, tx: repo, convSvc: &importConvClient{}, profiles: knownProfiles("user-1"), log: zap.NewNop()}
+
+		imp, err := svc.ImportFile(ctx, ImportCommand{
+			Source:    imports.SourceWhatsApp,
+			Name:      "chat",
+			CreatedBy: "user-1",
+			Mapping:   map[string]string{"Alice": "user-1", "Carol": "user-9"},
+		}, chat)
+		assert.ErrorIs(t, err, domain.ErrInvalidImport)
+		assert.Contains(t, imp.FailureReason, "user-9")
+	})
+
+	t.Run("Only import admins may use the API", func(t *testing.T) {
+		media := new(MockMediaClient)
+		svc := &Service{media: media, opts: Options{ImportAdmins: []string{"admin-1"}}}
+
+		_, err := svc.RequestImport(ctx, ImportCommand{Source: imports.SourceSlack, Name: "x", CreatedBy: "user-1", AttachmentID: "a1"})
+		assert.ErrorIs(t, err, domain.ErrImportForbidden)
+		media.AssertNotCalled(t, "GetAttachments", mock.Anything, mock.Anything)
+	})
+
+	t.Run("Uploads must belong to the importer", func(t *testing.T) {
+		media := new(MockMediaClient)
+		media.On("GetAttachments", ctx, &mediav1.GetAttachmentsRequest{AttachmentIds: []string{"a1"}}).Return(&mediav1.GetAttachmentsResponse{
+			Attachments: []*mediav1.Attachment{{AttachmentId: "a1", OwnerUserId: "user-2"}},
+		}, nil).Once()
+		svc := &Service{media: media, opts: Options{ImportAdmins: []string{"admin-1"}}}
+
+		_, err := svc.RequestImport(ctx, ImportCommand{
+			Source:       imports.SourceSlack,
+			Name:         "x",
+			CreatedBy:    "admin-1",
+			Mapping:      map[string]string{"U1": "admin-1"},
+			AttachmentID: "a1",
+		})
+		assert.ErrorIs(t, err, domain.ErrInvalidAttachment)
+		media.AssertExpectations(t)
+	})
+}
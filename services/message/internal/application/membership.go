@@ -0,0 +1,171 @@
+package application
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"time"
+
+	conversationv1 "github.com/SARVESHVARADKAR123/RealChat/contracts/gen/go/conversation/v1"
+	"github.com/SARVESHVARADKAR123/RealChat/services/message/internal/domain"
+	"go.uber.org/zap"
+)
+
+// requireParticipant fetches the conversation and fails with
+// domain.ErrNotParticipant unless userID is one of its participants.
+func (s *Service) requireParticipant(
+	ctx context.Context,
+	conversationID string,
+	userID string,
+) (*conversationv1.Conversation, error) {
+
+	conv, err := s.conversation(ctx, conversationID, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, pID := range conv.GetParticipantUserIds() {
+		if pID == userID {
+			return conv, nil
+		}
+	}
+
+	return nil, domain.ErrNotParticipant
+}
+
+// conversation returns the conversation from the local projection when it
+// is there and, for a non-empty userID, lists userID as a member. Otherwise
+// it asks the conversation service, which is authoritative, and backfills
+// the projection with the answer. Members are therefore confirmed locally,
+// and only non-members and conversations the projection hasn't seen yet
+// cost a call.
+func (s *Service) conversation(
+	ctx context.Context,
+	conversationID string,
+	userID string,
+) (*conversationv1.Conversation, error) {
+
+	info, err := s.repo.GetConversationInfo(ctx, nil, conversationID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load conversation projection: %w", err)
+	}
+	if info != nil {
+		if _, ok := info.Member(userID); ok || userID == "" {
+			return toProtoConversation(info), nil
+		}
+	}
+
+	resp, err := s.convSvc.GetConversation(ctx, &conversationv1.GetConversationRequest{
+		ConversationId: conversationID,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	conv := resp.GetConversation()
+	if conv == nil {
+		conv = &conversationv1.Conversation{ConversationId: conversationID}
+	}
+	// Older responses only list participants at the top level
+	if len(conv.ParticipantUserIds) == 0 {
+		conv.ParticipantUserIds = resp.GetParticipantUserIds()
+	}
+
+	if len(conv.GetParticipantsWithRoles()) > 0 {
+		err := s.tx.WithTx(ctx, func(ctx context.Context, tx *sql.Tx) error {
+			return s.repo.BackfillConversationInfo(ctx, tx, conversationInfo(conv))
+		})
+		if err != nil {
+			// The answer is still good; the next call retries the backfill
+			s.log.Warn("failed to backfill conversation projection",
+				zap.String("conversation_id", conversationID),
+				zap.Error(err),
+			)
+		}
+	}
+
+	return conv, nil
+}
+
+// ApplyConversation records conv, as carried by a conversation event, in the
+// projection. Events without roles predate them and are skipped; the
+// projection backfills those conversations on first use instead.
+func (s *Service) ApplyConversation(ctx context.Context, conv *conversationv1.Conversation) error {
+	if conv.GetConversationId() == "" {
+		return domain.ErrInvalidInput
+	}
+	if len(conv.GetParticipantsWithRoles()) == 0 {
+		return nil
+	}
+
+	return s.tx.WithTx(ctx, func(ctx context.Context, tx *sql.Tx) error {
+		return s.repo.ApplyConversationInfo(ctx, tx, conversationInfo(conv))
+	})
+}
+
+// ApplyMembership records userID joining or leaving conversationID at the
+// given time in the projection. Users join as plain members.
+func (s *Service) ApplyMembership(ctx context.Context, conversationID, userID string, added bool, at time.Time) error {
+	if conversationID == "" || userID == "" {
+		return domain.ErrInvalidInput
+	}
+
+	return s.tx.WithTx(ctx, func(ctx context.Context, tx *sql.Tx) error {
+		if added {
+			return s.repo.AddMember(ctx, tx, conversationID, domain.Member{UserID: userID, JoinedAt: &at})
+		}
+		return s.repo.RemoveMember(ctx, tx, conversationID, userID)
+	})
+}
+
+func conversationInfo(conv *conversationv1.Conversation) *domain.ConversationInfo {
+	info := &domain.ConversationInfo{
+		ID:         conv.GetConversationId(),
+		Group:      conv.GetType() == conversationv1.ConversationType_GROUP,
+		MessageTTL: time.Duration(conv.GetMessageTtlSeconds()) * time.Second,
+	}
+	for _, p := range conv.GetParticipantsWithRoles() {
+		info.Members = append(info.Members, domain.Member{
+			UserID: p.UserId,
+			Admin:  p.Role == conversationv1.ParticipantRole_ADMIN,
+		})
+	}
+	return info
+}
+
+func toProtoConversation(info *domain.ConversationInfo) *conversationv1.Conversation {
+	conv := &conversationv1.Conversation{
+		ConversationId:    info.ID,
+		Type:              conversationv1.ConversationType_DIRECT,
+		MessageTtlSeconds: int64(info.MessageTTL / time.Second),
+	}
+	if info.Group {
+		conv.Type = conversationv1.ConversationType_GROUP
+	}
+	for _, m := range info.Members {
+		role := conversationv1.ParticipantRole_MEMBER
+		if m.Admin {
+			role = conversationv1.ParticipantRole_ADMIN
+		}
+		conv.ParticipantUserIds = append(conv.ParticipantUserIds, m.UserID)
+		conv.ParticipantsWithRoles = append(conv.ParticipantsWithRoles, &conversationv1.Participant{
+			UserId: m.UserID,
+			Role:   role,
+		})
+	}
+	return conv
+}
+
+// hasAdminRights reports whether userID may act on behalf of the whole
+// conversation: an admin of a group, or either participant of a direct one.
+func hasAdminRights(conv *conversationv1.Conversation, userID string) bool {
+	if conv.GetType() == conversationv1.ConversationType_DIRECT {
+		return true
+	}
+	for _, p := range conv.GetParticipantsWithRoles() {
+		if p.UserId == userID && p.Role == conversationv1.ParticipantRole_ADMIN {
+			return true
+		}
+	}
+	return false
+}
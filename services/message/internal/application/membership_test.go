@@ -0,0 +1,91 @@
+package application
+
+import (
+	"context"
+	"database/sql"
+	"testing"
+
+	conversationv1 "github.com/SARVESHVARADKAR123/RealChat/contracts/gen/go/conversation/v1"
+	"github.com/SARVESHVARADKAR123/RealChat/services/message/internal/domain"
+	"github.com/SARVESHVARADKAR123/RealChat/services/message/internal/repository"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"go.uber.org/zap"
+)
+
+// projectionRepo serves a fixed conversation projection in front of
+// another repo and records backfills.
+type projectionRepo struct {
+	repository.Repository
+	info       *domain.ConversationInfo
+	backfilled []*domain.ConversationInfo
+}
+
+func (r *projectionRepo) GetConversationInfo(ctx context.Context, tx *sql.Tx, conversationID string) (*domain.ConversationInfo, error) {
+	if r.info == nil || r.info.ID != conversationID {
+		return nil, nil
+	}
+	return r.info, nil
+}
+
+func (r *projectionRepo) BackfillConversationInfo(ctx context.Context, tx *sql.Tx, info *domain.ConversationInfo) error {
+	r.backfilled = append(r.backfilled, info)
+	return nil
+}
+
+func TestRequireParticipant(t *testing.T) {
+	ctx := context.Background()
+	projected := &domain.ConversationInfo{
+		ID:      "conv-1",
+		Group:   true,
+		Members: []domain.Member{{UserID: "user-1", Admin: true}, {UserID: "user-2"}},
+	}
+	remote := &conversationv1.GetConversationResponse{
+		Conversation: &conversationv1.Conversation{
+			ConversationId:     "conv-1",
+			Type:               conversationv1.ConversationType_GROUP,
+			ParticipantUserIds: []string{"user-1", "user-3"},
+			ParticipantsWithRoles: []*conversationv1.Participant{
+				{UserId: "user-1", Role: conversationv1.ParticipantRole_ADMIN},
+				{UserId: "user-3", Role: conversationv1.ParticipantRole_MEMBER},
+			},
+		},
+	}
+
+	t.Run("Projected members are confirmed locally", func(t *testing.T) {
+		convSvc := new(MockConvClient)
+		svc := &Service{repo: &projectionRepo{Repository: new(MockRepo), info: projected}, tx: new(MockTransactor), convSvc: convSvc, log: zap.NewNop()}
+
+		conv, err := svc.requireParticipant(ctx, "conv-1", "user-1")
+		assert.NoError(t, err)
+		assert.True(t, hasAdminRights(conv, "user-1"))
+		assert.False(t, hasAdminRights(conv, "user-2"))
+		convSvc.AssertNotCalled(t, "GetConversation", mock.Anything, mock.Anything)
+	})
+
+	t.Run("Members missing locally are checked remotely and backfilled", func(t *testing.T) {
+		convSvc := new(MockConvClient)
+		repo := &projectionRepo{Repository: new(MockRepo), info: projected}
+		svc := &Service{repo: repo, tx: new(MockTransactor), convSvc: convSvc, log: zap.NewNop()}
+		convSvc.On("GetConversation", ctx, mock.Anything).Return(remote, nil).Once()
+
+		_, err := svc.requireParticipant(ctx, "conv-1", "user-3")
+		assert.NoError(t, err)
+		if assert.Len(t, repo.backfilled, 1) {
+			m, ok := repo.backfilled[0].Member("user-3")
+			assert.True(t, ok)
+			assert.False(t, m.Admin)
+		}
+		convSvc.AssertExpectations(t)
+	})
+
+	t.Run("Non-members are rejected", func(t *testing.T) {
+		convSvc := new(MockConvClient)
+		svc := &Service{repo: &projectionRepo{Repository: new(MockRepo), info: projected}, tx: new(MockTransactor), convSvc: convSvc, log: zap.NewNop()}
+		convSvc.On("GetConversation", ctx, mock.Anything).Return(remote, nil).Once()
+
+		_, err := svc.requireParticipant(ctx, "conv-1", "user-9")
+		assert.ErrorIs(t, err, domain.ErrNotParticipant)
+		convSvc.AssertExpectations(t)
+	})
+}
@@ -0,0 +1,31 @@
+package application
+
+import (
+	"context"
+
+	conversationv1 "github.com/SARVESHVARADKAR123/RealChat/contracts/gen/go/conversation/v1"
+)
+
+// GetUnreadMentionCounts returns, per conversation of userID, how many
+// messages after their last read sequence mention them. Conversations
+// without unread mentions are left out.
+func (s *Service) GetUnreadMentionCounts(ctx context.Context, userID string) (map[string]int64, error) {
+	resp, err := s.convSvc.ListConversations(ctx, &conversationv1.ListConversationsRequest{
+		UserId: userID,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	lastRead := make(map[string]int64, len(resp.Conversations))
+	for _, c := range resp.Conversations {
+		for _, p := range c.ParticipantsWithRoles {
+			if p.UserId == userID {
+				lastRead[c.ConversationId] = p.LastReadSequence
+				break
+			}
+		}
+	}
+
+	return s.repo.CountUnreadMentions(ctx, userID, lastRead)
+}
@@ -0,0 +1,171 @@
+package application
+
+import (
+	"context"
+	"database/sql"
+	"testing"
+
+	conversationv1 "github.com/SARVESHVARADKAR123/RealChat/contracts/gen/go/conversation/v1"
+	messagev1 "github.com/SARVESHVARADKAR123/RealChat/contracts/gen/go/message/v1"
+	sharedv1 "github.com/SARVESHVARADKAR123/RealChat/contracts/gen/go/shared/v1"
+	"github.com/SARVESHVARADKAR123/RealChat/services/message/internal/domain"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"go.uber.org/zap"
+	"google.golang.org/protobuf/proto"
+)
+
+// mentionRepo keeps messages and their mentions in memory and records the
+// MessageEditedEvents written to the outbox.
+type mentionRepo struct {
+	*threadRepo
+	mentions map[string][]string
+	edits    []*messagev1.MessageEditedEvent
+}
+
+func (r *mentionRepo) ListMessageAttachments(ctx context.Context, tx *sql.Tx, ids []string) (map[string][]domain.Attachment, error) {
+	return map[string][]domain.Attachment{}, nil
+}
+
+func (r *mentionRepo) InsertMessageRevision(ctx context.Context, tx *sql.Tx, messageID, content, metadata string) error {
+	return nil
+}
+
+func (r *mentionRepo) UpdateMessageContent(ctx context.Context, tx *sql.Tx, msg *domain.Message) error {
+	*r.find(msg.ID) = *msg
+	return nil
+}
+
+func (r *mentionRepo) InsertMentions(ctx context.Context, tx *sql.Tx, msg *domain.Message) error {
+	r.mentions[msg.ID] = msg.Mentions
+	return nil
+}
+
+func (r *mentionRepo) ReplaceMentions(ctx context.Context, tx *sql.Tx, msg *domain.Message) ([]string, error) {
+	before := make(map[string]bool)
+	for _, id := range r.mentions[msg.ID] {
+		before[id] = true
+	}
+	var added []string
+	for _, id := range msg.Mentions {
+		if !before[id] {
+			added = append(added, id)
+		}
+	}
+	r.mentions[msg.ID] = msg.Mentions
+	return added, nil
+}
+
+func (r *mentionRepo) InsertOutbox(ctx context.Context, tx *sql.Tx, aggregateType, aggregateID, eventType string, payload []byte) error {
+	if eventType != "MESSAGE_EDITED" {
+		return nil
+	}
+	var env sharedv1.EventEnvelope
+	if err := proto.Unmarshal(payload, &env); err != nil {
+		return err
+	}
+	var event messagev1.MessageEditedEvent
+	if err := proto.Unmarshal(env.GetPayload(), &event); err != nil {
+		return err
+	}
+	r.edits = append(r.edits, &event)
+	return nil
+}
+
+// MockMentionRepo adds the unread mention counts.
+type MockMentionRepo struct {
+	MockRepo
+}
+
+func (m *MockMentionRepo) CountUnreadMentions(ctx context.Context, userID string, lastRead map[string]int64) (map[string]int64, error) {
+	args := m.Called(ctx, userID, lastRead)
+	return args.Get(0).(map[string]int64), args.Error(1)
+}
+
+func TestMentions(t *testing.T) {
+	participants := []string{"alice", "bob", "carol"}
+
+	t.Run("Parsed from content", func(t *testing.T) {
+		m := domain.ParseMentions("hi @bob and @dave, also mail@carol and @bob again", "")
+		assert.Equal(t, []string{"bob", "dave"}, m.UserIDs)
+		assert.Equal(t, []string{"bob"}, m.Resolve("alice", participants, false))
+	})
+
+	t.Run("Metadata overrides content", func(t *testing.T) {
+		m := domain.ParseMentions("hi @bob", `{"mentions":["carol"]}`)
+		assert.Equal(t, []string{"carol"}, m.Resolve("alice", participants, false))
+	})
+
+	t.Run("All needs admin rights", func(t *testing.T) {
+		m := domain.ParseMentions("@all standup", "")
+		assert.True(t, m.All)
+		assert.Empty(t, m.Resolve("alice", participants, false))
+		assert.Equal(t, []string{"bob", "carol"}, m.Resolve("alice", participants, true))
+	})
+}
+
+func TestGetUnreadMentionCounts(t *testing.T) {
+	ctx := context.Background()
+	repo := new(MockMentionRepo)
+	convSvc := new(MockConvClient)
+	svc := &Service{repo: repo, tx: new(MockTransactor), convSvc: convSvc}
+
+	convSvc.On("ListConversations", ctx, mock.Anything).Return(&conversationv1.ListConversationsResponse{
+		Conversations: []*conversationv1.Conversation{
+			{ConversationId: "conv-1", ParticipantsWithRoles: []*conversationv1.Participant{
+				{UserId: "user-2", LastReadSequence: 40},
+				{UserId: "user-1", LastReadSequence: 12},
+			}},
+			{ConversationId: "conv-2", ParticipantsWithRoles: []*conversationv1.Participant{
+				{UserId: "user-1"},
+			}},
+		},
+	}, nil).Once()
+	repo.On("CountUnreadMentions", ctx, "user-1", map[string]int64{"conv-1": 12, "conv-2": 0}).
+		Return(map[string]int64{"conv-2": 3}, nil).Once()
+
+	counts, err := svc.GetUnreadMentionCounts(ctx, "user-1")
+	assert.NoError(t, err)
+	assert.Equal(t, map[string]int64{"conv-2": 3}, counts)
+	repo.AssertExpectations(t)
+}
+
+func TestEditMessage_Mentions(t *testing.T) {
+	ctx := context.Background()
+	repo := &mentionRepo{threadRepo: newThreadRepo(), mentions: map[string][]string{}}
+	repo.info = &domain.ConversationInfo{ID: "conv-1", Group: true, Members: []domain.Member{
+		{UserID: "user-1"}, {UserID: "user-2"}, {UserID: "user-3"},
+	}}
+	svc := &Service{repo: repo, tx: new(MockTransactor), convSvc: &slowConvClient{}, log: zap.NewNop()}
+
+	msg, err := svc.SendMessage(ctx, SendMessageCommand{
+		ConversationID: "conv-1",
+		UserID:         "user-1",
+		ClientMsgID:    "k1",
+		Content:        "hi @user-2",
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	edit := func(content string) {
+		t.Helper()
+		if _, err := svc.EditMessage(ctx, EditMessageCommand{
+			ConversationID: "conv-1",
+			MessageID:      msg.ID,
+			RequesterID:    "user-1",
+			Content:        content,
+		}); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	// Only the newly mentioned user is notified
+	edit("hi @user-2 and @user-3")
+	assert.Equal(t, []string{"user-2", "user-3"}, repo.mentions[msg.ID])
+	assert.Equal(t, []string{"user-3"}, repo.edits[0].GetMentionedUserIds())
+
+	// Dropped mentions are removed, and nobody is notified again
+	edit("just @user-3, @nobody")
+	assert.Equal(t, []string{"user-3"}, repo.mentions[msg.ID])
+	assert.Empty(t, repo.edits[1].GetMentionedUserIds())
+}
@@ -0,0 +1,133 @@
+package application
+
+import (
+	"context"
+	"database/sql"
+	"time"
+
+	conversationv1 "github.com/SARVESHVARADKAR123/RealChat/contracts/gen/go/conversation/v1"
+	"github.com/SARVESHVARADKAR123/RealChat/services/message/internal/domain"
+	"github.com/SARVESHVARADKAR123/RealChat/services/message/internal/repository"
+	"github.com/stretchr/testify/mock"
+	"google.golang.org/grpc"
+)
+
+// MockRepo scripts the repository calls most features share: loading and
+// writing messages, their attachments and mentions, and the outbox. Each
+// feature's tests embed it in a mock of their own for the rest. Like
+// MockConvClient it embeds the interface, so a call nothing implements
+// panics instead of reaching a default.
+type MockRepo struct {
+	mock.Mock
+	repository.Repository
+}
+
+func (m *MockRepo) InsertMessage(ctx context.Context, tx *sql.Tx, msg *domain.Message) error {
+	return m.Called(ctx, tx, msg).Error(0)
+}
+func (m *MockRepo) GetMessage(ctx context.Context, tx *sql.Tx, messageID string) (*domain.Message, error) {
+	args := m.Called(ctx, tx, messageID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*domain.Message), args.Error(1)
+}
+func (m *MockRepo) GetMessageForUpdate(ctx context.Context, tx *sql.Tx, messageID string) (*domain.Message, error) {
+	args := m.Called(ctx, tx, messageID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*domain.Message), args.Error(1)
+}
+func (m *MockRepo) ListMessageAttachments(ctx context.Context, tx *sql.Tx, messageIDs []string) (map[string][]domain.Attachment, error) {
+	args := m.Called(ctx, tx, messageIDs)
+	return args.Get(0).(map[string][]domain.Attachment), args.Error(1)
+}
+func (m *MockRepo) InsertMentions(ctx context.Context, tx *sql.Tx, msg *domain.Message) error {
+	return m.Called(ctx, tx, msg).Error(0)
+}
+func (m *MockRepo) InsertOutbox(ctx context.Context, tx *sql.Tx, aggregateType, aggregateID, eventType string, payload []byte) error {
+	return m.Called(ctx, tx, aggregateType, aggregateID, eventType, payload).Error(0)
+}
+
+// The plumbing behind a send answers quietly: every key is new, sequence
+// blocks start empty and nothing is projected, so sends reserve sequences
+// and look conversations up through the conversation service.
+func (m *MockRepo) TryInsertIdempotency(ctx context.Context, tx *sql.Tx, key, userID, conversationID string, expiresAt time.Time) (bool, error) {
+	return true, nil
+}
+func (m *MockRepo) GetIdempotencyForUpdate(ctx context.Context, tx *sql.Tx, key, userID, conversationID string) ([]byte, error) {
+	return nil, nil
+}
+func (m *MockRepo) GetIdempotencyResponse(ctx context.Context, key, userID, conversationID string) ([]byte, error) {
+	return nil, nil
+}
+func (m *MockRepo) UpdateIdempotencyResponse(ctx context.Context, tx *sql.Tx, key, userID, conversationID string, payload []byte) error {
+	return nil
+}
+func (m *MockRepo) GetSequenceBlockForUpdate(ctx context.Context, tx *sql.Tx, conversationID string) (*domain.SequenceBlock, error) {
+	return &domain.SequenceBlock{ConversationID: conversationID}, nil
+}
+func (m *MockRepo) SaveSequenceBlock(ctx context.Context, tx *sql.Tx, b *domain.SequenceBlock) error {
+	return nil
+}
+func (m *MockRepo) GetConversationInfo(ctx context.Context, tx *sql.Tx, conversationID string) (*domain.ConversationInfo, error) {
+	return nil, nil
+}
+func (m *MockRepo) ApplyConversationInfo(ctx context.Context, tx *sql.Tx, info *domain.ConversationInfo) error {
+	return nil
+}
+func (m *MockRepo) BackfillConversationInfo(ctx context.Context, tx *sql.Tx, info *domain.ConversationInfo) error {
+	return nil
+}
+func (m *MockRepo) AddMember(ctx context.Context, tx *sql.Tx, conversationID string, member domain.Member) error {
+	return nil
+}
+func (m *MockRepo) RemoveMember(ctx context.Context, tx *sql.Tx, conversationID, userID string) error {
+	return nil
+}
+
+// MockConvClient is a mock for the ConversationApiClient interface
+type MockConvClient struct {
+	mock.Mock
+	conversationv1.ConversationApiClient
+}
+
+func (m *MockConvClient) GetConversation(ctx context.Context, req *conversationv1.GetConversationRequest, opts ...grpc.CallOption) (*conversationv1.GetConversationResponse, error) {
+	args := m.Called(ctx, req)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*conversationv1.GetConversationResponse), args.Error(1)
+}
+
+func (m *MockConvClient) ListConversations(ctx context.Context, req *conversationv1.ListConversationsRequest, opts ...grpc.CallOption) (*conversationv1.ListConversationsResponse, error) {
+	args := m.Called(ctx, req)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*conversationv1.ListConversationsResponse), args.Error(1)
+}
+
+func (m *MockConvClient) ReserveSequences(ctx context.Context, req *conversationv1.ReserveSequencesRequest, opts ...grpc.CallOption) (*conversationv1.ReserveSequencesResponse, error) {
+	args := m.Called(ctx, req)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*conversationv1.ReserveSequencesResponse), args.Error(1)
+}
+
+func (m *MockConvClient) CreateConversation(ctx context.Context, req *conversationv1.CreateConversationRequest, opts ...grpc.CallOption) (*conversationv1.CreateConversationResponse, error) {
+	args := m.Called(ctx, req)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*conversationv1.CreateConversationResponse), args.Error(1)
+}
+
+// MockTransactor is a mock for the Transactor interface
+type MockTransactor struct{}
+
+func (m *MockTransactor) WithTx(ctx context.Context, fn func(ctx context.Context, tx *sql.Tx) error) error {
+	return fn(ctx, nil)
+}
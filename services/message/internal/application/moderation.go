@@ -0,0 +1,276 @@
+package application
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"slices"
+	"time"
+
+	"github.com/SARVESHVARADKAR123/RealChat/services/message/internal/domain"
+	"github.com/SARVESHVARADKAR123/RealChat/services/message/internal/moderation"
+	"github.com/SARVESHVARADKAR123/RealChat/services/message/internal/observability"
+	"github.com/google/uuid"
+)
+
+// Moderator screens messages before they are sent. *moderation.Chain
+// implements it.
+type Moderator interface {
+	Screen(ctx context.Context, m moderation.Message) (moderation.Result, error)
+}
+
+// moderate runs cmd through the moderation chain and applies redactions to
+// it. It returns nil when the send isn't screened.
+func (s *Service) moderate(ctx context.Context, cmd *SendMessageCommand) (*moderation.Result, error) {
+	if cmd.skipModeration {
+		return nil, nil
+	}
+
+	res, err := s.screen(ctx, moderation.Message{
+		ConversationID: cmd.ConversationID,
+		SenderID:       cmd.UserID,
+		Type:           cmd.Type,
+		Content:        cmd.Content,
+		Metadata:       cmd.Metadata,
+	})
+	if err != nil || res == nil {
+		return nil, err
+	}
+
+	if res.Content != cmd.Content {
+		cmd.Content = res.Content
+		// Hooks may redact to anything, so check the result again
+		if err := s.validateSend(cmd); err != nil {
+			return nil, err
+		}
+	}
+	return res, nil
+}
+
+// screen runs m through the moderation chain. It returns nil when m isn't
+// screened. Encrypted messages can't be read, so they never are.
+func (s *Service) screen(ctx context.Context, m moderation.Message) (*moderation.Result, error) {
+	if s.opts.Moderation == nil || m.Content == "" || m.Type == domain.TypeEncrypted {
+		return nil, nil
+	}
+
+	res, err := s.opts.Moderation.Screen(ctx, m)
+	if err != nil {
+		return nil, fmt.Errorf("failed to moderate message: %w", err)
+	}
+	observability.ModerationDecisionsTotal.WithLabelValues(string(res.Action)).Inc()
+	return &res, nil
+}
+
+// recordModeration adds the screening of a message by senderID to the audit
+// trail, with the message or held message it led to.
+func (s *Service) recordModeration(
+	ctx context.Context,
+	tx *sql.Tx,
+	conversationID, senderID string,
+	res *moderation.Result,
+	messageID, heldID string,
+) error {
+
+	rec := &domain.ModerationRecord{
+		ID:             uuid.NewString(),
+		ConversationID: conversationID,
+		SenderID:       senderID,
+		MessageID:      messageID,
+		HeldID:         heldID,
+		Action:         string(res.Action),
+		Reason:         res.Reason,
+		CreatedAt:      time.Now().UTC(),
+	}
+	for _, v := range res.Verdicts {
+		rec.Verdicts = append(rec.Verdicts, domain.ModerationVerdict{
+			Filter: v.Filter,
+			Action: string(v.Action),
+			Reason: v.Reason,
+		})
+	}
+	if err := s.repo.InsertModerationRecord(ctx, tx, rec); err != nil {
+		return fmt.Errorf("failed to record moderation verdict: %w", err)
+	}
+	return nil
+}
+
+// holdMessage queues cmd for review. A retry of a send that was already
+// held finds the queued message; one that was rejected on review is
+// rejected again.
+func (s *Service) holdMessage(
+	ctx context.Context,
+	tx *sql.Tx,
+	cmd SendMessageCommand,
+	res *moderation.Result,
+) (*domain.HeldMessage, error) {
+
+	held, err := s.repo.HoldMessage(ctx, tx, &domain.HeldMessage{
+		ID:             uuid.NewString(),
+		ConversationID: cmd.ConversationID,
+		SenderID:       cmd.UserID,
+		ClientMsgID:    cmd.ClientMsgID,
+		Type:           cmd.Type,
+		Content:        cmd.Content,
+		Metadata:       cmd.Metadata,
+		ReplyToID:      cmd.ReplyToID,
+		AttachmentIDs:  cmd.AttachmentIDs,
+		Reason:         res.Reason,
+		CreatedAt:      time.Now().UTC(),
+		Status:         domain.HeldPending,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to hold message: %w", err)
+	}
+	if held.Status == domain.HeldRejected {
+		return nil, fmt.Errorf("%w: %s", domain.ErrMessageRejected, held.Reason)
+	}
+
+	if err := s.recordModeration(ctx, tx, cmd.ConversationID, cmd.UserID, res, "", held.ID); err != nil {
+		return nil, err
+	}
+	return held, nil
+}
+
+type ListHeldCommand struct {
+	ModeratorID string
+	// Status defaults to pending.
+	Status    domain.HeldStatus
+	PageSize  int
+	PageToken string
+}
+
+// ListHeldMessages pages the moderation queue, oldest first. The returned
+// token is empty on the last page.
+func (s *Service) ListHeldMessages(ctx context.Context, cmd ListHeldCommand) ([]*domain.HeldMessage, string, error) {
+	if !slices.Contains(s.opts.Moderators, cmd.ModeratorID) {
+		return nil, "", domain.ErrModerationForbidden
+	}
+
+	status := cmd.Status
+	switch status {
+	case "":
+		status = domain.HeldPending
+	case domain.HeldPending, domain.HeldApproved, domain.HeldRejected:
+	default:
+		return nil, "", domain.ErrInvalidInput
+	}
+
+	pageSize := cmd.PageSize
+	if pageSize <= 0 || pageSize > 100 {
+		pageSize = 50
+	}
+
+	var (
+		afterCreatedAt time.Time
+		afterID        string
+	)
+	if cmd.PageToken != "" {
+		t, id, err := decodeSearchToken(cmd.PageToken)
+		if err != nil {
+			return nil, "", domain.ErrInvalidInput
+		}
+		afterCreatedAt, afterID = t, id
+	}
+
+	held, err := s.repo.ListHeldMessages(ctx, status, afterCreatedAt, afterID, pageSize+1)
+	if err != nil {
+		return nil, "", err
+	}
+
+	var next string
+	if len(held) > pageSize {
+		held = held[:pageSize]
+		last := held[len(held)-1]
+		next = encodeSearchToken(last.CreatedAt, last.ID)
+	}
+	return held, next, nil
+}
+
+type ReviewCommand struct {
+	HeldID     string
+	ReviewerID string
+	Approve    bool
+	Note       string
+}
+
+// ReviewHeldMessage approves or rejects a held message. Approving sends it
+// as its sender, unscreened, under the original idempotency key; if that
+// send fails, approving again retries it. The message is returned when one
+// was sent.
+func (s *Service) ReviewHeldMessage(ctx context.Context, cmd ReviewCommand) (*domain.HeldMessage, *domain.Message, error) {
+	if !slices.Contains(s.opts.Moderators, cmd.ReviewerID) {
+		return nil, nil, domain.ErrModerationForbidden
+	}
+	if cmd.HeldID == "" {
+		return nil, nil, domain.ErrInvalidInput
+	}
+
+	var held *domain.HeldMessage
+	err := s.tx.WithTx(ctx, func(ctx context.Context, tx *sql.Tx) error {
+		h, err := s.repo.GetHeldMessageForUpdate(ctx, tx, cmd.HeldID)
+		if err != nil {
+			return err
+		}
+		held = h
+
+		// An approval whose send failed is retried as is
+		if cmd.Approve && h.Status == domain.HeldApproved && h.MessageID == "" {
+			return nil
+		}
+
+		if err := h.Review(cmd.ReviewerID, cmd.Approve, cmd.Note, time.Now().UTC()); err != nil {
+			return err
+		}
+		if err := s.repo.UpdateHeldMessage(ctx, tx, h); err != nil {
+			return fmt.Errorf("failed to update held message: %w", err)
+		}
+
+		action := moderation.Reject
+		if cmd.Approve {
+			action = moderation.Allow
+		}
+		if err := s.repo.InsertModerationRecord(ctx, tx, &domain.ModerationRecord{
+			ID:             uuid.NewString(),
+			ConversationID: h.ConversationID,
+			SenderID:       h.SenderID,
+			HeldID:         h.ID,
+			Action:         string(action),
+			Reason:         cmd.Note,
+			ReviewerID:     cmd.ReviewerID,
+			CreatedAt:      *h.ReviewedAt,
+		}); err != nil {
+			return fmt.Errorf("failed to record moderation review: %w", err)
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, nil, err
+	}
+
+	if held.Status != domain.HeldApproved {
+		return held, nil, nil
+	}
+
+	msg, err := s.SendMessage(ctx, SendMessageCommand{
+		ConversationID: held.ConversationID,
+		UserID:         held.SenderID,
+		ClientMsgID:    held.ClientMsgID,
+		Type:           held.Type,
+		Content:        held.Content,
+		Metadata:       held.Metadata,
+		ReplyToID:      held.ReplyToID,
+		AttachmentIDs:  held.AttachmentIDs,
+		KeepDraft:      true,
+		skipModeration: true,
+	})
+	if err != nil {
+		return held, nil, err
+	}
+
+	held.MessageID = msg.ID
+	if err := s.repo.UpdateHeldMessage(ctx, nil, held); err != nil {
+		return held, msg, fmt.Errorf("failed to update held message: %w", err)
+	}
+	return held, msg, nil
+}
@@ -0,0 +1,504 @@
+package application
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/SARVESHVARADKAR123/RealChat/services/message/internal/domain"
+	"github.com/SARVESHVARADKAR123/RealChat/services/message/internal/moderation"
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/zap"
+)
+
+// moderationRepo sends messages like benchRepo and keeps what it is given
+// of messages and their ciphertexts, the moderation queue and the audit
+// trail in memory.
+type moderationRepo struct {
+	benchRepo
+	messages    []*domain.Message
+	ciphertexts map[string][]domain.DeviceCiphertext
+	held        map[string]*domain.HeldMessage
+	audit       []*domain.ModerationRecord
+}
+
+func newModerationRepo() *moderationRepo {
+	return &moderationRepo{
+		benchRepo: benchRepo{
+			blockRepo: blockRepo{MockRepo: new(MockRepo), blocks: map[string]domain.SequenceBlock{}},
+			info:      &domain.ConversationInfo{ID: "conv-1", Group: true, Members: []domain.Member{{UserID: "user-1"}}},
+		},
+		ciphertexts: map[string][]domain.DeviceCiphertext{},
+		held:        map[string]*domain.HeldMessage{},
+	}
+}
+
+func (r *moderationRepo) InsertMessage(ctx context.Context, tx *sql.Tx, msg *domain.Message) error {
+	r.messages = append(r.messages, msg)
+	return nil
+}
+
+func (r *moderationRepo) InsertMessageCiphertexts(ctx context.Context, tx *sql.Tx, messageID string, cts []domain.DeviceCiphertext) error {
+	r.ciphertexts[messageID] = cts
+	return nil
+}
+
+func (r *moderationRepo) GetMessage(ctx context.Context, tx *sql.Tx, id string) (*domain.Message, error) {
+	for _, m := range r.messages {
+		if m.ID == id {
+			cp := *m
+			return &cp, nil
+		}
+	}
+	return nil, domain.ErrMessageNotFound
+}
+
+func (r *moderationRepo) GetMessageForUpdate(ctx context.Context, tx *sql.Tx, id string) (*domain.Message, error) {
+	return r.GetMessage(ctx, tx, id)
+}
+
+func (r *moderationRepo) ListMessageAttachments(ctx context.Context, tx *sql.Tx, ids []string) (map[string][]domain.Attachment, error) {
+	return map[string][]domain.Attachment{}, nil
+}
+
+func (r *moderationRepo) InsertMessageRevision(ctx context.Context, tx *sql.Tx, messageID, content, metadata string) error {
+	return nil
+}
+
+func (r *moderationRepo) UpdateMessageContent(ctx context.Context, tx *sql.Tx, msg *domain.Message) error {
+	for i, m := range r.messages {
+		if m.ID == msg.ID {
+			r.messages[i] = msg
+		}
+	}
+	return nil
+}
+
+func (r *moderationRepo) HoldMessage(ctx context.Context, tx *sql.Tx, h *domain.HeldMessage) (*domain.HeldMessage, error) {
+	for _, cur := range r.held {
+		if cur.SenderID == h.SenderID && cur.ConversationID == h.ConversationID && cur.ClientMsgID == h.ClientMsgID {
+			return cur, nil
+		}
+	}
+	r.held[h.ID] = h
+	return h, nil
+}
+
+func (r *moderationRepo) GetHeldMessageForUpdate(ctx context.Context, tx *sql.Tx, id string) (*domain.HeldMessage, error) {
+	h, ok := r.held[id]
+	if !ok {
+		return nil, domain.ErrHeldNotFound
+	}
+	return h, nil
+}
+
+func (r *moderationRepo) UpdateHeldMessage(ctx context.Context, tx *sql.Tx, h *domain.HeldMessage) error {
+	r.held[h.ID] = h
+	return nil
+}
+
+func (r *moderationRepo) InsertModerationRecord(ctx context.Context, tx *sql.Tx, rec *domain.ModerationRecord) error {
+	r.audit = append(r.audit, rec)
+	return nil
+}
+
+func newModerationService(t *testing.T, repo *moderationRepo, config string) *Service {
+	chain, err := moderation.Load([]byte(config))
+	if err != nil {
+		t.Fatal(err)
+	}
+	return &Service{
+		repo:    repo,
+		tx:      new(MockTransactor),
+		convSvc: &slowConvClient{},
+		log:     zap.NewNop(),
+		opts:    Options{Moderation: chain, Moderators: []string{"mod-1"}},
+	}
+}
+
+func TestModerationChain(t *testing.T) {
+	ctx := context.Background()
+
+	var hookSaw string
+	hook := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var req struct {
+			Content string `json:"content"`
+		}
+		_ = json.NewDecoder(r.Body).Decode(&req)
+		hookSaw = req.Content
+		if req.Content == "buy now" {
+			fmt.Fprint(w, `{"action":"reject","reason":"advertising"}`)
+			return
+		}
+		fmt.Fprint(w, `{"action":"allow"}`)
+	}))
+	defer hook.Close()
+
+	chain, err := moderation.Load([]byte(`{"filters": [
+		{"type": "blocklist", "words": ["darn"], "action": "redact"},
+		{"type": "spam", "hold_score": 3},
+		{"type": "hook", "url": "` + hook.URL + `"}
+	]}`))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	t.Run("Redactions reach later filters", func(t *testing.T) {
+		res, err := chain.Screen(ctx, moderation.Message{Content: "Darn it"})
+		assert.NoError(t, err)
+		assert.Equal(t, moderation.Redact, res.Action)
+		assert.Equal(t, "**** it", res.Content)
+		assert.Equal(t, "**** it", hookSaw)
+		assert.Len(t, res.Verdicts, 3)
+	})
+
+	t.Run("Spam is held", func(t *testing.T) {
+		res, err := chain.Screen(ctx, moderation.Message{Content: "free stuff https://bit.ly/a https://bit.ly/b"})
+		assert.NoError(t, err)
+		assert.Equal(t, moderation.Hold, res.Action)
+		assert.Contains(t, res.Reason, "shortened links")
+	})
+
+	t.Run("Hook rejects", func(t *testing.T) {
+		res, err := chain.Screen(ctx, moderation.Message{Content: "buy now"})
+		assert.NoError(t, err)
+		assert.Equal(t, moderation.Reject, res.Action)
+		assert.Equal(t, "advertising", res.Reason)
+		assert.Equal(t, "hook", res.Verdicts[2].Filter)
+	})
+
+	t.Run("Rejection stops the chain", func(t *testing.T) {
+		strict, err := moderation.Load([]byte(`{"filters": [
+			{"type": "blocklist", "patterns": ["cheap\\s+pills"]},
+			{"type": "hook", "url": "` + hook.URL + `"}
+		]}`))
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		hookSaw = ""
+		res, err := strict.Screen(ctx, moderation.Message{Content: "CHEAP  pills here"})
+		assert.NoError(t, err)
+		assert.Equal(t, moderation.Reject, res.Action)
+		assert.Len(t, res.Verdicts, 1)
+		assert.Empty(t, hookSaw)
+	})
+
+	t.Run("Unreachable hook takes its on_error action", func(t *testing.T) {
+		down := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			w.WriteHeader(http.StatusBadGateway)
+		}))
+		defer down.Close()
+
+		failOpen, err := moderation.Load([]byte(`{"filters": [{"type": "hook", "url": "` + down.URL + `"}]}`))
+		if err != nil {
+			t.Fatal(err)
+		}
+		res, err := failOpen.Screen(ctx, moderation.Message{Content: "hello"})
+		assert.NoError(t, err)
+		assert.Equal(t, moderation.Allow, res.Action)
+
+		failClosed, err := moderation.Load([]byte(`{"filters": [{"type": "hook", "url": "` + down.URL + `", "on_error": "hold"}]}`))
+		if err != nil {
+			t.Fatal(err)
+		}
+		res, err = failClosed.Screen(ctx, moderation.Message{Content: "hello"})
+		assert.NoError(t, err)
+		assert.Equal(t, moderation.Hold, res.Action)
+		assert.Contains(t, res.Reason, "status 502")
+	})
+
+	t.Run("Invalid configs are refused", func(t *testing.T) {
+		for _, config := range []string{
+			`{"filters": [{"type": "profanity"}]}`,
+			`{"filters": [{"type": "blocklist"}]}`,
+			`{"filters": [{"type": "blocklist", "words": ["x"], "action": "allow"}]}`,
+			`{"filters": [{"type": "blocklist", "patterns": ["("]}]}`,
+			`{"filters": [{"type": "spam"}]}`,
+			`{"filters": [{"type": "hook"}]}`,
+			`{"filters": [{"type": "hook", "url": "http://localhost", "on_error": "redact"}]}`,
+		} {
+			_, err := moderation.Load([]byte(config))
+			assert.Error(t, err, config)
+		}
+	})
+}
+
+func TestSpamScore(t *testing.T) {
+	score, _ := moderation.SpamScore("see https://example.com/docs for details")
+	assert.Zero(t, score)
+
+	score, signals := moderation.SpamScore("WIN WIN WIN WIN WIN NOW!!!!!!!!!")
+	assert.Equal(t, 3.0, score)
+	assert.Equal(t, []string{"all caps", "repeated characters", "repeated words"}, signals)
+}
+
+const moderationConfig = `{"filters": [
+	{"type": "blocklist", "words": ["darn"], "action": "redact"},
+	{"type": "blocklist", "name": "slurs", "words": ["slur"]},
+	{"type": "spam", "hold_score": 3}
+]}`
+
+func TestSendMessage_Moderation(t *testing.T) {
+	ctx := context.Background()
+	send := func(svc *Service, key, content string) (*domain.Message, error) {
+		return svc.SendMessage(ctx, SendMessageCommand{
+			ConversationID: "conv-1",
+			UserID:         "user-1",
+			ClientMsgID:    key,
+			Content:        content,
+		})
+	}
+
+	t.Run("Redacted content is sent and audited", func(t *testing.T) {
+		repo := newModerationRepo()
+		svc := newModerationService(t, repo, moderationConfig)
+
+		msg, err := send(svc, "k1", "darn it")
+		if err != nil {
+			t.Fatal(err)
+		}
+		assert.Equal(t, "**** it", msg.Content)
+		if !assert.Len(t, repo.audit, 1) {
+			return
+		}
+		assert.Equal(t, msg.ID, repo.audit[0].MessageID)
+		assert.Equal(t, "redact", repo.audit[0].Action)
+		assert.Len(t, repo.audit[0].Verdicts, 3)
+	})
+
+	t.Run("Rejected sends are not stored but are audited", func(t *testing.T) {
+		repo := newModerationRepo()
+		svc := newModerationService(t, repo, moderationConfig)
+
+		_, err := send(svc, "k1", "you slur")
+		assert.ErrorIs(t, err, domain.ErrMessageRejected)
+		assert.Empty(t, repo.messages)
+		if !assert.Len(t, repo.audit, 1) {
+			return
+		}
+		assert.Equal(t, "reject", repo.audit[0].Action)
+		assert.Equal(t, "slurs", repo.audit[0].Verdicts[1].Filter)
+	})
+
+	t.Run("Held sends are queued once", func(t *testing.T) {
+		repo := newModerationRepo()
+		svc := newModerationService(t, repo, moderationConfig)
+		spam := "deals https://bit.ly/a https://bit.ly/b"
+
+		_, err := send(svc, "k1", spam)
+		var held *domain.HeldError
+		if !errors.As(err, &held) {
+			t.Fatalf("expected a held message, got %v", err)
+		}
+		assert.Equal(t, domain.HeldPending, held.Held.Status)
+		assert.Equal(t, spam, held.Held.Content)
+		assert.Empty(t, repo.messages)
+
+		// A retry finds the same held message
+		_, err = send(svc, "k1", spam)
+		var again *domain.HeldError
+		if !errors.As(err, &again) {
+			t.Fatalf("expected a held message, got %v", err)
+		}
+		assert.Equal(t, held.Held.ID, again.Held.ID)
+		assert.Len(t, repo.held, 1)
+		assert.Len(t, repo.audit, 2)
+	})
+
+	t.Run("Without a chain nothing is screened", func(t *testing.T) {
+		repo := newModerationRepo()
+		svc := newModerationService(t, repo, moderationConfig)
+		svc.opts.Moderation = nil
+
+		msg, err := send(svc, "k1", "darn it")
+		if err != nil {
+			t.Fatal(err)
+		}
+		assert.Equal(t, "darn it", msg.Content)
+		assert.Empty(t, repo.audit)
+	})
+}
+
+func TestEditMessage_Moderation(t *testing.T) {
+	ctx := context.Background()
+	setup := func(t *testing.T) (*moderationRepo, *Service, *domain.Message) {
+		repo := newModerationRepo()
+		svc := newModerationService(t, repo, moderationConfig)
+		msg, err := svc.SendMessage(ctx, SendMessageCommand{
+			ConversationID: "conv-1",
+			UserID:         "user-1",
+			ClientMsgID:    "k1",
+			Content:        "hi",
+		})
+		if err != nil {
+			t.Fatal(err)
+		}
+		return repo, svc, msg
+	}
+	edit := func(svc *Service, msg *domain.Message, content string) (*domain.Message, error) {
+		return svc.EditMessage(ctx, EditMessageCommand{
+			ConversationID: "conv-1",
+			MessageID:      msg.ID,
+			RequesterID:    "user-1",
+			Content:        content,
+		})
+	}
+
+	t.Run("Redacted edits are applied and audited", func(t *testing.T) {
+		repo, svc, msg := setup(t)
+
+		got, err := edit(svc, msg, "darn it")
+		if err != nil {
+			t.Fatal(err)
+		}
+		assert.Equal(t, "**** it", got.Content)
+		if !assert.Len(t, repo.audit, 2) {
+			return
+		}
+		assert.Equal(t, msg.ID, repo.audit[1].MessageID)
+		assert.Equal(t, "redact", repo.audit[1].Action)
+	})
+
+	t.Run("Rejected edits leave the message and are audited", func(t *testing.T) {
+		repo, svc, msg := setup(t)
+
+		_, err := edit(svc, msg, "you slur")
+		assert.ErrorIs(t, err, domain.ErrMessageRejected)
+		assert.Equal(t, "hi", repo.messages[0].Content)
+		if !assert.Len(t, repo.audit, 2) {
+			return
+		}
+		assert.Equal(t, msg.ID, repo.audit[1].MessageID)
+		assert.Equal(t, "reject", repo.audit[1].Action)
+	})
+
+	t.Run("Edits that would be held are refused", func(t *testing.T) {
+		repo, svc, msg := setup(t)
+
+		_, err := edit(svc, msg, "deals https://bit.ly/a https://bit.ly/b")
+		assert.ErrorIs(t, err, domain.ErrMessageRejected)
+		assert.Equal(t, "hi", repo.messages[0].Content)
+		assert.Empty(t, repo.held)
+		if !assert.Len(t, repo.audit, 2) {
+			return
+		}
+		assert.Equal(t, "hold", repo.audit[1].Action)
+		assert.Empty(t, repo.audit[1].HeldID)
+	})
+
+	t.Run("Edits by others are not screened", func(t *testing.T) {
+		repo, svc, msg := setup(t)
+
+		_, err := svc.EditMessage(ctx, EditMessageCommand{
+			ConversationID: "conv-1",
+			MessageID:      msg.ID,
+			RequesterID:    "user-2",
+			Content:        "you slur",
+		})
+		assert.ErrorIs(t, err, domain.ErrNotSender)
+		assert.Len(t, repo.audit, 1)
+	})
+}
+
+func TestReviewHeldMessage(t *testing.T) {
+	ctx := context.Background()
+	spam := "deals https://bit.ly/a https://bit.ly/b"
+
+	hold := func(svc *Service, key string) *domain.HeldMessage {
+		_, err := svc.SendMessage(ctx, SendMessageCommand{
+			ConversationID: "conv-1",
+			UserID:         "user-1",
+			ClientMsgID:    key,
+			Content:        spam,
+		})
+		var held *domain.HeldError
+		if !errors.As(err, &held) {
+			t.Fatalf("expected a held message, got %v", err)
+		}
+		return held.Held
+	}
+
+	t.Run("Only moderators review", func(t *testing.T) {
+		repo := newModerationRepo()
+		svc := newModerationService(t, repo, moderationConfig)
+		h := hold(svc, "k1")
+
+		_, _, err := svc.ReviewHeldMessage(ctx, ReviewCommand{HeldID: h.ID, ReviewerID: "user-1", Approve: true})
+		assert.ErrorIs(t, err, domain.ErrModerationForbidden)
+		_, _, err = svc.ListHeldMessages(ctx, ListHeldCommand{ModeratorID: "user-1"})
+		assert.ErrorIs(t, err, domain.ErrModerationForbidden)
+	})
+
+	t.Run("Approval sends the message unscreened", func(t *testing.T) {
+		repo := newModerationRepo()
+		svc := newModerationService(t, repo, moderationConfig)
+		h := hold(svc, "k1")
+
+		held, msg, err := svc.ReviewHeldMessage(ctx, ReviewCommand{HeldID: h.ID, ReviewerID: "mod-1", Approve: true, Note: "fine"})
+		if err != nil {
+			t.Fatal(err)
+		}
+		if msg == nil {
+			t.Fatal("approval sent no message")
+		}
+		assert.Equal(t, spam, msg.Content)
+		assert.Equal(t, "user-1", msg.SenderID)
+		assert.Equal(t, domain.HeldApproved, held.Status)
+		assert.Equal(t, msg.ID, held.MessageID)
+		assert.Equal(t, "mod-1", held.ReviewedBy)
+
+		last := repo.audit[len(repo.audit)-1]
+		assert.Equal(t, "allow", last.Action)
+		assert.Equal(t, "mod-1", last.ReviewerID)
+		assert.Equal(t, h.ID, last.HeldID)
+
+		_, _, err = svc.ReviewHeldMessage(ctx, ReviewCommand{HeldID: h.ID, ReviewerID: "mod-1", Approve: true})
+		assert.ErrorIs(t, err, domain.ErrHeldNotPending)
+	})
+
+	t.Run("Rejection is final", func(t *testing.T) {
+		repo := newModerationRepo()
+		svc := newModerationService(t, repo, moderationConfig)
+		h := hold(svc, "k1")
+
+		held, msg, err := svc.ReviewHeldMessage(ctx, ReviewCommand{HeldID: h.ID, ReviewerID: "mod-1", Approve: false})
+		if err != nil {
+			t.Fatal(err)
+		}
+		assert.Nil(t, msg)
+		assert.Equal(t, domain.HeldRejected, held.Status)
+		assert.Empty(t, repo.messages)
+
+		// Retrying the send is rejected rather than held again
+		_, err = svc.SendMessage(ctx, SendMessageCommand{
+			ConversationID: "conv-1",
+			UserID:         "user-1",
+			ClientMsgID:    "k1",
+			Content:        spam,
+		})
+		assert.ErrorIs(t, err, domain.ErrMessageRejected)
+	})
+
+	t.Run("Unknown held messages", func(t *testing.T) {
+		svc := newModerationService(t, newModerationRepo(), moderationConfig)
+		_, _, err := svc.ReviewHeldMessage(ctx, ReviewCommand{HeldID: "missing", ReviewerID: "mod-1", Approve: true})
+		assert.ErrorIs(t, err, domain.ErrHeldNotFound)
+	})
+}
+
+func TestHeldMessageReview(t *testing.T) {
+	now := time.Now().UTC()
+	h := &domain.HeldMessage{ID: "h1", Status: domain.HeldPending}
+
+	assert.NoError(t, h.Review("mod-1", false, "spam", now))
+	assert.Equal(t, domain.HeldRejected, h.Status)
+	assert.Equal(t, "spam", h.ReviewNote)
+	assert.ErrorIs(t, h.Review("mod-2", true, "", now), domain.ErrHeldNotPending)
+}
@@ -0,0 +1,77 @@
+package application
+
+import (
+	"context"
+	"testing"
+
+	"github.com/SARVESHVARADKAR123/RealChat/services/message/internal/domain"
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/zap"
+)
+
+func TestMessageTypeValidation(t *testing.T) {
+	types := domain.DefaultMessageTypes()
+
+	tests := []struct {
+		name        string
+		msgType     string
+		content     string
+		metadata    string
+		attachments int
+		field       string
+	}{
+		{"text", domain.TypeText, "hi", "", 0, ""},
+		{"text with only attachments", domain.TypeText, "", "", 1, ""},
+		{"empty text", domain.TypeText, "", "", 0, "content"},
+		{"unknown metadata field", domain.TypeText, "hi", `{"color":"red"}`, 0, "metadata_json.color"},
+		{"metadata not an object", domain.TypeText, "hi", `[1,2]`, 0, "metadata_json"},
+		{"image without attachment", domain.TypeImage, "caption", "", 0, "attachment_ids"},
+		{"location", domain.TypeLocation, "", `{"latitude":52.5,"longitude":13.4}`, 0, ""},
+		{"location missing longitude", domain.TypeLocation, "", `{"latitude":52.5}`, 0, "metadata_json.longitude"},
+		{"location out of range", domain.TypeLocation, "", `{"latitude":95,"longitude":13.4}`, 0, "metadata_json.latitude"},
+		{"location wrong kind", domain.TypeLocation, "", `{"latitude":"52.5","longitude":13.4}`, 0, "metadata_json.latitude"},
+		{"poll", domain.TypePoll, "Lunch?", `{"options":["pizza","sushi"]}`, 0, ""},
+		{"poll with one option", domain.TypePoll, "Lunch?", `{"options":["pizza"]}`, 0, "metadata_json.options"},
+		{"poll with duplicate options", domain.TypePoll, "Lunch?", `{"options":["pizza","pizza"]}`, 0, "metadata_json.options[1]"},
+		{"system is internal", domain.TypeSystem, "joined", "", 0, "message_type"},
+		{"unknown type", "sticker", "hi", "", 0, "message_type"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := types.Validate(tt.msgType, tt.content, tt.metadata, tt.attachments)
+			if tt.field == "" {
+				assert.NoError(t, err)
+				return
+			}
+
+			var ve *domain.ValidationError
+			if assert.ErrorAs(t, err, &ve) {
+				assert.Equal(t, tt.field, ve.Field)
+			}
+			assert.ErrorIs(t, err, domain.ErrInvalidMessage)
+		})
+	}
+}
+
+func TestSendMessage_CustomType(t *testing.T) {
+	types := domain.DefaultMessageTypes()
+	assert.NoError(t, types.Register(domain.MessageType{
+		Name:     "sticker",
+		Content:  domain.Forbidden,
+		Metadata: map[string]domain.Field{"sticker_id": {Kind: domain.FieldString, Required: true}},
+	}))
+
+	svc := &Service{repo: new(MockRepo), tx: new(MockTransactor), log: zap.NewNop(), opts: Options{MessageTypes: types}}
+
+	// Rejected by the custom type before anything else runs
+	_, err := svc.SendMessage(context.Background(), SendMessageCommand{
+		ConversationID: "conv-1",
+		UserID:         "user-1",
+		Type:           "sticker",
+		Content:        "hi",
+	})
+	var ve *domain.ValidationError
+	assert.ErrorAs(t, err, &ve)
+	assert.Equal(t, "content", ve.Field)
+}
@@ -0,0 +1,157 @@
+package application
+
+import (
+	"context"
+	"database/sql"
+	"time"
+
+	messagev1 "github.com/SARVESHVARADKAR123/RealChat/contracts/gen/go/message/v1"
+	sharedv1 "github.com/SARVESHVARADKAR123/RealChat/contracts/gen/go/shared/v1"
+	"github.com/SARVESHVARADKAR123/RealChat/services/message/internal/domain"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+type PinCommand struct {
+	ConversationID string
+	MessageID      string
+	UserID         string
+}
+
+// PinMessage pins a message to its conversation. Pinning an already pinned
+// message returns the existing pin and emits no event.
+func (s *Service) PinMessage(ctx context.Context, cmd PinCommand) (*domain.Pin, error) {
+	if err := s.requirePinPermission(ctx, cmd.ConversationID, cmd.UserID); err != nil {
+		return nil, err
+	}
+
+	var result *domain.Pin
+
+	err := s.tx.WithTx(ctx, func(ctx context.Context, tx *sql.Tx) error {
+
+		msg, err := s.repo.GetMessage(ctx, tx, cmd.MessageID)
+		if err != nil {
+			return err
+		}
+		if msg.ConversationID != cmd.ConversationID {
+			return domain.ErrMessageNotFound
+		}
+		if msg.DeletedAt != nil {
+			return domain.ErrMessageDeleted
+		}
+
+		// Serializable isolation keeps concurrent pins from overshooting the limit
+		count, err := s.repo.CountPins(ctx, tx, cmd.ConversationID)
+		if err != nil {
+			return err
+		}
+
+		now := time.Now().UTC()
+		if count < s.opts.MaxPins {
+			pinned, err := s.repo.PinMessage(ctx, tx, cmd.ConversationID, cmd.MessageID, cmd.UserID, now)
+			if err != nil {
+				return err
+			}
+			if pinned {
+				result = &domain.Pin{Message: msg, PinnedBy: cmd.UserID, PinnedAt: now}
+				return s.emitPinChanged(ctx, tx, cmd.ConversationID, cmd.MessageID, cmd.UserID, true, now)
+			}
+		}
+
+		// Either already pinned, or the limit is reached
+		pins, err := s.repo.ListPins(ctx, tx, cmd.ConversationID)
+		if err != nil {
+			return err
+		}
+		for _, p := range pins {
+			if p.Message.ID == cmd.MessageID {
+				result = p
+				return nil
+			}
+		}
+		return domain.ErrPinLimitReached
+	})
+
+	if err != nil {
+		return nil, err
+	}
+	return result, nil
+}
+
+// UnpinMessage removes a pin. Unpinning a message that isn't pinned is a no-op.
+func (s *Service) UnpinMessage(ctx context.Context, cmd PinCommand) error {
+	if err := s.requirePinPermission(ctx, cmd.ConversationID, cmd.UserID); err != nil {
+		return err
+	}
+
+	return s.tx.WithTx(ctx, func(ctx context.Context, tx *sql.Tx) error {
+		return s.unpin(ctx, tx, cmd.ConversationID, cmd.MessageID, cmd.UserID)
+	})
+}
+
+// ListPinnedMessages returns the pins of a conversation, newest first.
+func (s *Service) ListPinnedMessages(ctx context.Context, conversationID, userID string) ([]*domain.Pin, error) {
+	if _, err := s.requireParticipant(ctx, conversationID, userID); err != nil {
+		return nil, err
+	}
+
+	pins, err := s.repo.ListPins(ctx, nil, conversationID)
+	if err != nil {
+		return nil, err
+	}
+
+	msgs := make([]*domain.Message, 0, len(pins))
+	for _, p := range pins {
+		msgs = append(msgs, p.Message)
+	}
+	if err := s.attachAttachments(ctx, msgs); err != nil {
+		return nil, err
+	}
+	if err := s.attachPolls(ctx, userID, msgs); err != nil {
+		return nil, err
+	}
+	return pins, nil
+}
+
+// unpin removes the pin on messageID, if any, and emits the change within tx.
+func (s *Service) unpin(ctx context.Context, tx *sql.Tx, conversationID, messageID, userID string) error {
+	unpinned, err := s.repo.UnpinMessage(ctx, tx, conversationID, messageID)
+	if err != nil || !unpinned {
+		return err
+	}
+	return s.emitPinChanged(ctx, tx, conversationID, messageID, userID, false, time.Now().UTC())
+}
+
+// requirePinPermission allows admins of groups and either participant of a
+// direct conversation.
+func (s *Service) requirePinPermission(ctx context.Context, conversationID, userID string) error {
+	conv, err := s.requireParticipant(ctx, conversationID, userID)
+	if err != nil {
+		return err
+	}
+	if !hasAdminRights(conv, userID) {
+		return domain.ErrNotAdmin
+	}
+	return nil
+}
+
+func (s *Service) emitPinChanged(
+	ctx context.Context,
+	tx *sql.Tx,
+	conversationID, messageID, userID string,
+	pinned bool,
+	at time.Time,
+) error {
+	return s.emitEvent(
+		ctx, tx,
+		conversationID,
+		sharedv1.EventType_EVENT_TYPE_MESSAGE_PIN_CHANGED,
+		"MESSAGE_PIN_CHANGED",
+		&messagev1.MessagePinChangedEvent{
+			ConversationId: conversationID,
+			MessageId:      messageID,
+			UserId:         userID,
+			Pinned:         pinned,
+			ChangedAt:      timestamppb.New(at),
+		},
+	)
+}
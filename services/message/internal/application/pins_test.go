@@ -0,0 +1,97 @@
+package application
+
+import (
+	"context"
+	"database/sql"
+	"testing"
+	"time"
+
+	conversationv1 "github.com/SARVESHVARADKAR123/RealChat/contracts/gen/go/conversation/v1"
+	"github.com/SARVESHVARADKAR123/RealChat/services/message/internal/domain"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+// MockPinRepo adds the pin calls.
+type MockPinRepo struct {
+	MockRepo
+}
+
+func (m *MockPinRepo) PinMessage(ctx context.Context, tx *sql.Tx, conversationID, messageID, userID string, pinnedAt time.Time) (bool, error) {
+	args := m.Called(ctx, tx, conversationID, messageID, userID, pinnedAt)
+	return args.Bool(0), args.Error(1)
+}
+func (m *MockPinRepo) CountPins(ctx context.Context, tx *sql.Tx, conversationID string) (int, error) {
+	args := m.Called(ctx, tx, conversationID)
+	return args.Int(0), args.Error(1)
+}
+func (m *MockPinRepo) ListPins(ctx context.Context, tx *sql.Tx, conversationID string) ([]*domain.Pin, error) {
+	args := m.Called(ctx, tx, conversationID)
+	return args.Get(0).([]*domain.Pin), args.Error(1)
+}
+
+func TestPinMessage(t *testing.T) {
+	ctx := context.Background()
+	msg := &domain.Message{ID: "msg-1", ConversationID: "conv-1", SenderID: "user-1"}
+	cmd := PinCommand{ConversationID: "conv-1", MessageID: "msg-1", UserID: "user-1"}
+
+	group := func(role conversationv1.ParticipantRole) *conversationv1.GetConversationResponse {
+		return &conversationv1.GetConversationResponse{
+			ParticipantUserIds: []string{"user-1"},
+			Conversation: &conversationv1.Conversation{
+				Type:                  conversationv1.ConversationType_GROUP,
+				ParticipantsWithRoles: []*conversationv1.Participant{{UserId: "user-1", Role: role}},
+			},
+		}
+	}
+
+	t.Run("Group admin pins and emits event", func(t *testing.T) {
+		repo := new(MockPinRepo)
+		convSvc := new(MockConvClient)
+		svc := &Service{repo: repo, tx: new(MockTransactor), convSvc: convSvc, opts: Options{MaxPins: 2}}
+
+		convSvc.On("GetConversation", ctx, mock.Anything).Return(group(conversationv1.ParticipantRole_ADMIN), nil).Once()
+		repo.On("GetMessage", ctx, mock.Anything, "msg-1").Return(msg, nil).Once()
+		repo.On("CountPins", ctx, mock.Anything, "conv-1").Return(1, nil).Once()
+		repo.On("PinMessage", ctx, mock.Anything, "conv-1", "msg-1", "user-1", mock.Anything).Return(true, nil).Once()
+		repo.On("InsertOutbox", ctx, mock.Anything, "message", "conv-1", "MESSAGE_PIN_CHANGED", mock.Anything).Return(nil).Once()
+
+		pin, err := svc.PinMessage(ctx, cmd)
+		assert.NoError(t, err)
+		assert.Equal(t, "user-1", pin.PinnedBy)
+		repo.AssertExpectations(t)
+	})
+
+	t.Run("Group member cannot pin", func(t *testing.T) {
+		repo := new(MockPinRepo)
+		convSvc := new(MockConvClient)
+		svc := &Service{repo: repo, tx: new(MockTransactor), convSvc: convSvc, opts: Options{MaxPins: 2}}
+
+		convSvc.On("GetConversation", ctx, mock.Anything).Return(group(conversationv1.ParticipantRole_MEMBER), nil).Once()
+
+		_, err := svc.PinMessage(ctx, cmd)
+		assert.ErrorIs(t, err, domain.ErrNotAdmin)
+		repo.AssertExpectations(t)
+	})
+
+	t.Run("Limit reached", func(t *testing.T) {
+		repo := new(MockPinRepo)
+		convSvc := new(MockConvClient)
+		svc := &Service{repo: repo, tx: new(MockTransactor), convSvc: convSvc, opts: Options{MaxPins: 2}}
+
+		direct := &conversationv1.GetConversationResponse{
+			ParticipantUserIds: []string{"user-1", "user-2"},
+			Conversation:       &conversationv1.Conversation{Type: conversationv1.ConversationType_DIRECT},
+		}
+		other := &domain.Message{ID: "msg-0", ConversationID: "conv-1"}
+
+		convSvc.On("GetConversation", ctx, mock.Anything).Return(direct, nil).Once()
+		repo.On("GetMessage", ctx, mock.Anything, "msg-1").Return(msg, nil).Once()
+		repo.On("CountPins", ctx, mock.Anything, "conv-1").Return(2, nil).Once()
+		repo.On("ListPins", ctx, mock.Anything, "conv-1").Return([]*domain.Pin{{Message: other}, {Message: other}}, nil).Once()
+
+		_, err := svc.PinMessage(ctx, cmd)
+		assert.ErrorIs(t, err, domain.ErrPinLimitReached)
+		repo.AssertExpectations(t)
+	})
+}
@@ -0,0 +1,179 @@
+package application
+
+import (
+	"context"
+	"database/sql"
+	"time"
+
+	messagev1 "github.com/SARVESHVARADKAR123/RealChat/contracts/gen/go/message/v1"
+	sharedv1 "github.com/SARVESHVARADKAR123/RealChat/contracts/gen/go/shared/v1"
+	"github.com/SARVESHVARADKAR123/RealChat/services/message/internal/domain"
+)
+
+type PollCommand struct {
+	ConversationID string
+	MessageID      string
+	UserID         string
+	// Options are the chosen option indexes; only used by VotePoll.
+	Options []int
+}
+
+// VotePoll replaces the caller's votes and returns the poll tallied for them.
+// Casting the same vote again is a no-op and emits no event.
+func (s *Service) VotePoll(ctx context.Context, cmd PollCommand) (*domain.Poll, error) {
+	if _, err := s.requireParticipant(ctx, cmd.ConversationID, cmd.UserID); err != nil {
+		return nil, err
+	}
+
+	return s.changePoll(ctx, cmd, func(p *domain.Poll, now time.Time) (bool, error) {
+		return p.Vote(cmd.UserID, cmd.Options, now)
+	})
+}
+
+// RetractVote clears the caller's votes. Retracting without a vote is a no-op
+// and emits no event.
+func (s *Service) RetractVote(ctx context.Context, cmd PollCommand) (*domain.Poll, error) {
+	if _, err := s.requireParticipant(ctx, cmd.ConversationID, cmd.UserID); err != nil {
+		return nil, err
+	}
+
+	return s.changePoll(ctx, cmd, func(p *domain.Poll, now time.Time) (bool, error) {
+		return p.Retract(cmd.UserID, now)
+	})
+}
+
+// ClosePoll ends voting on a poll. Only its creator or a conversation admin
+// may close it; closing a closed poll is a no-op.
+func (s *Service) ClosePoll(ctx context.Context, cmd PollCommand) (*domain.Poll, error) {
+	conv, err := s.requireParticipant(ctx, cmd.ConversationID, cmd.UserID)
+	if err != nil {
+		return nil, err
+	}
+
+	return s.changePoll(ctx, cmd, func(p *domain.Poll, now time.Time) (bool, error) {
+		if p.CreatorID != cmd.UserID && !hasAdminRights(conv, cmd.UserID) {
+			return false, domain.ErrNotAdmin
+		}
+		return p.Close(cmd.UserID, now), nil
+	})
+}
+
+// changePoll locks the poll of cmd.MessageID, applies change and, if it
+// changed anything, persists the poll and emits the new tally.
+func (s *Service) changePoll(
+	ctx context.Context,
+	cmd PollCommand,
+	change func(p *domain.Poll, now time.Time) (bool, error),
+) (*domain.Poll, error) {
+
+	var result *domain.Poll
+
+	err := s.tx.WithTx(ctx, func(ctx context.Context, tx *sql.Tx) error {
+
+		msg, err := s.repo.GetMessage(ctx, tx, cmd.MessageID)
+		if err != nil {
+			return err
+		}
+		if msg.ConversationID != cmd.ConversationID {
+			return domain.ErrMessageNotFound
+		}
+		if msg.DeletedAt != nil {
+			return domain.ErrMessageDeleted
+		}
+
+		p, err := s.repo.GetPollForUpdate(ctx, tx, cmd.MessageID)
+		if err != nil {
+			return err
+		}
+
+		now := time.Now().UTC()
+		wasClosed := p.ClosedAt != nil
+		changed, err := change(p, now)
+		if err != nil {
+			return err
+		}
+
+		p.Tally = p.Count(cmd.UserID)
+		result = p
+
+		if !changed {
+			return nil
+		}
+
+		if p.ClosedAt != nil && !wasClosed {
+			err = s.repo.ClosePoll(ctx, tx, p)
+		} else {
+			err = s.repo.ReplacePollVotes(ctx, tx, p.MessageID, cmd.UserID, p.VotesOf(cmd.UserID), now)
+		}
+		if err != nil {
+			return err
+		}
+
+		// my_votes is viewer-specific, so the event carries the tally only
+		view := *p
+		view.Tally = p.Count("")
+		event := &messagev1.PollTallyChangedEvent{
+			ConversationId: cmd.ConversationID,
+			MessageId:      cmd.MessageID,
+			Poll:           ToProtoPoll(&view),
+		}
+		// Naming the voter next to a changed tally would give their ballot away
+		if !p.Anonymous {
+			event.UserId = cmd.UserID
+		}
+
+		return s.emitEvent(
+			ctx, tx,
+			cmd.ConversationID,
+			sharedv1.EventType_EVENT_TYPE_POLL_TALLY_CHANGED,
+			"POLL_TALLY_CHANGED",
+			event,
+		)
+	})
+
+	if err != nil {
+		return nil, err
+	}
+	return result, nil
+}
+
+// createPoll stores the poll of msg, a freshly inserted poll message.
+func (s *Service) createPoll(ctx context.Context, tx *sql.Tx, msg *domain.Message) error {
+	p, err := msg.NewPoll()
+	if err != nil {
+		return err
+	}
+	if err := s.repo.InsertPoll(ctx, tx, p); err != nil {
+		return err
+	}
+
+	p.Tally = p.Count("")
+	msg.Poll = p
+	return nil
+}
+
+// attachPolls fills Poll on the poll messages in msgs, tallied for viewerID.
+func (s *Service) attachPolls(ctx context.Context, viewerID string, msgs []*domain.Message) error {
+	var ids []string
+	for _, m := range msgs {
+		if m.Type == domain.TypePoll {
+			ids = append(ids, m.ID)
+		}
+	}
+	if len(ids) == 0 {
+		return nil
+	}
+
+	polls, err := s.repo.ListPolls(ctx, nil, ids)
+	if err != nil {
+		return err
+	}
+
+	for _, m := range msgs {
+		if p, ok := polls[m.ID]; ok {
+			p.Tally = p.Count(viewerID)
+			m.Poll = p
+		}
+	}
+	return nil
+}
@@ -0,0 +1,236 @@
+package application
+
+import (
+	"context"
+	"database/sql"
+	"testing"
+	"time"
+
+	conversationv1 "github.com/SARVESHVARADKAR123/RealChat/contracts/gen/go/conversation/v1"
+	messagev1 "github.com/SARVESHVARADKAR123/RealChat/contracts/gen/go/message/v1"
+	sharedv1 "github.com/SARVESHVARADKAR123/RealChat/contracts/gen/go/shared/v1"
+	"github.com/SARVESHVARADKAR123/RealChat/services/message/internal/domain"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"google.golang.org/protobuf/proto"
+)
+
+// MockPollRepo adds the poll calls.
+type MockPollRepo struct {
+	MockRepo
+}
+
+func (m *MockPollRepo) GetPollForUpdate(ctx context.Context, tx *sql.Tx, messageID string) (*domain.Poll, error) {
+	args := m.Called(ctx, tx, messageID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*domain.Poll), args.Error(1)
+}
+func (m *MockPollRepo) ReplacePollVotes(ctx context.Context, tx *sql.Tx, messageID, userID string, options []int, votedAt time.Time) error {
+	return m.Called(ctx, tx, messageID, userID, options, votedAt).Error(0)
+}
+func (m *MockPollRepo) ClosePoll(ctx context.Context, tx *sql.Tx, p *domain.Poll) error {
+	return m.Called(ctx, tx, p).Error(0)
+}
+
+func TestPollVoting(t *testing.T) {
+	now := time.Now().UTC()
+	newPoll := func(multiple bool) *domain.Poll {
+		return &domain.Poll{MessageID: "msg-1", Options: []string{"a", "b", "c"}, MultipleChoice: multiple}
+	}
+
+	t.Run("Single choice replaces the previous vote", func(t *testing.T) {
+		p := newPoll(false)
+
+		changed, err := p.Vote("user-1", []int{0}, now)
+		assert.NoError(t, err)
+		assert.True(t, changed)
+
+		changed, err = p.Vote("user-1", []int{2}, now)
+		assert.NoError(t, err)
+		assert.True(t, changed)
+
+		changed, err = p.Vote("user-1", []int{2}, now)
+		assert.NoError(t, err)
+		assert.False(t, changed)
+
+		tally := p.Count("user-1")
+		assert.Equal(t, []int64{0, 0, 1}, tally.Votes)
+		assert.Equal(t, []int{2}, tally.MyVotes)
+		assert.Equal(t, int64(1), tally.TotalVoters)
+	})
+
+	t.Run("Invalid votes are rejected", func(t *testing.T) {
+		p := newPoll(false)
+		for _, options := range [][]int{nil, {0, 1}, {3}, {-1}} {
+			_, err := p.Vote("user-1", options, now)
+			assert.ErrorIs(t, err, domain.ErrInvalidVote, "options %v", options)
+		}
+
+		_, err := newPoll(true).Vote("user-1", []int{1, 1}, now)
+		assert.ErrorIs(t, err, domain.ErrInvalidVote)
+	})
+
+	t.Run("Multiple choice counts voters once", func(t *testing.T) {
+		p := newPoll(true)
+		_, _ = p.Vote("user-1", []int{0, 1}, now)
+		_, _ = p.Vote("user-2", []int{1}, now)
+
+		tally := p.Count("")
+		assert.Equal(t, []int64{1, 2, 0}, tally.Votes)
+		assert.Equal(t, [][]string{{"user-1"}, {"user-1", "user-2"}, nil}, tally.Voters)
+		assert.Equal(t, int64(2), tally.TotalVoters)
+		assert.Empty(t, tally.MyVotes)
+	})
+
+	t.Run("Anonymous polls hide voters", func(t *testing.T) {
+		p := newPoll(false)
+		p.Anonymous = true
+		_, _ = p.Vote("user-1", []int{0}, now)
+
+		tally := p.Count("user-1")
+		assert.Nil(t, tally.Voters)
+		assert.Equal(t, []int{0}, tally.MyVotes)
+	})
+
+	t.Run("Closed polls reject votes", func(t *testing.T) {
+		p := newPoll(false)
+		assert.True(t, p.Close("user-1", now))
+		assert.False(t, p.Close("user-1", now))
+
+		_, err := p.Vote("user-2", []int{0}, now)
+		assert.ErrorIs(t, err, domain.ErrPollClosed)
+		_, err = p.Retract("user-2", now)
+		assert.ErrorIs(t, err, domain.ErrPollClosed)
+
+		past := now.Add(-time.Minute)
+		expired := newPoll(false)
+		expired.ClosesAt = &past
+		_, err = expired.Vote("user-2", []int{0}, now)
+		assert.ErrorIs(t, err, domain.ErrPollClosed)
+		assert.Error(t, expired.CheckOpen(now))
+	})
+}
+
+func TestVotePoll(t *testing.T) {
+	ctx := context.Background()
+	convID := "conv-1"
+	msgID := "msg-1"
+	userID := "user-1"
+
+	conv := &conversationv1.GetConversationResponse{
+		Conversation:       &conversationv1.Conversation{ConversationId: convID, Type: conversationv1.ConversationType_GROUP},
+		ParticipantUserIds: []string{userID, "user-2"},
+	}
+	msg := &domain.Message{ID: msgID, ConversationID: convID, SenderID: "user-2", Type: domain.TypePoll}
+	newPoll := func() *domain.Poll {
+		return &domain.Poll{MessageID: msgID, ConversationID: convID, CreatorID: "user-2", Options: []string{"a", "b"}}
+	}
+	cmd := PollCommand{ConversationID: convID, MessageID: msgID, UserID: userID, Options: []int{1}}
+
+	t.Run("Vote emits tally event", func(t *testing.T) {
+		repo := new(MockPollRepo)
+		convSvc := new(MockConvClient)
+		svc := &Service{repo: repo, tx: new(MockTransactor), convSvc: convSvc}
+
+		convSvc.On("GetConversation", ctx, mock.Anything).Return(conv, nil).Once()
+		repo.On("GetMessage", ctx, mock.Anything, msgID).Return(msg, nil).Once()
+		repo.On("GetPollForUpdate", ctx, mock.Anything, msgID).Return(newPoll(), nil).Once()
+		repo.On("ReplacePollVotes", ctx, mock.Anything, msgID, userID, []int{1}, mock.Anything).Return(nil).Once()
+		repo.On("InsertOutbox", ctx, mock.Anything, "message", convID, "POLL_TALLY_CHANGED", mock.Anything).Return(nil).Once()
+
+		got, err := svc.VotePoll(ctx, cmd)
+		assert.NoError(t, err)
+		assert.Equal(t, []int64{0, 1}, got.Tally.Votes)
+		assert.Equal(t, []int{1}, got.Tally.MyVotes)
+		repo.AssertExpectations(t)
+	})
+
+	t.Run("Anonymous poll event names no voter", func(t *testing.T) {
+		repo := new(MockPollRepo)
+		convSvc := new(MockConvClient)
+		svc := &Service{repo: repo, tx: new(MockTransactor), convSvc: convSvc}
+
+		p := newPoll()
+		p.Anonymous = true
+
+		var event messagev1.PollTallyChangedEvent
+		convSvc.On("GetConversation", ctx, mock.Anything).Return(conv, nil).Once()
+		repo.On("GetMessage", ctx, mock.Anything, msgID).Return(msg, nil).Once()
+		repo.On("GetPollForUpdate", ctx, mock.Anything, msgID).Return(p, nil).Once()
+		repo.On("ReplacePollVotes", ctx, mock.Anything, msgID, userID, []int{1}, mock.Anything).Return(nil).Once()
+		repo.On("InsertOutbox", ctx, mock.Anything, "message", convID, "POLL_TALLY_CHANGED", mock.MatchedBy(func(payload []byte) bool {
+			var env sharedv1.EventEnvelope
+			return proto.Unmarshal(payload, &env) == nil && proto.Unmarshal(env.GetPayload(), &event) == nil
+		})).Return(nil).Once()
+
+		_, err := svc.VotePoll(ctx, cmd)
+		assert.NoError(t, err)
+		assert.Empty(t, event.GetUserId())
+		assert.Equal(t, int64(1), event.GetPoll().GetOptions()[1].GetVotes())
+		assert.Empty(t, event.GetPoll().GetOptions()[1].GetVoterUserIds())
+		repo.AssertExpectations(t)
+	})
+
+	t.Run("Repeated vote is a no-op", func(t *testing.T) {
+		repo := new(MockPollRepo)
+		convSvc := new(MockConvClient)
+		svc := &Service{repo: repo, tx: new(MockTransactor), convSvc: convSvc}
+
+		p := newPoll()
+		p.Ballots = []domain.PollBallot{{UserID: userID, OptionIndex: 1}}
+
+		convSvc.On("GetConversation", ctx, mock.Anything).Return(conv, nil).Once()
+		repo.On("GetMessage", ctx, mock.Anything, msgID).Return(msg, nil).Once()
+		repo.On("GetPollForUpdate", ctx, mock.Anything, msgID).Return(p, nil).Once()
+
+		_, err := svc.VotePoll(ctx, cmd)
+		assert.NoError(t, err)
+		repo.AssertNotCalled(t, "InsertOutbox", mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+	})
+
+	t.Run("Non participant is rejected", func(t *testing.T) {
+		repo := new(MockPollRepo)
+		convSvc := new(MockConvClient)
+		svc := &Service{repo: repo, tx: new(MockTransactor), convSvc: convSvc}
+
+		convSvc.On("GetConversation", ctx, mock.Anything).Return(conv, nil).Once()
+
+		_, err := svc.VotePoll(ctx, PollCommand{ConversationID: convID, MessageID: msgID, UserID: "outsider", Options: []int{0}})
+		assert.ErrorIs(t, err, domain.ErrNotParticipant)
+		repo.AssertExpectations(t)
+	})
+
+	t.Run("Only the creator or an admin may close", func(t *testing.T) {
+		repo := new(MockPollRepo)
+		convSvc := new(MockConvClient)
+		svc := &Service{repo: repo, tx: new(MockTransactor), convSvc: convSvc}
+
+		convSvc.On("GetConversation", ctx, mock.Anything).Return(conv, nil).Once()
+		repo.On("GetMessage", ctx, mock.Anything, msgID).Return(msg, nil).Once()
+		repo.On("GetPollForUpdate", ctx, mock.Anything, msgID).Return(newPoll(), nil).Once()
+
+		_, err := svc.ClosePoll(ctx, PollCommand{ConversationID: convID, MessageID: msgID, UserID: userID})
+		assert.ErrorIs(t, err, domain.ErrNotAdmin)
+	})
+
+	t.Run("Creator closes the poll", func(t *testing.T) {
+		repo := new(MockPollRepo)
+		convSvc := new(MockConvClient)
+		svc := &Service{repo: repo, tx: new(MockTransactor), convSvc: convSvc}
+
+		convSvc.On("GetConversation", ctx, mock.Anything).Return(conv, nil).Once()
+		repo.On("GetMessage", ctx, mock.Anything, msgID).Return(msg, nil).Once()
+		repo.On("GetPollForUpdate", ctx, mock.Anything, msgID).Return(newPoll(), nil).Once()
+		repo.On("ClosePoll", ctx, mock.Anything, mock.MatchedBy(func(p *domain.Poll) bool {
+			return p.ClosedAt != nil && p.ClosedBy == "user-2"
+		})).Return(nil).Once()
+		repo.On("InsertOutbox", ctx, mock.Anything, "message", convID, "POLL_TALLY_CHANGED", mock.Anything).Return(nil).Once()
+
+		got, err := svc.ClosePoll(ctx, PollCommand{ConversationID: convID, MessageID: msgID, UserID: "user-2"})
+		assert.NoError(t, err)
+		assert.NotNil(t, got.ClosedAt)
+		repo.AssertExpectations(t)
+	})
+}
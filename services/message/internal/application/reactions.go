@@ -0,0 +1,129 @@
+package application
+
+import (
+	"context"
+	"database/sql"
+
+	messagev1 "github.com/SARVESHVARADKAR123/RealChat/contracts/gen/go/message/v1"
+	sharedv1 "github.com/SARVESHVARADKAR123/RealChat/contracts/gen/go/shared/v1"
+	"github.com/SARVESHVARADKAR123/RealChat/services/message/internal/domain"
+)
+
+type ReactionCommand struct {
+	ConversationID string
+	MessageID      string
+	UserID         string
+	Emoji          string
+}
+
+// AddReaction adds the caller's reaction and returns the message's reactions.
+// Re-adding an existing reaction is a no-op and emits no event.
+func (s *Service) AddReaction(ctx context.Context, cmd ReactionCommand) ([]domain.ReactionSummary, error) {
+	return s.changeReaction(ctx, cmd, true)
+}
+
+// RemoveReaction removes the caller's reaction and returns the message's
+// reactions. Removing a missing reaction is a no-op and emits no event.
+func (s *Service) RemoveReaction(ctx context.Context, cmd ReactionCommand) ([]domain.ReactionSummary, error) {
+	return s.changeReaction(ctx, cmd, false)
+}
+
+func (s *Service) changeReaction(
+	ctx context.Context,
+	cmd ReactionCommand,
+	add bool,
+) ([]domain.ReactionSummary, error) {
+
+	if err := domain.ValidateReaction(cmd.Emoji); err != nil {
+		return nil, err
+	}
+
+	if _, err := s.requireParticipant(ctx, cmd.ConversationID, cmd.UserID); err != nil {
+		return nil, err
+	}
+
+	var result []domain.ReactionSummary
+
+	err := s.tx.WithTx(ctx, func(ctx context.Context, tx *sql.Tx) error {
+
+		msg, err := s.repo.GetMessage(ctx, tx, cmd.MessageID)
+		if err != nil {
+			return err
+		}
+		if msg.ConversationID != cmd.ConversationID {
+			return domain.ErrMessageNotFound
+		}
+
+		var changed bool
+		if add {
+			if msg.DeletedAt != nil {
+				return domain.ErrMessageDeleted
+			}
+			changed, err = s.repo.AddReaction(ctx, tx, cmd.MessageID, cmd.UserID, cmd.Emoji)
+		} else {
+			changed, err = s.repo.RemoveReaction(ctx, tx, cmd.MessageID, cmd.UserID, cmd.Emoji)
+		}
+		if err != nil {
+			return err
+		}
+
+		summaries, err := s.repo.ListReactionSummaries(ctx, tx, []string{cmd.MessageID}, cmd.UserID)
+		if err != nil {
+			return err
+		}
+		result = summaries[cmd.MessageID]
+
+		if !changed {
+			return nil
+		}
+
+		// reacted_by_me is viewer-specific, so the event carries counts only
+		counts := make([]domain.ReactionSummary, len(result))
+		for i, rs := range result {
+			counts[i] = domain.ReactionSummary{Emoji: rs.Emoji, Count: rs.Count}
+		}
+		event := &messagev1.ReactionChangedEvent{
+			ConversationId: cmd.ConversationID,
+			MessageId:      cmd.MessageID,
+			UserId:         cmd.UserID,
+			Emoji:          cmd.Emoji,
+			Added:          add,
+			Reactions:      ToProtoReactions(counts),
+		}
+
+		return s.emitEvent(
+			ctx, tx,
+			cmd.ConversationID,
+			sharedv1.EventType_EVENT_TYPE_REACTION_CHANGED,
+			"REACTION_CHANGED",
+			event,
+		)
+	})
+
+	if err != nil {
+		return nil, err
+	}
+	return result, nil
+}
+
+// attachReactions fills Reactions on msgs from viewerID's point of view.
+func (s *Service) attachReactions(ctx context.Context, viewerID string, msgs []*domain.Message) error {
+	if len(msgs) == 0 {
+		return nil
+	}
+
+	ids := make([]string, 0, len(msgs))
+	for _, m := range msgs {
+		ids = append(ids, m.ID)
+	}
+
+	summaries, err := s.repo.ListReactionSummaries(ctx, nil, ids, viewerID)
+	if err != nil {
+		return err
+	}
+
+	for _, m := range msgs {
+		m.Reactions = summaries[m.ID]
+	}
+	return nil
+}
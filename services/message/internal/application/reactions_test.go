@@ -0,0 +1,88 @@
+package application
+
+import (
+	"context"
+	"database/sql"
+	"testing"
+
+	conversationv1 "github.com/SARVESHVARADKAR123/RealChat/contracts/gen/go/conversation/v1"
+	"github.com/SARVESHVARADKAR123/RealChat/services/message/internal/domain"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+// MockReactionRepo adds the reaction calls.
+type MockReactionRepo struct {
+	MockRepo
+}
+
+func (m *MockReactionRepo) AddReaction(ctx context.Context, tx *sql.Tx, messageID, userID, emoji string) (bool, error) {
+	args := m.Called(ctx, tx, messageID, userID, emoji)
+	return args.Bool(0), args.Error(1)
+}
+func (m *MockReactionRepo) ListReactionSummaries(ctx context.Context, tx *sql.Tx, messageIDs []string, viewerID string) (map[string][]domain.ReactionSummary, error) {
+	args := m.Called(ctx, tx, messageIDs, viewerID)
+	return args.Get(0).(map[string][]domain.ReactionSummary), args.Error(1)
+}
+
+func TestAddReaction(t *testing.T) {
+	ctx := context.Background()
+	convID := "conv-1"
+	msgID := "msg-1"
+	userID := "user-1"
+
+	conv := &conversationv1.GetConversationResponse{
+		Conversation:       &conversationv1.Conversation{ConversationId: convID},
+		ParticipantUserIds: []string{userID},
+	}
+	msg := &domain.Message{ID: msgID, ConversationID: convID, SenderID: "user-2"}
+	summaries := map[string][]domain.ReactionSummary{
+		msgID: {{Emoji: "👍", Count: 1, ReactedByMe: true}},
+	}
+	cmd := ReactionCommand{ConversationID: convID, MessageID: msgID, UserID: userID, Emoji: "👍"}
+
+	t.Run("New reaction emits event", func(t *testing.T) {
+		repo := new(MockReactionRepo)
+		convSvc := new(MockConvClient)
+		svc := &Service{repo: repo, tx: new(MockTransactor), convSvc: convSvc}
+
+		convSvc.On("GetConversation", ctx, mock.Anything).Return(conv, nil).Once()
+		repo.On("GetMessage", ctx, mock.Anything, msgID).Return(msg, nil).Once()
+		repo.On("AddReaction", ctx, mock.Anything, msgID, userID, "👍").Return(true, nil).Once()
+		repo.On("ListReactionSummaries", ctx, mock.Anything, []string{msgID}, userID).Return(summaries, nil).Once()
+		repo.On("InsertOutbox", ctx, mock.Anything, "message", convID, "REACTION_CHANGED", mock.Anything).Return(nil).Once()
+
+		got, err := svc.AddReaction(ctx, cmd)
+		assert.NoError(t, err)
+		assert.Equal(t, summaries[msgID], got)
+		repo.AssertExpectations(t)
+	})
+
+	t.Run("Duplicate reaction is a no-op", func(t *testing.T) {
+		repo := new(MockReactionRepo)
+		convSvc := new(MockConvClient)
+		svc := &Service{repo: repo, tx: new(MockTransactor), convSvc: convSvc}
+
+		convSvc.On("GetConversation", ctx, mock.Anything).Return(conv, nil).Once()
+		repo.On("GetMessage", ctx, mock.Anything, msgID).Return(msg, nil).Once()
+		repo.On("AddReaction", ctx, mock.Anything, msgID, userID, "👍").Return(false, nil).Once()
+		repo.On("ListReactionSummaries", ctx, mock.Anything, []string{msgID}, userID).Return(summaries, nil).Once()
+
+		_, err := svc.AddReaction(ctx, cmd)
+		assert.NoError(t, err)
+		repo.AssertExpectations(t)
+		repo.AssertNotCalled(t, "InsertOutbox", mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+	})
+
+	t.Run("Non participant is rejected", func(t *testing.T) {
+		repo := new(MockReactionRepo)
+		convSvc := new(MockConvClient)
+		svc := &Service{repo: repo, tx: new(MockTransactor), convSvc: convSvc}
+
+		convSvc.On("GetConversation", ctx, mock.Anything).Return(conv, nil).Once()
+
+		_, err := svc.AddReaction(ctx, ReactionCommand{ConversationID: convID, MessageID: msgID, UserID: "outsider", Emoji: "👍"})
+		assert.ErrorIs(t, err, domain.ErrNotParticipant)
+		repo.AssertExpectations(t)
+	})
+}
@@ -0,0 +1,81 @@
+package application
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"time"
+
+	"github.com/SARVESHVARADKAR123/RealChat/services/message/internal/domain"
+)
+
+// RetentionCommand changes how long a conversation's messages are kept.
+// UseDefault drops the override and falls back to the service-wide
+// retention; otherwise Retention applies, zero meaning forever.
+type RetentionCommand struct {
+	ConversationID string
+	UserID         string
+	Retention      time.Duration
+	UseDefault     bool
+}
+
+// Retention is the retention in effect for a conversation.
+type Retention struct {
+	Retention time.Duration
+	// Default is true when the conversation has no override.
+	Default bool
+}
+
+// SetRetentionPolicy is allowed to the same users as pinning: admins of
+// groups and either participant of a direct conversation.
+func (s *Service) SetRetentionPolicy(ctx context.Context, cmd RetentionCommand) (*Retention, error) {
+	if cmd.ConversationID == "" {
+		return nil, domain.ErrInvalidInput
+	}
+	if !cmd.UseDefault {
+		if err := domain.ValidateRetention(cmd.Retention); err != nil {
+			return nil, err
+		}
+	}
+
+	if err := s.requirePinPermission(ctx, cmd.ConversationID, cmd.UserID); err != nil {
+		return nil, err
+	}
+
+	err := s.tx.WithTx(ctx, func(ctx context.Context, tx *sql.Tx) error {
+		if cmd.UseDefault {
+			return s.repo.DeleteRetentionPolicy(ctx, tx, cmd.ConversationID)
+		}
+		return s.repo.SaveRetentionPolicy(ctx, tx, &domain.RetentionPolicy{
+			ConversationID: cmd.ConversationID,
+			Retention:      cmd.Retention,
+			UpdatedBy:      cmd.UserID,
+			UpdatedAt:      time.Now().UTC(),
+		})
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to save retention policy: %w", err)
+	}
+
+	if cmd.UseDefault {
+		return &Retention{Retention: s.opts.Retention, Default: true}, nil
+	}
+	return &Retention{Retention: cmd.Retention}, nil
+}
+
+// GetRetentionPolicy returns the retention in effect for a conversation the
+// caller belongs to.
+func (s *Service) GetRetentionPolicy(ctx context.Context, conversationID, userID string) (*Retention, error) {
+	if _, err := s.requireParticipant(ctx, conversationID, userID); err != nil {
+		return nil, err
+	}
+
+	p, err := s.repo.GetRetentionPolicy(ctx, nil, conversationID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load retention policy: %w", err)
+	}
+	if p == nil {
+		return &Retention{Retention: s.opts.Retention, Default: true}, nil
+	}
+	return &Retention{Retention: p.Retention}, nil
+}
@@ -0,0 +1,96 @@
+package application
+
+import (
+	"context"
+	"database/sql"
+	"testing"
+	"time"
+
+	conversationv1 "github.com/SARVESHVARADKAR123/RealChat/contracts/gen/go/conversation/v1"
+	"github.com/SARVESHVARADKAR123/RealChat/services/message/internal/domain"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+// MockRetentionRepo adds the retention policy calls.
+type MockRetentionRepo struct {
+	MockRepo
+}
+
+func (m *MockRetentionRepo) SaveRetentionPolicy(ctx context.Context, tx *sql.Tx, p *domain.RetentionPolicy) error {
+	return m.Called(ctx, tx, p).Error(0)
+}
+func (m *MockRetentionRepo) DeleteRetentionPolicy(ctx context.Context, tx *sql.Tx, conversationID string) error {
+	return m.Called(ctx, tx, conversationID).Error(0)
+}
+
+func TestValidateRetention(t *testing.T) {
+	assert.NoError(t, domain.ValidateRetention(0))
+	assert.NoError(t, domain.ValidateRetention(365*24*time.Hour))
+	assert.ErrorIs(t, domain.ValidateRetention(time.Hour), domain.ErrInvalidRetention)
+	assert.ErrorIs(t, domain.ValidateRetention(48*time.Hour+time.Millisecond), domain.ErrInvalidRetention)
+}
+
+func TestSetRetentionPolicy(t *testing.T) {
+	ctx := context.Background()
+	year := 365 * 24 * time.Hour
+
+	group := func(role conversationv1.ParticipantRole) *conversationv1.GetConversationResponse {
+		return &conversationv1.GetConversationResponse{
+			ParticipantUserIds: []string{"user-1"},
+			Conversation: &conversationv1.Conversation{
+				Type:                  conversationv1.ConversationType_GROUP,
+				ParticipantsWithRoles: []*conversationv1.Participant{{UserId: "user-1", Role: role}},
+			},
+		}
+	}
+
+	t.Run("Admin sets an override", func(t *testing.T) {
+		repo := new(MockRetentionRepo)
+		convSvc := new(MockConvClient)
+		svc := &Service{repo: repo, tx: new(MockTransactor), convSvc: convSvc}
+
+		convSvc.On("GetConversation", ctx, mock.Anything).Return(group(conversationv1.ParticipantRole_ADMIN), nil).Once()
+		repo.On("SaveRetentionPolicy", ctx, mock.Anything, mock.MatchedBy(func(p *domain.RetentionPolicy) bool {
+			return p.ConversationID == "conv-1" && p.Retention == year && p.UpdatedBy == "user-1"
+		})).Return(nil).Once()
+
+		r, err := svc.SetRetentionPolicy(ctx, RetentionCommand{ConversationID: "conv-1", UserID: "user-1", Retention: year})
+		assert.NoError(t, err)
+		assert.Equal(t, &Retention{Retention: year}, r)
+		repo.AssertExpectations(t)
+	})
+
+	t.Run("Back to the default", func(t *testing.T) {
+		repo := new(MockRetentionRepo)
+		convSvc := new(MockConvClient)
+		svc := &Service{repo: repo, tx: new(MockTransactor), convSvc: convSvc, opts: Options{Retention: 2 * year}}
+
+		convSvc.On("GetConversation", ctx, mock.Anything).Return(group(conversationv1.ParticipantRole_ADMIN), nil).Once()
+		repo.On("DeleteRetentionPolicy", ctx, mock.Anything, "conv-1").Return(nil).Once()
+
+		r, err := svc.SetRetentionPolicy(ctx, RetentionCommand{ConversationID: "conv-1", UserID: "user-1", UseDefault: true})
+		assert.NoError(t, err)
+		assert.Equal(t, &Retention{Retention: 2 * year, Default: true}, r)
+		repo.AssertExpectations(t)
+	})
+
+	t.Run("Group member cannot change it", func(t *testing.T) {
+		repo := new(MockRetentionRepo)
+		convSvc := new(MockConvClient)
+		svc := &Service{repo: repo, tx: new(MockTransactor), convSvc: convSvc}
+
+		convSvc.On("GetConversation", ctx, mock.Anything).Return(group(conversationv1.ParticipantRole_MEMBER), nil).Once()
+
+		_, err := svc.SetRetentionPolicy(ctx, RetentionCommand{ConversationID: "conv-1", UserID: "user-1", Retention: year})
+		assert.ErrorIs(t, err, domain.ErrNotAdmin)
+		repo.AssertExpectations(t)
+	})
+
+	t.Run("Too short", func(t *testing.T) {
+		svc := &Service{repo: new(MockRetentionRepo), tx: new(MockTransactor), convSvc: new(MockConvClient)}
+
+		_, err := svc.SetRetentionPolicy(ctx, RetentionCommand{ConversationID: "conv-1", UserID: "user-1", Retention: time.Hour})
+		assert.ErrorIs(t, err, domain.ErrInvalidRetention)
+	})
+}
@@ -0,0 +1,156 @@
+package application
+
+import (
+	"context"
+	"database/sql"
+	"time"
+
+	"github.com/SARVESHVARADKAR123/RealChat/services/message/internal/domain"
+	"github.com/google/uuid"
+)
+
+// ScheduleMessage stores cmd to be sent at sendAt. Membership and attachments
+// are checked now and again when the message fires.
+func (s *Service) ScheduleMessage(
+	ctx context.Context,
+	cmd SendMessageCommand,
+	sendAt time.Time,
+) (*domain.ScheduledMessage, error) {
+
+	if err := s.validateSend(&cmd); err != nil {
+		return nil, err
+	}
+	// Prekeys may be rotated by send time, so encrypted messages go out now
+	if cmd.Type == domain.TypeEncrypted {
+		return nil, domain.ErrMessageEncrypted
+	}
+
+	m, err := domain.NewScheduledMessage(
+		uuid.NewString(),
+		cmd.ConversationID,
+		cmd.UserID,
+		cmd.Type,
+		cmd.Content,
+		cmd.Metadata,
+		cmd.ReplyToID,
+		cmd.AttachmentIDs,
+		sendAt.UTC(),
+		time.Now().UTC(),
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	if _, err := s.requireParticipant(ctx, cmd.ConversationID, cmd.UserID); err != nil {
+		return nil, err
+	}
+	if _, err := s.resolveAttachments(ctx, cmd.UserID, cmd.AttachmentIDs); err != nil {
+		return nil, err
+	}
+
+	err = s.tx.WithTx(ctx, func(ctx context.Context, tx *sql.Tx) error {
+		if err := s.repo.InsertScheduledMessage(ctx, tx, m); err != nil {
+			return err
+		}
+		// The user is done composing, so the draft goes now
+		return s.clearDraftOnSend(ctx, tx, cmd.ConversationID, cmd.UserID, m.CreatedAt)
+	})
+	if err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+// ListScheduledMessages returns userID's pending scheduled messages.
+func (s *Service) ListScheduledMessages(
+	ctx context.Context,
+	userID string,
+	conversationID string,
+) ([]*domain.ScheduledMessage, error) {
+	return s.repo.ListScheduledMessages(ctx, userID, conversationID)
+}
+
+type UpdateScheduledCommand struct {
+	ScheduledID string
+	UserID      string
+	Content     string
+	Metadata    string
+	SendAt      time.Time
+}
+
+func (s *Service) UpdateScheduledMessage(
+	ctx context.Context,
+	cmd UpdateScheduledCommand,
+) (*domain.ScheduledMessage, error) {
+
+	var result *domain.ScheduledMessage
+
+	err := s.tx.WithTx(ctx, func(ctx context.Context, tx *sql.Tx) error {
+		m, err := s.repo.GetScheduledMessageForUpdate(ctx, tx, cmd.ScheduledID)
+		if err != nil {
+			return err
+		}
+
+		if t, ok := s.messageTypes().Lookup(m.Type); ok && m.SenderID == cmd.UserID {
+			if err := t.Validate(cmd.Content, cmd.Metadata, len(m.AttachmentIDs)); err != nil {
+				return err
+			}
+		}
+
+		if err := m.Edit(cmd.UserID, cmd.Content, cmd.Metadata, cmd.SendAt.UTC(), time.Now().UTC()); err != nil {
+			return err
+		}
+		if err := s.repo.UpdateScheduledMessage(ctx, tx, m); err != nil {
+			return err
+		}
+
+		result = m
+		return nil
+	})
+
+	if err != nil {
+		return nil, err
+	}
+	return result, nil
+}
+
+func (s *Service) CancelScheduledMessage(
+	ctx context.Context,
+	scheduledID string,
+	userID string,
+) error {
+
+	return s.tx.WithTx(ctx, func(ctx context.Context, tx *sql.Tx) error {
+		m, err := s.repo.GetScheduledMessageForUpdate(ctx, tx, scheduledID)
+		if err != nil {
+			return err
+		}
+
+		if err := m.Cancel(userID, time.Now().UTC()); err != nil {
+			return err
+		}
+		return s.repo.UpdateScheduledMessage(ctx, tx, m)
+	})
+}
+
+// SendScheduledMessage runs a due scheduled message through SendMessage, so
+// the sequence is allocated, membership re-checked and the outbox event
+// written at send time. The scheduled ID doubles as the idempotency key,
+// making a retry after a crash resolve to the message already sent.
+func (s *Service) SendScheduledMessage(
+	ctx context.Context,
+	m *domain.ScheduledMessage,
+) (*domain.Message, error) {
+
+	return s.SendMessage(ctx, SendMessageCommand{
+		ConversationID: m.ConversationID,
+		UserID:         m.SenderID,
+		ClientMsgID:    "scheduled:" + m.ID,
+		Type:           m.Type,
+		Content:        m.Content,
+		Metadata:       m.Metadata,
+		ReplyToID:      m.ReplyToID,
+		AttachmentIDs:  m.AttachmentIDs,
+		KeepDraft:      true,
+	})
+}
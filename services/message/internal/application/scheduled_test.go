@@ -0,0 +1,116 @@
+package application
+
+import (
+	"context"
+	"database/sql"
+	"testing"
+	"time"
+
+	"github.com/SARVESHVARADKAR123/RealChat/services/message/internal/domain"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+// MockScheduledRepo adds the scheduled message calls.
+type MockScheduledRepo struct {
+	MockRepo
+}
+
+func (m *MockScheduledRepo) GetScheduledMessageForUpdate(ctx context.Context, tx *sql.Tx, id string) (*domain.ScheduledMessage, error) {
+	args := m.Called(ctx, tx, id)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*domain.ScheduledMessage), args.Error(1)
+}
+func (m *MockScheduledRepo) UpdateScheduledMessage(ctx context.Context, tx *sql.Tx, sm *domain.ScheduledMessage) error {
+	return m.Called(ctx, tx, sm).Error(0)
+}
+
+func TestScheduledMessage(t *testing.T) {
+	ctx := context.Background()
+
+	pending := func() *domain.ScheduledMessage {
+		return &domain.ScheduledMessage{
+			ID:             "sched-1",
+			ConversationID: "conv-1",
+			SenderID:       "user-1",
+			Content:        "later",
+			SendAt:         time.Now().Add(time.Hour),
+			Status:         domain.ScheduledPending,
+		}
+	}
+
+	t.Run("Sender reschedules", func(t *testing.T) {
+		repo := new(MockScheduledRepo)
+		svc := &Service{repo: repo, tx: new(MockTransactor)}
+		sendAt := time.Now().Add(2 * time.Hour)
+
+		repo.On("GetScheduledMessageForUpdate", ctx, mock.Anything, "sched-1").Return(pending(), nil).Once()
+		repo.On("UpdateScheduledMessage", ctx, mock.Anything, mock.Anything).Return(nil).Once()
+
+		m, err := svc.UpdateScheduledMessage(ctx, UpdateScheduledCommand{
+			ScheduledID: "sched-1",
+			UserID:      "user-1",
+			Content:     "even later",
+			SendAt:      sendAt,
+		})
+		assert.NoError(t, err)
+		assert.Equal(t, "even later", m.Content)
+		assert.True(t, m.SendAt.Equal(sendAt.UTC()))
+		repo.AssertExpectations(t)
+	})
+
+	t.Run("Other user cannot cancel", func(t *testing.T) {
+		repo := new(MockScheduledRepo)
+		svc := &Service{repo: repo, tx: new(MockTransactor)}
+
+		repo.On("GetScheduledMessageForUpdate", ctx, mock.Anything, "sched-1").Return(pending(), nil).Once()
+
+		err := svc.CancelScheduledMessage(ctx, "sched-1", "user-2")
+		assert.ErrorIs(t, err, domain.ErrNotSender)
+		repo.AssertExpectations(t)
+	})
+
+	t.Run("Already sent cannot be cancelled", func(t *testing.T) {
+		repo := new(MockScheduledRepo)
+		svc := &Service{repo: repo, tx: new(MockTransactor)}
+		sent := pending()
+		sent.Status = domain.ScheduledSent
+
+		repo.On("GetScheduledMessageForUpdate", ctx, mock.Anything, "sched-1").Return(sent, nil).Once()
+
+		err := svc.CancelScheduledMessage(ctx, "sched-1", "user-1")
+		assert.ErrorIs(t, err, domain.ErrScheduleNotPending)
+		repo.AssertExpectations(t)
+	})
+
+	t.Run("Message being sent cannot be edited", func(t *testing.T) {
+		repo := new(MockScheduledRepo)
+		svc := &Service{repo: repo, tx: new(MockTransactor)}
+		claimed := time.Now()
+		sending := pending()
+		sending.Status, sending.ClaimedAt = domain.ScheduledSending, &claimed
+
+		repo.On("GetScheduledMessageForUpdate", ctx, mock.Anything, "sched-1").Return(sending, nil).Once()
+
+		_, err := svc.UpdateScheduledMessage(ctx, UpdateScheduledCommand{
+			ScheduledID: "sched-1",
+			UserID:      "user-1",
+			Content:     "too late",
+		})
+		assert.ErrorIs(t, err, domain.ErrScheduleNotPending)
+		repo.AssertNotCalled(t, "UpdateScheduledMessage", mock.Anything, mock.Anything, mock.Anything)
+	})
+
+	t.Run("Send time in the past is rejected", func(t *testing.T) {
+		svc := &Service{repo: new(MockScheduledRepo), tx: new(MockTransactor)}
+
+		_, err := svc.ScheduleMessage(ctx, SendMessageCommand{
+			ConversationID: "conv-1",
+			UserID:         "user-1",
+			Content:        "hi",
+		}, time.Now().Add(-time.Minute))
+		assert.ErrorIs(t, err, domain.ErrInvalidSchedule)
+	})
+}
@@ -0,0 +1,114 @@
+package application
+
+import (
+	"context"
+	"encoding/base64"
+	"strconv"
+	"strings"
+	"time"
+
+	conversationv1 "github.com/SARVESHVARADKAR123/RealChat/contracts/gen/go/conversation/v1"
+	"github.com/SARVESHVARADKAR123/RealChat/services/message/internal/domain"
+)
+
+type SearchCommand struct {
+	UserID         string
+	Query          string
+	ConversationID string
+	SenderID       string
+	Type           string
+	SentAfter      time.Time
+	SentBefore     time.Time
+	PageSize       int
+	PageToken      string
+}
+
+// SearchMessages searches the conversations userID belongs to. The returned
+// token is empty on the last page.
+func (s *Service) SearchMessages(
+	ctx context.Context,
+	cmd SearchCommand,
+) ([]*domain.SearchHit, string, error) {
+
+	if strings.TrimSpace(cmd.Query) == "" {
+		return nil, "", domain.ErrInvalidInput
+	}
+
+	pageSize := cmd.PageSize
+	if pageSize <= 0 || pageSize > 100 {
+		pageSize = 20
+	}
+
+	q := domain.SearchQuery{
+		Text:       cmd.Query,
+		SenderID:   cmd.SenderID,
+		Type:       cmd.Type,
+		SentAfter:  cmd.SentAfter,
+		SentBefore: cmd.SentBefore,
+		Limit:      pageSize + 1,
+	}
+
+	if cmd.PageToken != "" {
+		sentAt, id, err := decodeSearchToken(cmd.PageToken)
+		if err != nil {
+			return nil, "", domain.ErrInvalidInput
+		}
+		q.AfterSentAt, q.AfterID = sentAt, id
+	}
+
+	// Scope to the caller's conversations
+	resp, err := s.convSvc.ListConversations(ctx, &conversationv1.ListConversationsRequest{
+		UserId: cmd.UserID,
+	})
+	if err != nil {
+		return nil, "", err
+	}
+
+	for _, c := range resp.Conversations {
+		if cmd.ConversationID == "" || c.ConversationId == cmd.ConversationID {
+			q.ConversationIDs = append(q.ConversationIDs, c.ConversationId)
+		}
+	}
+
+	if cmd.ConversationID != "" && len(q.ConversationIDs) == 0 {
+		return nil, "", domain.ErrNotParticipant
+	}
+	if len(q.ConversationIDs) == 0 {
+		return nil, "", nil
+	}
+
+	hits, err := s.repo.SearchMessages(ctx, q)
+	if err != nil {
+		return nil, "", err
+	}
+
+	var next string
+	if len(hits) > pageSize {
+		hits = hits[:pageSize]
+		last := hits[len(hits)-1].Message
+		next = encodeSearchToken(last.SentAt, last.ID)
+	}
+
+	return hits, next, nil
+}
+
+func encodeSearchToken(sentAt time.Time, id string) string {
+	raw := strconv.FormatInt(sentAt.UnixNano(), 10) + ":" + id
+	return base64.RawURLEncoding.EncodeToString([]byte(raw))
+}
+
+func decodeSearchToken(token string) (time.Time, string, error) {
+	raw, err := base64.RawURLEncoding.DecodeString(token)
+	if err != nil {
+		return time.Time{}, "", err
+	}
+	nanos, id, ok := strings.Cut(string(raw), ":")
+	if !ok || id == "" {
+		return time.Time{}, "", domain.ErrInvalidInput
+	}
+	n, err := strconv.ParseInt(nanos, 10, 64)
+	if err != nil {
+		return time.Time{}, "", err
+	}
+	return time.Unix(0, n).UTC(), id, nil
+}
@@ -0,0 +1,72 @@
+package application
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	conversationv1 "github.com/SARVESHVARADKAR123/RealChat/contracts/gen/go/conversation/v1"
+	"github.com/SARVESHVARADKAR123/RealChat/services/message/internal/domain"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+// MockSearchRepo adds the full-text search.
+type MockSearchRepo struct {
+	MockRepo
+}
+
+func (m *MockSearchRepo) SearchMessages(ctx context.Context, q domain.SearchQuery) ([]*domain.SearchHit, error) {
+	args := m.Called(ctx, q)
+	return args.Get(0).([]*domain.SearchHit), args.Error(1)
+}
+
+func TestSearchMessages(t *testing.T) {
+	ctx := context.Background()
+	convs := &conversationv1.ListConversationsResponse{
+		Conversations: []*conversationv1.Conversation{
+			{ConversationId: "conv-1"},
+			{ConversationId: "conv-2"},
+		},
+	}
+
+	t.Run("Scopes to caller conversations and pages", func(t *testing.T) {
+		repo := new(MockSearchRepo)
+		convSvc := new(MockConvClient)
+		svc := &Service{repo: repo, tx: new(MockTransactor), convSvc: convSvc}
+
+		sentAt := time.Unix(1700000000, 0).UTC()
+		hits := []*domain.SearchHit{
+			{Message: &domain.Message{ID: "m2", SentAt: sentAt.Add(time.Second)}},
+			{Message: &domain.Message{ID: "m1", SentAt: sentAt}},
+		}
+
+		convSvc.On("ListConversations", ctx, &conversationv1.ListConversationsRequest{UserId: "user-1"}).Return(convs, nil).Once()
+		repo.On("SearchMessages", ctx, mock.MatchedBy(func(q domain.SearchQuery) bool {
+			return q.Limit == 2 && assert.ObjectsAreEqual([]string{"conv-1", "conv-2"}, q.ConversationIDs)
+		})).Return(hits, nil).Once()
+
+		got, next, err := svc.SearchMessages(ctx, SearchCommand{UserID: "user-1", Query: "hello", PageSize: 1})
+		assert.NoError(t, err)
+		assert.Len(t, got, 1)
+		assert.NotEmpty(t, next)
+
+		at, id, err := decodeSearchToken(next)
+		assert.NoError(t, err)
+		assert.Equal(t, "m2", id)
+		assert.True(t, at.Equal(sentAt.Add(time.Second)))
+		repo.AssertExpectations(t)
+	})
+
+	t.Run("Foreign conversation filter is rejected", func(t *testing.T) {
+		repo := new(MockSearchRepo)
+		convSvc := new(MockConvClient)
+		svc := &Service{repo: repo, tx: new(MockTransactor), convSvc: convSvc}
+
+		convSvc.On("ListConversations", ctx, mock.Anything).Return(convs, nil).Once()
+
+		_, _, err := svc.SearchMessages(ctx, SearchCommand{UserID: "user-1", Query: "hello", ConversationID: "conv-9"})
+		assert.ErrorIs(t, err, domain.ErrNotParticipant)
+		repo.AssertExpectations(t)
+	})
+}
@@ -4,13 +4,14 @@ import (
 	"context"
 	"database/sql"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"time"
 
-	conversationv1 "github.com/SARVESHVARADKAR123/RealChat/contracts/gen/go/conversation/v1"
 	messagev1 "github.com/SARVESHVARADKAR123/RealChat/contracts/gen/go/message/v1"
 	sharedv1 "github.com/SARVESHVARADKAR123/RealChat/contracts/gen/go/shared/v1"
 	"github.com/SARVESHVARADKAR123/RealChat/services/message/internal/domain"
+	"github.com/SARVESHVARADKAR123/RealChat/services/message/internal/moderation"
 	"github.com/google/uuid"
 	"go.uber.org/zap"
 	"google.golang.org/protobuf/proto"
@@ -24,6 +25,17 @@ type SendMessageCommand struct {
 	Type           string
 	Content        string
 	Metadata       string
+	ReplyToID      string
+	AttachmentIDs  []string
+	// SenderDeviceID and Ciphertexts carry an encrypted message.
+	SenderDeviceID string
+	Ciphertexts    []domain.DeviceCiphertext
+	// KeepDraft leaves the sender's draft alone, for sends the user did not
+	// just compose.
+	KeepDraft bool
+
+	// skipModeration is set for held messages a moderator approved.
+	skipModeration bool
 }
 
 func (s *Service) SendMessage(
@@ -36,16 +48,53 @@ func (s *Service) SendMessage(
 		zap.String("user_id", cmd.UserID),
 	)
 
-	var result *domain.Message
+	// A retry gets its original answer, even if the checks below would turn
+	// it down now
+	payload, err := s.repo.GetIdempotencyResponse(ctx, cmd.ClientMsgID, cmd.UserID, cmd.ConversationID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch idempotency response: %w", err)
+	}
+	if payload != nil {
+		var msg domain.Message
+		if err := json.Unmarshal(payload, &msg); err != nil {
+			return nil, fmt.Errorf("failed to unmarshal cached message: %w", err)
+		}
+		s.signAttachmentURLs(ctx, []*domain.Message{&msg})
+		return &msg, nil
+	}
+
+	if err := s.validateSend(&cmd); err != nil {
+		return nil, err
+	}
 
-	err := s.tx.WithTx(ctx, func(ctx context.Context, tx *sql.Tx) error {
+	conv, err := s.requireParticipant(ctx, cmd.ConversationID, cmd.UserID)
+	if err != nil {
+		return nil, err
+	}
+	if err := domain.ValidateCiphertexts(cmd.Type, cmd.SenderDeviceID, cmd.Ciphertexts, conv.GetParticipantUserIds()); err != nil {
+		return nil, err
+	}
+
+	// Screened before the transaction, as hooks may be slow and the
+	// sequence block stays locked until commit
+	screened, err := s.moderate(ctx, &cmd)
+	if err != nil {
+		return nil, err
+	}
+
+	var (
+		result *domain.Message
+		held   *domain.HeldMessage
+	)
+
+	err = s.tx.WithTx(ctx, func(ctx context.Context, tx *sql.Tx) error {
 
 		owned, err := s.repo.TryInsertIdempotency(
 			ctx, tx,
 			cmd.ClientMsgID,
 			cmd.UserID,
 			cmd.ConversationID,
-			time.Now().Add(24*time.Hour),
+			time.Now().Add(s.idempotencyTTL()),
 		)
 		if err != nil {
 			return fmt.Errorf("failed to check idempotency: %w", err)
@@ -71,22 +120,25 @@ func (s *Service) SendMessage(
 			}
 		}
 
-		// Parallelize calls to conversation service
-		resp, seq, err := s.parallelConvCalls(ctx, cmd.ConversationID)
-		if err != nil {
-			return err
+		if screened != nil {
+			switch screened.Action {
+			case moderation.Reject:
+				return fmt.Errorf("%w: %s", domain.ErrMessageRejected, screened.Reason)
+			case moderation.Hold:
+				held, err = s.holdMessage(ctx, tx, cmd, screened)
+				return err
+			}
 		}
 
-		isParticipant := false
-		for _, pID := range resp.ParticipantUserIds {
-			if pID == cmd.UserID {
-				isParticipant = true
-				break
-			}
+		attachments, err := s.resolveAttachments(ctx, cmd.UserID, cmd.AttachmentIDs)
+		if err != nil {
+			return err
 		}
 
-		if !isParticipant {
-			return domain.ErrNotParticipant
+		// Allocated last, as the sequence block stays locked until commit
+		seq, err := s.allocateSequences(ctx, tx, cmd.ConversationID, 1)
+		if err != nil {
+			return err
 		}
 
 		s.log.Info("Message sequence generated successfully", zap.Any("sequence", seq))
@@ -105,12 +157,78 @@ func (s *Service) SendMessage(
 			return fmt.Errorf("failed to create new message: %w", err)
 		}
 
+		msg.ExpireAfter(time.Duration(conv.GetMessageTtlSeconds()) * time.Second)
+
 		s.log.Info("Message created successfully", zap.Any("message", msg))
 
+		if cmd.ReplyToID != "" {
+			parent, err := s.repo.GetMessage(ctx, tx, cmd.ReplyToID)
+			if err != nil && err != domain.ErrMessageNotFound {
+				return fmt.Errorf("failed to load reply target: %w", err)
+			}
+			if err := msg.ReplyTo(parent); err != nil {
+				return err
+			}
+		}
+
+		if msg.Type == domain.TypeEncrypted {
+			msg.SenderDeviceID = cmd.SenderDeviceID
+		}
+
 		if err := s.repo.InsertMessage(ctx, tx, msg); err != nil {
 			return fmt.Errorf("failed to save message: %w", err)
 		}
 
+		if msg.Type == domain.TypeEncrypted {
+			msg.Ciphertexts = cmd.Ciphertexts
+			if err := s.repo.InsertMessageCiphertexts(ctx, tx, msg.ID, msg.Ciphertexts); err != nil {
+				return fmt.Errorf("failed to save message ciphertexts: %w", err)
+			}
+		}
+
+		if msg.Type == domain.TypePoll {
+			if err := s.createPoll(ctx, tx, msg); err != nil {
+				return err
+			}
+			if err := msg.Poll.CheckOpen(msg.SentAt); err != nil {
+				return err
+			}
+		}
+
+		msg.Mentions = domain.ParseMentions(msg.Content, msg.Metadata).Resolve(
+			cmd.UserID,
+			conv.GetParticipantUserIds(),
+			hasAdminRights(conv, cmd.UserID),
+		)
+		if err := s.repo.InsertMentions(ctx, tx, msg); err != nil {
+			return fmt.Errorf("failed to save mentions: %w", err)
+		}
+
+		if len(attachments) > 0 {
+			msg.Attachments = attachments
+			if err := s.repo.InsertMessageAttachments(ctx, tx, msg.ID, attachments); err != nil {
+				return fmt.Errorf("failed to save message attachments: %w", err)
+			}
+		}
+
+		if msg.ThreadRootID != "" {
+			if err := s.repo.RecordThreadReply(ctx, tx, msg.ThreadRootID, msg.SentAt); err != nil {
+				return fmt.Errorf("failed to update thread summary: %w", err)
+			}
+		}
+
+		if !cmd.KeepDraft {
+			if err := s.clearDraftOnSend(ctx, tx, cmd.ConversationID, cmd.UserID, msg.SentAt); err != nil {
+				return err
+			}
+		}
+
+		if screened != nil {
+			if err := s.recordModeration(ctx, tx, cmd.ConversationID, cmd.UserID, screened, msg.ID, ""); err != nil {
+				return err
+			}
+		}
+
 		s.log.Info("Message inserted successfully", zap.Any("message", msg))
 
 		payload, err := json.Marshal(msg)
@@ -120,21 +238,13 @@ func (s *Service) SendMessage(
 		s.log.Info("Message marshaled successfully", zap.Any("message", msg))
 
 		// Emit Event
-		pbMsg := &messagev1.Message{
-			MessageId:      msg.ID,
-			ConversationId: msg.ConversationID,
-			SenderUserId:   msg.SenderID,
-			Sequence:       msg.Sequence,
-			MessageType:    msg.Type,
-			Content:        msg.Content,
-			MetadataJson:   msg.Metadata,
-			SentAt:         timestamppb.New(msg.SentAt),
-		}
+		pbMsg := ToProtoMessage(msg)
 
 		s.log.Info("Message created successfully", zap.Any("message", pbMsg))
 
 		event := &messagev1.MessageSentEvent{
-			Message: pbMsg,
+			Message:          pbMsg,
+			MentionedUserIds: msg.Mentions,
 		}
 		eventPayload, err := proto.Marshal(event)
 		if err != nil {
@@ -178,46 +288,30 @@ func (s *Service) SendMessage(
 		result = msg
 		return nil
 	})
-
-	return result, err
-}
-
-func (s *Service) parallelConvCalls(ctx context.Context, convID string) (*conversationv1.GetConversationResponse, int64, error) {
-	type convRes struct {
-		resp *conversationv1.GetConversationResponse
-		err  error
+	if errors.Is(err, domain.ErrMessageRejected) && screened != nil && screened.Action == moderation.Reject {
+		// The rejection rolled back the send, so it is recorded on its own
+		if auditErr := s.recordModeration(ctx, nil, cmd.ConversationID, cmd.UserID, screened, "", ""); auditErr != nil {
+			return nil, auditErr
+		}
 	}
-	type seqRes struct {
-		resp *conversationv1.NextSequenceResponse
-		err  error
+	if err != nil {
+		return nil, err
 	}
-
-	convChan := make(chan convRes, 1)
-	seqChan := make(chan seqRes, 1)
-
-	go func() {
-		resp, err := s.convSvc.GetConversation(ctx, &conversationv1.GetConversationRequest{
-			ConversationId: convID,
-		})
-		convChan <- convRes{resp, err}
-	}()
-
-	go func() {
-		resp, err := s.convSvc.NextSequence(ctx, &conversationv1.NextSequenceRequest{
-			ConversationId: convID,
-		})
-		seqChan <- seqRes{resp, err}
-	}()
-
-	cr := <-convChan
-	if cr.err != nil {
-		return nil, 0, fmt.Errorf("failed to fetch conversation via gRPC: %w", cr.err)
+	if held != nil {
+		return nil, &domain.HeldError{Held: held}
 	}
 
-	sr := <-seqChan
-	if sr.err != nil {
-		return nil, 0, fmt.Errorf("failed to generate message sequence: %w", sr.err)
-	}
+	// Signed only now, so the media call holds no locks and the expiring
+	// URLs stay out of the event and the idempotency cache
+	s.signAttachmentURLs(ctx, []*domain.Message{result})
+	return result, nil
+}
 
-	return cr.resp, sr.resp.Sequence, nil
+// validateSend checks cmd against its message type, defaulting the type to
+// text.
+func (s *Service) validateSend(cmd *SendMessageCommand) error {
+	if cmd.Type == "" {
+		cmd.Type = domain.TypeText
+	}
+	return s.messageTypes().Validate(cmd.Type, cmd.Content, cmd.Metadata, len(cmd.AttachmentIDs))
 }
@@ -0,0 +1,56 @@
+package application
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+
+	conversationv1 "github.com/SARVESHVARADKAR123/RealChat/contracts/gen/go/conversation/v1"
+)
+
+const defaultSequenceBlock = 100
+
+func (s *Service) sequenceBlock() int {
+	if s.opts.SequenceBlock > 0 {
+		return s.opts.SequenceBlock
+	}
+	return defaultSequenceBlock
+}
+
+// allocateSequences takes n consecutive sequences for conversationID within
+// tx and returns the first. Only when the local block runs out does it call
+// the conversation service, to reserve the range right after it.
+//
+// The block stays locked until tx ends and a rollback hands its sequences
+// back. A reservation made by a rolled-back tx is not lost either: the retry
+// asks for the range after the same sequence and gets the same block, so
+// committed sequences stay gapless.
+func (s *Service) allocateSequences(ctx context.Context, tx *sql.Tx, conversationID string, n int) (int64, error) {
+	block, err := s.repo.GetSequenceBlockForUpdate(ctx, tx, conversationID)
+	if err != nil {
+		return 0, fmt.Errorf("failed to load sequence block: %w", err)
+	}
+
+	if block.Remaining() < int64(n) {
+		resp, err := s.convSvc.ReserveSequences(ctx, &conversationv1.ReserveSequencesRequest{
+			ConversationId: conversationID,
+			AfterSequence:  block.Last,
+			Count:          int64(max(n, s.sequenceBlock())),
+		})
+		if err != nil {
+			return 0, fmt.Errorf("failed to reserve message sequences: %w", err)
+		}
+		if err := block.Extend(resp.FirstSequence, resp.LastSequence); err != nil {
+			return 0, err
+		}
+	}
+
+	first, err := block.Take(int64(n))
+	if err != nil {
+		return 0, err
+	}
+	if err := s.repo.SaveSequenceBlock(ctx, tx, block); err != nil {
+		return 0, fmt.Errorf("failed to save sequence block: %w", err)
+	}
+	return first, nil
+}
@@ -0,0 +1,185 @@
+package application
+
+import (
+	"context"
+	"database/sql"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	conversationv1 "github.com/SARVESHVARADKAR123/RealChat/contracts/gen/go/conversation/v1"
+	"github.com/SARVESHVARADKAR123/RealChat/services/message/internal/domain"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"go.uber.org/zap"
+	"google.golang.org/grpc"
+)
+
+func TestSequenceBlock(t *testing.T) {
+	b := &domain.SequenceBlock{ConversationID: "conv-1"}
+
+	_, err := b.Take(1)
+	assert.ErrorIs(t, err, domain.ErrSequencesExhausted)
+
+	assert.NoError(t, b.Extend(1, 3))
+	first, err := b.Take(2)
+	assert.NoError(t, err)
+	assert.Equal(t, int64(1), first)
+	assert.Equal(t, int64(1), b.Remaining())
+
+	// Only the range right after the block may extend it
+	assert.ErrorIs(t, b.Extend(5, 10), domain.ErrSequenceGap)
+	assert.NoError(t, b.Extend(4, 10))
+
+	first, err = b.Take(2)
+	assert.NoError(t, err)
+	assert.Equal(t, int64(3), first)
+	assert.Equal(t, int64(6), b.Remaining())
+}
+
+// blockRepo keeps sequence blocks in memory.
+type blockRepo struct {
+	*MockRepo
+	blocks map[string]domain.SequenceBlock
+}
+
+func (r *blockRepo) GetSequenceBlockForUpdate(ctx context.Context, tx *sql.Tx, conversationID string) (*domain.SequenceBlock, error) {
+	b, ok := r.blocks[conversationID]
+	if !ok {
+		b = domain.SequenceBlock{ConversationID: conversationID}
+	}
+	return &b, nil
+}
+
+func (r *blockRepo) SaveSequenceBlock(ctx context.Context, tx *sql.Tx, b *domain.SequenceBlock) error {
+	r.blocks[b.ConversationID] = *b
+	return nil
+}
+
+func TestAllocateSequences(t *testing.T) {
+	ctx := context.Background()
+	convSvc := new(MockConvClient)
+	repo := &blockRepo{MockRepo: new(MockRepo), blocks: map[string]domain.SequenceBlock{}}
+	svc := &Service{repo: repo, convSvc: convSvc, opts: Options{SequenceBlock: 3}}
+
+	reserve := func(after, count int64) interface{} {
+		return mock.MatchedBy(func(r *conversationv1.ReserveSequencesRequest) bool {
+			return r.ConversationId == "conv-1" && r.AfterSequence == after && r.Count == count
+		})
+	}
+	convSvc.On("ReserveSequences", ctx, reserve(0, 3)).Return(&conversationv1.ReserveSequencesResponse{FirstSequence: 8, LastSequence: 10}, nil).Once()
+	convSvc.On("ReserveSequences", ctx, reserve(10, 3)).Return(&conversationv1.ReserveSequencesResponse{FirstSequence: 11, LastSequence: 13}, nil).Once()
+	convSvc.On("ReserveSequences", ctx, reserve(13, 7)).Return(&conversationv1.ReserveSequencesResponse{FirstSequence: 14, LastSequence: 20}, nil).Once()
+
+	var got []int64
+	for i := 0; i < 4; i++ {
+		seq, err := svc.allocateSequences(ctx, nil, "conv-1", 1)
+		assert.NoError(t, err)
+		got = append(got, seq)
+	}
+	assert.Equal(t, []int64{8, 9, 10, 11}, got)
+
+	// A batch larger than the block reserves what it needs, continuing after
+	// the sequences already held
+	first, err := svc.allocateSequences(ctx, nil, "conv-1", 7)
+	assert.NoError(t, err)
+	assert.Equal(t, int64(12), first)
+	assert.Equal(t, domain.SequenceBlock{ConversationID: "conv-1", Next: 19, Last: 20}, repo.blocks["conv-1"])
+
+	convSvc.AssertExpectations(t)
+}
+
+// slowConvClient answers like the conversation service, one network round
+// trip away.
+type slowConvClient struct {
+	conversationv1.ConversationApiClient
+	rtt   time.Duration
+	calls atomic.Int64
+	next  atomic.Int64
+}
+
+func (c *slowConvClient) GetConversation(ctx context.Context, req *conversationv1.GetConversationRequest, opts ...grpc.CallOption) (*conversationv1.GetConversationResponse, error) {
+	c.calls.Add(1)
+	time.Sleep(c.rtt)
+	return &conversationv1.GetConversationResponse{
+		Conversation: &conversationv1.Conversation{
+			ConversationId:        req.ConversationId,
+			Type:                  conversationv1.ConversationType_GROUP,
+			ParticipantUserIds:    []string{"user-1"},
+			ParticipantsWithRoles: []*conversationv1.Participant{{UserId: "user-1", Role: conversationv1.ParticipantRole_ADMIN}},
+		},
+	}, nil
+}
+
+func (c *slowConvClient) ReserveSequences(ctx context.Context, req *conversationv1.ReserveSequencesRequest, opts ...grpc.CallOption) (*conversationv1.ReserveSequencesResponse, error) {
+	c.calls.Add(1)
+	time.Sleep(c.rtt)
+	last := c.next.Add(req.Count)
+	return &conversationv1.ReserveSequencesResponse{FirstSequence: last - req.Count + 1, LastSequence: last}, nil
+}
+
+// benchRepo accepts writes and keeps the projection and sequence blocks in
+// memory.
+type benchRepo struct {
+	blockRepo
+	info *domain.ConversationInfo
+}
+
+func (r *benchRepo) GetConversationInfo(ctx context.Context, tx *sql.Tx, conversationID string) (*domain.ConversationInfo, error) {
+	return r.info, nil
+}
+func (r *benchRepo) InsertMessage(ctx context.Context, tx *sql.Tx, msg *domain.Message) error {
+	return nil
+}
+func (r *benchRepo) InsertMentions(ctx context.Context, tx *sql.Tx, msg *domain.Message) error {
+	return nil
+}
+func (r *benchRepo) ReplaceMentions(ctx context.Context, tx *sql.Tx, msg *domain.Message) ([]string, error) {
+	return nil, nil
+}
+func (r *benchRepo) GetDraftForUpdate(ctx context.Context, tx *sql.Tx, userID, conversationID string) (*domain.Draft, error) {
+	return nil, nil
+}
+func (r *benchRepo) InsertOutbox(ctx context.Context, tx *sql.Tx, aggregateType, aggregateID, eventType string, payload []byte) error {
+	return nil
+}
+
+// BenchmarkSendMessage compares sending with a call to the conversation
+// service on every message against the projection and sequence blocks. Run
+// with -benchtime=2000x; the rpcs/op metric is what drives the difference.
+func BenchmarkSendMessage(b *testing.B) {
+	const rtt = 500 * time.Microsecond
+	member := &domain.ConversationInfo{ID: "conv-1", Group: true, Members: []domain.Member{{UserID: "user-1", Admin: true}}}
+
+	cases := []struct {
+		name  string
+		info  *domain.ConversationInfo
+		block int
+	}{
+		// What every send paid before: membership and a sequence fetched remotely
+		{"remote lookup per message", nil, 1},
+		{"projection and sequence blocks", member, defaultSequenceBlock},
+	}
+
+	for _, c := range cases {
+		b.Run(c.name, func(b *testing.B) {
+			convSvc := &slowConvClient{rtt: rtt}
+			repo := &benchRepo{blockRepo: blockRepo{MockRepo: new(MockRepo), blocks: map[string]domain.SequenceBlock{}}, info: c.info}
+			svc := &Service{repo: repo, tx: new(MockTransactor), convSvc: convSvc, log: zap.NewNop(), opts: Options{SequenceBlock: c.block}}
+			ctx := context.Background()
+
+			b.ResetTimer()
+			for i := 0; i < b.N; i++ {
+				if _, err := svc.SendMessage(ctx, SendMessageCommand{
+					ConversationID: "conv-1",
+					UserID:         "user-1",
+					ClientMsgID:    "msg",
+					Content:        "hello",
+				}); err != nil {
+					b.Fatal(err)
+				}
+			}
+			b.ReportMetric(float64(convSvc.calls.Load())/float64(b.N), "rpcs/op")
+		})
+	}
+}
@@ -1,19 +1,80 @@
 package application
 
 import (
+	"time"
+
 	conversationv1 "github.com/SARVESHVARADKAR123/RealChat/contracts/gen/go/conversation/v1"
+	mediav1 "github.com/SARVESHVARADKAR123/RealChat/contracts/gen/go/media/v1"
+	profilev1 "github.com/SARVESHVARADKAR123/RealChat/contracts/gen/go/profile/v1"
+	"github.com/SARVESHVARADKAR123/RealChat/services/message/internal/archive"
+	"github.com/SARVESHVARADKAR123/RealChat/services/message/internal/domain"
 	"github.com/SARVESHVARADKAR123/RealChat/services/message/internal/repository"
 	"github.com/SARVESHVARADKAR123/RealChat/services/message/internal/tx"
 	"go.uber.org/zap"
 )
 
+// Options holds the tunable message policies of the service.
+type Options struct {
+	// EditWindow is how long after sending a message can still be edited.
+	// Zero disables the limit.
+	EditWindow time.Duration
+
+	// MaxPins caps how many messages a conversation can have pinned.
+	MaxPins int
+
+	// MessageTypes validates message types and their metadata. Nil means
+	// domain.DefaultMessageTypes.
+	MessageTypes *domain.MessageTypeRegistry
+
+	// SequenceBlock is how many message sequences are reserved from the
+	// conversation service at a time. Zero means 100.
+	SequenceBlock int
+
+	// Retention is how long messages are kept before they are archived,
+	// unless a conversation overrides it. Zero keeps them forever.
+	Retention time.Duration
+
+	// RestoreHold is how long restored archives stay readable before they
+	// are archived again. Zero means a week.
+	RestoreHold time.Duration
+
+	// IdempotencyTTL is how long SendMessage and ForwardMessages honour a
+	// client's idempotency key. Zero means a day.
+	IdempotencyTTL time.Duration
+
+	// ImportAdmins are the users allowed to request history imports through
+	// the API. Imports attribute messages to other users, so by default
+	// nobody is; the admin command is not restricted.
+	ImportAdmins []string
+
+	// Moderation screens every send before it is stored. Nil sends
+	// messages unscreened.
+	Moderation Moderator
+
+	// Moderators are the users allowed to review held messages.
+	Moderators []string
+}
+
 type Service struct {
-	repo    repository.Repository
-	tx      tx.Transactor
-	convSvc conversationv1.ConversationApiClient
-	log     *zap.Logger
+	repo     repository.Repository
+	tx       tx.Transactor
+	convSvc  conversationv1.ConversationApiClient
+	media    mediav1.MediaApiClient
+	profiles profilev1.ProfileApiClient
+	archive  archive.Store
+	log      *zap.Logger
+	opts     Options
+}
+
+var defaultMessageTypes = domain.DefaultMessageTypes()
+
+func (s *Service) messageTypes() *domain.MessageTypeRegistry {
+	if s.opts.MessageTypes != nil {
+		return s.opts.MessageTypes
+	}
+	return defaultMessageTypes
 }
 
-func New(repo repository.Repository, transactor tx.Transactor, convSvc conversationv1.ConversationApiClient, log *zap.Logger) *Service {
-	return &Service{repo: repo, tx: transactor, convSvc: convSvc, log: log}
+func New(repo repository.Repository, transactor tx.Transactor, convSvc conversationv1.ConversationApiClient, media mediav1.MediaApiClient, profiles profilev1.ProfileApiClient, archives archive.Store, log *zap.Logger, opts Options) *Service {
+	return &Service{repo: repo, tx: transactor, convSvc: convSvc, media: media, profiles: profiles, archive: archives, log: log, opts: opts}
 }
@@ -3,46 +3,103 @@ package application
 import (
 	"context"
 
-	conversationv1 "github.com/SARVESHVARADKAR123/RealChat/contracts/gen/go/conversation/v1"
 	"github.com/SARVESHVARADKAR123/RealChat/services/message/internal/domain"
 )
 
+// SyncQuery selects one page of a conversation's history. AroundSequence
+// takes precedence over BeforeSequence, which takes precedence over
+// AfterSequence.
+type SyncQuery struct {
+	ConversationID string
+	UserID         string
+	// DeviceID selects which ciphertext encrypted messages carry.
+	DeviceID       string
+	AfterSequence  int64
+	BeforeSequence int64
+	AroundSequence int64
+	PageSize       int
+}
+
+// SyncResult is a page of messages in ascending sequence order.
+type SyncResult struct {
+	Messages      []*domain.Message
+	HasMoreBefore bool
+	HasMoreAfter  bool
+}
+
 func (s *Service) SyncMessages(
 	ctx context.Context,
-	conversationID string,
-	userID string,
-	afterSequence int64,
-	pageSize int,
-) ([]*domain.Message, error) {
+	q SyncQuery,
+) (*SyncResult, error) {
 
+	pageSize := q.PageSize
 	if pageSize <= 0 || pageSize > 500 {
 		pageSize = 100
 	}
 
 	// Verify membership
-	resp, err := s.convSvc.GetConversation(ctx, &conversationv1.GetConversationRequest{
-		ConversationId: conversationID,
-	})
-	if err != nil {
+	if _, err := s.requireParticipant(ctx, q.ConversationID, q.UserID); err != nil {
 		return nil, err
 	}
 
-	isParticipant := false
-	for _, pID := range resp.ParticipantUserIds {
-		if pID == userID {
-			isParticipant = true
-			break
+	var (
+		messages []*domain.Message
+		err      error
+		// lo/hi bound the window that was read, used when it came back empty
+		lo, hi int64
+	)
+
+	switch {
+	case q.AroundSequence > 0:
+		lo, hi = q.AroundSequence, q.AroundSequence-1
+		messages, err = s.repo.FetchMessagesBefore(ctx, q.ConversationID, q.AroundSequence, pageSize/2)
+		if err != nil {
+			return nil, err
 		}
+		var newer []*domain.Message
+		newer, err = s.repo.FetchMessages(ctx, q.ConversationID, q.AroundSequence-1, pageSize-len(messages))
+		messages = append(messages, newer...)
+
+	case q.BeforeSequence > 0:
+		lo, hi = q.BeforeSequence, q.BeforeSequence-1
+		messages, err = s.repo.FetchMessagesBefore(ctx, q.ConversationID, q.BeforeSequence, pageSize)
+
+	default:
+		lo, hi = q.AfterSequence+1, q.AfterSequence
+		messages, err = s.repo.FetchMessages(ctx, q.ConversationID, q.AfterSequence, pageSize)
+	}
+	if err != nil {
+		return nil, err
 	}
 
-	if !isParticipant {
-		return nil, domain.ErrNotParticipant
+	if len(messages) > 0 {
+		lo, hi = messages[0].Sequence, messages[len(messages)-1].Sequence
 	}
 
-	return s.repo.FetchMessages(
-		ctx,
-		conversationID,
-		afterSequence,
-		pageSize,
-	)
+	hasBefore, hasAfter, err := s.repo.HasMessagesOutside(ctx, q.ConversationID, lo, hi)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := s.attachReactions(ctx, q.UserID, messages); err != nil {
+		return nil, err
+	}
+
+	if err := s.attachAttachments(ctx, messages); err != nil {
+		return nil, err
+	}
+
+	if err := s.attachPolls(ctx, q.UserID, messages); err != nil {
+		return nil, err
+	}
+
+	if err := s.attachCiphertexts(ctx, nil, messages, q.UserID, q.DeviceID); err != nil {
+		return nil, err
+	}
+
+	return &SyncResult{
+		Messages:      messages,
+		HasMoreBefore: hasBefore,
+		HasMoreAfter:  hasAfter,
+	}, nil
 }
@@ -0,0 +1,86 @@
+package application
+
+import (
+	"context"
+	"database/sql"
+	"testing"
+
+	conversationv1 "github.com/SARVESHVARADKAR123/RealChat/contracts/gen/go/conversation/v1"
+	"github.com/SARVESHVARADKAR123/RealChat/services/message/internal/domain"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func seqMessages(from, to int64) []*domain.Message {
+	var out []*domain.Message
+	for seq := from; seq <= to; seq++ {
+		out = append(out, &domain.Message{ID: "m", ConversationID: "conv-1", Sequence: seq})
+	}
+	return out
+}
+
+// MockSyncRepo adds the history reads behind SyncMessages.
+type MockSyncRepo struct {
+	MockRepo
+}
+
+func (m *MockSyncRepo) FetchMessages(ctx context.Context, convID string, lastSeq int64, limit int) ([]*domain.Message, error) {
+	args := m.Called(ctx, convID, lastSeq, limit)
+	return args.Get(0).([]*domain.Message), args.Error(1)
+}
+func (m *MockSyncRepo) FetchMessagesBefore(ctx context.Context, convID string, beforeSeq int64, limit int) ([]*domain.Message, error) {
+	args := m.Called(ctx, convID, beforeSeq, limit)
+	return args.Get(0).([]*domain.Message), args.Error(1)
+}
+func (m *MockSyncRepo) HasMessagesOutside(ctx context.Context, convID string, minSeq, maxSeq int64) (bool, bool, error) {
+	args := m.Called(ctx, convID, minSeq, maxSeq)
+	return args.Bool(0), args.Bool(1), args.Error(2)
+}
+func (m *MockSyncRepo) ListReactionSummaries(ctx context.Context, tx *sql.Tx, messageIDs []string, viewerID string) (map[string][]domain.ReactionSummary, error) {
+	args := m.Called(ctx, tx, messageIDs, viewerID)
+	return args.Get(0).(map[string][]domain.ReactionSummary), args.Error(1)
+}
+
+func TestSyncMessages(t *testing.T) {
+	ctx := context.Background()
+	conv := &conversationv1.GetConversationResponse{ParticipantUserIds: []string{"user-1"}}
+	noReactions := map[string][]domain.ReactionSummary{}
+
+	t.Run("Around sequence splits the page", func(t *testing.T) {
+		repo := new(MockSyncRepo)
+		convSvc := new(MockConvClient)
+		svc := &Service{repo: repo, tx: new(MockTransactor), convSvc: convSvc}
+
+		convSvc.On("GetConversation", ctx, mock.Anything).Return(conv, nil).Once()
+		repo.On("FetchMessagesBefore", ctx, "conv-1", int64(50), 5).Return(seqMessages(45, 49), nil).Once()
+		repo.On("FetchMessages", ctx, "conv-1", int64(49), 5).Return(seqMessages(50, 54), nil).Once()
+		repo.On("HasMessagesOutside", ctx, "conv-1", int64(45), int64(54)).Return(true, true, nil).Once()
+		repo.On("ListReactionSummaries", ctx, mock.Anything, mock.Anything, "user-1").Return(noReactions, nil).Once()
+		repo.On("ListMessageAttachments", ctx, mock.Anything, mock.Anything).Return(map[string][]domain.Attachment{}, nil).Once()
+
+		page, err := svc.SyncMessages(ctx, SyncQuery{ConversationID: "conv-1", UserID: "user-1", AroundSequence: 50, PageSize: 10})
+		assert.NoError(t, err)
+		assert.Len(t, page.Messages, 10)
+		assert.Equal(t, int64(45), page.Messages[0].Sequence)
+		assert.True(t, page.HasMoreBefore)
+		assert.True(t, page.HasMoreAfter)
+		repo.AssertExpectations(t)
+	})
+
+	t.Run("Empty forward page reports older history", func(t *testing.T) {
+		repo := new(MockSyncRepo)
+		convSvc := new(MockConvClient)
+		svc := &Service{repo: repo, tx: new(MockTransactor), convSvc: convSvc}
+
+		convSvc.On("GetConversation", ctx, mock.Anything).Return(conv, nil).Once()
+		repo.On("FetchMessages", ctx, "conv-1", int64(20), 100).Return([]*domain.Message(nil), nil).Once()
+		repo.On("HasMessagesOutside", ctx, "conv-1", int64(21), int64(20)).Return(true, false, nil).Once()
+
+		page, err := svc.SyncMessages(ctx, SyncQuery{ConversationID: "conv-1", UserID: "user-1", AfterSequence: 20})
+		assert.NoError(t, err)
+		assert.Empty(t, page.Messages)
+		assert.True(t, page.HasMoreBefore)
+		assert.False(t, page.HasMoreAfter)
+		repo.AssertExpectations(t)
+	})
+}
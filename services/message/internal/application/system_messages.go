@@ -0,0 +1,116 @@
+package application
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"fmt"
+	"time"
+
+	messagev1 "github.com/SARVESHVARADKAR123/RealChat/contracts/gen/go/message/v1"
+	sharedv1 "github.com/SARVESHVARADKAR123/RealChat/contracts/gen/go/shared/v1"
+	"github.com/SARVESHVARADKAR123/RealChat/services/message/internal/domain"
+	"github.com/google/uuid"
+)
+
+// systemIdempotencyTTL outlives any realistic consumer lag, so a redelivered
+// source event still finds its key.
+const systemIdempotencyTTL = 7 * 24 * time.Hour
+
+type SystemMessageCommand struct {
+	// EventID identifies the source event; redeliveries carry the same ID.
+	EventID        string
+	ConversationID string
+	Event          domain.SystemEvent
+}
+
+// PostSystemMessage appends a system message recording cmd.Event to the
+// conversation. It is idempotent per source event: a redelivered event
+// returns the message written the first time.
+func (s *Service) PostSystemMessage(
+	ctx context.Context,
+	cmd SystemMessageCommand,
+) (*domain.Message, error) {
+
+	if cmd.EventID == "" || cmd.ConversationID == "" {
+		return nil, domain.ErrInvalidInput
+	}
+
+	conv, err := s.conversation(ctx, cmd.ConversationID, "")
+	if err != nil {
+		return nil, err
+	}
+
+	key := "event:" + cmd.EventID
+	var result *domain.Message
+
+	err = s.tx.WithTx(ctx, func(ctx context.Context, tx *sql.Tx) error {
+
+		owned, err := s.repo.TryInsertIdempotency(
+			ctx, tx,
+			key,
+			domain.SystemSenderID,
+			cmd.ConversationID,
+			time.Now().Add(systemIdempotencyTTL),
+		)
+		if err != nil {
+			return fmt.Errorf("failed to check idempotency: %w", err)
+		}
+
+		if !owned {
+			payload, err := s.repo.GetIdempotencyForUpdate(ctx, tx, key, domain.SystemSenderID, cmd.ConversationID)
+			if err != nil {
+				return fmt.Errorf("failed to fetch idempotency response: %w", err)
+			}
+			if payload != nil {
+				var msg domain.Message
+				if err := json.Unmarshal(payload, &msg); err != nil {
+					return fmt.Errorf("failed to unmarshal cached message: %w", err)
+				}
+				result = &msg
+			}
+			return nil
+		}
+
+		seq, err := s.allocateSequences(ctx, tx, cmd.ConversationID, 1)
+		if err != nil {
+			return err
+		}
+
+		msg, err := domain.NewSystemMessage(uuid.NewString(), cmd.ConversationID, seq, cmd.Event, time.Now().UTC())
+		if err != nil {
+			return err
+		}
+		msg.ExpireAfter(time.Duration(conv.GetMessageTtlSeconds()) * time.Second)
+
+		if err := s.repo.InsertMessage(ctx, tx, msg); err != nil {
+			return fmt.Errorf("failed to save message: %w", err)
+		}
+
+		if err := s.emitEvent(
+			ctx, tx,
+			msg.ConversationID,
+			sharedv1.EventType_EVENT_TYPE_MESSAGE_SENT,
+			"MESSAGE_SENT",
+			&messagev1.MessageSentEvent{Message: ToProtoMessage(msg)},
+		); err != nil {
+			return err
+		}
+
+		payload, err := json.Marshal(msg)
+		if err != nil {
+			return fmt.Errorf("failed to marshal message for idempotency: %w", err)
+		}
+		if err := s.repo.UpdateIdempotencyResponse(ctx, tx, key, domain.SystemSenderID, cmd.ConversationID, payload); err != nil {
+			return fmt.Errorf("failed to update idempotency response: %w", err)
+		}
+
+		result = msg
+		return nil
+	})
+
+	if err != nil {
+		return nil, err
+	}
+	return result, nil
+}
@@ -0,0 +1,96 @@
+package application
+
+import (
+	"context"
+	"database/sql"
+	"testing"
+	"time"
+
+	conversationv1 "github.com/SARVESHVARADKAR123/RealChat/contracts/gen/go/conversation/v1"
+	"github.com/SARVESHVARADKAR123/RealChat/services/message/internal/domain"
+	"github.com/SARVESHVARADKAR123/RealChat/services/message/internal/repository"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func TestMembershipEvent(t *testing.T) {
+	added := domain.MembershipEvent("alice", "bob", true)
+	assert.Equal(t, domain.SystemMemberAdded, added.Event)
+	assert.Equal(t, "alice added bob", added.Content())
+
+	removed := domain.MembershipEvent("alice", "bob", false)
+	assert.Equal(t, domain.SystemMemberRemoved, removed.Event)
+	assert.Equal(t, "alice removed bob", removed.Content())
+
+	left := domain.MembershipEvent("bob", "bob", false)
+	assert.Equal(t, domain.SystemMemberLeft, left.Event)
+	assert.Equal(t, "bob left", left.Content())
+}
+
+// memIdempotencyRepo keeps idempotency keys in memory in front of another
+// repo, so redeliveries and retries can be tested end to end.
+type memIdempotencyRepo struct {
+	repository.Repository
+	keys map[string][]byte
+}
+
+func (r *memIdempotencyRepo) TryInsertIdempotency(ctx context.Context, tx *sql.Tx, key, userID, conversationID string, expiresAt time.Time) (bool, error) {
+	k := key + "|" + userID + "|" + conversationID
+	if _, ok := r.keys[k]; ok {
+		return false, nil
+	}
+	r.keys[k] = nil
+	return true, nil
+}
+
+func (r *memIdempotencyRepo) GetIdempotencyForUpdate(ctx context.Context, tx *sql.Tx, key, userID, conversationID string) ([]byte, error) {
+	return r.keys[key+"|"+userID+"|"+conversationID], nil
+}
+
+func (r *memIdempotencyRepo) GetIdempotencyResponse(ctx context.Context, key, userID, conversationID string) ([]byte, error) {
+	return r.keys[key+"|"+userID+"|"+conversationID], nil
+}
+
+func (r *memIdempotencyRepo) UpdateIdempotencyResponse(ctx context.Context, tx *sql.Tx, key, userID, conversationID string, payload []byte) error {
+	r.keys[key+"|"+userID+"|"+conversationID] = payload
+	return nil
+}
+
+func TestPostSystemMessage(t *testing.T) {
+	ctx := context.Background()
+	convID := "conv-1"
+	cmd := SystemMessageCommand{
+		EventID:        "evt-1",
+		ConversationID: convID,
+		Event:          domain.MembershipEvent("alice", "bob", true),
+	}
+
+	repo := new(MockRepo)
+	convSvc := new(MockConvClient)
+	svc := &Service{repo: &memIdempotencyRepo{Repository: repo, keys: map[string][]byte{}}, tx: new(MockTransactor), convSvc: convSvc}
+
+	// Looked up on every delivery; MockRepo projects nothing, so each
+	// lookup falls back to the conversation service
+	convSvc.On("GetConversation", ctx, mock.Anything).Return(&conversationv1.GetConversationResponse{
+		Conversation: &conversationv1.Conversation{ConversationId: convID},
+	}, nil).Twice()
+	convSvc.On("ReserveSequences", ctx, mock.Anything).Return(&conversationv1.ReserveSequencesResponse{FirstSequence: 5, LastSequence: 104}, nil).Once()
+	repo.On("InsertMessage", ctx, mock.Anything, mock.MatchedBy(func(m *domain.Message) bool {
+		return m.Type == domain.TypeSystem && m.SenderID == domain.SystemSenderID && m.Sequence == 5
+	})).Return(nil).Once()
+	repo.On("InsertOutbox", ctx, mock.Anything, "message", convID, "MESSAGE_SENT", mock.Anything).Return(nil).Once()
+
+	first, err := svc.PostSystemMessage(ctx, cmd)
+	assert.NoError(t, err)
+	assert.Equal(t, "alice added bob", first.Content)
+	assert.JSONEq(t, `{"event":"member_added","actor_user_id":"alice","user_ids":["bob"]}`, first.Metadata)
+
+	// A redelivery of the same event writes nothing new
+	again, err := svc.PostSystemMessage(ctx, cmd)
+	assert.NoError(t, err)
+	assert.Equal(t, first.ID, again.ID)
+	assert.Equal(t, int64(5), again.Sequence)
+
+	repo.AssertExpectations(t)
+	convSvc.AssertExpectations(t)
+}
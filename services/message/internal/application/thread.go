@@ -0,0 +1,52 @@
+package application
+
+import (
+	"context"
+
+	"github.com/SARVESHVARADKAR123/RealChat/services/message/internal/domain"
+)
+
+// ListThreadReplies returns the thread root and a page of its replies.
+func (s *Service) ListThreadReplies(
+	ctx context.Context,
+	conversationID string,
+	userID string,
+	rootID string,
+	afterSequence int64,
+	pageSize int,
+) (*domain.Message, []*domain.Message, error) {
+
+	if pageSize <= 0 || pageSize > 500 {
+		pageSize = 100
+	}
+
+	if _, err := s.requireParticipant(ctx, conversationID, userID); err != nil {
+		return nil, nil, err
+	}
+
+	root, err := s.repo.GetMessage(ctx, nil, rootID)
+	if err != nil {
+		return nil, nil, err
+	}
+	if root.ConversationID != conversationID {
+		return nil, nil, domain.ErrMessageNotFound
+	}
+
+	replies, err := s.repo.FetchThreadReplies(ctx, conversationID, rootID, afterSequence, pageSize)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	page := append([]*domain.Message{root}, replies...)
+	if err := s.attachReactions(ctx, userID, page); err != nil {
+		return nil, nil, err
+	}
+	if err := s.attachAttachments(ctx, page); err != nil {
+		return nil, nil, err
+	}
+	if err := s.attachPolls(ctx, userID, page); err != nil {
+		return nil, nil, err
+	}
+
+	return root, replies, nil
+}
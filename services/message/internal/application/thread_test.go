@@ -0,0 +1,317 @@
+package application
+
+import (
+	"context"
+	"database/sql"
+	"testing"
+	"time"
+
+	"github.com/SARVESHVARADKAR123/RealChat/services/message/internal/domain"
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/zap"
+)
+
+// threadRepo sends messages like benchRepo and keeps them in memory with
+// their thread summaries.
+type threadRepo struct {
+	benchRepo
+	messages []*domain.Message
+}
+
+func newThreadRepo() *threadRepo {
+	return &threadRepo{
+		benchRepo: benchRepo{
+			blockRepo: blockRepo{MockRepo: new(MockRepo), blocks: map[string]domain.SequenceBlock{}},
+			info:      &domain.ConversationInfo{ID: "conv-1", Group: true, Members: []domain.Member{{UserID: "user-1"}}},
+		},
+	}
+}
+
+func (r *threadRepo) find(id string) *domain.Message {
+	for _, m := range r.messages {
+		if m.ID == id {
+			return m
+		}
+	}
+	return nil
+}
+
+func (r *threadRepo) InsertMessage(ctx context.Context, tx *sql.Tx, msg *domain.Message) error {
+	r.messages = append(r.messages, msg)
+	return nil
+}
+
+func (r *threadRepo) GetMessage(ctx context.Context, tx *sql.Tx, id string) (*domain.Message, error) {
+	// Like the query, messages past their TTL are gone
+	m := r.find(id)
+	if m == nil || (m.ExpiresAt != nil && !m.ExpiresAt.After(time.Now())) {
+		return nil, domain.ErrMessageNotFound
+	}
+	cp := *m
+	return &cp, nil
+}
+
+func (r *threadRepo) GetMessageForUpdate(ctx context.Context, tx *sql.Tx, id string) (*domain.Message, error) {
+	return r.GetMessage(ctx, tx, id)
+}
+
+func (r *threadRepo) FetchThreadReplies(ctx context.Context, convID, rootID string, lastSeq int64, limit int) ([]*domain.Message, error) {
+	var out []*domain.Message
+	for _, m := range r.messages {
+		if m.ConversationID == convID && m.ThreadRootID == rootID && m.Sequence > lastSeq && len(out) < limit {
+			out = append(out, m)
+		}
+	}
+	return out, nil
+}
+
+func (r *threadRepo) RecordThreadReply(ctx context.Context, tx *sql.Tx, rootID string, repliedAt time.Time) error {
+	root := r.find(rootID)
+	root.ReplyCount++
+	if root.LastReplyAt == nil || repliedAt.After(*root.LastReplyAt) {
+		root.LastReplyAt = &repliedAt
+	}
+	return nil
+}
+
+func (r *threadRepo) RefreshThreadSummaries(ctx context.Context, tx *sql.Tx, rootIDs []string) error {
+	for _, id := range rootIDs {
+		root := r.find(id)
+		if root == nil {
+			continue
+		}
+		root.ReplyCount, root.LastReplyAt = 0, nil
+		for _, m := range r.messages {
+			if m.ThreadRootID != id || m.DeletedAt != nil {
+				continue
+			}
+			root.ReplyCount++
+			if root.LastReplyAt == nil || m.SentAt.After(*root.LastReplyAt) {
+				sentAt := m.SentAt
+				root.LastReplyAt = &sentAt
+			}
+		}
+	}
+	return nil
+}
+
+func (r *threadRepo) MarkMessageDeleted(ctx context.Context, tx *sql.Tx, id string) error {
+	now := time.Now().UTC()
+	r.find(id).DeletedAt = &now
+	return nil
+}
+
+func (r *threadRepo) DeleteExpiredMessages(ctx context.Context, tx *sql.Tx, now time.Time, limit int) ([]*domain.Message, error) {
+	var kept, deleted []*domain.Message
+	for _, m := range r.messages {
+		if m.ExpiresAt != nil && !m.ExpiresAt.After(now) {
+			deleted = append(deleted, m)
+		} else {
+			kept = append(kept, m)
+		}
+	}
+	r.messages = kept
+	return deleted, nil
+}
+
+func (r *threadRepo) UnpinMessage(ctx context.Context, tx *sql.Tx, conversationID, messageID string) (bool, error) {
+	return false, nil
+}
+
+func (r *threadRepo) ListReactionSummaries(ctx context.Context, tx *sql.Tx, ids []string, viewerID string) (map[string][]domain.ReactionSummary, error) {
+	return map[string][]domain.ReactionSummary{}, nil
+}
+
+func (r *threadRepo) ListMessageAttachments(ctx context.Context, tx *sql.Tx, ids []string) (map[string][]domain.Attachment, error) {
+	return map[string][]domain.Attachment{}, nil
+}
+
+func newThreadService(repo *threadRepo) *Service {
+	return &Service{
+		repo:    repo,
+		tx:      new(MockTransactor),
+		convSvc: &slowConvClient{},
+		log:     zap.NewNop(),
+	}
+}
+
+// sendReply sends content into conv-1 as user-1, as a reply when replyTo is
+// set.
+func sendReply(t *testing.T, svc *Service, replyTo, content string) (*domain.Message, error) {
+	t.Helper()
+	return svc.SendMessage(context.Background(), SendMessageCommand{
+		ConversationID: "conv-1",
+		UserID:         "user-1",
+		ClientMsgID:    content,
+		Content:        content,
+		ReplyToID:      replyTo,
+	})
+}
+
+func TestSendMessage_Replies(t *testing.T) {
+	repo := newThreadRepo()
+	svc := newThreadService(repo)
+
+	root, err := sendReply(t, svc, "", "root")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	t.Run("Replies update the root summary", func(t *testing.T) {
+		reply, err := sendReply(t, svc, root.ID, "first")
+		if err != nil {
+			t.Fatal(err)
+		}
+		assert.Equal(t, root.ID, reply.ReplyToID)
+		assert.Equal(t, root.ID, reply.ThreadRootID)
+
+		// Replying to a reply stays in the root's thread
+		nested, err := sendReply(t, svc, reply.ID, "second")
+		if err != nil {
+			t.Fatal(err)
+		}
+		assert.Equal(t, reply.ID, nested.ReplyToID)
+		assert.Equal(t, root.ID, nested.ThreadRootID)
+
+		stored := repo.find(root.ID)
+		assert.Equal(t, int64(2), stored.ReplyCount)
+		if assert.NotNil(t, stored.LastReplyAt) {
+			assert.Equal(t, nested.SentAt, *stored.LastReplyAt)
+		}
+	})
+
+	t.Run("Missing parent is refused", func(t *testing.T) {
+		_, err := sendReply(t, svc, "no-such-message", "orphan")
+		assert.ErrorIs(t, err, domain.ErrInvalidReplyTarget)
+	})
+
+	t.Run("Parent in another conversation is refused", func(t *testing.T) {
+		repo.messages = append(repo.messages, &domain.Message{ID: "elsewhere", ConversationID: "conv-2", SenderID: "user-1", Sequence: 1})
+
+		_, err := sendReply(t, svc, "elsewhere", "stray")
+		assert.ErrorIs(t, err, domain.ErrInvalidReplyTarget)
+	})
+
+	t.Run("Deleted parent is refused", func(t *testing.T) {
+		gone, err := sendReply(t, svc, "", "gone")
+		if err != nil {
+			t.Fatal(err)
+		}
+		if err := svc.DeleteMessage(context.Background(), DeleteMessageCommand{
+			ConversationID: "conv-1",
+			MessageID:      gone.ID,
+			RequesterID:    "user-1",
+		}); err != nil {
+			t.Fatal(err)
+		}
+
+		_, err = sendReply(t, svc, gone.ID, "too late")
+		assert.ErrorIs(t, err, domain.ErrInvalidReplyTarget)
+	})
+}
+
+func TestListThreadReplies(t *testing.T) {
+	ctx := context.Background()
+	repo := newThreadRepo()
+	svc := newThreadService(repo)
+
+	root, err := sendReply(t, svc, "", "root")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := sendReply(t, svc, "", "not in the thread"); err != nil {
+		t.Fatal(err)
+	}
+	var want []string
+	for _, content := range []string{"a", "b", "c", "d", "e"} {
+		reply, err := sendReply(t, svc, root.ID, content)
+		if err != nil {
+			t.Fatal(err)
+		}
+		want = append(want, reply.ID)
+	}
+
+	// Page through two at a time, continuing after the last sequence seen
+	var got []string
+	var after int64
+	for {
+		gotRoot, replies, err := svc.ListThreadReplies(ctx, "conv-1", "user-1", root.ID, after, 2)
+		if err != nil {
+			t.Fatal(err)
+		}
+		assert.Equal(t, root.ID, gotRoot.ID)
+		assert.LessOrEqual(t, len(replies), 2)
+		if len(replies) == 0 {
+			break
+		}
+		for _, r := range replies {
+			got = append(got, r.ID)
+		}
+		after = replies[len(replies)-1].Sequence
+	}
+	assert.Equal(t, want, got)
+
+	t.Run("Root from another conversation", func(t *testing.T) {
+		repo.messages = append(repo.messages, &domain.Message{ID: "elsewhere", ConversationID: "conv-2", Sequence: 1})
+
+		_, _, err := svc.ListThreadReplies(ctx, "conv-1", "user-1", "elsewhere", 0, 10)
+		assert.ErrorIs(t, err, domain.ErrMessageNotFound)
+	})
+
+	t.Run("Non participant", func(t *testing.T) {
+		_, _, err := svc.ListThreadReplies(ctx, "conv-1", "user-2", root.ID, 0, 10)
+		assert.ErrorIs(t, err, domain.ErrNotParticipant)
+	})
+}
+
+func TestThreadSummary_Deletions(t *testing.T) {
+	ctx := context.Background()
+	repo := newThreadRepo()
+	svc := newThreadService(repo)
+
+	root, err := sendReply(t, svc, "", "root")
+	if err != nil {
+		t.Fatal(err)
+	}
+	first, err := sendReply(t, svc, root.ID, "first")
+	if err != nil {
+		t.Fatal(err)
+	}
+	second, err := sendReply(t, svc, root.ID, "second")
+	if err != nil {
+		t.Fatal(err)
+	}
+	third, err := sendReply(t, svc, root.ID, "third")
+	if err != nil {
+		t.Fatal(err)
+	}
+	assert.Equal(t, int64(3), repo.find(root.ID).ReplyCount)
+
+	t.Run("Deleting the latest reply", func(t *testing.T) {
+		err := svc.DeleteMessage(ctx, DeleteMessageCommand{ConversationID: "conv-1", MessageID: third.ID, RequesterID: "user-1"})
+		if err != nil {
+			t.Fatal(err)
+		}
+		stored := repo.find(root.ID)
+		assert.Equal(t, int64(2), stored.ReplyCount)
+		if assert.NotNil(t, stored.LastReplyAt) {
+			assert.Equal(t, second.SentAt, *stored.LastReplyAt)
+		}
+	})
+
+	t.Run("Reaping an expired reply", func(t *testing.T) {
+		expired := first.SentAt
+		repo.find(second.ID).ExpiresAt = &expired
+
+		n, err := svc.ReapExpiredMessages(ctx, time.Now(), 10)
+		if err != nil {
+			t.Fatal(err)
+		}
+		assert.Equal(t, 1, n)
+		stored := repo.find(root.ID)
+		assert.Equal(t, int64(1), stored.ReplyCount)
+		if assert.NotNil(t, stored.LastReplyAt) {
+			assert.Equal(t, first.SentAt, *stored.LastReplyAt)
+		}
+	})
+}
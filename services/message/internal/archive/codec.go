@@ -0,0 +1,47 @@
+package archive
+
+import (
+	"bufio"
+	"compress/gzip"
+	"encoding/json"
+	"io"
+
+	"github.com/SARVESHVARADKAR123/RealChat/services/message/internal/domain"
+)
+
+// Encode writes msgs as gzipped JSON lines, one message per line, in the
+// same JSON form as the idempotency cache.
+func Encode(w io.Writer, msgs []*domain.Message) error {
+	zw := gzip.NewWriter(w)
+	enc := json.NewEncoder(zw)
+	for _, m := range msgs {
+		if err := enc.Encode(m); err != nil {
+			zw.Close()
+			return err
+		}
+	}
+	return zw.Close()
+}
+
+// Decode reads messages written by Encode.
+func Decode(r io.Reader) ([]*domain.Message, error) {
+	zr, err := gzip.NewReader(bufio.NewReader(r))
+	if err != nil {
+		return nil, err
+	}
+	defer zr.Close()
+
+	var msgs []*domain.Message
+	dec := json.NewDecoder(zr)
+	for {
+		var m domain.Message
+		err := dec.Decode(&m)
+		if err == io.EOF {
+			return msgs, nil
+		}
+		if err != nil {
+			return nil, err
+		}
+		msgs = append(msgs, &m)
+	}
+}
@@ -0,0 +1,30 @@
+package archive
+
+import (
+	"context"
+	"errors"
+	"io"
+	"path"
+	"strings"
+)
+
+var (
+	ErrNotFound   = errors.New("archive object not found")
+	ErrInvalidKey = errors.New("invalid archive object key")
+)
+
+// Store holds archive objects under slash-separated keys. Putting an
+// existing key replaces the object.
+type Store interface {
+	Put(ctx context.Context, key string, r io.Reader) error
+	Open(ctx context.Context, key string) (io.ReadCloser, error)
+}
+
+// ValidKey reports whether key is a relative, clean path that stays inside
+// the store.
+func ValidKey(key string) bool {
+	if key == "" || strings.HasPrefix(key, "/") || path.Clean(key) != key {
+		return false
+	}
+	return key != ".." && !strings.HasPrefix(key, "../")
+}
@@ -0,0 +1,62 @@
+package archiver
+
+import (
+	"context"
+	"time"
+
+	"github.com/SARVESHVARADKAR123/RealChat/services/message/internal/observability"
+	"go.uber.org/zap"
+)
+
+// Archiver moves messages past their retention to the archive store and
+// keeps the monthly partitions of messages in shape.
+type Archiver interface {
+	ArchiveExpiredMessages(ctx context.Context, now time.Time, limit int) (units, messages int, err error)
+	MaintainPartitions(ctx context.Context, now time.Time) error
+}
+
+// Worker archives expired conversation months in batches, and maintains
+// partitions every PartitionInterval. Archival is never urgent, so a
+// lagging worker only keeps old messages readable for longer.
+type Worker struct {
+	Archiver          Archiver
+	BatchSize         int
+	PollDelay         time.Duration
+	PartitionInterval time.Duration
+}
+
+// Start Worker
+func (w *Worker) Start(ctx context.Context) {
+
+	log := observability.GetLogger(ctx)
+	var maintained time.Time
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		default:
+		}
+
+		now := time.Now().UTC()
+		if now.Sub(maintained) >= w.PartitionInterval {
+			if err := w.Archiver.MaintainPartitions(ctx, now); err != nil {
+				log.Error("partition maintenance error", zap.Error(err))
+			} else {
+				maintained = now
+			}
+		}
+
+		units, n, err := w.Archiver.ArchiveExpiredMessages(ctx, now, w.BatchSize)
+		observability.ArchivedMessagesTotal.Add(float64(n))
+		if err != nil {
+			log.Error("archiver error", zap.Error(err))
+			time.Sleep(time.Second)
+			continue
+		}
+
+		// A full batch means there is likely more to archive right away
+		if units < w.BatchSize {
+			time.Sleep(w.PollDelay)
+		}
+	}
+}
@@ -19,14 +19,8 @@ const (
 	HeaderRequestID            = "x-request-id"
 )
 
-// Interceptor extracts the x-user-id and x-request-id headers and injects them into the context.
-func Interceptor(
-	ctx context.Context,
-	req interface{},
-	info *grpc.UnaryServerInfo,
-	handler grpc.UnaryHandler,
-) (interface{}, error) {
-
+// withIdentity extracts the x-user-id and x-request-id headers into the context.
+func withIdentity(ctx context.Context) (context.Context, error) {
 	md, ok := metadata.FromIncomingContext(ctx)
 	if !ok {
 		return nil, status.Error(codes.Unauthenticated, "metadata is not provided")
@@ -47,9 +41,48 @@ func Interceptor(
 		newCtx = context.WithValue(newCtx, RequestIDKey, reqValues[0])
 	}
 
+	return newCtx, nil
+}
+
+// Interceptor extracts the x-user-id and x-request-id headers and injects them into the context.
+func Interceptor(
+	ctx context.Context,
+	req interface{},
+	info *grpc.UnaryServerInfo,
+	handler grpc.UnaryHandler,
+) (interface{}, error) {
+
+	newCtx, err := withIdentity(ctx)
+	if err != nil {
+		return nil, err
+	}
 	return handler(newCtx, req)
 }
 
+// StreamInterceptor is the streaming counterpart of Interceptor.
+func StreamInterceptor(
+	srv interface{},
+	ss grpc.ServerStream,
+	info *grpc.StreamServerInfo,
+	handler grpc.StreamHandler,
+) error {
+
+	newCtx, err := withIdentity(ss.Context())
+	if err != nil {
+		return err
+	}
+	return handler(srv, &wrappedStream{ServerStream: ss, ctx: newCtx})
+}
+
+type wrappedStream struct {
+	grpc.ServerStream
+	ctx context.Context
+}
+
+func (w *wrappedStream) Context() context.Context {
+	return w.ctx
+}
+
 // ClientInterceptor propagates user-id and request-id to outgoing gRPC calls.
 func ClientInterceptor(
 	ctx context.Context,
@@ -3,7 +3,9 @@ package config
 import (
 	"log"
 	"os"
+	"strconv"
 	"strings"
+	"time"
 )
 
 type Config struct {
@@ -11,6 +13,7 @@ type Config struct {
 	DatabaseURL         string
 	KafkaBrokers        string
 	KafkaTopic          string
+	ConversationTopic   string
 	RedisAddr           string
 	ServiceName         string
 	ObsHTTPAddr         string
@@ -18,6 +21,18 @@ type Config struct {
 	TracingEnabled      bool
 	JaegerURL           string
 	ConversationSvcAddr string
+	MediaSvcAddr        string
+	ProfileSvcAddr      string
+	EditWindow          time.Duration
+	MaxPins             int
+	SequenceBlock       int
+	Retention           time.Duration
+	RestoreHold         time.Duration
+	ArchiveDir          string
+	IdempotencyTTL      time.Duration
+	ImportAdmins        []string
+	ModerationConfig    string
+	Moderators          []string
 }
 
 func Load() *Config {
@@ -26,6 +41,7 @@ func Load() *Config {
 		DatabaseURL:         mustEnv("DATABASE_URL"),
 		KafkaBrokers:        mustEnv("KAFKA_BROKERS"),
 		KafkaTopic:          mustEnv("KAFKA_TOPIC"),
+		ConversationTopic:   mustEnv("CONVERSATION_KAFKA_TOPIC"),
 		RedisAddr:           mustEnv("REDIS_ADDR"),
 		ServiceName:         mustEnv("SERVICE_NAME"),
 		ObsHTTPAddr:         fixPort(mustEnv("HTTP_ADDR")),
@@ -33,6 +49,18 @@ func Load() *Config {
 		TracingEnabled:      getEnvBool("TRACING_ENABLED", false),
 		JaegerURL:           getEnv("JAEGER_URL", "http://jaeger:14268/api/traces"),
 		ConversationSvcAddr: mustEnv("CONVERSATION_SVC_ADDR"),
+		MediaSvcAddr:        mustEnv("MEDIA_SVC_ADDR"),
+		ProfileSvcAddr:      mustEnv("PROFILE_SVC_ADDR"),
+		EditWindow:          getEnvDuration("MESSAGE_EDIT_WINDOW", 15*time.Minute),
+		MaxPins:             getEnvInt("MESSAGE_MAX_PINS", 50),
+		SequenceBlock:       getEnvInt("MESSAGE_SEQUENCE_BLOCK", 100),
+		Retention:           getEnvDuration("MESSAGE_RETENTION", 0),
+		RestoreHold:         getEnvDuration("MESSAGE_ARCHIVE_RESTORE_HOLD", 7*24*time.Hour),
+		ArchiveDir:          getEnv("MESSAGE_ARCHIVE_DIR", "/var/lib/realchat/archive"),
+		IdempotencyTTL:      getEnvDuration("MESSAGE_IDEMPOTENCY_TTL", 24*time.Hour),
+		ImportAdmins:        getEnvList("MESSAGE_IMPORT_ADMINS", nil),
+		ModerationConfig:    getEnv("MESSAGE_MODERATION_CONFIG", ""),
+		Moderators:          getEnvList("MESSAGE_MODERATORS", nil),
 	}
 }
 
@@ -65,3 +93,41 @@ func getEnv(key, fallback string) string {
 	}
 	return fallback
 }
+
+func getEnvInt(key string, fallback int) int {
+	v := os.Getenv(key)
+	if v == "" {
+		return fallback
+	}
+	n, err := strconv.Atoi(v)
+	if err != nil {
+		log.Fatalf("invalid integer for %s: %v", key, err)
+	}
+	return n
+}
+
+func getEnvDuration(key string, fallback time.Duration) time.Duration {
+	v := os.Getenv(key)
+	if v == "" {
+		return fallback
+	}
+	d, err := time.ParseDuration(v)
+	if err != nil {
+		log.Fatalf("invalid duration for %s: %v", key, err)
+	}
+	return d
+}
+
+func getEnvList(key string, fallback []string) []string {
+	v := os.Getenv(key)
+	if v == "" {
+		return fallback
+	}
+	var out []string
+	for _, item := range strings.Split(v, ",") {
+		if item = strings.TrimSpace(item); item != "" {
+			out = append(out, item)
+		}
+	}
+	return out
+}
@@ -0,0 +1,37 @@
+package domain
+
+import "time"
+
+// MaxAttachmentsPerMessage bounds how many files a single message can carry.
+const MaxAttachmentsPerMessage = 10
+
+// Attachment is a media-service file referenced by a message. The metadata is
+// copied at send time; DownloadURL is signed per read and never persisted.
+type Attachment struct {
+	ID                   string
+	FileName             string
+	MimeType             string
+	SizeBytes            int64
+	DownloadURL          string     `json:"-"`
+	DownloadURLExpiresAt *time.Time `json:"-"`
+}
+
+// ValidateAttachmentIDs checks that ids is short enough and holds no blanks or
+// duplicates. Ownership is checked against the media service by the caller.
+func ValidateAttachmentIDs(ids []string) error {
+	if len(ids) > MaxAttachmentsPerMessage {
+		return ErrInvalidAttachment
+	}
+
+	seen := make(map[string]struct{}, len(ids))
+	for _, id := range ids {
+		if id == "" {
+			return ErrInvalidAttachment
+		}
+		if _, ok := seen[id]; ok {
+			return ErrInvalidAttachment
+		}
+		seen[id] = struct{}{}
+	}
+	return nil
+}
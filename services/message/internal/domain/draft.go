@@ -0,0 +1,49 @@
+package domain
+
+import "time"
+
+// MaxDraftClockSkew is how far ahead of the server a device's clock may be.
+// Later timestamps are pulled back to now, so one fast clock cannot freeze a
+// draft against every other device.
+const MaxDraftClockSkew = time.Minute
+
+// Draft is the unsent text a user has typed into a conversation. Drafts are
+// last-writer-wins by UpdatedAt; an empty Text is a cleared draft.
+type Draft struct {
+	ConversationID string
+	UserID         string
+	Text           string
+	DeviceID       string
+	UpdatedAt      time.Time
+}
+
+// NewDraft validates a draft written at updatedAt, defaulting to now.
+func NewDraft(conversationID, userID, deviceID, text string, updatedAt, now time.Time) (*Draft, error) {
+	if conversationID == "" || userID == "" {
+		return nil, ErrInvalidInput
+	}
+	if len(text) > MaxMessageSize {
+		return nil, ErrMessageTooLarge
+	}
+	if updatedAt.IsZero() || updatedAt.After(now.Add(MaxDraftClockSkew)) {
+		updatedAt = now
+	}
+	return &Draft{
+		ConversationID: conversationID,
+		UserID:         userID,
+		Text:           text,
+		DeviceID:       deviceID,
+		UpdatedAt:      updatedAt.UTC(),
+	}, nil
+}
+
+// Cleared reports whether the draft holds no text.
+func (d *Draft) Cleared() bool {
+	return d.Text == ""
+}
+
+// Supersedes reports whether d replaces current, the stored draft if any.
+// Ties keep the stored draft.
+func (d *Draft) Supersedes(current *Draft) bool {
+	return current == nil || d.UpdatedAt.After(current.UpdatedAt)
+}
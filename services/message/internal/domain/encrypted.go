@@ -0,0 +1,80 @@
+package domain
+
+import "fmt"
+
+const (
+	// MaxCiphertextSize caps one device's ciphertext, leaving room for the
+	// encryption overhead on a MaxMessageSize plaintext.
+	MaxCiphertextSize = 16 << 10
+
+	// MaxCiphertextsPerMessage caps the devices one message fans out to.
+	MaxCiphertextsPerMessage = 1000
+
+	// MaxDeviceIDLength matches the device IDs the key service accepts.
+	MaxDeviceIDLength = 128
+)
+
+// DeviceCiphertext is an encrypted message's content for one device of one
+// recipient. The service stores and routes it without reading it.
+type DeviceCiphertext struct {
+	UserID     string
+	DeviceID   string
+	Ciphertext []byte
+}
+
+// ValidateCiphertexts checks the ciphertexts of a message of type msgType:
+// encrypted messages need at least one, every other type none. Each
+// recipient must be in participants, at most once per device.
+func ValidateCiphertexts(msgType, senderDeviceID string, cts []DeviceCiphertext, participants []string) error {
+	if msgType != TypeEncrypted {
+		if len(cts) > 0 {
+			return invalidField("ciphertexts", "are only allowed on %s messages", TypeEncrypted)
+		}
+		return nil
+	}
+
+	if senderDeviceID == "" || len(senderDeviceID) > MaxDeviceIDLength {
+		return invalidField("sender_device_id", "is required, up to %d bytes", MaxDeviceIDLength)
+	}
+	if len(cts) == 0 {
+		return invalidField("ciphertexts", "is required")
+	}
+	if len(cts) > MaxCiphertextsPerMessage {
+		return invalidField("ciphertexts", "exceeds %d devices", MaxCiphertextsPerMessage)
+	}
+
+	members := make(map[string]bool, len(participants))
+	for _, p := range participants {
+		members[p] = true
+	}
+
+	type device struct{ user, id string }
+	seen := make(map[device]bool, len(cts))
+	for i, ct := range cts {
+		field := fmt.Sprintf("ciphertexts[%d]", i)
+		switch {
+		case !members[ct.UserID]:
+			return invalidField(field, "recipient is not a participant")
+		case ct.DeviceID == "" || len(ct.DeviceID) > MaxDeviceIDLength:
+			return invalidField(field, "device_id is required, up to %d bytes", MaxDeviceIDLength)
+		case len(ct.Ciphertext) == 0 || len(ct.Ciphertext) > MaxCiphertextSize:
+			return invalidField(field, "ciphertext must be 1 to %d bytes", MaxCiphertextSize)
+		}
+		d := device{ct.UserID, ct.DeviceID}
+		if seen[d] {
+			return invalidField(field, "duplicates another device")
+		}
+		seen[d] = true
+	}
+	return nil
+}
+
+// CiphertextFor returns the ciphertext addressed to a device, or nil.
+func (m *Message) CiphertextFor(userID, deviceID string) []DeviceCiphertext {
+	for _, ct := range m.Ciphertexts {
+		if ct.UserID == userID && ct.DeviceID == deviceID {
+			return []DeviceCiphertext{ct}
+		}
+	}
+	return nil
+}
@@ -9,4 +9,42 @@ var (
 	ErrNotParticipant  = errors.New("user not participant")
 	ErrMessageNotFound = errors.New("message not found")
 	ErrInvalidInput    = errors.New("invalid input")
+
+	ErrNotSender         = errors.New("only the sender can modify this message")
+	ErrMessageDeleted    = errors.New("message deleted")
+	ErrEditWindowExpired = errors.New("edit window expired")
+
+	ErrInvalidReplyTarget = errors.New("reply target not found in conversation")
+	ErrInvalidReaction    = errors.New("invalid reaction")
+	ErrInvalidAttachment  = errors.New("invalid attachment")
+	ErrMessageEncrypted   = errors.New("not supported on encrypted messages")
+
+	ErrNotAdmin        = errors.New("only admins can do this in a group")
+	ErrPinLimitReached = errors.New("pin limit reached for conversation")
+
+	ErrScheduledNotFound  = errors.New("scheduled message not found")
+	ErrInvalidSchedule    = errors.New("send_at must be in the future")
+	ErrScheduleNotPending = errors.New("scheduled message is no longer pending")
+
+	ErrPollNotFound = errors.New("poll not found")
+	ErrPollClosed   = errors.New("poll is closed")
+	ErrInvalidVote  = errors.New("invalid vote")
+
+	ErrSequencesExhausted = errors.New("no reserved sequences left")
+	ErrSequenceGap        = errors.New("reserved sequences do not follow the current block")
+
+	ErrInvalidRetention = errors.New("retention must be zero or at least a day, in whole seconds")
+
+	ErrExportNotFound = errors.New("export not found")
+	ErrExportNotReady = errors.New("export is not ready")
+
+	ErrImportNotFound  = errors.New("import not found")
+	ErrImportForbidden = errors.New("imports are restricted to import admins")
+	ErrInvalidImport   = errors.New("invalid import")
+
+	ErrMessageRejected     = errors.New("message rejected by moderation")
+	ErrMessageHeld         = errors.New("message held for moderation review")
+	ErrHeldNotFound        = errors.New("held message not found")
+	ErrHeldNotPending      = errors.New("held message was already reviewed")
+	ErrModerationForbidden = errors.New("moderation review is restricted to moderators")
 )
@@ -0,0 +1,55 @@
+package domain
+
+import "time"
+
+type ExportStatus string
+
+const (
+	ExportPending ExportStatus = "pending"
+	ExportRunning ExportStatus = "running"
+	ExportDone    ExportStatus = "done"
+	ExportFailed  ExportStatus = "failed"
+)
+
+// Export is a participant's request for a copy of a conversation's history.
+// It is built in the background; once done, ObjectKey names the archive in
+// the archive store.
+type Export struct {
+	ID             string
+	ConversationID string
+	UserID         string
+	Status         ExportStatus
+
+	ObjectKey     string
+	MessageCount  int64
+	SizeBytes     int64
+	FailureReason string
+
+	CreatedAt   time.Time
+	StartedAt   *time.Time
+	CompletedAt *time.Time
+}
+
+func NewExport(id, conversationID, userID string, now time.Time) (*Export, error) {
+	if id == "" || conversationID == "" || userID == "" {
+		return nil, ErrInvalidInput
+	}
+	return &Export{
+		ID:             id,
+		ConversationID: conversationID,
+		UserID:         userID,
+		Status:         ExportPending,
+		CreatedAt:      now,
+	}, nil
+}
+
+// Open reports whether the export is still waiting for or being built by a
+// worker.
+func (e *Export) Open() bool {
+	return e.Status == ExportPending || e.Status == ExportRunning
+}
+
+// ExportObjectKey is where the archive of an export is stored.
+func ExportObjectKey(e *Export) string {
+	return "exports/" + e.ConversationID + "/" + e.ID + ".zip"
+}
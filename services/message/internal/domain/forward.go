@@ -0,0 +1,65 @@
+package domain
+
+import "time"
+
+// MaxForwardMessages caps how many messages one ForwardMessages call copies.
+const MaxForwardMessages = 50
+
+// ForwardedFrom records where a forwarded message was copied from.
+type ForwardedFrom struct {
+	MessageID      string
+	ConversationID string
+	SenderID       string
+	SentAt         time.Time
+}
+
+// ValidateForwardIDs checks the message IDs of a forward request.
+func ValidateForwardIDs(ids []string) error {
+	if len(ids) == 0 || len(ids) > MaxForwardMessages {
+		return ErrInvalidInput
+	}
+
+	seen := make(map[string]struct{}, len(ids))
+	for _, id := range ids {
+		if id == "" {
+			return ErrInvalidInput
+		}
+		if _, dup := seen[id]; dup {
+			return ErrInvalidInput
+		}
+		seen[id] = struct{}{}
+	}
+	return nil
+}
+
+// Forward copies m into conversationID as a new message from senderID. The
+// copy points at the original message, so forwarding a forward does not
+// lose the original author.
+func (m *Message) Forward(
+	id string,
+	conversationID string,
+	senderID string,
+	sequence int64,
+	now time.Time,
+) (*Message, error) {
+
+	if m.DeletedAt != nil {
+		return nil, ErrMessageDeleted
+	}
+
+	copied, err := NewMessage(id, conversationID, senderID, sequence, m.Type, m.Content, m.Metadata, now)
+	if err != nil {
+		return nil, err
+	}
+
+	copied.ForwardedFrom = m.ForwardedFrom
+	if copied.ForwardedFrom == nil {
+		copied.ForwardedFrom = &ForwardedFrom{
+			MessageID:      m.ID,
+			ConversationID: m.ConversationID,
+			SenderID:       m.SenderID,
+			SentAt:         m.SentAt,
+		}
+	}
+	return copied, nil
+}
@@ -0,0 +1,85 @@
+package domain
+
+import "time"
+
+type ImportStatus string
+
+const (
+	ImportPending ImportStatus = "pending"
+	ImportRunning ImportStatus = "running"
+	ImportDone    ImportStatus = "done"
+	ImportFailed  ImportStatus = "failed"
+)
+
+// Import brings the history of another chat app into a new conversation.
+// The ID is derived from the export and settings, so asking for the same
+// import twice finds the first one, and a job picked up again resumes after
+// ImportedMessages instead of writing anything twice.
+type Import struct {
+	ID             string
+	ConversationID string
+	Source         string
+	Name           string
+	CreatedBy      string
+
+	// AttachmentID is the uploaded export, for imports requested through
+	// the API.
+	AttachmentID string
+	// Mapping turns the export's senders into RealChat user IDs.
+	Mapping  map[string]string
+	Channel  string
+	TimeZone string
+	DayFirst bool
+
+	Status           ImportStatus
+	TotalMessages    int64
+	ImportedMessages int64
+	FailureReason    string
+
+	CreatedAt   time.Time
+	UpdatedAt   time.Time
+	StartedAt   *time.Time
+	CompletedAt *time.Time
+}
+
+func NewImport(id, conversationID, source, name, createdBy string, mapping map[string]string, now time.Time) (*Import, error) {
+	if id == "" || conversationID == "" || source == "" || name == "" || createdBy == "" || len(mapping) == 0 {
+		return nil, ErrInvalidInput
+	}
+	for sender, userID := range mapping {
+		if sender == "" || userID == "" {
+			return nil, ErrInvalidInput
+		}
+	}
+	return &Import{
+		ID:             id,
+		ConversationID: conversationID,
+		Source:         source,
+		Name:           name,
+		CreatedBy:      createdBy,
+		Mapping:        mapping,
+		Status:         ImportPending,
+		CreatedAt:      now,
+		UpdatedAt:      now,
+	}, nil
+}
+
+// Open reports whether the import is still waiting for or being run by a
+// worker.
+func (i *Import) Open() bool {
+	return i.Status == ImportPending || i.Status == ImportRunning
+}
+
+// Participants returns the RealChat users of the import, the importer
+// first, without duplicates and in a stable order.
+func (i *Import) Participants(senders []string) []string {
+	seen := map[string]bool{i.CreatedBy: true}
+	out := []string{i.CreatedBy}
+	for _, s := range senders {
+		if id := i.Mapping[s]; id != "" && !seen[id] {
+			seen[id] = true
+			out = append(out, id)
+		}
+	}
+	return out
+}
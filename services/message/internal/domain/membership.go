@@ -0,0 +1,44 @@
+package domain
+
+import "time"
+
+// ConversationInfo is the message service's own copy of a conversation's
+// settings and current members, kept up to date from conversation events.
+type ConversationInfo struct {
+	ID         string
+	Group      bool
+	MessageTTL time.Duration
+	Members    []Member
+}
+
+type Member struct {
+	UserID string
+	Admin  bool
+
+	// JoinedAt is when the member last joined, if known. Members without it
+	// came with the conversation or from a backfill.
+	JoinedAt *time.Time
+	// JoinedSequence is the last sequence handed out in the conversation
+	// when the member joined. Set along with JoinedAt.
+	JoinedSequence int64
+}
+
+// Sees reports whether m's history includes msg: anything, for members who
+// came with the conversation, else what was sent or written since they
+// joined. Imported messages are written with their original, older sent_at.
+func (m Member) Sees(msg *Message) bool {
+	if m.JoinedAt == nil {
+		return true
+	}
+	return !msg.SentAt.Before(*m.JoinedAt) || msg.Sequence > m.JoinedSequence
+}
+
+// Member returns userID's membership, if any.
+func (c *ConversationInfo) Member(userID string) (Member, bool) {
+	for _, m := range c.Members {
+		if m.UserID == userID {
+			return m, true
+		}
+	}
+	return Member{}, false
+}
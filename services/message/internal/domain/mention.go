@@ -0,0 +1,73 @@
+package domain
+
+import (
+	"encoding/json"
+	"regexp"
+)
+
+// MentionAll is the mention that targets every participant. Only admins may
+// use it in groups.
+const MentionAll = "all"
+
+var mentionPattern = regexp.MustCompile(`(?:^|[^\w@])@([\w-]+)`)
+
+// Mentions are the raw targets of a message, before they are checked
+// against the conversation.
+type Mentions struct {
+	UserIDs []string
+	All     bool
+}
+
+// ParseMentions takes the mentions from the "mentions" array of metadata
+// when present, and otherwise from @<user_id> tokens in content.
+func ParseMentions(content, metadata string) Mentions {
+	var targets []string
+
+	var meta struct {
+		Mentions []string `json:"mentions"`
+	}
+	if metadata != "" && json.Unmarshal([]byte(metadata), &meta) == nil && meta.Mentions != nil {
+		targets = meta.Mentions
+	} else {
+		for _, m := range mentionPattern.FindAllStringSubmatch(content, -1) {
+			targets = append(targets, m[1])
+		}
+	}
+
+	var out Mentions
+	seen := make(map[string]struct{}, len(targets))
+	for _, t := range targets {
+		if t == MentionAll {
+			out.All = true
+			continue
+		}
+		if _, dup := seen[t]; dup || t == "" {
+			continue
+		}
+		seen[t] = struct{}{}
+		out.UserIDs = append(out.UserIDs, t)
+	}
+	return out
+}
+
+// Resolve returns the participants the mentions target. Unknown users and
+// the sender are dropped, and @all only counts when allowAll is set.
+func (m Mentions) Resolve(senderID string, participants []string, allowAll bool) []string {
+	members := make(map[string]struct{}, len(participants))
+	for _, p := range participants {
+		members[p] = struct{}{}
+	}
+
+	candidates := m.UserIDs
+	if m.All && allowAll {
+		candidates = participants
+	}
+
+	var out []string
+	for _, id := range candidates {
+		if _, ok := members[id]; ok && id != senderID {
+			out = append(out, id)
+		}
+	}
+	return out
+}
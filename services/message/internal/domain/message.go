@@ -5,9 +5,9 @@ import "time"
 const MaxMessageSize = 5000
 
 // Message Invariants:
-// 1. Ordering: Sequence must be strictly increasing, gapless, and unique per conversation_id.
-// 2. Immutability: Standard fields are immutable. Only DeletedAt can change.
-// 3. Event Consistency: Creation must emit a MessageSentEvent.
+// 1. Ordering: Sequence must be strictly increasing, gapless, and unique per conversation_id. It is taken from the conversation's SequenceBlock in the transaction that inserts the message.
+// 2. Immutability: Standard fields are immutable. Only DeletedAt can change, plus Content/Metadata/EditedAt via Edit.
+// 3. Event Consistency: Creation must emit a MessageSentEvent, an edit a MessageEditedEvent.
 type Message struct {
 	ID             string
 	ConversationID string
@@ -18,6 +18,44 @@ type Message struct {
 	Metadata       string
 	SentAt         time.Time
 	DeletedAt      *time.Time
+	EditedAt       *time.Time
+
+	// ExpiresAt is set in conversations with a message TTL. Expired messages
+	// are never served and are eventually hard-deleted.
+	ExpiresAt *time.Time
+
+	// Threading. ReplyCount and LastReplyAt are maintained on the root only.
+	ReplyToID    string
+	ThreadRootID string
+	ReplyCount   int64
+	LastReplyAt  *time.Time
+
+	// Mentions is resolved by SendMessage and indexed separately; reads
+	// don't load it.
+	Mentions []string
+
+	// ForwardedFrom is set on copies made by forwarding.
+	ForwardedFrom *ForwardedFrom
+
+	// Attachments are stored alongside the message; their download URLs are
+	// signed per read.
+	Attachments []Attachment
+
+	// Reactions is filled per reader and never persisted on the message row.
+	Reactions []ReactionSummary
+
+	// Poll is set on poll messages when read, tallied for the reader.
+	Poll *Poll
+
+	// SenderDeviceID and Ciphertexts are set on encrypted messages. Reads
+	// load only the reading device's ciphertext.
+	SenderDeviceID string
+	Ciphertexts    []DeviceCiphertext
+
+	// Extras is loaded only by archival, which carries every row stored
+	// with a message so that a restore loses nothing. Archived messages
+	// also keep their Mentions and their Poll with its ballots.
+	Extras *MessageExtras `json:",omitempty"`
 }
 
 func NewMessage(
@@ -54,3 +92,62 @@ func NewMessage(
 		SentAt:         now,
 	}, nil
 }
+
+// Edit replaces the content of the message. Only the sender may edit, the
+// message must not be deleted, and a non-zero window limits how long after
+// SentAt an edit is allowed.
+func (m *Message) Edit(
+	editorID string,
+	content string,
+	metadata string,
+	window time.Duration,
+	now time.Time,
+) error {
+
+	if m.SenderID != editorID {
+		return ErrNotSender
+	}
+
+	if m.DeletedAt != nil {
+		return ErrMessageDeleted
+	}
+
+	if window > 0 && now.Sub(m.SentAt) > window {
+		return ErrEditWindowExpired
+	}
+
+	if len(content) > MaxMessageSize {
+		return ErrMessageTooLarge
+	}
+
+	m.Content = content
+	m.Metadata = metadata
+	m.EditedAt = &now
+	return nil
+}
+
+// ExpireAfter makes the message disappear ttl after it was sent. A zero ttl
+// leaves it permanent.
+func (m *Message) ExpireAfter(ttl time.Duration) {
+	if ttl <= 0 {
+		return
+	}
+	expiresAt := m.SentAt.Add(ttl)
+	m.ExpiresAt = &expiresAt
+}
+
+// ReplyTo makes the message a reply to parent. Threads are flat: replying to
+// a reply joins the parent's thread rather than starting a nested one. A
+// deleted parent can't be replied to.
+func (m *Message) ReplyTo(parent *Message) error {
+	if parent == nil || parent.ConversationID != m.ConversationID || parent.DeletedAt != nil {
+		return ErrInvalidReplyTarget
+	}
+
+	m.ReplyToID = parent.ID
+	m.ThreadRootID = parent.ID
+	if parent.ThreadRootID != "" {
+		m.ThreadRootID = parent.ThreadRootID
+	}
+	return nil
+}
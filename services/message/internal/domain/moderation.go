@@ -0,0 +1,90 @@
+package domain
+
+import (
+	"fmt"
+	"time"
+)
+
+type HeldStatus string
+
+const (
+	HeldPending  HeldStatus = "pending"
+	HeldApproved HeldStatus = "approved"
+	HeldRejected HeldStatus = "rejected"
+)
+
+// HeldMessage is a send that moderation held back for review. It carries
+// everything needed to send it once approved, under the sender's original
+// idempotency key.
+type HeldMessage struct {
+	ID             string
+	ConversationID string
+	SenderID       string
+	ClientMsgID    string
+	Type           string
+	Content        string
+	Metadata       string
+	ReplyToID      string
+	AttachmentIDs  []string
+	Reason         string
+	CreatedAt      time.Time
+
+	Status     HeldStatus
+	ReviewedBy string
+	ReviewNote string
+	ReviewedAt *time.Time
+	// MessageID is set once an approved message has been sent.
+	MessageID string
+}
+
+// Review records a moderator's decision on a pending message.
+func (h *HeldMessage) Review(reviewerID string, approve bool, note string, now time.Time) error {
+	if h.Status != HeldPending {
+		return ErrHeldNotPending
+	}
+	h.Status = HeldRejected
+	if approve {
+		h.Status = HeldApproved
+	}
+	h.ReviewedBy = reviewerID
+	h.ReviewNote = note
+	h.ReviewedAt = &now
+	return nil
+}
+
+// HeldError is returned for a send that was held for review instead of
+// being sent.
+type HeldError struct {
+	Held *HeldMessage
+}
+
+func (e *HeldError) Error() string {
+	return fmt.Sprintf("%v: %s", ErrMessageHeld, e.Held.Reason)
+}
+
+func (e *HeldError) Unwrap() error { return ErrMessageHeld }
+
+// ModerationVerdict is one filter's decision, as kept in the audit trail.
+type ModerationVerdict struct {
+	Filter string `json:"filter"`
+	Action string `json:"action"`
+	Reason string `json:"reason,omitempty"`
+}
+
+// ModerationRecord is the audit entry of one moderation decision: a message
+// screened on send, or a moderator's review of a held message.
+type ModerationRecord struct {
+	ID             string
+	ConversationID string
+	SenderID       string
+	// MessageID is the message that was sent, if any.
+	MessageID string
+	HeldID    string
+	// Action is the decision taken; Verdicts are the filter decisions that
+	// led to it.
+	Action     string
+	Reason     string
+	Verdicts   []ModerationVerdict
+	ReviewerID string
+	CreatedAt  time.Time
+}
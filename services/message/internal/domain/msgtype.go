@@ -0,0 +1,261 @@
+package domain
+
+import (
+	"encoding/json"
+	"fmt"
+	"sync"
+)
+
+// Presence says whether a part of a message is required, optional or not
+// allowed for a message type.
+type Presence int
+
+const (
+	Optional Presence = iota
+	Required
+	Forbidden
+)
+
+// FieldKind is the JSON type of a metadata field.
+type FieldKind int
+
+const (
+	FieldString FieldKind = iota
+	FieldNumber
+	FieldBool
+	FieldStringList
+)
+
+func (k FieldKind) String() string {
+	switch k {
+	case FieldString:
+		return "a string"
+	case FieldNumber:
+		return "a number"
+	case FieldBool:
+		return "a boolean"
+	case FieldStringList:
+		return "a list of strings"
+	default:
+		return "unknown"
+	}
+}
+
+// Field describes one allowed metadata field.
+type Field struct {
+	Kind     FieldKind
+	Required bool
+	// MaxLen caps the length of strings and lists. Zero means no limit.
+	MaxLen int
+}
+
+// DefaultMaxMetadataSize caps metadata_json for types that don't set their own limit.
+const DefaultMaxMetadataSize = 4096
+
+// MessageType declares what a message of a given type may carry.
+type MessageType struct {
+	Name string
+
+	// Internal types are written by the service itself and can't be sent
+	// by clients.
+	Internal bool
+
+	Content Presence
+	// MaxContentSize caps the content in bytes, up to MaxMessageSize.
+	// Zero means MaxMessageSize.
+	MaxContentSize int
+
+	Attachments Presence
+
+	// Metadata lists the allowed metadata fields; any other field is
+	// rejected. MaxMetadataSize caps the raw JSON, zero meaning
+	// DefaultMaxMetadataSize.
+	Metadata        map[string]Field
+	MaxMetadataSize int
+
+	// Check runs type-specific rules after the generic ones. meta holds
+	// the decoded metadata and is never nil.
+	Check func(content string, meta map[string]interface{}, attachments int) error
+}
+
+// ValidationError reports which part of a message broke its type's rules.
+type ValidationError struct {
+	Field  string
+	Reason string
+}
+
+func (e *ValidationError) Error() string {
+	return fmt.Sprintf("invalid %s: %s", e.Field, e.Reason)
+}
+
+// Unwrap makes validation errors match ErrInvalidMessage.
+func (e *ValidationError) Unwrap() error {
+	return ErrInvalidMessage
+}
+
+func invalidField(field, format string, args ...interface{}) *ValidationError {
+	return &ValidationError{Field: field, Reason: fmt.Sprintf(format, args...)}
+}
+
+// MessageTypeRegistry holds the known message types. It is safe for
+// concurrent use, so types can be registered at any time.
+type MessageTypeRegistry struct {
+	mu    sync.RWMutex
+	types map[string]MessageType
+}
+
+// NewMessageTypeRegistry returns a registry holding types.
+func NewMessageTypeRegistry(types ...MessageType) *MessageTypeRegistry {
+	r := &MessageTypeRegistry{types: make(map[string]MessageType)}
+	for _, t := range types {
+		if err := r.Register(t); err != nil {
+			panic(err)
+		}
+	}
+	return r
+}
+
+// Register adds t, replacing any type of the same name.
+func (r *MessageTypeRegistry) Register(t MessageType) error {
+	if t.Name == "" {
+		return fmt.Errorf("message type needs a name")
+	}
+	if t.MaxContentSize < 0 || t.MaxContentSize > MaxMessageSize {
+		return fmt.Errorf("message type %q: content limit must be within %d bytes", t.Name, MaxMessageSize)
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.types[t.Name] = t
+	return nil
+}
+
+// Lookup returns the type called name.
+func (r *MessageTypeRegistry) Lookup(name string) (MessageType, bool) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	t, ok := r.types[name]
+	return t, ok
+}
+
+// Validate checks a message sent by a client against its type.
+func (r *MessageTypeRegistry) Validate(msgType, content, metadata string, attachments int) error {
+	t, ok := r.Lookup(msgType)
+	if !ok || t.Internal {
+		return invalidField("message_type", "unknown message type %q", msgType)
+	}
+	return t.Validate(content, metadata, attachments)
+}
+
+// Validate checks content, metadata and the number of attachments of a
+// message against t.
+func (t MessageType) Validate(content, metadata string, attachments int) error {
+	maxContent := t.MaxContentSize
+	if maxContent == 0 {
+		maxContent = MaxMessageSize
+	}
+	if err := checkPresence("content", t.Content, content != ""); err != nil {
+		return err
+	}
+	if len(content) > maxContent {
+		return invalidField("content", "exceeds %d bytes", maxContent)
+	}
+
+	if err := checkPresence("attachment_ids", t.Attachments, attachments > 0); err != nil {
+		return err
+	}
+
+	meta, err := t.decodeMetadata(metadata)
+	if err != nil {
+		return err
+	}
+
+	if t.Check != nil {
+		return t.Check(content, meta, attachments)
+	}
+	return nil
+}
+
+func checkPresence(field string, p Presence, present bool) error {
+	switch {
+	case p == Required && !present:
+		return invalidField(field, "is required")
+	case p == Forbidden && present:
+		return invalidField(field, "is not allowed for this message type")
+	}
+	return nil
+}
+
+func (t MessageType) decodeMetadata(metadata string) (map[string]interface{}, error) {
+	maxSize := t.MaxMetadataSize
+	if maxSize == 0 {
+		maxSize = DefaultMaxMetadataSize
+	}
+	if len(metadata) > maxSize {
+		return nil, invalidField("metadata_json", "exceeds %d bytes", maxSize)
+	}
+
+	meta := make(map[string]interface{})
+	if metadata != "" {
+		if err := json.Unmarshal([]byte(metadata), &meta); err != nil {
+			return nil, invalidField("metadata_json", "must be a JSON object")
+		}
+	}
+
+	for name := range meta {
+		if _, ok := t.Metadata[name]; !ok {
+			return nil, invalidField("metadata_json."+name, "unknown field")
+		}
+	}
+
+	for name, f := range t.Metadata {
+		v, ok := meta[name]
+		if !ok {
+			if f.Required {
+				return nil, invalidField("metadata_json."+name, "is required")
+			}
+			continue
+		}
+		if err := f.check(v); err != nil {
+			return nil, invalidField("metadata_json."+name, "%s", err.Error())
+		}
+	}
+	return meta, nil
+}
+
+func (f Field) check(v interface{}) error {
+	length := -1
+
+	switch f.Kind {
+	case FieldString:
+		s, ok := v.(string)
+		if !ok {
+			return fmt.Errorf("must be %s", f.Kind)
+		}
+		length = len(s)
+	case FieldNumber:
+		if _, ok := v.(float64); !ok {
+			return fmt.Errorf("must be %s", f.Kind)
+		}
+	case FieldBool:
+		if _, ok := v.(bool); !ok {
+			return fmt.Errorf("must be %s", f.Kind)
+		}
+	case FieldStringList:
+		list, ok := v.([]interface{})
+		if !ok {
+			return fmt.Errorf("must be %s", f.Kind)
+		}
+		for _, item := range list {
+			if _, ok := item.(string); !ok {
+				return fmt.Errorf("must be %s", f.Kind)
+			}
+		}
+		length = len(list)
+	}
+
+	if f.MaxLen > 0 && length > f.MaxLen {
+		return fmt.Errorf("longer than %d", f.MaxLen)
+	}
+	return nil
+}
@@ -0,0 +1,166 @@
+package domain
+
+import (
+	"fmt"
+	"time"
+)
+
+// Built-in message types.
+const (
+	TypeText     = "text"
+	TypeImage    = "image"
+	TypeVideo    = "video"
+	TypeAudio    = "audio"
+	TypeFile     = "file"
+	TypeLocation = "location"
+	TypePoll     = "poll"
+	TypeSystem   = "system"
+
+	// TypeEncrypted messages are end-to-end encrypted. They carry no
+	// content or metadata, only per-device ciphertexts.
+	TypeEncrypted = "encrypted"
+)
+
+const (
+	maxCaptionSize      = 1000
+	maxMentionsPerMsg   = 50
+	maxPollQuestionSize = 300
+	maxPollOptionSize   = 100
+	MaxPollOptions      = 10
+)
+
+var mentionsField = Field{Kind: FieldStringList, MaxLen: maxMentionsPerMsg}
+
+// DefaultMessageTypes returns a registry holding the built-in types.
+func DefaultMessageTypes() *MessageTypeRegistry {
+	return NewMessageTypeRegistry(
+		MessageType{
+			Name:        TypeText,
+			Content:     Optional,
+			Attachments: Optional,
+			Metadata:    map[string]Field{"mentions": mentionsField},
+			Check: func(content string, _ map[string]interface{}, attachments int) error {
+				if content == "" && attachments == 0 {
+					return invalidField("content", "is required without attachments")
+				}
+				return nil
+			},
+		},
+		MessageType{
+			Name:           TypeImage,
+			Content:        Optional,
+			MaxContentSize: maxCaptionSize,
+			Attachments:    Required,
+			Metadata: map[string]Field{
+				"mentions": mentionsField,
+				"width":    {Kind: FieldNumber},
+				"height":   {Kind: FieldNumber},
+			},
+		},
+		MessageType{
+			Name:           TypeVideo,
+			Content:        Optional,
+			MaxContentSize: maxCaptionSize,
+			Attachments:    Required,
+			Metadata: map[string]Field{
+				"mentions":         mentionsField,
+				"width":            {Kind: FieldNumber},
+				"height":           {Kind: FieldNumber},
+				"duration_seconds": {Kind: FieldNumber},
+			},
+		},
+		MessageType{
+			Name:        TypeAudio,
+			Content:     Forbidden,
+			Attachments: Required,
+			Metadata: map[string]Field{
+				"duration_seconds": {Kind: FieldNumber},
+			},
+		},
+		MessageType{
+			Name:           TypeFile,
+			Content:        Optional,
+			MaxContentSize: maxCaptionSize,
+			Attachments:    Required,
+			Metadata:       map[string]Field{"mentions": mentionsField},
+		},
+		MessageType{
+			Name:           TypeLocation,
+			Content:        Optional,
+			MaxContentSize: maxCaptionSize,
+			Attachments:    Forbidden,
+			Metadata: map[string]Field{
+				"latitude":  {Kind: FieldNumber, Required: true},
+				"longitude": {Kind: FieldNumber, Required: true},
+				"label":     {Kind: FieldString, MaxLen: 200},
+			},
+			Check: checkLocation,
+		},
+		MessageType{
+			Name:           TypePoll,
+			Content:        Required,
+			MaxContentSize: maxPollQuestionSize,
+			Attachments:    Forbidden,
+			Metadata: map[string]Field{
+				"options":         {Kind: FieldStringList, Required: true, MaxLen: MaxPollOptions},
+				"multiple_choice": {Kind: FieldBool},
+				"anonymous":       {Kind: FieldBool},
+				"closes_at":       {Kind: FieldString, MaxLen: 64},
+			},
+			Check: checkPoll,
+		},
+		MessageType{
+			Name:        TypeEncrypted,
+			Content:     Forbidden,
+			Attachments: Forbidden,
+		},
+		MessageType{
+			Name:        TypeSystem,
+			Internal:    true,
+			Content:     Required,
+			Attachments: Forbidden,
+			Metadata: map[string]Field{
+				"event":         {Kind: FieldString, Required: true, MaxLen: 64},
+				"actor_user_id": {Kind: FieldString, MaxLen: 128},
+				"user_ids":      {Kind: FieldStringList},
+			},
+		},
+	)
+}
+
+func checkLocation(_ string, meta map[string]interface{}, _ int) error {
+	if lat := meta["latitude"].(float64); lat < -90 || lat > 90 {
+		return invalidField("metadata_json.latitude", "must be between -90 and 90")
+	}
+	if lng := meta["longitude"].(float64); lng < -180 || lng > 180 {
+		return invalidField("metadata_json.longitude", "must be between -180 and 180")
+	}
+	return nil
+}
+
+func checkPoll(_ string, meta map[string]interface{}, _ int) error {
+	options := meta["options"].([]interface{})
+	if len(options) < 2 {
+		return invalidField("metadata_json.options", "needs at least 2 options")
+	}
+
+	seen := make(map[string]struct{}, len(options))
+	for i, o := range options {
+		opt := o.(string)
+		field := fmt.Sprintf("metadata_json.options[%d]", i)
+		if opt == "" || len(opt) > maxPollOptionSize {
+			return invalidField(field, "must be 1 to %d bytes", maxPollOptionSize)
+		}
+		if _, dup := seen[opt]; dup {
+			return invalidField(field, "duplicates another option")
+		}
+		seen[opt] = struct{}{}
+	}
+
+	if v, ok := meta["closes_at"]; ok {
+		if _, err := time.Parse(time.RFC3339, v.(string)); err != nil {
+			return invalidField("metadata_json.closes_at", "must be an RFC 3339 timestamp")
+		}
+	}
+	return nil
+}
@@ -0,0 +1,10 @@
+package domain
+
+import "time"
+
+// Pin is a message pinned to the top of its conversation.
+type Pin struct {
+	Message  *Message
+	PinnedBy string
+	PinnedAt time.Time
+}
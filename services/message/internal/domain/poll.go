@@ -0,0 +1,204 @@
+package domain
+
+import (
+	"encoding/json"
+	"sort"
+	"time"
+)
+
+// Poll is the voting state of a poll message. The question is the message
+// content; options and settings come from its metadata and never change.
+type Poll struct {
+	MessageID      string
+	ConversationID string
+	CreatorID      string
+	Options        []string
+	MultipleChoice bool
+	Anonymous      bool
+	ClosesAt       *time.Time
+	ClosedAt       *time.Time
+	ClosedBy       string
+
+	// Ballots holds one entry per (user, option) vote, oldest first.
+	Ballots []PollBallot
+
+	// Tally is filled per reader and never persisted.
+	Tally PollTally
+}
+
+// PollBallot is one user's vote for one option.
+type PollBallot struct {
+	UserID      string
+	OptionIndex int
+	VotedAt     time.Time
+}
+
+// PollTally is the aggregated view of a poll's ballots.
+type PollTally struct {
+	Votes []int64
+	// Voters lists the voters per option; nil for anonymous polls.
+	Voters      [][]string
+	TotalVoters int64
+	// MyVotes are the option indexes the viewer voted for.
+	MyVotes []int
+}
+
+// pollMetadata mirrors the metadata of the built-in poll type.
+type pollMetadata struct {
+	Options        []string `json:"options"`
+	MultipleChoice bool     `json:"multiple_choice"`
+	Anonymous      bool     `json:"anonymous"`
+	ClosesAt       string   `json:"closes_at"`
+}
+
+// NewPoll builds the poll for m, a poll message whose metadata has already
+// passed type validation.
+func (m *Message) NewPoll() (*Poll, error) {
+	var meta pollMetadata
+	if err := json.Unmarshal([]byte(m.Metadata), &meta); err != nil {
+		return nil, invalidField("metadata_json", "must be a JSON object")
+	}
+
+	p := &Poll{
+		MessageID:      m.ID,
+		ConversationID: m.ConversationID,
+		CreatorID:      m.SenderID,
+		Options:        meta.Options,
+		MultipleChoice: meta.MultipleChoice,
+		Anonymous:      meta.Anonymous,
+	}
+	if meta.ClosesAt != "" {
+		t, err := time.Parse(time.RFC3339, meta.ClosesAt)
+		if err != nil {
+			return nil, invalidField("metadata_json.closes_at", "must be an RFC 3339 timestamp")
+		}
+		t = t.UTC()
+		p.ClosesAt = &t
+	}
+	return p, nil
+}
+
+// CheckOpen rejects a new poll whose close time is not after now.
+func (p *Poll) CheckOpen(now time.Time) error {
+	if p.ClosesAt != nil && !p.ClosesAt.After(now) {
+		return invalidField("metadata_json.closes_at", "must be in the future")
+	}
+	return nil
+}
+
+// Closed reports whether voting has ended, either explicitly or because the
+// close time has passed.
+func (p *Poll) Closed(now time.Time) bool {
+	return p.ClosedAt != nil || (p.ClosesAt != nil && !now.Before(*p.ClosesAt))
+}
+
+// VotesOf returns the option indexes userID voted for, in ascending order.
+func (p *Poll) VotesOf(userID string) []int {
+	var out []int
+	for _, b := range p.Ballots {
+		if b.UserID == userID {
+			out = append(out, b.OptionIndex)
+		}
+	}
+	sort.Ints(out)
+	return out
+}
+
+// Vote replaces userID's votes with options. It reports false when the vote
+// is unchanged.
+func (p *Poll) Vote(userID string, options []int, now time.Time) (bool, error) {
+	if p.Closed(now) {
+		return false, ErrPollClosed
+	}
+	if len(options) == 0 || (!p.MultipleChoice && len(options) > 1) {
+		return false, ErrInvalidVote
+	}
+
+	chosen := make([]int, len(options))
+	copy(chosen, options)
+	sort.Ints(chosen)
+	for i, o := range chosen {
+		if o < 0 || o >= len(p.Options) || (i > 0 && chosen[i-1] == o) {
+			return false, ErrInvalidVote
+		}
+	}
+
+	if equalInts(p.VotesOf(userID), chosen) {
+		return false, nil
+	}
+
+	p.removeBallots(userID)
+	for _, o := range chosen {
+		p.Ballots = append(p.Ballots, PollBallot{UserID: userID, OptionIndex: o, VotedAt: now})
+	}
+	return true, nil
+}
+
+// Retract removes userID's votes. It reports false when there were none.
+func (p *Poll) Retract(userID string, now time.Time) (bool, error) {
+	if p.Closed(now) {
+		return false, ErrPollClosed
+	}
+	return p.removeBallots(userID), nil
+}
+
+// Close ends voting. It reports false when the poll was already closed.
+func (p *Poll) Close(userID string, now time.Time) bool {
+	if p.Closed(now) {
+		return false
+	}
+	p.ClosedAt = &now
+	p.ClosedBy = userID
+	return true
+}
+
+// Count tallies the ballots from viewerID's point of view. Pass "" to leave
+// MyVotes empty.
+func (p *Poll) Count(viewerID string) PollTally {
+	t := PollTally{Votes: make([]int64, len(p.Options))}
+	if !p.Anonymous {
+		t.Voters = make([][]string, len(p.Options))
+	}
+
+	voters := make(map[string]struct{})
+	for _, b := range p.Ballots {
+		if b.OptionIndex < 0 || b.OptionIndex >= len(p.Options) {
+			continue
+		}
+		t.Votes[b.OptionIndex]++
+		if t.Voters != nil {
+			t.Voters[b.OptionIndex] = append(t.Voters[b.OptionIndex], b.UserID)
+		}
+		voters[b.UserID] = struct{}{}
+	}
+	t.TotalVoters = int64(len(voters))
+
+	if viewerID != "" {
+		t.MyVotes = p.VotesOf(viewerID)
+	}
+	return t
+}
+
+func (p *Poll) removeBallots(userID string) bool {
+	kept := p.Ballots[:0]
+	for _, b := range p.Ballots {
+		if b.UserID != userID {
+			kept = append(kept, b)
+		}
+	}
+	removed := len(kept) != len(p.Ballots)
+	p.Ballots = kept
+	return removed
+}
+
+func equalInts(a, b []int) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	for i := range a {
+		if a[i] != b[i] {
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,25 @@
+package domain
+
+import (
+	"strings"
+	"unicode/utf8"
+)
+
+// MaxReactionLength bounds the emoji string; multi-codepoint sequences such
+// as flags or skin-tone variants stay well below it.
+const MaxReactionLength = 32
+
+// ReactionSummary is the aggregated view of one emoji on a message.
+type ReactionSummary struct {
+	Emoji       string
+	Count       int64
+	ReactedByMe bool
+}
+
+// ValidateReaction checks that emoji is a short, non-blank, valid UTF-8 string.
+func ValidateReaction(emoji string) error {
+	if strings.TrimSpace(emoji) == "" || len(emoji) > MaxReactionLength || !utf8.ValidString(emoji) {
+		return ErrInvalidReaction
+	}
+	return nil
+}
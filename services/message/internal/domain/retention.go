@@ -0,0 +1,110 @@
+package domain
+
+import "time"
+
+// MinRetention is the shortest retention a conversation can be given.
+// Archival works on whole months, so anything shorter would not be honoured
+// anyway.
+const MinRetention = 24 * time.Hour
+
+// RetentionPolicy overrides the service-wide retention for one
+// conversation. A Retention of zero keeps messages forever.
+type RetentionPolicy struct {
+	ConversationID string
+	Retention      time.Duration
+	UpdatedBy      string
+	UpdatedAt      time.Time
+}
+
+// ValidateRetention accepts zero or whole seconds of at least MinRetention.
+func ValidateRetention(d time.Duration) error {
+	if d == 0 {
+		return nil
+	}
+	if d < MinRetention || d%time.Second != 0 {
+		return ErrInvalidRetention
+	}
+	return nil
+}
+
+// Archive is one month of a conversation's messages moved out of the
+// messages table into a blob. While restored, the messages are back in the
+// table until RestoredUntil.
+type Archive struct {
+	ConversationID string
+	PeriodStart    time.Time
+	PeriodEnd      time.Time
+	ObjectKey      string
+	MessageCount   int64
+	FirstSequence  int64
+	LastSequence   int64
+	ArchivedAt     time.Time
+	RestoredAt     *time.Time
+	RestoredUntil  *time.Time
+}
+
+// MessageExtras are the rows of a message that reads never load: the
+// content its edits replaced, each user's reactions and its pin.
+type MessageExtras struct {
+	Revisions []Revision
+	Reactions []Reaction
+	PinnedBy  string
+	PinnedAt  *time.Time
+}
+
+// Revision is content of a message replaced by an edit.
+type Revision struct {
+	Number     int
+	Content    string
+	Metadata   string
+	ReplacedAt time.Time
+}
+
+// Reaction is one user's reaction to a message.
+type Reaction struct {
+	UserID    string
+	Emoji     string
+	CreatedAt time.Time
+}
+
+// NewArchive describes msgs, all sent in the month starting at periodStart.
+func NewArchive(conversationID string, periodStart time.Time, msgs []*Message, now time.Time) *Archive {
+	start, end := ArchivePeriod(periodStart)
+	a := &Archive{
+		ConversationID: conversationID,
+		PeriodStart:    start,
+		PeriodEnd:      end,
+		ObjectKey:      ArchiveObjectKey(conversationID, start),
+		MessageCount:   int64(len(msgs)),
+		ArchivedAt:     now,
+	}
+	for _, m := range msgs {
+		if a.FirstSequence == 0 || m.Sequence < a.FirstSequence {
+			a.FirstSequence = m.Sequence
+		}
+		if m.Sequence > a.LastSequence {
+			a.LastSequence = m.Sequence
+		}
+	}
+	return a
+}
+
+// ArchiveUnit is a month of one conversation due for archival.
+type ArchiveUnit struct {
+	ConversationID string
+	PeriodStart    time.Time
+}
+
+// ArchivePeriod returns the UTC month containing t, which is also the
+// messages partition holding it.
+func ArchivePeriod(t time.Time) (start, end time.Time) {
+	t = t.UTC()
+	start = time.Date(t.Year(), t.Month(), 1, 0, 0, 0, 0, time.UTC)
+	return start, start.AddDate(0, 1, 0)
+}
+
+// ArchiveObjectKey is where the archive of a conversation's month is
+// stored.
+func ArchiveObjectKey(conversationID string, periodStart time.Time) string {
+	return "messages/" + conversationID + "/" + periodStart.UTC().Format("2006-01") + ".jsonl.gz"
+}
@@ -0,0 +1,133 @@
+package domain
+
+import "time"
+
+type ScheduledStatus string
+
+const (
+	ScheduledPending   ScheduledStatus = "pending"
+	ScheduledSending   ScheduledStatus = "sending"
+	ScheduledSent      ScheduledStatus = "sent"
+	ScheduledCancelled ScheduledStatus = "cancelled"
+	ScheduledFailed    ScheduledStatus = "failed"
+)
+
+// ScheduledMessage is a message held back until SendAt. It is only turned
+// into a Message, with a sequence, when it fires.
+type ScheduledMessage struct {
+	ID             string
+	ConversationID string
+	SenderID       string
+	Type           string
+	Content        string
+	Metadata       string
+	ReplyToID      string
+	AttachmentIDs  []string
+	SendAt         time.Time
+	CreatedAt      time.Time
+	UpdatedAt      time.Time
+
+	Status ScheduledStatus
+	// MessageID is set once sent; FailureReason once it failed permanently.
+	MessageID     string
+	FailureReason string
+	// ClaimedAt is when a worker took the message to send it, while it is
+	// sending.
+	ClaimedAt *time.Time
+}
+
+func NewScheduledMessage(
+	id string,
+	conversationID string,
+	senderID string,
+	msgType string,
+	content string,
+	metadata string,
+	replyToID string,
+	attachmentIDs []string,
+	sendAt time.Time,
+	now time.Time,
+) (*ScheduledMessage, error) {
+
+	if id == "" || conversationID == "" || senderID == "" {
+		return nil, ErrInvalidMessage
+	}
+
+	if !sendAt.After(now) {
+		return nil, ErrInvalidSchedule
+	}
+
+	if len(content) > MaxMessageSize {
+		return nil, ErrMessageTooLarge
+	}
+
+	if err := ValidateAttachmentIDs(attachmentIDs); err != nil {
+		return nil, err
+	}
+
+	return &ScheduledMessage{
+		ID:             id,
+		ConversationID: conversationID,
+		SenderID:       senderID,
+		Type:           msgType,
+		Content:        content,
+		Metadata:       metadata,
+		ReplyToID:      replyToID,
+		AttachmentIDs:  attachmentIDs,
+		SendAt:         sendAt,
+		CreatedAt:      now,
+		UpdatedAt:      now,
+		Status:         ScheduledPending,
+	}, nil
+}
+
+// Edit replaces the content and, when sendAt is non-zero, the send time of a
+// pending scheduled message.
+func (m *ScheduledMessage) Edit(
+	editorID string,
+	content string,
+	metadata string,
+	sendAt time.Time,
+	now time.Time,
+) error {
+
+	if err := m.checkPending(editorID); err != nil {
+		return err
+	}
+
+	if !sendAt.IsZero() {
+		if !sendAt.After(now) {
+			return ErrInvalidSchedule
+		}
+		m.SendAt = sendAt
+	}
+
+	if len(content) > MaxMessageSize {
+		return ErrMessageTooLarge
+	}
+
+	m.Content = content
+	m.Metadata = metadata
+	m.UpdatedAt = now
+	return nil
+}
+
+// Cancel stops a pending scheduled message from being sent.
+func (m *ScheduledMessage) Cancel(userID string, now time.Time) error {
+	if err := m.checkPending(userID); err != nil {
+		return err
+	}
+	m.Status = ScheduledCancelled
+	m.UpdatedAt = now
+	return nil
+}
+
+func (m *ScheduledMessage) checkPending(userID string) error {
+	if m.SenderID != userID {
+		return ErrNotSender
+	}
+	if m.Status != ScheduledPending {
+		return ErrScheduleNotPending
+	}
+	return nil
+}
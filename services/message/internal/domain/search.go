@@ -0,0 +1,25 @@
+package domain
+
+import "time"
+
+// SearchQuery is a full-text search over a fixed set of conversations.
+// Zero-valued filters are ignored. Results are ordered newest first and
+// AfterSentAt/AfterID, when set, resume strictly after that position.
+type SearchQuery struct {
+	Text            string
+	ConversationIDs []string
+	SenderID        string
+	Type            string
+	SentAfter       time.Time
+	SentBefore      time.Time
+	AfterSentAt     time.Time
+	AfterID         string
+	Limit           int
+}
+
+// SearchHit is a matching message with a highlighted excerpt. Snippet is
+// HTML-escaped content with the matches wrapped in <mark></mark>.
+type SearchHit struct {
+	Message *Message
+	Snippet string
+}
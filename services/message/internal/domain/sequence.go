@@ -0,0 +1,41 @@
+package domain
+
+// SequenceBlock is the range of message sequences a conversation has
+// reserved from the conversation service. Sequences are handed out in order
+// from Next through Last, and a block only ever grows by the range directly
+// after Last, so allocated sequences stay gapless (see Message).
+type SequenceBlock struct {
+	ConversationID string
+	Next           int64 // Zero until the first reservation
+	Last           int64
+}
+
+// Remaining is how many reserved sequences are still unallocated.
+func (b *SequenceBlock) Remaining() int64 {
+	if b.Next == 0 {
+		return 0
+	}
+	return b.Last - b.Next + 1
+}
+
+// Extend appends the reserved range first..last to the block.
+func (b *SequenceBlock) Extend(first, last int64) error {
+	if first <= 0 || last < first || (b.Next != 0 && first != b.Last+1) {
+		return ErrSequenceGap
+	}
+	if b.Next == 0 {
+		b.Next = first
+	}
+	b.Last = last
+	return nil
+}
+
+// Take allocates n consecutive sequences and returns the first of them.
+func (b *SequenceBlock) Take(n int64) (int64, error) {
+	if n <= 0 || n > b.Remaining() {
+		return 0, ErrSequencesExhausted
+	}
+	first := b.Next
+	b.Next += n
+	return first, nil
+}
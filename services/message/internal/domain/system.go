@@ -0,0 +1,86 @@
+package domain
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+	"time"
+)
+
+// SystemSenderID is the sender of system messages.
+const SystemSenderID = "system"
+
+// System message events, stored in the "event" metadata field.
+const (
+	SystemConversationCreated = "conversation_created"
+	SystemMemberAdded         = "member_added"
+	SystemMemberRemoved       = "member_removed"
+	SystemMemberLeft          = "member_left"
+)
+
+// SystemEvent describes a change recorded in the timeline as a system
+// message. Clients render it from the metadata; the content is a plain-text
+// fallback naming users by ID.
+type SystemEvent struct {
+	Event   string   `json:"event"`
+	ActorID string   `json:"actor_user_id,omitempty"`
+	UserIDs []string `json:"user_ids,omitempty"`
+}
+
+// MembershipEvent returns the system event for userID joining or leaving
+// the conversation because of actorID.
+func MembershipEvent(actorID, userID string, added bool) SystemEvent {
+	e := SystemEvent{ActorID: actorID, UserIDs: []string{userID}}
+	switch {
+	case added:
+		e.Event = SystemMemberAdded
+	case actorID == "" || actorID == userID:
+		e.Event = SystemMemberLeft
+	default:
+		e.Event = SystemMemberRemoved
+	}
+	return e
+}
+
+// Content renders e as plain text, e.g. "alice added bob".
+func (e SystemEvent) Content() string {
+	users := strings.Join(e.UserIDs, ", ")
+	actor := e.ActorID
+	if actor == "" {
+		actor = "someone"
+	}
+
+	switch e.Event {
+	case SystemConversationCreated:
+		return fmt.Sprintf("%s created the conversation", actor)
+	case SystemMemberAdded:
+		return fmt.Sprintf("%s added %s", actor, users)
+	case SystemMemberRemoved:
+		return fmt.Sprintf("%s removed %s", actor, users)
+	case SystemMemberLeft:
+		return fmt.Sprintf("%s left", users)
+	default:
+		return e.Event
+	}
+}
+
+// NewSystemMessage builds the system message recording e.
+func NewSystemMessage(
+	id string,
+	conversationID string,
+	sequence int64,
+	e SystemEvent,
+	now time.Time,
+) (*Message, error) {
+
+	if e.Event == "" {
+		return nil, ErrInvalidMessage
+	}
+
+	metadata, err := json.Marshal(e)
+	if err != nil {
+		return nil, err
+	}
+
+	return NewMessage(id, conversationID, SystemSenderID, sequence, TypeSystem, e.Content(), string(metadata), now)
+}
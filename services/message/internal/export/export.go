@@ -0,0 +1,85 @@
+// Package export renders a conversation's history for people to keep. An
+// export is a zip with the same history in every format of Formats.
+package export
+
+import (
+	"encoding/json"
+	"io"
+	"time"
+)
+
+// Header describes the export as a whole.
+type Header struct {
+	ConversationID string    `json:"conversation_id"`
+	ExportedBy     string    `json:"exported_by"`
+	ExportedAt     time.Time `json:"exported_at"`
+	// Since is when the exporting user joined, if the export starts there.
+	Since *time.Time `json:"since,omitempty"`
+}
+
+type Participant struct {
+	UserID      string `json:"user_id"`
+	DisplayName string `json:"display_name,omitempty"`
+	AvatarURL   string `json:"avatar_url,omitempty"`
+}
+
+// Name is how the participant is shown: their display name, or their user
+// ID without one.
+func (p Participant) Name() string {
+	if p.DisplayName != "" {
+		return p.DisplayName
+	}
+	return p.UserID
+}
+
+type Message struct {
+	ID            string          `json:"id"`
+	Sequence      int64           `json:"sequence"`
+	SenderID      string          `json:"sender_id"`
+	SenderName    string          `json:"sender_name"`
+	Type          string          `json:"type"`
+	Content       string          `json:"content"`
+	Metadata      json.RawMessage `json:"metadata,omitempty"`
+	SentAt        time.Time       `json:"sent_at"`
+	EditedAt      *time.Time      `json:"edited_at,omitempty"`
+	ReplyToID     string          `json:"reply_to_id,omitempty"`
+	ThreadRootID  string          `json:"thread_root_id,omitempty"`
+	ForwardedFrom *ForwardedFrom  `json:"forwarded_from,omitempty"`
+	Attachments   []Attachment    `json:"attachments,omitempty"`
+}
+
+type ForwardedFrom struct {
+	MessageID      string    `json:"message_id"`
+	ConversationID string    `json:"conversation_id"`
+	SenderID       string    `json:"sender_id"`
+	SentAt         time.Time `json:"sent_at"`
+}
+
+// Attachment is a reference to a media-service file. Exports don't carry
+// the files themselves.
+type Attachment struct {
+	ID        string `json:"id"`
+	FileName  string `json:"file_name"`
+	MimeType  string `json:"mime_type"`
+	SizeBytes int64  `json:"size_bytes"`
+}
+
+// Writer renders one format. Messages is called with consecutive pages in
+// sequence order, then Finish once with everyone who appears in them.
+type Writer interface {
+	Messages(msgs []Message) error
+	Finish(participants []Participant) error
+}
+
+// Format is one rendering of an export, stored in the zip as FileName.
+type Format struct {
+	FileName string
+	New      func(w io.Writer, h Header) (Writer, error)
+}
+
+// Formats are written to every export, in this order.
+var Formats = []Format{
+	{FileName: "conversation.json", New: NewJSONWriter},
+	{FileName: "conversation.html", New: NewHTMLWriter},
+	{FileName: "conversation.txt", New: NewTextWriter},
+}
@@ -0,0 +1,86 @@
+package export
+
+import (
+	"fmt"
+	"html/template"
+	"io"
+	"time"
+)
+
+var htmlTemplates = template.Must(template.New("export").Funcs(template.FuncMap{
+	"time": formatTime,
+	"size": formatSize,
+}).Parse(`
+{{define "head"}}<!DOCTYPE html>
+<html>
+<head>
+<meta charset="utf-8">
+<title>Conversation {{.ConversationID}}</title>
+<style>
+body { font-family: sans-serif; max-width: 48rem; margin: 2rem auto; color: #222; }
+.meta, .when, .note { color: #777; font-size: 0.85rem; }
+.message { margin: 0.75rem 0; }
+.system { font-style: italic; color: #555; }
+.content { white-space: pre-wrap; margin: 0.2rem 0; }
+ul.attachments { margin: 0.2rem 0; padding-left: 1.25rem; font-size: 0.9rem; }
+</style>
+</head>
+<body>
+<h1>Conversation {{.ConversationID}}</h1>
+<p class="meta">Exported by {{.ExportedBy}} on {{time .ExportedAt}}{{with .Since}}, history since {{time .}}{{end}}</p>
+<section id="messages">
+{{end}}
+
+{{define "messages"}}{{range .}}<div class="message{{if eq .Type "system"}} system{{end}}" id="m{{.Sequence}}">
+<div><strong>{{.SenderName}}</strong> <span class="when">{{time .SentAt}}{{if .EditedAt}} (edited){{end}}</span></div>
+{{with .ForwardedFrom}}<div class="note">Forwarded from {{.SenderID}}, {{time .SentAt}}</div>
+{{end}}{{with .ReplyToID}}<div class="note">Reply to message {{.}}</div>
+{{end}}<div class="content">{{.Content}}</div>
+{{with .Attachments}}<ul class="attachments">
+{{range .}}<li>{{.FileName}} ({{.MimeType}}, {{size .SizeBytes}})</li>
+{{end}}</ul>
+{{end}}</div>
+{{end}}{{end}}
+
+{{define "foot"}}</section>
+<h2>Participants</h2>
+<ul>
+{{range .}}<li>{{.Name}} <span class="meta">{{.UserID}}</span></li>
+{{end}}</ul>
+</body>
+</html>
+{{end}}
+`))
+
+type htmlWriter struct {
+	w io.Writer
+}
+
+func NewHTMLWriter(w io.Writer, h Header) (Writer, error) {
+	if err := htmlTemplates.ExecuteTemplate(w, "head", h); err != nil {
+		return nil, err
+	}
+	return &htmlWriter{w: w}, nil
+}
+
+func (h *htmlWriter) Messages(msgs []Message) error {
+	return htmlTemplates.ExecuteTemplate(h.w, "messages", msgs)
+}
+
+func (h *htmlWriter) Finish(participants []Participant) error {
+	return htmlTemplates.ExecuteTemplate(h.w, "foot", participants)
+}
+
+func formatTime(t time.Time) string {
+	return t.UTC().Format("2006-01-02 15:04:05 UTC")
+}
+
+func formatSize(n int64) string {
+	switch {
+	case n >= 1<<20:
+		return fmt.Sprintf("%.1f MB", float64(n)/(1<<20))
+	case n >= 1<<10:
+		return fmt.Sprintf("%.1f KB", float64(n)/(1<<10))
+	}
+	return fmt.Sprintf("%d B", n)
+}
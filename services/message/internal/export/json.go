@@ -0,0 +1,58 @@
+package export
+
+import (
+	"bufio"
+	"encoding/json"
+	"io"
+)
+
+// jsonWriter streams an object holding the header fields, "messages" and
+// "participants", without keeping the messages in memory.
+type jsonWriter struct {
+	w     *bufio.Writer
+	first bool
+}
+
+func NewJSONWriter(w io.Writer, h Header) (Writer, error) {
+	head, err := json.Marshal(h)
+	if err != nil {
+		return nil, err
+	}
+	bw := bufio.NewWriter(w)
+	// Open the header object back up to append the arrays
+	bw.Write(head[:len(head)-1])
+	bw.WriteString(`,"messages":[`)
+	return &jsonWriter{w: bw, first: true}, nil
+}
+
+func (j *jsonWriter) Messages(msgs []Message) error {
+	for _, m := range msgs {
+		b, err := json.Marshal(m)
+		if err != nil {
+			return err
+		}
+		if !j.first {
+			j.w.WriteByte(',')
+		}
+		j.first = false
+		j.w.WriteString("\n")
+		if _, err := j.w.Write(b); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (j *jsonWriter) Finish(participants []Participant) error {
+	if participants == nil {
+		participants = []Participant{}
+	}
+	b, err := json.Marshal(participants)
+	if err != nil {
+		return err
+	}
+	j.w.WriteString("\n],\"participants\":")
+	j.w.Write(b)
+	j.w.WriteString("}\n")
+	return j.w.Flush()
+}
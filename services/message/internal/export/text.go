@@ -0,0 +1,64 @@
+package export
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"strings"
+)
+
+type textWriter struct {
+	w *bufio.Writer
+}
+
+func NewTextWriter(w io.Writer, h Header) (Writer, error) {
+	bw := bufio.NewWriter(w)
+	fmt.Fprintf(bw, "Conversation %s\n", h.ConversationID)
+	fmt.Fprintf(bw, "Exported by %s on %s\n", h.ExportedBy, formatTime(h.ExportedAt))
+	if h.Since != nil {
+		fmt.Fprintf(bw, "History since %s\n", formatTime(*h.Since))
+	}
+	bw.WriteString("\n")
+	return &textWriter{w: bw}, bw.Flush()
+}
+
+func (t *textWriter) Messages(msgs []Message) error {
+	for _, m := range msgs {
+		edited := ""
+		if m.EditedAt != nil {
+			edited = " (edited)"
+		}
+		fmt.Fprintf(t.w, "[%s] %s%s:", formatTime(m.SentAt), m.SenderName, edited)
+
+		// Continuation lines are indented so every message starts a line
+		content := strings.ReplaceAll(m.Content, "\n", "\n    ")
+		if strings.Contains(m.Content, "\n") {
+			fmt.Fprintf(t.w, "\n    %s\n", content)
+		} else {
+			fmt.Fprintf(t.w, " %s\n", content)
+		}
+
+		if f := m.ForwardedFrom; f != nil {
+			fmt.Fprintf(t.w, "    (forwarded from %s, %s)\n", f.SenderID, formatTime(f.SentAt))
+		}
+		if m.ReplyToID != "" {
+			fmt.Fprintf(t.w, "    (reply to message %s)\n", m.ReplyToID)
+		}
+		for _, a := range m.Attachments {
+			fmt.Fprintf(t.w, "    [attachment] %s (%s, %s)\n", a.FileName, a.MimeType, formatSize(a.SizeBytes))
+		}
+	}
+	return t.w.Flush()
+}
+
+func (t *textWriter) Finish(participants []Participant) error {
+	t.w.WriteString("\nParticipants\n")
+	for _, p := range participants {
+		if p.DisplayName != "" {
+			fmt.Fprintf(t.w, "  %s (%s)\n", p.DisplayName, p.UserID)
+		} else {
+			fmt.Fprintf(t.w, "  %s\n", p.UserID)
+		}
+	}
+	return t.w.Flush()
+}
@@ -0,0 +1,60 @@
+package exporter
+
+import (
+	"context"
+	"time"
+
+	"github.com/SARVESHVARADKAR123/RealChat/services/message/internal/auth"
+	"github.com/SARVESHVARADKAR123/RealChat/services/message/internal/domain"
+	"github.com/SARVESHVARADKAR123/RealChat/services/message/internal/observability"
+	"go.uber.org/zap"
+)
+
+// Exporter builds conversation exports.
+type Exporter interface {
+	ClaimExports(ctx context.Context, now time.Time, limit int) ([]*domain.Export, error)
+	BuildExport(ctx context.Context, e *domain.Export) error
+}
+
+// Worker builds queued conversation exports. Exports are claimed with FOR
+// UPDATE SKIP LOCKED, so several replicas can run it side by side.
+type Worker struct {
+	Exporter  Exporter
+	BatchSize int
+	PollDelay time.Duration
+}
+
+// Start Worker
+func (w *Worker) Start(ctx context.Context) {
+
+	log := observability.GetLogger(ctx)
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		default:
+		}
+
+		claimed, err := w.Exporter.ClaimExports(ctx, time.Now().UTC(), w.BatchSize)
+		if err != nil {
+			log.Error("exporter error", zap.Error(err))
+			time.Sleep(time.Second)
+			continue
+		}
+
+		for _, e := range claimed {
+			// Downstream calls are made on behalf of the requester
+			userCtx := context.WithValue(ctx, auth.UserIDKey, e.UserID)
+			if err := w.Exporter.BuildExport(userCtx, e); err != nil {
+				// The export is claimed again once its lease runs out
+				log.Error("exporter error", zap.String("export_id", e.ID), zap.Error(err))
+				continue
+			}
+			observability.ExportsTotal.WithLabelValues(string(e.Status)).Inc()
+		}
+
+		if len(claimed) < w.BatchSize {
+			time.Sleep(w.PollDelay)
+		}
+	}
+}
@@ -0,0 +1,60 @@
+package importer
+
+import (
+	"context"
+	"time"
+
+	"github.com/SARVESHVARADKAR123/RealChat/services/message/internal/auth"
+	"github.com/SARVESHVARADKAR123/RealChat/services/message/internal/domain"
+	"github.com/SARVESHVARADKAR123/RealChat/services/message/internal/observability"
+	"go.uber.org/zap"
+)
+
+// Importer runs history imports.
+type Importer interface {
+	ClaimImports(ctx context.Context, now time.Time, limit int) ([]*domain.Import, error)
+	RunImport(ctx context.Context, imp *domain.Import) error
+}
+
+// Worker runs imports requested through the API. Imports are claimed with
+// FOR UPDATE SKIP LOCKED, so several replicas can run it side by side.
+type Worker struct {
+	Importer  Importer
+	BatchSize int
+	PollDelay time.Duration
+}
+
+// Start Worker
+func (w *Worker) Start(ctx context.Context) {
+
+	log := observability.GetLogger(ctx)
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		default:
+		}
+
+		claimed, err := w.Importer.ClaimImports(ctx, time.Now().UTC(), w.BatchSize)
+		if err != nil {
+			log.Error("importer error", zap.Error(err))
+			time.Sleep(time.Second)
+			continue
+		}
+
+		for _, imp := range claimed {
+			// Downstream calls are made on behalf of the importer
+			userCtx := context.WithValue(ctx, auth.UserIDKey, imp.CreatedBy)
+			if err := w.Importer.RunImport(userCtx, imp); err != nil {
+				// The import is claimed again once its lease runs out
+				log.Error("importer error", zap.String("import_id", imp.ID), zap.Error(err))
+				continue
+			}
+			observability.ImportsTotal.WithLabelValues(string(imp.Status)).Inc()
+		}
+
+		if len(claimed) < w.BatchSize {
+			time.Sleep(w.PollDelay)
+		}
+	}
+}
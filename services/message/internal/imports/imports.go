@@ -0,0 +1,82 @@
+// Package imports reads chat history exported from other chat apps into a
+// common form for the import pipeline.
+package imports
+
+import (
+	"errors"
+	"fmt"
+	"sort"
+	"time"
+)
+
+const (
+	SourceSlack    = "slack"
+	SourceWhatsApp = "whatsapp"
+)
+
+var ErrUnsupported = errors.New("unsupported export")
+
+// Message is one message of an export. Sender is the export's own name for
+// the user; the import's mapping turns it into a RealChat user ID.
+type Message struct {
+	// ExternalID is unique within the export and stable across parses.
+	ExternalID string
+	Sender     string
+	SentAt     time.Time
+	Text       string
+	// ThreadID is the ExternalID of the thread's first message, for replies.
+	ThreadID string
+}
+
+// Options tune how an export is read.
+type Options struct {
+	// Channel picks the channel of a Slack workspace export. It may be left
+	// empty when the export holds a single channel.
+	Channel string `json:"channel,omitempty"`
+	// TimeZone is the IANA time zone of WhatsApp timestamps, which carry none.
+	// Empty means UTC.
+	TimeZone string `json:"time_zone,omitempty"`
+	// DayFirst reads WhatsApp dates as day/month instead of month/day.
+	DayFirst bool `json:"day_first,omitempty"`
+}
+
+// Parse reads an export of the given source. Messages come back oldest
+// first, in the same order on every parse of the same input.
+func Parse(source string, data []byte, opts Options) ([]Message, error) {
+	var (
+		msgs []Message
+		err  error
+	)
+	switch source {
+	case SourceSlack:
+		msgs, err = parseSlack(data, opts)
+	case SourceWhatsApp:
+		msgs, err = parseWhatsApp(data, opts)
+	default:
+		return nil, fmt.Errorf("%w: unknown source %q", ErrUnsupported, source)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	sort.SliceStable(msgs, func(i, j int) bool { return msgs[i].SentAt.Before(msgs[j].SentAt) })
+	return msgs, nil
+}
+
+// ValidSource reports whether source names a supported export format.
+func ValidSource(source string) bool {
+	return source == SourceSlack || source == SourceWhatsApp
+}
+
+// Senders returns the distinct senders of msgs in order of appearance.
+func Senders(msgs []Message) []string {
+	seen := map[string]bool{}
+	var out []string
+	for _, m := range msgs {
+		if !seen[m.Sender] {
+			seen[m.Sender] = true
+			out = append(out, m.Sender)
+		}
+	}
+	return out
+}
@@ -0,0 +1,149 @@
+package imports
+
+import (
+	"archive/zip"
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"html"
+	"io"
+	"path"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+)
+
+type slackMessage struct {
+	Type     string `json:"type"`
+	Subtype  string `json:"subtype"`
+	User     string `json:"user"`
+	Text     string `json:"text"`
+	TS       string `json:"ts"`
+	ThreadTS string `json:"thread_ts"`
+	Files    []struct {
+		Name  string `json:"name"`
+		Title string `json:"title"`
+	} `json:"files"`
+}
+
+// slackSubtypes are the message subtypes carrying something a user said.
+// Joins, topic changes and the like are left out.
+var slackSubtypes = map[string]bool{
+	"":                 true,
+	"thread_broadcast": true,
+	"file_share":       true,
+	"me_message":       true,
+}
+
+// parseSlack reads a Slack export: either the workspace zip, with one
+// directory of daily JSON files per channel, or a single JSON array of
+// messages. Messages are keyed by user ID (e.g. U024BE7LH).
+func parseSlack(data []byte, opts Options) ([]Message, error) {
+	if trimmed := bytes.TrimSpace(data); len(trimmed) > 0 && trimmed[0] == '[' {
+		return slackMessages([][]byte{data})
+	}
+
+	zr, err := zip.NewReader(bytes.NewReader(data), int64(len(data)))
+	if err != nil {
+		return nil, fmt.Errorf("%w: slack export is neither a zip nor a JSON array", ErrUnsupported)
+	}
+
+	// Daily files live at <channel>/<YYYY-MM-DD>.json
+	days := map[string][]*zip.File{}
+	for _, f := range zr.File {
+		dir, name := path.Split(f.Name)
+		dir = strings.TrimSuffix(dir, "/")
+		if dir == "" || strings.Contains(dir, "/") || path.Ext(name) != ".json" {
+			continue
+		}
+		days[dir] = append(days[dir], f)
+	}
+
+	channel := opts.Channel
+	if channel == "" {
+		if len(days) != 1 {
+			return nil, fmt.Errorf("%w: slack export has %d channels, pick one", ErrUnsupported, len(days))
+		}
+		for c := range days {
+			channel = c
+		}
+	}
+	files, ok := days[channel]
+	if !ok {
+		return nil, fmt.Errorf("%w: slack export has no channel %q", ErrUnsupported, channel)
+	}
+	sort.Slice(files, func(i, j int) bool { return files[i].Name < files[j].Name })
+
+	pages := make([][]byte, 0, len(files))
+	for _, f := range files {
+		rc, err := f.Open()
+		if err != nil {
+			return nil, err
+		}
+		b, err := io.ReadAll(rc)
+		rc.Close()
+		if err != nil {
+			return nil, err
+		}
+		pages = append(pages, b)
+	}
+	return slackMessages(pages)
+}
+
+func slackMessages(pages [][]byte) ([]Message, error) {
+	var out []Message
+	for _, page := range pages {
+		var raw []slackMessage
+		if err := json.Unmarshal(page, &raw); err != nil {
+			return nil, fmt.Errorf("%w: %v", ErrUnsupported, err)
+		}
+
+		for _, m := range raw {
+			if m.Type != "message" || !slackSubtypes[m.Subtype] || m.User == "" {
+				continue
+			}
+			sentAt, err := slackTime(m.TS)
+			if err != nil {
+				return nil, fmt.Errorf("%w: message ts %q", ErrUnsupported, m.TS)
+			}
+
+			text := html.UnescapeString(m.Text)
+			for _, f := range m.Files {
+				name := f.Name
+				if name == "" {
+					name = f.Title
+				}
+				text = strings.TrimSpace(text + "\n[file: " + name + "]")
+			}
+			if text == "" {
+				continue
+			}
+
+			msg := Message{ExternalID: m.TS, Sender: m.User, SentAt: sentAt, Text: text}
+			if m.ThreadTS != "" && m.ThreadTS != m.TS {
+				msg.ThreadID = m.ThreadTS
+			}
+			out = append(out, msg)
+		}
+	}
+	return out, nil
+}
+
+// slackTime reads a Slack ts, seconds since the epoch with a microsecond
+// fraction such as "1355517523.000005".
+func slackTime(ts string) (time.Time, error) {
+	secs, frac, _ := strings.Cut(ts, ".")
+	s, err := strconv.ParseInt(secs, 10, 64)
+	if err != nil {
+		return time.Time{}, err
+	}
+	var us int64
+	if frac != "" {
+		frac = (frac + "000000")[:6]
+		if us, err = strconv.ParseInt(frac, 10, 64); err != nil {
+			return time.Time{}, err
+		}
+	}
+	return time.Unix(s, us*1000).UTC(), nil
+}
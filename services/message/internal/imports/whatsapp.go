@@ -0,0 +1,155 @@
+package imports
+
+import (
+	"archive/zip"
+	"bufio"
+	"bytes"
+	"fmt"
+	"io"
+	"path"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// whatsappLine matches the first line of a message in both export styles:
+//
+//	[31/12/23, 21:05:09] Alice: Happy new year   (iOS)
+//	31/12/23, 9:05 pm - Alice: Happy new year    (Android)
+//
+// Dates are day/month or month/day depending on the phone's locale.
+var whatsappLine = regexp.MustCompile(
+	`^\[?(\d{1,2})[./-](\d{1,2})[./-](\d{2,4}),? (\d{1,2}):(\d{2})(?::(\d{2}))? ?([AaPp]\.? ?[Mm]\.?)?(?:\] | - )(.*)$`,
+)
+
+// parseWhatsApp reads a WhatsApp "Export chat" file, or the zip it comes in
+// when media is included. Messages are keyed by the sender's name as shown
+// in the export. Lines from WhatsApp itself, such as "Alice added Bob", are
+// skipped.
+func parseWhatsApp(data []byte, opts Options) ([]Message, error) {
+	loc := time.UTC
+	if opts.TimeZone != "" {
+		var err error
+		if loc, err = time.LoadLocation(opts.TimeZone); err != nil {
+			return nil, fmt.Errorf("%w: time zone %q", ErrUnsupported, opts.TimeZone)
+		}
+	}
+
+	if zr, err := zip.NewReader(bytes.NewReader(data), int64(len(data))); err == nil {
+		if data, err = whatsappChat(zr); err != nil {
+			return nil, err
+		}
+	}
+
+	var (
+		out     []Message
+		current *Message
+	)
+	flush := func() {
+		if current != nil {
+			current.Text = strings.TrimRight(current.Text, "\n ")
+			if current.Text != "" {
+				out = append(out, *current)
+			}
+			current = nil
+		}
+	}
+
+	sc := bufio.NewScanner(bytes.NewReader(data))
+	sc.Buffer(make([]byte, 64<<10), 1<<20)
+	for n := 1; sc.Scan(); n++ {
+		line := cleanWhatsApp(sc.Text())
+
+		m := whatsappLine.FindStringSubmatch(line)
+		if m == nil {
+			// Continuation of a multi-line message
+			if current != nil {
+				current.Text += "\n" + line
+			}
+			continue
+		}
+		flush()
+
+		sentAt, err := whatsappTime(m, opts.DayFirst, loc)
+		if err != nil {
+			return nil, fmt.Errorf("%w: line %d: %v", ErrUnsupported, n, err)
+		}
+		sender, text, ok := strings.Cut(m[8], ": ")
+		if !ok {
+			// A notice from WhatsApp, not a message
+			continue
+		}
+		current = &Message{
+			ExternalID: strconv.Itoa(n),
+			Sender:     strings.TrimSpace(sender),
+			SentAt:     sentAt,
+			Text:       text,
+		}
+	}
+	if err := sc.Err(); err != nil {
+		return nil, err
+	}
+	flush()
+	return out, nil
+}
+
+// whatsappChat returns the chat text from an export zip.
+func whatsappChat(zr *zip.Reader) ([]byte, error) {
+	for _, f := range zr.File {
+		if path.Ext(f.Name) != ".txt" {
+			continue
+		}
+		rc, err := f.Open()
+		if err != nil {
+			return nil, err
+		}
+		defer rc.Close()
+		return io.ReadAll(rc)
+	}
+	return nil, fmt.Errorf("%w: no chat text in whatsapp zip", ErrUnsupported)
+}
+
+// cleanWhatsApp drops the direction marks and odd spaces iOS exports carry.
+func cleanWhatsApp(line string) string {
+	line = strings.TrimPrefix(line, "\ufeff")
+	line = strings.ReplaceAll(line, "\u200e", "")
+	line = strings.ReplaceAll(line, "\u202f", " ")
+	return strings.ReplaceAll(line, "\u00a0", " ")
+}
+
+func whatsappTime(m []string, dayFirst bool, loc *time.Location) (time.Time, error) {
+	a, _ := strconv.Atoi(m[1])
+	b, _ := strconv.Atoi(m[2])
+	year, _ := strconv.Atoi(m[3])
+	hour, _ := strconv.Atoi(m[4])
+	minute, _ := strconv.Atoi(m[5])
+	sec, _ := strconv.Atoi(m[6])
+
+	day, month := b, a
+	if dayFirst {
+		day, month = a, b
+	}
+	if year < 100 {
+		year += 2000
+	}
+
+	if ampm := strings.ToLower(strings.NewReplacer(".", "", " ", "").Replace(m[7])); ampm != "" {
+		if hour < 1 || hour > 12 {
+			return time.Time{}, fmt.Errorf("bad hour %d", hour)
+		}
+		hour %= 12
+		if ampm == "pm" {
+			hour += 12
+		}
+	}
+
+	if month < 1 || month > 12 || day < 1 || day > 31 || hour > 23 || minute > 59 || sec > 59 {
+		return time.Time{}, fmt.Errorf("bad date %s", m[0])
+	}
+	t := time.Date(year, time.Month(month), day, hour, minute, sec, 0, loc)
+	if t.Day() != day {
+		return time.Time{}, fmt.Errorf("bad date %s", m[0])
+	}
+	return t.UTC(), nil
+}
@@ -0,0 +1,54 @@
+package janitor
+
+import (
+	"context"
+	"time"
+
+	"github.com/SARVESHVARADKAR123/RealChat/services/message/internal/observability"
+	"go.uber.org/zap"
+)
+
+// Janitor deletes expired idempotency keys.
+type Janitor interface {
+	PurgeExpiredIdempotencyKeys(ctx context.Context, now time.Time, limit int) (int, error)
+	EstimateIdempotencyKeys(ctx context.Context) (int64, error)
+}
+
+// Worker purges expired idempotency keys in batches. Lookups already ignore
+// expired keys, so a lagging worker only lets the table grow.
+type Worker struct {
+	Janitor   Janitor
+	BatchSize int
+	PollDelay time.Duration
+}
+
+// Start Worker
+func (w *Worker) Start(ctx context.Context) {
+
+	log := observability.GetLogger(ctx)
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		default:
+		}
+
+		n, err := w.Janitor.PurgeExpiredIdempotencyKeys(ctx, time.Now().UTC(), w.BatchSize)
+		if err != nil {
+			log.Error("idempotency janitor error", zap.Error(err))
+			time.Sleep(time.Second)
+			continue
+		}
+		observability.IdempotencyKeysPurgedTotal.Add(float64(n))
+
+		// A full batch means there is likely more to delete right away
+		if n < w.BatchSize {
+			if size, err := w.Janitor.EstimateIdempotencyKeys(ctx); err != nil {
+				log.Error("idempotency table size error", zap.Error(err))
+			} else {
+				observability.IdempotencyKeys.Set(float64(size))
+			}
+			time.Sleep(w.PollDelay)
+		}
+	}
+}
@@ -0,0 +1,64 @@
+package moderation
+
+import (
+	"context"
+	"fmt"
+	"regexp"
+	"strings"
+	"unicode/utf8"
+)
+
+// Blocklist matches whole words and regular expressions, case-insensitively.
+// On a match it takes its configured action; redaction masks every match
+// with asterisks.
+type Blocklist struct {
+	name     string
+	action   Action
+	patterns []*regexp.Regexp
+}
+
+func NewBlocklist(name string, words, patterns []string, action Action) (*Blocklist, error) {
+	if action == Allow || !action.Valid() {
+		return nil, fmt.Errorf("blocklist %s: invalid action %q", name, action)
+	}
+
+	b := &Blocklist{name: name, action: action}
+	for _, w := range words {
+		if w = strings.TrimSpace(w); w == "" {
+			continue
+		}
+		b.patterns = append(b.patterns, regexp.MustCompile(`(?i)\b`+regexp.QuoteMeta(w)+`\b`))
+	}
+	for _, p := range patterns {
+		re, err := regexp.Compile("(?i)" + p)
+		if err != nil {
+			return nil, fmt.Errorf("blocklist %s: %w", name, err)
+		}
+		b.patterns = append(b.patterns, re)
+	}
+	if len(b.patterns) == 0 {
+		return nil, fmt.Errorf("blocklist %s: no words or patterns", name)
+	}
+	return b, nil
+}
+
+func (b *Blocklist) Name() string { return b.name }
+
+func (b *Blocklist) Check(_ context.Context, m Message) (Verdict, error) {
+	content, hits := m.Content, 0
+	for _, re := range b.patterns {
+		content = re.ReplaceAllStringFunc(content, func(match string) string {
+			hits++
+			return strings.Repeat("*", utf8.RuneCountInString(match))
+		})
+	}
+	if hits == 0 {
+		return Verdict{Action: Allow}, nil
+	}
+
+	v := Verdict{Action: b.action, Reason: "message contains a blocked term"}
+	if b.action == Redact {
+		v.Content = content
+	}
+	return v, nil
+}
@@ -0,0 +1,98 @@
+package moderation
+
+import (
+	"encoding/json"
+	"fmt"
+	"time"
+)
+
+const (
+	TypeBlocklist = "blocklist"
+	TypeSpam      = "spam"
+	TypeHook      = "hook"
+)
+
+// Config is a deployment's moderation chain, usually read from a JSON file:
+//
+//	{"filters": [
+//	  {"type": "blocklist", "words": ["badword"], "action": "redact"},
+//	  {"type": "spam", "hold_score": 3, "reject_score": 6},
+//	  {"type": "hook", "url": "http://localhost:9000/moderate", "timeout": "500ms"}
+//	]}
+type Config struct {
+	Filters []FilterConfig `json:"filters"`
+}
+
+// FilterConfig configures one filter. Which fields apply depends on Type.
+type FilterConfig struct {
+	Type string `json:"type"`
+	// Name tells filters of the same type apart in the audit trail. It
+	// defaults to Type.
+	Name string `json:"name,omitempty"`
+
+	// Blocklist
+	Words    []string `json:"words,omitempty"`
+	Patterns []string `json:"patterns,omitempty"`
+	Action   Action   `json:"action,omitempty"`
+
+	// Spam
+	HoldScore   float64 `json:"hold_score,omitempty"`
+	RejectScore float64 `json:"reject_score,omitempty"`
+
+	// Hook
+	URL     string `json:"url,omitempty"`
+	Timeout string `json:"timeout,omitempty"`
+	OnError Action `json:"on_error,omitempty"`
+}
+
+// Load builds the chain described by a JSON Config.
+func Load(data []byte) (*Chain, error) {
+	var cfg Config
+	if err := json.Unmarshal(data, &cfg); err != nil {
+		return nil, fmt.Errorf("invalid moderation config: %w", err)
+	}
+	return cfg.Build()
+}
+
+func (c Config) Build() (*Chain, error) {
+	filters := make([]Filter, 0, len(c.Filters))
+	for i, fc := range c.Filters {
+		f, err := fc.build()
+		if err != nil {
+			return nil, fmt.Errorf("moderation filter %d: %w", i, err)
+		}
+		filters = append(filters, f)
+	}
+	return NewChain(filters...), nil
+}
+
+func (fc FilterConfig) build() (Filter, error) {
+	name := fc.Name
+	if name == "" {
+		name = fc.Type
+	}
+
+	switch fc.Type {
+	case TypeBlocklist:
+		action := fc.Action
+		if action == "" {
+			action = Reject
+		}
+		return NewBlocklist(name, fc.Words, fc.Patterns, action)
+
+	case TypeSpam:
+		return NewSpamScorer(name, fc.HoldScore, fc.RejectScore)
+
+	case TypeHook:
+		var timeout time.Duration
+		if fc.Timeout != "" {
+			d, err := time.ParseDuration(fc.Timeout)
+			if err != nil {
+				return nil, fmt.Errorf("hook %s: invalid timeout: %w", name, err)
+			}
+			timeout = d
+		}
+		return NewHook(name, fc.URL, timeout, fc.OnError)
+	}
+	return nil, fmt.Errorf("unknown filter type %q", fc.Type)
+}
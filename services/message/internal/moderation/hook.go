@@ -0,0 +1,121 @@
+package moderation
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"time"
+)
+
+const defaultHookTimeout = 2 * time.Second
+
+// Hook asks an HTTP endpoint run alongside the service, such as a
+// classifier sidecar, for a verdict. It POSTs
+//
+//	{"conversation_id", "sender_user_id", "message_type", "content", "metadata_json"}
+//
+// and expects {"action", "reason", "content"} back, where content is the
+// redacted text for a redact action. When the endpoint can't be reached or
+// answers with anything else, the hook takes its OnError action instead, so
+// a sidecar outage doesn't stop every send.
+type Hook struct {
+	name    string
+	url     string
+	client  *http.Client
+	onError Action
+}
+
+func NewHook(name, url string, timeout time.Duration, onError Action) (*Hook, error) {
+	if url == "" {
+		return nil, fmt.Errorf("hook %s: missing url", name)
+	}
+	if onError == "" {
+		onError = Allow
+	}
+	if onError == Redact || !onError.Valid() {
+		return nil, fmt.Errorf("hook %s: invalid on_error action %q", name, onError)
+	}
+	if timeout <= 0 {
+		timeout = defaultHookTimeout
+	}
+	return &Hook{
+		name:    name,
+		url:     url,
+		client:  &http.Client{Timeout: timeout},
+		onError: onError,
+	}, nil
+}
+
+func (h *Hook) Name() string { return h.name }
+
+type hookRequest struct {
+	ConversationID string `json:"conversation_id"`
+	SenderUserID   string `json:"sender_user_id"`
+	MessageType    string `json:"message_type"`
+	Content        string `json:"content"`
+	MetadataJSON   string `json:"metadata_json,omitempty"`
+}
+
+type hookResponse struct {
+	Action  Action  `json:"action"`
+	Reason  string  `json:"reason"`
+	Content *string `json:"content"`
+}
+
+func (h *Hook) Check(ctx context.Context, m Message) (Verdict, error) {
+	v, err := h.call(ctx, m)
+	if err != nil {
+		return Verdict{Action: h.onError, Reason: "moderation hook failed: " + err.Error()}, nil
+	}
+	return v, nil
+}
+
+func (h *Hook) call(ctx context.Context, m Message) (Verdict, error) {
+	body, err := json.Marshal(hookRequest{
+		ConversationID: m.ConversationID,
+		SenderUserID:   m.SenderID,
+		MessageType:    m.Type,
+		Content:        m.Content,
+		MetadataJSON:   m.Metadata,
+	})
+	if err != nil {
+		return Verdict{}, err
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, h.url, bytes.NewReader(body))
+	if err != nil {
+		return Verdict{}, err
+	}
+	req.Header.Set("Content-Type", "application/json")
+
+	resp, err := h.client.Do(req)
+	if err != nil {
+		return Verdict{}, err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return Verdict{}, fmt.Errorf("status %d", resp.StatusCode)
+	}
+
+	var out hookResponse
+	if err := json.NewDecoder(io.LimitReader(resp.Body, 1<<20)).Decode(&out); err != nil {
+		return Verdict{}, fmt.Errorf("invalid response: %w", err)
+	}
+	if !out.Action.Valid() {
+		return Verdict{}, fmt.Errorf("invalid action %q", out.Action)
+	}
+
+	v := Verdict{Action: out.Action, Reason: out.Reason}
+	if out.Action == Redact {
+		if out.Content == nil {
+			return Verdict{}, errors.New("redact without content")
+		}
+		v.Content = *out.Content
+	}
+	return v, nil
+}
@@ -0,0 +1,112 @@
+// Package moderation screens outgoing messages through a chain of filters
+// configured per deployment. Each filter allows a message, rejects it,
+// redacts parts of it or holds it for review by a moderator.
+package moderation
+
+import (
+	"context"
+	"fmt"
+)
+
+type Action string
+
+const (
+	Allow  Action = "allow"
+	Redact Action = "redact"
+	Hold   Action = "hold"
+	Reject Action = "reject"
+)
+
+// Valid reports whether a names a known action.
+func (a Action) Valid() bool {
+	return a == Allow || a == Redact || a == Hold || a == Reject
+}
+
+// severity orders actions so that the strictest verdict of a chain wins.
+func (a Action) severity() int {
+	switch a {
+	case Redact:
+		return 1
+	case Hold:
+		return 2
+	case Reject:
+		return 3
+	}
+	return 0
+}
+
+// Message is what filters see of a message about to be sent.
+type Message struct {
+	ConversationID string
+	SenderID       string
+	Type           string
+	Content        string
+	Metadata       string
+}
+
+// Verdict is one filter's decision on a message.
+type Verdict struct {
+	Filter string `json:"filter"`
+	Action Action `json:"action"`
+	Reason string `json:"reason,omitempty"`
+	// Content replaces the message content when Action is Redact.
+	Content string `json:"-"`
+}
+
+type Filter interface {
+	Name() string
+	Check(ctx context.Context, m Message) (Verdict, error)
+}
+
+// Result is the outcome of running a message through a Chain.
+type Result struct {
+	// Action and Reason come from the strictest verdict.
+	Action Action
+	Reason string
+	// Content is the message content after every redaction.
+	Content  string
+	Verdicts []Verdict
+}
+
+// Chain runs filters in order. Redactions are passed on, so later filters
+// see the redacted content, and a rejection stops the chain.
+type Chain struct {
+	filters []Filter
+}
+
+func NewChain(filters ...Filter) *Chain {
+	return &Chain{filters: filters}
+}
+
+// Len returns the number of filters in c.
+func (c *Chain) Len() int {
+	return len(c.filters)
+}
+
+func (c *Chain) Screen(ctx context.Context, m Message) (Result, error) {
+	res := Result{Action: Allow, Content: m.Content}
+
+	for _, f := range c.filters {
+		m.Content = res.Content
+		v, err := f.Check(ctx, m)
+		if err != nil {
+			return Result{}, fmt.Errorf("moderation filter %s: %w", f.Name(), err)
+		}
+		if !v.Action.Valid() {
+			return Result{}, fmt.Errorf("moderation filter %s: unknown action %q", f.Name(), v.Action)
+		}
+		v.Filter = f.Name()
+		res.Verdicts = append(res.Verdicts, v)
+
+		if v.Action == Redact {
+			res.Content = v.Content
+		}
+		if v.Action.severity() > res.Action.severity() {
+			res.Action, res.Reason = v.Action, v.Reason
+		}
+		if v.Action == Reject {
+			break
+		}
+	}
+	return res, nil
+}
@@ -0,0 +1,157 @@
+package moderation
+
+import (
+	"context"
+	"fmt"
+	"net/url"
+	"regexp"
+	"strings"
+	"unicode"
+)
+
+var linkPattern = regexp.MustCompile(`(?i)\bhttps?://[^\s<>"]+|\bwww\.[^\s<>"]+`)
+
+// shorteners hide where a link goes, which spam relies on.
+var shorteners = map[string]bool{
+	"bit.ly":      true,
+	"tinyurl.com": true,
+	"t.co":        true,
+	"goo.gl":      true,
+	"ow.ly":       true,
+	"is.gd":       true,
+	"buff.ly":     true,
+	"cutt.ly":     true,
+	"rebrand.ly":  true,
+}
+
+// SpamScorer scores messages on link and spam heuristics and holds or
+// rejects those that score too high. A zero threshold is never reached.
+type SpamScorer struct {
+	name        string
+	holdScore   float64
+	rejectScore float64
+}
+
+func NewSpamScorer(name string, holdScore, rejectScore float64) (*SpamScorer, error) {
+	if holdScore < 0 || rejectScore < 0 || (holdScore == 0 && rejectScore == 0) {
+		return nil, fmt.Errorf("spam scorer %s: needs a positive hold or reject score", name)
+	}
+	return &SpamScorer{name: name, holdScore: holdScore, rejectScore: rejectScore}, nil
+}
+
+func (s *SpamScorer) Name() string { return s.name }
+
+func (s *SpamScorer) Check(_ context.Context, m Message) (Verdict, error) {
+	score, signals := SpamScore(m.Content)
+	reason := fmt.Sprintf("spam score %.1f (%s)", score, strings.Join(signals, ", "))
+
+	switch {
+	case s.rejectScore > 0 && score >= s.rejectScore:
+		return Verdict{Action: Reject, Reason: reason}, nil
+	case s.holdScore > 0 && score >= s.holdScore:
+		return Verdict{Action: Hold, Reason: reason}, nil
+	}
+	return Verdict{Action: Allow}, nil
+}
+
+// SpamScore rates how spammy content looks and names the signals that
+// contributed. A single link in an otherwise ordinary message scores zero.
+func SpamScore(content string) (float64, []string) {
+	var (
+		score   float64
+		signals []string
+	)
+
+	links := linkPattern.FindAllString(content, -1)
+	if len(links) > 1 {
+		score += float64(len(links) - 1)
+		signals = append(signals, fmt.Sprintf("%d links", len(links)))
+	}
+	shortened := 0
+	for _, l := range links {
+		if shorteners[linkHost(l)] {
+			shortened++
+		}
+	}
+	if shortened > 0 {
+		score += 2 * float64(shortened)
+		signals = append(signals, "shortened links")
+	}
+
+	if shouting(content) {
+		score++
+		signals = append(signals, "all caps")
+	}
+	if longRun(content, 8) {
+		score++
+		signals = append(signals, "repeated characters")
+	}
+	if repetitive(content) {
+		score++
+		signals = append(signals, "repeated words")
+	}
+	return score, signals
+}
+
+func linkHost(link string) string {
+	if !strings.Contains(link, "://") {
+		link = "http://" + link
+	}
+	u, err := url.Parse(link)
+	if err != nil {
+		return ""
+	}
+	return strings.TrimPrefix(strings.ToLower(u.Hostname()), "www.")
+}
+
+// shouting reports whether most of a reasonably long message's letters are
+// upper case.
+func shouting(content string) bool {
+	letters, upper := 0, 0
+	for _, r := range content {
+		if unicode.IsLetter(r) {
+			letters++
+			if unicode.IsUpper(r) {
+				upper++
+			}
+		}
+	}
+	return letters >= 12 && float64(upper) > 0.7*float64(letters)
+}
+
+// longRun reports whether content repeats a non-space character n or more
+// times in a row.
+func longRun(content string, n int) bool {
+	var prev rune
+	run := 0
+	for _, r := range content {
+		if r == prev && !unicode.IsSpace(r) {
+			run++
+			if run >= n {
+				return true
+			}
+		} else {
+			prev, run = r, 1
+		}
+	}
+	return false
+}
+
+// repetitive reports whether one word makes up a third or more of a message
+// of at least six words.
+func repetitive(content string) bool {
+	words := strings.FieldsFunc(strings.ToLower(content), func(r rune) bool {
+		return !unicode.IsLetter(r) && !unicode.IsNumber(r)
+	})
+	if len(words) < 6 {
+		return false
+	}
+	counts := map[string]int{}
+	for _, w := range words {
+		counts[w]++
+		if counts[w] >= 5 && 3*counts[w] >= len(words) {
+			return true
+		}
+	}
+	return false
+}
@@ -31,6 +31,89 @@ var (
 		[]string{"service", "topic"},
 	)
 
+	ScheduledMessagesFiredTotal = promauto.NewCounterVec(
+		prometheus.CounterOpts{
+			Name: "scheduled_messages_fired_total",
+			Help: "Total number of scheduled messages processed by the scheduler, by result",
+		},
+		[]string{"result"},
+	)
+
+	ExportsTotal = promauto.NewCounterVec(
+		prometheus.CounterOpts{
+			Name: "conversation_exports_total",
+			Help: "Total number of conversation exports built, by result",
+		},
+		[]string{"result"},
+	)
+
+	ImportsTotal = promauto.NewCounterVec(
+		prometheus.CounterOpts{
+			Name: "message_imports_total",
+			Help: "Total number of history imports run, by result",
+		},
+		[]string{"result"},
+	)
+
+	ImportedMessagesTotal = promauto.NewCounter(
+		prometheus.CounterOpts{
+			Name: "imported_messages_total",
+			Help: "Total number of messages written by history imports",
+		},
+	)
+
+	ExpiredMessagesDeletedTotal = promauto.NewCounter(
+		prometheus.CounterOpts{
+			Name: "expired_messages_deleted_total",
+			Help: "Total number of messages hard-deleted after their TTL passed",
+		},
+	)
+
+	IdempotencyKeysPurgedTotal = promauto.NewCounter(
+		prometheus.CounterOpts{
+			Name: "idempotency_keys_purged_total",
+			Help: "Total number of expired idempotency keys deleted by the janitor",
+		},
+	)
+
+	IdempotencyKeys = promauto.NewGauge(
+		prometheus.GaugeOpts{
+			Name: "idempotency_keys",
+			Help: "Approximate number of rows in the idempotency_keys table, from planner statistics",
+		},
+	)
+
+	ArchivedMessagesTotal = promauto.NewCounter(
+		prometheus.CounterOpts{
+			Name: "archived_messages_total",
+			Help: "Total number of messages moved to the archive store after their retention passed",
+		},
+	)
+
+	SystemMessagesTotal = promauto.NewCounterVec(
+		prometheus.CounterOpts{
+			Name: "system_messages_total",
+			Help: "Total number of conversation events handled by the system message consumer, by result",
+		},
+		[]string{"result"},
+	)
+
+	ProjectionEventsTotal = promauto.NewCounterVec(
+		prometheus.CounterOpts{
+			Name: "conversation_projection_events_total",
+			Help: "Total number of conversation events handled by the conversation projection consumer, by result",
+		},
+		[]string{"result"},
+	)
+
+	ModerationDecisionsTotal = promauto.NewCounterVec(
+		prometheus.CounterOpts{
+			Name: "moderation_decisions_total",
+			Help: "Total number of sends screened by the moderation chain, by resulting action",
+		},
+		[]string{"action"},
+	)
+
 	DbQueryDuration = promauto.NewHistogramVec(
 		prometheus.HistogramOpts{
 			Name:    "db_query_duration_seconds",
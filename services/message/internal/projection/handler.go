@@ -0,0 +1,93 @@
+package projection
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	conversationv1 "github.com/SARVESHVARADKAR123/RealChat/contracts/gen/go/conversation/v1"
+	sharedv1 "github.com/SARVESHVARADKAR123/RealChat/contracts/gen/go/shared/v1"
+	"github.com/SARVESHVARADKAR123/RealChat/services/message/internal/domain"
+	"github.com/SARVESHVARADKAR123/RealChat/services/message/internal/observability"
+	"go.uber.org/zap"
+	"google.golang.org/protobuf/proto"
+)
+
+// Applier records conversation changes in the local projection.
+type Applier interface {
+	ApplyConversation(ctx context.Context, conv *conversationv1.Conversation) error
+	ApplyMembership(ctx context.Context, conversationID, userID string, added bool, at time.Time) error
+}
+
+// Handler keeps the message service's copy of conversations and their
+// members up to date, so sends and syncs can check membership without
+// calling the conversation service.
+type Handler struct {
+	Applier Applier
+}
+
+// Handle applies one conversation event. Events arrive in order per
+// conversation, so applying each as it comes leaves the projection matching
+// the conversation service once the consumer has caught up. Malformed events
+// are dropped; other failures are returned for the consumer to retry.
+func (h *Handler) Handle(ctx context.Context, record []byte) error {
+	log := observability.GetLogger(ctx)
+
+	var env sharedv1.EventEnvelope
+	if err := proto.Unmarshal(record, &env); err != nil {
+		log.Error("projection: error unmarshaling event", zap.Error(err))
+		observability.ProjectionEventsTotal.WithLabelValues("dropped").Inc()
+		return nil
+	}
+
+	err := h.apply(ctx, &env)
+	switch {
+	case err == nil:
+		observability.ProjectionEventsTotal.WithLabelValues("applied").Inc()
+		return nil
+	case errors.Is(err, domain.ErrInvalidInput), errors.As(err, new(unmarshalError)):
+		log.Warn("projection: dropping event", zap.String("event_type", env.GetEventType().String()), zap.Error(err))
+		observability.ProjectionEventsTotal.WithLabelValues("dropped").Inc()
+		return nil
+	default:
+		observability.ProjectionEventsTotal.WithLabelValues("retry").Inc()
+		return err
+	}
+}
+
+func (h *Handler) apply(ctx context.Context, env *sharedv1.EventEnvelope) error {
+	switch env.GetEventType() {
+	case sharedv1.EventType_EVENT_TYPE_CONVERSATION_CREATED:
+		var event conversationv1.ConversationCreatedEvent
+		if err := proto.Unmarshal(env.GetPayload(), &event); err != nil {
+			return unmarshalError{err}
+		}
+		return h.Applier.ApplyConversation(ctx, event.GetConversation())
+
+	case sharedv1.EventType_EVENT_TYPE_CONVERSATION_UPDATED:
+		var event conversationv1.ConversationUpdatedEvent
+		if err := proto.Unmarshal(env.GetPayload(), &event); err != nil {
+			return unmarshalError{err}
+		}
+		return h.Applier.ApplyConversation(ctx, event.GetConversation())
+
+	case sharedv1.EventType_EVENT_TYPE_MEMBERSHIP_CHANGED:
+		var event conversationv1.MembershipChangedEvent
+		if err := proto.Unmarshal(env.GetPayload(), &event); err != nil {
+			return unmarshalError{err}
+		}
+		// The event time bounds what history the member can export
+		at := time.Now().UTC()
+		if env.GetOccurredAt() != nil {
+			at = env.GetOccurredAt().AsTime()
+		}
+		return h.Applier.ApplyMembership(ctx, event.GetConversationId(), event.GetUserId(), event.GetAdded(), at)
+	}
+
+	return nil
+}
+
+type unmarshalError struct{ err error }
+
+func (e unmarshalError) Error() string { return "unmarshal payload: " + e.err.Error() }
+func (e unmarshalError) Unwrap() error { return e.err }
@@ -0,0 +1,49 @@
+package reaper
+
+import (
+	"context"
+	"time"
+
+	"github.com/SARVESHVARADKAR123/RealChat/services/message/internal/observability"
+	"go.uber.org/zap"
+)
+
+// Reaper deletes messages whose TTL has passed.
+type Reaper interface {
+	ReapExpiredMessages(ctx context.Context, now time.Time, limit int) (int, error)
+}
+
+// Worker hard-deletes expired messages in batches. Reads already hide
+// expired messages, so a lagging worker only delays the cleanup and the
+// deletion events.
+type Worker struct {
+	Reaper    Reaper
+	BatchSize int
+	PollDelay time.Duration
+}
+
+// Start Worker
+func (w *Worker) Start(ctx context.Context) {
+
+	log := observability.GetLogger(ctx)
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		default:
+		}
+
+		n, err := w.Reaper.ReapExpiredMessages(ctx, time.Now().UTC(), w.BatchSize)
+		if err != nil {
+			log.Error("reaper error", zap.Error(err))
+			time.Sleep(time.Second)
+			continue
+		}
+		observability.ExpiredMessagesDeletedTotal.Add(float64(n))
+
+		// A full batch means there is likely more to delete right away
+		if n < w.BatchSize {
+			time.Sleep(w.PollDelay)
+		}
+	}
+}
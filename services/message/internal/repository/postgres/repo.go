@@ -3,10 +3,15 @@ package postgres
 import (
 	"context"
 	"database/sql"
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strings"
 	"time"
 
 	"github.com/SARVESHVARADKAR123/RealChat/services/message/internal/cache"
 	"github.com/SARVESHVARADKAR123/RealChat/services/message/internal/domain"
+	"github.com/lib/pq"
 )
 
 type Repository struct {
@@ -27,25 +32,153 @@ func (r *Repository) getter(tx *sql.Tx) queryable {
 	return r.DB
 }
 
+// messageColumns is the column list understood by scanMessage.
+const messageColumns = `id, conversation_id, sender_id, sequence,
+		       type, content, metadata, sent_at, deleted_at, edited_at,
+		       reply_to_id, thread_root_id, reply_count, last_reply_at, expires_at,
+		       forwarded_from_message_id, forwarded_from_conversation_id,
+		       forwarded_from_sender_id, forwarded_from_sent_at,
+		       sender_device_id`
+
+// notExpired filters out messages past their TTL that the reaper has not
+// deleted yet.
+const notExpired = `(expires_at IS NULL OR expires_at > now())`
+
+// escapedContent is content with HTML special characters escaped, so the
+// only markup in a search snippet is the <mark> tags ts_headline adds.
+const escapedContent = `replace(replace(replace(replace(replace(content,
+		'&', '&amp;'), '<', '&lt;'), '>', '&gt;'), '"', '&quot;'), '''', '&#39;')`
+
+type rowScanner interface {
+	Scan(dest ...interface{}) error
+}
+
+func scanMessage(row rowScanner) (*domain.Message, error) {
+	var msg domain.Message
+	var metadata sql.NullString
+	var deletedAt, editedAt, lastReplyAt, expiresAt sql.NullTime
+	var replyToID, threadRootID sql.NullString
+	var fwdMessageID, fwdConversationID, fwdSenderID sql.NullString
+	var fwdSentAt sql.NullTime
+	var senderDeviceID sql.NullString
+
+	if err := row.Scan(
+		&msg.ID,
+		&msg.ConversationID,
+		&msg.SenderID,
+		&msg.Sequence,
+		&msg.Type,
+		&msg.Content,
+		&metadata,
+		&msg.SentAt,
+		&deletedAt,
+		&editedAt,
+		&replyToID,
+		&threadRootID,
+		&msg.ReplyCount,
+		&lastReplyAt,
+		&expiresAt,
+		&fwdMessageID,
+		&fwdConversationID,
+		&fwdSenderID,
+		&fwdSentAt,
+		&senderDeviceID,
+	); err != nil {
+		return nil, err
+	}
+
+	msg.Metadata = metadata.String
+	msg.SenderDeviceID = senderDeviceID.String
+	if deletedAt.Valid {
+		msg.DeletedAt = &deletedAt.Time
+	}
+	if editedAt.Valid {
+		msg.EditedAt = &editedAt.Time
+	}
+	msg.ReplyToID = replyToID.String
+	msg.ThreadRootID = threadRootID.String
+	if lastReplyAt.Valid {
+		msg.LastReplyAt = &lastReplyAt.Time
+	}
+	if expiresAt.Valid {
+		msg.ExpiresAt = &expiresAt.Time
+	}
+	if fwdMessageID.Valid {
+		msg.ForwardedFrom = &domain.ForwardedFrom{
+			MessageID:      fwdMessageID.String,
+			ConversationID: fwdConversationID.String,
+			SenderID:       fwdSenderID.String,
+			SentAt:         fwdSentAt.Time,
+		}
+	}
+	return &msg, nil
+}
+
+// nullIfEmpty stores empty strings as SQL NULL.
+func nullIfEmpty(v string) interface{} {
+	if v == "" {
+		return nil
+	}
+	return v
+}
+
+// nullIfZero stores zero times as SQL NULL.
+func nullIfZero(t time.Time) interface{} {
+	if t.IsZero() {
+		return nil
+	}
+	return t
+}
+
+// scanWithExtra lets scanMessage read rows that select additional columns
+// after messageColumns.
+type scanWithExtra struct {
+	row   rowScanner
+	extra []interface{}
+}
+
+func (s scanWithExtra) Scan(dest ...interface{}) error {
+	return s.row.Scan(append(dest, s.extra...)...)
+}
+
+func scanMessages(rows *sql.Rows) ([]*domain.Message, error) {
+	defer rows.Close()
+
+	var messages []*domain.Message
+	for rows.Next() {
+		msg, err := scanMessage(rows)
+		if err != nil {
+			return nil, err
+		}
+		messages = append(messages, msg)
+	}
+	return messages, rows.Err()
+}
+
+// InsertMessage stores a new message. The messages_claim_sequence trigger
+// claims msg.Sequence in message_sequences, so a sequence already taken in
+// the conversation fails the insert.
 func (r *Repository) InsertMessage(
 	ctx context.Context,
 	tx *sql.Tx,
 	msg *domain.Message,
 ) error {
-	var metadata interface{}
-	if msg.Metadata == "" {
-		metadata = nil
-	} else {
-		metadata = msg.Metadata
+	var fwd domain.ForwardedFrom
+	if msg.ForwardedFrom != nil {
+		fwd = *msg.ForwardedFrom
 	}
 
 	q := r.getter(tx)
 	_, err := q.ExecContext(ctx, `
 		INSERT INTO messages (
 			id, conversation_id, sender_id,
-			sequence, type, content, metadata, sent_at
+			sequence, type, content, metadata, sent_at,
+			reply_to_id, thread_root_id, expires_at,
+			forwarded_from_message_id, forwarded_from_conversation_id,
+			forwarded_from_sender_id, forwarded_from_sent_at,
+			sender_device_id
 		)
-		VALUES ($1,$2,$3,$4,$5,$6,$7,$8)
+		VALUES ($1,$2,$3,$4,$5,$6,$7,$8,$9,$10,$11,$12,$13,$14,$15,$16)
 	`,
 		msg.ID,
 		msg.ConversationID,
@@ -53,8 +186,16 @@ func (r *Repository) InsertMessage(
 		msg.Sequence,
 		msg.Type,
 		msg.Content,
-		metadata,
+		nullIfEmpty(msg.Metadata),
 		msg.SentAt,
+		nullIfEmpty(msg.ReplyToID),
+		nullIfEmpty(msg.ThreadRootID),
+		msg.ExpiresAt,
+		nullIfEmpty(fwd.MessageID),
+		nullIfEmpty(fwd.ConversationID),
+		nullIfEmpty(fwd.SenderID),
+		nullIfZero(fwd.SentAt),
+		nullIfEmpty(msg.SenderDeviceID),
 	)
 
 	return err
@@ -68,11 +209,11 @@ func (r *Repository) FetchMessages(
 ) ([]*domain.Message, error) {
 
 	rows, err := r.DB.QueryContext(ctx, `
-		SELECT id, conversation_id, sender_id, sequence,
-		       type, content, metadata, sent_at, deleted_at
+		SELECT `+messageColumns+`
 		FROM messages
 		WHERE conversation_id = $1
 		  AND sequence > $2
+		  AND `+notExpired+`
 		ORDER BY sequence ASC
 		LIMIT $3
 	`, convID, lastSeq, limit)
@@ -80,38 +221,90 @@ func (r *Repository) FetchMessages(
 	if err != nil {
 		return nil, err
 	}
-	defer rows.Close()
 
-	var messages []*domain.Message
+	return scanMessages(rows)
+}
 
-	for rows.Next() {
-		var msg domain.Message
-		var metadata sql.NullString
-		var deletedAt sql.NullTime
-		if err := rows.Scan(
-			&msg.ID,
-			&msg.ConversationID,
-			&msg.SenderID,
-			&msg.Sequence,
-			&msg.Type,
-			&msg.Content,
-			&metadata,
-			&msg.SentAt,
-			&deletedAt,
-		); err != nil {
-			return nil, err
-		}
-		msg.Metadata = metadata.String
-		if deletedAt.Valid {
-			msg.DeletedAt = &deletedAt.Time
-		}
-		messages = append(messages, &msg)
+// FetchMessagesBefore returns up to limit messages with sequence < beforeSeq,
+// the newest ones, in ascending order.
+func (r *Repository) FetchMessagesBefore(
+	ctx context.Context,
+	convID string,
+	beforeSeq int64,
+	limit int,
+) ([]*domain.Message, error) {
+
+	rows, err := r.DB.QueryContext(ctx, `
+		SELECT `+messageColumns+`
+		FROM messages
+		WHERE conversation_id = $1
+		  AND sequence < $2
+		  AND `+notExpired+`
+		ORDER BY sequence DESC
+		LIMIT $3
+	`, convID, beforeSeq, limit)
+
+	if err != nil {
+		return nil, err
+	}
+
+	messages, err := scanMessages(rows)
+	if err != nil {
+		return nil, err
 	}
 
+	for i, j := 0, len(messages)-1; i < j; i, j = i+1, j-1 {
+		messages[i], messages[j] = messages[j], messages[i]
+	}
 	return messages, nil
 }
 
-func (r *Repository) GetMessageForUpdate(
+// HasMessagesOutside reports whether the conversation has messages with
+// sequence < minSeq and with sequence > maxSeq.
+func (r *Repository) HasMessagesOutside(
+	ctx context.Context,
+	convID string,
+	minSeq, maxSeq int64,
+) (bool, bool, error) {
+
+	var before, after bool
+	err := r.DB.QueryRowContext(ctx, `
+		SELECT
+			EXISTS (SELECT 1 FROM messages WHERE conversation_id = $1 AND sequence < $2 AND `+notExpired+`),
+			EXISTS (SELECT 1 FROM messages WHERE conversation_id = $1 AND sequence > $3 AND `+notExpired+`)
+	`, convID, minSeq, maxSeq).Scan(&before, &after)
+
+	return before, after, err
+}
+
+// FetchThreadReplies returns the replies of a thread after lastSeq, oldest first.
+func (r *Repository) FetchThreadReplies(
+	ctx context.Context,
+	convID string,
+	rootID string,
+	lastSeq int64,
+	limit int,
+) ([]*domain.Message, error) {
+
+	rows, err := r.DB.QueryContext(ctx, `
+		SELECT `+messageColumns+`
+		FROM messages
+		WHERE thread_root_id = $1
+		  AND conversation_id = $2
+		  AND sequence > $3
+		  AND `+notExpired+`
+		ORDER BY sequence ASC
+		LIMIT $4
+	`, rootID, convID, lastSeq, limit)
+
+	if err != nil {
+		return nil, err
+	}
+
+	return scanMessages(rows)
+}
+
+func (r *Repository) GetMessage(
 	ctx context.Context,
 	tx *sql.Tx,
 	messageID string,
@@ -119,41 +312,89 @@ func (r *Repository) GetMessageForUpdate(
 
 	q := r.getter(tx)
 	row := q.QueryRowContext(ctx, `
-		SELECT id, conversation_id, sender_id, sequence,
-		       type, content, metadata, sent_at, deleted_at
+		SELECT `+messageColumns+`
 		FROM messages
 		WHERE id = $1
-		FOR UPDATE
+		  AND `+notExpired+`
 	`, messageID)
 
-	var msg domain.Message
-	var metadata sql.NullString
-	var deletedAt sql.NullTime
+	msg, err := scanMessage(row)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, domain.ErrMessageNotFound
+		}
+		return nil, err
+	}
 
-	err := row.Scan(
-		&msg.ID,
-		&msg.ConversationID,
-		&msg.SenderID,
-		&msg.Sequence,
-		&msg.Type,
-		&msg.Content,
-		&metadata,
-		&msg.SentAt,
-		&deletedAt,
-	)
+	return msg, nil
+}
+
+// RecordThreadReply bumps the reply summary kept on the thread root.
+func (r *Repository) RecordThreadReply(
+	ctx context.Context,
+	tx *sql.Tx,
+	rootID string,
+	repliedAt time.Time,
+) error {
+	q := r.getter(tx)
+	_, err := q.ExecContext(ctx, `
+		UPDATE messages
+		SET reply_count = reply_count + 1,
+		    last_reply_at = GREATEST(COALESCE(last_reply_at, $2), $2)
+		WHERE id = $1
+	`, rootID, repliedAt)
+	return err
+}
+
+// RefreshThreadSummaries recounts the replies of each thread root after
+// replies were deleted. Deleted replies don't count, and last_reply_at falls
+// back to the latest reply left.
+func (r *Repository) RefreshThreadSummaries(
+	ctx context.Context,
+	tx *sql.Tx,
+	rootIDs []string,
+) error {
+	q := r.getter(tx)
+	_, err := q.ExecContext(ctx, `
+		UPDATE messages m
+		SET reply_count = s.reply_count,
+		    last_reply_at = s.last_reply_at
+		FROM (
+			SELECT root.id, COUNT(reply.id) AS reply_count, MAX(reply.sent_at) AS last_reply_at
+			FROM unnest($1::TEXT[]) AS root (id)
+			LEFT JOIN messages reply
+			  ON reply.thread_root_id = root.id AND reply.deleted_at IS NULL
+			GROUP BY root.id
+		) s
+		WHERE m.id = s.id
+	`, pq.Array(rootIDs))
+	return err
+}
+
+func (r *Repository) GetMessageForUpdate(
+	ctx context.Context,
+	tx *sql.Tx,
+	messageID string,
+) (*domain.Message, error) {
+
+	q := r.getter(tx)
+	row := q.QueryRowContext(ctx, `
+		SELECT `+messageColumns+`
+		FROM messages
+		WHERE id = $1
+		  AND `+notExpired+`
+		FOR UPDATE
+	`, messageID)
 
+	msg, err := scanMessage(row)
 	if err != nil {
 		if err == sql.ErrNoRows {
 			return nil, domain.ErrMessageNotFound
 		}
 		return nil, err
 	}
-	msg.Metadata = metadata.String
-	if deletedAt.Valid {
-		msg.DeletedAt = &deletedAt.Time
-	}
 
-	return &msg, nil
+	return msg, nil
 }
 
 func (r *Repository) MarkMessageDeleted(
@@ -170,75 +411,2347 @@ func (r *Repository) MarkMessageDeleted(
 	return err
 }
 
-func (r *Repository) TryInsertIdempotency(
+// UpdateMessageContent persists an edit made through domain.Message.Edit.
+func (r *Repository) UpdateMessageContent(
 	ctx context.Context,
 	tx *sql.Tx,
-	key, userID, conversationID string,
-	expiresAt time.Time,
+	msg *domain.Message,
+) error {
+	q := r.getter(tx)
+	_, err := q.ExecContext(ctx, `
+		UPDATE messages
+		SET content = $2, metadata = $3, edited_at = $4
+		WHERE id = $1
+	`, msg.ID, msg.Content, nullIfEmpty(msg.Metadata), msg.EditedAt)
+	return err
+}
+
+// InsertMessageRevision stores content that is about to be replaced by an
+// edit, numbering revisions per message starting at 1.
+func (r *Repository) InsertMessageRevision(
+	ctx context.Context,
+	tx *sql.Tx,
+	messageID, content, metadata string,
+) error {
+	q := r.getter(tx)
+	_, err := q.ExecContext(ctx, `
+		INSERT INTO message_revisions (message_id, revision, content, metadata)
+		SELECT $1, COALESCE(MAX(revision), 0) + 1, $2, $3
+		FROM message_revisions
+		WHERE message_id = $1
+	`, messageID, content, nullIfEmpty(metadata))
+	return err
+}
+
+// SearchMessages runs q against the full-text index. Deleted and encrypted
+// messages are never returned. Each hit's snippet is HTML: the content is
+// escaped before the matches are wrapped in <mark></mark>.
+func (r *Repository) SearchMessages(
+	ctx context.Context,
+	q domain.SearchQuery,
+) ([]*domain.SearchHit, error) {
+
+	rows, err := r.DB.QueryContext(ctx, `
+		SELECT `+messageColumns+`,
+		       ts_headline('simple', `+escapedContent+`, query,
+		                   'StartSel=<mark>, StopSel=</mark>, MaxFragments=2, MaxWords=20, MinWords=5')
+		FROM messages, websearch_to_tsquery('simple', $1) query
+		WHERE search_tsv @@ query
+		  AND deleted_at IS NULL
+		  AND type <> '`+domain.TypeEncrypted+`'
+		  AND `+notExpired+`
+		  AND conversation_id = ANY($2)
+		  AND ($3 = '' OR sender_id = $3)
+		  AND ($4 = '' OR type = $4)
+		  AND ($5::timestamptz IS NULL OR sent_at >= $5)
+		  AND ($6::timestamptz IS NULL OR sent_at < $6)
+		  AND ($7::timestamptz IS NULL OR (sent_at, id) < ($7, $8))
+		ORDER BY sent_at DESC, id DESC
+		LIMIT $9
+	`,
+		q.Text,
+		pq.Array(q.ConversationIDs),
+		q.SenderID,
+		q.Type,
+		nullIfZero(q.SentAfter),
+		nullIfZero(q.SentBefore),
+		nullIfZero(q.AfterSentAt),
+		q.AfterID,
+		q.Limit,
+	)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var hits []*domain.SearchHit
+	for rows.Next() {
+		var hit domain.SearchHit
+		msg, err := scanMessage(scanWithExtra{rows, []interface{}{&hit.Snippet}})
+		if err != nil {
+			return nil, err
+		}
+		hit.Message = msg
+		hits = append(hits, &hit)
+	}
+	return hits, rows.Err()
+}
+
+// AddReaction reports whether the reaction was newly added.
+func (r *Repository) AddReaction(
+	ctx context.Context,
+	tx *sql.Tx,
+	messageID, userID, emoji string,
 ) (bool, error) {
 	q := r.getter(tx)
-	result, err := q.ExecContext(ctx, `
-		INSERT INTO idempotency_keys (key, user_id, conversation_id, expires_at)
-		VALUES ($1, $2, $3, $4)
-		ON CONFLICT (key, user_id, conversation_id) DO NOTHING
-	`, key, userID, conversationID, expiresAt)
+	res, err := q.ExecContext(ctx, `
+		INSERT INTO message_reactions (message_id, user_id, emoji)
+		VALUES ($1, $2, $3)
+		ON CONFLICT DO NOTHING
+	`, messageID, userID, emoji)
 	if err != nil {
 		return false, err
 	}
-	n, err := result.RowsAffected()
+	n, err := res.RowsAffected()
+	return n > 0, err
+}
+
+// RemoveReaction reports whether a reaction was actually removed.
+func (r *Repository) RemoveReaction(
+	ctx context.Context,
+	tx *sql.Tx,
+	messageID, userID, emoji string,
+) (bool, error) {
+	q := r.getter(tx)
+	res, err := q.ExecContext(ctx, `
+		DELETE FROM message_reactions
+		WHERE message_id = $1 AND user_id = $2 AND emoji = $3
+	`, messageID, userID, emoji)
 	if err != nil {
 		return false, err
 	}
-	return n == 1, nil
+	n, err := res.RowsAffected()
+	return n > 0, err
 }
 
-func (r *Repository) GetIdempotencyForUpdate(
+// ListReactionSummaries aggregates reactions per message and emoji, ordered by
+// first use. ReactedByMe is set for viewerID; pass "" to leave it unset.
+func (r *Repository) ListReactionSummaries(
 	ctx context.Context,
 	tx *sql.Tx,
-	key, userID, conversationID string,
-) ([]byte, error) {
+	messageIDs []string,
+	viewerID string,
+) (map[string][]domain.ReactionSummary, error) {
+
+	out := make(map[string][]domain.ReactionSummary)
+	if len(messageIDs) == 0 {
+		return out, nil
+	}
+
 	q := r.getter(tx)
-	var payload []byte
-	err := q.QueryRowContext(ctx, `
-        SELECT payload
-        FROM idempotency_keys
-        WHERE key = $1 AND user_id = $2 AND conversation_id = $3
-        FOR UPDATE
-    `, key, userID, conversationID).Scan(&payload)
+	rows, err := q.QueryContext(ctx, `
+		SELECT message_id, emoji, COUNT(*), BOOL_OR(user_id = $2)
+		FROM message_reactions
+		WHERE message_id = ANY($1)
+		GROUP BY message_id, emoji
+		ORDER BY message_id, MIN(created_at), emoji
+	`, pq.Array(messageIDs), viewerID)
 	if err != nil {
-		if err == sql.ErrNoRows {
-			return nil, nil
-		}
 		return nil, err
 	}
-	return payload, nil
+	defer rows.Close()
+
+	for rows.Next() {
+		var messageID string
+		var rs domain.ReactionSummary
+		if err := rows.Scan(&messageID, &rs.Emoji, &rs.Count, &rs.ReactedByMe); err != nil {
+			return nil, err
+		}
+		out[messageID] = append(out[messageID], rs)
+	}
+	return out, rows.Err()
 }
 
-func (r *Repository) UpdateIdempotencyResponse(
+// PinMessage pins messageID. It returns false if the message was already pinned.
+func (r *Repository) PinMessage(
 	ctx context.Context,
 	tx *sql.Tx,
-	key, userID, conversationID string,
-	payload []byte,
-) error {
+	conversationID, messageID, userID string,
+	pinnedAt time.Time,
+) (bool, error) {
 	q := r.getter(tx)
-	_, err := q.ExecContext(ctx, `
-        UPDATE idempotency_keys
-        SET payload = $4
-        WHERE key = $1 AND user_id = $2 AND conversation_id = $3
-    `, key, userID, conversationID, payload)
-	return err
+	result, err := q.ExecContext(ctx, `
+		INSERT INTO message_pins (message_id, conversation_id, pinned_by, pinned_at)
+		VALUES ($1, $2, $3, $4)
+		ON CONFLICT (message_id) DO NOTHING
+	`, messageID, conversationID, userID, pinnedAt)
+	if err != nil {
+		return false, err
+	}
+	n, err := result.RowsAffected()
+	return n > 0, err
 }
 
-func (r *Repository) InsertOutbox(
+// UnpinMessage removes the pin on messageID. It returns false if there was none.
+func (r *Repository) UnpinMessage(
 	ctx context.Context,
 	tx *sql.Tx,
-	aggregateType, aggregateID, eventType string,
-	payload []byte,
-) error {
+	conversationID, messageID string,
+) (bool, error) {
 	q := r.getter(tx)
-	_, err := q.ExecContext(ctx, `
-        INSERT INTO outbox_events (aggregate_type, aggregate_id, event_type, payload)
-        VALUES ($1, $2, $3, $4)
-    `, aggregateType, aggregateID, eventType, payload)
+	result, err := q.ExecContext(ctx, `
+		DELETE FROM message_pins
+		WHERE message_id = $1 AND conversation_id = $2
+	`, messageID, conversationID)
+	if err != nil {
+		return false, err
+	}
+	n, err := result.RowsAffected()
+	return n > 0, err
+}
+
+func (r *Repository) CountPins(ctx context.Context, tx *sql.Tx, conversationID string) (int, error) {
+	var n int
+	err := r.getter(tx).QueryRowContext(ctx, `
+		SELECT COUNT(*) FROM message_pins WHERE conversation_id = $1
+	`, conversationID).Scan(&n)
+	return n, err
+}
+
+// ListPins returns the pins of a conversation with their messages, newest first.
+func (r *Repository) ListPins(ctx context.Context, tx *sql.Tx, conversationID string) ([]*domain.Pin, error) {
+	q := r.getter(tx)
+	rows, err := q.QueryContext(ctx, `
+		SELECT `+messageColumns+`, p.pinned_by, p.pinned_at
+		FROM messages
+		JOIN (
+			SELECT message_id, pinned_by, pinned_at
+			FROM message_pins
+			WHERE conversation_id = $1
+		) p ON p.message_id = messages.id
+		WHERE `+notExpired+`
+		ORDER BY p.pinned_at DESC, messages.id
+	`, conversationID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var pins []*domain.Pin
+	for rows.Next() {
+		var pin domain.Pin
+		msg, err := scanMessage(scanWithExtra{rows, []interface{}{&pin.PinnedBy, &pin.PinnedAt}})
+		if err != nil {
+			return nil, err
+		}
+		pin.Message = msg
+		pins = append(pins, &pin)
+	}
+	return pins, rows.Err()
+}
+
+// InsertMessageAttachments stores atts on messageID in the given order.
+func (r *Repository) InsertMessageAttachments(
+	ctx context.Context,
+	tx *sql.Tx,
+	messageID string,
+	atts []domain.Attachment,
+) error {
+	q := r.getter(tx)
+	for i, a := range atts {
+		if _, err := q.ExecContext(ctx, `
+			INSERT INTO message_attachments
+				(message_id, attachment_id, position, file_name, mime_type, size_bytes)
+			VALUES ($1, $2, $3, $4, $5, $6)
+		`, messageID, a.ID, i, a.FileName, a.MimeType, a.SizeBytes); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// ListMessageAttachments returns the attachments of each message in upload order.
+func (r *Repository) ListMessageAttachments(
+	ctx context.Context,
+	tx *sql.Tx,
+	messageIDs []string,
+) (map[string][]domain.Attachment, error) {
+
+	out := make(map[string][]domain.Attachment)
+	if len(messageIDs) == 0 {
+		return out, nil
+	}
+
+	q := r.getter(tx)
+	rows, err := q.QueryContext(ctx, `
+		SELECT message_id, attachment_id, file_name, mime_type, size_bytes
+		FROM message_attachments
+		WHERE message_id = ANY($1)
+		ORDER BY message_id, position
+	`, pq.Array(messageIDs))
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var messageID string
+		var a domain.Attachment
+		if err := rows.Scan(&messageID, &a.ID, &a.FileName, &a.MimeType, &a.SizeBytes); err != nil {
+			return nil, err
+		}
+		out[messageID] = append(out[messageID], a)
+	}
+	return out, rows.Err()
+}
+
+// InsertMessageCiphertexts stores the per-device ciphertexts of an encrypted
+// message.
+func (r *Repository) InsertMessageCiphertexts(
+	ctx context.Context,
+	tx *sql.Tx,
+	messageID string,
+	cts []domain.DeviceCiphertext,
+) error {
+	if len(cts) == 0 {
+		return nil
+	}
+
+	users := make([]string, len(cts))
+	devices := make([]string, len(cts))
+	blobs := make([][]byte, len(cts))
+	for i, ct := range cts {
+		users[i], devices[i], blobs[i] = ct.UserID, ct.DeviceID, ct.Ciphertext
+	}
+
+	q := r.getter(tx)
+	_, err := q.ExecContext(ctx, `
+		INSERT INTO message_ciphertexts (message_id, user_id, device_id, ciphertext)
+		SELECT $1, c.user_id, c.device_id, c.ciphertext
+		FROM unnest($2::text[], $3::text[], $4::bytea[]) AS c(user_id, device_id, ciphertext)
+	`, messageID, pq.Array(users), pq.Array(devices), pq.Array(blobs))
+	return err
+}
+
+// ListMessageCiphertexts returns the ciphertexts of each message addressed
+// to one device, or to every device when userID is empty.
+func (r *Repository) ListMessageCiphertexts(
+	ctx context.Context,
+	tx *sql.Tx,
+	messageIDs []string,
+	userID, deviceID string,
+) (map[string][]domain.DeviceCiphertext, error) {
+
+	out := make(map[string][]domain.DeviceCiphertext)
+	if len(messageIDs) == 0 {
+		return out, nil
+	}
+
+	q := r.getter(tx)
+	rows, err := q.QueryContext(ctx, `
+		SELECT message_id, user_id, device_id, ciphertext
+		FROM message_ciphertexts
+		WHERE message_id = ANY($1)
+		  AND ($2 = '' OR (user_id = $2 AND device_id = $3))
+		ORDER BY message_id, user_id, device_id
+	`, pq.Array(messageIDs), userID, deviceID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var messageID string
+		var ct domain.DeviceCiphertext
+		if err := rows.Scan(&messageID, &ct.UserID, &ct.DeviceID, &ct.Ciphertext); err != nil {
+			return nil, err
+		}
+		out[messageID] = append(out[messageID], ct)
+	}
+	return out, rows.Err()
+}
+
+// exportColumns is the column list understood by scanExport.
+const exportColumns = `id, conversation_id, user_id, status, object_key, message_count,
+		       size_bytes, failure_reason, created_at, started_at, completed_at`
+
+func scanExport(row rowScanner) (*domain.Export, error) {
+	var e domain.Export
+	var status string
+	var objectKey, failureReason sql.NullString
+	var startedAt, completedAt sql.NullTime
+
+	if err := row.Scan(
+		&e.ID,
+		&e.ConversationID,
+		&e.UserID,
+		&status,
+		&objectKey,
+		&e.MessageCount,
+		&e.SizeBytes,
+		&failureReason,
+		&e.CreatedAt,
+		&startedAt,
+		&completedAt,
+	); err != nil {
+		return nil, err
+	}
+
+	e.Status = domain.ExportStatus(status)
+	e.ObjectKey = objectKey.String
+	e.FailureReason = failureReason.String
+	if startedAt.Valid {
+		e.StartedAt = &startedAt.Time
+	}
+	if completedAt.Valid {
+		e.CompletedAt = &completedAt.Time
+	}
+	return &e, nil
+}
+
+func (r *Repository) InsertExport(ctx context.Context, tx *sql.Tx, e *domain.Export) error {
+	q := r.getter(tx)
+	_, err := q.ExecContext(ctx, `
+		INSERT INTO conversation_exports (id, conversation_id, user_id, status, created_at)
+		VALUES ($1, $2, $3, $4, $5)
+	`, e.ID, e.ConversationID, e.UserID, string(e.Status), e.CreatedAt)
+	return err
+}
+
+// GetExport returns the export, or nil if there is none with that ID.
+func (r *Repository) GetExport(ctx context.Context, tx *sql.Tx, id string) (*domain.Export, error) {
+	q := r.getter(tx)
+	e, err := scanExport(q.QueryRowContext(ctx, `
+		SELECT `+exportColumns+`
+		FROM conversation_exports
+		WHERE id = $1
+	`, id))
+	if err == sql.ErrNoRows {
+		return nil, nil
+	}
+	return e, err
+}
+
+// FindOpenExport returns userID's pending or running export of
+// conversationID, or nil if there is none.
+func (r *Repository) FindOpenExport(ctx context.Context, tx *sql.Tx, conversationID, userID string) (*domain.Export, error) {
+	q := r.getter(tx)
+	e, err := scanExport(q.QueryRowContext(ctx, `
+		SELECT `+exportColumns+`
+		FROM conversation_exports
+		WHERE user_id = $1
+		  AND conversation_id = $2
+		  AND status IN ('pending', 'running')
+		ORDER BY created_at DESC
+		LIMIT 1
+	`, userID, conversationID))
+	if err == sql.ErrNoRows {
+		return nil, nil
+	}
+	return e, err
+}
+
+// ClaimExports marks up to limit exports running as of now and returns
+// them, oldest first. Pending exports are claimed, and so are running ones
+// started before staleBefore, whose worker is presumed gone.
+func (r *Repository) ClaimExports(ctx context.Context, now, staleBefore time.Time, limit int) ([]*domain.Export, error) {
+	rows, err := r.DB.QueryContext(ctx, `
+		UPDATE conversation_exports
+		SET status = 'running', started_at = $1
+		WHERE id IN (
+			SELECT id
+			FROM conversation_exports
+			WHERE status = 'pending'
+			   OR (status = 'running' AND started_at < $2)
+			ORDER BY created_at
+			FOR UPDATE SKIP LOCKED
+			LIMIT $3
+		)
+		RETURNING `+exportColumns+`
+	`, now, staleBefore, limit)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var out []*domain.Export
+	for rows.Next() {
+		e, err := scanExport(rows)
+		if err != nil {
+			return nil, err
+		}
+		out = append(out, e)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	sort.Slice(out, func(i, j int) bool { return out[i].CreatedAt.Before(out[j].CreatedAt) })
+	return out, nil
+}
+
+// FinishExport records the outcome of a running export. It reports false
+// if the export was claimed again in the meantime, i.e. started_at no
+// longer matches, in which case nothing is written.
+func (r *Repository) FinishExport(ctx context.Context, tx *sql.Tx, e *domain.Export) (bool, error) {
+	q := r.getter(tx)
+	res, err := q.ExecContext(ctx, `
+		UPDATE conversation_exports
+		SET status = $2,
+		    object_key = $3,
+		    message_count = $4,
+		    size_bytes = $5,
+		    failure_reason = $6,
+		    completed_at = $7
+		WHERE id = $1
+		  AND status = 'running'
+		  AND started_at = $8
+	`,
+		e.ID,
+		string(e.Status),
+		nullIfEmpty(e.ObjectKey),
+		e.MessageCount,
+		e.SizeBytes,
+		nullIfEmpty(e.FailureReason),
+		e.CompletedAt,
+		e.StartedAt,
+	)
+	if err != nil {
+		return false, err
+	}
+	n, err := res.RowsAffected()
+	return n == 1, err
+}
+
+// importColumns is the column list understood by scanImport.
+const importColumns = `id, conversation_id, source, name, created_by, attachment_id,
+		       mapping, channel, time_zone, day_first, status, total_messages,
+		       imported_messages, failure_reason, created_at, updated_at,
+		       started_at, completed_at`
+
+func scanImport(row rowScanner) (*domain.Import, error) {
+	var i domain.Import
+	var status string
+	var mapping []byte
+	var attachmentID, channel, timeZone, failureReason sql.NullString
+	var startedAt, completedAt sql.NullTime
+
+	if err := row.Scan(
+		&i.ID,
+		&i.ConversationID,
+		&i.Source,
+		&i.Name,
+		&i.CreatedBy,
+		&attachmentID,
+		&mapping,
+		&channel,
+		&timeZone,
+		&i.DayFirst,
+		&status,
+		&i.TotalMessages,
+		&i.ImportedMessages,
+		&failureReason,
+		&i.CreatedAt,
+		&i.UpdatedAt,
+		&startedAt,
+		&completedAt,
+	); err != nil {
+		return nil, err
+	}
+	if err := json.Unmarshal(mapping, &i.Mapping); err != nil {
+		return nil, fmt.Errorf("bad mapping of import %s: %w", i.ID, err)
+	}
+
+	i.Status = domain.ImportStatus(status)
+	i.AttachmentID = attachmentID.String
+	i.Channel = channel.String
+	i.TimeZone = timeZone.String
+	i.FailureReason = failureReason.String
+	if startedAt.Valid {
+		i.StartedAt = &startedAt.Time
+	}
+	if completedAt.Valid {
+		i.CompletedAt = &completedAt.Time
+	}
+	return &i, nil
+}
+
+func scanImports(rows *sql.Rows) ([]*domain.Import, error) {
+	defer rows.Close()
+
+	var out []*domain.Import
+	for rows.Next() {
+		i, err := scanImport(rows)
+		if err != nil {
+			return nil, err
+		}
+		out = append(out, i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	sort.Slice(out, func(a, b int) bool { return out[a].CreatedAt.Before(out[b].CreatedAt) })
+	return out, nil
+}
+
+// InsertImport stores a new import and reports whether it did; an import
+// with the same ID is left as it is.
+func (r *Repository) InsertImport(ctx context.Context, tx *sql.Tx, i *domain.Import) (bool, error) {
+	mapping, err := json.Marshal(i.Mapping)
+	if err != nil {
+		return false, err
+	}
+
+	q := r.getter(tx)
+	res, err := q.ExecContext(ctx, `
+		INSERT INTO message_imports (
+			id, conversation_id, source, name, created_by, attachment_id,
+			mapping, channel, time_zone, day_first, status, created_at, updated_at
+		)
+		VALUES ($1,$2,$3,$4,$5,$6,$7,$8,$9,$10,$11,$12,$12)
+		ON CONFLICT (id) DO NOTHING
+	`,
+		i.ID,
+		i.ConversationID,
+		i.Source,
+		i.Name,
+		i.CreatedBy,
+		nullIfEmpty(i.AttachmentID),
+		mapping,
+		nullIfEmpty(i.Channel),
+		nullIfEmpty(i.TimeZone),
+		i.DayFirst,
+		string(i.Status),
+		i.CreatedAt,
+	)
+	if err != nil {
+		return false, err
+	}
+	n, err := res.RowsAffected()
+	return n == 1, err
+}
+
+// GetImport returns the import, or nil if there is none with that ID.
+func (r *Repository) GetImport(ctx context.Context, tx *sql.Tx, id string) (*domain.Import, error) {
+	q := r.getter(tx)
+	i, err := scanImport(q.QueryRowContext(ctx, `
+		SELECT `+importColumns+`
+		FROM message_imports
+		WHERE id = $1
+	`, id))
+	if err == sql.ErrNoRows {
+		return nil, nil
+	}
+	return i, err
+}
+
+// RequeueImport puts a failed import back in the queue, to resume where it
+// stopped. It reports false if the import had not failed.
+func (r *Repository) RequeueImport(ctx context.Context, tx *sql.Tx, id string, now time.Time) (bool, error) {
+	q := r.getter(tx)
+	res, err := q.ExecContext(ctx, `
+		UPDATE message_imports
+		SET status = 'pending',
+		    failure_reason = NULL,
+		    completed_at = NULL,
+		    updated_at = $2
+		WHERE id = $1
+		  AND status = 'failed'
+	`, id, now)
+	if err != nil {
+		return false, err
+	}
+	n, err := res.RowsAffected()
+	return n == 1, err
+}
+
+// ClaimImports marks up to limit uploaded imports running as of now and
+// returns them, oldest first. Pending imports are claimed, and so are
+// running ones that have not made progress since staleBefore. Imports
+// without an upload belong to the admin command and are never claimed here.
+func (r *Repository) ClaimImports(ctx context.Context, now, staleBefore time.Time, limit int) ([]*domain.Import, error) {
+	rows, err := r.DB.QueryContext(ctx, `
+		UPDATE message_imports
+		SET status = 'running', started_at = $1, updated_at = $1
+		WHERE id IN (
+			SELECT id
+			FROM message_imports
+			WHERE attachment_id IS NOT NULL
+			  AND (status = 'pending'
+			   OR (status = 'running' AND updated_at < $2))
+			ORDER BY created_at
+			FOR UPDATE SKIP LOCKED
+			LIMIT $3
+		)
+		RETURNING `+importColumns+`
+	`, now, staleBefore, limit)
+	if err != nil {
+		return nil, err
+	}
+	return scanImports(rows)
+}
+
+// ClaimImport marks one import running as of now, whether pending, failed
+// or running without progress since staleBefore. It returns nil if the
+// import is done or another run is still making progress.
+func (r *Repository) ClaimImport(ctx context.Context, id string, now, staleBefore time.Time) (*domain.Import, error) {
+	i, err := scanImport(r.DB.QueryRowContext(ctx, `
+		UPDATE message_imports
+		SET status = 'running',
+		    started_at = $2,
+		    updated_at = $2,
+		    failure_reason = NULL,
+		    completed_at = NULL
+		WHERE id = $1
+		  AND (status IN ('pending', 'failed')
+		   OR (status = 'running' AND updated_at < $3))
+		RETURNING `+importColumns+`
+	`, id, now, staleBefore))
+	if err == sql.ErrNoRows {
+		return nil, nil
+	}
+	return i, err
+}
+
+// SaveImportProgress records that a running import has written its parsed
+// messages up to i.ImportedMessages, having started the batch at from. It
+// reports false if the import was claimed again or moved on in the
+// meantime, in which case nothing is written and the batch must be rolled
+// back. The row stays locked until tx ends, so batches never overlap.
+func (r *Repository) SaveImportProgress(ctx context.Context, tx *sql.Tx, i *domain.Import, from int64) (bool, error) {
+	q := r.getter(tx)
+	res, err := q.ExecContext(ctx, `
+		UPDATE message_imports
+		SET imported_messages = $2,
+		    total_messages = $3,
+		    updated_at = $4
+		WHERE id = $1
+		  AND status = 'running'
+		  AND started_at = $5
+		  AND imported_messages = $6
+	`,
+		i.ID,
+		i.ImportedMessages,
+		i.TotalMessages,
+		i.UpdatedAt,
+		i.StartedAt,
+		from,
+	)
+	if err != nil {
+		return false, err
+	}
+	n, err := res.RowsAffected()
+	return n == 1, err
+}
+
+// FinishImport records the outcome of a running import. It reports false
+// if the import was claimed again in the meantime, i.e. started_at no
+// longer matches, in which case nothing is written.
+func (r *Repository) FinishImport(ctx context.Context, tx *sql.Tx, i *domain.Import) (bool, error) {
+	q := r.getter(tx)
+	res, err := q.ExecContext(ctx, `
+		UPDATE message_imports
+		SET status = $2,
+		    total_messages = $3,
+		    failure_reason = $4,
+		    completed_at = $5,
+		    updated_at = $5
+		WHERE id = $1
+		  AND status = 'running'
+		  AND started_at = $6
+	`,
+		i.ID,
+		string(i.Status),
+		i.TotalMessages,
+		nullIfEmpty(i.FailureReason),
+		i.CompletedAt,
+		i.StartedAt,
+	)
+	if err != nil {
+		return false, err
+	}
+	n, err := res.RowsAffected()
+	return n == 1, err
+}
+
+// scheduledColumns is the column list understood by scanScheduled.
+const scheduledColumns = `id, conversation_id, sender_id, type, content, metadata,
+		       reply_to_id, attachment_ids, send_at, status, message_id,
+		       failure_reason, created_at, updated_at, claimed_at`
+
+func scanScheduled(row rowScanner) (*domain.ScheduledMessage, error) {
+	var m domain.ScheduledMessage
+	var metadata, replyToID, messageID, failureReason sql.NullString
+	var status string
+
+	if err := row.Scan(
+		&m.ID,
+		&m.ConversationID,
+		&m.SenderID,
+		&m.Type,
+		&m.Content,
+		&metadata,
+		&replyToID,
+		pq.Array(&m.AttachmentIDs),
+		&m.SendAt,
+		&status,
+		&messageID,
+		&failureReason,
+		&m.CreatedAt,
+		&m.UpdatedAt,
+		&m.ClaimedAt,
+	); err != nil {
+		return nil, err
+	}
+
+	m.Metadata = metadata.String
+	m.ReplyToID = replyToID.String
+	m.Status = domain.ScheduledStatus(status)
+	m.MessageID = messageID.String
+	m.FailureReason = failureReason.String
+	return &m, nil
+}
+
+func (r *Repository) InsertScheduledMessage(ctx context.Context, tx *sql.Tx, m *domain.ScheduledMessage) error {
+	q := r.getter(tx)
+	_, err := q.ExecContext(ctx, `
+		INSERT INTO scheduled_messages (
+			id, conversation_id, sender_id, type, content, metadata,
+			reply_to_id, attachment_ids, send_at, status, created_at, updated_at
+		)
+		VALUES ($1,$2,$3,$4,$5,$6,$7,$8,$9,$10,$11,$12)
+	`,
+		m.ID,
+		m.ConversationID,
+		m.SenderID,
+		m.Type,
+		m.Content,
+		nullIfEmpty(m.Metadata),
+		nullIfEmpty(m.ReplyToID),
+		pq.Array(m.AttachmentIDs),
+		m.SendAt,
+		string(m.Status),
+		m.CreatedAt,
+		m.UpdatedAt,
+	)
+	return err
+}
+
+func (r *Repository) GetScheduledMessageForUpdate(ctx context.Context, tx *sql.Tx, id string) (*domain.ScheduledMessage, error) {
+	q := r.getter(tx)
+	row := q.QueryRowContext(ctx, `
+		SELECT `+scheduledColumns+`
+		FROM scheduled_messages
+		WHERE id = $1
+		FOR UPDATE
+	`, id)
+
+	m, err := scanScheduled(row)
+	if err == sql.ErrNoRows {
+		return nil, domain.ErrScheduledNotFound
+	}
+	return m, err
+}
+
+// UpdateScheduledMessage persists the mutable fields of m.
+func (r *Repository) UpdateScheduledMessage(ctx context.Context, tx *sql.Tx, m *domain.ScheduledMessage) error {
+	q := r.getter(tx)
+	_, err := q.ExecContext(ctx, `
+		UPDATE scheduled_messages
+		SET content = $2,
+		    metadata = $3,
+		    send_at = $4,
+		    status = $5,
+		    message_id = $6,
+		    failure_reason = $7,
+		    updated_at = $8
+		WHERE id = $1
+	`,
+		m.ID,
+		m.Content,
+		nullIfEmpty(m.Metadata),
+		m.SendAt,
+		string(m.Status),
+		nullIfEmpty(m.MessageID),
+		nullIfEmpty(m.FailureReason),
+		m.UpdatedAt,
+	)
+	return err
+}
+
+// ListScheduledMessages returns senderID's pending scheduled messages, soonest
+// first, optionally limited to one conversation.
+func (r *Repository) ListScheduledMessages(ctx context.Context, senderID, conversationID string) ([]*domain.ScheduledMessage, error) {
+	rows, err := r.DB.QueryContext(ctx, `
+		SELECT `+scheduledColumns+`
+		FROM scheduled_messages
+		WHERE sender_id = $1
+		  AND status = 'pending'
+		  AND ($2 = '' OR conversation_id = $2)
+		ORDER BY send_at, id
+	`, senderID, conversationID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var out []*domain.ScheduledMessage
+	for rows.Next() {
+		m, err := scanScheduled(rows)
+		if err != nil {
+			return nil, err
+		}
+		out = append(out, m)
+	}
+	return out, rows.Err()
+}
+
+func (r *Repository) InsertMentions(ctx context.Context, tx *sql.Tx, msg *domain.Message) error {
+	if len(msg.Mentions) == 0 {
+		return nil
+	}
+
+	q := r.getter(tx)
+	_, err := q.ExecContext(ctx, `
+		INSERT INTO message_mentions (message_id, user_id, conversation_id, sequence)
+		SELECT $1, user_id, $3, $4
+		FROM unnest($2::text[]) AS user_id
+		ON CONFLICT DO NOTHING
+	`, msg.ID, pq.Array(msg.Mentions), msg.ConversationID, msg.Sequence)
+	return err
+}
+
+// ReplaceMentions makes msg.Mentions the message's mentions, deleting the
+// rest, and returns the users that weren't mentioned before.
+func (r *Repository) ReplaceMentions(ctx context.Context, tx *sql.Tx, msg *domain.Message) ([]string, error) {
+	rows, err := r.getter(tx).QueryContext(ctx, `
+		WITH gone AS (
+			DELETE FROM message_mentions
+			WHERE message_id = $1
+			  AND NOT (user_id = ANY($2::text[]))
+		)
+		INSERT INTO message_mentions (message_id, user_id, conversation_id, sequence)
+		SELECT $1, user_id, $3, $4
+		FROM unnest($2::text[]) AS user_id
+		ON CONFLICT DO NOTHING
+		RETURNING user_id
+	`, msg.ID, pq.Array(msg.Mentions), msg.ConversationID, msg.Sequence)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var added []string
+	for rows.Next() {
+		var userID string
+		if err := rows.Scan(&userID); err != nil {
+			return nil, err
+		}
+		added = append(added, userID)
+	}
+	return added, rows.Err()
+}
+
+func (r *Repository) CountUnreadMentions(ctx context.Context, userID string, lastRead map[string]int64) (map[string]int64, error) {
+	counts := make(map[string]int64)
+	if len(lastRead) == 0 {
+		return counts, nil
+	}
+
+	convIDs := make([]string, 0, len(lastRead))
+	seqs := make([]int64, 0, len(lastRead))
+	for id, seq := range lastRead {
+		convIDs = append(convIDs, id)
+		seqs = append(seqs, seq)
+	}
+
+	rows, err := r.DB.QueryContext(ctx, `
+		SELECT mm.conversation_id, COUNT(*)
+		FROM unnest($2::text[], $3::bigint[]) AS r(conversation_id, last_read)
+		JOIN message_mentions mm
+		  ON mm.user_id = $1
+		 AND mm.conversation_id = r.conversation_id
+		 AND mm.sequence > r.last_read
+		JOIN messages ON messages.id = mm.message_id
+		WHERE messages.deleted_at IS NULL
+		  AND `+notExpired+`
+		GROUP BY mm.conversation_id
+	`, userID, pq.Array(convIDs), pq.Array(seqs))
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var convID string
+		var n int64
+		if err := rows.Scan(&convID, &n); err != nil {
+			return nil, err
+		}
+		counts[convID] = n
+	}
+	return counts, rows.Err()
+}
+
+// pollColumns is the column list understood by scanPoll.
+const pollColumns = `message_id, conversation_id, creator_id, options,
+		       multiple_choice, anonymous, closes_at, closed_at, closed_by`
+
+func scanPoll(row rowScanner) (*domain.Poll, error) {
+	var p domain.Poll
+	var closesAt, closedAt sql.NullTime
+	var closedBy sql.NullString
+
+	if err := row.Scan(
+		&p.MessageID,
+		&p.ConversationID,
+		&p.CreatorID,
+		pq.Array(&p.Options),
+		&p.MultipleChoice,
+		&p.Anonymous,
+		&closesAt,
+		&closedAt,
+		&closedBy,
+	); err != nil {
+		return nil, err
+	}
+
+	if closesAt.Valid {
+		t := closesAt.Time.UTC()
+		p.ClosesAt = &t
+	}
+	if closedAt.Valid {
+		t := closedAt.Time.UTC()
+		p.ClosedAt = &t
+	}
+	p.ClosedBy = closedBy.String
+	return &p, nil
+}
+
+func (r *Repository) InsertPoll(ctx context.Context, tx *sql.Tx, p *domain.Poll) error {
+	q := r.getter(tx)
+	_, err := q.ExecContext(ctx, `
+		INSERT INTO message_polls (`+pollColumns+`)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9)
+	`,
+		p.MessageID,
+		p.ConversationID,
+		p.CreatorID,
+		pq.Array(p.Options),
+		p.MultipleChoice,
+		p.Anonymous,
+		p.ClosesAt,
+		p.ClosedAt,
+		nullIfEmpty(p.ClosedBy),
+	)
+	return err
+}
+
+// GetPollForUpdate locks the poll of messageID and loads its ballots.
+func (r *Repository) GetPollForUpdate(ctx context.Context, tx *sql.Tx, messageID string) (*domain.Poll, error) {
+	row := tx.QueryRowContext(ctx, `
+		SELECT `+pollColumns+`
+		FROM message_polls
+		WHERE message_id = $1
+		FOR UPDATE
+	`, messageID)
+
+	p, err := scanPoll(row)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, domain.ErrPollNotFound
+		}
+		return nil, err
+	}
+
+	polls := map[string]*domain.Poll{p.MessageID: p}
+	if err := r.loadBallots(ctx, tx, polls); err != nil {
+		return nil, err
+	}
+	return p, nil
+}
+
+// ListPolls returns the polls of messageIDs with their ballots, keyed by
+// message ID. Messages without a poll are absent.
+func (r *Repository) ListPolls(ctx context.Context, tx *sql.Tx, messageIDs []string) (map[string]*domain.Poll, error) {
+	out := make(map[string]*domain.Poll)
+	if len(messageIDs) == 0 {
+		return out, nil
+	}
+
+	q := r.getter(tx)
+	rows, err := q.QueryContext(ctx, `
+		SELECT `+pollColumns+`
+		FROM message_polls
+		WHERE message_id = ANY($1)
+	`, pq.Array(messageIDs))
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		p, err := scanPoll(rows)
+		if err != nil {
+			return nil, err
+		}
+		out[p.MessageID] = p
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+
+	if err := r.loadBallots(ctx, tx, out); err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (r *Repository) loadBallots(ctx context.Context, tx *sql.Tx, polls map[string]*domain.Poll) error {
+	if len(polls) == 0 {
+		return nil
+	}
+
+	ids := make([]string, 0, len(polls))
+	for id := range polls {
+		ids = append(ids, id)
+	}
+
+	q := r.getter(tx)
+	rows, err := q.QueryContext(ctx, `
+		SELECT message_id, user_id, option_index, voted_at
+		FROM message_poll_votes
+		WHERE message_id = ANY($1)
+		ORDER BY voted_at, user_id, option_index
+	`, pq.Array(ids))
+	if err != nil {
+		return err
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var messageID string
+		var b domain.PollBallot
+		if err := rows.Scan(&messageID, &b.UserID, &b.OptionIndex, &b.VotedAt); err != nil {
+			return err
+		}
+		b.VotedAt = b.VotedAt.UTC()
+		polls[messageID].Ballots = append(polls[messageID].Ballots, b)
+	}
+	return rows.Err()
+}
+
+// ReplacePollVotes replaces userID's votes on messageID with options; an
+// empty options retracts them.
+func (r *Repository) ReplacePollVotes(
+	ctx context.Context,
+	tx *sql.Tx,
+	messageID, userID string,
+	options []int,
+	votedAt time.Time,
+) error {
+	if _, err := tx.ExecContext(ctx, `
+		DELETE FROM message_poll_votes
+		WHERE message_id = $1 AND user_id = $2
+	`, messageID, userID); err != nil {
+		return err
+	}
+	if len(options) == 0 {
+		return nil
+	}
+
+	indexes := make([]int64, len(options))
+	for i, o := range options {
+		indexes[i] = int64(o)
+	}
+	_, err := tx.ExecContext(ctx, `
+		INSERT INTO message_poll_votes (message_id, user_id, option_index, voted_at)
+		SELECT $1, $2, option_index, $4
+		FROM unnest($3::int[]) AS option_index
+	`, messageID, userID, pq.Array(indexes), votedAt)
+	return err
+}
+
+func (r *Repository) ClosePoll(ctx context.Context, tx *sql.Tx, p *domain.Poll) error {
+	_, err := tx.ExecContext(ctx, `
+		UPDATE message_polls
+		SET closed_at = $2, closed_by = $3
+		WHERE message_id = $1
+	`, p.MessageID, p.ClosedAt, nullIfEmpty(p.ClosedBy))
+	return err
+}
+
+// GetSequenceBlockForUpdate locks the sequence block of conversationID. A
+// conversation without one gets an empty block.
+func (r *Repository) GetSequenceBlockForUpdate(ctx context.Context, tx *sql.Tx, conversationID string) (*domain.SequenceBlock, error) {
+	b := &domain.SequenceBlock{ConversationID: conversationID}
+	err := tx.QueryRowContext(ctx, `
+		SELECT next_sequence, last_sequence
+		FROM sequence_blocks
+		WHERE conversation_id = $1
+		FOR UPDATE
+	`, conversationID).Scan(&b.Next, &b.Last)
+	if err != nil && err != sql.ErrNoRows {
+		return nil, err
+	}
+	return b, nil
+}
+
+func (r *Repository) SaveSequenceBlock(ctx context.Context, tx *sql.Tx, b *domain.SequenceBlock) error {
+	_, err := tx.ExecContext(ctx, `
+		INSERT INTO sequence_blocks (conversation_id, next_sequence, last_sequence)
+		VALUES ($1, $2, $3)
+		ON CONFLICT (conversation_id)
+		DO UPDATE SET next_sequence = EXCLUDED.next_sequence, last_sequence = EXCLUDED.last_sequence
+	`, b.ConversationID, b.Next, b.Last)
+	return err
+}
+
+// GetConversationInfo returns the projected conversation with its current
+// members, or nil if the conversation is not in the projection.
+func (r *Repository) GetConversationInfo(ctx context.Context, tx *sql.Tx, conversationID string) (*domain.ConversationInfo, error) {
+	q := r.getter(tx)
+
+	info := domain.ConversationInfo{ID: conversationID}
+	var ttlSeconds int64
+	err := q.QueryRowContext(ctx, `
+		SELECT is_group, message_ttl_seconds
+		FROM conversation_projection
+		WHERE conversation_id = $1
+	`, conversationID).Scan(&info.Group, &ttlSeconds)
+	if err == sql.ErrNoRows {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	info.MessageTTL = time.Duration(ttlSeconds) * time.Second
+
+	rows, err := q.QueryContext(ctx, `
+		SELECT user_id, is_admin, joined_at, COALESCE(joined_sequence, 0)
+		FROM conversation_members
+		WHERE conversation_id = $1 AND removed_at IS NULL
+	`, conversationID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var m domain.Member
+		var joinedAt sql.NullTime
+		if err := rows.Scan(&m.UserID, &m.Admin, &joinedAt, &m.JoinedSequence); err != nil {
+			return nil, err
+		}
+		if joinedAt.Valid {
+			m.JoinedAt = &joinedAt.Time
+		}
+		info.Members = append(info.Members, m)
+	}
+	return &info, rows.Err()
+}
+
+// lastHandedOut is the last sequence allocated in conversation $1, or 0.
+const lastHandedOut = `(
+			SELECT GREATEST(COALESCE(MAX(next_sequence), 0) - 1, 0)
+			FROM sequence_blocks
+			WHERE conversation_id = $1
+		)`
+
+// ApplyConversationInfo makes info the projected state of the conversation:
+// listed members are (re)added and everyone else is marked removed.
+func (r *Repository) ApplyConversationInfo(ctx context.Context, tx *sql.Tx, info *domain.ConversationInfo) error {
+	if _, err := tx.ExecContext(ctx, `
+		INSERT INTO conversation_projection (conversation_id, is_group, message_ttl_seconds)
+		VALUES ($1, $2, $3)
+		ON CONFLICT (conversation_id)
+		DO UPDATE SET is_group = EXCLUDED.is_group,
+		              message_ttl_seconds = EXCLUDED.message_ttl_seconds,
+		              updated_at = now()
+	`, info.ID, info.Group, int64(info.MessageTTL/time.Second)); err != nil {
+		return err
+	}
+
+	userIDs, admins := memberColumns(info.Members)
+	if _, err := tx.ExecContext(ctx, `
+		UPDATE conversation_members
+		SET removed_at = now()
+		WHERE conversation_id = $1
+		  AND removed_at IS NULL
+		  AND NOT (user_id = ANY($2))
+	`, info.ID, pq.Array(userIDs)); err != nil {
+		return err
+	}
+
+	_, err := tx.ExecContext(ctx, `
+		INSERT INTO conversation_members (conversation_id, user_id, is_admin)
+		SELECT $1, m.user_id, m.is_admin
+		FROM unnest($2::text[], $3::boolean[]) AS m (user_id, is_admin)
+		ON CONFLICT (conversation_id, user_id)
+		DO UPDATE SET is_admin = EXCLUDED.is_admin,
+		              removed_at = NULL,
+		              joined_at = CASE WHEN conversation_members.removed_at IS NULL
+		                               THEN conversation_members.joined_at ELSE now() END,
+		              joined_sequence = CASE WHEN conversation_members.removed_at IS NULL
+		                               THEN conversation_members.joined_sequence ELSE `+lastHandedOut+` END
+	`, info.ID, pq.Array(userIDs), pq.Array(admins))
+	return err
+}
+
+// BackfillConversationInfo adds info to the projection without overwriting
+// anything already there, including removed members.
+func (r *Repository) BackfillConversationInfo(ctx context.Context, tx *sql.Tx, info *domain.ConversationInfo) error {
+	if _, err := tx.ExecContext(ctx, `
+		INSERT INTO conversation_projection (conversation_id, is_group, message_ttl_seconds)
+		VALUES ($1, $2, $3)
+		ON CONFLICT (conversation_id) DO NOTHING
+	`, info.ID, info.Group, int64(info.MessageTTL/time.Second)); err != nil {
+		return err
+	}
+
+	userIDs, admins := memberColumns(info.Members)
+	_, err := tx.ExecContext(ctx, `
+		INSERT INTO conversation_members (conversation_id, user_id, is_admin)
+		SELECT $1, m.user_id, m.is_admin
+		FROM unnest($2::text[], $3::boolean[]) AS m (user_id, is_admin)
+		ON CONFLICT (conversation_id, user_id) DO NOTHING
+	`, info.ID, pq.Array(userIDs), pq.Array(admins))
+	return err
+}
+
+// AddMember records m as a member, joining after the last sequence handed
+// out so far. A member who is already in keeps their original JoinedAt and
+// JoinedSequence.
+func (r *Repository) AddMember(ctx context.Context, tx *sql.Tx, conversationID string, m domain.Member) error {
+	_, err := tx.ExecContext(ctx, `
+		INSERT INTO conversation_members (conversation_id, user_id, is_admin, joined_at, joined_sequence)
+		VALUES ($1, $2, $3, $4, `+lastHandedOut+`)
+		ON CONFLICT (conversation_id, user_id)
+		DO UPDATE SET is_admin = EXCLUDED.is_admin,
+		              removed_at = NULL,
+		              joined_at = CASE WHEN conversation_members.removed_at IS NULL
+		                               THEN conversation_members.joined_at ELSE EXCLUDED.joined_at END,
+		              joined_sequence = CASE WHEN conversation_members.removed_at IS NULL
+		                               THEN conversation_members.joined_sequence ELSE EXCLUDED.joined_sequence END
+	`, conversationID, m.UserID, m.Admin, m.JoinedAt)
+	return err
+}
+
+func (r *Repository) RemoveMember(ctx context.Context, tx *sql.Tx, conversationID, userID string) error {
+	_, err := tx.ExecContext(ctx, `
+		INSERT INTO conversation_members (conversation_id, user_id, removed_at)
+		VALUES ($1, $2, now())
+		ON CONFLICT (conversation_id, user_id)
+		DO UPDATE SET removed_at = now()
+	`, conversationID, userID)
+	return err
+}
+
+func memberColumns(members []domain.Member) ([]string, []bool) {
+	userIDs := make([]string, len(members))
+	admins := make([]bool, len(members))
+	for i, m := range members {
+		userIDs[i] = m.UserID
+		admins[i] = m.Admin
+	}
+	return userIDs, admins
+}
+
+func (r *Repository) DeleteExpiredMessages(ctx context.Context, tx *sql.Tx, now time.Time, limit int) ([]*domain.Message, error) {
+	q := r.getter(tx)
+	rows, err := q.QueryContext(ctx, `
+		DELETE FROM messages
+		WHERE id IN (
+			SELECT id
+			FROM messages
+			WHERE expires_at <= $1
+			ORDER BY expires_at
+			LIMIT $2
+			FOR UPDATE SKIP LOCKED
+		)
+		RETURNING `+messageColumns+`
+	`, now, limit)
+	if err != nil {
+		return nil, err
+	}
+
+	return scanMessages(rows)
+}
+
+// GetRetentionPolicy returns the retention override of conversationID, or
+// nil if it uses the service-wide retention.
+func (r *Repository) GetRetentionPolicy(ctx context.Context, tx *sql.Tx, conversationID string) (*domain.RetentionPolicy, error) {
+	p := domain.RetentionPolicy{ConversationID: conversationID}
+	var seconds int64
+	err := r.getter(tx).QueryRowContext(ctx, `
+		SELECT retention_seconds, updated_by, updated_at
+		FROM conversation_retention
+		WHERE conversation_id = $1
+	`, conversationID).Scan(&seconds, &p.UpdatedBy, &p.UpdatedAt)
+	if err == sql.ErrNoRows {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	p.Retention = time.Duration(seconds) * time.Second
+	return &p, nil
+}
+
+func (r *Repository) SaveRetentionPolicy(ctx context.Context, tx *sql.Tx, p *domain.RetentionPolicy) error {
+	_, err := tx.ExecContext(ctx, `
+		INSERT INTO conversation_retention (conversation_id, retention_seconds, updated_by, updated_at)
+		VALUES ($1, $2, $3, $4)
+		ON CONFLICT (conversation_id)
+		DO UPDATE SET retention_seconds = EXCLUDED.retention_seconds,
+		              updated_by = EXCLUDED.updated_by,
+		              updated_at = EXCLUDED.updated_at
+	`, p.ConversationID, int64(p.Retention/time.Second), p.UpdatedBy, p.UpdatedAt)
+	return err
+}
+
+func (r *Repository) DeleteRetentionPolicy(ctx context.Context, tx *sql.Tx, conversationID string) error {
+	_, err := tx.ExecContext(ctx, `
+		DELETE FROM conversation_retention WHERE conversation_id = $1
+	`, conversationID)
+	return err
+}
+
+// ListArchiveUnits returns up to limit conversation months whose messages
+// are all older than the conversation's retention at now, oldest first.
+// Months restored until after now are left alone, as are expired messages,
+// which the reaper deletes.
+func (r *Repository) ListArchiveUnits(ctx context.Context, now time.Time, defaultRetention time.Duration, limit int) ([]domain.ArchiveUnit, error) {
+	// No month ending after now-MinRetention can qualify; bounding sent_at
+	// lets the planner skip recent partitions
+	horizon, _ := domain.ArchivePeriod(now.Add(-domain.MinRetention))
+
+	rows, err := r.DB.QueryContext(ctx, `
+		SELECT u.conversation_id, u.period_start
+		FROM (
+			SELECT DISTINCT conversation_id,
+			       date_trunc('month', sent_at AT TIME ZONE 'UTC') AT TIME ZONE 'UTC' AS period_start
+			FROM messages
+			WHERE sent_at < $2
+			  AND `+notExpired+`
+		) u
+		LEFT JOIN conversation_retention cr ON cr.conversation_id = u.conversation_id
+		LEFT JOIN message_archives a
+		       ON a.conversation_id = u.conversation_id AND a.period_start = u.period_start
+		WHERE COALESCE(cr.retention_seconds, $3::bigint) > 0
+		  AND u.period_start + interval '1 month'
+		      <= $1::timestamptz - make_interval(secs => COALESCE(cr.retention_seconds, $3::bigint))
+		  AND (a.restored_until IS NULL OR a.restored_until <= $1)
+		ORDER BY u.period_start, u.conversation_id
+		LIMIT $4
+	`, now, horizon, int64(defaultRetention/time.Second), limit)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var units []domain.ArchiveUnit
+	for rows.Next() {
+		var u domain.ArchiveUnit
+		if err := rows.Scan(&u.ConversationID, &u.PeriodStart); err != nil {
+			return nil, err
+		}
+		u.PeriodStart = u.PeriodStart.UTC()
+		units = append(units, u)
+	}
+	return units, rows.Err()
+}
+
+// LockMessagesInPeriod locks and returns the messages of conversationID
+// sent in [start, end), in sequence order. Expired messages are left to the
+// reaper.
+func (r *Repository) LockMessagesInPeriod(ctx context.Context, tx *sql.Tx, conversationID string, start, end time.Time) ([]*domain.Message, error) {
+	rows, err := tx.QueryContext(ctx, `
+		SELECT `+messageColumns+`
+		FROM messages
+		WHERE conversation_id = $1
+		  AND sent_at >= $2 AND sent_at < $3
+		  AND `+notExpired+`
+		ORDER BY sequence
+		FOR UPDATE
+	`, conversationID, start, end)
+	if err != nil {
+		return nil, err
+	}
+	return scanMessages(rows)
+}
+
+// DeleteMessagesInPeriod hard-deletes the listed messages of conversationID
+// sent in [start, end). Their reactions, pins and other children go with
+// them, so archival reads those first.
+func (r *Repository) DeleteMessagesInPeriod(ctx context.Context, tx *sql.Tx, conversationID string, start, end time.Time, ids []string) error {
+	_, err := tx.ExecContext(ctx, `
+		DELETE FROM messages
+		WHERE conversation_id = $1
+		  AND sent_at >= $2 AND sent_at < $3
+		  AND id = ANY($4)
+	`, conversationID, start, end, pq.Array(ids))
+	return err
+}
+
+// RestoreMessage inserts msg with all of its stored fields. It reports false
+// if the message is already there.
+func (r *Repository) RestoreMessage(ctx context.Context, tx *sql.Tx, msg *domain.Message) (bool, error) {
+	var fwd domain.ForwardedFrom
+	if msg.ForwardedFrom != nil {
+		fwd = *msg.ForwardedFrom
+	}
+
+	res, err := tx.ExecContext(ctx, `
+		INSERT INTO messages (
+			id, conversation_id, sender_id,
+			sequence, type, content, metadata, sent_at,
+			deleted_at, edited_at,
+			reply_to_id, thread_root_id, reply_count, last_reply_at, expires_at,
+			forwarded_from_message_id, forwarded_from_conversation_id,
+			forwarded_from_sender_id, forwarded_from_sent_at,
+			sender_device_id
+		)
+		VALUES ($1,$2,$3,$4,$5,$6,$7,$8,$9,$10,$11,$12,$13,$14,$15,$16,$17,$18,$19,$20)
+		ON CONFLICT DO NOTHING
+	`,
+		msg.ID,
+		msg.ConversationID,
+		msg.SenderID,
+		msg.Sequence,
+		msg.Type,
+		msg.Content,
+		nullIfEmpty(msg.Metadata),
+		msg.SentAt,
+		msg.DeletedAt,
+		msg.EditedAt,
+		nullIfEmpty(msg.ReplyToID),
+		nullIfEmpty(msg.ThreadRootID),
+		msg.ReplyCount,
+		msg.LastReplyAt,
+		msg.ExpiresAt,
+		nullIfEmpty(fwd.MessageID),
+		nullIfEmpty(fwd.ConversationID),
+		nullIfEmpty(fwd.SenderID),
+		nullIfZero(fwd.SentAt),
+		nullIfEmpty(msg.SenderDeviceID),
+	)
+	if err != nil {
+		return false, err
+	}
+	n, err := res.RowsAffected()
+	if err != nil {
+		return false, err
+	}
+	return n == 1, nil
+}
+
+// ListMessageExtras returns the revisions, reactions and pins of messageIDs
+// for archival, keyed by message ID. Messages with none are absent.
+func (r *Repository) ListMessageExtras(ctx context.Context, tx *sql.Tx, messageIDs []string) (map[string]*domain.MessageExtras, error) {
+	out := make(map[string]*domain.MessageExtras)
+	if len(messageIDs) == 0 {
+		return out, nil
+	}
+	extras := func(id string) *domain.MessageExtras {
+		if out[id] == nil {
+			out[id] = &domain.MessageExtras{}
+		}
+		return out[id]
+	}
+	q := r.getter(tx)
+
+	rows, err := q.QueryContext(ctx, `
+		SELECT message_id, revision, content, metadata, replaced_at
+		FROM message_revisions
+		WHERE message_id = ANY($1)
+		ORDER BY message_id, revision
+	`, pq.Array(messageIDs))
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	for rows.Next() {
+		var id string
+		var rev domain.Revision
+		var metadata sql.NullString
+		if err := rows.Scan(&id, &rev.Number, &rev.Content, &metadata, &rev.ReplacedAt); err != nil {
+			return nil, err
+		}
+		rev.Metadata = metadata.String
+		rev.ReplacedAt = rev.ReplacedAt.UTC()
+		e := extras(id)
+		e.Revisions = append(e.Revisions, rev)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+
+	rows, err = q.QueryContext(ctx, `
+		SELECT message_id, user_id, emoji, created_at
+		FROM message_reactions
+		WHERE message_id = ANY($1)
+		ORDER BY message_id, created_at, user_id, emoji
+	`, pq.Array(messageIDs))
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	for rows.Next() {
+		var id string
+		var re domain.Reaction
+		if err := rows.Scan(&id, &re.UserID, &re.Emoji, &re.CreatedAt); err != nil {
+			return nil, err
+		}
+		re.CreatedAt = re.CreatedAt.UTC()
+		e := extras(id)
+		e.Reactions = append(e.Reactions, re)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+
+	rows, err = q.QueryContext(ctx, `
+		SELECT message_id, pinned_by, pinned_at
+		FROM message_pins
+		WHERE message_id = ANY($1)
+	`, pq.Array(messageIDs))
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	for rows.Next() {
+		var id, pinnedBy string
+		var pinnedAt time.Time
+		if err := rows.Scan(&id, &pinnedBy, &pinnedAt); err != nil {
+			return nil, err
+		}
+		pinnedAt = pinnedAt.UTC()
+		e := extras(id)
+		e.PinnedBy, e.PinnedAt = pinnedBy, &pinnedAt
+	}
+	return out, rows.Err()
+}
+
+// RestoreMessageExtras puts back the revisions, reactions and pin archived
+// with msg.
+func (r *Repository) RestoreMessageExtras(ctx context.Context, tx *sql.Tx, msg *domain.Message) error {
+	e := msg.Extras
+	if e == nil {
+		return nil
+	}
+
+	for _, rev := range e.Revisions {
+		if _, err := tx.ExecContext(ctx, `
+			INSERT INTO message_revisions (message_id, revision, content, metadata, replaced_at)
+			VALUES ($1, $2, $3, $4, $5)
+			ON CONFLICT DO NOTHING
+		`, msg.ID, rev.Number, rev.Content, nullIfEmpty(rev.Metadata), rev.ReplacedAt); err != nil {
+			return err
+		}
+	}
+	for _, re := range e.Reactions {
+		if _, err := tx.ExecContext(ctx, `
+			INSERT INTO message_reactions (message_id, user_id, emoji, created_at)
+			VALUES ($1, $2, $3, $4)
+			ON CONFLICT DO NOTHING
+		`, msg.ID, re.UserID, re.Emoji, re.CreatedAt); err != nil {
+			return err
+		}
+	}
+	if e.PinnedAt != nil {
+		if _, err := tx.ExecContext(ctx, `
+			INSERT INTO message_pins (message_id, conversation_id, pinned_by, pinned_at)
+			VALUES ($1, $2, $3, $4)
+			ON CONFLICT (message_id) DO NOTHING
+		`, msg.ID, msg.ConversationID, e.PinnedBy, *e.PinnedAt); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// ListMessageMentions returns the users mentioned by each of messageIDs.
+func (r *Repository) ListMessageMentions(ctx context.Context, tx *sql.Tx, messageIDs []string) (map[string][]string, error) {
+	out := make(map[string][]string)
+	if len(messageIDs) == 0 {
+		return out, nil
+	}
+
+	rows, err := r.getter(tx).QueryContext(ctx, `
+		SELECT message_id, user_id
+		FROM message_mentions
+		WHERE message_id = ANY($1)
+		ORDER BY message_id, user_id
+	`, pq.Array(messageIDs))
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var id, userID string
+		if err := rows.Scan(&id, &userID); err != nil {
+			return nil, err
+		}
+		out[id] = append(out[id], userID)
+	}
+	return out, rows.Err()
+}
+
+// RestorePollBallots puts back the archived ballots of p, whose poll row
+// must already exist.
+func (r *Repository) RestorePollBallots(ctx context.Context, tx *sql.Tx, p *domain.Poll) error {
+	for _, b := range p.Ballots {
+		if _, err := tx.ExecContext(ctx, `
+			INSERT INTO message_poll_votes (message_id, user_id, option_index, voted_at)
+			VALUES ($1, $2, $3, $4)
+			ON CONFLICT DO NOTHING
+		`, p.MessageID, b.UserID, b.OptionIndex, b.VotedAt); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// archiveColumns is the column list understood by scanArchive.
+const archiveColumns = `conversation_id, period_start, period_end, object_key,
+		       message_count, first_sequence, last_sequence,
+		       archived_at, restored_at, restored_until`
+
+func scanArchive(row rowScanner) (*domain.Archive, error) {
+	var a domain.Archive
+	var restoredAt, restoredUntil sql.NullTime
+	if err := row.Scan(
+		&a.ConversationID,
+		&a.PeriodStart,
+		&a.PeriodEnd,
+		&a.ObjectKey,
+		&a.MessageCount,
+		&a.FirstSequence,
+		&a.LastSequence,
+		&a.ArchivedAt,
+		&restoredAt,
+		&restoredUntil,
+	); err != nil {
+		return nil, err
+	}
+	a.PeriodStart = a.PeriodStart.UTC()
+	a.PeriodEnd = a.PeriodEnd.UTC()
+	if restoredAt.Valid {
+		a.RestoredAt = &restoredAt.Time
+	}
+	if restoredUntil.Valid {
+		a.RestoredUntil = &restoredUntil.Time
+	}
+	return &a, nil
+}
+
+// GetArchiveForUpdate locks the archive of a conversation's month, or
+// returns nil if it has never been archived.
+func (r *Repository) GetArchiveForUpdate(ctx context.Context, tx *sql.Tx, conversationID string, periodStart time.Time) (*domain.Archive, error) {
+	a, err := scanArchive(tx.QueryRowContext(ctx, `
+		SELECT `+archiveColumns+`
+		FROM message_archives
+		WHERE conversation_id = $1 AND period_start = $2
+		FOR UPDATE
+	`, conversationID, periodStart))
+	if err == sql.ErrNoRows {
+		return nil, nil
+	}
+	return a, err
+}
+
+// SaveArchive records a, replacing any earlier archive of the same month.
+func (r *Repository) SaveArchive(ctx context.Context, tx *sql.Tx, a *domain.Archive) error {
+	_, err := tx.ExecContext(ctx, `
+		INSERT INTO message_archives (
+			conversation_id, period_start, period_end, object_key,
+			message_count, first_sequence, last_sequence,
+			archived_at, restored_at, restored_until
+		)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10)
+		ON CONFLICT (conversation_id, period_start)
+		DO UPDATE SET period_end = EXCLUDED.period_end,
+		              object_key = EXCLUDED.object_key,
+		              message_count = EXCLUDED.message_count,
+		              first_sequence = EXCLUDED.first_sequence,
+		              last_sequence = EXCLUDED.last_sequence,
+		              archived_at = EXCLUDED.archived_at,
+		              restored_at = EXCLUDED.restored_at,
+		              restored_until = EXCLUDED.restored_until
+	`,
+		a.ConversationID, a.PeriodStart, a.PeriodEnd, a.ObjectKey,
+		a.MessageCount, a.FirstSequence, a.LastSequence,
+		a.ArchivedAt, a.RestoredAt, a.RestoredUntil,
+	)
+	return err
+}
+
+// ListArchives returns the archives of conversationID overlapping
+// [from, to), oldest first.
+func (r *Repository) ListArchives(ctx context.Context, tx *sql.Tx, conversationID string, from, to time.Time) ([]*domain.Archive, error) {
+	rows, err := r.getter(tx).QueryContext(ctx, `
+		SELECT `+archiveColumns+`
+		FROM message_archives
+		WHERE conversation_id = $1
+		  AND period_end > $2 AND period_start < $3
+		ORDER BY period_start
+	`, conversationID, from, to)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var out []*domain.Archive
+	for rows.Next() {
+		a, err := scanArchive(rows)
+		if err != nil {
+			return nil, err
+		}
+		out = append(out, a)
+	}
+	return out, rows.Err()
+}
+
+// partitionPrefix names the monthly partitions of messages, e.g.
+// messages_p202401.
+const partitionPrefix = "messages_p"
+
+func partitionName(periodStart time.Time) string {
+	return partitionPrefix + periodStart.UTC().Format("200601")
+}
+
+// EnsurePartition creates the messages partition of the month starting at
+// periodStart and returns whether it did. A month that already has rows in
+// the default partition is left there, as Postgres refuses to create a
+// partition that would take them over.
+func (r *Repository) EnsurePartition(ctx context.Context, tx *sql.Tx, periodStart time.Time) (bool, error) {
+	start, end := domain.ArchivePeriod(periodStart)
+	name := partitionName(start)
+
+	var exists, stranded bool
+	if err := tx.QueryRowContext(ctx, `
+		SELECT to_regclass($1) IS NOT NULL,
+		       EXISTS (SELECT 1 FROM messages_default WHERE sent_at >= $2 AND sent_at < $3)
+	`, name, start, end).Scan(&exists, &stranded); err != nil {
+		return false, err
+	}
+	if exists || stranded {
+		return false, nil
+	}
+
+	// Bounds are formatted by us, not taken from input
+	_, err := tx.ExecContext(ctx, fmt.Sprintf(
+		`CREATE TABLE %s PARTITION OF messages FOR VALUES FROM ('%s') TO ('%s')`,
+		pq.QuoteIdentifier(name),
+		start.Format(time.RFC3339),
+		end.Format(time.RFC3339),
+	))
+	if err != nil {
+		return false, err
+	}
+	return true, nil
+}
+
+// DropEmptyPartitions drops the monthly partitions of messages that end
+// before before and hold no rows, and returns their names.
+func (r *Repository) DropEmptyPartitions(ctx context.Context, tx *sql.Tx, before time.Time) ([]string, error) {
+	rows, err := tx.QueryContext(ctx, `
+		SELECT c.relname
+		FROM pg_inherits i
+		JOIN pg_class c ON c.oid = i.inhrelid
+		JOIN pg_class p ON p.oid = i.inhparent
+		WHERE p.relname = 'messages'
+		  AND c.relname LIKE $1
+		ORDER BY c.relname
+	`, partitionPrefix+"%")
+	if err != nil {
+		return nil, err
+	}
+
+	var candidates []string
+	for rows.Next() {
+		var name string
+		if err := rows.Scan(&name); err != nil {
+			rows.Close()
+			return nil, err
+		}
+		start, err := time.Parse("200601", strings.TrimPrefix(name, partitionPrefix))
+		if err != nil {
+			continue
+		}
+		if _, end := domain.ArchivePeriod(start); !end.After(before) {
+			candidates = append(candidates, name)
+		}
+	}
+	rows.Close()
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+
+	var dropped []string
+	for _, name := range candidates {
+		table := pq.QuoteIdentifier(name)
+		// Locked first so no insert can land between the check and the drop
+		if _, err := tx.ExecContext(ctx, `LOCK TABLE `+table+` IN ACCESS EXCLUSIVE MODE`); err != nil {
+			return nil, err
+		}
+		var empty bool
+		if err := tx.QueryRowContext(ctx, `SELECT NOT EXISTS (SELECT 1 FROM `+table+`)`).Scan(&empty); err != nil {
+			return nil, err
+		}
+		if !empty {
+			continue
+		}
+		if _, err := tx.ExecContext(ctx, `DROP TABLE `+table); err != nil {
+			return nil, err
+		}
+		dropped = append(dropped, name)
+	}
+	return dropped, nil
+}
+
+// ClaimDueScheduledMessages marks up to limit messages due at now as
+// sending, leased to the caller as of now, and returns them. Messages whose
+// lease started before staleBefore are claimed again. Rows locked by another
+// replica are skipped.
+func (r *Repository) ClaimDueScheduledMessages(ctx context.Context, now, staleBefore time.Time, limit int) ([]*domain.ScheduledMessage, error) {
+	rows, err := r.DB.QueryContext(ctx, `
+		UPDATE scheduled_messages
+		SET status = 'sending', claimed_at = $1
+		WHERE id IN (
+			SELECT id
+			FROM scheduled_messages
+			WHERE (status = 'pending' AND send_at <= $1)
+			   OR (status = 'sending' AND claimed_at < $2)
+			ORDER BY send_at
+			FOR UPDATE SKIP LOCKED
+			LIMIT $3
+		)
+		RETURNING `+scheduledColumns+`
+	`, now, staleBefore, limit)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var out []*domain.ScheduledMessage
+	for rows.Next() {
+		m, err := scanScheduled(rows)
+		if err != nil {
+			return nil, err
+		}
+		out = append(out, m)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	sort.Slice(out, func(i, j int) bool { return out[i].SendAt.Before(out[j].SendAt) })
+	return out, nil
+}
+
+// FinishScheduledMessage records the outcome of sending m and ends its
+// lease. It reports false if m was claimed again in the meantime, i.e.
+// claimed_at no longer matches, in which case nothing is written.
+func (r *Repository) FinishScheduledMessage(ctx context.Context, tx *sql.Tx, m *domain.ScheduledMessage) (bool, error) {
+	q := r.getter(tx)
+	res, err := q.ExecContext(ctx, `
+		UPDATE scheduled_messages
+		SET status = $2,
+		    message_id = $3,
+		    failure_reason = $4,
+		    updated_at = $5,
+		    claimed_at = NULL
+		WHERE id = $1
+		  AND status = 'sending'
+		  AND claimed_at = $6
+	`,
+		m.ID,
+		string(m.Status),
+		nullIfEmpty(m.MessageID),
+		nullIfEmpty(m.FailureReason),
+		m.UpdatedAt,
+		m.ClaimedAt,
+	)
+	if err != nil {
+		return false, err
+	}
+	n, err := res.RowsAffected()
+	return n == 1, err
+}
+
+func (r *Repository) TryInsertIdempotency(
+	ctx context.Context,
+	tx *sql.Tx,
+	key, userID, conversationID string,
+	expiresAt time.Time,
+) (bool, error) {
+	q := r.getter(tx)
+	// An expired key is taken over as if it were new
+	result, err := q.ExecContext(ctx, `
+		INSERT INTO idempotency_keys (key, user_id, conversation_id, expires_at)
+		VALUES ($1, $2, $3, $4)
+		ON CONFLICT (key, user_id, conversation_id)
+		DO UPDATE SET payload = NULL, created_at = now(), expires_at = EXCLUDED.expires_at
+		WHERE idempotency_keys.expires_at <= now()
+	`, key, userID, conversationID, expiresAt)
+	if err != nil {
+		return false, err
+	}
+	n, err := result.RowsAffected()
+	if err != nil {
+		return false, err
+	}
+	return n == 1, nil
+}
+
+func (r *Repository) GetIdempotencyForUpdate(
+	ctx context.Context,
+	tx *sql.Tx,
+	key, userID, conversationID string,
+) ([]byte, error) {
+	q := r.getter(tx)
+	var payload []byte
+	err := q.QueryRowContext(ctx, `
+        SELECT payload
+        FROM idempotency_keys
+        WHERE key = $1 AND user_id = $2 AND conversation_id = $3
+          AND expires_at > now()
+        FOR UPDATE
+    `, key, userID, conversationID).Scan(&payload)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, nil
+		}
+		return nil, err
+	}
+	return payload, nil
+}
+
+// GetIdempotencyResponse returns the response stored under the key without
+// locking it, or nil if there is none yet.
+func (r *Repository) GetIdempotencyResponse(
+	ctx context.Context,
+	key, userID, conversationID string,
+) ([]byte, error) {
+	var payload []byte
+	err := r.DB.QueryRowContext(ctx, `
+		SELECT payload
+		FROM idempotency_keys
+		WHERE key = $1 AND user_id = $2 AND conversation_id = $3
+		  AND expires_at > now()
+	`, key, userID, conversationID).Scan(&payload)
+	if err == sql.ErrNoRows {
+		return nil, nil
+	}
+	return payload, err
+}
+
+func (r *Repository) UpdateIdempotencyResponse(
+	ctx context.Context,
+	tx *sql.Tx,
+	key, userID, conversationID string,
+	payload []byte,
+) error {
+	q := r.getter(tx)
+	_, err := q.ExecContext(ctx, `
+        UPDATE idempotency_keys
+        SET payload = $4
+        WHERE key = $1 AND user_id = $2 AND conversation_id = $3
+    `, key, userID, conversationID, payload)
+	return err
+}
+
+// DeleteExpiredIdempotencyKeys deletes up to limit keys that expired
+// before now and returns how many it deleted. Rows locked by an in-flight
+// request are skipped.
+func (r *Repository) DeleteExpiredIdempotencyKeys(ctx context.Context, tx *sql.Tx, now time.Time, limit int) (int, error) {
+	res, err := r.getter(tx).ExecContext(ctx, `
+		DELETE FROM idempotency_keys
+		WHERE (key, user_id, conversation_id) IN (
+			SELECT key, user_id, conversation_id
+			FROM idempotency_keys
+			WHERE expires_at <= $1
+			ORDER BY expires_at
+			LIMIT $2
+			FOR UPDATE SKIP LOCKED
+		)
+	`, now, limit)
+	if err != nil {
+		return 0, err
+	}
+	n, err := res.RowsAffected()
+	return int(n), err
+}
+
+// EstimateIdempotencyKeys returns the planner's estimate of the row count
+// of idempotency_keys, which is cheap where count(*) is not.
+func (r *Repository) EstimateIdempotencyKeys(ctx context.Context) (int64, error) {
+	var n int64
+	err := r.DB.QueryRowContext(ctx, `
+		SELECT GREATEST(reltuples, 0)::BIGINT
+		FROM pg_class
+		WHERE oid = 'idempotency_keys'::regclass
+	`).Scan(&n)
+	return n, err
+}
+
+func (r *Repository) InsertOutbox(
+	ctx context.Context,
+	tx *sql.Tx,
+	aggregateType, aggregateID, eventType string,
+	payload []byte,
+) error {
+	q := r.getter(tx)
+	_, err := q.ExecContext(ctx, `
+        INSERT INTO outbox_events (aggregate_type, aggregate_id, event_type, payload)
+        VALUES ($1, $2, $3, $4)
+    `, aggregateType, aggregateID, eventType, payload)
+	return err
+}
+
+const draftColumns = `user_id, conversation_id, text, device_id, updated_at`
+
+func scanDraft(row rowScanner) (*domain.Draft, error) {
+	var d domain.Draft
+	if err := row.Scan(&d.UserID, &d.ConversationID, &d.Text, &d.DeviceID, &d.UpdatedAt); err != nil {
+		return nil, err
+	}
+	return &d, nil
+}
+
+// GetDraftForUpdate returns userID's draft of conversationID, cleared or
+// not, and locks it until tx ends. It returns nil if there is none.
+func (r *Repository) GetDraftForUpdate(ctx context.Context, tx *sql.Tx, userID, conversationID string) (*domain.Draft, error) {
+	q := r.getter(tx)
+	d, err := scanDraft(q.QueryRowContext(ctx, `
+		SELECT `+draftColumns+`
+		FROM drafts
+		WHERE user_id = $1 AND conversation_id = $2
+		FOR UPDATE
+	`, userID, conversationID))
+	if err == sql.ErrNoRows {
+		return nil, nil
+	}
+	return d, err
+}
+
+// PutDraft stores d unless a draft with the same or a later updated_at is
+// already stored, and reports whether it did.
+func (r *Repository) PutDraft(ctx context.Context, tx *sql.Tx, d *domain.Draft) (bool, error) {
+	q := r.getter(tx)
+	res, err := q.ExecContext(ctx, `
+		INSERT INTO drafts (user_id, conversation_id, text, device_id, updated_at)
+		VALUES ($1, $2, $3, $4, $5)
+		ON CONFLICT (user_id, conversation_id) DO UPDATE
+		SET text = EXCLUDED.text,
+		    device_id = EXCLUDED.device_id,
+		    updated_at = EXCLUDED.updated_at
+		WHERE drafts.updated_at < EXCLUDED.updated_at
+	`, d.UserID, d.ConversationID, d.Text, d.DeviceID, d.UpdatedAt)
+	if err != nil {
+		return false, err
+	}
+	n, err := res.RowsAffected()
+	return n > 0, err
+}
+
+// ListDrafts returns userID's drafts that hold text, latest first.
+func (r *Repository) ListDrafts(ctx context.Context, userID string) ([]*domain.Draft, error) {
+	rows, err := r.DB.QueryContext(ctx, `
+		SELECT `+draftColumns+`
+		FROM drafts
+		WHERE user_id = $1 AND text <> ''
+		ORDER BY updated_at DESC
+	`, userID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var drafts []*domain.Draft
+	for rows.Next() {
+		d, err := scanDraft(rows)
+		if err != nil {
+			return nil, err
+		}
+		drafts = append(drafts, d)
+	}
+	return drafts, rows.Err()
+}
+
+// heldColumns is the column list understood by scanHeld.
+const heldColumns = `id, conversation_id, sender_id, client_msg_id, type, content,
+		       metadata, reply_to_id, attachment_ids, reason, created_at,
+		       status, reviewed_by, review_note, reviewed_at, message_id`
+
+func scanHeld(row rowScanner) (*domain.HeldMessage, error) {
+	var h domain.HeldMessage
+	var metadata, replyToID, reviewedBy, reviewNote, messageID sql.NullString
+	var reviewedAt sql.NullTime
+	var status string
+
+	if err := row.Scan(
+		&h.ID,
+		&h.ConversationID,
+		&h.SenderID,
+		&h.ClientMsgID,
+		&h.Type,
+		&h.Content,
+		&metadata,
+		&replyToID,
+		pq.Array(&h.AttachmentIDs),
+		&h.Reason,
+		&h.CreatedAt,
+		&status,
+		&reviewedBy,
+		&reviewNote,
+		&reviewedAt,
+		&messageID,
+	); err != nil {
+		return nil, err
+	}
+
+	h.Metadata = metadata.String
+	h.ReplyToID = replyToID.String
+	h.Status = domain.HeldStatus(status)
+	h.ReviewedBy = reviewedBy.String
+	h.ReviewNote = reviewNote.String
+	h.MessageID = messageID.String
+	if reviewedAt.Valid {
+		t := reviewedAt.Time
+		h.ReviewedAt = &t
+	}
+	return &h, nil
+}
+
+// HoldMessage queues h for review and returns the queued row. If the sender
+// already had a send with the same idempotency key held, that row is
+// returned instead.
+func (r *Repository) HoldMessage(ctx context.Context, tx *sql.Tx, h *domain.HeldMessage) (*domain.HeldMessage, error) {
+	q := r.getter(tx)
+	return scanHeld(q.QueryRowContext(ctx, `
+		INSERT INTO moderation_queue (
+			id, conversation_id, sender_id, client_msg_id, type, content,
+			metadata, reply_to_id, attachment_ids, reason, created_at
+		)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11)
+		ON CONFLICT (sender_id, conversation_id, client_msg_id)
+		DO UPDATE SET client_msg_id = EXCLUDED.client_msg_id
+		RETURNING `+heldColumns,
+		h.ID,
+		h.ConversationID,
+		h.SenderID,
+		h.ClientMsgID,
+		h.Type,
+		h.Content,
+		nullIfEmpty(h.Metadata),
+		nullIfEmpty(h.ReplyToID),
+		pq.Array(h.AttachmentIDs),
+		h.Reason,
+		h.CreatedAt,
+	))
+}
+
+// GetHeldMessageForUpdate returns a held message and locks it until tx ends.
+func (r *Repository) GetHeldMessageForUpdate(ctx context.Context, tx *sql.Tx, id string) (*domain.HeldMessage, error) {
+	q := r.getter(tx)
+	h, err := scanHeld(q.QueryRowContext(ctx, `
+		SELECT `+heldColumns+`
+		FROM moderation_queue
+		WHERE id = $1
+		FOR UPDATE
+	`, id))
+	if err == sql.ErrNoRows {
+		return nil, domain.ErrHeldNotFound
+	}
+	return h, err
+}
+
+// UpdateHeldMessage persists the review of h.
+func (r *Repository) UpdateHeldMessage(ctx context.Context, tx *sql.Tx, h *domain.HeldMessage) error {
+	q := r.getter(tx)
+	_, err := q.ExecContext(ctx, `
+		UPDATE moderation_queue
+		SET status = $2,
+		    reviewed_by = $3,
+		    review_note = $4,
+		    reviewed_at = $5,
+		    message_id = $6
+		WHERE id = $1
+	`,
+		h.ID,
+		string(h.Status),
+		nullIfEmpty(h.ReviewedBy),
+		nullIfEmpty(h.ReviewNote),
+		h.ReviewedAt,
+		nullIfEmpty(h.MessageID),
+	)
+	return err
+}
+
+// ListHeldMessages pages the held messages with the given status, oldest
+// first, starting after (afterCreatedAt, afterID) when afterID is set.
+func (r *Repository) ListHeldMessages(
+	ctx context.Context,
+	status domain.HeldStatus,
+	afterCreatedAt time.Time,
+	afterID string,
+	limit int,
+) ([]*domain.HeldMessage, error) {
+
+	rows, err := r.DB.QueryContext(ctx, `
+		SELECT `+heldColumns+`
+		FROM moderation_queue
+		WHERE status = $1
+		  AND ($2 = '' OR (created_at, id) > ($3, $2))
+		ORDER BY created_at, id
+		LIMIT $4
+	`, string(status), afterID, afterCreatedAt, limit)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var out []*domain.HeldMessage
+	for rows.Next() {
+		h, err := scanHeld(rows)
+		if err != nil {
+			return nil, err
+		}
+		out = append(out, h)
+	}
+	return out, rows.Err()
+}
+
+// InsertModerationRecord appends rec to the moderation audit trail.
+func (r *Repository) InsertModerationRecord(ctx context.Context, tx *sql.Tx, rec *domain.ModerationRecord) error {
+	verdicts, err := json.Marshal(rec.Verdicts)
+	if err != nil {
+		return err
+	}
+	if rec.Verdicts == nil {
+		verdicts = []byte("[]")
+	}
+
+	q := r.getter(tx)
+	_, err = q.ExecContext(ctx, `
+		INSERT INTO moderation_audit (
+			id, conversation_id, sender_id, message_id, held_id,
+			action, reason, verdicts, reviewer_id, created_at
+		)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10)
+	`,
+		rec.ID,
+		rec.ConversationID,
+		rec.SenderID,
+		nullIfEmpty(rec.MessageID),
+		nullIfEmpty(rec.HeldID),
+		rec.Action,
+		rec.Reason,
+		verdicts,
+		nullIfEmpty(rec.ReviewerID),
+		rec.CreatedAt,
+	)
 	return err
 }
@@ -14,11 +14,134 @@ type Repository interface {
 	MarkMessageDeleted(ctx context.Context, tx *sql.Tx, msgID string) error
 	GetMessageForUpdate(ctx context.Context, tx *sql.Tx, messageID string) (*domain.Message, error)
 	FetchMessages(ctx context.Context, convID string, lastSeq int64, limit int) ([]*domain.Message, error)
+	FetchMessagesBefore(ctx context.Context, convID string, beforeSeq int64, limit int) ([]*domain.Message, error)
+	HasMessagesOutside(ctx context.Context, convID string, minSeq, maxSeq int64) (before, after bool, err error)
+	GetMessage(ctx context.Context, tx *sql.Tx, messageID string) (*domain.Message, error)
+	FetchThreadReplies(ctx context.Context, convID, rootID string, lastSeq int64, limit int) ([]*domain.Message, error)
+	RecordThreadReply(ctx context.Context, tx *sql.Tx, rootID string, repliedAt time.Time) error
+	RefreshThreadSummaries(ctx context.Context, tx *sql.Tx, rootIDs []string) error
+	UpdateMessageContent(ctx context.Context, tx *sql.Tx, msg *domain.Message) error
+	InsertMessageRevision(ctx context.Context, tx *sql.Tx, messageID, content, metadata string) error
 
-	// Idempotency
+	// Search
+	SearchMessages(ctx context.Context, q domain.SearchQuery) ([]*domain.SearchHit, error)
+
+	// Reactions
+	AddReaction(ctx context.Context, tx *sql.Tx, messageID, userID, emoji string) (bool, error)
+	RemoveReaction(ctx context.Context, tx *sql.Tx, messageID, userID, emoji string) (bool, error)
+	ListReactionSummaries(ctx context.Context, tx *sql.Tx, messageIDs []string, viewerID string) (map[string][]domain.ReactionSummary, error)
+
+	// Pins
+	PinMessage(ctx context.Context, tx *sql.Tx, conversationID, messageID, userID string, pinnedAt time.Time) (bool, error)
+	UnpinMessage(ctx context.Context, tx *sql.Tx, conversationID, messageID string) (bool, error)
+	CountPins(ctx context.Context, tx *sql.Tx, conversationID string) (int, error)
+	ListPins(ctx context.Context, tx *sql.Tx, conversationID string) ([]*domain.Pin, error)
+
+	// Mentions
+	InsertMentions(ctx context.Context, tx *sql.Tx, msg *domain.Message) error
+	// ReplaceMentions makes msg.Mentions the message's mentions and returns
+	// the users that weren't mentioned before.
+	ReplaceMentions(ctx context.Context, tx *sql.Tx, msg *domain.Message) ([]string, error)
+	// CountUnreadMentions counts, per conversation, the live messages
+	// mentioning userID with a sequence above lastRead[conversationID].
+	CountUnreadMentions(ctx context.Context, userID string, lastRead map[string]int64) (map[string]int64, error)
+
+	// Polls
+	InsertPoll(ctx context.Context, tx *sql.Tx, p *domain.Poll) error
+	GetPollForUpdate(ctx context.Context, tx *sql.Tx, messageID string) (*domain.Poll, error)
+	ListPolls(ctx context.Context, tx *sql.Tx, messageIDs []string) (map[string]*domain.Poll, error)
+	ReplacePollVotes(ctx context.Context, tx *sql.Tx, messageID, userID string, options []int, votedAt time.Time) error
+	ClosePoll(ctx context.Context, tx *sql.Tx, p *domain.Poll) error
+
+	// Sequence blocks
+	GetSequenceBlockForUpdate(ctx context.Context, tx *sql.Tx, conversationID string) (*domain.SequenceBlock, error)
+	SaveSequenceBlock(ctx context.Context, tx *sql.Tx, b *domain.SequenceBlock) error
+
+	// Conversation projection
+	GetConversationInfo(ctx context.Context, tx *sql.Tx, conversationID string) (*domain.ConversationInfo, error)
+	ApplyConversationInfo(ctx context.Context, tx *sql.Tx, info *domain.ConversationInfo) error
+	BackfillConversationInfo(ctx context.Context, tx *sql.Tx, info *domain.ConversationInfo) error
+	AddMember(ctx context.Context, tx *sql.Tx, conversationID string, m domain.Member) error
+	RemoveMember(ctx context.Context, tx *sql.Tx, conversationID, userID string) error
+
+	// DeleteExpiredMessages hard-deletes up to limit messages whose TTL passed
+	// before now and returns them.
+	DeleteExpiredMessages(ctx context.Context, tx *sql.Tx, now time.Time, limit int) ([]*domain.Message, error)
+
+	// Retention and archival
+	GetRetentionPolicy(ctx context.Context, tx *sql.Tx, conversationID string) (*domain.RetentionPolicy, error)
+	SaveRetentionPolicy(ctx context.Context, tx *sql.Tx, p *domain.RetentionPolicy) error
+	DeleteRetentionPolicy(ctx context.Context, tx *sql.Tx, conversationID string) error
+	ListArchiveUnits(ctx context.Context, now time.Time, defaultRetention time.Duration, limit int) ([]domain.ArchiveUnit, error)
+	LockMessagesInPeriod(ctx context.Context, tx *sql.Tx, conversationID string, start, end time.Time) ([]*domain.Message, error)
+	DeleteMessagesInPeriod(ctx context.Context, tx *sql.Tx, conversationID string, start, end time.Time, ids []string) error
+	RestoreMessage(ctx context.Context, tx *sql.Tx, msg *domain.Message) (bool, error)
+	ListMessageExtras(ctx context.Context, tx *sql.Tx, messageIDs []string) (map[string]*domain.MessageExtras, error)
+	RestoreMessageExtras(ctx context.Context, tx *sql.Tx, msg *domain.Message) error
+	ListMessageMentions(ctx context.Context, tx *sql.Tx, messageIDs []string) (map[string][]string, error)
+	RestorePollBallots(ctx context.Context, tx *sql.Tx, p *domain.Poll) error
+	GetArchiveForUpdate(ctx context.Context, tx *sql.Tx, conversationID string, periodStart time.Time) (*domain.Archive, error)
+	SaveArchive(ctx context.Context, tx *sql.Tx, a *domain.Archive) error
+	ListArchives(ctx context.Context, tx *sql.Tx, conversationID string, from, to time.Time) ([]*domain.Archive, error)
+
+	// Partitions
+	EnsurePartition(ctx context.Context, tx *sql.Tx, periodStart time.Time) (bool, error)
+	DropEmptyPartitions(ctx context.Context, tx *sql.Tx, before time.Time) ([]string, error)
+
+	// Exports
+	InsertExport(ctx context.Context, tx *sql.Tx, e *domain.Export) error
+	GetExport(ctx context.Context, tx *sql.Tx, id string) (*domain.Export, error)
+	FindOpenExport(ctx context.Context, tx *sql.Tx, conversationID, userID string) (*domain.Export, error)
+	ClaimExports(ctx context.Context, now, staleBefore time.Time, limit int) ([]*domain.Export, error)
+	FinishExport(ctx context.Context, tx *sql.Tx, e *domain.Export) (bool, error)
+
+	// Imports
+	InsertImport(ctx context.Context, tx *sql.Tx, i *domain.Import) (bool, error)
+	GetImport(ctx context.Context, tx *sql.Tx, id string) (*domain.Import, error)
+	RequeueImport(ctx context.Context, tx *sql.Tx, id string, now time.Time) (bool, error)
+	ClaimImports(ctx context.Context, now, staleBefore time.Time, limit int) ([]*domain.Import, error)
+	ClaimImport(ctx context.Context, id string, now, staleBefore time.Time) (*domain.Import, error)
+	SaveImportProgress(ctx context.Context, tx *sql.Tx, i *domain.Import, from int64) (bool, error)
+	FinishImport(ctx context.Context, tx *sql.Tx, i *domain.Import) (bool, error)
+
+	// Drafts
+	GetDraftForUpdate(ctx context.Context, tx *sql.Tx, userID, conversationID string) (*domain.Draft, error)
+	PutDraft(ctx context.Context, tx *sql.Tx, d *domain.Draft) (bool, error)
+	ListDrafts(ctx context.Context, userID string) ([]*domain.Draft, error)
+
+	// Moderation
+	HoldMessage(ctx context.Context, tx *sql.Tx, h *domain.HeldMessage) (*domain.HeldMessage, error)
+	GetHeldMessageForUpdate(ctx context.Context, tx *sql.Tx, id string) (*domain.HeldMessage, error)
+	UpdateHeldMessage(ctx context.Context, tx *sql.Tx, h *domain.HeldMessage) error
+	ListHeldMessages(ctx context.Context, status domain.HeldStatus, afterCreatedAt time.Time, afterID string, limit int) ([]*domain.HeldMessage, error)
+	InsertModerationRecord(ctx context.Context, tx *sql.Tx, rec *domain.ModerationRecord) error
+
+	// Scheduled messages
+	InsertScheduledMessage(ctx context.Context, tx *sql.Tx, m *domain.ScheduledMessage) error
+	GetScheduledMessageForUpdate(ctx context.Context, tx *sql.Tx, id string) (*domain.ScheduledMessage, error)
+	UpdateScheduledMessage(ctx context.Context, tx *sql.Tx, m *domain.ScheduledMessage) error
+	ListScheduledMessages(ctx context.Context, senderID, conversationID string) ([]*domain.ScheduledMessage, error)
+	ClaimDueScheduledMessages(ctx context.Context, now, staleBefore time.Time, limit int) ([]*domain.ScheduledMessage, error)
+	FinishScheduledMessage(ctx context.Context, tx *sql.Tx, m *domain.ScheduledMessage) (bool, error)
+
+	// Attachments
+	InsertMessageAttachments(ctx context.Context, tx *sql.Tx, messageID string, atts []domain.Attachment) error
+	ListMessageAttachments(ctx context.Context, tx *sql.Tx, messageIDs []string) (map[string][]domain.Attachment, error)
+
+	// Encrypted messages. An empty userID lists every device's ciphertext.
+	InsertMessageCiphertexts(ctx context.Context, tx *sql.Tx, messageID string, cts []domain.DeviceCiphertext) error
+	ListMessageCiphertexts(ctx context.Context, tx *sql.Tx, messageIDs []string, userID, deviceID string) (map[string][]domain.DeviceCiphertext, error)
+
+	// Idempotency. Expired keys are treated as absent: TryInsertIdempotency
+	// takes them over and GetIdempotencyForUpdate doesn't return them.
 	TryInsertIdempotency(ctx context.Context, tx *sql.Tx, key, userID, conversationID string, expiresAt time.Time) (bool, error)
 	GetIdempotencyForUpdate(ctx context.Context, tx *sql.Tx, key, userID, conversationID string) ([]byte, error)
+	// GetIdempotencyResponse reads the stored response without a lock, so
+	// retries can be answered before any other check.
+	GetIdempotencyResponse(ctx context.Context, key, userID, conversationID string) ([]byte, error)
 	UpdateIdempotencyResponse(ctx context.Context, tx *sql.Tx, key, userID, conversationID string, payload []byte) error
+	DeleteExpiredIdempotencyKeys(ctx context.Context, tx *sql.Tx, now time.Time, limit int) (int, error)
+	EstimateIdempotencyKeys(ctx context.Context) (int64, error)
 
 	// Outbox
 	InsertOutbox(ctx context.Context, tx *sql.Tx, aggregateType, aggregateID, eventType string, payload []byte) error
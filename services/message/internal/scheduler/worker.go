@@ -0,0 +1,132 @@
+package scheduler
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/SARVESHVARADKAR123/RealChat/services/message/internal/auth"
+	"github.com/SARVESHVARADKAR123/RealChat/services/message/internal/domain"
+	"github.com/SARVESHVARADKAR123/RealChat/services/message/internal/observability"
+	"github.com/SARVESHVARADKAR123/RealChat/services/message/internal/repository"
+	"go.uber.org/zap"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// Sender sends a due scheduled message through the normal send path.
+type Sender interface {
+	SendScheduledMessage(ctx context.Context, m *domain.ScheduledMessage) (*domain.Message, error)
+}
+
+// claimLease is how long a claimed scheduled message may go without being
+// finished before another worker sends it instead.
+const claimLease = 5 * time.Minute
+
+// Worker fires scheduled messages once they are due. Due rows are claimed
+// with FOR UPDATE SKIP LOCKED and leased to the worker, so several replicas
+// can run it side by side. Claims are committed before anything is sent, so
+// editing or cancelling other messages never waits on a send.
+type Worker struct {
+	Repo      repository.Repository
+	Sender    Sender
+	BatchSize int
+	PollDelay time.Duration
+}
+
+// Start Worker
+func (w *Worker) Start(ctx context.Context) {
+
+	log := observability.GetLogger(ctx)
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		default:
+			if err := w.processBatch(ctx); err != nil {
+				log.Error("scheduler error", zap.Error(err))
+				time.Sleep(time.Second)
+			}
+		}
+	}
+}
+
+func (w *Worker) processBatch(ctx context.Context) error {
+
+	now := time.Now().UTC()
+	due, err := w.Repo.ClaimDueScheduledMessages(ctx, now, now.Add(-claimLease), w.BatchSize)
+	if err != nil {
+		return err
+	}
+
+	if len(due) == 0 {
+		time.Sleep(w.PollDelay)
+		return nil
+	}
+
+	// Each outcome is recorded on its own, so a failure only sends again
+	// the message it concerns
+	var retryErr error
+	for _, m := range due {
+		if err := w.fire(ctx, m); err != nil {
+			retryErr = err
+		}
+		finished, err := w.Repo.FinishScheduledMessage(ctx, nil, m)
+		if err != nil {
+			return err
+		}
+		if !finished {
+			observability.GetLogger(ctx).Warn("scheduled message was taken over by another worker", zap.String("scheduled_id", m.ID))
+		}
+	}
+	return retryErr
+}
+
+// fire sends m as its sender and records the outcome on m. Failures worth
+// retrying put m back to pending for the next poll and are returned.
+func (w *Worker) fire(ctx context.Context, m *domain.ScheduledMessage) error {
+	// Downstream calls are made on behalf of the sender
+	sendCtx := context.WithValue(ctx, auth.UserIDKey, m.SenderID)
+
+	msg, err := w.Sender.SendScheduledMessage(sendCtx, m)
+	m.UpdatedAt = time.Now().UTC()
+	switch {
+	case err == nil:
+		m.Status = domain.ScheduledSent
+		m.MessageID = msg.ID
+		observability.ScheduledMessagesFiredTotal.WithLabelValues("sent").Inc()
+
+	case permanent(err):
+		m.Status = domain.ScheduledFailed
+		m.FailureReason = err.Error()
+		observability.ScheduledMessagesFiredTotal.WithLabelValues("failed").Inc()
+
+	default:
+		m.Status = domain.ScheduledPending
+		observability.ScheduledMessagesFiredTotal.WithLabelValues("retry").Inc()
+		return fmt.Errorf("scheduled message %s: %w", m.ID, err)
+	}
+	return nil
+}
+
+// permanent reports whether err will fail the same way on every retry, e.g.
+// the sender has left the conversation in the meantime. A message held by
+// moderation counts too: it is sent if a moderator approves it.
+func permanent(err error) bool {
+	if errors.Is(err, domain.ErrNotParticipant) ||
+		errors.Is(err, domain.ErrInvalidReplyTarget) ||
+		errors.Is(err, domain.ErrInvalidAttachment) ||
+		errors.Is(err, domain.ErrMessageTooLarge) ||
+		errors.Is(err, domain.ErrInvalidMessage) ||
+		errors.Is(err, domain.ErrMessageRejected) ||
+		errors.Is(err, domain.ErrMessageHeld) {
+		return true
+	}
+
+	switch status.Code(err) {
+	case codes.NotFound, codes.PermissionDenied, codes.InvalidArgument:
+		return true
+	}
+	return false
+}
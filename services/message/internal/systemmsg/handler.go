@@ -0,0 +1,114 @@
+package systemmsg
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+
+	conversationv1 "github.com/SARVESHVARADKAR123/RealChat/contracts/gen/go/conversation/v1"
+	sharedv1 "github.com/SARVESHVARADKAR123/RealChat/contracts/gen/go/shared/v1"
+	"github.com/SARVESHVARADKAR123/RealChat/services/message/internal/application"
+	"github.com/SARVESHVARADKAR123/RealChat/services/message/internal/domain"
+	"github.com/SARVESHVARADKAR123/RealChat/services/message/internal/observability"
+	"go.uber.org/zap"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/proto"
+)
+
+// Poster writes system messages.
+type Poster interface {
+	PostSystemMessage(ctx context.Context, cmd application.SystemMessageCommand) (*domain.Message, error)
+}
+
+// Handler turns conversation events into system messages, so clients that
+// sync later see membership changes in timeline order.
+type Handler struct {
+	Poster Poster
+}
+
+// Handle processes one conversation event. Malformed events and events for
+// conversations that no longer exist are dropped; other failures are
+// returned for the consumer to retry.
+func (h *Handler) Handle(ctx context.Context, record []byte) error {
+	log := observability.GetLogger(ctx)
+
+	var env sharedv1.EventEnvelope
+	if err := proto.Unmarshal(record, &env); err != nil {
+		log.Error("systemmsg: error unmarshaling event", zap.Error(err))
+		observability.SystemMessagesTotal.WithLabelValues("dropped").Inc()
+		return nil
+	}
+
+	cmd, ok, err := commandFor(&env)
+	if err != nil {
+		log.Error("systemmsg: error unmarshaling payload", zap.String("event_type", env.GetEventType().String()), zap.Error(err))
+		observability.SystemMessagesTotal.WithLabelValues("dropped").Inc()
+		return nil
+	}
+	if !ok {
+		return nil
+	}
+
+	// Events written before event_id existed are keyed by their bytes, which
+	// the outbox republishes unchanged
+	cmd.EventID = env.GetEventId()
+	if cmd.EventID == "" {
+		sum := sha256.Sum256(record)
+		cmd.EventID = "sha256:" + hex.EncodeToString(sum[:])
+	}
+
+	if _, err := h.Poster.PostSystemMessage(ctx, cmd); err != nil {
+		if permanent(err) {
+			log.Warn("systemmsg: dropping event", zap.String("conversation_id", cmd.ConversationID), zap.Error(err))
+			observability.SystemMessagesTotal.WithLabelValues("dropped").Inc()
+			return nil
+		}
+		observability.SystemMessagesTotal.WithLabelValues("retry").Inc()
+		return err
+	}
+
+	observability.SystemMessagesTotal.WithLabelValues("posted").Inc()
+	return nil
+}
+
+// commandFor maps env to a system message. It reports false for event types
+// that don't produce one.
+func commandFor(env *sharedv1.EventEnvelope) (application.SystemMessageCommand, bool, error) {
+	switch env.GetEventType() {
+	case sharedv1.EventType_EVENT_TYPE_CONVERSATION_CREATED:
+		var event conversationv1.ConversationCreatedEvent
+		if err := proto.Unmarshal(env.GetPayload(), &event); err != nil {
+			return application.SystemMessageCommand{}, false, err
+		}
+		return application.SystemMessageCommand{
+			ConversationID: event.GetConversation().GetConversationId(),
+			Event: domain.SystemEvent{
+				Event:   domain.SystemConversationCreated,
+				ActorID: event.GetCreatorUserId(),
+			},
+		}, true, nil
+
+	case sharedv1.EventType_EVENT_TYPE_MEMBERSHIP_CHANGED:
+		var event conversationv1.MembershipChangedEvent
+		if err := proto.Unmarshal(env.GetPayload(), &event); err != nil {
+			return application.SystemMessageCommand{}, false, err
+		}
+		return application.SystemMessageCommand{
+			ConversationID: event.GetConversationId(),
+			Event:          domain.MembershipEvent(event.GetActorUserId(), event.GetUserId(), event.GetAdded()),
+		}, true, nil
+	}
+
+	return application.SystemMessageCommand{}, false, nil
+}
+
+// permanent reports whether err will fail the same way on every retry, e.g.
+// the conversation has been deleted in the meantime.
+func permanent(err error) bool {
+	if errors.Is(err, domain.ErrInvalidInput) || errors.Is(err, domain.ErrInvalidMessage) {
+		return true
+	}
+	return status.Code(err) == codes.NotFound
+}
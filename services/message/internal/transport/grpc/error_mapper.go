@@ -5,6 +5,7 @@ import (
 	"log"
 
 	"github.com/SARVESHVARADKAR123/RealChat/services/message/internal/domain"
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
 	"google.golang.org/grpc/codes"
 	"google.golang.org/grpc/status"
 )
@@ -20,17 +21,50 @@ func MapError(err error) error {
 		return err
 	}
 
+	// Validation errors name the offending field in a BadRequest detail
+	var ve *domain.ValidationError
+	if errors.As(err, &ve) {
+		return validationStatus(ve)
+	}
+
 	switch {
-	case errors.Is(err, domain.ErrMessageNotFound):
+	case errors.Is(err, domain.ErrMessageNotFound),
+		errors.Is(err, domain.ErrScheduledNotFound),
+		errors.Is(err, domain.ErrPollNotFound),
+		errors.Is(err, domain.ErrExportNotFound),
+		errors.Is(err, domain.ErrImportNotFound),
+		errors.Is(err, domain.ErrHeldNotFound):
 		return status.Error(codes.NotFound, err.Error())
 
-	case errors.Is(err, domain.ErrNotParticipant):
+	case errors.Is(err, domain.ErrNotParticipant),
+		errors.Is(err, domain.ErrNotSender),
+		errors.Is(err, domain.ErrNotAdmin),
+		errors.Is(err, domain.ErrImportForbidden),
+		errors.Is(err, domain.ErrMessageRejected),
+		errors.Is(err, domain.ErrModerationForbidden):
 		return status.Error(codes.PermissionDenied, err.Error())
 
+	case errors.Is(err, domain.ErrMessageDeleted),
+		errors.Is(err, domain.ErrEditWindowExpired),
+		errors.Is(err, domain.ErrPinLimitReached),
+		errors.Is(err, domain.ErrScheduleNotPending),
+		errors.Is(err, domain.ErrPollClosed),
+		errors.Is(err, domain.ErrExportNotReady),
+		errors.Is(err, domain.ErrHeldNotPending),
+		errors.Is(err, domain.ErrMessageEncrypted):
+		return status.Error(codes.FailedPrecondition, err.Error())
+
 	case errors.Is(err, domain.ErrInvalidMessage),
 		errors.Is(err, domain.ErrInvalidSequence),
 		errors.Is(err, domain.ErrMessageTooLarge),
-		errors.Is(err, domain.ErrInvalidInput):
+		errors.Is(err, domain.ErrInvalidInput),
+		errors.Is(err, domain.ErrInvalidReplyTarget),
+		errors.Is(err, domain.ErrInvalidReaction),
+		errors.Is(err, domain.ErrInvalidAttachment),
+		errors.Is(err, domain.ErrInvalidSchedule),
+		errors.Is(err, domain.ErrInvalidVote),
+		errors.Is(err, domain.ErrInvalidRetention),
+		errors.Is(err, domain.ErrInvalidImport):
 		return status.Error(codes.InvalidArgument, err.Error())
 
 	default:
@@ -39,3 +73,17 @@ func MapError(err error) error {
 		return status.Error(codes.Internal, "internal server error")
 	}
 }
+
+func validationStatus(ve *domain.ValidationError) error {
+	st := status.New(codes.InvalidArgument, ve.Error())
+	detailed, err := st.WithDetails(&errdetails.BadRequest{
+		FieldViolations: []*errdetails.BadRequest_FieldViolation{{
+			Field:       ve.Field,
+			Description: ve.Reason,
+		}},
+	})
+	if err != nil {
+		return st.Err()
+	}
+	return detailed.Err()
+}
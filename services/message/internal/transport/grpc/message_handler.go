@@ -2,10 +2,17 @@ package grpc
 
 import (
 	"context"
+	"errors"
+	"io"
+	"sort"
+	"time"
 
 	messagev1 "github.com/SARVESHVARADKAR123/RealChat/contracts/gen/go/message/v1"
 	"github.com/SARVESHVARADKAR123/RealChat/services/message/internal/application"
 	"github.com/SARVESHVARADKAR123/RealChat/services/message/internal/auth"
+	"github.com/SARVESHVARADKAR123/RealChat/services/message/internal/domain"
+	"github.com/SARVESHVARADKAR123/RealChat/services/message/internal/imports"
+	"google.golang.org/grpc"
 	"google.golang.org/grpc/codes"
 	"google.golang.org/grpc/status"
 	"google.golang.org/protobuf/types/known/timestamppb"
@@ -24,29 +31,76 @@ func (s *Server) SendMessage(
 		return nil, status.Error(codes.PermissionDenied, "sender id mismatch")
 	}
 
-	msg, err := s.app.SendMessage(ctx, application.SendMessageCommand{
+	cmd := application.SendMessageCommand{
 		ConversationID: req.ConversationId,
 		UserID:         req.SenderUserId,
 		ClientMsgID:    req.IdempotencyKey,
 		Type:           req.MessageType,
 		Content:        req.Content,
 		Metadata:       req.MetadataJson,
-	})
+		ReplyToID:      req.ReplyToMessageId,
+		AttachmentIDs:  req.AttachmentIds,
+		SenderDeviceID: req.SenderDeviceId,
+		Ciphertexts:    fromProtoCiphertexts(req.Ciphertexts),
+	}
+
+	// A send_at in the past or present just sends now
+	if req.SendAt != nil && req.SendAt.AsTime().After(time.Now()) {
+		scheduled, err := s.app.ScheduleMessage(ctx, cmd, req.SendAt.AsTime())
+		if err != nil {
+			return nil, MapError(err)
+		}
+		return &messagev1.SendMessageResponse{
+			Scheduled: toProtoScheduled(scheduled),
+		}, nil
+	}
+
+	msg, err := s.app.SendMessage(ctx, cmd)
+	var held *domain.HeldError
+	if errors.As(err, &held) {
+		return &messagev1.SendMessageResponse{
+			Held: toProtoHeld(held.Held),
+		}, nil
+	}
 	if err != nil {
 		return nil, MapError(err)
 	}
 
+	// The sender only gets back what its own device can read
+	sent := *msg
+	sent.Ciphertexts = msg.CiphertextFor(userID, req.SenderDeviceId)
+
 	return &messagev1.SendMessageResponse{
-		Message: &messagev1.Message{
-			MessageId:      msg.ID,
-			ConversationId: msg.ConversationID,
-			SenderUserId:   msg.SenderID,
-			Sequence:       msg.Sequence,
-			MessageType:    msg.Type,
-			Content:        msg.Content,
-			MetadataJson:   msg.Metadata,
-			SentAt:         timestamppb.New(msg.SentAt),
-		},
+		Message: application.ToProtoMessage(&sent),
+	}, nil
+}
+
+func (s *Server) EditMessage(
+	ctx context.Context,
+	req *messagev1.EditMessageRequest,
+) (*messagev1.EditMessageResponse, error) {
+
+	userID, err := auth.GetUserID(ctx)
+	if err != nil {
+		return nil, status.Error(codes.Unauthenticated, err.Error())
+	}
+	if req.ActorUserId != userID {
+		return nil, status.Error(codes.PermissionDenied, "actor id mismatch")
+	}
+
+	msg, err := s.app.EditMessage(ctx, application.EditMessageCommand{
+		ConversationID: req.ConversationId,
+		MessageID:      req.MessageId,
+		RequesterID:    req.ActorUserId,
+		Content:        req.Content,
+		Metadata:       req.MetadataJson,
+	})
+	if err != nil {
+		return nil, MapError(err)
+	}
+
+	return &messagev1.EditMessageResponse{
+		Message: application.ToProtoMessage(msg),
 	}, nil
 }
 
@@ -85,10 +139,47 @@ func (s *Server) SyncMessages(
 		return nil, status.Error(codes.Unauthenticated, err.Error())
 	}
 
-	messages, err := s.app.SyncMessages(
+	page, err := s.app.SyncMessages(ctx, application.SyncQuery{
+		ConversationID: req.ConversationId,
+		UserID:         userID,
+		DeviceID:       req.DeviceId,
+		AfterSequence:  req.AfterSequence,
+		BeforeSequence: req.BeforeSequence,
+		AroundSequence: req.AroundSequence,
+		PageSize:       int(req.PageSize),
+	})
+	if err != nil {
+		return nil, MapError(err)
+	}
+
+	var protoMsgs []*messagev1.Message
+
+	for _, m := range page.Messages {
+		protoMsgs = append(protoMsgs, application.ToProtoMessage(m))
+	}
+
+	return &messagev1.SyncMessagesResponse{
+		Messages:      protoMsgs,
+		HasMoreBefore: page.HasMoreBefore,
+		HasMoreAfter:  page.HasMoreAfter,
+	}, nil
+}
+
+func (s *Server) ListThreadReplies(
+	ctx context.Context,
+	req *messagev1.ListThreadRepliesRequest,
+) (*messagev1.ListThreadRepliesResponse, error) {
+
+	userID, err := auth.GetUserID(ctx)
+	if err != nil {
+		return nil, status.Error(codes.Unauthenticated, err.Error())
+	}
+
+	root, replies, err := s.app.ListThreadReplies(
 		ctx,
 		req.ConversationId,
 		userID,
+		req.ThreadRootId,
 		req.AfterSequence,
 		int(req.PageSize),
 	)
@@ -96,26 +187,817 @@ func (s *Server) SyncMessages(
 		return nil, MapError(err)
 	}
 
-	var protoMsgs []*messagev1.Message
+	var protoReplies []*messagev1.Message
+	for _, m := range replies {
+		protoReplies = append(protoReplies, application.ToProtoMessage(m))
+	}
 
-	for _, m := range messages {
-		pm := &messagev1.Message{
-			MessageId:      m.ID,
-			ConversationId: m.ConversationID,
-			SenderUserId:   m.SenderID,
-			Sequence:       m.Sequence,
-			MessageType:    m.Type,
-			Content:        m.Content,
-			MetadataJson:   m.Metadata,
-			SentAt:         timestamppb.New(m.SentAt),
-		}
-		if m.DeletedAt != nil {
-			pm.DeletedAt = timestamppb.New(*m.DeletedAt)
-		}
-		protoMsgs = append(protoMsgs, pm)
+	return &messagev1.ListThreadRepliesResponse{
+		Root:    application.ToProtoMessage(root),
+		Replies: protoReplies,
+	}, nil
+}
+
+func (s *Server) AddReaction(
+	ctx context.Context,
+	req *messagev1.AddReactionRequest,
+) (*messagev1.AddReactionResponse, error) {
+
+	userID, err := auth.GetUserID(ctx)
+	if err != nil {
+		return nil, status.Error(codes.Unauthenticated, err.Error())
+	}
+	if req.ActorUserId != userID {
+		return nil, status.Error(codes.PermissionDenied, "actor id mismatch")
 	}
 
-	return &messagev1.SyncMessagesResponse{
-		Messages: protoMsgs,
+	reactions, err := s.app.AddReaction(ctx, application.ReactionCommand{
+		ConversationID: req.ConversationId,
+		MessageID:      req.MessageId,
+		UserID:         req.ActorUserId,
+		Emoji:          req.Emoji,
+	})
+	if err != nil {
+		return nil, MapError(err)
+	}
+
+	return &messagev1.AddReactionResponse{
+		Reactions: application.ToProtoReactions(reactions),
+	}, nil
+}
+
+func (s *Server) RemoveReaction(
+	ctx context.Context,
+	req *messagev1.RemoveReactionRequest,
+) (*messagev1.RemoveReactionResponse, error) {
+
+	userID, err := auth.GetUserID(ctx)
+	if err != nil {
+		return nil, status.Error(codes.Unauthenticated, err.Error())
+	}
+	if req.ActorUserId != userID {
+		return nil, status.Error(codes.PermissionDenied, "actor id mismatch")
+	}
+
+	reactions, err := s.app.RemoveReaction(ctx, application.ReactionCommand{
+		ConversationID: req.ConversationId,
+		MessageID:      req.MessageId,
+		UserID:         req.ActorUserId,
+		Emoji:          req.Emoji,
+	})
+	if err != nil {
+		return nil, MapError(err)
+	}
+
+	return &messagev1.RemoveReactionResponse{
+		Reactions: application.ToProtoReactions(reactions),
+	}, nil
+}
+
+func (s *Server) VotePoll(
+	ctx context.Context,
+	req *messagev1.VotePollRequest,
+) (*messagev1.VotePollResponse, error) {
+
+	userID, err := auth.GetUserID(ctx)
+	if err != nil {
+		return nil, status.Error(codes.Unauthenticated, err.Error())
+	}
+	if req.ActorUserId != userID {
+		return nil, status.Error(codes.PermissionDenied, "actor id mismatch")
+	}
+
+	options := make([]int, len(req.OptionIndexes))
+	for i, o := range req.OptionIndexes {
+		options[i] = int(o)
+	}
+
+	poll, err := s.app.VotePoll(ctx, application.PollCommand{
+		ConversationID: req.ConversationId,
+		MessageID:      req.MessageId,
+		UserID:         req.ActorUserId,
+		Options:        options,
+	})
+	if err != nil {
+		return nil, MapError(err)
+	}
+
+	return &messagev1.VotePollResponse{Poll: application.ToProtoPoll(poll)}, nil
+}
+
+func (s *Server) RetractVote(
+	ctx context.Context,
+	req *messagev1.RetractVoteRequest,
+) (*messagev1.RetractVoteResponse, error) {
+
+	userID, err := auth.GetUserID(ctx)
+	if err != nil {
+		return nil, status.Error(codes.Unauthenticated, err.Error())
+	}
+	if req.ActorUserId != userID {
+		return nil, status.Error(codes.PermissionDenied, "actor id mismatch")
+	}
+
+	poll, err := s.app.RetractVote(ctx, application.PollCommand{
+		ConversationID: req.ConversationId,
+		MessageID:      req.MessageId,
+		UserID:         req.ActorUserId,
+	})
+	if err != nil {
+		return nil, MapError(err)
+	}
+
+	return &messagev1.RetractVoteResponse{Poll: application.ToProtoPoll(poll)}, nil
+}
+
+func (s *Server) ClosePoll(
+	ctx context.Context,
+	req *messagev1.ClosePollRequest,
+) (*messagev1.ClosePollResponse, error) {
+
+	userID, err := auth.GetUserID(ctx)
+	if err != nil {
+		return nil, status.Error(codes.Unauthenticated, err.Error())
+	}
+	if req.ActorUserId != userID {
+		return nil, status.Error(codes.PermissionDenied, "actor id mismatch")
+	}
+
+	poll, err := s.app.ClosePoll(ctx, application.PollCommand{
+		ConversationID: req.ConversationId,
+		MessageID:      req.MessageId,
+		UserID:         req.ActorUserId,
+	})
+	if err != nil {
+		return nil, MapError(err)
+	}
+
+	return &messagev1.ClosePollResponse{Poll: application.ToProtoPoll(poll)}, nil
+}
+
+func (s *Server) SearchMessages(
+	ctx context.Context,
+	req *messagev1.SearchMessagesRequest,
+) (*messagev1.SearchMessagesResponse, error) {
+
+	userID, err := auth.GetUserID(ctx)
+	if err != nil {
+		return nil, status.Error(codes.Unauthenticated, err.Error())
+	}
+
+	cmd := application.SearchCommand{
+		UserID:         userID,
+		Query:          req.Query,
+		ConversationID: req.ConversationId,
+		SenderID:       req.SenderUserId,
+		Type:           req.MessageType,
+		PageSize:       int(req.PageSize),
+		PageToken:      req.PageToken,
+	}
+	if req.SentAfter != nil {
+		cmd.SentAfter = req.SentAfter.AsTime()
+	}
+	if req.SentBefore != nil {
+		cmd.SentBefore = req.SentBefore.AsTime()
+	}
+
+	hits, next, err := s.app.SearchMessages(ctx, cmd)
+	if err != nil {
+		return nil, MapError(err)
+	}
+
+	protoHits := make([]*messagev1.SearchHit, 0, len(hits))
+	for _, h := range hits {
+		protoHits = append(protoHits, &messagev1.SearchHit{
+			Message: application.ToProtoMessage(h.Message),
+			Snippet: h.Snippet,
+		})
+	}
+
+	return &messagev1.SearchMessagesResponse{
+		Hits:          protoHits,
+		NextPageToken: next,
+	}, nil
+}
+
+func (s *Server) PinMessage(
+	ctx context.Context,
+	req *messagev1.PinMessageRequest,
+) (*messagev1.PinMessageResponse, error) {
+
+	userID, err := auth.GetUserID(ctx)
+	if err != nil {
+		return nil, status.Error(codes.Unauthenticated, err.Error())
+	}
+	if req.ActorUserId != userID {
+		return nil, status.Error(codes.PermissionDenied, "actor id mismatch")
+	}
+
+	pin, err := s.app.PinMessage(ctx, application.PinCommand{
+		ConversationID: req.ConversationId,
+		MessageID:      req.MessageId,
+		UserID:         req.ActorUserId,
+	})
+	if err != nil {
+		return nil, MapError(err)
+	}
+
+	return &messagev1.PinMessageResponse{
+		Pin: toProtoPin(pin),
 	}, nil
 }
+
+func (s *Server) UnpinMessage(
+	ctx context.Context,
+	req *messagev1.UnpinMessageRequest,
+) (*messagev1.UnpinMessageResponse, error) {
+
+	userID, err := auth.GetUserID(ctx)
+	if err != nil {
+		return nil, status.Error(codes.Unauthenticated, err.Error())
+	}
+	if req.ActorUserId != userID {
+		return nil, status.Error(codes.PermissionDenied, "actor id mismatch")
+	}
+
+	err = s.app.UnpinMessage(ctx, application.PinCommand{
+		ConversationID: req.ConversationId,
+		MessageID:      req.MessageId,
+		UserID:         req.ActorUserId,
+	})
+	if err != nil {
+		return nil, MapError(err)
+	}
+
+	return &messagev1.UnpinMessageResponse{}, nil
+}
+
+func (s *Server) ListPinnedMessages(
+	ctx context.Context,
+	req *messagev1.ListPinnedMessagesRequest,
+) (*messagev1.ListPinnedMessagesResponse, error) {
+
+	userID, err := auth.GetUserID(ctx)
+	if err != nil {
+		return nil, status.Error(codes.Unauthenticated, err.Error())
+	}
+
+	pins, err := s.app.ListPinnedMessages(ctx, req.ConversationId, userID)
+	if err != nil {
+		return nil, MapError(err)
+	}
+
+	resp := &messagev1.ListPinnedMessagesResponse{}
+	for _, p := range pins {
+		resp.Pins = append(resp.Pins, toProtoPin(p))
+	}
+	return resp, nil
+}
+
+func toProtoPin(p *domain.Pin) *messagev1.PinnedMessage {
+	return &messagev1.PinnedMessage{
+		Message:        application.ToProtoMessage(p.Message),
+		PinnedByUserId: p.PinnedBy,
+		PinnedAt:       timestamppb.New(p.PinnedAt),
+	}
+}
+
+func (s *Server) ForwardMessages(
+	ctx context.Context,
+	req *messagev1.ForwardMessagesRequest,
+) (*messagev1.ForwardMessagesResponse, error) {
+
+	userID, err := auth.GetUserID(ctx)
+	if err != nil {
+		return nil, status.Error(codes.Unauthenticated, err.Error())
+	}
+	if req.SenderUserId != userID {
+		return nil, status.Error(codes.PermissionDenied, "sender id mismatch")
+	}
+
+	msgs, err := s.app.ForwardMessages(ctx, application.ForwardMessagesCommand{
+		SourceConversationID: req.SourceConversationId,
+		TargetConversationID: req.TargetConversationId,
+		MessageIDs:           req.MessageIds,
+		UserID:               req.SenderUserId,
+		ClientMsgID:          req.IdempotencyKey,
+	})
+	if err != nil {
+		return nil, MapError(err)
+	}
+
+	resp := &messagev1.ForwardMessagesResponse{}
+	for _, m := range msgs {
+		resp.Messages = append(resp.Messages, application.ToProtoMessage(m))
+	}
+	return resp, nil
+}
+
+func (s *Server) GetUnreadMentionCounts(
+	ctx context.Context,
+	req *messagev1.GetUnreadMentionCountsRequest,
+) (*messagev1.GetUnreadMentionCountsResponse, error) {
+
+	userID, err := auth.GetUserID(ctx)
+	if err != nil {
+		return nil, status.Error(codes.Unauthenticated, err.Error())
+	}
+
+	counts, err := s.app.GetUnreadMentionCounts(ctx, userID)
+	if err != nil {
+		return nil, MapError(err)
+	}
+
+	resp := &messagev1.GetUnreadMentionCountsResponse{}
+	for convID, n := range counts {
+		resp.Counts = append(resp.Counts, &messagev1.UnreadMentionCount{
+			ConversationId: convID,
+			Count:          n,
+		})
+	}
+	sort.Slice(resp.Counts, func(i, j int) bool {
+		return resp.Counts[i].ConversationId < resp.Counts[j].ConversationId
+	})
+	return resp, nil
+}
+
+func (s *Server) ListScheduledMessages(
+	ctx context.Context,
+	req *messagev1.ListScheduledMessagesRequest,
+) (*messagev1.ListScheduledMessagesResponse, error) {
+
+	userID, err := auth.GetUserID(ctx)
+	if err != nil {
+		return nil, status.Error(codes.Unauthenticated, err.Error())
+	}
+
+	scheduled, err := s.app.ListScheduledMessages(ctx, userID, req.ConversationId)
+	if err != nil {
+		return nil, MapError(err)
+	}
+
+	resp := &messagev1.ListScheduledMessagesResponse{}
+	for _, m := range scheduled {
+		resp.Scheduled = append(resp.Scheduled, toProtoScheduled(m))
+	}
+	return resp, nil
+}
+
+func (s *Server) UpdateScheduledMessage(
+	ctx context.Context,
+	req *messagev1.UpdateScheduledMessageRequest,
+) (*messagev1.UpdateScheduledMessageResponse, error) {
+
+	userID, err := auth.GetUserID(ctx)
+	if err != nil {
+		return nil, status.Error(codes.Unauthenticated, err.Error())
+	}
+	if req.ActorUserId != userID {
+		return nil, status.Error(codes.PermissionDenied, "actor id mismatch")
+	}
+
+	cmd := application.UpdateScheduledCommand{
+		ScheduledID: req.ScheduledId,
+		UserID:      req.ActorUserId,
+		Content:     req.Content,
+		Metadata:    req.MetadataJson,
+	}
+	if req.SendAt != nil {
+		cmd.SendAt = req.SendAt.AsTime()
+	}
+
+	scheduled, err := s.app.UpdateScheduledMessage(ctx, cmd)
+	if err != nil {
+		return nil, MapError(err)
+	}
+
+	return &messagev1.UpdateScheduledMessageResponse{
+		Scheduled: toProtoScheduled(scheduled),
+	}, nil
+}
+
+func (s *Server) CancelScheduledMessage(
+	ctx context.Context,
+	req *messagev1.CancelScheduledMessageRequest,
+) (*messagev1.CancelScheduledMessageResponse, error) {
+
+	userID, err := auth.GetUserID(ctx)
+	if err != nil {
+		return nil, status.Error(codes.Unauthenticated, err.Error())
+	}
+	if req.ActorUserId != userID {
+		return nil, status.Error(codes.PermissionDenied, "actor id mismatch")
+	}
+
+	if err := s.app.CancelScheduledMessage(ctx, req.ScheduledId, req.ActorUserId); err != nil {
+		return nil, MapError(err)
+	}
+
+	return &messagev1.CancelScheduledMessageResponse{}, nil
+}
+
+func toProtoScheduled(m *domain.ScheduledMessage) *messagev1.ScheduledMessage {
+	return &messagev1.ScheduledMessage{
+		ScheduledId:      m.ID,
+		ConversationId:   m.ConversationID,
+		SenderUserId:     m.SenderID,
+		MessageType:      m.Type,
+		Content:          m.Content,
+		MetadataJson:     m.Metadata,
+		ReplyToMessageId: m.ReplyToID,
+		AttachmentIds:    m.AttachmentIDs,
+		SendAt:           timestamppb.New(m.SendAt),
+		CreatedAt:        timestamppb.New(m.CreatedAt),
+		UpdatedAt:        timestamppb.New(m.UpdatedAt),
+	}
+}
+
+func fromProtoCiphertexts(cts []*messagev1.DeviceCiphertext) []domain.DeviceCiphertext {
+	var out []domain.DeviceCiphertext
+	for _, ct := range cts {
+		out = append(out, domain.DeviceCiphertext{
+			UserID:     ct.GetUserId(),
+			DeviceID:   ct.GetDeviceId(),
+			Ciphertext: ct.GetCiphertext(),
+		})
+	}
+	return out
+}
+
+func (s *Server) SetRetentionPolicy(
+	ctx context.Context,
+	req *messagev1.SetRetentionPolicyRequest,
+) (*messagev1.SetRetentionPolicyResponse, error) {
+
+	userID, err := auth.GetUserID(ctx)
+	if err != nil {
+		return nil, status.Error(codes.Unauthenticated, err.Error())
+	}
+	if req.ActorUserId != userID {
+		return nil, status.Error(codes.PermissionDenied, "actor id mismatch")
+	}
+
+	r, err := s.app.SetRetentionPolicy(ctx, application.RetentionCommand{
+		ConversationID: req.ConversationId,
+		UserID:         req.ActorUserId,
+		Retention:      time.Duration(req.RetentionSeconds) * time.Second,
+		UseDefault:     req.UseDefault,
+	})
+	if err != nil {
+		return nil, MapError(err)
+	}
+
+	return &messagev1.SetRetentionPolicyResponse{Policy: toProtoRetention(r)}, nil
+}
+
+func (s *Server) GetRetentionPolicy(
+	ctx context.Context,
+	req *messagev1.GetRetentionPolicyRequest,
+) (*messagev1.GetRetentionPolicyResponse, error) {
+
+	userID, err := auth.GetUserID(ctx)
+	if err != nil {
+		return nil, status.Error(codes.Unauthenticated, err.Error())
+	}
+
+	r, err := s.app.GetRetentionPolicy(ctx, req.ConversationId, userID)
+	if err != nil {
+		return nil, MapError(err)
+	}
+
+	return &messagev1.GetRetentionPolicyResponse{Policy: toProtoRetention(r)}, nil
+}
+
+func toProtoRetention(r *application.Retention) *messagev1.RetentionPolicy {
+	return &messagev1.RetentionPolicy{
+		RetentionSeconds: int64(r.Retention / time.Second),
+		IsDefault:        r.Default,
+	}
+}
+
+// exportChunkSize keeps DownloadExport messages well under the default
+// 4 MiB gRPC limit.
+const exportChunkSize = 64 << 10
+
+func (s *Server) RequestExport(
+	ctx context.Context,
+	req *messagev1.RequestExportRequest,
+) (*messagev1.RequestExportResponse, error) {
+
+	userID, err := auth.GetUserID(ctx)
+	if err != nil {
+		return nil, status.Error(codes.Unauthenticated, err.Error())
+	}
+	if req.UserId != userID {
+		return nil, status.Error(codes.PermissionDenied, "user id mismatch")
+	}
+
+	e, err := s.app.RequestExport(ctx, application.ExportCommand{
+		ConversationID: req.ConversationId,
+		UserID:         req.UserId,
+	})
+	if err != nil {
+		return nil, MapError(err)
+	}
+
+	return &messagev1.RequestExportResponse{Export: toProtoExport(e)}, nil
+}
+
+func (s *Server) GetExport(
+	ctx context.Context,
+	req *messagev1.GetExportRequest,
+) (*messagev1.GetExportResponse, error) {
+
+	userID, err := auth.GetUserID(ctx)
+	if err != nil {
+		return nil, status.Error(codes.Unauthenticated, err.Error())
+	}
+
+	e, err := s.app.GetExport(ctx, req.ExportId, userID)
+	if err != nil {
+		return nil, MapError(err)
+	}
+
+	return &messagev1.GetExportResponse{Export: toProtoExport(e)}, nil
+}
+
+func (s *Server) DownloadExport(
+	req *messagev1.DownloadExportRequest,
+	stream grpc.ServerStreamingServer[messagev1.DownloadExportResponse],
+) error {
+
+	ctx := stream.Context()
+	userID, err := auth.GetUserID(ctx)
+	if err != nil {
+		return status.Error(codes.Unauthenticated, err.Error())
+	}
+
+	e, rc, err := s.app.OpenExport(ctx, req.ExportId, userID)
+	if err != nil {
+		return MapError(err)
+	}
+	defer rc.Close()
+
+	if err := stream.Send(&messagev1.DownloadExportResponse{Export: toProtoExport(e)}); err != nil {
+		return err
+	}
+
+	buf := make([]byte, exportChunkSize)
+	for {
+		n, err := rc.Read(buf)
+		if n > 0 {
+			if sendErr := stream.Send(&messagev1.DownloadExportResponse{Data: buf[:n]}); sendErr != nil {
+				return sendErr
+			}
+		}
+		if errors.Is(err, io.EOF) {
+			return nil
+		}
+		if err != nil {
+			return MapError(err)
+		}
+	}
+}
+
+func toProtoExport(e *domain.Export) *messagev1.ConversationExport {
+	out := &messagev1.ConversationExport{
+		ExportId:       e.ID,
+		ConversationId: e.ConversationID,
+		UserId:         e.UserID,
+		Status:         string(e.Status),
+		MessageCount:   e.MessageCount,
+		SizeBytes:      e.SizeBytes,
+		FailureReason:  e.FailureReason,
+		CreatedAt:      timestamppb.New(e.CreatedAt),
+	}
+	if e.CompletedAt != nil {
+		out.CompletedAt = timestamppb.New(*e.CompletedAt)
+	}
+	return out
+}
+
+func (s *Server) RequestImport(
+	ctx context.Context,
+	req *messagev1.RequestImportRequest,
+) (*messagev1.RequestImportResponse, error) {
+
+	userID, err := auth.GetUserID(ctx)
+	if err != nil {
+		return nil, status.Error(codes.Unauthenticated, err.Error())
+	}
+	if req.UserId != userID {
+		return nil, status.Error(codes.PermissionDenied, "user id mismatch")
+	}
+
+	imp, err := s.app.RequestImport(ctx, application.ImportCommand{
+		Source:       req.Source,
+		Name:         req.Name,
+		CreatedBy:    req.UserId,
+		Mapping:      req.Mapping,
+		AttachmentID: req.AttachmentId,
+		Options: imports.Options{
+			Channel:  req.Channel,
+			TimeZone: req.TimeZone,
+			DayFirst: req.DayFirst,
+		},
+	})
+	if err != nil {
+		return nil, MapError(err)
+	}
+
+	return &messagev1.RequestImportResponse{Import: toProtoImport(imp)}, nil
+}
+
+func (s *Server) GetImport(
+	ctx context.Context,
+	req *messagev1.GetImportRequest,
+) (*messagev1.GetImportResponse, error) {
+
+	userID, err := auth.GetUserID(ctx)
+	if err != nil {
+		return nil, status.Error(codes.Unauthenticated, err.Error())
+	}
+
+	imp, err := s.app.GetImport(ctx, req.ImportId, userID)
+	if err != nil {
+		return nil, MapError(err)
+	}
+
+	return &messagev1.GetImportResponse{Import: toProtoImport(imp)}, nil
+}
+
+func (s *Server) SaveDraft(
+	ctx context.Context,
+	req *messagev1.SaveDraftRequest,
+) (*messagev1.SaveDraftResponse, error) {
+
+	userID, err := auth.GetUserID(ctx)
+	if err != nil {
+		return nil, status.Error(codes.Unauthenticated, err.Error())
+	}
+
+	cmd := application.SaveDraftCommand{
+		ConversationID: req.ConversationId,
+		UserID:         userID,
+		DeviceID:       req.DeviceId,
+		Text:           req.Text,
+	}
+	if req.UpdatedAt != nil {
+		cmd.UpdatedAt = req.UpdatedAt.AsTime()
+	}
+
+	draft, applied, err := s.app.SaveDraft(ctx, cmd)
+	if err != nil {
+		return nil, MapError(err)
+	}
+
+	return &messagev1.SaveDraftResponse{Draft: application.ToProtoDraft(draft), Applied: applied}, nil
+}
+
+func (s *Server) ClearDraft(
+	ctx context.Context,
+	req *messagev1.ClearDraftRequest,
+) (*messagev1.ClearDraftResponse, error) {
+
+	userID, err := auth.GetUserID(ctx)
+	if err != nil {
+		return nil, status.Error(codes.Unauthenticated, err.Error())
+	}
+
+	cmd := application.SaveDraftCommand{
+		ConversationID: req.ConversationId,
+		UserID:         userID,
+		DeviceID:       req.DeviceId,
+	}
+	if req.ClearedAt != nil {
+		cmd.UpdatedAt = req.ClearedAt.AsTime()
+	}
+
+	draft, applied, err := s.app.SaveDraft(ctx, cmd)
+	if err != nil {
+		return nil, MapError(err)
+	}
+
+	return &messagev1.ClearDraftResponse{Draft: application.ToProtoDraft(draft), Applied: applied}, nil
+}
+
+func (s *Server) GetDrafts(
+	ctx context.Context,
+	req *messagev1.GetDraftsRequest,
+) (*messagev1.GetDraftsResponse, error) {
+
+	userID, err := auth.GetUserID(ctx)
+	if err != nil {
+		return nil, status.Error(codes.Unauthenticated, err.Error())
+	}
+
+	drafts, err := s.app.GetDrafts(ctx, userID)
+	if err != nil {
+		return nil, MapError(err)
+	}
+
+	resp := &messagev1.GetDraftsResponse{Drafts: make([]*messagev1.Draft, 0, len(drafts))}
+	for _, d := range drafts {
+		resp.Drafts = append(resp.Drafts, application.ToProtoDraft(d))
+	}
+	return resp, nil
+}
+
+func toProtoImport(i *domain.Import) *messagev1.MessageImport {
+	out := &messagev1.MessageImport{
+		ImportId:         i.ID,
+		ConversationId:   i.ConversationID,
+		Source:           i.Source,
+		Name:             i.Name,
+		CreatedBy:        i.CreatedBy,
+		Status:           string(i.Status),
+		TotalMessages:    i.TotalMessages,
+		ImportedMessages: i.ImportedMessages,
+		FailureReason:    i.FailureReason,
+		CreatedAt:        timestamppb.New(i.CreatedAt),
+	}
+	if i.CompletedAt != nil {
+		out.CompletedAt = timestamppb.New(*i.CompletedAt)
+	}
+	return out
+}
+
+func (s *Server) ListHeldMessages(
+	ctx context.Context,
+	req *messagev1.ListHeldMessagesRequest,
+) (*messagev1.ListHeldMessagesResponse, error) {
+
+	userID, err := auth.GetUserID(ctx)
+	if err != nil {
+		return nil, status.Error(codes.Unauthenticated, err.Error())
+	}
+
+	held, next, err := s.app.ListHeldMessages(ctx, application.ListHeldCommand{
+		ModeratorID: userID,
+		Status:      domain.HeldStatus(req.Status),
+		PageSize:    int(req.PageSize),
+		PageToken:   req.PageToken,
+	})
+	if err != nil {
+		return nil, MapError(err)
+	}
+
+	resp := &messagev1.ListHeldMessagesResponse{
+		Held:          make([]*messagev1.HeldMessage, 0, len(held)),
+		NextPageToken: next,
+	}
+	for _, h := range held {
+		resp.Held = append(resp.Held, toProtoHeld(h))
+	}
+	return resp, nil
+}
+
+func (s *Server) ReviewHeldMessage(
+	ctx context.Context,
+	req *messagev1.ReviewHeldMessageRequest,
+) (*messagev1.ReviewHeldMessageResponse, error) {
+
+	userID, err := auth.GetUserID(ctx)
+	if err != nil {
+		return nil, status.Error(codes.Unauthenticated, err.Error())
+	}
+
+	held, msg, err := s.app.ReviewHeldMessage(ctx, application.ReviewCommand{
+		HeldID:     req.HeldId,
+		ReviewerID: userID,
+		Approve:    req.Approve,
+		Note:       req.Note,
+	})
+	if err != nil {
+		return nil, MapError(err)
+	}
+
+	resp := &messagev1.ReviewHeldMessageResponse{Held: toProtoHeld(held)}
+	if msg != nil {
+		resp.Message = application.ToProtoMessage(msg)
+	}
+	return resp, nil
+}
+
+func toProtoHeld(h *domain.HeldMessage) *messagev1.HeldMessage {
+	ph := &messagev1.HeldMessage{
+		HeldId:           h.ID,
+		ConversationId:   h.ConversationID,
+		SenderUserId:     h.SenderID,
+		MessageType:      h.Type,
+		Content:          h.Content,
+		MetadataJson:     h.Metadata,
+		ReplyToMessageId: h.ReplyToID,
+		AttachmentIds:    h.AttachmentIDs,
+		Reason:           h.Reason,
+		CreatedAt:        timestamppb.New(h.CreatedAt),
+		Status:           string(h.Status),
+		ReviewedBy:       h.ReviewedBy,
+		ReviewNote:       h.ReviewNote,
+		MessageId:        h.MessageID,
+	}
+	if h.ReviewedAt != nil {
+		ph.ReviewedAt = timestamppb.New(*h.ReviewedAt)
+	}
+	return ph
+}
@@ -22,6 +22,7 @@ func New(app *application.Service) *Server {
 	grpcServer := grpc.NewServer(
 		grpc.StatsHandler(otelgrpc.NewServerHandler()),
 		grpc.UnaryInterceptor(auth.Interceptor),
+		grpc.StreamInterceptor(auth.StreamInterceptor),
 	)
 
 	s := &Server{
@@ -0,0 +1,17 @@
+-- Track the last edit on each message and keep every previous revision.
+
+ALTER TABLE messages
+    ADD COLUMN edited_at TIMESTAMPTZ;
+
+CREATE TABLE message_revisions (
+    message_id  TEXT NOT NULL REFERENCES messages (id) ON DELETE CASCADE,
+    revision    INT  NOT NULL,
+
+    content     TEXT NOT NULL,
+    metadata    JSONB,
+
+    -- when this content was replaced by a newer revision
+    replaced_at TIMESTAMPTZ NOT NULL DEFAULT now(),
+
+    PRIMARY KEY (message_id, revision)
+);
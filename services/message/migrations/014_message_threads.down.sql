@@ -0,0 +1,7 @@
+DROP INDEX IF EXISTS idx_messages_thread_root_seq;
+
+ALTER TABLE messages
+    DROP COLUMN IF EXISTS last_reply_at,
+    DROP COLUMN IF EXISTS reply_count,
+    DROP COLUMN IF EXISTS thread_root_id,
+    DROP COLUMN IF EXISTS reply_to_id;
@@ -0,0 +1,9 @@
+ALTER TABLE messages
+    ADD COLUMN reply_to_id    TEXT,
+    ADD COLUMN thread_root_id TEXT,
+    ADD COLUMN reply_count    BIGINT NOT NULL DEFAULT 0,
+    ADD COLUMN last_reply_at  TIMESTAMPTZ;
+
+CREATE INDEX idx_messages_thread_root_seq
+ON messages(thread_root_id, sequence)
+WHERE thread_root_id IS NOT NULL;
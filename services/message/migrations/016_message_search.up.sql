@@ -0,0 +1,8 @@
+-- 'simple' keeps tokens language-neutral; chats mix languages freely.
+ALTER TABLE messages
+    ADD COLUMN search_tsv tsvector
+    GENERATED ALWAYS AS (to_tsvector('simple', content)) STORED;
+
+CREATE INDEX idx_messages_search_tsv
+ON messages USING GIN (search_tsv)
+WHERE deleted_at IS NULL;
@@ -0,0 +1,12 @@
+CREATE TABLE message_attachments (
+    message_id    TEXT NOT NULL REFERENCES messages (id) ON DELETE CASCADE,
+    attachment_id TEXT NOT NULL,
+    position      INT NOT NULL,
+
+    -- copied from the media service when the message is sent
+    file_name     TEXT NOT NULL,
+    mime_type     TEXT NOT NULL,
+    size_bytes    BIGINT NOT NULL,
+
+    PRIMARY KEY (message_id, attachment_id)
+);
@@ -0,0 +1,9 @@
+CREATE TABLE message_pins (
+    message_id      TEXT PRIMARY KEY REFERENCES messages (id) ON DELETE CASCADE,
+    conversation_id TEXT NOT NULL,
+    pinned_by       TEXT NOT NULL,
+    pinned_at       TIMESTAMPTZ NOT NULL DEFAULT now()
+);
+
+CREATE INDEX idx_message_pins_conversation
+ON message_pins(conversation_id, pinned_at DESC);
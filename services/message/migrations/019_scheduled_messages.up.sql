@@ -0,0 +1,28 @@
+CREATE TABLE scheduled_messages (
+    id              TEXT PRIMARY KEY,
+    conversation_id TEXT NOT NULL,
+    sender_id       TEXT NOT NULL,
+    type            TEXT NOT NULL,
+    content         TEXT NOT NULL,
+    metadata        JSONB,
+    reply_to_id     TEXT,
+    attachment_ids  TEXT[] NOT NULL DEFAULT '{}',
+    send_at         TIMESTAMPTZ NOT NULL,
+
+    -- pending -> sent | cancelled | failed
+    status          TEXT NOT NULL DEFAULT 'pending',
+    message_id      TEXT,
+    failure_reason  TEXT,
+
+    created_at      TIMESTAMPTZ NOT NULL DEFAULT now(),
+    updated_at      TIMESTAMPTZ NOT NULL DEFAULT now()
+);
+
+-- polled by the scheduler worker
+CREATE INDEX idx_scheduled_messages_due
+ON scheduled_messages(send_at)
+WHERE status = 'pending';
+
+CREATE INDEX idx_scheduled_messages_sender
+ON scheduled_messages(sender_id, send_at)
+WHERE status = 'pending';
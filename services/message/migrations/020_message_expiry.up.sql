@@ -0,0 +1,6 @@
+ALTER TABLE messages ADD COLUMN expires_at TIMESTAMPTZ;
+
+-- Drives the reaper; most messages never expire
+CREATE INDEX idx_messages_expires_at
+ON messages(expires_at)
+WHERE expires_at IS NOT NULL;
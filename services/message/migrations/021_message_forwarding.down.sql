@@ -0,0 +1,5 @@
+ALTER TABLE messages
+    DROP COLUMN IF EXISTS forwarded_from_message_id,
+    DROP COLUMN IF EXISTS forwarded_from_conversation_id,
+    DROP COLUMN IF EXISTS forwarded_from_sender_id,
+    DROP COLUMN IF EXISTS forwarded_from_sent_at;
@@ -0,0 +1,6 @@
+-- Provenance of forwarded copies; all NULL on ordinary messages
+ALTER TABLE messages
+    ADD COLUMN forwarded_from_message_id      TEXT,
+    ADD COLUMN forwarded_from_conversation_id TEXT,
+    ADD COLUMN forwarded_from_sender_id       TEXT,
+    ADD COLUMN forwarded_from_sent_at         TIMESTAMPTZ;
@@ -0,0 +1,11 @@
+CREATE TABLE message_mentions (
+    message_id      TEXT NOT NULL REFERENCES messages (id) ON DELETE CASCADE,
+    user_id         TEXT NOT NULL,
+    conversation_id TEXT NOT NULL,
+    sequence        BIGINT NOT NULL,
+    PRIMARY KEY (message_id, user_id)
+);
+
+-- Unread counts scan a user's mentions per conversation past a sequence
+CREATE INDEX idx_message_mentions_user
+ON message_mentions(user_id, conversation_id, sequence);
@@ -0,0 +1,2 @@
+DROP TABLE IF EXISTS message_poll_votes;
+DROP TABLE IF EXISTS message_polls;
@@ -0,0 +1,20 @@
+CREATE TABLE message_polls (
+    message_id      TEXT PRIMARY KEY REFERENCES messages (id) ON DELETE CASCADE,
+    conversation_id TEXT NOT NULL,
+    creator_id      TEXT NOT NULL,
+    options         TEXT[] NOT NULL,
+    multiple_choice BOOLEAN NOT NULL DEFAULT FALSE,
+    anonymous       BOOLEAN NOT NULL DEFAULT FALSE,
+    closes_at       TIMESTAMPTZ,
+    closed_at       TIMESTAMPTZ,
+    closed_by       TEXT
+);
+
+CREATE TABLE message_poll_votes (
+    message_id   TEXT NOT NULL REFERENCES message_polls (message_id) ON DELETE CASCADE,
+    user_id      TEXT NOT NULL,
+    option_index INT NOT NULL,
+    voted_at     TIMESTAMPTZ NOT NULL DEFAULT now(),
+
+    PRIMARY KEY (message_id, user_id, option_index)
+);
@@ -0,0 +1,3 @@
+DROP TABLE IF EXISTS conversation_members;
+DROP TABLE IF EXISTS conversation_projection;
+DROP TABLE IF EXISTS sequence_blocks;
@@ -0,0 +1,27 @@
+-- Sequences reserved from the conversation service, handed out locally.
+-- next_sequence is 0 until the first reservation.
+CREATE TABLE sequence_blocks (
+    conversation_id TEXT PRIMARY KEY,
+    next_sequence   BIGINT NOT NULL,
+    last_sequence   BIGINT NOT NULL
+);
+
+-- Local copy of conversations and their members, fed by conversation events
+-- and backfilled from the conversation service on a miss.
+CREATE TABLE conversation_projection (
+    conversation_id     TEXT PRIMARY KEY,
+    is_group            BOOLEAN NOT NULL,
+    message_ttl_seconds BIGINT NOT NULL DEFAULT 0,
+    updated_at          TIMESTAMPTZ NOT NULL DEFAULT now()
+);
+
+-- Removed members are kept with removed_at set, so a stale backfill cannot
+-- bring them back.
+CREATE TABLE conversation_members (
+    conversation_id TEXT NOT NULL,
+    user_id         TEXT NOT NULL,
+    is_admin        BOOLEAN NOT NULL DEFAULT FALSE,
+    removed_at      TIMESTAMPTZ,
+
+    PRIMARY KEY (conversation_id, user_id)
+);
@@ -0,0 +1,85 @@
+DROP TRIGGER IF EXISTS messages_delete_children ON messages;
+DROP FUNCTION IF EXISTS delete_message_children();
+
+ALTER TABLE messages RENAME TO messages_partitioned;
+
+CREATE TABLE messages (
+    id              TEXT PRIMARY KEY,
+    conversation_id TEXT NOT NULL,
+
+    sender_id       TEXT NOT NULL,
+    sequence        BIGINT NOT NULL,
+
+    type            TEXT NOT NULL DEFAULT 'text',
+    content         TEXT NOT NULL,
+    metadata        JSONB,
+    deleted_at      TIMESTAMPTZ,
+    sent_at         TIMESTAMPTZ NOT NULL DEFAULT now(),
+    edited_at       TIMESTAMPTZ,
+
+    reply_to_id     TEXT,
+    thread_root_id  TEXT,
+    reply_count     BIGINT NOT NULL DEFAULT 0,
+    last_reply_at   TIMESTAMPTZ,
+
+    search_tsv      tsvector
+        GENERATED ALWAYS AS (to_tsvector('simple', content)) STORED,
+
+    expires_at      TIMESTAMPTZ,
+
+    forwarded_from_message_id      TEXT,
+    forwarded_from_conversation_id TEXT,
+    forwarded_from_sender_id       TEXT,
+    forwarded_from_sent_at         TIMESTAMPTZ,
+
+    UNIQUE (conversation_id, sequence)
+);
+
+INSERT INTO messages (
+    id, conversation_id, sender_id, sequence,
+    type, content, metadata, deleted_at, sent_at, edited_at,
+    reply_to_id, thread_root_id, reply_count, last_reply_at, expires_at,
+    forwarded_from_message_id, forwarded_from_conversation_id,
+    forwarded_from_sender_id, forwarded_from_sent_at
+)
+SELECT
+    id, conversation_id, sender_id, sequence,
+    type, content, metadata, deleted_at, sent_at, edited_at,
+    reply_to_id, thread_root_id, reply_count, last_reply_at, expires_at,
+    forwarded_from_message_id, forwarded_from_conversation_id,
+    forwarded_from_sender_id, forwarded_from_sent_at
+FROM messages_partitioned;
+
+-- Drops every partition along with the parent and its indexes
+DROP TABLE messages_partitioned;
+
+CREATE INDEX idx_messages_conv_seq_desc
+ON messages(conversation_id, sequence DESC);
+
+CREATE INDEX idx_messages_conv_sent_at_desc
+ON messages(conversation_id, sent_at DESC);
+
+CREATE INDEX idx_messages_thread_root_seq
+ON messages(thread_root_id, sequence)
+WHERE thread_root_id IS NOT NULL;
+
+CREATE INDEX idx_messages_search_tsv
+ON messages USING GIN (search_tsv)
+WHERE deleted_at IS NULL;
+
+CREATE INDEX idx_messages_expires_at
+ON messages(expires_at)
+WHERE expires_at IS NOT NULL;
+
+ALTER TABLE message_revisions
+    ADD FOREIGN KEY (message_id) REFERENCES messages (id) ON DELETE CASCADE;
+ALTER TABLE message_reactions
+    ADD FOREIGN KEY (message_id) REFERENCES messages (id) ON DELETE CASCADE;
+ALTER TABLE message_attachments
+    ADD FOREIGN KEY (message_id) REFERENCES messages (id) ON DELETE CASCADE;
+ALTER TABLE message_pins
+    ADD FOREIGN KEY (message_id) REFERENCES messages (id) ON DELETE CASCADE;
+ALTER TABLE message_mentions
+    ADD FOREIGN KEY (message_id) REFERENCES messages (id) ON DELETE CASCADE;
+ALTER TABLE message_polls
+    ADD FOREIGN KEY (message_id) REFERENCES messages (id) ON DELETE CASCADE;
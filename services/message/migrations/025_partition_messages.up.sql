@@ -0,0 +1,118 @@
+-- Partition messages by month of sent_at, so expired history can be archived
+-- and dropped a partition at a time.
+--
+-- A partitioned table can only enforce uniqueness on keys that include
+-- sent_at, so the primary key becomes (id, sent_at) and UNIQUE
+-- (conversation_id, sequence) goes away; sequences are unique because each
+-- is handed out once from sequence_blocks. Foreign keys to messages go away
+-- for the same reason and the cascades become a trigger.
+
+ALTER TABLE messages RENAME TO messages_unpartitioned;
+
+CREATE TABLE messages (
+    id              TEXT NOT NULL,
+    conversation_id TEXT NOT NULL,
+
+    sender_id       TEXT NOT NULL,
+    sequence        BIGINT NOT NULL,
+
+    type            TEXT NOT NULL DEFAULT 'text',
+    content         TEXT NOT NULL,
+    metadata        JSONB,
+    deleted_at      TIMESTAMPTZ,
+    sent_at         TIMESTAMPTZ NOT NULL DEFAULT now(),
+    edited_at       TIMESTAMPTZ,
+
+    reply_to_id     TEXT,
+    thread_root_id  TEXT,
+    reply_count     BIGINT NOT NULL DEFAULT 0,
+    last_reply_at   TIMESTAMPTZ,
+
+    search_tsv      tsvector
+        GENERATED ALWAYS AS (to_tsvector('simple', content)) STORED,
+
+    expires_at      TIMESTAMPTZ,
+
+    forwarded_from_message_id      TEXT,
+    forwarded_from_conversation_id TEXT,
+    forwarded_from_sender_id       TEXT,
+    forwarded_from_sent_at         TIMESTAMPTZ
+) PARTITION BY RANGE (sent_at);
+
+-- Rows outside every monthly partition, e.g. imports of very old history
+CREATE TABLE messages_default PARTITION OF messages DEFAULT;
+
+-- Monthly partitions (UTC) from the oldest message to two months ahead; the
+-- service creates later ones as time goes on.
+DO $$
+DECLARE
+    m DATE := date_trunc('month', COALESCE(
+        (SELECT min(sent_at) FROM messages_unpartitioned), now()
+    ) AT TIME ZONE 'UTC');
+    until DATE := date_trunc('month', now() AT TIME ZONE 'UTC') + interval '2 months';
+BEGIN
+    WHILE m <= until LOOP
+        EXECUTE format(
+            'CREATE TABLE %I PARTITION OF messages FOR VALUES FROM (%L) TO (%L)',
+            'messages_p' || to_char(m, 'YYYYMM'),
+            to_char(m, 'YYYY-MM-DD') || ' 00:00:00+00',
+            to_char(m + interval '1 month', 'YYYY-MM-DD') || ' 00:00:00+00'
+        );
+        m := m + interval '1 month';
+    END LOOP;
+END $$;
+
+INSERT INTO messages (
+    id, conversation_id, sender_id, sequence,
+    type, content, metadata, deleted_at, sent_at, edited_at,
+    reply_to_id, thread_root_id, reply_count, last_reply_at, expires_at,
+    forwarded_from_message_id, forwarded_from_conversation_id,
+    forwarded_from_sender_id, forwarded_from_sent_at
+)
+SELECT
+    id, conversation_id, sender_id, sequence,
+    type, content, metadata, deleted_at, sent_at, edited_at,
+    reply_to_id, thread_root_id, reply_count, last_reply_at, expires_at,
+    forwarded_from_message_id, forwarded_from_conversation_id,
+    forwarded_from_sender_id, forwarded_from_sent_at
+FROM messages_unpartitioned;
+
+-- Also drops the foreign keys from the child tables
+DROP TABLE messages_unpartitioned CASCADE;
+
+ALTER TABLE messages ADD PRIMARY KEY (id, sent_at);
+
+CREATE INDEX idx_messages_conv_seq_desc
+ON messages(conversation_id, sequence DESC);
+
+CREATE INDEX idx_messages_conv_sent_at_desc
+ON messages(conversation_id, sent_at DESC);
+
+CREATE INDEX idx_messages_thread_root_seq
+ON messages(thread_root_id, sequence)
+WHERE thread_root_id IS NOT NULL;
+
+CREATE INDEX idx_messages_search_tsv
+ON messages USING GIN (search_tsv)
+WHERE deleted_at IS NULL;
+
+CREATE INDEX idx_messages_expires_at
+ON messages(expires_at)
+WHERE expires_at IS NOT NULL;
+
+-- Replaces ON DELETE CASCADE from the child tables
+CREATE FUNCTION delete_message_children() RETURNS trigger AS $$
+BEGIN
+    DELETE FROM message_revisions WHERE message_id = OLD.id;
+    DELETE FROM message_reactions WHERE message_id = OLD.id;
+    DELETE FROM message_attachments WHERE message_id = OLD.id;
+    DELETE FROM message_pins WHERE message_id = OLD.id;
+    DELETE FROM message_mentions WHERE message_id = OLD.id;
+    DELETE FROM message_polls WHERE message_id = OLD.id;
+    RETURN NULL;
+END
+$$ LANGUAGE plpgsql;
+
+CREATE TRIGGER messages_delete_children
+AFTER DELETE ON messages
+FOR EACH ROW EXECUTE FUNCTION delete_message_children();
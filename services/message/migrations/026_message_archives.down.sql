@@ -0,0 +1,2 @@
+DROP TABLE IF EXISTS message_archives;
+DROP TABLE IF EXISTS conversation_retention;
@@ -0,0 +1,27 @@
+-- Per-conversation override of the service-wide retention. 0 keeps
+-- messages forever.
+CREATE TABLE conversation_retention (
+    conversation_id   TEXT PRIMARY KEY,
+    retention_seconds BIGINT NOT NULL,
+    updated_by        TEXT NOT NULL,
+    updated_at        TIMESTAMPTZ NOT NULL DEFAULT now()
+);
+
+-- One archived month of a conversation. Restored archives are back in
+-- messages until restored_until, after which they are archived again.
+CREATE TABLE message_archives (
+    conversation_id TEXT NOT NULL,
+    period_start    TIMESTAMPTZ NOT NULL,
+    period_end      TIMESTAMPTZ NOT NULL,
+
+    object_key      TEXT NOT NULL,
+    message_count   BIGINT NOT NULL,
+    first_sequence  BIGINT NOT NULL,
+    last_sequence   BIGINT NOT NULL,
+
+    archived_at     TIMESTAMPTZ NOT NULL DEFAULT now(),
+    restored_at     TIMESTAMPTZ,
+    restored_until  TIMESTAMPTZ,
+
+    PRIMARY KEY (conversation_id, period_start)
+);
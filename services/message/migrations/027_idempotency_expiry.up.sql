@@ -0,0 +1,3 @@
+-- Drives the idempotency janitor
+CREATE INDEX idx_idempotency_expires_at
+ON idempotency_keys(expires_at);
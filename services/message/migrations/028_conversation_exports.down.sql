@@ -0,0 +1,2 @@
+DROP TABLE IF EXISTS conversation_exports;
+ALTER TABLE conversation_members DROP COLUMN IF EXISTS joined_at;
@@ -0,0 +1,30 @@
+-- When a member joined, from the membership event. NULL when unknown, e.g.
+-- members who came with the conversation or from a backfill, who see the
+-- whole history.
+ALTER TABLE conversation_members ADD COLUMN joined_at TIMESTAMPTZ;
+
+-- Export jobs. A job is pending until a worker claims it, running while the
+-- archive is built, and done or failed after. Running jobs whose worker
+-- went away are claimed again once started_at is stale.
+CREATE TABLE conversation_exports (
+    id              TEXT PRIMARY KEY,
+    conversation_id TEXT NOT NULL,
+    user_id         TEXT NOT NULL,
+
+    status          TEXT NOT NULL DEFAULT 'pending',
+    object_key      TEXT,
+    message_count   BIGINT NOT NULL DEFAULT 0,
+    size_bytes      BIGINT NOT NULL DEFAULT 0,
+    failure_reason  TEXT,
+
+    created_at      TIMESTAMPTZ NOT NULL DEFAULT now(),
+    started_at      TIMESTAMPTZ,
+    completed_at    TIMESTAMPTZ
+);
+
+CREATE INDEX idx_conversation_exports_open
+ON conversation_exports(created_at)
+WHERE status IN ('pending', 'running');
+
+CREATE INDEX idx_conversation_exports_user_conv
+ON conversation_exports(user_id, conversation_id, created_at DESC);
@@ -0,0 +1,38 @@
+-- History imports from other chat apps. A job is pending until a worker
+-- claims it and running while batches are written; imported_messages is the
+-- number of parsed messages written so far, so a job picked up again
+-- resumes there. Running jobs that stopped making progress are claimed
+-- again once updated_at is stale.
+CREATE TABLE message_imports (
+    id                TEXT PRIMARY KEY,
+    conversation_id   TEXT NOT NULL,
+    source            TEXT NOT NULL,
+    name              TEXT NOT NULL,
+    created_by        TEXT NOT NULL,
+
+    -- Export to read. Set for imports requested through the API; the admin
+    -- command reads a local file instead.
+    attachment_id     TEXT,
+    -- Export sender -> RealChat user ID
+    mapping           JSONB NOT NULL,
+    channel           TEXT,
+    time_zone         TEXT,
+    day_first         BOOLEAN NOT NULL DEFAULT false,
+
+    status            TEXT NOT NULL DEFAULT 'pending',
+    total_messages    BIGINT NOT NULL DEFAULT 0,
+    imported_messages BIGINT NOT NULL DEFAULT 0,
+    failure_reason    TEXT,
+
+    created_at        TIMESTAMPTZ NOT NULL DEFAULT now(),
+    updated_at        TIMESTAMPTZ NOT NULL DEFAULT now(),
+    started_at        TIMESTAMPTZ,
+    completed_at      TIMESTAMPTZ
+);
+
+CREATE INDEX idx_message_imports_open
+ON message_imports(created_at)
+WHERE status IN ('pending', 'running');
+
+CREATE INDEX idx_message_imports_created_by
+ON message_imports(created_by, created_at DESC);
@@ -0,0 +1,10 @@
+-- One draft per user and conversation. Cleared drafts keep their row with
+-- empty text, so an older save arriving late still loses to the clear.
+CREATE TABLE drafts (
+    user_id         TEXT NOT NULL,
+    conversation_id TEXT NOT NULL,
+    text            TEXT NOT NULL DEFAULT '',
+    device_id       TEXT NOT NULL DEFAULT '',
+    updated_at      TIMESTAMPTZ NOT NULL,
+    PRIMARY KEY (user_id, conversation_id)
+);
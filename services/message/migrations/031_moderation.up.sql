@@ -0,0 +1,51 @@
+-- Sends held back by the moderation chain until a moderator reviews them.
+-- A retried send with the same idempotency key finds the row it already
+-- held instead of holding a second one.
+CREATE TABLE moderation_queue (
+    id              TEXT PRIMARY KEY,
+    conversation_id TEXT NOT NULL,
+    sender_id       TEXT NOT NULL,
+    client_msg_id   TEXT NOT NULL,
+    type            TEXT NOT NULL,
+    content         TEXT NOT NULL,
+    metadata        JSONB,
+    reply_to_id     TEXT,
+    attachment_ids  TEXT[] NOT NULL DEFAULT '{}',
+    reason          TEXT NOT NULL DEFAULT '',
+    created_at      TIMESTAMPTZ NOT NULL DEFAULT now(),
+
+    -- pending -> approved | rejected
+    status          TEXT NOT NULL DEFAULT 'pending',
+    reviewed_by     TEXT,
+    review_note     TEXT,
+    reviewed_at     TIMESTAMPTZ,
+    -- set once an approved message has been sent
+    message_id      TEXT,
+
+    UNIQUE (sender_id, conversation_id, client_msg_id)
+);
+
+CREATE INDEX idx_moderation_queue_status
+ON moderation_queue(status, created_at, id);
+
+-- Audit trail of every moderation decision: one row per screened send and
+-- one per review. verdicts holds each filter's decision.
+CREATE TABLE moderation_audit (
+    id              TEXT PRIMARY KEY,
+    conversation_id TEXT NOT NULL,
+    sender_id       TEXT NOT NULL,
+    message_id      TEXT,
+    held_id         TEXT,
+    action          TEXT NOT NULL,
+    reason          TEXT NOT NULL DEFAULT '',
+    verdicts        JSONB NOT NULL DEFAULT '[]',
+    reviewer_id     TEXT,
+    created_at      TIMESTAMPTZ NOT NULL DEFAULT now()
+);
+
+CREATE INDEX idx_moderation_audit_conversation
+ON moderation_audit(conversation_id, created_at);
+
+CREATE INDEX idx_moderation_audit_held
+ON moderation_audit(held_id)
+WHERE held_id IS NOT NULL;
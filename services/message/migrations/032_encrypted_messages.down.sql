@@ -0,0 +1,15 @@
+CREATE OR REPLACE FUNCTION delete_message_children() RETURNS trigger AS $$
+BEGIN
+    DELETE FROM message_revisions WHERE message_id = OLD.id;
+    DELETE FROM message_reactions WHERE message_id = OLD.id;
+    DELETE FROM message_attachments WHERE message_id = OLD.id;
+    DELETE FROM message_pins WHERE message_id = OLD.id;
+    DELETE FROM message_mentions WHERE message_id = OLD.id;
+    DELETE FROM message_polls WHERE message_id = OLD.id;
+    RETURN NULL;
+END
+$$ LANGUAGE plpgsql;
+
+DROP TABLE IF EXISTS message_ciphertexts;
+
+ALTER TABLE messages DROP COLUMN IF EXISTS sender_device_id;
@@ -0,0 +1,25 @@
+-- The device that sent an encrypted message
+ALTER TABLE messages ADD COLUMN sender_device_id TEXT;
+
+-- Per-device content of encrypted messages, opaque to the service
+CREATE TABLE message_ciphertexts (
+    message_id TEXT NOT NULL,
+    user_id    TEXT NOT NULL,
+    device_id  TEXT NOT NULL,
+    ciphertext BYTEA NOT NULL,
+
+    PRIMARY KEY (message_id, user_id, device_id)
+);
+
+CREATE OR REPLACE FUNCTION delete_message_children() RETURNS trigger AS $$
+BEGIN
+    DELETE FROM message_revisions WHERE message_id = OLD.id;
+    DELETE FROM message_reactions WHERE message_id = OLD.id;
+    DELETE FROM message_attachments WHERE message_id = OLD.id;
+    DELETE FROM message_pins WHERE message_id = OLD.id;
+    DELETE FROM message_mentions WHERE message_id = OLD.id;
+    DELETE FROM message_polls WHERE message_id = OLD.id;
+    DELETE FROM message_ciphertexts WHERE message_id = OLD.id;
+    RETURN NULL;
+END
+$$ LANGUAGE plpgsql;
@@ -0,0 +1,4 @@
+-- The last sequence handed out in the conversation when a member joined.
+-- Messages written later, such as imported history, are theirs to export
+-- even when their sent_at predates joined_at. NULL when joined_at is.
+ALTER TABLE conversation_members ADD COLUMN joined_sequence BIGINT;
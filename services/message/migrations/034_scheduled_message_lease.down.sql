@@ -0,0 +1,5 @@
+UPDATE scheduled_messages SET status = 'pending' WHERE status = 'sending';
+
+DROP INDEX IF EXISTS idx_scheduled_messages_claimed;
+
+ALTER TABLE scheduled_messages DROP COLUMN IF EXISTS claimed_at;
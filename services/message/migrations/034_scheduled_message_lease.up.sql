@@ -0,0 +1,9 @@
+-- pending -> sending -> sent | failed, or back to pending on a transient
+-- error. A worker marks a row sending when it claims it; claimed_at is its
+-- lease, after which another worker may claim the row again.
+ALTER TABLE scheduled_messages ADD COLUMN claimed_at TIMESTAMPTZ;
+
+-- polled by the scheduler worker for abandoned claims
+CREATE INDEX idx_scheduled_messages_claimed
+ON scheduled_messages(claimed_at)
+WHERE status = 'sending';
@@ -0,0 +1,48 @@
+-- Put UNIQUE (conversation_id, sequence) back, which 025 had to drop from
+-- the partitioned messages table. Every message claims its sequence in
+-- message_sequences from a trigger, in the statement that inserts it, so a
+-- sequence handed out twice fails that transaction instead of landing as a
+-- second message. Deleting the message, when it is reaped or archived,
+-- releases the sequence so a restored archive can claim it again.
+--
+-- The backfill fails if the table already holds duplicates; they have to be
+-- cleaned up by hand before this migration can run.
+
+CREATE TABLE message_sequences (
+    conversation_id TEXT NOT NULL,
+    sequence BIGINT NOT NULL,
+    message_id TEXT NOT NULL,
+    PRIMARY KEY (conversation_id, sequence)
+);
+
+INSERT INTO message_sequences (conversation_id, sequence, message_id)
+SELECT conversation_id, sequence, id FROM messages;
+
+CREATE OR REPLACE FUNCTION claim_message_sequence() RETURNS trigger AS $$
+BEGIN
+    INSERT INTO message_sequences (conversation_id, sequence, message_id)
+    VALUES (NEW.conversation_id, NEW.sequence, NEW.id);
+    RETURN NULL;
+END
+$$ LANGUAGE plpgsql;
+
+CREATE TRIGGER messages_claim_sequence
+AFTER INSERT ON messages
+FOR EACH ROW EXECUTE FUNCTION claim_message_sequence();
+
+CREATE OR REPLACE FUNCTION delete_message_children() RETURNS trigger AS $$
+BEGIN
+    DELETE FROM message_revisions WHERE message_id = OLD.id;
+    DELETE FROM message_reactions WHERE message_id = OLD.id;
+    DELETE FROM message_attachments WHERE message_id = OLD.id;
+    DELETE FROM message_pins WHERE message_id = OLD.id;
+    DELETE FROM message_mentions WHERE message_id = OLD.id;
+    DELETE FROM message_polls WHERE message_id = OLD.id;
+    DELETE FROM message_ciphertexts WHERE message_id = OLD.id;
+    DELETE FROM message_sequences
+    WHERE conversation_id = OLD.conversation_id
+      AND sequence = OLD.sequence
+      AND message_id = OLD.id;
+    RETURN NULL;
+END
+$$ LANGUAGE plpgsql;
@@ -0,0 +1,143 @@
+package main
+
+import (
+	"context"
+	"database/sql"
+	"net/http"
+	"os"
+	"os/signal"
+	"syscall"
+	"time"
+
+	"github.com/go-chi/chi/v5"
+	_ "github.com/lib/pq"
+	"github.com/prometheus/client_golang/prometheus/promhttp"
+	"go.uber.org/zap"
+
+	conversationv1 "github.com/SARVESHVARADKAR123/RealChat/contracts/gen/go/conversation/v1"
+	"github.com/SARVESHVARADKAR123/RealChat/services/webhook/internal/application"
+	"github.com/SARVESHVARADKAR123/RealChat/services/webhook/internal/auth"
+	"github.com/SARVESHVARADKAR123/RealChat/services/webhook/internal/config"
+	"github.com/SARVESHVARADKAR123/RealChat/services/webhook/internal/dispatcher"
+	"github.com/SARVESHVARADKAR123/RealChat/services/webhook/internal/domain"
+	"github.com/SARVESHVARADKAR123/RealChat/services/webhook/internal/events"
+	"github.com/SARVESHVARADKAR123/RealChat/services/webhook/internal/kafka"
+	"github.com/SARVESHVARADKAR123/RealChat/services/webhook/internal/observability"
+	"github.com/SARVESHVARADKAR123/RealChat/services/webhook/internal/repository/postgres"
+	grpc_transport "github.com/SARVESHVARADKAR123/RealChat/services/webhook/internal/transport/grpc"
+	"github.com/SARVESHVARADKAR123/RealChat/services/webhook/internal/tx"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials/insecure"
+)
+
+func main() {
+	cfg := config.Load()
+
+	// Observability
+	observability.InitLogger(cfg.ServiceName)
+	log := observability.Log
+
+	if cfg.TracingEnabled {
+		tp, err := observability.InitTracer(cfg.ServiceName, cfg.JaegerURL)
+		if err != nil {
+			log.Fatal("failed to initialize tracer", zap.Error(err))
+		}
+		defer func() {
+			if err := tp.Shutdown(context.Background()); err != nil {
+				log.Error("failed to shutdown tracer provider", zap.Error(err))
+			}
+		}()
+	}
+
+	db, err := sql.Open("postgres", cfg.DatabaseURL)
+	if err != nil {
+		log.Fatal("db open failed", zap.Error(err))
+	}
+	defer db.Close()
+
+	// HTTP Server for Observability (Metrics & Health)
+	mux := chi.NewRouter()
+	mux.Use(observability.MetricsMiddleware(cfg.ServiceName))
+	mux.Handle("/metrics", promhttp.Handler())
+	mux.Get("/health/live", observability.HealthLiveHandler)
+	mux.Get("/health/ready", observability.HealthReadyHandler(db))
+
+	obsSrv := &http.Server{Addr: cfg.ObsHTTPAddr, Handler: mux}
+
+	go func() {
+		log.Info("HTTP observability server started", zap.String("addr", cfg.ObsHTTPAddr))
+		if err := obsSrv.ListenAndServe(); err != nil && err != http.ErrServerClosed {
+			log.Error("HTTP observability server failed", zap.Error(err))
+		}
+	}()
+
+	// gRPC Client to Conversation Service
+	conn, err := grpc.Dial(
+		cfg.ConversationSvcAddr,
+		grpc.WithTransportCredentials(insecure.NewCredentials()),
+		grpc.WithUnaryInterceptor(auth.ClientInterceptor),
+	)
+	if err != nil {
+		log.Fatal("failed to connect to conversation service", zap.Error(err))
+	}
+	defer conn.Close()
+	convSvcClient := conversationv1.NewConversationApiClient(conn)
+
+	repo := &postgres.Repository{DB: db}
+	txMgr := &tx.Manager{DB: db}
+	app := application.New(repo, txMgr, convSvcClient, log, application.Options{
+		Retry: domain.RetryPolicy{
+			MaxAttempts:  cfg.MaxAttempts,
+			BaseDelay:    cfg.RetryBase,
+			MaxDelay:     cfg.RetryMax,
+			DisableAfter: cfg.DisableAfter,
+		},
+		Client: application.NewHTTPClient(cfg.Timeout, cfg.AllowPrivateURLs),
+		Lease:  2*cfg.Timeout + time.Minute,
+	})
+
+	// The events the delivery service reads become webhook deliveries
+	consumer, err := kafka.NewConsumer(
+		cfg.KafkaBrokers,
+		cfg.KafkaGroupID,
+		cfg.KafkaTopics,
+		&events.Handler{Queuer: app},
+	)
+	if err != nil {
+		log.Fatal("kafka consumer failed", zap.Error(err))
+	}
+	defer consumer.Close()
+
+	worker := &dispatcher.Worker{
+		Deliverer: app,
+		BatchSize: 50,
+		PollDelay: time.Second,
+	}
+
+	// Cancellable context for background workers
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	go consumer.Start(ctx)
+	go worker.Start(ctx)
+
+	// gRPC Server
+	server := grpc_transport.New(app)
+	go server.Start(cfg.GRPCAddr)
+
+	// Shutdown
+	stop := make(chan os.Signal, 1)
+	signal.Notify(stop, os.Interrupt, syscall.SIGTERM)
+	<-stop
+
+	log.Info("shutting down...")
+	cancel()
+
+	ctxShut, obsCancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer obsCancel()
+	_ = obsSrv.Shutdown(ctxShut)
+
+	server.Stop()
+
+	log.Info("shutdown complete")
+}
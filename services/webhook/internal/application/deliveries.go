@@ -0,0 +1,182 @@
+package application
+
+import (
+	"bytes"
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"time"
+
+	"github.com/SARVESHVARADKAR123/RealChat/services/webhook/internal/domain"
+	"github.com/SARVESHVARADKAR123/RealChat/services/webhook/internal/observability"
+	"github.com/google/uuid"
+	"go.uber.org/zap"
+)
+
+// Headers sent with every delivery.
+const (
+	HeaderEvent     = "X-RealChat-Event"
+	HeaderDelivery  = "X-RealChat-Delivery"
+	HeaderWebhook   = "X-RealChat-Webhook"
+	HeaderSignature = "X-RealChat-Signature"
+)
+
+// QueueEvent queues a delivery of ev for each enabled webhook of its
+// conversation and returns how many were queued. Queueing an event again
+// queues nothing new.
+func (s *Service) QueueEvent(ctx context.Context, ev *domain.Event) (int, error) {
+	hooks, err := s.repo.ListWebhooks(ctx, nil, ev.ConversationID)
+	if err != nil {
+		return 0, fmt.Errorf("failed to list webhooks: %w", err)
+	}
+
+	now := time.Now().UTC()
+	var ds []*domain.Delivery
+	for _, w := range hooks {
+		if !w.Enabled() {
+			continue
+		}
+		ds = append(ds, &domain.Delivery{
+			ID:            uuid.NewString(),
+			WebhookID:     w.ID,
+			EventID:       ev.ID,
+			EventType:     ev.Type,
+			Payload:       ev.Payload,
+			NextAttemptAt: now,
+			CreatedAt:     now,
+		})
+	}
+
+	if err := s.repo.InsertDeliveries(ctx, nil, ds); err != nil {
+		return 0, fmt.Errorf("failed to queue deliveries: %w", err)
+	}
+	return len(ds), nil
+}
+
+// ClaimDeliveries leases up to limit due deliveries to the caller.
+func (s *Service) ClaimDeliveries(ctx context.Context, now time.Time, limit int) ([]*domain.Delivery, error) {
+	return s.repo.ClaimDeliveries(ctx, now, now.Add(s.opts.Lease), limit)
+}
+
+// Deliver POSTs a claimed delivery to its webhook and records the outcome:
+// a delivered event is dropped from the queue, a failed one is retried
+// with backoff until it runs out of attempts and is dead-lettered. A
+// webhook whose deliveries keep being dead-lettered is disabled.
+func (s *Service) Deliver(ctx context.Context, d *domain.Delivery) error {
+	w, err := s.repo.GetWebhook(ctx, nil, d.WebhookID)
+	if errors.Is(err, domain.ErrWebhookNotFound) {
+		// Deleted since the claim, along with the delivery
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+	if !w.Enabled() {
+		// Its pending deliveries were dead-lettered when it was disabled
+		return nil
+	}
+
+	start := time.Now()
+	postErr := s.post(ctx, w, d)
+	observability.WebhookDeliveryDuration.Observe(time.Since(start).Seconds())
+
+	if postErr == nil {
+		return s.delivered(ctx, w, d)
+	}
+	return s.failed(ctx, d, postErr.Error())
+}
+
+func (s *Service) post(ctx context.Context, w *domain.Webhook, d *domain.Delivery) error {
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, w.URL, bytes.NewReader(d.Payload))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("User-Agent", "RealChat-Webhooks/1.0")
+	req.Header.Set(HeaderEvent, d.EventType)
+	req.Header.Set(HeaderDelivery, d.ID)
+	req.Header.Set(HeaderWebhook, w.ID)
+	req.Header.Set(HeaderSignature, domain.Sign(w.Secret, time.Now(), d.Payload))
+
+	resp, err := s.opts.Client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	// Drained so the connection can be reused
+	_, _ = io.Copy(io.Discard, io.LimitReader(resp.Body, 64<<10))
+
+	if resp.StatusCode < 200 || resp.StatusCode > 299 {
+		return fmt.Errorf("webhook answered %s", resp.Status)
+	}
+	return nil
+}
+
+func (s *Service) delivered(ctx context.Context, w *domain.Webhook, d *domain.Delivery) error {
+	if err := s.repo.DeleteDelivery(ctx, nil, d.ID); err != nil {
+		return fmt.Errorf("failed to drop delivered event: %w", err)
+	}
+	observability.WebhookDeliveriesTotal.WithLabelValues("delivered").Inc()
+
+	if w.ConsecutiveFailures == 0 {
+		return nil
+	}
+	return s.tx.WithTx(ctx, func(ctx context.Context, tx *sql.Tx) error {
+		cur, err := s.repo.GetWebhookForUpdate(ctx, tx, w.ID)
+		if err != nil {
+			return err
+		}
+		cur.ConsecutiveFailures = 0
+		return s.repo.UpdateWebhook(ctx, tx, cur)
+	})
+}
+
+func (s *Service) failed(ctx context.Context, d *domain.Delivery, reason string) error {
+	now := time.Now().UTC()
+	policy := s.opts.Retry
+
+	if !d.Fail(reason, now, policy) {
+		observability.WebhookDeliveriesTotal.WithLabelValues("retry").Inc()
+		s.log.Debug("webhook delivery failed, retrying",
+			zap.String("delivery_id", d.ID),
+			zap.Int("attempts", d.Attempts),
+			zap.Time("next_attempt_at", d.NextAttemptAt),
+			zap.String("error", reason),
+		)
+		return s.repo.UpdateDelivery(ctx, nil, d)
+	}
+
+	observability.WebhookDeliveriesTotal.WithLabelValues("dead_lettered").Inc()
+	return s.tx.WithTx(ctx, func(ctx context.Context, tx *sql.Tx) error {
+		w, err := s.repo.GetWebhookForUpdate(ctx, tx, d.WebhookID)
+		if errors.Is(err, domain.ErrWebhookNotFound) {
+			return nil
+		}
+		if err != nil {
+			return err
+		}
+		if err := s.repo.DeadLetterDelivery(ctx, tx, d, now); err != nil {
+			return fmt.Errorf("failed to dead-letter delivery: %w", err)
+		}
+
+		w.ConsecutiveFailures++
+		if w.Enabled() && policy.DisableAfter > 0 && w.ConsecutiveFailures >= policy.DisableAfter {
+			w.Disable(fmt.Sprintf("%d deliveries failed in a row, the last with: %s", w.ConsecutiveFailures, reason), now)
+			n, err := s.repo.DeadLetterPending(ctx, tx, w.ID, "webhook disabled", now)
+			if err != nil {
+				return fmt.Errorf("failed to dead-letter pending deliveries: %w", err)
+			}
+			observability.WebhooksDisabledTotal.Inc()
+			s.log.Warn("webhook disabled",
+				zap.String("webhook_id", w.ID),
+				zap.String("conversation_id", w.ConversationID),
+				zap.Int("dead_lettered", n+1),
+				zap.String("reason", w.DisabledReason),
+			)
+		}
+		return s.repo.UpdateWebhook(ctx, tx, w)
+	})
+}
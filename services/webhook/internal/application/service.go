@@ -0,0 +1,49 @@
+package application
+
+import (
+	"net/http"
+	"time"
+
+	conversationv1 "github.com/SARVESHVARADKAR123/RealChat/contracts/gen/go/conversation/v1"
+	"github.com/SARVESHVARADKAR123/RealChat/services/webhook/internal/domain"
+	"github.com/SARVESHVARADKAR123/RealChat/services/webhook/internal/repository"
+	"github.com/SARVESHVARADKAR123/RealChat/services/webhook/internal/tx"
+	"go.uber.org/zap"
+)
+
+type Options struct {
+	Retry domain.RetryPolicy
+	// Client posts deliveries; see NewHTTPClient.
+	Client *http.Client
+	// Lease is how long a claimed delivery is left to its worker before it
+	// may be claimed again. It must outlast Client's timeout.
+	Lease time.Duration
+}
+
+type Service struct {
+	repo    repository.Repository
+	tx      tx.Transactor
+	convSvc conversationv1.ConversationApiClient
+	log     *zap.Logger
+	opts    Options
+}
+
+func New(
+	repo repository.Repository,
+	transactor tx.Transactor,
+	convSvc conversationv1.ConversationApiClient,
+	log *zap.Logger,
+	opts Options,
+) *Service {
+
+	if opts.Retry.MaxAttempts <= 0 {
+		opts.Retry = domain.DefaultRetryPolicy()
+	}
+	if opts.Client == nil {
+		opts.Client = NewHTTPClient(10*time.Second, false)
+	}
+	if opts.Lease <= 0 {
+		opts.Lease = 2 * time.Minute
+	}
+	return &Service{repo: repo, tx: transactor, convSvc: convSvc, log: log, opts: opts}
+}
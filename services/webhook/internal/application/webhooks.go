@@ -0,0 +1,226 @@
+package application
+
+import (
+	"context"
+	"database/sql"
+	"encoding/base64"
+	"fmt"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+
+	conversationv1 "github.com/SARVESHVARADKAR123/RealChat/contracts/gen/go/conversation/v1"
+	"github.com/SARVESHVARADKAR123/RealChat/services/webhook/internal/domain"
+	"github.com/google/uuid"
+	"go.uber.org/zap"
+)
+
+type CreateWebhookCommand struct {
+	ConversationID string
+	UserID         string
+	URL            string
+}
+
+// CreateWebhook registers a webhook for a conversation. The returned
+// webhook carries its secret, which is not shown again.
+func (s *Service) CreateWebhook(ctx context.Context, cmd CreateWebhookCommand) (*domain.Webhook, error) {
+	if cmd.ConversationID == "" || cmd.UserID == "" {
+		return nil, domain.ErrInvalidInput
+	}
+	if err := domain.ValidateURL(cmd.URL); err != nil {
+		return nil, err
+	}
+	if err := s.requireAdmin(ctx, cmd.ConversationID, cmd.UserID); err != nil {
+		return nil, err
+	}
+
+	w, err := domain.NewWebhook(uuid.NewString(), cmd.ConversationID, cmd.URL, cmd.UserID, time.Now().UTC())
+	if err != nil {
+		return nil, err
+	}
+
+	err = s.tx.WithTx(ctx, func(ctx context.Context, tx *sql.Tx) error {
+		existing, err := s.repo.ListWebhooks(ctx, tx, cmd.ConversationID)
+		if err != nil {
+			return err
+		}
+		if len(existing) >= domain.MaxWebhooksPerConversation {
+			return fmt.Errorf("%w: at most %d", domain.ErrTooManyWebhooks, domain.MaxWebhooksPerConversation)
+		}
+		return s.repo.InsertWebhook(ctx, tx, w)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	s.log.Info("webhook created",
+		zap.String("webhook_id", w.ID),
+		zap.String("conversation_id", w.ConversationID),
+		zap.String("user_id", cmd.UserID),
+	)
+	return w, nil
+}
+
+// ListWebhooks returns a conversation's webhooks, oldest first.
+func (s *Service) ListWebhooks(ctx context.Context, conversationID, userID string) ([]*domain.Webhook, error) {
+	if conversationID == "" || userID == "" {
+		return nil, domain.ErrInvalidInput
+	}
+	if err := s.requireAdmin(ctx, conversationID, userID); err != nil {
+		return nil, err
+	}
+	return s.repo.ListWebhooks(ctx, nil, conversationID)
+}
+
+// DeleteWebhook removes a webhook with its pending deliveries and dead
+// letters.
+func (s *Service) DeleteWebhook(ctx context.Context, webhookID, userID string) error {
+	w, err := s.managedWebhook(ctx, webhookID, userID)
+	if err != nil {
+		return err
+	}
+	if err := s.repo.DeleteWebhook(ctx, nil, w.ID); err != nil {
+		return err
+	}
+
+	s.log.Info("webhook deleted", zap.String("webhook_id", w.ID), zap.String("user_id", userID))
+	return nil
+}
+
+// EnableWebhook re-enables a disabled webhook. Deliveries resume with the
+// next event.
+func (s *Service) EnableWebhook(ctx context.Context, webhookID, userID string) (*domain.Webhook, error) {
+	if _, err := s.managedWebhook(ctx, webhookID, userID); err != nil {
+		return nil, err
+	}
+
+	var w *domain.Webhook
+	err := s.tx.WithTx(ctx, func(ctx context.Context, tx *sql.Tx) error {
+		cur, err := s.repo.GetWebhookForUpdate(ctx, tx, webhookID)
+		if err != nil {
+			return err
+		}
+		if err := cur.Enable(); err != nil {
+			return err
+		}
+		w = cur
+		return s.repo.UpdateWebhook(ctx, tx, cur)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	s.log.Info("webhook enabled", zap.String("webhook_id", w.ID), zap.String("user_id", userID))
+	return w, nil
+}
+
+type ListDeadLettersCommand struct {
+	WebhookID string
+	UserID    string
+	PageSize  int
+	PageToken string
+}
+
+// ListDeadLetters pages a webhook's dead letters, newest first. The
+// returned token is empty on the last page.
+func (s *Service) ListDeadLetters(ctx context.Context, cmd ListDeadLettersCommand) ([]*domain.DeadLetter, string, error) {
+	if _, err := s.managedWebhook(ctx, cmd.WebhookID, cmd.UserID); err != nil {
+		return nil, "", err
+	}
+
+	pageSize := cmd.PageSize
+	if pageSize <= 0 || pageSize > 100 {
+		pageSize = 50
+	}
+
+	var (
+		beforeFailedAt time.Time
+		beforeID       string
+	)
+	if cmd.PageToken != "" {
+		t, id, err := decodePageToken(cmd.PageToken)
+		if err != nil {
+			return nil, "", domain.ErrInvalidInput
+		}
+		beforeFailedAt, beforeID = t, id
+	}
+
+	dls, err := s.repo.ListDeadLetters(ctx, cmd.WebhookID, beforeFailedAt, beforeID, pageSize+1)
+	if err != nil {
+		return nil, "", err
+	}
+
+	var next string
+	if len(dls) > pageSize {
+		dls = dls[:pageSize]
+		last := dls[len(dls)-1]
+		next = encodePageToken(last.FailedAt, last.ID)
+	}
+	return dls, next, nil
+}
+
+// managedWebhook loads a webhook the user administers.
+func (s *Service) managedWebhook(ctx context.Context, webhookID, userID string) (*domain.Webhook, error) {
+	if webhookID == "" || userID == "" {
+		return nil, domain.ErrInvalidInput
+	}
+	w, err := s.repo.GetWebhook(ctx, nil, webhookID)
+	if err != nil {
+		return nil, err
+	}
+	if err := s.requireAdmin(ctx, w.ConversationID, userID); err != nil {
+		return nil, err
+	}
+	return w, nil
+}
+
+// requireAdmin asks the conversation service whether the user administers
+// the conversation. Either participant of a direct conversation does.
+func (s *Service) requireAdmin(ctx context.Context, conversationID, userID string) error {
+	resp, err := s.convSvc.GetConversation(ctx, &conversationv1.GetConversationRequest{
+		ConversationId: conversationID,
+	})
+	if err != nil {
+		return err
+	}
+
+	conv := resp.GetConversation()
+	if conv.GetType() == conversationv1.ConversationType_DIRECT {
+		participants := conv.GetParticipantUserIds()
+		if len(participants) == 0 {
+			participants = resp.GetParticipantUserIds()
+		}
+		if slices.Contains(participants, userID) {
+			return nil
+		}
+		return domain.ErrNotAdmin
+	}
+
+	for _, p := range conv.GetParticipantsWithRoles() {
+		if p.GetUserId() == userID && p.GetRole() == conversationv1.ParticipantRole_ADMIN {
+			return nil
+		}
+	}
+	return domain.ErrNotAdmin
+}
+
+func encodePageToken(t time.Time, id string) string {
+	return base64.RawURLEncoding.EncodeToString([]byte(strconv.FormatInt(t.UnixNano(), 10) + "|" + id))
+}
+
+func decodePageToken(token string) (time.Time, string, error) {
+	raw, err := base64.RawURLEncoding.DecodeString(token)
+	if err != nil {
+		return time.Time{}, "", err
+	}
+	ns, id, ok := strings.Cut(string(raw), "|")
+	if !ok || id == "" {
+		return time.Time{}, "", domain.ErrInvalidInput
+	}
+	n, err := strconv.ParseInt(ns, 10, 64)
+	if err != nil {
+		return time.Time{}, "", err
+	}
+	return time.Unix(0, n).UTC(), id, nil
+}
@@ -0,0 +1,110 @@
+package config
+
+import (
+	"log"
+	"os"
+	"strconv"
+	"strings"
+	"time"
+)
+
+type Config struct {
+	GRPCAddr            string
+	DatabaseURL         string
+	KafkaBrokers        string
+	KafkaTopics         []string
+	KafkaGroupID        string
+	ConversationSvcAddr string
+	ServiceName         string
+	ObsHTTPAddr         string
+	MetricsEnabled      bool
+	TracingEnabled      bool
+	JaegerURL           string
+
+	// Timeout bounds each POST to a webhook.
+	Timeout      time.Duration
+	MaxAttempts  int
+	RetryBase    time.Duration
+	RetryMax     time.Duration
+	DisableAfter int
+	// AllowPrivateURLs lets webhooks reach loopback and private addresses,
+	// for local development.
+	AllowPrivateURLs bool
+}
+
+func Load() *Config {
+	return &Config{
+		GRPCAddr:            fixPort(mustEnv("GRPC_ADDR")),
+		DatabaseURL:         mustEnv("DATABASE_URL"),
+		KafkaBrokers:        mustEnv("KAFKA_BROKERS"),
+		KafkaTopics:         strings.Split(mustEnv("KAFKA_TOPICS"), ","),
+		KafkaGroupID:        getEnv("KAFKA_GROUP_ID", "webhook-service"),
+		ConversationSvcAddr: mustEnv("CONVERSATION_SVC_ADDR"),
+		ServiceName:         mustEnv("SERVICE_NAME"),
+		ObsHTTPAddr:         fixPort(mustEnv("HTTP_ADDR")),
+		MetricsEnabled:      getEnvBool("METRICS_ENABLED", false),
+		TracingEnabled:      getEnvBool("TRACING_ENABLED", false),
+		JaegerURL:           getEnv("JAEGER_URL", "http://jaeger:14268/api/traces"),
+
+		Timeout:          getEnvDuration("WEBHOOK_TIMEOUT", 10*time.Second),
+		MaxAttempts:      getEnvInt("WEBHOOK_MAX_ATTEMPTS", 10),
+		RetryBase:        getEnvDuration("WEBHOOK_RETRY_BASE", 5*time.Second),
+		RetryMax:         getEnvDuration("WEBHOOK_RETRY_MAX", time.Hour),
+		DisableAfter:     getEnvInt("WEBHOOK_DISABLE_AFTER", 5),
+		AllowPrivateURLs: getEnvBool("WEBHOOK_ALLOW_PRIVATE_URLS", false),
+	}
+}
+
+func fixPort(port string) string {
+	if port != "" && !strings.Contains(port, ":") {
+		return ":" + port
+	}
+	return port
+}
+
+func getEnvBool(key string, fallback bool) bool {
+	v := os.Getenv(key)
+	if v == "" {
+		return fallback
+	}
+	return v == "true"
+}
+
+func mustEnv(k string) string {
+	v := os.Getenv(k)
+	if v == "" {
+		log.Fatalf("missing required env: %s", k)
+	}
+	return v
+}
+
+func getEnv(key, fallback string) string {
+	if value, exists := os.LookupEnv(key); exists {
+		return value
+	}
+	return fallback
+}
+
+func getEnvInt(key string, fallback int) int {
+	v := os.Getenv(key)
+	if v == "" {
+		return fallback
+	}
+	n, err := strconv.Atoi(v)
+	if err != nil {
+		log.Fatalf("invalid integer for %s: %v", key, err)
+	}
+	return n
+}
+
+func getEnvDuration(key string, fallback time.Duration) time.Duration {
+	v := os.Getenv(key)
+	if v == "" {
+		return fallback
+	}
+	d, err := time.ParseDuration(v)
+	if err != nil {
+		log.Fatalf("invalid duration for %s: %v", key, err)
+	}
+	return d
+}
@@ -0,0 +1,64 @@
+package dispatcher
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/SARVESHVARADKAR123/RealChat/services/webhook/internal/domain"
+	"github.com/SARVESHVARADKAR123/RealChat/services/webhook/internal/observability"
+	"go.uber.org/zap"
+)
+
+// Deliverer sends queued webhook deliveries.
+type Deliverer interface {
+	ClaimDeliveries(ctx context.Context, now time.Time, limit int) ([]*domain.Delivery, error)
+	Deliver(ctx context.Context, d *domain.Delivery) error
+}
+
+// Worker sends due deliveries. Deliveries are claimed with FOR UPDATE SKIP
+// LOCKED under a lease, so several replicas can run it side by side. Each
+// batch is sent concurrently, so one slow endpoint delays a batch by at most
+// the client timeout.
+type Worker struct {
+	Deliverer Deliverer
+	BatchSize int
+	PollDelay time.Duration
+}
+
+// Start Worker
+func (w *Worker) Start(ctx context.Context) {
+
+	log := observability.GetLogger(ctx)
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		default:
+		}
+
+		claimed, err := w.Deliverer.ClaimDeliveries(ctx, time.Now().UTC(), w.BatchSize)
+		if err != nil {
+			log.Error("dispatcher error", zap.Error(err))
+			time.Sleep(time.Second)
+			continue
+		}
+
+		var wg sync.WaitGroup
+		for _, d := range claimed {
+			wg.Add(1)
+			go func() {
+				defer wg.Done()
+				if err := w.Deliverer.Deliver(ctx, d); err != nil {
+					// The delivery is claimed again once its lease runs out
+					log.Error("dispatcher error", zap.String("delivery_id", d.ID), zap.Error(err))
+				}
+			}()
+		}
+		wg.Wait()
+
+		if len(claimed) < w.BatchSize {
+			time.Sleep(w.PollDelay)
+		}
+	}
+}
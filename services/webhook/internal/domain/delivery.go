@@ -0,0 +1,89 @@
+package domain
+
+import "time"
+
+// Event types, as named in deliveries.
+const (
+	EventMessageSent       = "message.sent"
+	EventMessageDeleted    = "message.deleted"
+	EventMembershipChanged = "membership.changed"
+)
+
+// Event is a conversation event rendered for webhooks.
+type Event struct {
+	// ID is stable across redeliveries of the event from Kafka.
+	ID             string
+	Type           string
+	ConversationID string
+	// Payload is the JSON body of every delivery of the event.
+	Payload []byte
+}
+
+// Delivery is one event queued for one webhook. The same event is never
+// queued twice for a webhook.
+type Delivery struct {
+	ID        string
+	WebhookID string
+	EventID   string
+	EventType string
+	// Payload is the JSON body POSTed to the webhook.
+	Payload       []byte
+	Attempts      int
+	NextAttemptAt time.Time
+	LastError     string
+	CreatedAt     time.Time
+}
+
+// DeadLetter is a delivery that ran out of attempts, or was pending when
+// its webhook was disabled.
+type DeadLetter struct {
+	Delivery
+	FailedAt time.Time
+}
+
+// RetryPolicy says how failed deliveries are retried and when a webhook is
+// given up on.
+type RetryPolicy struct {
+	// MaxAttempts is the number of attempts before a delivery is
+	// dead-lettered.
+	MaxAttempts int
+	// The delay before the n-th retry is BaseDelay * 2^(n-1), up to
+	// MaxDelay.
+	BaseDelay time.Duration
+	MaxDelay  time.Duration
+	// DisableAfter is the number of deliveries dead-lettered in a row that
+	// disables a webhook.
+	DisableAfter int
+}
+
+// DefaultRetryPolicy retries for about 40 minutes before giving up on a
+// delivery.
+func DefaultRetryPolicy() RetryPolicy {
+	return RetryPolicy{
+		MaxAttempts:  10,
+		BaseDelay:    5 * time.Second,
+		MaxDelay:     time.Hour,
+		DisableAfter: 5,
+	}
+}
+
+// Backoff returns the delay after the given number of failed attempts.
+func (p RetryPolicy) Backoff(attempts int) time.Duration {
+	d := p.BaseDelay
+	for i := 1; i < attempts && d < p.MaxDelay; i++ {
+		d *= 2
+	}
+	return min(d, p.MaxDelay)
+}
+
+// Fail records a failed attempt. It reports true when the delivery has run
+// out of attempts; otherwise the next attempt is scheduled.
+func (d *Delivery) Fail(reason string, now time.Time, p RetryPolicy) bool {
+	d.Attempts++
+	d.LastError = reason
+	if d.Attempts >= p.MaxAttempts {
+		return true
+	}
+	d.NextAttemptAt = now.Add(p.Backoff(d.Attempts))
+	return false
+}
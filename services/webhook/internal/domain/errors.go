@@ -0,0 +1,12 @@
+package domain
+
+import "errors"
+
+var (
+	ErrInvalidInput       = errors.New("invalid input")
+	ErrInvalidURL         = errors.New("invalid webhook url")
+	ErrNotAdmin           = errors.New("only conversation admins can manage webhooks")
+	ErrTooManyWebhooks    = errors.New("too many webhooks for this conversation")
+	ErrWebhookNotFound    = errors.New("webhook not found")
+	ErrWebhookNotDisabled = errors.New("webhook is not disabled")
+)
@@ -0,0 +1,61 @@
+package events
+
+import (
+	"context"
+	"errors"
+
+	sharedv1 "github.com/SARVESHVARADKAR123/RealChat/contracts/gen/go/shared/v1"
+	"github.com/SARVESHVARADKAR123/RealChat/services/webhook/internal/domain"
+	"github.com/SARVESHVARADKAR123/RealChat/services/webhook/internal/observability"
+	"go.uber.org/zap"
+	"google.golang.org/protobuf/proto"
+)
+
+// Queuer queues an event for the webhooks of its conversation.
+type Queuer interface {
+	QueueEvent(ctx context.Context, ev *domain.Event) (int, error)
+}
+
+// Handler turns the events the delivery service reads into webhook
+// deliveries. Deliveries are only queued here; the dispatcher sends them,
+// so a slow endpoint never holds up the topic.
+type Handler struct {
+	Queuer Queuer
+}
+
+// Handle queues one record. Malformed events are dropped; failures to queue
+// are returned for the consumer to retry, which can't queue an event twice.
+func (h *Handler) Handle(ctx context.Context, record []byte) error {
+	log := observability.GetLogger(ctx)
+
+	var env sharedv1.EventEnvelope
+	if err := proto.Unmarshal(record, &env); err != nil {
+		log.Error("webhook: error unmarshaling event", zap.Error(err))
+		observability.WebhookEventsTotal.WithLabelValues("dropped").Inc()
+		return nil
+	}
+
+	ev, err := Render(&env, record)
+	switch {
+	case errors.Is(err, domain.ErrInvalidInput), errors.As(err, new(unmarshalError)):
+		log.Warn("webhook: dropping event", zap.String("event_type", env.GetEventType().String()), zap.Error(err))
+		observability.WebhookEventsTotal.WithLabelValues("dropped").Inc()
+		return nil
+	case err != nil:
+		return err
+	case ev == nil:
+		return nil
+	}
+
+	n, err := h.Queuer.QueueEvent(ctx, ev)
+	if err != nil {
+		observability.WebhookEventsTotal.WithLabelValues("retry").Inc()
+		return err
+	}
+	if n == 0 {
+		observability.WebhookEventsTotal.WithLabelValues("no_webhooks").Inc()
+		return nil
+	}
+	observability.WebhookEventsTotal.WithLabelValues("queued").Inc()
+	return nil
+}
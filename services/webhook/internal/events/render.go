@@ -0,0 +1,102 @@
+package events
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"time"
+
+	conversationv1 "github.com/SARVESHVARADKAR123/RealChat/contracts/gen/go/conversation/v1"
+	messagev1 "github.com/SARVESHVARADKAR123/RealChat/contracts/gen/go/message/v1"
+	sharedv1 "github.com/SARVESHVARADKAR123/RealChat/contracts/gen/go/shared/v1"
+	"github.com/SARVESHVARADKAR123/RealChat/services/webhook/internal/domain"
+	"google.golang.org/protobuf/encoding/protojson"
+	"google.golang.org/protobuf/proto"
+)
+
+// body is the JSON POSTed to webhooks. Data is the event in the canonical
+// protobuf JSON mapping, with the proto field names.
+type body struct {
+	ID             string          `json:"id"`
+	Type           string          `json:"type"`
+	ConversationID string          `json:"conversation_id"`
+	OccurredAt     time.Time       `json:"occurred_at"`
+	Data           json.RawMessage `json:"data"`
+}
+
+var marshaler = protojson.MarshalOptions{UseProtoNames: true}
+
+// Render turns an event envelope into the event webhooks receive. It
+// returns nil for event types webhooks don't get. record is the envelope as
+// consumed, whose hash stands in for the ID of events that predate event
+// IDs.
+func Render(env *sharedv1.EventEnvelope, record []byte) (*domain.Event, error) {
+	var (
+		ev   = &domain.Event{ID: env.GetEventId()}
+		data proto.Message
+	)
+
+	switch env.GetEventType() {
+	case sharedv1.EventType_EVENT_TYPE_MESSAGE_SENT:
+		var event messagev1.MessageSentEvent
+		if err := proto.Unmarshal(env.GetPayload(), &event); err != nil {
+			return nil, unmarshalError{err}
+		}
+		// Ciphertexts are addressed to devices, not to webhooks
+		if event.GetMessage() != nil {
+			event.Message.Ciphertexts = nil
+		}
+		ev.Type, ev.ConversationID, data = domain.EventMessageSent, event.GetMessage().GetConversationId(), &event
+
+	case sharedv1.EventType_EVENT_TYPE_MESSAGE_DELETED:
+		var event messagev1.MessageDeletedEvent
+		if err := proto.Unmarshal(env.GetPayload(), &event); err != nil {
+			return nil, unmarshalError{err}
+		}
+		ev.Type, ev.ConversationID, data = domain.EventMessageDeleted, event.GetConversationId(), &event
+
+	case sharedv1.EventType_EVENT_TYPE_MEMBERSHIP_CHANGED:
+		var event conversationv1.MembershipChangedEvent
+		if err := proto.Unmarshal(env.GetPayload(), &event); err != nil {
+			return nil, unmarshalError{err}
+		}
+		ev.Type, ev.ConversationID, data = domain.EventMembershipChanged, event.GetConversationId(), &event
+
+	default:
+		return nil, nil
+	}
+
+	if ev.ConversationID == "" {
+		return nil, domain.ErrInvalidInput
+	}
+	if ev.ID == "" {
+		sum := sha256.Sum256(record)
+		ev.ID = hex.EncodeToString(sum[:])
+	}
+
+	raw, err := marshaler.Marshal(data)
+	if err != nil {
+		return nil, err
+	}
+	occurredAt := time.Now().UTC()
+	if env.GetOccurredAt() != nil {
+		occurredAt = env.GetOccurredAt().AsTime()
+	}
+
+	ev.Payload, err = json.Marshal(body{
+		ID:             ev.ID,
+		Type:           ev.Type,
+		ConversationID: ev.ConversationID,
+		OccurredAt:     occurredAt,
+		Data:           raw,
+	})
+	if err != nil {
+		return nil, err
+	}
+	return ev, nil
+}
+
+type unmarshalError struct{ err error }
+
+func (e unmarshalError) Error() string { return "unmarshal payload: " + e.err.Error() }
+func (e unmarshalError) Unwrap() error { return e.err }
@@ -0,0 +1,107 @@
+package events
+
+import (
+	"encoding/json"
+	"testing"
+	"time"
+
+	conversationv1 "github.com/SARVESHVARADKAR123/RealChat/contracts/gen/go/conversation/v1"
+	messagev1 "github.com/SARVESHVARADKAR123/RealChat/contracts/gen/go/message/v1"
+	sharedv1 "github.com/SARVESHVARADKAR123/RealChat/contracts/gen/go/shared/v1"
+	"github.com/SARVESHVARADKAR123/RealChat/services/webhook/internal/domain"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+func envelope(t *testing.T, eventType sharedv1.EventType, eventID string, event proto.Message) (*sharedv1.EventEnvelope, []byte) {
+	t.Helper()
+	payload, err := proto.Marshal(event)
+	if err != nil {
+		t.Fatal(err)
+	}
+	env := &sharedv1.EventEnvelope{
+		EventType:  eventType,
+		OccurredAt: timestamppb.New(time.Date(2026, 10, 1, 12, 0, 0, 0, time.UTC)),
+		Payload:    payload,
+		EventId:    eventID,
+	}
+	record, err := proto.Marshal(env)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return env, record
+}
+
+func TestRender(t *testing.T) {
+	t.Run("Encrypted messages are sent without ciphertexts", func(t *testing.T) {
+		env, record := envelope(t, sharedv1.EventType_EVENT_TYPE_MESSAGE_SENT, "evt-1", &messagev1.MessageSentEvent{
+			Message: &messagev1.Message{
+				MessageId:      "msg-1",
+				ConversationId: "conv-1",
+				MessageType:    "encrypted",
+				Ciphertexts:    []*messagev1.DeviceCiphertext{{UserId: "user-2", DeviceId: "phone", Ciphertext: []byte{1}}},
+			},
+		})
+		ev, err := Render(env, record)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if ev.ID != "evt-1" || ev.Type != domain.EventMessageSent || ev.ConversationID != "conv-1" {
+			t.Fatalf("event = %+v", ev)
+		}
+
+		var body struct {
+			ID         string    `json:"id"`
+			Type       string    `json:"type"`
+			OccurredAt time.Time `json:"occurred_at"`
+			Data       struct {
+				Message map[string]any `json:"message"`
+			} `json:"data"`
+		}
+		if err := json.Unmarshal(ev.Payload, &body); err != nil {
+			t.Fatal(err)
+		}
+		if body.ID != "evt-1" || body.Type != domain.EventMessageSent || !body.OccurredAt.Equal(env.OccurredAt.AsTime()) {
+			t.Fatalf("body = %s", ev.Payload)
+		}
+		if body.Data.Message["message_id"] != "msg-1" {
+			t.Fatalf("data = %s", ev.Payload)
+		}
+		if _, ok := body.Data.Message["ciphertexts"]; ok {
+			t.Fatalf("ciphertexts leaked: %s", ev.Payload)
+		}
+	})
+
+	t.Run("Events without an ID get one from the record", func(t *testing.T) {
+		env, record := envelope(t, sharedv1.EventType_EVENT_TYPE_MEMBERSHIP_CHANGED, "", &conversationv1.MembershipChangedEvent{
+			ConversationId: "conv-1",
+			UserId:         "user-2",
+			Added:          true,
+		})
+		first, err := Render(env, record)
+		if err != nil {
+			t.Fatal(err)
+		}
+		again, err := Render(env, record)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if first.ID == "" || first.ID != again.ID || first.Type != domain.EventMembershipChanged {
+			t.Fatalf("events = %+v, %+v", first, again)
+		}
+	})
+
+	t.Run("Other events are ignored", func(t *testing.T) {
+		env, record := envelope(t, sharedv1.EventType_EVENT_TYPE_REACTION_CHANGED, "evt-2", &messagev1.MessageDeletedEvent{})
+		if ev, err := Render(env, record); ev != nil || err != nil {
+			t.Fatalf("Render = %+v, %v", ev, err)
+		}
+	})
+
+	t.Run("Events without a conversation are refused", func(t *testing.T) {
+		env, record := envelope(t, sharedv1.EventType_EVENT_TYPE_MESSAGE_DELETED, "evt-3", &messagev1.MessageDeletedEvent{MessageId: "msg-1"})
+		if _, err := Render(env, record); err != domain.ErrInvalidInput {
+			t.Fatalf("err = %v, want ErrInvalidInput", err)
+		}
+	})
+}
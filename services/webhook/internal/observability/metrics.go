@@ -0,0 +1,65 @@
+package observability
+
+import (
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promauto"
+)
+
+var (
+	HttpRequestsTotal = promauto.NewCounterVec(
+		prometheus.CounterOpts{
+			Name: "http_requests_total",
+			Help: "Total number of HTTP requests",
+		},
+		[]string{"service", "method", "path", "status"},
+	)
+
+	HttpRequestDuration = promauto.NewHistogramVec(
+		prometheus.HistogramOpts{
+			Name:    "http_request_duration_seconds",
+			Help:    "Duration of HTTP requests in seconds",
+			Buckets: prometheus.DefBuckets,
+		},
+		[]string{"service", "method", "path"},
+	)
+
+	WebhookEventsTotal = promauto.NewCounterVec(
+		prometheus.CounterOpts{
+			Name: "webhook_events_total",
+			Help: "Total number of consumed events, by whether deliveries were queued for them",
+		},
+		[]string{"result"},
+	)
+
+	WebhookDeliveriesTotal = promauto.NewCounterVec(
+		prometheus.CounterOpts{
+			Name: "webhook_deliveries_total",
+			Help: "Total number of delivery attempts, by outcome",
+		},
+		[]string{"result"},
+	)
+
+	WebhookDeliveryDuration = promauto.NewHistogram(
+		prometheus.HistogramOpts{
+			Name:    "webhook_delivery_duration_seconds",
+			Help:    "Duration of webhook POSTs in seconds",
+			Buckets: prometheus.DefBuckets,
+		},
+	)
+
+	WebhooksDisabledTotal = promauto.NewCounter(
+		prometheus.CounterOpts{
+			Name: "webhooks_disabled_total",
+			Help: "Total number of webhooks disabled for failing",
+		},
+	)
+
+	DbQueryDuration = promauto.NewHistogramVec(
+		prometheus.HistogramOpts{
+			Name:    "db_query_duration_seconds",
+			Help:    "Duration of database queries in seconds",
+			Buckets: prometheus.DefBuckets,
+		},
+		[]string{"service", "query_type"},
+	)
+)
@@ -0,0 +1,325 @@
+package postgres
+
+import (
+	"context"
+	"database/sql"
+	"time"
+
+	"github.com/SARVESHVARADKAR123/RealChat/services/webhook/internal/domain"
+	"github.com/lib/pq"
+)
+
+type Repository struct {
+	DB *sql.DB
+}
+
+type queryable interface {
+	QueryRowContext(ctx context.Context, query string, args ...interface{}) *sql.Row
+	QueryContext(ctx context.Context, query string, args ...interface{}) (*sql.Rows, error)
+	ExecContext(ctx context.Context, query string, args ...interface{}) (sql.Result, error)
+}
+
+func (r *Repository) getter(tx *sql.Tx) queryable {
+	if tx != nil {
+		return tx
+	}
+	return r.DB
+}
+
+type rowScanner interface {
+	Scan(dest ...interface{}) error
+}
+
+const webhookColumns = `id, conversation_id, url, secret, created_by, created_at,
+		       disabled_at, disabled_reason, consecutive_failures`
+
+func scanWebhook(row rowScanner) (*domain.Webhook, error) {
+	var w domain.Webhook
+	var disabledAt sql.NullTime
+
+	if err := row.Scan(
+		&w.ID,
+		&w.ConversationID,
+		&w.URL,
+		&w.Secret,
+		&w.CreatedBy,
+		&w.CreatedAt,
+		&disabledAt,
+		&w.DisabledReason,
+		&w.ConsecutiveFailures,
+	); err != nil {
+		if err == sql.ErrNoRows {
+			return nil, domain.ErrWebhookNotFound
+		}
+		return nil, err
+	}
+
+	if disabledAt.Valid {
+		t := disabledAt.Time
+		w.DisabledAt = &t
+	}
+	return &w, nil
+}
+
+func (r *Repository) InsertWebhook(ctx context.Context, tx *sql.Tx, w *domain.Webhook) error {
+	q := r.getter(tx)
+	_, err := q.ExecContext(ctx, `
+		INSERT INTO webhooks (id, conversation_id, url, secret, created_by, created_at)
+		VALUES ($1, $2, $3, $4, $5, $6)
+	`, w.ID, w.ConversationID, w.URL, w.Secret, w.CreatedBy, w.CreatedAt)
+	return err
+}
+
+func (r *Repository) GetWebhook(ctx context.Context, tx *sql.Tx, id string) (*domain.Webhook, error) {
+	q := r.getter(tx)
+	return scanWebhook(q.QueryRowContext(ctx, `
+		SELECT `+webhookColumns+`
+		FROM webhooks
+		WHERE id = $1
+	`, id))
+}
+
+func (r *Repository) GetWebhookForUpdate(ctx context.Context, tx *sql.Tx, id string) (*domain.Webhook, error) {
+	q := r.getter(tx)
+	return scanWebhook(q.QueryRowContext(ctx, `
+		SELECT `+webhookColumns+`
+		FROM webhooks
+		WHERE id = $1
+		FOR UPDATE
+	`, id))
+}
+
+func (r *Repository) UpdateWebhook(ctx context.Context, tx *sql.Tx, w *domain.Webhook) error {
+	q := r.getter(tx)
+	res, err := q.ExecContext(ctx, `
+		UPDATE webhooks
+		SET disabled_at = $2, disabled_reason = $3, consecutive_failures = $4
+		WHERE id = $1
+	`, w.ID, w.DisabledAt, w.DisabledReason, w.ConsecutiveFailures)
+	if err != nil {
+		return err
+	}
+	return requireRow(res, domain.ErrWebhookNotFound)
+}
+
+func (r *Repository) DeleteWebhook(ctx context.Context, tx *sql.Tx, id string) error {
+	q := r.getter(tx)
+	res, err := q.ExecContext(ctx, `DELETE FROM webhooks WHERE id = $1`, id)
+	if err != nil {
+		return err
+	}
+	return requireRow(res, domain.ErrWebhookNotFound)
+}
+
+func (r *Repository) ListWebhooks(ctx context.Context, tx *sql.Tx, conversationID string) ([]*domain.Webhook, error) {
+	q := r.getter(tx)
+	rows, err := q.QueryContext(ctx, `
+		SELECT `+webhookColumns+`
+		FROM webhooks
+		WHERE conversation_id = $1
+		ORDER BY created_at, id
+	`, conversationID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var out []*domain.Webhook
+	for rows.Next() {
+		w, err := scanWebhook(rows)
+		if err != nil {
+			return nil, err
+		}
+		out = append(out, w)
+	}
+	return out, rows.Err()
+}
+
+const deliveryColumns = `id, webhook_id, event_id, event_type, payload,
+		       attempts, next_attempt_at, last_error, created_at`
+
+func scanDelivery(row rowScanner) (*domain.Delivery, error) {
+	var d domain.Delivery
+	if err := row.Scan(
+		&d.ID,
+		&d.WebhookID,
+		&d.EventID,
+		&d.EventType,
+		&d.Payload,
+		&d.Attempts,
+		&d.NextAttemptAt,
+		&d.LastError,
+		&d.CreatedAt,
+	); err != nil {
+		return nil, err
+	}
+	return &d, nil
+}
+
+func (r *Repository) InsertDeliveries(ctx context.Context, tx *sql.Tx, ds []*domain.Delivery) error {
+	if len(ds) == 0 {
+		return nil
+	}
+
+	var (
+		ids        = make([]string, len(ds))
+		webhookIDs = make([]string, len(ds))
+		eventIDs   = make([]string, len(ds))
+		types      = make([]string, len(ds))
+		payloads   = make([][]byte, len(ds))
+		due        = make([]string, len(ds))
+	)
+	for i, d := range ds {
+		ids[i], webhookIDs[i], eventIDs[i], types[i] = d.ID, d.WebhookID, d.EventID, d.EventType
+		payloads[i], due[i] = d.Payload, d.NextAttemptAt.Format(time.RFC3339Nano)
+	}
+
+	q := r.getter(tx)
+	_, err := q.ExecContext(ctx, `
+		INSERT INTO webhook_deliveries (id, webhook_id, event_id, event_type, payload, next_attempt_at)
+		SELECT * FROM unnest($1::text[], $2::text[], $3::text[], $4::text[], $5::bytea[], $6::timestamptz[])
+		ON CONFLICT (webhook_id, event_id) DO NOTHING
+	`, pq.Array(ids), pq.Array(webhookIDs), pq.Array(eventIDs), pq.Array(types), pq.ByteaArray(payloads), pq.Array(due))
+	return err
+}
+
+func (r *Repository) ClaimDeliveries(ctx context.Context, now, leaseUntil time.Time, limit int) ([]*domain.Delivery, error) {
+	rows, err := r.DB.QueryContext(ctx, `
+		UPDATE webhook_deliveries
+		SET next_attempt_at = $2
+		WHERE id IN (
+			SELECT d.id
+			FROM webhook_deliveries d
+			JOIN webhooks w ON w.id = d.webhook_id
+			WHERE d.next_attempt_at <= $1
+			  AND w.disabled_at IS NULL
+			ORDER BY d.next_attempt_at
+			FOR UPDATE OF d SKIP LOCKED
+			LIMIT $3
+		)
+		RETURNING `+deliveryColumns+`
+	`, now, leaseUntil, limit)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var out []*domain.Delivery
+	for rows.Next() {
+		d, err := scanDelivery(rows)
+		if err != nil {
+			return nil, err
+		}
+		out = append(out, d)
+	}
+	return out, rows.Err()
+}
+
+func (r *Repository) UpdateDelivery(ctx context.Context, tx *sql.Tx, d *domain.Delivery) error {
+	q := r.getter(tx)
+	_, err := q.ExecContext(ctx, `
+		UPDATE webhook_deliveries
+		SET attempts = $2, next_attempt_at = $3, last_error = $4
+		WHERE id = $1
+	`, d.ID, d.Attempts, d.NextAttemptAt, d.LastError)
+	return err
+}
+
+func (r *Repository) DeleteDelivery(ctx context.Context, tx *sql.Tx, id string) error {
+	q := r.getter(tx)
+	_, err := q.ExecContext(ctx, `DELETE FROM webhook_deliveries WHERE id = $1`, id)
+	return err
+}
+
+func (r *Repository) DeadLetterDelivery(ctx context.Context, tx *sql.Tx, d *domain.Delivery, failedAt time.Time) error {
+	q := r.getter(tx)
+	// Skipped if the webhook was deleted or disabled meanwhile, as the
+	// delivery is then gone
+	_, err := q.ExecContext(ctx, `
+		WITH moved AS (
+			DELETE FROM webhook_deliveries
+			WHERE id = $1
+			RETURNING id, webhook_id, event_id, event_type, payload, created_at
+		)
+		INSERT INTO webhook_dead_letters
+			(id, webhook_id, event_id, event_type, payload, attempts, last_error, created_at, failed_at)
+		SELECT id, webhook_id, event_id, event_type, payload, $2, $3, created_at, $4
+		FROM moved
+	`, d.ID, d.Attempts, d.LastError, failedAt)
+	return err
+}
+
+func (r *Repository) DeadLetterPending(ctx context.Context, tx *sql.Tx, webhookID, reason string, failedAt time.Time) (int, error) {
+	q := r.getter(tx)
+	res, err := q.ExecContext(ctx, `
+		WITH moved AS (
+			DELETE FROM webhook_deliveries
+			WHERE webhook_id = $1
+			RETURNING id, webhook_id, event_id, event_type, payload, attempts, last_error, created_at
+		)
+		INSERT INTO webhook_dead_letters
+			(id, webhook_id, event_id, event_type, payload, attempts, last_error, created_at, failed_at)
+		SELECT id, webhook_id, event_id, event_type, payload, attempts,
+		       CASE WHEN last_error = '' THEN $2 ELSE last_error END, created_at, $3
+		FROM moved
+	`, webhookID, reason, failedAt)
+	if err != nil {
+		return 0, err
+	}
+	n, err := res.RowsAffected()
+	return int(n), err
+}
+
+func (r *Repository) ListDeadLetters(
+	ctx context.Context,
+	webhookID string,
+	beforeFailedAt time.Time,
+	beforeID string,
+	limit int,
+) ([]*domain.DeadLetter, error) {
+
+	rows, err := r.DB.QueryContext(ctx, `
+		SELECT id, webhook_id, event_id, event_type, payload,
+		       attempts, last_error, created_at, failed_at
+		FROM webhook_dead_letters
+		WHERE webhook_id = $1
+		  AND ($2::text = '' OR (failed_at, id) < ($3, $2))
+		ORDER BY failed_at DESC, id DESC
+		LIMIT $4
+	`, webhookID, beforeID, beforeFailedAt, limit)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var out []*domain.DeadLetter
+	for rows.Next() {
+		var dl domain.DeadLetter
+		if err := rows.Scan(
+			&dl.ID,
+			&dl.WebhookID,
+			&dl.EventID,
+			&dl.EventType,
+			&dl.Payload,
+			&dl.Attempts,
+			&dl.LastError,
+			&dl.CreatedAt,
+			&dl.FailedAt,
+		); err != nil {
+			return nil, err
+		}
+		out = append(out, &dl)
+	}
+	return out, rows.Err()
+}
+
+func requireRow(res sql.Result, notFound error) error {
+	n, err := res.RowsAffected()
+	if err != nil {
+		return err
+	}
+	if n == 0 {
+		return notFound
+	}
+	return nil
+}
@@ -0,0 +1,34 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"time"
+
+	"github.com/SARVESHVARADKAR123/RealChat/services/webhook/internal/domain"
+)
+
+type Repository interface {
+	// Webhooks. The secret is loaded with the webhook.
+	InsertWebhook(ctx context.Context, tx *sql.Tx, w *domain.Webhook) error
+	GetWebhook(ctx context.Context, tx *sql.Tx, id string) (*domain.Webhook, error)
+	GetWebhookForUpdate(ctx context.Context, tx *sql.Tx, id string) (*domain.Webhook, error)
+	UpdateWebhook(ctx context.Context, tx *sql.Tx, w *domain.Webhook) error
+	DeleteWebhook(ctx context.Context, tx *sql.Tx, id string) error
+	ListWebhooks(ctx context.Context, tx *sql.Tx, conversationID string) ([]*domain.Webhook, error)
+
+	// Deliveries. InsertDeliveries skips events already queued for a
+	// webhook. ClaimDeliveries returns due deliveries of enabled webhooks
+	// and pushes their next attempt to leaseUntil, so a delivery whose
+	// worker died is claimed again once the lease runs out.
+	InsertDeliveries(ctx context.Context, tx *sql.Tx, ds []*domain.Delivery) error
+	ClaimDeliveries(ctx context.Context, now, leaseUntil time.Time, limit int) ([]*domain.Delivery, error)
+	UpdateDelivery(ctx context.Context, tx *sql.Tx, d *domain.Delivery) error
+	DeleteDelivery(ctx context.Context, tx *sql.Tx, id string) error
+
+	// Dead letters. DeadLetterDelivery moves one delivery to the dead
+	// letters; DeadLetterPending moves every pending delivery of a webhook.
+	DeadLetterDelivery(ctx context.Context, tx *sql.Tx, d *domain.Delivery, failedAt time.Time) error
+	DeadLetterPending(ctx context.Context, tx *sql.Tx, webhookID, reason string, failedAt time.Time) (int, error)
+	ListDeadLetters(ctx context.Context, webhookID string, beforeFailedAt time.Time, beforeID string, limit int) ([]*domain.DeadLetter, error)
+}
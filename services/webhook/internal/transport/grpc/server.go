@@ -0,0 +1,55 @@
+package grpc
+
+import (
+	"log"
+	"net"
+
+	"go.opentelemetry.io/contrib/instrumentation/google.golang.org/grpc/otelgrpc"
+	"google.golang.org/grpc"
+
+	webhookv1 "github.com/SARVESHVARADKAR123/RealChat/contracts/gen/go/webhook/v1"
+	"github.com/SARVESHVARADKAR123/RealChat/services/webhook/internal/application"
+	"github.com/SARVESHVARADKAR123/RealChat/services/webhook/internal/auth"
+)
+
+type Server struct {
+	webhookv1.UnimplementedWebhookApiServer
+	grpcServer *grpc.Server
+	app        *application.Service
+}
+
+func New(app *application.Service) *Server {
+	grpcServer := grpc.NewServer(
+		grpc.StatsHandler(otelgrpc.NewServerHandler()),
+		grpc.UnaryInterceptor(auth.Interceptor),
+	)
+
+	s := &Server{
+		grpcServer: grpcServer,
+		app:        app,
+	}
+
+	webhookv1.RegisterWebhookApiServer(
+		grpcServer,
+		s,
+	)
+
+	return s
+}
+
+func (s *Server) Start(port string) {
+	lis, err := net.Listen("tcp", port)
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	log.Println("gRPC listening on", port)
+	if err := s.grpcServer.Serve(lis); err != nil {
+		log.Println("gRPC server stopped:", err)
+	}
+}
+
+func (s *Server) Stop() {
+	log.Println("shutting down gRPC...")
+	s.grpcServer.GracefulStop()
+}
@@ -0,0 +1,152 @@
+package grpc
+
+import (
+	"context"
+
+	webhookv1 "github.com/SARVESHVARADKAR123/RealChat/contracts/gen/go/webhook/v1"
+	"github.com/SARVESHVARADKAR123/RealChat/services/webhook/internal/application"
+	"github.com/SARVESHVARADKAR123/RealChat/services/webhook/internal/auth"
+	"github.com/SARVESHVARADKAR123/RealChat/services/webhook/internal/domain"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+func (s *Server) CreateWebhook(
+	ctx context.Context,
+	req *webhookv1.CreateWebhookRequest,
+) (*webhookv1.CreateWebhookResponse, error) {
+
+	userID, err := auth.GetUserID(ctx)
+	if err != nil {
+		return nil, status.Error(codes.Unauthenticated, err.Error())
+	}
+
+	w, err := s.app.CreateWebhook(ctx, application.CreateWebhookCommand{
+		ConversationID: req.ConversationId,
+		UserID:         userID,
+		URL:            req.Url,
+	})
+	if err != nil {
+		return nil, MapError(err)
+	}
+
+	return &webhookv1.CreateWebhookResponse{
+		Webhook: toProtoWebhook(w),
+		Secret:  w.Secret,
+	}, nil
+}
+
+func (s *Server) ListWebhooks(
+	ctx context.Context,
+	req *webhookv1.ListWebhooksRequest,
+) (*webhookv1.ListWebhooksResponse, error) {
+
+	userID, err := auth.GetUserID(ctx)
+	if err != nil {
+		return nil, status.Error(codes.Unauthenticated, err.Error())
+	}
+
+	hooks, err := s.app.ListWebhooks(ctx, req.ConversationId, userID)
+	if err != nil {
+		return nil, MapError(err)
+	}
+
+	resp := &webhookv1.ListWebhooksResponse{
+		Webhooks: make([]*webhookv1.Webhook, 0, len(hooks)),
+	}
+	for _, w := range hooks {
+		resp.Webhooks = append(resp.Webhooks, toProtoWebhook(w))
+	}
+	return resp, nil
+}
+
+func (s *Server) DeleteWebhook(
+	ctx context.Context,
+	req *webhookv1.DeleteWebhookRequest,
+) (*webhookv1.DeleteWebhookResponse, error) {
+
+	userID, err := auth.GetUserID(ctx)
+	if err != nil {
+		return nil, status.Error(codes.Unauthenticated, err.Error())
+	}
+
+	if err := s.app.DeleteWebhook(ctx, req.WebhookId, userID); err != nil {
+		return nil, MapError(err)
+	}
+	return &webhookv1.DeleteWebhookResponse{}, nil
+}
+
+func (s *Server) EnableWebhook(
+	ctx context.Context,
+	req *webhookv1.EnableWebhookRequest,
+) (*webhookv1.EnableWebhookResponse, error) {
+
+	userID, err := auth.GetUserID(ctx)
+	if err != nil {
+		return nil, status.Error(codes.Unauthenticated, err.Error())
+	}
+
+	w, err := s.app.EnableWebhook(ctx, req.WebhookId, userID)
+	if err != nil {
+		return nil, MapError(err)
+	}
+	return &webhookv1.EnableWebhookResponse{Webhook: toProtoWebhook(w)}, nil
+}
+
+func (s *Server) ListDeadLetters(
+	ctx context.Context,
+	req *webhookv1.ListDeadLettersRequest,
+) (*webhookv1.ListDeadLettersResponse, error) {
+
+	userID, err := auth.GetUserID(ctx)
+	if err != nil {
+		return nil, status.Error(codes.Unauthenticated, err.Error())
+	}
+
+	dls, next, err := s.app.ListDeadLetters(ctx, application.ListDeadLettersCommand{
+		WebhookID: req.WebhookId,
+		UserID:    userID,
+		PageSize:  int(req.PageSize),
+		PageToken: req.PageToken,
+	})
+	if err != nil {
+		return nil, MapError(err)
+	}
+
+	resp := &webhookv1.ListDeadLettersResponse{
+		DeadLetters:   make([]*webhookv1.DeadLetter, 0, len(dls)),
+		NextPageToken: next,
+	}
+	for _, dl := range dls {
+		resp.DeadLetters = append(resp.DeadLetters, &webhookv1.DeadLetter{
+			DeliveryId: dl.ID,
+			WebhookId:  dl.WebhookID,
+			EventId:    dl.EventID,
+			EventType:  dl.EventType,
+			Payload:    string(dl.Payload),
+			Attempts:   int32(dl.Attempts),
+			LastError:  dl.LastError,
+			CreatedAt:  timestamppb.New(dl.CreatedAt),
+			FailedAt:   timestamppb.New(dl.FailedAt),
+		})
+	}
+	return resp, nil
+}
+
+// toProtoWebhook leaves the secret out; only CreateWebhook returns it.
+func toProtoWebhook(w *domain.Webhook) *webhookv1.Webhook {
+	pw := &webhookv1.Webhook{
+		WebhookId:           w.ID,
+		ConversationId:      w.ConversationID,
+		Url:                 w.URL,
+		CreatedBy:           w.CreatedBy,
+		CreatedAt:           timestamppb.New(w.CreatedAt),
+		DisabledReason:      w.DisabledReason,
+		ConsecutiveFailures: int32(w.ConsecutiveFailures),
+	}
+	if w.DisabledAt != nil {
+		pw.DisabledAt = timestamppb.New(*w.DisabledAt)
+	}
+	return pw
+}
@@ -0,0 +1,47 @@
+CREATE TABLE webhooks (
+    id                   TEXT PRIMARY KEY,
+    conversation_id      TEXT NOT NULL,
+    url                  TEXT NOT NULL,
+    secret               TEXT NOT NULL,
+    created_by           TEXT NOT NULL,
+    created_at           TIMESTAMPTZ NOT NULL DEFAULT now(),
+    disabled_at          TIMESTAMPTZ,
+    disabled_reason      TEXT NOT NULL DEFAULT '',
+    -- Deliveries dead-lettered in a row since the last success
+    consecutive_failures INT NOT NULL DEFAULT 0
+);
+
+CREATE INDEX idx_webhooks_conversation ON webhooks (conversation_id);
+
+-- Events waiting to be delivered, one row per webhook
+CREATE TABLE webhook_deliveries (
+    id              TEXT PRIMARY KEY,
+    webhook_id      TEXT NOT NULL REFERENCES webhooks (id) ON DELETE CASCADE,
+    event_id        TEXT NOT NULL,
+    event_type      TEXT NOT NULL,
+    payload         BYTEA NOT NULL,
+    attempts        INT NOT NULL DEFAULT 0,
+    next_attempt_at TIMESTAMPTZ NOT NULL,
+    last_error      TEXT NOT NULL DEFAULT '',
+    created_at      TIMESTAMPTZ NOT NULL DEFAULT now(),
+
+    UNIQUE (webhook_id, event_id)
+);
+
+CREATE INDEX idx_webhook_deliveries_due ON webhook_deliveries (next_attempt_at);
+
+-- Deliveries that ran out of attempts, or were pending when their webhook
+-- was disabled
+CREATE TABLE webhook_dead_letters (
+    id         TEXT PRIMARY KEY,
+    webhook_id TEXT NOT NULL REFERENCES webhooks (id) ON DELETE CASCADE,
+    event_id   TEXT NOT NULL,
+    event_type TEXT NOT NULL,
+    payload    BYTEA NOT NULL,
+    attempts   INT NOT NULL,
+    last_error TEXT NOT NULL,
+    created_at TIMESTAMPTZ NOT NULL,
+    failed_at  TIMESTAMPTZ NOT NULL DEFAULT now()
+);
+
+CREATE INDEX idx_webhook_dead_letters_webhook ON webhook_dead_letters (webhook_id, failed_at DESC, id DESC);